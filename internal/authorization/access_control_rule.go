@@ -27,6 +27,14 @@ func NewAccessControlRule(pos int, rule schema.AccessControlRule, networksMap ma
 		Networks: schemaNetworksToACL(rule.Networks, networksMap, networksCacheMap),
 		Subjects: schemaSubjectsToACL(rule.Subjects),
 		Policy:   NewLevel(rule.Policy),
+		Requirements: Requirements{
+			AMR: rule.AMR,
+			MaxAuthenticationAge: MaxAuthenticationAge{
+				OneFactor: rule.MaxAuthenticationAge.OneFactor,
+				TwoFactor: rule.MaxAuthenticationAge.TwoFactor,
+			},
+			DisableTrustedDevice: rule.DisableTrustedDevice,
+		},
 	}
 
 	if len(r.Subjects) != 0 {
@@ -52,6 +60,8 @@ type AccessControlRule struct {
 	Networks  []*net.IPNet
 	Subjects  []AccessControlSubjects
 	Policy    Level
+
+	Requirements Requirements
 }
 
 // IsMatch returns true if all elements of an AccessControlRule match the object and subject.
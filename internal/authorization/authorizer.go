@@ -1,6 +1,8 @@
 package authorization
 
 import (
+	"sync"
+
 	"github.com/sirupsen/logrus"
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
@@ -13,42 +15,48 @@ type Authorizer struct {
 	rules         []*AccessControlRule
 	mfa           bool
 	log           *logrus.Logger
+
+	mu sync.RWMutex
 }
 
 // NewAuthorizer create an instance of authorizer with a given access control config.
 func NewAuthorizer(config *schema.Configuration) (authorizer *Authorizer) {
 	authorizer = &Authorizer{
-		defaultPolicy: NewLevel(config.AccessControl.DefaultPolicy),
-		rules:         NewAccessControlRules(config.AccessControl),
-		log:           logging.Logger(),
+		log: logging.Logger(),
 	}
 
-	if authorizer.defaultPolicy == TwoFactor {
-		authorizer.mfa = true
+	authorizer.defaultPolicy, authorizer.rules, authorizer.mfa = newAuthorizerPolicies(config)
 
-		return authorizer
-	}
+	return authorizer
+}
 
-	for _, rule := range authorizer.rules {
-		if rule.Policy == TwoFactor {
-			authorizer.mfa = true
+// Reload replaces the default policy and rules of the Authorizer with the ones from the given access control config.
+func (p *Authorizer) Reload(config *schema.Configuration) {
+	defaultPolicy, rules, mfa := newAuthorizerPolicies(config)
 
-			return authorizer
-		}
-	}
+	p.mu.Lock()
 
-	authorizer.mfa = isOpenIDConnectMFA(config)
+	defer p.mu.Unlock()
 
-	return authorizer
+	p.defaultPolicy, p.rules, p.mfa = defaultPolicy, rules, mfa
 }
 
 // IsSecondFactorEnabled return true if at least one policy is set to second factor.
 func (p *Authorizer) IsSecondFactorEnabled() bool {
+	p.mu.RLock()
+
+	defer p.mu.RUnlock()
+
 	return p.mfa
 }
 
-// GetRequiredLevel retrieve the required level of authorization to access the object.
-func (p *Authorizer) GetRequiredLevel(subject Subject, object Object) (hasSubjects bool, level Level) {
+// GetRequiredLevel retrieve the required level of authorization to access the object, and the additional Requirements
+// of the matched rule.
+func (p *Authorizer) GetRequiredLevel(subject Subject, object Object) (hasSubjects bool, level Level, requirements Requirements) {
+	p.mu.RLock()
+
+	defer p.mu.RUnlock()
+
 	p.log.Debugf("Check authorization of subject %s and object %s (method %s).",
 		subject.String(), object.String(), object.Method)
 
@@ -56,7 +64,7 @@ func (p *Authorizer) GetRequiredLevel(subject Subject, object Object) (hasSubjec
 		if rule.IsMatch(subject, object) {
 			p.log.Tracef(traceFmtACLHitMiss, "HIT", rule.Position, subject, object, object.Method, rule.Policy)
 
-			return rule.HasSubjects, rule.Policy
+			return rule.HasSubjects, rule.Policy, rule.Requirements
 		}
 
 		p.log.Tracef(traceFmtACLHitMiss, "MISS", rule.Position, subject, object, object.Method, rule.Policy)
@@ -64,11 +72,15 @@ func (p *Authorizer) GetRequiredLevel(subject Subject, object Object) (hasSubjec
 
 	p.log.Debugf("No matching rule for subject %s and url %s (method %s) applying default policy", subject, object, object.Method)
 
-	return false, p.defaultPolicy
+	return false, p.defaultPolicy, Requirements{}
 }
 
 // GetRuleMatchResults iterates through the rules and produces a list of RuleMatchResult provided a subject and object.
 func (p *Authorizer) GetRuleMatchResults(subject Subject, object Object) (results []RuleMatchResult) {
+	p.mu.RLock()
+
+	defer p.mu.RUnlock()
+
 	skipped := false
 
 	results = make([]RuleMatchResult, len(p.rules))
@@ -92,3 +104,19 @@ func (p *Authorizer) GetRuleMatchResults(subject Subject, object Object) (result
 
 	return results
 }
+
+func newAuthorizerPolicies(config *schema.Configuration) (defaultPolicy Level, rules []*AccessControlRule, mfa bool) {
+	defaultPolicy, rules = NewLevel(config.AccessControl.DefaultPolicy), NewAccessControlRules(config.AccessControl)
+
+	if defaultPolicy == TwoFactor {
+		return defaultPolicy, rules, true
+	}
+
+	for _, rule := range rules {
+		if rule.Policy == TwoFactor {
+			return defaultPolicy, rules, true
+		}
+	}
+
+	return defaultPolicy, rules, isOpenIDConnectMFA(config)
+}
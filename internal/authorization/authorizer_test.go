@@ -5,6 +5,7 @@ import (
 	"net/url"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -37,7 +38,7 @@ func (s *AuthorizerTester) CheckAuthorizations(t *testing.T, subject Subject, re
 
 	object := NewObject(targetURL, method)
 
-	_, level := s.GetRequiredLevel(subject, object)
+	_, level, _ := s.GetRequiredLevel(subject, object)
 
 	assert.Equal(t, expectedLevel, level)
 }
@@ -214,6 +215,95 @@ func (s *AuthorizerSuite) TestShouldCheckFactorsPolicy() {
 	tester.CheckAuthorizations(s.T(), UserWithGroups, "https://example.com/", fasthttp.MethodGet, Denied)
 }
 
+func (s *AuthorizerSuite) TestShouldCheckRuleAMR() {
+	tester := NewAuthorizerBuilder().
+		WithDefaultPolicy(twoFactor).
+		WithRule(schema.AccessControlRule{
+			Domains:  []string{"admin.example.com"},
+			Subjects: [][]string{{"group:admins"}},
+			Policy:   twoFactor,
+			AMR:      []string{"hwk"},
+		}).
+		WithRule(schema.AccessControlRule{
+			Domains: []string{"admin.example.com"},
+			Policy:  twoFactor,
+		}).
+		Build()
+
+	targetURL, _ := url.ParseRequestURI("https://admin.example.com/")
+
+	_, level, requirements := tester.GetRequiredLevel(UserWithGroups, NewObject(targetURL, fasthttp.MethodGet))
+
+	s.Equal(TwoFactor, level)
+	s.Equal([]string{"hwk"}, requirements.AMR)
+
+	_, level, requirements = tester.GetRequiredLevel(UserWithoutGroups, NewObject(targetURL, fasthttp.MethodGet))
+
+	s.Equal(TwoFactor, level)
+	s.Nil(requirements.AMR)
+
+	targetURL, _ = url.ParseRequestURI("https://example.com/")
+
+	_, level, requirements = tester.GetRequiredLevel(UserWithGroups, NewObject(targetURL, fasthttp.MethodGet))
+
+	s.Equal(TwoFactor, level)
+	s.Nil(requirements.AMR)
+}
+
+func (s *AuthorizerSuite) TestShouldCheckRuleMaxAuthenticationAge() {
+	tester := NewAuthorizerBuilder().
+		WithDefaultPolicy(twoFactor).
+		WithRule(schema.AccessControlRule{
+			Domains:   []string{"admin.example.com"},
+			Resources: []regexp.Regexp{*regexp.MustCompile("^/admin.*$")},
+			Policy:    twoFactor,
+			MaxAuthenticationAge: schema.AccessControlRuleMaxAuthenticationAge{
+				OneFactor: time.Hour,
+				TwoFactor: time.Minute * 15,
+			},
+		}).
+		Build()
+
+	targetURL, _ := url.ParseRequestURI("https://admin.example.com/admin/users")
+
+	_, level, requirements := tester.GetRequiredLevel(UserWithGroups, NewObject(targetURL, fasthttp.MethodGet))
+
+	s.Equal(TwoFactor, level)
+	s.Equal(MaxAuthenticationAge{OneFactor: time.Hour, TwoFactor: time.Minute * 15}, requirements.MaxAuthenticationAge)
+
+	targetURL, _ = url.ParseRequestURI("https://admin.example.com/")
+
+	_, level, requirements = tester.GetRequiredLevel(UserWithGroups, NewObject(targetURL, fasthttp.MethodGet))
+
+	s.Equal(TwoFactor, level)
+	s.Equal(MaxAuthenticationAge{}, requirements.MaxAuthenticationAge)
+}
+
+func (s *AuthorizerSuite) TestShouldCheckRuleDisableTrustedDevice() {
+	tester := NewAuthorizerBuilder().
+		WithDefaultPolicy(twoFactor).
+		WithRule(schema.AccessControlRule{
+			Domains:              []string{"admin.example.com"},
+			Policy:               twoFactor,
+			DisableTrustedDevice: true,
+		}).
+		Build()
+
+	targetURL, _ := url.ParseRequestURI("https://admin.example.com/")
+
+	_, level, requirements := tester.GetRequiredLevel(UserWithGroups, NewObject(targetURL, fasthttp.MethodGet))
+
+	s.Equal(TwoFactor, level)
+	s.True(requirements.DisableTrustedDevice)
+
+	targetURL, _ = url.ParseRequestURI("https://public.example.com/")
+
+	_, level, requirements = tester.GetRequiredLevel(UserWithGroups, NewObject(targetURL, fasthttp.MethodGet))
+
+	s.Equal(TwoFactor, level)
+	s.False(requirements.DisableTrustedDevice)
+}
+
 func (s *AuthorizerSuite) TestShouldCheckQueryPolicy() {
 	tester := NewAuthorizerBuilder().
 		WithDefaultPolicy(deny).
@@ -1031,6 +1121,38 @@ func TestNewAuthorizer(t *testing.T) {
 	assert.Equal(t, "admins", group.Name)
 }
 
+func TestAuthorizerReload(t *testing.T) {
+	config := &schema.Configuration{
+		AccessControl: schema.AccessControl{
+			DefaultPolicy: oneFactor,
+		},
+	}
+
+	authorizer := NewAuthorizer(config)
+
+	assert.False(t, authorizer.IsSecondFactorEnabled())
+
+	subject := Subject{Username: "john", Groups: []string{"admins"}, IP: net.ParseIP("127.0.0.1")}
+	object := NewObject(&url.URL{Scheme: "https", Host: "example.com", Path: "/"}, fasthttp.MethodGet)
+
+	_, level, _ := authorizer.GetRequiredLevel(subject, object)
+	assert.Equal(t, OneFactor, level)
+
+	config.AccessControl.Rules = []schema.AccessControlRule{
+		{
+			Domains: []string{"example.com"},
+			Policy:  twoFactor,
+		},
+	}
+
+	authorizer.Reload(config)
+
+	assert.True(t, authorizer.IsSecondFactorEnabled())
+
+	_, level, _ = authorizer.GetRequiredLevel(subject, object)
+	assert.Equal(t, TwoFactor, level)
+}
+
 func TestAuthorizerIsSecondFactorEnabledRuleWithNoOIDC(t *testing.T) {
 	config := &schema.Configuration{
 		AccessControl: schema.AccessControl{
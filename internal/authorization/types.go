@@ -5,6 +5,7 @@ import (
 	"net"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/authelia/authelia/v4/internal/utils"
 )
@@ -76,6 +77,37 @@ func NewObject(targetURL *url.URL, method string) (object Object) {
 	}
 }
 
+// Requirements describes the additional requirements of a rule which must be satisfied beyond the Level.
+type Requirements struct {
+	// AMR is the list of Authentication Methods References of which at least one must have been used.
+	AMR []string
+
+	// MaxAuthenticationAge is the maximum age of each authentication factor.
+	MaxAuthenticationAge MaxAuthenticationAge
+
+	// DisableTrustedDevice prevents a trusted device from satisfying the TwoFactor Level.
+	DisableTrustedDevice bool
+}
+
+// MaxAuthenticationAge describes the maximum age of each authentication factor, a zero value means there is no maximum.
+type MaxAuthenticationAge struct {
+	OneFactor time.Duration
+	TwoFactor time.Duration
+}
+
+// GetReauthenticationLevel returns the Level the user must re-authenticate at given the time each factor was last
+// performed, or Bypass if the user doesn't need to re-authenticate.
+func (a MaxAuthenticationAge) GetReauthenticationLevel(now, oneFactor, twoFactor time.Time) (level Level) {
+	switch {
+	case a.OneFactor > 0 && now.Sub(oneFactor) > a.OneFactor:
+		return OneFactor
+	case a.TwoFactor > 0 && now.Sub(twoFactor) > a.TwoFactor:
+		return TwoFactor
+	default:
+		return Bypass
+	}
+}
+
 // RuleMatchResult describes how well a rule matched a subject/object combo.
 type RuleMatchResult struct {
 	Rule *AccessControlRule
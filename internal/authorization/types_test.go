@@ -3,6 +3,7 @@ package authorization
 import (
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -115,3 +116,57 @@ func TestRuleMatchResult_IsPotentialMatch(t *testing.T) {
 		})
 	}
 }
+
+func TestMaxAuthenticationAge_GetReauthenticationLevel(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	testCases := []struct {
+		name      string
+		have      MaxAuthenticationAge
+		oneFactor time.Time
+		twoFactor time.Time
+		expected  Level
+	}{
+		{
+			"ShouldNotRequireReauthenticationWhenUnconfigured",
+			MaxAuthenticationAge{},
+			now.Add(-time.Hour * 24),
+			now.Add(-time.Hour * 24),
+			Bypass,
+		},
+		{
+			"ShouldNotRequireReauthenticationWhenWithinAge",
+			MaxAuthenticationAge{OneFactor: time.Hour, TwoFactor: time.Minute * 15},
+			now.Add(-time.Minute * 30),
+			now.Add(-time.Minute * 10),
+			Bypass,
+		},
+		{
+			"ShouldRequireOneFactorReauthentication",
+			MaxAuthenticationAge{OneFactor: time.Hour, TwoFactor: time.Minute * 15},
+			now.Add(-time.Hour * 2),
+			now.Add(-time.Hour * 2),
+			OneFactor,
+		},
+		{
+			"ShouldRequireTwoFactorReauthentication",
+			MaxAuthenticationAge{OneFactor: time.Hour, TwoFactor: time.Minute * 15},
+			now.Add(-time.Minute * 30),
+			now.Add(-time.Minute * 20),
+			TwoFactor,
+		},
+		{
+			"ShouldRequireTwoFactorReauthenticationOnly",
+			MaxAuthenticationAge{TwoFactor: time.Minute * 15},
+			now.Add(-time.Hour * 2),
+			now.Add(-time.Minute * 20),
+			TwoFactor,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.have.GetReauthenticationLevel(now, tc.oneFactor, tc.twoFactor))
+		})
+	}
+}
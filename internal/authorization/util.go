@@ -7,6 +7,7 @@ import (
 
 	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/utils"
 )
 
 // NewLevel converts a string policy to int authorization level.
@@ -233,6 +234,16 @@ func IsAuthLevelSufficient(authenticationLevel authentication.Level, authorizati
 	return true
 }
 
+// IsAuthMethodsSufficient returns true if the required Authentication Methods References are empty or at least one of
+// them is present in the Authentication Methods References the user has performed.
+func IsAuthMethodsSufficient(amr, required []string) bool {
+	if len(required) == 0 {
+		return true
+	}
+
+	return utils.IsStringSliceContainsAny(required, amr)
+}
+
 func isOpenIDConnectMFA(config *schema.Configuration) (mfa bool) {
 	if config == nil || config.IdentityProviders.OIDC == nil {
 		return false
@@ -219,6 +219,15 @@ func TestIsAuthLevelSufficient(t *testing.T) {
 	assert.True(t, IsAuthLevelSufficient(authentication.TwoFactor, TwoFactor))
 }
 
+func TestIsAuthMethodsSufficient(t *testing.T) {
+	assert.True(t, IsAuthMethodsSufficient(nil, nil))
+	assert.True(t, IsAuthMethodsSufficient([]string{"pwd", "otp", "mfa"}, nil))
+	assert.False(t, IsAuthMethodsSufficient(nil, []string{"hwk"}))
+	assert.False(t, IsAuthMethodsSufficient([]string{"pwd", "otp", "mfa"}, []string{"hwk"}))
+	assert.True(t, IsAuthMethodsSufficient([]string{"pwd", "hwk", "mfa"}, []string{"hwk"}))
+	assert.True(t, IsAuthMethodsSufficient([]string{"pwd", "otp", "mfa"}, []string{"hwk", "otp"}))
+}
+
 func TestStringSliceToRegexpSlice(t *testing.T) {
 	testCases := []struct {
 		name     string
@@ -2,12 +2,23 @@ package commands
 
 import (
 	"bytes"
+	"crypto/tls"
+	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 
+	"github.com/authelia/jsonschema"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 
+	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/configuration"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/configuration/validator"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/utils"
 )
 
 func newConfigCmd(ctx *CmdCtx) (cmd *cobra.Command) {
@@ -27,7 +38,7 @@ func newConfigCmd(ctx *CmdCtx) (cmd *cobra.Command) {
 		DisableAutoGenTag: true,
 	}
 
-	cmd.AddCommand(newConfigValidateCmd(ctx), newConfigTemplateCmd(ctx))
+	cmd.AddCommand(newConfigValidateCmd(ctx), newConfigTemplateCmd(ctx), newConfigMigrateCmd(ctx), newConfigDiffCmd(ctx), newConfigDumpCmd(ctx), newConfigSchemaCmd(ctx))
 
 	return cmd
 }
@@ -52,6 +63,104 @@ func newConfigTemplateCmd(ctx *CmdCtx) (cmd *cobra.Command) {
 	return cmd
 }
 
+func newConfigMigrateCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "migrate",
+		Short:   cmdAutheliaConfigMigrateShort,
+		Long:    cmdAutheliaConfigMigrateLong,
+		Example: cmdAutheliaConfigMigrateExample,
+		Args:    cobra.NoArgs,
+		RunE:    ctx.ConfigMigrateRunE,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.Flags().Bool(cmdFlagNameDryRun, false, "print the migrated configuration files instead of writing them")
+
+	return cmd
+}
+
+func newConfigDiffCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "diff",
+		Short:   cmdAutheliaConfigDiffShort,
+		Long:    cmdAutheliaConfigDiffLong,
+		Example: cmdAutheliaConfigDiffExample,
+		Args:    cobra.NoArgs,
+		PreRunE: ctx.ChainRunE(
+			ctx.HelperConfigLoadRunE,
+			ctx.HelperConfigValidateKeysRunE,
+			ctx.HelperConfigValidateRunE,
+		),
+		RunE: ctx.ConfigDiffRunE,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.Flags().String(cmdFlagNameFile, "", "the configuration file to compare the effective configuration against instead of the defaults")
+
+	return cmd
+}
+
+func newConfigDumpCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "dump",
+		Short:   cmdAutheliaConfigDumpShort,
+		Long:    cmdAutheliaConfigDumpLong,
+		Example: cmdAutheliaConfigDumpExample,
+		Args:    cobra.NoArgs,
+		PreRunE: ctx.ChainRunE(
+			ctx.HelperConfigLoadRunE,
+			ctx.HelperConfigValidateKeysRunE,
+			ctx.HelperConfigValidateRunE,
+		),
+		RunE: ctx.ConfigDumpRunE,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.Flags().String(cmdFlagNameFormat, configDumpFormatYAML, "the output format, options are 'yaml' and 'json'")
+	cmd.Flags().Bool(cmdFlagNameProvenance, false, "annotate each key with the source which supplied its value")
+
+	return cmd
+}
+
+func newConfigSchemaCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "schema",
+		Short:   cmdAutheliaConfigSchemaShort,
+		Long:    cmdAutheliaConfigSchemaLong,
+		Example: cmdAutheliaConfigSchemaExample,
+		Args:    cobra.NoArgs,
+		RunE:    ctx.ConfigSchemaRunE,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.PersistentFlags().String(cmdFlagNameType, configSchemaTypeConfiguration, "the schema type, options are 'configuration' and 'user-database'")
+
+	cmd.AddCommand(newConfigSchemaValidateCmd(ctx))
+
+	return cmd
+}
+
+func newConfigSchemaValidateCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "validate [paths]",
+		Short:   cmdAutheliaConfigSchemaValidateShort,
+		Long:    cmdAutheliaConfigSchemaValidateLong,
+		Example: cmdAutheliaConfigSchemaValidateExample,
+		Args:    cobra.ArbitraryArgs,
+		RunE:    ctx.ConfigSchemaValidateRunE,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.Flags().String(cmdFlagNameFormat, configSchemaFormatText, "the output format, options are 'text' and 'json'")
+
+	return cmd
+}
+
 func newConfigValidateCmd(ctx *CmdCtx) (cmd *cobra.Command) {
 	cmd = &cobra.Command{
 		Use:     "validate",
@@ -152,6 +261,285 @@ func (ctx *CmdCtx) ConfigTemplateRunE(_ *cobra.Command, _ []string) (err error)
 	return nil
 }
 
+// ConfigMigrateRunE is the RunE for the authelia config migrate command.
+func (ctx *CmdCtx) ConfigMigrateRunE(cmd *cobra.Command, _ []string) (err error) {
+	var (
+		configs []string
+		dryRun  bool
+	)
+
+	if configs, _, err = loadXEnvCLIConfigValues(cmd); err != nil {
+		return err
+	}
+
+	if dryRun, err = cmd.Flags().GetBool(cmdFlagNameDryRun); err != nil {
+		return err
+	}
+
+	var files []*configuration.File
+
+	for _, config := range configs {
+		if configuration.IsRemoteSourcePath(config) {
+			fmt.Printf("Configuration source '%s' is a remote source and has been skipped\n\n", config)
+
+			continue
+		}
+
+		if files, err = configuration.NewFileSource(config).ReadFiles(); err != nil {
+			return fmt.Errorf("error occurred reading the configuration files at path '%s': %w", config, err)
+		}
+
+		for _, file := range files {
+			if err = configMigrateFile(file, dryRun); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func configMigrateFile(file *configuration.File, dryRun bool) (err error) {
+	var (
+		data       []byte
+		migrations []configuration.Migration
+	)
+
+	if data, migrations, err = configuration.MigrateYAML(file.Data); err != nil {
+		return fmt.Errorf("error occurred migrating the configuration file '%s': %w", file.Path, err)
+	}
+
+	if len(migrations) == 0 {
+		fmt.Printf("Configuration file '%s' does not contain any deprecated keys\n\n", file.Path)
+
+		return nil
+	}
+
+	fmt.Printf("Configuration file '%s' contains deprecated keys:\n\n", file.Path)
+
+	for _, migration := range migrations {
+		fmt.Printf("\t - %s\n", migration)
+	}
+
+	fmt.Println("")
+
+	if bytes.Equal(data, file.Data) {
+		return nil
+	}
+
+	if dryRun {
+		fmt.Printf("Configuration file '%s' would be migrated to:\n\n", file.Path)
+		fmt.Println(string(data))
+
+		return nil
+	}
+
+	var backup string
+
+	if backup, err = configMigrateWriteFile(file.Path, file.Data, data); err != nil {
+		return fmt.Errorf("error occurred writing the configuration file '%s': %w", file.Path, err)
+	}
+
+	fmt.Printf("Configuration file '%s' has been migrated and the original has been saved to '%s'\n\n", file.Path, backup)
+
+	return nil
+}
+
+// configMigrateWriteFile saves the original configuration file next to itself with the backup extension, then writes the
+// migrated configuration file to a temporary file in the same directory and renames it over the original so the
+// original is never left partially written.
+func configMigrateWriteFile(path string, original, migrated []byte) (backup string, err error) {
+	if path, err = filepath.EvalSymlinks(path); err != nil {
+		return "", err
+	}
+
+	var info os.FileInfo
+
+	if info, err = os.Stat(path); err != nil {
+		return "", err
+	}
+
+	mode := info.Mode().Perm()
+
+	backup = path + configMigrateExtBackup
+
+	if err = os.WriteFile(backup, original, mode); err != nil {
+		return "", fmt.Errorf("error occurred saving the backup file '%s': %w", backup, err)
+	}
+
+	var f *os.File
+
+	if f, err = os.CreateTemp(filepath.Dir(path), fmt.Sprintf(".%s.*.tmp", filepath.Base(path))); err != nil {
+		return "", err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = f.Close()
+			_ = os.Remove(f.Name())
+		}
+	}()
+
+	if _, err = f.Write(migrated); err != nil {
+		return "", err
+	}
+
+	if err = f.Chmod(mode); err != nil {
+		return "", err
+	}
+
+	if err = f.Sync(); err != nil {
+		return "", err
+	}
+
+	if err = f.Close(); err != nil {
+		return "", err
+	}
+
+	if err = os.Rename(f.Name(), path); err != nil {
+		return "", err
+	}
+
+	return backup, nil
+}
+
+// ConfigDiffRunE is the RunE for the authelia config diff command.
+func (ctx *CmdCtx) ConfigDiffRunE(cmd *cobra.Command, _ []string) (err error) {
+	if errs := ctx.cconfig.validator.Errors(); len(errs) != 0 {
+		return fmt.Errorf("the configuration has %d errors: run 'authelia config validate' for more information", len(errs))
+	}
+
+	var path string
+
+	if path, err = cmd.Flags().GetString(cmdFlagNameFile); err != nil {
+		return err
+	}
+
+	baseline := &schema.Configuration{}
+
+	val := schema.NewStructValidator()
+
+	if path != "" {
+		var filters []configuration.BytesFilter
+
+		if _, filters, err = loadXEnvCLIConfigValues(cmd); err != nil {
+			return err
+		}
+
+		if _, err = os.Stat(path); err != nil {
+			return fmt.Errorf("error occurred loading the configuration file '%s': %w", path, err)
+		}
+
+		if _, err = configuration.LoadAdvanced(val, "", baseline, configuration.NewFilteredFileSource(path, filters...)); err != nil {
+			return fmt.Errorf("error occurred loading the configuration file '%s': %w", path, err)
+		}
+	}
+
+	validator.ValidateConfiguration(baseline, val, validator.WithTLSConfig(&tls.Config{
+		RootCAs:    ctx.trusted,
+		MinVersion: tls.VersionTLS12,
+		MaxVersion: tls.VersionTLS13,
+	}))
+
+	name := "the default configuration"
+
+	if path != "" {
+		name = fmt.Sprintf("the configuration file '%s'", path)
+	}
+
+	changes := configuration.Diff(baseline, ctx.config)
+
+	if len(changes) == 0 {
+		fmt.Printf("The effective configuration does not differ from %s.\n\n", name)
+
+		return nil
+	}
+
+	fmt.Printf("The effective configuration differs from %s:\n\n", name)
+
+	for _, change := range changes {
+		if change.Before == "" && change.After == "" {
+			fmt.Printf("\t - %s\n", change.Key)
+
+			continue
+		}
+
+		fmt.Printf("\t - %s: '%s' -> '%s'\n", change.Key, change.Before, change.After)
+	}
+
+	fmt.Println("")
+
+	return nil
+}
+
+// ConfigDumpRunE is the RunE for the authelia config dump command.
+func (ctx *CmdCtx) ConfigDumpRunE(cmd *cobra.Command, _ []string) (err error) {
+	var (
+		format     string
+		provenance bool
+	)
+
+	if format, err = cmd.Flags().GetString(cmdFlagNameFormat); err != nil {
+		return err
+	}
+
+	if provenance, err = cmd.Flags().GetBool(cmdFlagNameProvenance); err != nil {
+		return err
+	}
+
+	var p configuration.Provenance
+
+	if provenance {
+		if p = ctx.cconfig.provenance; p == nil {
+			p = configuration.Provenance{}
+		}
+	}
+
+	node, origins := configuration.Dump(ctx.config, p)
+
+	switch format {
+	case configDumpFormatYAML:
+		buf := &bytes.Buffer{}
+
+		encoder := yaml.NewEncoder(buf)
+
+		encoder.SetIndent(2)
+
+		if err = encoder.Encode(node); err != nil {
+			return fmt.Errorf("error occurred encoding the configuration: %w", err)
+		}
+
+		if err = encoder.Close(); err != nil {
+			return fmt.Errorf("error occurred encoding the configuration: %w", err)
+		}
+
+		fmt.Print(buf.String())
+	case configDumpFormatJSON:
+		var (
+			value any
+			data  []byte
+		)
+
+		if err = node.Decode(&value); err != nil {
+			return fmt.Errorf("error occurred encoding the configuration: %w", err)
+		}
+
+		if provenance {
+			value = map[string]any{"configuration": value, "provenance": origins}
+		}
+
+		if data, err = json.MarshalIndent(value, "", "  "); err != nil {
+			return fmt.Errorf("error occurred encoding the configuration: %w", err)
+		}
+
+		fmt.Println(string(data))
+	default:
+		return fmt.Errorf("flag --%s with value '%s' is invalid: must be one of '%s' or '%s'", cmdFlagNameFormat, format, configDumpFormatYAML, configDumpFormatJSON)
+	}
+
+	return nil
+}
+
 func newConfigValidateLegacyCmd(ctx *CmdCtx) (cmd *cobra.Command) {
 	cmd = newConfigValidateCmd(ctx)
 
@@ -160,3 +548,146 @@ func newConfigValidateLegacyCmd(ctx *CmdCtx) (cmd *cobra.Command) {
 
 	return cmd
 }
+
+// ConfigSchemaRunE is the RunE for the authelia config schema command.
+func (ctx *CmdCtx) ConfigSchemaRunE(cmd *cobra.Command, _ []string) (err error) {
+	var (
+		s    *jsonschema.Schema
+		data []byte
+	)
+
+	if s, _, err = configSchemaLoad(cmd); err != nil {
+		return err
+	}
+
+	if data, err = json.MarshalIndent(s, "", "  "); err != nil {
+		return fmt.Errorf("error occurred encoding the schema: %w", err)
+	}
+
+	fmt.Println(string(data))
+
+	return nil
+}
+
+// ConfigSchemaValidateRunE is the RunE for the authelia config schema validate command.
+func (ctx *CmdCtx) ConfigSchemaValidateRunE(cmd *cobra.Command, args []string) (err error) {
+	var (
+		s            *jsonschema.Schema
+		name, format string
+		filters      []configuration.BytesFilter
+	)
+
+	if s, name, err = configSchemaLoad(cmd); err != nil {
+		return err
+	}
+
+	if format, err = cmd.Flags().GetString(cmdFlagNameFormat); err != nil {
+		return err
+	}
+
+	switch format {
+	case configSchemaFormatText, configSchemaFormatJSON:
+		break
+	default:
+		return fmt.Errorf("flag --%s with value '%s' is invalid: must be one of '%s' or '%s'", cmdFlagNameFormat, format, configSchemaFormatText, configSchemaFormatJSON)
+	}
+
+	paths := args
+
+	if name == configSchemaTypeConfiguration {
+		var configs []string
+
+		if configs, filters, err = loadXEnvCLIConfigValues(cmd); err != nil {
+			return err
+		}
+
+		if len(paths) == 0 {
+			paths = configs
+		}
+	}
+
+	if len(paths) == 0 {
+		return fmt.Errorf("validating files against the %s schema requires at least one path", name)
+	}
+
+	var (
+		files      []*configuration.File
+		violations = []configuration.JSONSchemaViolation{}
+	)
+
+	for _, path := range paths {
+		if configuration.IsRemoteSourcePath(path) {
+			fmt.Fprintf(os.Stderr, "Configuration source '%s' is a remote source and has been skipped\n", path)
+
+			continue
+		}
+
+		if files, err = configuration.NewFilteredFileSource(path, filters...).ReadFiles(); err != nil {
+			return fmt.Errorf("error occurred reading the files at path '%s': %w", path, err)
+		}
+
+		for _, file := range files {
+			violations = append(violations, configuration.ValidateJSONSchema(s, file.Path, file.Data)...)
+		}
+	}
+
+	n := 0
+
+	for _, violation := range violations {
+		if violation.Level == configuration.JSONSchemaViolationLevelError {
+			n++
+		}
+	}
+
+	switch format {
+	case configSchemaFormatJSON:
+		var data []byte
+
+		if data, err = json.MarshalIndent(map[string]any{"valid": n == 0, "violations": violations}, "", "  "); err != nil {
+			return fmt.Errorf("error occurred encoding the violations: %w", err)
+		}
+
+		fmt.Println(string(data))
+	default:
+		for _, violation := range violations {
+			fmt.Println(violation)
+		}
+
+		if len(violations) == 0 {
+			fmt.Printf("Files are valid according to the %s schema\n", name)
+		}
+	}
+
+	if n != 0 {
+		return fmt.Errorf("files are invalid according to the %s schema: %d errors were reported", name, n)
+	}
+
+	return nil
+}
+
+func configSchemaLoad(cmd *cobra.Command) (s *jsonschema.Schema, name string, err error) {
+	if name, err = cmd.Flags().GetString(cmdFlagNameType); err != nil {
+		return nil, "", err
+	}
+
+	switch name {
+	case configSchemaTypeConfiguration:
+		s = configuration.NewJSONSchema(&schema.Configuration{})
+	case configSchemaTypeUserDatabase:
+		s = configuration.NewJSONSchema(&authentication.FileUserDatabase{})
+	default:
+		return nil, "", fmt.Errorf("flag --%s with value '%s' is invalid: must be one of '%s' or '%s'", cmdFlagNameType, name, configSchemaTypeConfiguration, configSchemaTypeUserDatabase)
+	}
+
+	var v *model.SemanticVersion
+
+	version := configSchemaVersionLatest
+
+	if v, err = model.NewSemanticVersion(utils.BuildTag); err == nil {
+		version = fmt.Sprintf("v%d.%d", v.Major, v.Minor)
+	}
+
+	s.ID = jsonschema.ID(fmt.Sprintf(model.FormatJSONSchemaIdentifier, version, name))
+
+	return s, name, nil
+}
@@ -0,0 +1,79 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration"
+)
+
+func TestConfigMigrateFile(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     string
+		dryRun   bool
+		expected string
+		backup   bool
+	}{
+		{
+			"ShouldMigrateFileAndSaveBackup",
+			"log_level: 'debug'\n\ntheme: 'dark'\n",
+			false,
+			"theme: 'dark'\nlog:\n  level: 'debug'\n",
+			true,
+		},
+		{
+			"ShouldNotWriteFileOnDryRun",
+			"log_level: 'debug'\n\ntheme: 'dark'\n",
+			true,
+			"log_level: 'debug'\n\ntheme: 'dark'\n",
+			false,
+		},
+		{
+			"ShouldNotWriteFileWithoutDeprecatedKeys",
+			"theme: 'dark'\n\nlog:\n  level: 'debug'\n",
+			false,
+			"theme: 'dark'\n\nlog:\n  level: 'debug'\n",
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "configuration.yml")
+
+			require.NoError(t, os.WriteFile(path, []byte(tc.have), 0600))
+
+			require.NoError(t, configMigrateFile(&configuration.File{Path: path, Data: []byte(tc.have)}, tc.dryRun))
+
+			actual, err := os.ReadFile(path)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, string(actual))
+
+			info, err := os.Stat(path)
+			require.NoError(t, err)
+
+			assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+			entries, err := os.ReadDir(dir)
+			require.NoError(t, err)
+
+			if tc.backup {
+				backup, err := os.ReadFile(path + configMigrateExtBackup)
+				require.NoError(t, err)
+
+				assert.Equal(t, tc.have, string(backup))
+				assert.Len(t, entries, 2)
+			} else {
+				assert.NoFileExists(t, path+configMigrateExtBackup)
+				assert.Len(t, entries, 1)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package commands
 import (
 	"errors"
 	"regexp"
+	"time"
 )
 
 const (
@@ -84,6 +85,79 @@ authelia access-control check-policy --config config.yml --url https://example.c
 authelia access-control check-policy --config config.yml --url https://example.com --username john --method GET
 authelia access-control check-policy --config config.yml --url https://example.com --username john --method GET --verbose`
 
+	cmdAutheliaRegulationShort = "Manage the Authelia regulation"
+
+	cmdAutheliaRegulationLong = `Manage the Authelia regulation.
+
+This subcommand allows management of the regulation state of users such as unlocking users which have been locked out
+after being banned too many times.
+`
+
+	cmdAutheliaRegulationExample = `authelia regulation --help`
+
+	cmdAutheliaRegulationUnlockShort = "Unlock a user who has been locked out by the regulation"
+
+	cmdAutheliaRegulationUnlockLong = `Unlock a user who has been locked out by the regulation.
+
+This subcommand removes the permanent lockout of a user. The failed authentication history which caused the lockout is
+disregarded for future regulation decisions.`
+
+	cmdAutheliaRegulationUnlockExample = `authelia regulation unlock john
+authelia regulation unlock john --config config.yml
+authelia regulation unlock john --sqlite.path config.sqlite3
+authelia regulation unlock john --postgres.host postgres --postgres.password autheliapw`
+
+	cmdAutheliaNotificationsShort = "Manage the Authelia notification queue"
+
+	cmdAutheliaNotificationsLong = `Manage the Authelia notification queue.
+
+This subcommand allows inspection and management of the durable notification queue which is used to deliver
+notifications in the background when the notifier queue is enabled.
+`
+
+	cmdAutheliaNotificationsExample = `authelia notifications --help`
+
+	cmdAutheliaNotificationsListShort = "List the notifications in the queue"
+
+	cmdAutheliaNotificationsListLong = `List the notifications in the queue.
+
+This subcommand lists the notifications in the queue with a specific status, by default the dead notifications which
+have exhausted all delivery attempts are listed.`
+
+	cmdAutheliaNotificationsListExample = `authelia notifications list
+authelia notifications list --status pending
+authelia notifications list --status sent --limit 20 --page 2
+authelia notifications list --config config.yml
+authelia notifications list --sqlite.path config.sqlite3
+authelia notifications list --postgres.host postgres --postgres.password autheliapw`
+
+	cmdAutheliaNotificationsRetryShort = "Retry the delivery of dead notifications"
+
+	cmdAutheliaNotificationsRetryLong = `Retry the delivery of dead notifications.
+
+This subcommand moves a dead notification, or all dead notifications, back to the pending state with the delivery
+attempts reset so they are delivered by the next running Authelia instance.`
+
+	cmdAutheliaNotificationsRetryExample = `authelia notifications retry 10
+authelia notifications retry --all
+authelia notifications retry --all --config config.yml
+authelia notifications retry --all --sqlite.path config.sqlite3
+authelia notifications retry --all --postgres.host postgres --postgres.password autheliapw`
+
+	cmdAutheliaNotificationsPurgeShort = "Purge the sent or dead notifications from the queue"
+
+	cmdAutheliaNotificationsPurgeLong = `Purge the sent or dead notifications from the queue.
+
+This subcommand deletes the notifications with a specific status which were created longer ago than a specific
+duration, by default the sent notifications older than 7 days are deleted.`
+
+	cmdAutheliaNotificationsPurgeExample = `authelia notifications purge
+authelia notifications purge --older-than 1d
+authelia notifications purge --status dead --older-than 30d
+authelia notifications purge --config config.yml
+authelia notifications purge --sqlite.path config.sqlite3
+authelia notifications purge --postgres.host postgres --postgres.password autheliapw`
+
 	cmdAutheliaStorageShort = "Manage the Authelia storage"
 
 	cmdAutheliaStorageLong = `Manage the Authelia storage.
@@ -122,6 +196,16 @@ This subcommand allows you to change the encryption key of an Authelia SQL datab
 	cmdAutheliaStorageEncryptionChangeKeyExample = `authelia storage encryption change-key --config config.yml --new-encryption-key 0e95cb49-5804-4ad9-be82-bb04a9ddecd8
 authelia storage encryption change-key --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --new-encryption-key 0e95cb49-5804-4ad9-be82-bb04a9ddecd8 --postgres.host postgres --postgres.password autheliapw`
 
+	cmdAutheliaStorageEncryptionRotateKEKShort = "Rotates the key-encryption key"
+
+	cmdAutheliaStorageEncryptionRotateKEKLong = `Rotates the key-encryption key.
+
+This subcommand wraps the data key with the current key-encryption key of the configured key management provider. The
+encrypted data is not modified. The previous key-encryption key must still be available to the key management
+provider when this command is run.`
+
+	cmdAutheliaStorageEncryptionRotateKEKExample = `authelia storage encryption rotate-kek --config config.yml`
+
 	cmdAutheliaStorageUserShort = "Manages user settings"
 
 	cmdAutheliaStorageUserLong = `Manages user settings.
@@ -197,11 +281,13 @@ This subcommand allows interacting with WebAuthn credentials.`
 
 	cmdAutheliaStorageUserWebAuthnImportLong = `Perform imports of the WebAuthn credentials.
 
-This subcommand allows importing WebAuthn credentials from the YAML format.`
+This subcommand allows importing WebAuthn credentials from the YAML format. The usernames of the credentials can be
+changed during the import using a CSV file which maps the username in the YAML file to the username in Authelia.`
 
 	cmdAutheliaStorageUserWebAuthnImportExample = `authelia storage user webauthn export
 authelia storage user webauthn import --file authelia.export.webauthn.yaml
 authelia storage user webauthn import --file authelia.export.webauthn.yaml --config config.yml
+authelia storage user webauthn import authelia.export.webauthn.yaml --username-map usernames.csv --dry-run
 authelia storage user webauthn import --file authelia.export.webauthn.yaml --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
 
 	cmdAutheliaStorageUserWebAuthnExportShort = "Perform exports of the WebAuthn credentials"
@@ -244,6 +330,36 @@ authelia storage user webauthn delete --kid abc123
 authelia storage user webauthn delete --kid abc123 --config config.yml
 authelia storage user webauthn delete --kid abc123 --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
 
+	cmdAutheliaStorageUserTrustedDevicesShort = "Manage trusted devices"
+
+	cmdAutheliaStorageUserTrustedDevicesLong = `Manage trusted devices.
+
+This subcommand allows listing and revoking the devices users have trusted to satisfy the second factor.`
+
+	cmdAutheliaStorageUserTrustedDevicesExample = `authelia storage user trusted-devices --help`
+
+	cmdAutheliaStorageUserTrustedDevicesListShort = "List the trusted devices for a user"
+
+	cmdAutheliaStorageUserTrustedDevicesListLong = `List the trusted devices for a user.
+
+This subcommand allows listing the trusted devices for a user which have not expired or been revoked.`
+
+	cmdAutheliaStorageUserTrustedDevicesListExample = `authelia storage user trusted-devices list john
+authelia storage user trusted-devices list john --config config.yml
+authelia storage user trusted-devices list john --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
+
+	cmdAutheliaStorageUserTrustedDevicesRevokeShort = "Revoke trusted devices for a user"
+
+	cmdAutheliaStorageUserTrustedDevicesRevokeLong = `Revoke trusted devices for a user.
+
+This subcommand allows revoking a single trusted device for a user by its id or all of the trusted devices for a user.
+Revoked devices no longer satisfy the second factor.`
+
+	cmdAutheliaStorageUserTrustedDevicesRevokeExample = `authelia storage user trusted-devices revoke john 2b3f7e0d-8a53-4c1c-9b8e-2b3b2f1c5a6d
+authelia storage user trusted-devices revoke john --all
+authelia storage user trusted-devices revoke john --all --config config.yml
+authelia storage user trusted-devices revoke john --all --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
+
 	cmdAutheliaStorageUserTOTPShort = "Manage TOTP configurations"
 
 	cmdAutheliaStorageUserTOTPLong = `Manage TOTP configurations.
@@ -280,12 +396,49 @@ authelia storage user totp delete john --encryption-key b3453fde-ecc2-4a1f-9422-
 
 	cmdAutheliaStorageUserTOTPImportLong = `Perform imports of the TOTP configurations.
 
-This subcommand allows importing TOTP configurations from the YAML format.`
+This subcommand allows importing TOTP configurations from the YAML format. The optional type of each configuration
+may be totp, hotp for counter-based tokens such as OATH-HOTP hardware tokens, or steam for Steam Guard tokens. The
+counter value of hotp configurations is the next counter value expected from the token.`
 
 	cmdAutheliaStorageUserTOTPImportExample = `authelia storage user totp import authelia.export.totp.yaml
 authelia storage user totp import --config config.yml authelia.export.totp.yaml
 authelia storage user totp import --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw authelia.export.totp.yaml`
 
+	cmdAutheliaStorageUserTOTPImportCSVShort = "Perform bulk imports of TOTP configurations from a CSV"
+
+	cmdAutheliaStorageUserTOTPImportCSVLong = `Perform bulk imports of TOTP configurations from a CSV.
+
+This subcommand allows importing TOTP configurations in bulk from a CSV file with a header row. The username and secret
+columns are required and the secret must be base32 encoded. The issuer, type, algorithm, digits, period, and counter
+columns are optional. This is the same format produced by the 'authelia storage user totp export csv' command.
+
+Each row is validated against the allowed algorithms, digits, and periods in the TOTP configuration and the result of
+each row is reported. Rows for users who already have a TOTP configuration are skipped unless the --force flag is used.`
+
+	cmdAutheliaStorageUserTOTPImportCSVExample = `authelia storage user totp import csv users.csv
+authelia storage user totp import csv users.csv --dry-run
+authelia storage user totp import csv users.csv --username-map usernames.csv --report report.csv
+authelia storage user totp import csv users.csv --config config.yml
+authelia storage user totp import csv users.csv --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
+
+	cmdAutheliaStorageUserTOTPImportURIShort = "Perform bulk imports of TOTP configurations from URIs"
+
+	cmdAutheliaStorageUserTOTPImportURILong = `Perform bulk imports of TOTP configurations from URIs.
+
+This subcommand allows importing TOTP configurations in bulk from a file with one otpauth URI per line. The username is
+taken from the account name in the URI label. This is the same format produced by the
+'authelia storage user totp export uri' command.
+
+Each URI is validated against the allowed algorithms, digits, and periods in the TOTP configuration and the result of
+each line is reported. Lines for users who already have a TOTP configuration are skipped unless the --force flag is
+used.`
+
+	cmdAutheliaStorageUserTOTPImportURIExample = `authelia storage user totp import uri users.txt
+authelia storage user totp import uri users.txt --dry-run
+authelia storage user totp import uri users.txt --username-map usernames.csv --report report.csv
+authelia storage user totp import uri users.txt --config config.yml
+authelia storage user totp import uri users.txt --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
+
 	cmdAutheliaStorageUserTOTPExportShort = "Perform exports of the TOTP configurations"
 
 	cmdAutheliaStorageUserTOTPExportLong = `Perform exports of the TOTP configurations.
@@ -310,9 +463,11 @@ authelia storage user totp export csv --encryption-key b3453fde-ecc2-4a1f-9422-2
 
 	cmdAutheliaStorageUserTOTPExportURILong = `Perform exports of the TOTP configurations to URIs.
 
-This subcommand allows exporting TOTP configurations to TOTP URIs.`
+This subcommand allows exporting TOTP configurations to TOTP URIs which are printed to the console or saved to a
+file if the --file flag is used.`
 
 	cmdAutheliaStorageUserTOTPExportURIExample = `authelia storage user totp export uri
+authelia storage user totp export uri --file users.txt
 authelia storage user totp export uri --config config.yml
 authelia storage user totp export uri --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
 
@@ -327,6 +482,122 @@ authelia storage user totp export png --directory example/dir
 authelia storage user totp export png --config config.yml
 authelia storage user totp export png --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
 
+	cmdAutheliaStorageRegulationShort = "Manage the regulation bans"
+
+	cmdAutheliaStorageRegulationLong = `Manage the regulation bans.
+
+This subcommand allows inspecting the users and IPs which are currently banned along with their authentication history,
+lifting bans early, and banning users or IPs manually. Each subcommand which accepts a username or an IP considers the
+value an IP if it can be parsed as one.`
+
+	cmdAutheliaStorageRegulationExample = `authelia storage regulation --help`
+
+	cmdAutheliaStorageRegulationListShort = "List the users and IPs which are currently banned"
+
+	cmdAutheliaStorageRegulationListLong = `List the users and IPs which are currently banned.
+
+This subcommand lists the bans applied automatically by the regulation as well as the bans applied manually.`
+
+	cmdAutheliaStorageRegulationListExample = `authelia storage regulation list
+authelia storage regulation list --config config.yml
+authelia storage regulation list --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
+
+	cmdAutheliaStorageRegulationShowShort = "Show the bans and authentication history of a user or IP"
+
+	cmdAutheliaStorageRegulationShowLong = `Show the bans and authentication history of a user or IP.
+
+This subcommand shows the bans which currently apply to a user or IP along with the recent authentication attempts.`
+
+	cmdAutheliaStorageRegulationShowExample = `authelia storage regulation show john
+authelia storage regulation show 192.168.1.10
+authelia storage regulation show john --config config.yml
+authelia storage regulation show john --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
+
+	cmdAutheliaStorageRegulationClearShort = "Lift the bans of a user or IP"
+
+	cmdAutheliaStorageRegulationClearLong = `Lift the bans of a user or IP.
+
+This subcommand lifts all bans which currently apply to a user or IP including bans applied automatically by the
+regulation and lockouts. The failed authentication attempts of a user which occurred before the bans were lifted are
+disregarded for future regulation decisions.`
+
+	cmdAutheliaStorageRegulationClearExample = `authelia storage regulation clear john
+authelia storage regulation clear 192.168.1.10
+authelia storage regulation clear john --config config.yml
+authelia storage regulation clear john --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
+
+	cmdAutheliaStorageRegulationBanShort = "Ban a user or IP"
+
+	cmdAutheliaStorageRegulationBanLong = `Ban a user or IP.
+
+This subcommand bans a user or IP manually for the specified duration or permanently if no duration is specified. Bans
+are only enforced when the regulation is enabled.`
+
+	cmdAutheliaStorageRegulationBanExample = `authelia storage regulation ban john
+authelia storage regulation ban john --duration 1d --reason "compromised credentials"
+authelia storage regulation ban 192.168.1.10 --duration 1h
+authelia storage regulation ban john --config config.yml
+authelia storage regulation ban john --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
+
+	cmdAutheliaStorageBackupShort = "Perform a backup of the storage"
+
+	cmdAutheliaStorageBackupLong = `Perform a backup of the storage.
+
+This subcommand writes the data from every table in the storage to a versioned and compressed backup archive. Values
+which are encrypted in the storage remain encrypted in the archive, and the same encryption key is required to restore
+it. It's recommended that Authelia is stopped while performing a backup to ensure the backup is consistent.`
+
+	cmdAutheliaStorageBackupExample = `authelia storage backup
+authelia storage backup --file authelia.backup.json.gz
+authelia storage backup --config config.yml
+authelia storage backup --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
+
+	cmdAutheliaStorageRestoreShort = "Restore a backup of the storage"
+
+	cmdAutheliaStorageRestoreLong = `Restore a backup of the storage.
+
+This subcommand restores a backup archive created with the 'authelia storage backup' command. The storage must either
+be empty in which case the schema is migrated to the version of the backup, or must be the same schema version as the
+backup. The storage must not contain any data unless the force flag is used in which case the existing data is deleted.
+The backup is restored in a single transaction and the number of rows restored to each table is verified.`
+
+	cmdAutheliaStorageRestoreExample = `authelia storage restore authelia.backup.json.gz
+authelia storage restore authelia.backup.json.gz --force
+authelia storage restore authelia.backup.json.gz --config config.yml
+authelia storage restore authelia.backup.json.gz --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
+
+	cmdAutheliaStorageMigrateBackendShort = "Copy the storage data to another storage backend"
+
+	cmdAutheliaStorageMigrateBackendLong = `Copy the storage data to another storage backend.
+
+This subcommand copies the data from every table of one configured storage backend to another configured storage
+backend, for example from SQLite3 to PostgreSQL. Both backends must be configured and use the same encryption key. The
+schema of the source backend must be up to date, and the destination backend must either be empty or the same schema
+version. The destination backend must not contain any data unless the force flag is used in which case the existing
+data is deleted. The number of rows in each table of both backends is compared after the data is copied.
+
+If the from flag is not provided the source backend is the first configured backend other than the destination in the
+order postgres, mysql, and sqlite. It's recommended that Authelia is stopped while the data is being copied.`
+
+	cmdAutheliaStorageMigrateBackendExample = `authelia storage migrate-backend --to postgres
+authelia storage migrate-backend --from sqlite --to postgres --force
+authelia storage migrate-backend --to postgres --config config.yml
+authelia storage migrate-backend --to postgres --sqlite.path /config/db.sqlite3 --postgres.host postgres --postgres.password autheliapw`
+
+	cmdAutheliaStoragePruneShort = "Prune the data which has exceeded the retention period from the storage"
+
+	cmdAutheliaStoragePruneLong = `Prune the data which has exceeded the retention period from the storage.
+
+This subcommand deletes the data which has exceeded the retention period configured in the storage retention
+configuration in batches. This is the same process which is performed automatically at the configured interval while
+Authelia is running unless it's disabled. The dry-run flag reports the number of rows which would be deleted from each
+table without deleting them.`
+
+	cmdAutheliaStoragePruneExample = `authelia storage prune
+authelia storage prune --dry-run
+authelia storage prune --config config.yml
+authelia storage prune --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
+
 	cmdAutheliaStorageSchemaInfoShort = "Show the storage information"
 
 	cmdAutheliaStorageSchemaInfoLong = `Show the storage information.
@@ -432,6 +703,72 @@ authelia config validate --config config.yml`
 	cmdAutheliaConfigValidateLegacyExample = `authelia validate-config
 authelia validate-config --config config.yml`
 
+	cmdAutheliaConfigMigrateShort = "Migrate the deprecated keys of configuration files to their replacements"
+
+	cmdAutheliaConfigMigrateLong = `Migrate the deprecated keys of configuration files to their replacements.
+
+This subcommand rewrites the configuration files replacing the deprecated keys which are automatically mapped at startup
+with their replacements. The comments, ordering, and template expressions of the files are preserved, however the
+formatting such as blank lines and indentation is normalized. The original of each migrated file is saved next to it
+with the .bak extension before the migrated file replaces it. The deprecated keys which can't be automatically migrated
+are reported and must be migrated manually. Remote configuration sources are never modified.`
+
+	cmdAutheliaConfigMigrateExample = `authelia config migrate
+authelia config migrate --dry-run --config config.yml`
+
+	cmdAutheliaConfigDiffShort = "Compare the effective configuration against the defaults or another configuration file"
+
+	cmdAutheliaConfigDiffLong = `Compare the effective configuration against the defaults or another configuration file.
+
+This subcommand loads the effective configuration from all configuration sources and prints every key which differs
+from the default configuration, or from the configuration loaded from the file specified by the --file flag. The values
+of secret keys are always redacted, and the values of complex keys such as lists of maps are omitted.`
+
+	cmdAutheliaConfigDiffExample = `authelia config diff
+authelia config diff --config config.yml --file config.old.yml`
+
+	cmdAutheliaConfigDumpShort = "Print the effective configuration with the secrets redacted"
+
+	cmdAutheliaConfigDumpLong = `Print the effective configuration with the secrets redacted.
+
+This subcommand loads the configuration from all configuration sources, applies the defaults, and prints the result as
+YAML or JSON with the values of secret keys redacted. The --provenance flag annotates each key with the source which
+supplied its value such as the file path and line number, the environment variable, or the secret file. Keys which
+were not supplied by any source are annotated as having the default value.`
+
+	cmdAutheliaConfigDumpExample = `authelia config dump
+authelia config dump --provenance --config config.yml
+authelia config dump --format json --provenance --config config.yml`
+
+	cmdAutheliaConfigSchemaShort = "Print the JSON Schema for the configuration or the users database"
+
+	cmdAutheliaConfigSchemaLong = `Print the JSON Schema for the configuration or the users database.
+
+This subcommand generates the JSON Schema for this version of Authelia and prints it. The --type flag selects the
+schema for either the configuration file or the file based authentication backend users database file.`
+
+	cmdAutheliaConfigSchemaExample = `authelia config schema
+authelia config schema --type user-database`
+
+	cmdAutheliaConfigSchemaValidateShort = "Validate files against the JSON Schema without loading them"
+
+	cmdAutheliaConfigSchemaValidateLong = `Validate files against the JSON Schema without loading them.
+
+This subcommand validates each file or directory against the JSON Schema for this version of Authelia without loading
+the configuration, and reports the key path, line, and column of each violation. If no paths are specified the
+configuration files specified by the --config flag are validated. The --format flag selects either text output in the
+file:line:column format, or JSON output which is suitable for consumption by continuous integration tools.
+
+Violations which prevent Authelia from starting are reported as errors, and deprecated keys are reported as warnings.
+The command exits with a non-zero status if any errors are reported. This command does not perform the semantic
+validation performed by the 'authelia config validate' command.`
+
+	cmdAutheliaConfigSchemaValidateExample = `authelia config schema validate
+authelia config schema validate --config config.yml
+authelia config schema validate config.yml config.d/
+authelia config schema validate --format json config.yml
+authelia config schema validate --type user-database users.yml`
+
 	cmdAutheliaCryptoShort = "Perform cryptographic operations"
 
 	cmdAutheliaCryptoLong = `Perform cryptographic operations.
@@ -626,6 +963,9 @@ const (
 	cmdFlagNameConfigExpFilters = "config.experimental.filters"
 	cmdFlagEnvNameConfigFilters = "X_AUTHELIA_CONFIG_FILTERS"
 
+	cmdFlagNameConfigExpSecretResolvers = "config.experimental.secret-resolvers"
+	cmdFlagEnvNameConfigSecretResolvers = "X_AUTHELIA_CONFIG_SECRET_RESOLVERS"
+
 	cmdFlagNameCharSet     = "charset"
 	cmdFlagValueCharSet    = "alphanumeric"
 	cmdFlagUsageCharset    = "sets the charset for the random password, options are 'ascii', 'alphanumeric', 'alphabetic', 'numeric', 'numeric-hex', and 'rfc3986'"
@@ -656,7 +996,20 @@ const (
 	cmdFlagNameForce       = "force"
 	cmdFlagNamePath        = "path"
 	cmdFlagNameTarget      = "target"
+	cmdFlagNameFrom        = "from"
+	cmdFlagNameTo          = "to"
 	cmdFlagNameDestroyData = "destroy-data"
+	cmdFlagNameReason      = "reason"
+	cmdFlagNameStatus      = "status"
+	cmdFlagNameLimit       = "limit"
+	cmdFlagNamePage        = "page"
+	cmdFlagNameOlderThan   = "older-than"
+	cmdFlagNameDryRun      = "dry-run"
+	cmdFlagNameUsernameMap = "username-map"
+	cmdFlagNameReport      = "report"
+	cmdFlagNameFormat      = "format"
+	cmdFlagNameProvenance  = "provenance"
+	cmdFlagNameType        = "type"
 
 	cmdFlagNameEncryptionKey      = "encryption-key"
 	cmdFlagNameSQLite3Path        = "sqlite.path"
@@ -704,6 +1057,33 @@ const (
 	prefixFilePassword = "authentication_backend.file.password"
 )
 
+const (
+	configMigrateExtBackup = ".bak"
+)
+
+const (
+	configDumpFormatYAML = "yaml"
+	configDumpFormatJSON = "json"
+)
+
+const (
+	configSchemaTypeConfiguration = "configuration"
+	configSchemaTypeUserDatabase  = "user-database"
+	configSchemaFormatText        = "text"
+	configSchemaFormatJSON        = "json"
+	configSchemaVersionLatest     = "latest"
+)
+
+const (
+	storageBackendPostgreSQL = "postgres"
+	storageBackendMySQL      = "mysql"
+	storageBackendSQLite     = "sqlite"
+)
+
+var (
+	storageBackends = []string{storageBackendPostgreSQL, storageBackendMySQL, storageBackendSQLite}
+)
+
 var (
 	errStorageSchemaOutdated     = errors.New("storage schema outdated")
 	errStorageSchemaIncompatible = errors.New("storage schema incompatible")
@@ -743,6 +1123,29 @@ The following filters are available:
 
 		For a full list of functions see: https://www.authelia.com/configuration/methods/files/#functions`
 
+	helpTopicConfigSecretResolvers = `Secret Resolvers are an experimental system for resolving secret references from external
+secret stores.
+
+Using the --config.experimental.secret-resolvers flag or the X_AUTHELIA_CONFIG_SECRET_RESOLVERS environment variable
+users can enable multiple secret resolvers. The value of any secret configuration key which starts with the name of an
+enabled resolver followed by a colon is a reference which is resolved when the configuration is loaded or reloaded. The
+resolved values are never logged.
+
+The following secret resolvers are available:
+
+	vault:
+
+		This resolver reads a field from a HashiCorp Vault KV v2 secret. The reference is the API path of the secret
+		followed by a hash and the name of the field, for example 'vault:secret/data/authelia#jwt_secret'. The resolver
+		is configured using the VAULT_ADDR, VAULT_TOKEN, and VAULT_NAMESPACE environment variables.
+
+	exec:
+
+		This resolver executes a command without a shell and uses the output with the trailing newline removed, for
+		example 'exec:/usr/bin/pass show authelia/jwt_secret'.
+
+For more information see: https://www.authelia.com/configuration/methods/secrets/#secret-references`
+
 	helpTopicConfig = `Configuration can be specified in multiple layers where each layer is a different source from
 the last. The layers are loaded in the order below where each layer potentially overrides the individual settings from
 previous layers with the individual settings it provides (i.e. if the same setting is specified twice).
@@ -768,6 +1171,10 @@ File/Directory Paths:
 	The files loaded via this method can be interpolated or templated via the configuration filters. Read more about
 	this topic by running: authelia -h authelia filters
 
+	Remote sources can be specified in the same way as File/Directory Paths using a URL with one of the 'http',
+	'https', 'consul', 'consul+https', 'etcd', or 'etcd+https' schemes. For more information see:
+	https://www.authelia.com/configuration/methods/files/#remote-sources
+
 Environment Variables:
 
 	Most configuration options in Authelia can be specified via an environment variable. The available options and the
@@ -841,17 +1248,47 @@ const (
 	logFieldService = "service"
 	logFieldFile    = "file"
 	logFieldOP      = "op"
+	logFieldSource  = "source"
 
 	serviceTypeServer  = "server"
 	serviceTypeWatcher = "watcher"
+	serviceTypeWorker  = "worker"
 
 	logFieldProvider            = "provider"
 	logMessageStartupCheckError = "Error occurred running a startup check"
 
-	providerNameNTP          = "ntp"
-	providerNameStorage      = "storage"
-	providerNameUser         = "user"
-	providerNameNotification = "notification"
+	providerNameNTP              = "ntp"
+	providerNameStorage          = "storage"
+	providerNameUser             = "user"
+	providerNameNotification     = "notification"
+	providerNameWebAuthnMetadata = "webauthn-metadata"
+)
+
+const (
+	serviceNameConfiguration = "configuration"
+
+	configurationReloadTriggerSignal = "signal"
+	configurationReloadTriggerWatch  = "watch"
+	configurationReloadTriggerPoll   = "poll"
+
+	configurationReloadDebounce = time.Second
+
+	extYML  = ".yml"
+	extYAML = ".yaml"
+)
+
+// Configuration keys which can be reloaded without a restart.
+const (
+	keyLogLevel                     = "log.level"
+	keyAccessControl                = "access_control"
+	keyPasswordPolicy               = "password_policy"
+	keyIdentityProvidersOIDCClients = "identity_providers.oidc.clients"
+	keyNotifierDisableStartupCheck  = "notifier.disable_startup_check"
+	keyNotifierTemplatePath         = "notifier.template_path"
+	keyNotifierEvents               = "notifier.events"
+	keyNotifierSMTP                 = "notifier.smtp"
+	keyNotifierFileSystem           = "notifier.filesystem"
+	keyNotifierWebhook              = "notifier.webhook"
 )
 
 const (
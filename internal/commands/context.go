@@ -21,6 +21,7 @@ import (
 	"github.com/authelia/authelia/v4/internal/configuration"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/configuration/validator"
+	"github.com/authelia/authelia/v4/internal/fido"
 	"github.com/authelia/authelia/v4/internal/logging"
 	"github.com/authelia/authelia/v4/internal/metrics"
 	"github.com/authelia/authelia/v4/internal/middlewares"
@@ -72,29 +73,36 @@ func NewCmdCtxConfig() *CmdCtxConfig {
 
 // CmdCtxConfig is the configuration for the CmdCtx.
 type CmdCtxConfig struct {
-	files     []string
-	filters   []string
-	defaults  configuration.Source
-	sources   []configuration.Source
-	keys      []string
-	validator *schema.StructValidator
+	files      []string
+	filters    []string
+	resolvers  []string
+	defaults   configuration.Source
+	sources    []configuration.Source
+	keys       []string
+	provenance configuration.Provenance
+	validator  *schema.StructValidator
 }
 
 // CobraRunECmd describes a function that can be used as a *cobra.Command RunE, PreRunE, or PostRunE.
 type CobraRunECmd func(cmd *cobra.Command, args []string) (err error)
 
 func (ctx *CmdCtx) CheckSchemaVersion() (err error) {
-	if ctx.providers.StorageProvider == nil {
+	return ctx.CheckProviderSchemaVersion(ctx.providers.StorageProvider)
+}
+
+// CheckProviderSchemaVersion is a utility function which checks the schema version of a specific storage provider.
+func (ctx *CmdCtx) CheckProviderSchemaVersion(provider storage.Provider) (err error) {
+	if provider == nil {
 		return fmt.Errorf("storage not loaded")
 	}
 
 	var version, latest int
 
-	if version, err = ctx.providers.StorageProvider.SchemaVersion(ctx); err != nil {
+	if version, err = provider.SchemaVersion(ctx); err != nil {
 		return err
 	}
 
-	if latest, err = ctx.providers.StorageProvider.SchemaLatestVersion(); err != nil {
+	if latest, err = provider.SchemaLatestVersion(); err != nil {
 		return err
 	}
 
@@ -110,13 +118,19 @@ func (ctx *CmdCtx) CheckSchemaVersion() (err error) {
 
 // CheckSchema is a utility function which checks the schema version and encryption key.
 func (ctx *CmdCtx) CheckSchema() (err error) {
-	if err = ctx.CheckSchemaVersion(); err != nil {
+	return ctx.CheckProviderSchema(ctx.providers.StorageProvider)
+}
+
+// CheckProviderSchema is a utility function which checks the schema version and encryption key of a specific storage
+// provider.
+func (ctx *CmdCtx) CheckProviderSchema(provider storage.Provider) (err error) {
+	if err = ctx.CheckProviderSchemaVersion(provider); err != nil {
 		return err
 	}
 
 	var result storage.EncryptionValidationResult
 
-	if result, err = ctx.providers.StorageProvider.SchemaEncryptionCheckKey(ctx, false); !result.Checked() || !result.Success() {
+	if result, err = provider.SchemaEncryptionCheckKey(ctx, false); !result.Checked() || !result.Success() {
 		if err != nil {
 			return fmt.Errorf("failed to check the schema encryption key: %w", err)
 		}
@@ -150,6 +164,10 @@ func (ctx *CmdCtx) LoadProviders() (warns, errs []error) {
 	ctx.providers.SessionProvider = session.NewProvider(ctx.config.Session, ctx.trusted)
 	ctx.providers.TOTP = totp.NewTimeBasedProvider(ctx.config.TOTP)
 
+	if !ctx.config.WebAuthn.Disable && ctx.config.WebAuthn.Metadata.Enabled {
+		ctx.providers.WebAuthnMetadata = fido.NewMetadataProvider(&ctx.config.WebAuthn.Metadata)
+	}
+
 	var err error
 
 	switch {
@@ -163,11 +181,10 @@ func (ctx *CmdCtx) LoadProviders() (warns, errs []error) {
 		errs = append(errs, err)
 	}
 
-	switch {
-	case ctx.config.Notifier.SMTP != nil:
-		ctx.providers.Notifier = notification.NewSMTPNotifier(ctx.config.Notifier.SMTP, ctx.trusted)
-	case ctx.config.Notifier.FileSystem != nil:
-		ctx.providers.Notifier = notification.NewFileNotifier(*ctx.config.Notifier.FileSystem)
+	ctx.providers.Notifier = getNotifier(&ctx.config.Notifier, ctx.trusted)
+
+	if ctx.config.Notifier.Queue.Enable && ctx.providers.Notifier != nil && ctx.providers.Templates != nil {
+		ctx.providers.Notifier = notification.NewQueueNotifier(&ctx.config.Notifier.Queue, ctx.providers.Notifier, ctx.providers.StorageProvider, ctx.providers.Templates)
 	}
 
 	ctx.providers.OpenIDConnect = oidc.NewOpenIDConnectProvider(ctx.config.IdentityProviders.OIDC, ctx.providers.StorageProvider, ctx.providers.Templates)
@@ -263,7 +280,7 @@ func (ctx *CmdCtx) LogConfigure(_ *cobra.Command, _ []string) (err error) {
 		return fmt.Errorf("Cannot initialize logger: %w", err)
 	}
 
-	ctx.log.WithFields(map[string]any{"filters": ctx.cconfig.filters, "files": ctx.cconfig.files}).Debug("Loaded Configuration Sources")
+	ctx.log.WithFields(map[string]any{"filters": ctx.cconfig.filters, "files": ctx.cconfig.files, "secret_resolvers": ctx.cconfig.resolvers}).Debug("Loaded Configuration Sources")
 	ctx.log.WithFields(map[string]any{"level": ctx.config.Log.Level, "format": ctx.config.Log.Format, "file": ctx.config.Log.FilePath, "keep_stdout": ctx.config.Log.KeepStdout}).Debug("Logging Initialized")
 
 	return nil
@@ -397,7 +414,8 @@ func (ctx *CmdCtx) ConfigEnsureExistsRunE(cmd *cobra.Command, _ []string) (err e
 // HelperConfigLoadRunE loads the configuration into the CmdCtx.
 func (ctx *CmdCtx) HelperConfigLoadRunE(cmd *cobra.Command, _ []string) (err error) {
 	var (
-		filters []configuration.BytesFilter
+		filters   []configuration.BytesFilter
+		resolvers []configuration.SecretResolver
 	)
 
 	if ctx.cconfig == nil {
@@ -408,6 +426,10 @@ func (ctx *CmdCtx) HelperConfigLoadRunE(cmd *cobra.Command, _ []string) (err err
 		return err
 	}
 
+	if ctx.cconfig.resolvers, resolvers, err = loadXEnvCLISecretResolvers(cmd); err != nil {
+		return err
+	}
+
 	ctx.cconfig.filters = make([]string, len(filters))
 
 	for i, filter := range filters {
@@ -420,9 +442,9 @@ func (ctx *CmdCtx) HelperConfigLoadRunE(cmd *cobra.Command, _ []string) (err err
 		configuration.DefaultEnvPrefix,
 		configuration.DefaultEnvDelimiter,
 		ctx.cconfig.defaults,
-		ctx.cconfig.sources...)
+		append(ctx.cconfig.sources, configuration.NewSecretReferencesSource(resolvers...))...)
 
-	if ctx.cconfig.keys, err = configuration.LoadAdvanced(
+	if ctx.cconfig.keys, ctx.cconfig.provenance, err = configuration.LoadAdvancedWithProvenance(
 		ctx.cconfig.validator,
 		"",
 		ctx.config,
@@ -1,15 +1,28 @@
 package commands
 
 import (
+	"bufio"
+	"bytes"
+	"crypto/x509"
 	"encoding/base32"
+	"encoding/csv"
 	"errors"
 	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/pflag"
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/configuration/validator"
 	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/notification"
 	"github.com/authelia/authelia/v4/internal/storage"
+	"github.com/authelia/authelia/v4/internal/utils"
 )
 
 func getStorageProvider(ctx *CmdCtx) (provider storage.Provider) {
@@ -25,6 +38,73 @@ func getStorageProvider(ctx *CmdCtx) (provider storage.Provider) {
 	}
 }
 
+func getNotifier(config *schema.Notifier, trusted *x509.CertPool) (notifier notification.Notifier) {
+	switch {
+	case config.SMTP != nil:
+		return notification.NewSMTPNotifier(config.SMTP, trusted)
+	case config.FileSystem != nil:
+		return notification.NewFileNotifier(*config.FileSystem)
+	case config.Webhook != nil:
+		return notification.NewWebhookNotifier(config.Webhook, trusted)
+	default:
+		return nil
+	}
+}
+
+func getStorageProviderByName(ctx *CmdCtx, name string) (provider storage.Provider, err error) {
+	config := *ctx.config
+
+	config.Storage = schema.Storage{
+		EncryptionKey: ctx.config.Storage.EncryptionKey,
+		KeyManagement: ctx.config.Storage.KeyManagement,
+	}
+
+	switch name {
+	case storageBackendPostgreSQL:
+		config.Storage.PostgreSQL = ctx.config.Storage.PostgreSQL
+	case storageBackendMySQL:
+		config.Storage.MySQL = ctx.config.Storage.MySQL
+	case storageBackendSQLite:
+		config.Storage.Local = ctx.config.Storage.Local
+	default:
+		return nil, fmt.Errorf("the storage backend '%s' is unknown, it must be one of %s", name, utils.StringJoinOr(storageBackends))
+	}
+
+	if config.Storage.PostgreSQL == nil && config.Storage.MySQL == nil && config.Storage.Local == nil {
+		return nil, fmt.Errorf("the storage backend '%s' is not configured", name)
+	}
+
+	val := schema.NewStructValidator()
+
+	validator.ValidateStorage(config.Storage, val)
+
+	if errs := val.Errors(); len(errs) != 0 {
+		return nil, fmt.Errorf("the storage backend '%s' has an invalid configuration: %w", name, errors.Join(errs...))
+	}
+
+	switch {
+	case config.Storage.PostgreSQL != nil:
+		return storage.NewPostgreSQLProvider(&config, ctx.trusted), nil
+	case config.Storage.MySQL != nil:
+		return storage.NewMySQLProvider(&config, ctx.trusted), nil
+	default:
+		return storage.NewSQLiteProvider(&config), nil
+	}
+}
+
+func getStorageBackendSource(config schema.Storage, to string) (from string) {
+	switch {
+	case to != storageBackendPostgreSQL && config.PostgreSQL != nil:
+		return storageBackendPostgreSQL
+	case to != storageBackendMySQL && config.MySQL != nil:
+		return storageBackendMySQL
+	case to != storageBackendSQLite && config.Local != nil:
+		return storageBackendSQLite
+	default:
+		return ""
+	}
+}
+
 func containsIdentifier(identifier model.UserOpaqueIdentifier, identifiers []model.UserOpaqueIdentifier) bool {
 	for i := 0; i < len(identifiers); i++ {
 		if identifier.Service == identifiers[i].Service && identifier.SectorID == identifiers[i].SectorID && identifier.Username == identifiers[i].Username {
@@ -119,3 +199,297 @@ func storageWebAuthnDeleteRunEOptsFromFlags(flags *pflag.FlagSet, args []string)
 
 	return
 }
+
+// storageTOTPBulkRecord is a single record from a bulk TOTP import file along with the result of processing it.
+type storageTOTPBulkRecord struct {
+	Line   int
+	Config *model.TOTPConfiguration
+	Result string
+	Err    error
+}
+
+// Username returns the username of the record if it was parsed successfully.
+func (r storageTOTPBulkRecord) Username() string {
+	if r.Config == nil {
+		return ""
+	}
+
+	return r.Config.Username
+}
+
+type storageTOTPBulkParser func(data []byte, issuer string, now time.Time) (records []storageTOTPBulkRecord, err error)
+
+// storageTOTPBulkParseCSV parses a CSV file with a header row into bulk TOTP records. The username and secret columns
+// are required, and the issuer, type, algorithm, digits, period, and counter columns are optional. This is the same
+// format produced by the 'authelia storage user totp export csv' command.
+func storageTOTPBulkParseCSV(data []byte, issuer string, now time.Time) (records []storageTOTPBulkRecord, err error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var header, row []string
+
+	if header, err = reader.Read(); err != nil {
+		return nil, fmt.Errorf("error reading the CSV header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	for _, name := range []string{"username", "secret"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("error reading the CSV header: the '%s' column is required", name)
+		}
+	}
+
+	for {
+		if row, err = reader.Read(); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			var perr *csv.ParseError
+
+			if errors.As(err, &perr) {
+				records = append(records, storageTOTPBulkRecord{Line: perr.Line, Err: perr.Err})
+
+				continue
+			}
+
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		values := url.Values{}
+
+		for name, i := range columns {
+			if i < len(row) {
+				values.Set(name, strings.TrimSpace(row[i]))
+			}
+		}
+
+		record := storageTOTPBulkRecord{Line: line}
+
+		record.Config, record.Err = storageTOTPBulkNewConfig(values.Get("username"), issuer, now, values)
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// storageTOTPBulkParseURI parses a file with one otpauth URI per line into bulk TOTP records. Empty lines and lines
+// starting with a '#' are ignored. This is the same format produced by the 'authelia storage user totp export uri'
+// command.
+func storageTOTPBulkParseURI(data []byte, issuer string, now time.Time) (records []storageTOTPBulkRecord, err error) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		record := storageTOTPBulkRecord{Line: line}
+
+		var uri *url.URL
+
+		switch uri, record.Err = url.Parse(text); {
+		case record.Err != nil:
+			break
+		case uri.Scheme != "otpauth":
+			record.Err = fmt.Errorf("the URI scheme must be 'otpauth' but it's '%s'", uri.Scheme)
+		default:
+			values := uri.Query()
+
+			switch {
+			case values.Get("encoder") == model.OneTimePasswordTypeSteam:
+				values.Set("type", model.OneTimePasswordTypeSteam)
+			default:
+				values.Set("type", strings.ToLower(uri.Host))
+			}
+
+			label := strings.TrimPrefix(uri.Path, "/")
+
+			if i := strings.Index(label, ":"); i != -1 {
+				if values.Get("issuer") == "" {
+					values.Set("issuer", strings.TrimSpace(label[:i]))
+				}
+
+				label = label[i+1:]
+			}
+
+			record.Config, record.Err = storageTOTPBulkNewConfig(strings.TrimSpace(label), issuer, now, values)
+		}
+
+		records = append(records, record)
+	}
+
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// storageTOTPBulkNewConfig creates a new model.TOTPConfiguration from the string values of a bulk TOTP record applying
+// the defaults for any values which are absent.
+func storageTOTPBulkNewConfig(username, issuer string, now time.Time, values url.Values) (config *model.TOTPConfiguration, err error) {
+	if username == "" {
+		return nil, fmt.Errorf("the username is required")
+	}
+
+	config = &model.TOTPConfiguration{
+		CreatedAt: now,
+		Username:  username,
+		Issuer:    values.Get("issuer"),
+		Type:      strings.ToLower(values.Get("type")),
+		Algorithm: strings.ToUpper(values.Get("algorithm")),
+	}
+
+	if config.Issuer == "" {
+		config.Issuer = issuer
+	}
+
+	if config.Type == "" {
+		config.Type = model.OneTimePasswordTypeTOTP
+	}
+
+	if !utils.IsStringInSlice(config.Type, model.OneTimePasswordTypes) {
+		return nil, fmt.Errorf("the type must be one of %s but it's '%s'", utils.StringJoinOr(model.OneTimePasswordTypes), config.Type)
+	}
+
+	if config.Algorithm == "" {
+		config.Algorithm = schema.TOTPAlgorithmSHA1
+	}
+
+	defaultDigits, defaultPeriod := uint64(6), uint64(30)
+
+	switch config.Type {
+	case model.OneTimePasswordTypeHOTP:
+		defaultPeriod = 0
+	case model.OneTimePasswordTypeSteam:
+		defaultDigits = 5
+	}
+
+	var value uint64
+
+	if value, err = storageTOTPBulkParseUint(values.Get("digits"), defaultDigits); err != nil {
+		return nil, fmt.Errorf("the digits must be a number: %w", err)
+	}
+
+	config.Digits = uint(value)
+
+	if value, err = storageTOTPBulkParseUint(values.Get("period"), defaultPeriod); err != nil {
+		return nil, fmt.Errorf("the period must be a number: %w", err)
+	}
+
+	config.Period = uint(value)
+
+	if config.Counter, err = storageTOTPBulkParseUint(values.Get("counter"), 0); err != nil {
+		return nil, fmt.Errorf("the counter must be a number: %w", err)
+	}
+
+	secret := strings.ToUpper(strings.TrimRight(strings.ReplaceAll(values.Get("secret"), " ", ""), "="))
+
+	if secret == "" {
+		return nil, fmt.Errorf("the secret is required")
+	}
+
+	if _, err = base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret); err != nil {
+		return nil, fmt.Errorf("the secret must be base32 encoded: %w", err)
+	}
+
+	config.Secret = []byte(secret)
+
+	return config, nil
+}
+
+func storageTOTPBulkParseUint(value string, fallback uint64) (uint64, error) {
+	if value == "" {
+		return fallback, nil
+	}
+
+	return strconv.ParseUint(value, 10, 64)
+}
+
+// storageTOTPBulkValidate validates a bulk TOTP record against the allowed values in the TOTP configuration.
+func storageTOTPBulkValidate(config *schema.TOTP, c *model.TOTPConfiguration) (err error) {
+	if !utils.IsStringInSlice(c.Algorithm, config.AllowedAlgorithms) {
+		return fmt.Errorf("the algorithm must be one of %s but it's '%s'", utils.StringJoinOr(config.AllowedAlgorithms), c.Algorithm)
+	}
+
+	switch c.Type {
+	case model.OneTimePasswordTypeSteam:
+		if c.Digits != 5 {
+			return fmt.Errorf("the digits must be 5 for the type '%s' but it's '%d'", c.Type, c.Digits)
+		}
+	default:
+		if !utils.IsIntegerInSlice(int(c.Digits), config.AllowedDigits) {
+			return fmt.Errorf("the digits must be one of %s but it's '%d'", storageJoinIntegers(config.AllowedDigits), c.Digits)
+		}
+	}
+
+	if c.Type != model.OneTimePasswordTypeHOTP && !utils.IsIntegerInSlice(int(c.Period), config.AllowedPeriods) {
+		return fmt.Errorf("the period must be one of %s but it's '%d'", storageJoinIntegers(config.AllowedPeriods), c.Period)
+	}
+
+	return nil
+}
+
+func storageJoinIntegers(values []int) string {
+	items := make([]string, len(values))
+
+	for i, value := range values {
+		items[i] = strconv.Itoa(value)
+	}
+
+	return utils.StringJoinOr(items)
+}
+
+// storageReadUsernameMap reads a CSV file where each row maps the username in an import file in the first column to
+// the username in Authelia in the second column.
+func storageReadUsernameMap(filename string) (usernames map[string]string, err error) {
+	usernames = map[string]string{}
+
+	if filename == "" {
+		return usernames, nil
+	}
+
+	var data []byte
+
+	if data, err = os.ReadFile(filename); err != nil {
+		return nil, fmt.Errorf("error reading the username map file '%s': %w", filename, err)
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+
+	reader.Comment = '#'
+	reader.FieldsPerRecord = 2
+	reader.TrimLeadingSpace = true
+
+	var rows [][]string
+
+	if rows, err = reader.ReadAll(); err != nil {
+		return nil, fmt.Errorf("error reading the username map file '%s': %w", filename, err)
+	}
+
+	for _, row := range rows {
+		from, to := strings.TrimSpace(row[0]), strings.TrimSpace(row[1])
+
+		if from == "" || to == "" {
+			return nil, fmt.Errorf("error reading the username map file '%s': each row must have a username in both columns", filename)
+		}
+
+		usernames[from] = to
+	}
+
+	return usernames, nil
+}
@@ -1,11 +1,227 @@
 package commands
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/model"
 )
 
 func TestGetStorageProvider(t *testing.T) {
 	assert.Nil(t, getStorageProvider(NewCmdCtx()))
 }
+
+func TestGetStorageProviderByName(t *testing.T) {
+	ctx := NewCmdCtx()
+
+	ctx.config.Storage = schema.Storage{
+		EncryptionKey: "a_very_important_secret",
+		Local:         &schema.StorageLocal{Path: "/tmp/db.sqlite3"},
+	}
+
+	provider, err := getStorageProviderByName(ctx, storageBackendSQLite)
+	assert.NoError(t, err)
+	assert.NotNil(t, provider)
+
+	provider, err = getStorageProviderByName(ctx, storageBackendPostgreSQL)
+	assert.EqualError(t, err, "the storage backend 'postgres' is not configured")
+	assert.Nil(t, provider)
+
+	provider, err = getStorageProviderByName(ctx, "oracle")
+	assert.EqualError(t, err, "the storage backend 'oracle' is unknown, it must be one of 'postgres', 'mysql', or 'sqlite'")
+	assert.Nil(t, provider)
+}
+
+func TestGetStorageBackendSource(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     schema.Storage
+		to       string
+		expected string
+	}{
+		{
+			"ShouldPreferPostgreSQL",
+			schema.Storage{PostgreSQL: &schema.StoragePostgreSQL{}, MySQL: &schema.StorageMySQL{}, Local: &schema.StorageLocal{}},
+			storageBackendSQLite,
+			storageBackendPostgreSQL,
+		},
+		{
+			"ShouldSkipDestination",
+			schema.Storage{PostgreSQL: &schema.StoragePostgreSQL{}, Local: &schema.StorageLocal{}},
+			storageBackendPostgreSQL,
+			storageBackendSQLite,
+		},
+		{
+			"ShouldReturnEmptyWhenOnlyDestination",
+			schema.Storage{MySQL: &schema.StorageMySQL{}},
+			storageBackendMySQL,
+			"",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, getStorageBackendSource(tc.have, tc.to))
+		})
+	}
+}
+
+func TestStorageTOTPBulkParseCSV(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	data := []byte(`issuer,username,algorithm,digits,period,secret,type,counter
+example.com,john,SHA1,6,30,JBSWY3DPEHPK3PXP,totp,0
+,harry,sha256,8,60,jbsw y3dp ehpk 3pxp,,
+example.com,bob,SHA1,6,,JBSWY3DPEHPK3PXP,hotp,20
+example.com,fred,SHA1,6,30,NOT-BASE32,totp,0
+example.com,,SHA1,6,30,JBSWY3DPEHPK3PXP,totp,0
+`)
+
+	records, err := storageTOTPBulkParseCSV(data, "authelia.com", now)
+	require.NoError(t, err)
+	require.Len(t, records, 5)
+
+	assert.NoError(t, records[0].Err)
+	assert.Equal(t, 2, records[0].Line)
+	assert.Equal(t, &model.TOTPConfiguration{CreatedAt: now, Username: "john", Issuer: "example.com", Type: model.OneTimePasswordTypeTOTP, Algorithm: "SHA1", Digits: 6, Period: 30, Secret: []byte("JBSWY3DPEHPK3PXP")}, records[0].Config)
+
+	assert.NoError(t, records[1].Err)
+	assert.Equal(t, &model.TOTPConfiguration{CreatedAt: now, Username: "harry", Issuer: "authelia.com", Type: model.OneTimePasswordTypeTOTP, Algorithm: "SHA256", Digits: 8, Period: 60, Secret: []byte("JBSWY3DPEHPK3PXP")}, records[1].Config)
+
+	assert.NoError(t, records[2].Err)
+	assert.Equal(t, &model.TOTPConfiguration{CreatedAt: now, Username: "bob", Issuer: "example.com", Type: model.OneTimePasswordTypeHOTP, Algorithm: "SHA1", Digits: 6, Period: 0, Counter: 20, Secret: []byte("JBSWY3DPEHPK3PXP")}, records[2].Config)
+
+	assert.EqualError(t, records[3].Err, "the secret must be base32 encoded: illegal base32 data at input byte 3")
+	assert.Equal(t, 5, records[3].Line)
+
+	assert.EqualError(t, records[4].Err, "the username is required")
+	assert.Equal(t, "", records[4].Username())
+}
+
+func TestStorageTOTPBulkParseCSVShouldFailMissingColumn(t *testing.T) {
+	_, err := storageTOTPBulkParseCSV([]byte("username,algorithm\njohn,SHA1\n"), "authelia.com", time.Now())
+
+	assert.EqualError(t, err, "error reading the CSV header: the 'secret' column is required")
+}
+
+func TestStorageTOTPBulkParseURI(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	data := []byte(`# exported from another provider
+otpauth://totp/example.com:john?algorithm=SHA1&digits=6&period=30&secret=JBSWY3DPEHPK3PXP
+
+otpauth://hotp/harry?counter=5&secret=JBSWY3DPEHPK3PXP
+otpauth://totp/Steam:bob?secret=JBSWY3DPEHPK3PXP&issuer=Steam&encoder=steam
+https://example.com/fred?secret=JBSWY3DPEHPK3PXP
+otpauth://oath/fred?secret=JBSWY3DPEHPK3PXP
+`)
+
+	records, err := storageTOTPBulkParseURI(data, "authelia.com", now)
+	require.NoError(t, err)
+	require.Len(t, records, 5)
+
+	assert.NoError(t, records[0].Err)
+	assert.Equal(t, 2, records[0].Line)
+	assert.Equal(t, &model.TOTPConfiguration{CreatedAt: now, Username: "john", Issuer: "example.com", Type: model.OneTimePasswordTypeTOTP, Algorithm: "SHA1", Digits: 6, Period: 30, Secret: []byte("JBSWY3DPEHPK3PXP")}, records[0].Config)
+
+	assert.NoError(t, records[1].Err)
+	assert.Equal(t, 4, records[1].Line)
+	assert.Equal(t, &model.TOTPConfiguration{CreatedAt: now, Username: "harry", Issuer: "authelia.com", Type: model.OneTimePasswordTypeHOTP, Algorithm: "SHA1", Digits: 6, Counter: 5, Secret: []byte("JBSWY3DPEHPK3PXP")}, records[1].Config)
+
+	assert.NoError(t, records[2].Err)
+	assert.Equal(t, &model.TOTPConfiguration{CreatedAt: now, Username: "bob", Issuer: "Steam", Type: model.OneTimePasswordTypeSteam, Algorithm: "SHA1", Digits: 5, Period: 30, Secret: []byte("JBSWY3DPEHPK3PXP")}, records[2].Config)
+
+	assert.EqualError(t, records[3].Err, "the URI scheme must be 'otpauth' but it's 'https'")
+	assert.EqualError(t, records[4].Err, "the type must be one of 'totp', 'hotp', or 'steam' but it's 'oath'")
+}
+
+func TestStorageTOTPBulkValidate(t *testing.T) {
+	config := &schema.TOTP{
+		AllowedAlgorithms: []string{schema.TOTPAlgorithmSHA1},
+		AllowedDigits:     []int{6},
+		AllowedPeriods:    []int{30},
+	}
+
+	testCases := []struct {
+		name     string
+		have     *model.TOTPConfiguration
+		expected string
+	}{
+		{
+			"ShouldPass",
+			&model.TOTPConfiguration{Type: model.OneTimePasswordTypeTOTP, Algorithm: "SHA1", Digits: 6, Period: 30},
+			"",
+		},
+		{
+			"ShouldPassHOTPWithoutPeriod",
+			&model.TOTPConfiguration{Type: model.OneTimePasswordTypeHOTP, Algorithm: "SHA1", Digits: 6},
+			"",
+		},
+		{
+			"ShouldPassSteam",
+			&model.TOTPConfiguration{Type: model.OneTimePasswordTypeSteam, Algorithm: "SHA1", Digits: 5, Period: 30},
+			"",
+		},
+		{
+			"ShouldFailAlgorithm",
+			&model.TOTPConfiguration{Type: model.OneTimePasswordTypeTOTP, Algorithm: "SHA512", Digits: 6, Period: 30},
+			"the algorithm must be one of 'SHA1' but it's 'SHA512'",
+		},
+		{
+			"ShouldFailDigits",
+			&model.TOTPConfiguration{Type: model.OneTimePasswordTypeTOTP, Algorithm: "SHA1", Digits: 8, Period: 30},
+			"the digits must be one of '6' but it's '8'",
+		},
+		{
+			"ShouldFailSteamDigits",
+			&model.TOTPConfiguration{Type: model.OneTimePasswordTypeSteam, Algorithm: "SHA1", Digits: 6, Period: 30},
+			"the digits must be 5 for the type 'steam' but it's '6'",
+		},
+		{
+			"ShouldFailPeriod",
+			&model.TOTPConfiguration{Type: model.OneTimePasswordTypeTOTP, Algorithm: "SHA1", Digits: 6, Period: 60},
+			"the period must be one of '30' but it's '60'",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := storageTOTPBulkValidate(config, tc.have)
+
+			if tc.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expected)
+			}
+		})
+	}
+}
+
+func TestStorageReadUsernameMap(t *testing.T) {
+	dir := t.TempDir()
+
+	usernames, err := storageReadUsernameMap("")
+	assert.NoError(t, err)
+	assert.Len(t, usernames, 0)
+
+	valid := filepath.Join(dir, "valid.csv")
+
+	require.NoError(t, os.WriteFile(valid, []byte("# old,new\njohn@example.com,john\nharry@example.com, harry\n"), 0600))
+
+	usernames, err = storageReadUsernameMap(valid)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"john@example.com": "john", "harry@example.com": "harry"}, usernames)
+
+	invalid := filepath.Join(dir, "invalid.csv")
+
+	require.NoError(t, os.WriteFile(invalid, []byte("john@example.com,\n"), 0600))
+
+	_, err = storageReadUsernameMap(invalid)
+	assert.EqualError(t, err, "error reading the username map file '"+invalid+"': each row must have a username in both columns")
+}
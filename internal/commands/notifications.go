@@ -0,0 +1,250 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/storage"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+func newNotificationsCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "notifications",
+		Short:   cmdAutheliaNotificationsShort,
+		Long:    cmdAutheliaNotificationsLong,
+		Example: cmdAutheliaNotificationsExample,
+		PersistentPreRunE: ctx.ChainRunE(
+			ctx.ConfigStorageCommandLineConfigRunE,
+			ctx.HelperConfigLoadRunE,
+			ctx.ConfigValidateStorageRunE,
+			ctx.LoadProvidersStorageRunE,
+		),
+		Args: cobra.NoArgs,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmdStoragePersistentFlags(cmd)
+
+	cmd.AddCommand(
+		newNotificationsListCmd(ctx),
+		newNotificationsRetryCmd(ctx),
+		newNotificationsPurgeCmd(ctx),
+	)
+
+	return cmd
+}
+
+func newNotificationsListCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "list",
+		Short:   cmdAutheliaNotificationsListShort,
+		Long:    cmdAutheliaNotificationsListLong,
+		Example: cmdAutheliaNotificationsListExample,
+		RunE:    ctx.NotificationsListRunE,
+		Args:    cobra.NoArgs,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.Flags().String(cmdFlagNameStatus, model.QueuedNotificationStatusDead, "the status of the notifications to list, options are 'pending', 'sent', and 'dead'")
+	cmd.Flags().Int(cmdFlagNameLimit, 100, "the maximum number of notifications to list")
+	cmd.Flags().Int(cmdFlagNamePage, 1, "the page of notifications to list")
+
+	return cmd
+}
+
+func newNotificationsRetryCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "retry [id]",
+		Short:   cmdAutheliaNotificationsRetryShort,
+		Long:    cmdAutheliaNotificationsRetryLong,
+		Example: cmdAutheliaNotificationsRetryExample,
+		RunE:    ctx.NotificationsRetryRunE,
+		Args:    cobra.MaximumNArgs(1),
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.Flags().Bool(cmdFlagNameAll, false, "retries all dead notifications")
+
+	return cmd
+}
+
+func newNotificationsPurgeCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "purge",
+		Short:   cmdAutheliaNotificationsPurgeShort,
+		Long:    cmdAutheliaNotificationsPurgeLong,
+		Example: cmdAutheliaNotificationsPurgeExample,
+		RunE:    ctx.NotificationsPurgeRunE,
+		Args:    cobra.NoArgs,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.Flags().String(cmdFlagNameStatus, model.QueuedNotificationStatusSent, "the status of the notifications to purge, options are 'sent' and 'dead'")
+	cmd.Flags().String(cmdFlagNameOlderThan, "7d", "only purge notifications created longer ago than this duration in the duration common syntax")
+
+	return cmd
+}
+
+// NotificationsListRunE is the RunE for the authelia notifications list command.
+func (ctx *CmdCtx) NotificationsListRunE(cmd *cobra.Command, _ []string) (err error) {
+	defer func() {
+		_ = ctx.providers.StorageProvider.Close()
+	}()
+
+	if err = ctx.CheckSchemaVersion(); err != nil {
+		return storageWrapCheckSchemaErr(err)
+	}
+
+	var (
+		status      string
+		limit, page int
+	)
+
+	if status, err = cmd.Flags().GetString(cmdFlagNameStatus); err != nil {
+		return err
+	}
+
+	switch status {
+	case model.QueuedNotificationStatusPending, model.QueuedNotificationStatusSent, model.QueuedNotificationStatusDead:
+		break
+	default:
+		return fmt.Errorf("failed to list notifications: the status '%s' is not one of 'pending', 'sent', or 'dead'", status)
+	}
+
+	if limit, err = cmd.Flags().GetInt(cmdFlagNameLimit); err != nil {
+		return err
+	}
+
+	if page, err = cmd.Flags().GetInt(cmdFlagNamePage); err != nil {
+		return err
+	}
+
+	if limit < 1 || page < 1 {
+		return fmt.Errorf("failed to list notifications: the limit and page must both be above zero")
+	}
+
+	var notifications []model.QueuedNotification
+
+	if notifications, err = ctx.providers.StorageProvider.LoadQueuedNotifications(ctx, status, limit, page-1); err != nil && !errors.Is(err, storage.ErrNoQueuedNotifications) {
+		return fmt.Errorf("failed to list notifications: %w", err)
+	}
+
+	if len(notifications) == 0 {
+		fmt.Printf("No notifications with the status '%s' were found\n", status)
+
+		return nil
+	}
+
+	fmt.Printf("ID\tCreated\tNext Attempt\tAttempts\tRecipient\tTemplate\tLast Error\n")
+
+	for _, n := range notifications {
+		fmt.Printf("%d\t%s\t%s\t%d\t%s\t%s\t%s\n", n.ID, n.CreatedAt.Format(time.RFC3339), n.NextAttemptAt.Format(time.RFC3339), n.Attempts, n.Recipient, n.Template, n.LastError.String)
+	}
+
+	return nil
+}
+
+// NotificationsRetryRunE is the RunE for the authelia notifications retry command.
+func (ctx *CmdCtx) NotificationsRetryRunE(cmd *cobra.Command, args []string) (err error) {
+	defer func() {
+		_ = ctx.providers.StorageProvider.Close()
+	}()
+
+	if err = ctx.CheckSchemaVersion(); err != nil {
+		return storageWrapCheckSchemaErr(err)
+	}
+
+	var all bool
+
+	if all, err = cmd.Flags().GetBool(cmdFlagNameAll); err != nil {
+		return err
+	}
+
+	switch {
+	case all && len(args) != 0:
+		return fmt.Errorf("failed to retry notifications: the id argument and the --%s flag can't be used together", cmdFlagNameAll)
+	case all:
+		var affected int64
+
+		if affected, err = ctx.providers.StorageProvider.RetryQueuedNotifications(ctx, time.Now()); err != nil {
+			return fmt.Errorf("failed to retry notifications: %w", err)
+		}
+
+		fmt.Printf("Successfully scheduled %d dead notifications for delivery\n", affected)
+	case len(args) == 0:
+		return fmt.Errorf("failed to retry notifications: either the id argument or the --%s flag must be provided", cmdFlagNameAll)
+	default:
+		var id int
+
+		if id, err = strconv.Atoi(args[0]); err != nil {
+			return fmt.Errorf("failed to retry notification: the id '%s' is not a number", args[0])
+		}
+
+		if err = ctx.providers.StorageProvider.RetryQueuedNotification(ctx, id, time.Now()); err != nil {
+			if errors.Is(err, storage.ErrNoQueuedNotifications) {
+				return fmt.Errorf("failed to retry notification with id '%d': the notification doesn't exist or isn't dead", id)
+			}
+
+			return fmt.Errorf("failed to retry notification with id '%d': %w", id, err)
+		}
+
+		fmt.Printf("Successfully scheduled notification with id '%d' for delivery\n", id)
+	}
+
+	return nil
+}
+
+// NotificationsPurgeRunE is the RunE for the authelia notifications purge command.
+func (ctx *CmdCtx) NotificationsPurgeRunE(cmd *cobra.Command, _ []string) (err error) {
+	defer func() {
+		_ = ctx.providers.StorageProvider.Close()
+	}()
+
+	if err = ctx.CheckSchemaVersion(); err != nil {
+		return storageWrapCheckSchemaErr(err)
+	}
+
+	var (
+		status, value string
+		olderThan     time.Duration
+	)
+
+	if status, err = cmd.Flags().GetString(cmdFlagNameStatus); err != nil {
+		return err
+	}
+
+	switch status {
+	case model.QueuedNotificationStatusSent, model.QueuedNotificationStatusDead:
+		break
+	default:
+		return fmt.Errorf("failed to purge notifications: the status '%s' is not one of 'sent' or 'dead'", status)
+	}
+
+	if value, err = cmd.Flags().GetString(cmdFlagNameOlderThan); err != nil {
+		return err
+	}
+
+	if olderThan, err = utils.ParseDurationString(value); err != nil {
+		return fmt.Errorf("failed to parse duration string: %w", err)
+	}
+
+	var affected int64
+
+	if affected, err = ctx.providers.StorageProvider.PurgeQueuedNotifications(ctx, status, time.Now().Add(-olderThan)); err != nil {
+		return fmt.Errorf("failed to purge notifications: %w", err)
+	}
+
+	fmt.Printf("Successfully purged %d notifications with the status '%s'\n", affected, status)
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/authelia/authelia/v4/internal/clock"
+	"github.com/authelia/authelia/v4/internal/regulation"
+)
+
+func newRegulationCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "regulation",
+		Short:   cmdAutheliaRegulationShort,
+		Long:    cmdAutheliaRegulationLong,
+		Example: cmdAutheliaRegulationExample,
+		PersistentPreRunE: ctx.ChainRunE(
+			ctx.ConfigStorageCommandLineConfigRunE,
+			ctx.HelperConfigLoadRunE,
+			ctx.ConfigValidateStorageRunE,
+			ctx.LoadProvidersStorageRunE,
+		),
+		Args: cobra.NoArgs,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmdStoragePersistentFlags(cmd)
+
+	cmd.AddCommand(
+		newRegulationUnlockCmd(ctx),
+	)
+
+	return cmd
+}
+
+func newRegulationUnlockCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "unlock <username>",
+		Short:   cmdAutheliaRegulationUnlockShort,
+		Long:    cmdAutheliaRegulationUnlockLong,
+		Example: cmdAutheliaRegulationUnlockExample,
+		RunE:    ctx.RegulationUnlockRunE,
+		Args:    cobra.ExactArgs(1),
+
+		DisableAutoGenTag: true,
+	}
+
+	return cmd
+}
+
+// RegulationUnlockRunE is the RunE for the authelia regulation unlock command.
+func (ctx *CmdCtx) RegulationUnlockRunE(_ *cobra.Command, args []string) (err error) {
+	defer func() {
+		_ = ctx.providers.StorageProvider.Close()
+	}()
+
+	if err = ctx.CheckSchemaVersion(); err != nil {
+		return storageWrapCheckSchemaErr(err)
+	}
+
+	username := args[0]
+
+	regulator := regulation.NewRegulator(ctx.config.Regulation, ctx.providers.StorageProvider, clock.New())
+
+	var status *regulation.Status
+
+	if status, err = regulator.Status(ctx, username); err != nil {
+		return fmt.Errorf("failed to unlock user '%s': %w", username, err)
+	}
+
+	var lockout *regulation.Ban
+
+	// The lockout is either saved when the user was locked or determined from the bans of the user.
+	for i, ban := range status.Bans {
+		if ban.Source == regulation.BanSourceLockout {
+			lockout = &status.Bans[i]
+
+			break
+		}
+	}
+
+	if lockout == nil {
+		return fmt.Errorf("failed to unlock user '%s': the user is not currently locked", username)
+	}
+
+	if err = regulator.Unlock(ctx, username, regulation.UnlockMethodAdministrator); err != nil {
+		return fmt.Errorf("failed to unlock user '%s': %w", username, err)
+	}
+
+	fmt.Printf("Successfully unlocked user '%s' which was locked at %s\n", username, lockout.Time.Format(time.RFC3339))
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package commands
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/clock"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/regulation"
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+func TestRegulationUnlockRunE(t *testing.T) {
+	testCases := []struct {
+		name  string
+		setup func(t *testing.T, provider storage.Provider, now time.Time)
+		err   string
+	}{
+		{
+			"ShouldUnlockSavedLockout",
+			func(t *testing.T, provider storage.Provider, now time.Time) {
+				appendTestRegulationBans(t, provider, now)
+
+				require.NoError(t, provider.SaveRegulationLockout(context.Background(), model.RegulationLockout{LockedAt: now.Add(-time.Minute * 5), Username: "john", Bans: 2}))
+			},
+			"",
+		},
+		{
+			"ShouldUnlockUnsavedLockout",
+			func(t *testing.T, provider storage.Provider, now time.Time) {
+				appendTestRegulationBans(t, provider, now)
+			},
+			"",
+		},
+		{
+			"ShouldFailToUnlockUserNotLocked",
+			func(_ *testing.T, _ storage.Provider, _ time.Time) {},
+			"failed to unlock user 'john': the user is not currently locked",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := newTestRegulationConfig(t)
+			now := time.Now()
+
+			tc.setup(t, newTestRegulationStorageProvider(t, config), now)
+
+			ctx := NewCmdCtx()
+			ctx.config = config
+			ctx.providers.StorageProvider = newTestRegulationStorageProvider(t, config)
+
+			err := ctx.RegulationUnlockRunE(nil, []string{"john"})
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+
+			regulator := regulation.NewRegulator(config.Regulation, newTestRegulationStorageProvider(t, config), clock.New())
+
+			status, err := regulator.Status(context.Background(), "john")
+			require.NoError(t, err)
+
+			for _, ban := range status.Bans {
+				assert.NotEqual(t, regulation.BanSourceLockout, ban.Source)
+			}
+		})
+	}
+}
+
+// appendTestRegulationBans appends two bursts of failed authentication attempts which each result in a ban.
+func appendTestRegulationBans(t *testing.T, provider storage.Provider, now time.Time) {
+	for _, offset := range []time.Duration{time.Minute * 30, time.Minute * 10} {
+		for i := 0; i < 3; i++ {
+			require.NoError(t, provider.AppendAuthenticationLog(context.Background(), model.AuthenticationAttempt{
+				Time:     now.Add(-offset + time.Duration(i)*time.Second),
+				Username: "john",
+				Type:     regulation.AuthTypeTOTP,
+			}))
+		}
+	}
+}
+
+func newTestRegulationConfig(t *testing.T) *schema.Configuration {
+	return &schema.Configuration{
+		Regulation: schema.Regulation{
+			MaxRetries: 3,
+			FindTime:   time.Minute * 2,
+			BanTime:    time.Minute * 5,
+			Lockout: schema.RegulationLockout{
+				MaxBans: 2,
+				Window:  time.Hour * 24,
+			},
+		},
+		Storage: schema.Storage{
+			EncryptionKey: "a_not_so_secure_encryption_key",
+			Local:         &schema.StorageLocal{Path: filepath.Join(t.TempDir(), "db.sqlite3")},
+		},
+	}
+}
+
+func newTestRegulationStorageProvider(t *testing.T, config *schema.Configuration) storage.Provider {
+	provider := storage.NewSQLiteProvider(config)
+
+	require.NoError(t, provider.StartupCheck())
+
+	t.Cleanup(func() {
+		_ = provider.Close()
+	})
+
+	return provider
+}
@@ -0,0 +1,492 @@
+package commands
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/sirupsen/logrus"
+
+	"github.com/authelia/authelia/v4/internal/configuration"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/configuration/validator"
+	"github.com/authelia/authelia/v4/internal/logging"
+	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/notification"
+	"github.com/authelia/authelia/v4/internal/templates"
+)
+
+// NewConfigurationReloader returns a new ConfigurationReloader which reloads the configuration from the same sources
+// as the CmdCtx and applies the changes to the current configuration and providers of the CmdCtx.
+func NewConfigurationReloader(ctx *CmdCtx) (reloader *ConfigurationReloader) {
+	reloader = &ConfigurationReloader{
+		files:     ctx.cconfig.files,
+		filters:   ctx.cconfig.filters,
+		resolvers: ctx.cconfig.resolvers,
+		defaults:  ctx.cconfig.defaults,
+		trusted:   ctx.trusted,
+		watch:     ctx.config.Reload.Watch,
+		poll:      ctx.config.Reload.PollInterval,
+		log:       ctx.log.WithField(logFieldService, serviceNameConfiguration),
+	}
+
+	providers := ctx.providers
+
+	providers.ConfigurationReloader = reloader
+
+	reloader.current.Store(&configurationReloaderState{config: ctx.config, providers: &providers})
+
+	return reloader
+}
+
+// ConfigurationReloader reloads the configuration while Authelia is running. The changes to the subsystems which can
+// safely be swapped while running are applied and all other changes are reported as requiring a restart.
+type ConfigurationReloader struct {
+	files     []string
+	filters   []string
+	resolvers []string
+	defaults  configuration.Source
+	trusted   *x509.CertPool
+	watch     bool
+	poll      time.Duration
+
+	current atomic.Pointer[configurationReloaderState]
+
+	mu  sync.Mutex
+	log *logrus.Entry
+}
+
+type configurationReloaderState struct {
+	config    *schema.Configuration
+	providers *middlewares.Providers
+}
+
+// Current returns the schema.Configuration and middlewares.Providers which are currently in use.
+func (r *ConfigurationReloader) Current() (config *schema.Configuration, providers *middlewares.Providers) {
+	state := r.current.Load()
+
+	return state.config, state.providers
+}
+
+// Run listens for the SIGHUP signal and if enabled watches the configuration files and polls the remote configuration
+// sources for changes, reloading the configuration when any of these occur until the context is done.
+func (r *ConfigurationReloader) Run(ctx context.Context) (err error) {
+	hup := make(chan os.Signal, 1)
+
+	signal.Notify(hup, syscall.SIGHUP)
+
+	defer signal.Stop(hup)
+
+	var (
+		watcher *fsnotify.Watcher
+		events  <-chan fsnotify.Event
+		errs    <-chan error
+	)
+
+	if r.watch {
+		if watcher, err = r.newWatcher(); err != nil {
+			return err
+		}
+
+		defer watcher.Close()
+
+		events, errs = watcher.Events, watcher.Errors
+	}
+
+	var (
+		remotes []configuration.RemoteSource
+		poll    <-chan time.Time
+	)
+
+	if r.watch {
+		if remotes = r.newRemoteSources(ctx); len(remotes) != 0 {
+			ticker := time.NewTicker(r.poll)
+
+			defer ticker.Stop()
+
+			poll = ticker.C
+		}
+	}
+
+	debounce := time.NewTimer(time.Hour)
+
+	debounce.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-hup:
+			r.reload(configurationReloadTriggerSignal)
+		case event, ok := <-events:
+			if !ok {
+				events = nil
+
+				continue
+			}
+
+			if !r.isWatched(event) {
+				continue
+			}
+
+			r.log.WithFields(map[string]any{logFieldFile: event.Name, logFieldOP: event.Op}).Debug("Configuration file modification was detected")
+
+			debounce.Reset(configurationReloadDebounce)
+		case <-debounce.C:
+			r.reload(configurationReloadTriggerWatch)
+		case <-poll:
+			if r.isRemoteChanged(ctx, remotes) {
+				r.reload(configurationReloadTriggerPoll)
+			}
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+
+				continue
+			}
+
+			r.log.WithError(err).Error("Error while watching configuration files for changes")
+		}
+	}
+}
+
+// ReloadConfiguration reloads the configuration from the sources, validates it, and applies the changes which can be
+// applied without a restart. If the configuration is not valid or any of the changes can't be applied then none of
+// the changes are applied.
+func (r *ConfigurationReloader) ReloadConfiguration(trigger string) (result *middlewares.ConfigurationReloadResult, err error) {
+	r.mu.Lock()
+
+	defer r.mu.Unlock()
+
+	log := r.log.WithField("trigger", trigger)
+
+	log.Debug("Configuration reload initiated")
+
+	var config *schema.Configuration
+
+	if config, err = r.load(log); err != nil {
+		return nil, err
+	}
+
+	current := r.current.Load()
+
+	changes := configuration.Diff(current.config, config)
+
+	result = &middlewares.ConfigurationReloadResult{Applied: []string{}, Restart: []string{}}
+
+	if len(changes) == 0 {
+		log.Debug("Configuration reload found no changes")
+
+		return result, nil
+	}
+
+	applied, providers := *current.config, *current.providers
+
+	var pending []configuration.Change
+
+	for _, change := range changes {
+		if applyConfigurationChange(&applied, config, change) {
+			pending = append(pending, change)
+
+			continue
+		}
+
+		result.Restart = append(result.Restart, change.Key)
+
+		log.WithFields(map[string]any{"key": change.Key, "before": change.Before, "after": change.After}).Warn("Configuration change requires a restart to be applied")
+	}
+
+	if len(pending) == 0 {
+		return result, nil
+	}
+
+	if err = r.apply(&applied, &providers, pending); err != nil {
+		return nil, err
+	}
+
+	r.current.Store(&configurationReloaderState{config: &applied, providers: &providers})
+
+	for _, change := range pending {
+		result.Applied = append(result.Applied, change.Key)
+
+		log.WithFields(map[string]any{"key": change.Key, "before": change.Before, "after": change.After}).Info("Configuration change applied")
+	}
+
+	return result, nil
+}
+
+func (r *ConfigurationReloader) reload(trigger string) {
+	switch result, err := r.ReloadConfiguration(trigger); {
+	case err != nil:
+		r.log.WithError(err).WithField("trigger", trigger).Error("Error occurred reloading the configuration, the existing configuration is still in use")
+	case len(result.Applied) == 0 && len(result.Restart) == 0:
+		r.log.WithField("trigger", trigger).Debug("Configuration reload completed with no changes")
+	default:
+		r.log.WithFields(map[string]any{"trigger": trigger, "applied": len(result.Applied), "restart": len(result.Restart)}).Info("Configuration reloaded successfully")
+	}
+}
+
+// load the configuration from the sources and validate it.
+func (r *ConfigurationReloader) load(log *logrus.Entry) (config *schema.Configuration, err error) {
+	var filters []configuration.BytesFilter
+
+	if filters, err = configuration.NewFileFilters(r.filters); err != nil {
+		return nil, fmt.Errorf("error occurred loading the configuration filters: %w", err)
+	}
+
+	var resolvers []configuration.SecretResolver
+
+	if resolvers, err = configuration.NewSecretResolvers(r.resolvers); err != nil {
+		return nil, fmt.Errorf("error occurred loading the secret resolvers: %w", err)
+	}
+
+	val := schema.NewStructValidator()
+
+	sources := configuration.NewDefaultSourcesWithDefaults(r.files, filters, configuration.DefaultEnvPrefix, configuration.DefaultEnvDelimiter, r.defaults, configuration.NewSecretReferencesSource(resolvers...))
+
+	config = &schema.Configuration{}
+
+	var keys []string
+
+	if keys, err = configuration.LoadAdvanced(val, "", config, sources...); err != nil {
+		return nil, fmt.Errorf("error occurred loading the configuration: %w", err)
+	}
+
+	validator.ValidateKeys(keys, configuration.GetMultiKeyMappedDeprecationKeys(), configuration.DefaultEnvPrefix, val)
+
+	validator.ValidateConfiguration(config, val, validator.WithTLSConfig(&tls.Config{
+		RootCAs:    r.trusted,
+		MinVersion: tls.VersionTLS12,
+		MaxVersion: tls.VersionTLS13,
+	}))
+
+	for _, warning := range val.Warnings() {
+		log.Warnf("Configuration: %+v", warning)
+	}
+
+	if errs := val.Errors(); len(errs) != 0 {
+		for _, err = range errs {
+			log.Errorf("Configuration: %+v", err)
+		}
+
+		return nil, fmt.Errorf("the configuration has %d errors", len(errs))
+	}
+
+	return config, nil
+}
+
+// apply the changes which have been applied to the configuration to the providers. All operations which may fail are
+// performed before any of the providers are modified.
+func (r *ConfigurationReloader) apply(config *schema.Configuration, providers *middlewares.Providers, changes []configuration.Change) (err error) {
+	var notifier notification.Notifier
+
+	if hasConfigurationChange(changes, keyNotifierSMTP, keyNotifierFileSystem, keyNotifierWebhook) {
+		if notifier = getNotifier(&config.Notifier, r.trusted); notifier == nil {
+			return errors.New("error occurred reloading the notifier: no notifier is configured")
+		}
+
+		if !config.Notifier.DisableStartupCheck {
+			if err = notifier.StartupCheck(); err != nil {
+				return fmt.Errorf("error occurred performing the notifier startup check: %w", err)
+			}
+		}
+	}
+
+	if hasConfigurationChange(changes, keyNotifierTemplatePath) && providers.Templates != nil {
+		if err = providers.Templates.Reload(templates.Config{EmailTemplatesPath: config.Notifier.TemplatePath}); err != nil {
+			return fmt.Errorf("error occurred reloading the templates: %w", err)
+		}
+	}
+
+	if notifier != nil {
+		if queue, ok := providers.Notifier.(*notification.QueueNotifier); ok {
+			queue.SetNotifier(notifier)
+		} else {
+			providers.Notifier = notifier
+		}
+	}
+
+	if hasConfigurationChange(changes, keyAccessControl, keyIdentityProvidersOIDCClients) && providers.Authorizer != nil {
+		providers.Authorizer.Reload(config)
+	}
+
+	if hasConfigurationChange(changes, keyIdentityProvidersOIDCClients) && providers.OpenIDConnect != nil {
+		providers.OpenIDConnect.ReloadClients(config.IdentityProviders.OIDC)
+	}
+
+	if hasConfigurationChange(changes, keyPasswordPolicy) {
+		providers.PasswordPolicy = middlewares.NewPasswordPolicyProvider(config.PasswordPolicy)
+	}
+
+	if hasConfigurationChange(changes, keyLogLevel) {
+		logging.SetLevel(config.Log.Level)
+	}
+
+	return nil
+}
+
+func (r *ConfigurationReloader) newWatcher() (watcher *fsnotify.Watcher, err error) {
+	if watcher, err = fsnotify.NewWatcher(); err != nil {
+		return nil, err
+	}
+
+	directories := map[string]struct{}{}
+
+	for _, path := range r.files {
+		if configuration.IsRemoteSourcePath(path) {
+			continue
+		}
+
+		var info os.FileInfo
+
+		if info, err = os.Stat(path); err != nil {
+			_ = watcher.Close()
+
+			return nil, fmt.Errorf("error stating configuration path '%s': %w", path, err)
+		}
+
+		directory := path
+
+		if !info.IsDir() {
+			directory = filepath.Dir(path)
+		}
+
+		if _, ok := directories[directory]; ok {
+			continue
+		}
+
+		if err = watcher.Add(directory); err != nil {
+			_ = watcher.Close()
+
+			return nil, fmt.Errorf("failed to add path '%s' to watch list: %w", directory, err)
+		}
+
+		directories[directory] = struct{}{}
+
+		r.log.WithField(logFieldFile, path).Info("Watching configuration for changes")
+	}
+
+	return watcher, nil
+}
+
+// newRemoteSources returns a configuration.RemoteSource for each of the remote configuration sources which is only
+// used to detect changes. Each source is checked once to establish the initial revision.
+func (r *ConfigurationReloader) newRemoteSources(ctx context.Context) (remotes []configuration.RemoteSource) {
+	opts := configuration.NewRemoteSourceOptionsFromEnvironment()
+
+	for _, path := range r.files {
+		remote := configuration.NewRemoteSource(path, opts)
+		if remote == nil {
+			continue
+		}
+
+		if _, err := remote.Changed(ctx); err != nil {
+			r.log.WithError(err).WithField(logFieldSource, remote.Name()).Error("Error checking remote configuration source for changes")
+		}
+
+		r.log.WithField(logFieldSource, remote.Name()).Info("Polling remote configuration source for changes")
+
+		remotes = append(remotes, remote)
+	}
+
+	return remotes
+}
+
+// isRemoteChanged returns true if any of the remote configuration sources have changed. All sources are checked so
+// the revision of each source is updated.
+func (r *ConfigurationReloader) isRemoteChanged(ctx context.Context, remotes []configuration.RemoteSource) (changed bool) {
+	for _, remote := range remotes {
+		switch c, err := remote.Changed(ctx); {
+		case err != nil:
+			r.log.WithError(err).WithField(logFieldSource, remote.Name()).Error("Error checking remote configuration source for changes")
+		case c:
+			r.log.WithField(logFieldSource, remote.Name()).Debug("Remote configuration source modification was detected")
+
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+// isWatched returns true if the event relates to one of the configuration files or a YAML file within one of the
+// configuration directories.
+func (r *ConfigurationReloader) isWatched(event fsnotify.Event) bool {
+	if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) && !event.Has(fsnotify.Rename) {
+		return false
+	}
+
+	name := filepath.Clean(event.Name)
+
+	for _, path := range r.files {
+		path = filepath.Clean(path)
+
+		if name == path {
+			return true
+		}
+
+		switch filepath.Ext(name) {
+		case extYML, extYAML:
+			if filepath.Dir(name) == path {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// applyConfigurationChange applies a single change from the loaded configuration to the applied configuration if the
+// change can be applied without a restart, and returns true if it was applied.
+func applyConfigurationChange(applied, loaded *schema.Configuration, change configuration.Change) bool {
+	switch {
+	case change.HasPrefix(keyLogLevel):
+		applied.Log.Level = loaded.Log.Level
+	case change.HasPrefix(keyAccessControl):
+		applied.AccessControl = loaded.AccessControl
+	case change.HasPrefix(keyPasswordPolicy):
+		applied.PasswordPolicy = loaded.PasswordPolicy
+	case change.HasPrefix(keyIdentityProvidersOIDCClients):
+		if applied.IdentityProviders.OIDC == nil || loaded.IdentityProviders.OIDC == nil {
+			return false
+		}
+
+		oidc := *applied.IdentityProviders.OIDC
+
+		oidc.Clients = loaded.IdentityProviders.OIDC.Clients
+
+		applied.IdentityProviders.OIDC = &oidc
+	case change.HasPrefix(keyNotifierTemplatePath):
+		applied.Notifier.TemplatePath = loaded.Notifier.TemplatePath
+	case change.HasPrefix(keyNotifierEvents):
+		applied.Notifier.Events = loaded.Notifier.Events
+	case change.HasPrefix(keyNotifierDisableStartupCheck):
+		applied.Notifier.DisableStartupCheck = loaded.Notifier.DisableStartupCheck
+	case change.HasPrefix(keyNotifierSMTP, keyNotifierFileSystem, keyNotifierWebhook):
+		applied.Notifier.SMTP, applied.Notifier.FileSystem, applied.Notifier.Webhook = loaded.Notifier.SMTP, loaded.Notifier.FileSystem, loaded.Notifier.Webhook
+	default:
+		return false
+	}
+
+	return true
+}
+
+func hasConfigurationChange(changes []configuration.Change, keys ...string) bool {
+	for _, change := range changes {
+		if change.HasPrefix(keys...) {
+			return true
+		}
+	}
+
+	return false
+}
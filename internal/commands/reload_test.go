@@ -0,0 +1,193 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/logging"
+	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/notification"
+)
+
+func TestConfigurationReloaderReloadConfiguration(t *testing.T) {
+	testCases := []struct {
+		name     string
+		policy   string
+		notifier string
+		issuer   string
+		expected *middlewares.ConfigurationReloadResult
+		err      string
+		check    func(t *testing.T, before, after *schema.Configuration, providersBefore, providersAfter *middlewares.Providers)
+	}{
+		{
+			"ShouldReportNoChanges",
+			"one_factor",
+			"notification.txt",
+			"authelia.com",
+			&middlewares.ConfigurationReloadResult{Applied: []string{}, Restart: []string{}},
+			"",
+			func(t *testing.T, before, after *schema.Configuration, providersBefore, providersAfter *middlewares.Providers) {
+				assert.Same(t, before, after)
+				assert.Same(t, providersBefore, providersAfter)
+			},
+		},
+		{
+			"ShouldNotApplyInvalidConfiguration",
+			"deny",
+			"notification.alt.txt",
+			"example.com",
+			nil,
+			"the configuration has 1 errors",
+			func(t *testing.T, before, after *schema.Configuration, providersBefore, providersAfter *middlewares.Providers) {
+				assert.Same(t, before, after)
+				assert.Same(t, providersBefore, providersAfter)
+				assert.Equal(t, "one_factor", after.AccessControl.DefaultPolicy)
+				assert.False(t, providersAfter.Authorizer.IsSecondFactorEnabled())
+			},
+		},
+		{
+			"ShouldApplyAccessControlChanges",
+			"two_factor",
+			"notification.txt",
+			"authelia.com",
+			&middlewares.ConfigurationReloadResult{Applied: []string{"access_control.default_policy"}, Restart: []string{}},
+			"",
+			func(t *testing.T, before, after *schema.Configuration, providersBefore, providersAfter *middlewares.Providers) {
+				assert.Equal(t, "one_factor", before.AccessControl.DefaultPolicy)
+				assert.Equal(t, "two_factor", after.AccessControl.DefaultPolicy)
+				assert.Same(t, providersBefore.Authorizer, providersAfter.Authorizer)
+				assert.True(t, providersAfter.Authorizer.IsSecondFactorEnabled())
+				assert.Same(t, providersBefore.Notifier, providersAfter.Notifier)
+			},
+		},
+		{
+			"ShouldApplyNotifierChanges",
+			"one_factor",
+			"notification.alt.txt",
+			"authelia.com",
+			&middlewares.ConfigurationReloadResult{Applied: []string{"notifier.filesystem.filename"}, Restart: []string{}},
+			"",
+			func(t *testing.T, before, after *schema.Configuration, providersBefore, providersAfter *middlewares.Providers) {
+				assert.Equal(t, "notification.txt", filepath.Base(before.Notifier.FileSystem.Filename))
+				assert.Equal(t, "notification.alt.txt", filepath.Base(after.Notifier.FileSystem.Filename))
+				assert.IsType(t, &notification.FileNotifier{}, providersAfter.Notifier)
+				assert.NotSame(t, providersBefore.Notifier, providersAfter.Notifier)
+				assert.FileExists(t, after.Notifier.FileSystem.Filename)
+			},
+		},
+		{
+			"ShouldReportRestartOnlyChanges",
+			"one_factor",
+			"notification.txt",
+			"example.com",
+			&middlewares.ConfigurationReloadResult{Applied: []string{}, Restart: []string{"totp.issuer"}},
+			"",
+			func(t *testing.T, before, after *schema.Configuration, providersBefore, providersAfter *middlewares.Providers) {
+				assert.Same(t, before, after)
+				assert.Same(t, providersBefore, providersAfter)
+				assert.Equal(t, "authelia.com", after.TOTP.Issuer)
+			},
+		},
+		{
+			"ShouldApplyChangesAndReportRestartOnlyChanges",
+			"two_factor",
+			"notification.txt",
+			"example.com",
+			&middlewares.ConfigurationReloadResult{Applied: []string{"access_control.default_policy"}, Restart: []string{"totp.issuer"}},
+			"",
+			func(t *testing.T, before, after *schema.Configuration, providersBefore, providersAfter *middlewares.Providers) {
+				assert.Equal(t, "two_factor", after.AccessControl.DefaultPolicy)
+				assert.Equal(t, "authelia.com", after.TOTP.Issuer)
+				assert.True(t, providersAfter.Authorizer.IsSecondFactorEnabled())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "configuration.yml")
+
+			writeTestReloadConfiguration(t, path, dir, "one_factor", "notification.txt", "authelia.com")
+
+			reloader := newTestConfigurationReloader(t, path)
+
+			before, providersBefore := reloader.Current()
+
+			writeTestReloadConfiguration(t, path, dir, tc.policy, tc.notifier, tc.issuer)
+
+			result, err := reloader.ReloadConfiguration(configurationReloadTriggerSignal)
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+
+			assert.Equal(t, tc.expected, result)
+
+			after, providersAfter := reloader.Current()
+
+			tc.check(t, before, after, providersBefore, providersAfter)
+		})
+	}
+}
+
+func newTestConfigurationReloader(t *testing.T, path string) (reloader *ConfigurationReloader) {
+	reloader = &ConfigurationReloader{
+		files: []string{path},
+		log:   logrus.NewEntry(logging.Logger()),
+	}
+
+	config, err := reloader.load(reloader.log)
+
+	require.NoError(t, err)
+
+	providers := &middlewares.Providers{
+		Authorizer:            authorization.NewAuthorizer(config),
+		Notifier:              notification.NewFileNotifier(*config.Notifier.FileSystem),
+		PasswordPolicy:        middlewares.NewPasswordPolicyProvider(config.PasswordPolicy),
+		ConfigurationReloader: reloader,
+	}
+
+	reloader.current.Store(&configurationReloaderState{config: config, providers: providers})
+
+	return reloader
+}
+
+func writeTestReloadConfiguration(t *testing.T, path, dir, policy, notifier, issuer string) {
+	require.NoError(t, os.WriteFile(path, []byte(fmt.Sprintf(testReloadConfiguration, issuer, filepath.Join(dir, "users.yml"), policy, filepath.Join(dir, "db.sqlite3"), filepath.Join(dir, notifier))), 0600))
+}
+
+const testReloadConfiguration = `
+totp:
+  issuer: '%s'
+identity_validation:
+  reset_password:
+    jwt_secret: 'a_very_important_secret'
+authentication_backend:
+  file:
+    path: '%s'
+access_control:
+  default_policy: '%s'
+session:
+  secret: 'a_very_important_secret'
+  cookies:
+    - domain: 'example.com'
+      authelia_url: 'https://auth.example.com'
+storage:
+  encryption_key: 'a_not_so_secure_encryption_key'
+  local:
+    path: '%s'
+notifier:
+  filesystem:
+    filename: '%s'
+`
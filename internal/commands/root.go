@@ -40,17 +40,21 @@ func NewRootCmd() (cmd *cobra.Command) {
 
 	cmd.PersistentFlags().StringSliceP(cmdFlagNameConfig, "c", []string{"configuration.yml"}, "configuration files or directories to load, for more information run 'authelia -h authelia config'")
 	cmd.PersistentFlags().StringSlice(cmdFlagNameConfigExpFilters, nil, "list of filters to apply to all configuration files, for more information run 'authelia -h authelia filters'")
+	cmd.PersistentFlags().StringSlice(cmdFlagNameConfigExpSecretResolvers, nil, "list of secret resolvers used to resolve secret references, for more information run 'authelia -h authelia secret-resolvers'")
 
 	cmd.AddCommand(
 		newAccessControlCommand(ctx),
 		newBuildInfoCmd(ctx),
 		newCryptoCmd(ctx),
 		newStorageCmd(ctx),
+		newRegulationCmd(ctx),
+		newNotificationsCmd(ctx),
 		newConfigCmd(ctx),
 		newConfigValidateLegacyCmd(ctx),
 
 		newHelpTopic("config", "Help for the config file/directory paths", helpTopicConfig),
 		newHelpTopic("filters", "help topic for the config filters", helpTopicConfigFilters),
+		newHelpTopic("secret-resolvers", "help topic for the config secret resolvers", helpTopicConfigSecretResolvers),
 		newHelpTopic("time-layouts", "help topic for the various time layouts", helpTopicTimeLayouts),
 		newHelpTopic("hash-password", "help topic for hashing passwords", helpTopicHashPassword),
 	)
@@ -87,6 +91,8 @@ func (ctx *CmdCtx) RootRunE(_ *cobra.Command, _ []string) (err error) {
 
 	doStartupChecks(ctx)
 
+	ctx.providers.ConfigurationReloader = NewConfigurationReloader(ctx)
+
 	ctx.cconfig = nil
 
 	ctx.log.Trace("Starting Services")
@@ -146,6 +152,16 @@ func doStartupChecks(ctx *CmdCtx) {
 		ctx.log.WithFields(map[string]any{logFieldProvider: providerNameNTP}).Trace("Startup Check Completed Successfully")
 	}
 
+	ctx.log.WithFields(map[string]any{logFieldProvider: providerNameWebAuthnMetadata}).Trace("Performing Startup Check")
+
+	if err = doStartupCheck(ctx, providerNameWebAuthnMetadata, ctx.providers.WebAuthnMetadata, ctx.providers.WebAuthnMetadata == nil); err != nil {
+		ctx.log.WithError(err).WithField(logFieldProvider, providerNameWebAuthnMetadata).Error(logMessageStartupCheckError)
+
+		failures = append(failures, providerNameWebAuthnMetadata)
+	} else {
+		ctx.log.WithFields(map[string]any{logFieldProvider: providerNameWebAuthnMetadata}).Trace("Startup Check Completed Successfully")
+	}
+
 	if len(failures) != 0 {
 		ctx.log.WithField("providers", failures).Fatalf("One or more providers had fatal failures performing startup checks, for more detail check the error level logs")
 	}
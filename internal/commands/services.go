@@ -18,7 +18,9 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/notification"
 	"github.com/authelia/authelia/v4/internal/server"
+	"github.com/authelia/authelia/v4/internal/storage"
 )
 
 // NewServerService creates a new ServerService with the appropriate logger etc.
@@ -83,6 +85,25 @@ func NewFileWatcherService(name, path string, reload ProviderReload, log *logrus
 	return service, nil
 }
 
+// NewWorkerService creates a new WorkerService with the appropriate logger etc.
+func NewWorkerService(name string, worker Worker, log *logrus.Logger) (service *WorkerService) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &WorkerService{
+		name:   name,
+		worker: worker,
+		ctx:    ctx,
+		cancel: cancel,
+		done:   make(chan struct{}),
+		log:    log.WithFields(map[string]any{logFieldService: serviceTypeWorker, serviceTypeWorker: name}),
+	}
+}
+
+// Worker represents the required methods to support running a background worker.
+type Worker interface {
+	Run(ctx context.Context) (err error)
+}
+
 // ProviderReload represents the required methods to support reloading a provider.
 type ProviderReload interface {
 	Reload() (reloaded bool, err error)
@@ -246,6 +267,61 @@ func (service *FileWatcherService) Log() *logrus.Entry {
 	return service.log
 }
 
+// WorkerService is a Service which runs a background worker.
+type WorkerService struct {
+	name   string
+	worker Worker
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	log *logrus.Entry
+}
+
+// ServiceType returns the service type for this service, which is always 'worker'.
+func (service *WorkerService) ServiceType() string {
+	return serviceTypeWorker
+}
+
+// ServiceName returns the individual name for this service.
+func (service *WorkerService) ServiceName() string {
+	return service.name
+}
+
+// Run the WorkerService.
+func (service *WorkerService) Run() (err error) {
+	defer close(service.done)
+
+	defer func() {
+		if r := recover(); r != nil {
+			service.log.WithError(recoverErr(r)).Error("Critical error caught (recovered)")
+		}
+	}()
+
+	service.log.Info("Worker started")
+
+	if err = service.worker.Run(service.ctx); err != nil {
+		service.log.WithError(err).Error("Worker returned error")
+
+		return err
+	}
+
+	return nil
+}
+
+// Shutdown the WorkerService waiting for the worker to complete any in-flight work.
+func (service *WorkerService) Shutdown() {
+	service.cancel()
+
+	<-service.done
+}
+
+// Log returns the *logrus.Entry of the WorkerService.
+func (service *WorkerService) Log() *logrus.Entry {
+	return service.log
+}
+
 func svcSvrMainFunc(ctx *CmdCtx) (service Service) {
 	switch svr, listener, paths, isTLS, err := server.CreateDefaultServer(ctx.config, ctx.providers); {
 	case err != nil:
@@ -286,6 +362,38 @@ func svcWatcherUsersFunc(ctx *CmdCtx) (service Service) {
 	return service
 }
 
+func svcWorkerNotificationsFunc(ctx *CmdCtx) (service Service) {
+	if queue, ok := ctx.providers.Notifier.(*notification.QueueNotifier); ok {
+		service = NewWorkerService("notifications", queue, ctx.log)
+	}
+
+	return service
+}
+
+func svcWorkerRetentionFunc(ctx *CmdCtx) (service Service) {
+	if !ctx.config.Storage.Retention.Disable && ctx.providers.StorageProvider != nil {
+		service = NewWorkerService("retention", storage.NewRetentionJanitor(&ctx.config.Storage.Retention, ctx.providers.StorageProvider, ctx.providers.Metrics), ctx.log)
+	}
+
+	return service
+}
+
+func svcWorkerReplicasFunc(ctx *CmdCtx) (service Service) {
+	if provider, ok := ctx.providers.StorageProvider.(storage.ReplicatedProvider); ok && provider.Replicas() != nil {
+		service = NewWorkerService("replicas", provider.Replicas(), ctx.log)
+	}
+
+	return service
+}
+
+func svcWorkerConfigurationFunc(ctx *CmdCtx) (service Service) {
+	if reloader, ok := ctx.providers.ConfigurationReloader.(*ConfigurationReloader); ok {
+		service = NewWorkerService(serviceNameConfiguration, reloader, ctx.log)
+	}
+
+	return service
+}
+
 func connectionType(isTLS bool) string {
 	if isTLS {
 		return "TLS"
@@ -314,6 +422,10 @@ func servicesRun(ctx *CmdCtx) {
 	for _, serviceFunc := range []func(ctx *CmdCtx) Service{
 		svcSvrMainFunc, svcSvrMetricsFunc,
 		svcWatcherUsersFunc,
+		svcWorkerNotificationsFunc,
+		svcWorkerRetentionFunc,
+		svcWorkerReplicasFunc,
+		svcWorkerConfigurationFunc,
 	} {
 		if service := serviceFunc(ctx); service != nil {
 			service.Log().Trace("Service Loaded")
@@ -26,6 +26,24 @@ func newStorageCmd(ctx *CmdCtx) (cmd *cobra.Command) {
 		DisableAutoGenTag: true,
 	}
 
+	cmdStoragePersistentFlags(cmd)
+
+	cmd.AddCommand(
+		newStorageMigrateCmd(ctx),
+		newStorageSchemaInfoCmd(ctx),
+		newStorageEncryptionCmd(ctx),
+		newStorageUserCmd(ctx),
+		newStorageRegulationCmd(ctx),
+		newStorageBackupCmd(ctx),
+		newStorageRestoreCmd(ctx),
+		newStorageMigrateBackendCmd(ctx),
+		newStoragePruneCmd(ctx),
+	)
+
+	return cmd
+}
+
+func cmdStoragePersistentFlags(cmd *cobra.Command) {
 	cmd.PersistentFlags().String(cmdFlagNameEncryptionKey, "", "the storage encryption key to use")
 
 	cmd.PersistentFlags().String(cmdFlagNameSQLite3Path, "", "the SQLite database path")
@@ -46,13 +64,76 @@ func newStorageCmd(ctx *CmdCtx) (cmd *cobra.Command) {
 	cmd.PersistentFlags().String("postgres.ssl.root_certificate", "", "the PostgreSQL ssl root certificate file location")
 	cmd.PersistentFlags().String("postgres.ssl.certificate", "", "the PostgreSQL ssl certificate file location")
 	cmd.PersistentFlags().String("postgres.ssl.key", "", "the PostgreSQL ssl key file location")
+}
 
-	cmd.AddCommand(
-		newStorageMigrateCmd(ctx),
-		newStorageSchemaInfoCmd(ctx),
-		newStorageEncryptionCmd(ctx),
-		newStorageUserCmd(ctx),
-	)
+func newStorageBackupCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "backup",
+		Short:   cmdAutheliaStorageBackupShort,
+		Long:    cmdAutheliaStorageBackupLong,
+		Example: cmdAutheliaStorageBackupExample,
+		RunE:    ctx.StorageBackupRunE,
+		Args:    cobra.NoArgs,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.Flags().StringP(cmdFlagNameFile, "f", "authelia.backup.json.gz", "the file name for the backup archive")
+
+	return cmd
+}
+
+func newStorageRestoreCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "restore <file>",
+		Short:   cmdAutheliaStorageRestoreShort,
+		Long:    cmdAutheliaStorageRestoreLong,
+		Example: cmdAutheliaStorageRestoreExample,
+		RunE:    ctx.StorageRestoreRunE,
+		Args:    cobra.ExactArgs(1),
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.Flags().Bool(cmdFlagNameForce, false, "deletes any existing data in the storage before restoring the backup")
+
+	return cmd
+}
+
+func newStorageMigrateBackendCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "migrate-backend",
+		Short:   cmdAutheliaStorageMigrateBackendShort,
+		Long:    cmdAutheliaStorageMigrateBackendLong,
+		Example: cmdAutheliaStorageMigrateBackendExample,
+		RunE:    ctx.StorageMigrateBackendRunE,
+		Args:    cobra.NoArgs,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.Flags().String(cmdFlagNameFrom, "", fmt.Sprintf("the storage backend to copy the data from, options are %s", strings.Join(storageBackends, ", ")))
+	cmd.Flags().String(cmdFlagNameTo, "", fmt.Sprintf("the storage backend to copy the data to, options are %s", strings.Join(storageBackends, ", ")))
+	cmd.Flags().Bool(cmdFlagNameForce, false, "deletes any existing data in the destination storage backend before copying the data")
+
+	_ = cmd.MarkFlagRequired(cmdFlagNameTo)
+
+	return cmd
+}
+
+func newStoragePruneCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "prune",
+		Short:   cmdAutheliaStoragePruneShort,
+		Long:    cmdAutheliaStoragePruneLong,
+		Example: cmdAutheliaStoragePruneExample,
+		RunE:    ctx.StoragePruneRunE,
+		Args:    cobra.NoArgs,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.Flags().Bool(cmdFlagNameDryRun, false, "reports the number of rows which would be pruned without deleting them")
 
 	return cmd
 }
@@ -71,6 +152,7 @@ func newStorageEncryptionCmd(ctx *CmdCtx) (cmd *cobra.Command) {
 	cmd.AddCommand(
 		newStorageEncryptionChangeKeyCmd(ctx),
 		newStorageEncryptionCheckCmd(ctx),
+		newStorageEncryptionRotateKEKCmd(ctx),
 	)
 
 	return cmd
@@ -110,6 +192,21 @@ func newStorageEncryptionChangeKeyCmd(ctx *CmdCtx) (cmd *cobra.Command) {
 	return cmd
 }
 
+func newStorageEncryptionRotateKEKCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "rotate-kek",
+		Short:   cmdAutheliaStorageEncryptionRotateKEKShort,
+		Long:    cmdAutheliaStorageEncryptionRotateKEKLong,
+		Example: cmdAutheliaStorageEncryptionRotateKEKExample,
+		RunE:    ctx.StorageSchemaEncryptionRotateKEKRunE,
+		Args:    cobra.NoArgs,
+
+		DisableAutoGenTag: true,
+	}
+
+	return cmd
+}
+
 func newStorageUserCmd(ctx *CmdCtx) (cmd *cobra.Command) {
 	cmd = &cobra.Command{
 		Use:     "user",
@@ -125,6 +222,7 @@ func newStorageUserCmd(ctx *CmdCtx) (cmd *cobra.Command) {
 		newStorageUserIdentifiersCmd(ctx),
 		newStorageUserTOTPCmd(ctx),
 		newStorageUserWebAuthnCmd(ctx),
+		newStorageUserTrustedDevicesCmd(ctx),
 	)
 
 	return cmd
@@ -254,6 +352,9 @@ func newStorageUserWebAuthnImportCmd(ctx *CmdCtx) (cmd *cobra.Command) {
 		DisableAutoGenTag: true,
 	}
 
+	cmd.Flags().Bool(cmdFlagNameDryRun, false, "validates the file and reports the credentials which would be imported without saving anything to the database")
+	cmd.Flags().String(cmdFlagNameUsernameMap, "", "path to a CSV file which maps the usernames in the import file in the first column to the usernames in Authelia in the second column")
+
 	return cmd
 }
 
@@ -308,6 +409,57 @@ func newStorageUserWebAuthnDeleteCmd(ctx *CmdCtx) (cmd *cobra.Command) {
 	return cmd
 }
 
+func newStorageUserTrustedDevicesCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "trusted-devices",
+		Short:   cmdAutheliaStorageUserTrustedDevicesShort,
+		Long:    cmdAutheliaStorageUserTrustedDevicesLong,
+		Example: cmdAutheliaStorageUserTrustedDevicesExample,
+		Args:    cobra.NoArgs,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.AddCommand(
+		newStorageUserTrustedDevicesListCmd(ctx),
+		newStorageUserTrustedDevicesRevokeCmd(ctx),
+	)
+
+	return cmd
+}
+
+func newStorageUserTrustedDevicesListCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "list <username>",
+		Short:   cmdAutheliaStorageUserTrustedDevicesListShort,
+		Long:    cmdAutheliaStorageUserTrustedDevicesListLong,
+		Example: cmdAutheliaStorageUserTrustedDevicesListExample,
+		RunE:    ctx.StorageUserTrustedDevicesListRunE,
+		Args:    cobra.ExactArgs(1),
+
+		DisableAutoGenTag: true,
+	}
+
+	return cmd
+}
+
+func newStorageUserTrustedDevicesRevokeCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "revoke <username> [id]",
+		Short:   cmdAutheliaStorageUserTrustedDevicesRevokeShort,
+		Long:    cmdAutheliaStorageUserTrustedDevicesRevokeLong,
+		Example: cmdAutheliaStorageUserTrustedDevicesRevokeExample,
+		RunE:    ctx.StorageUserTrustedDevicesRevokeRunE,
+		Args:    cobra.RangeArgs(1, 2),
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.Flags().Bool(cmdFlagNameAll, false, "revoke all of the users trusted devices")
+
+	return cmd
+}
+
 func newStorageUserTOTPCmd(ctx *CmdCtx) (cmd *cobra.Command) {
 	cmd = &cobra.Command{
 		Use:     "totp",
@@ -380,9 +532,55 @@ func newStorageUserTOTPImportCmd(ctx *CmdCtx) (cmd *cobra.Command) {
 		DisableAutoGenTag: true,
 	}
 
+	cmd.AddCommand(
+		newStorageUserTOTPImportCSVCmd(ctx),
+		newStorageUserTOTPImportURICmd(ctx),
+	)
+
 	return cmd
 }
 
+func newStorageUserTOTPImportCSVCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "csv <filename>",
+		Short:   cmdAutheliaStorageUserTOTPImportCSVShort,
+		Long:    cmdAutheliaStorageUserTOTPImportCSVLong,
+		Example: cmdAutheliaStorageUserTOTPImportCSVExample,
+		RunE:    ctx.StorageUserTOTPImportCSVRunE,
+		Args:    cobra.ExactArgs(1),
+
+		DisableAutoGenTag: true,
+	}
+
+	storageUserTOTPImportBulkFlags(cmd)
+
+	return cmd
+}
+
+func newStorageUserTOTPImportURICmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "uri <filename>",
+		Short:   cmdAutheliaStorageUserTOTPImportURIShort,
+		Long:    cmdAutheliaStorageUserTOTPImportURILong,
+		Example: cmdAutheliaStorageUserTOTPImportURIExample,
+		RunE:    ctx.StorageUserTOTPImportURIRunE,
+		Args:    cobra.ExactArgs(1),
+
+		DisableAutoGenTag: true,
+	}
+
+	storageUserTOTPImportBulkFlags(cmd)
+
+	return cmd
+}
+
+func storageUserTOTPImportBulkFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool(cmdFlagNameDryRun, false, "validates every record and reports the results without saving anything to the database")
+	cmd.Flags().BoolP(cmdFlagNameForce, "f", false, "overwrites the TOTP configuration of users who already have one")
+	cmd.Flags().String(cmdFlagNameUsernameMap, "", "path to a CSV file which maps the usernames in the import file in the first column to the usernames in Authelia in the second column")
+	cmd.Flags().String(cmdFlagNameReport, "", "path to a CSV file to save the result of every record to")
+}
+
 func newStorageUserTOTPExportCmd(ctx *CmdCtx) (cmd *cobra.Command) {
 	cmd = &cobra.Command{
 		Use:     cmdUseExport,
@@ -418,6 +616,8 @@ func newStorageUserTOTPExportURICmd(ctx *CmdCtx) (cmd *cobra.Command) {
 		DisableAutoGenTag: true,
 	}
 
+	cmd.Flags().StringP(cmdFlagNameFile, "f", "", "The file name for the URI export, the URIs are printed to the console if not specified")
+
 	return cmd
 }
 
@@ -455,6 +655,90 @@ func newStorageUserTOTPExportPNGCmd(ctx *CmdCtx) (cmd *cobra.Command) {
 	return cmd
 }
 
+func newStorageRegulationCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "regulation",
+		Short:   cmdAutheliaStorageRegulationShort,
+		Long:    cmdAutheliaStorageRegulationLong,
+		Example: cmdAutheliaStorageRegulationExample,
+		Args:    cobra.NoArgs,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.AddCommand(
+		newStorageRegulationListCmd(ctx),
+		newStorageRegulationShowCmd(ctx),
+		newStorageRegulationClearCmd(ctx),
+		newStorageRegulationBanCmd(ctx),
+	)
+
+	return cmd
+}
+
+func newStorageRegulationListCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "list",
+		Short:   cmdAutheliaStorageRegulationListShort,
+		Long:    cmdAutheliaStorageRegulationListLong,
+		Example: cmdAutheliaStorageRegulationListExample,
+		RunE:    ctx.StorageRegulationListRunE,
+		Args:    cobra.NoArgs,
+
+		DisableAutoGenTag: true,
+	}
+
+	return cmd
+}
+
+func newStorageRegulationShowCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "show <username|ip>",
+		Short:   cmdAutheliaStorageRegulationShowShort,
+		Long:    cmdAutheliaStorageRegulationShowLong,
+		Example: cmdAutheliaStorageRegulationShowExample,
+		RunE:    ctx.StorageRegulationShowRunE,
+		Args:    cobra.ExactArgs(1),
+
+		DisableAutoGenTag: true,
+	}
+
+	return cmd
+}
+
+func newStorageRegulationClearCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "clear <username|ip>",
+		Short:   cmdAutheliaStorageRegulationClearShort,
+		Long:    cmdAutheliaStorageRegulationClearLong,
+		Example: cmdAutheliaStorageRegulationClearExample,
+		RunE:    ctx.StorageRegulationClearRunE,
+		Args:    cobra.ExactArgs(1),
+
+		DisableAutoGenTag: true,
+	}
+
+	return cmd
+}
+
+func newStorageRegulationBanCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "ban <username|ip>",
+		Short:   cmdAutheliaStorageRegulationBanShort,
+		Long:    cmdAutheliaStorageRegulationBanLong,
+		Example: cmdAutheliaStorageRegulationBanExample,
+		RunE:    ctx.StorageRegulationBanRunE,
+		Args:    cobra.ExactArgs(1),
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.Flags().String(cmdFlagNameDuration, "", "the duration of the ban in the duration common syntax, if not set the ban is permanent")
+	cmd.Flags().String(cmdFlagNameReason, "", "the reason for the ban")
+
+	return cmd
+}
+
 func newStorageSchemaInfoCmd(ctx *CmdCtx) (cmd *cobra.Command) {
 	cmd = &cobra.Command{
 		Use:     "schema-info",
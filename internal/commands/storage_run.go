@@ -3,14 +3,18 @@ package commands
 import (
 	"bytes"
 	"database/sql"
+	"encoding/csv"
 	"errors"
 	"fmt"
 	"image"
 	"image/png"
+	"io"
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/spf13/cobra"
@@ -20,6 +24,7 @@ import (
 	"github.com/authelia/authelia/v4/internal/configuration/validator"
 	"github.com/authelia/authelia/v4/internal/model"
 	"github.com/authelia/authelia/v4/internal/random"
+	"github.com/authelia/authelia/v4/internal/regulation"
 	"github.com/authelia/authelia/v4/internal/storage"
 	"github.com/authelia/authelia/v4/internal/totp"
 	"github.com/authelia/authelia/v4/internal/utils"
@@ -107,8 +112,12 @@ func (ctx *CmdCtx) ConfigValidateStorageRunE(_ *cobra.Command, _ []string) (err
 
 	validator.ValidateStorage(ctx.config.Storage, ctx.cconfig.validator)
 
+	validator.ValidateStorageRetention(ctx.config, ctx.cconfig.validator)
+
 	validator.ValidateTOTP(ctx.config, ctx.cconfig.validator)
 
+	validator.ValidateRegulation(ctx.config, ctx.cconfig.validator)
+
 	if errs := ctx.cconfig.validator.Errors(); len(errs) != 0 {
 		var (
 			i int
@@ -238,6 +247,37 @@ func (ctx *CmdCtx) StorageSchemaEncryptionChangeKeyRunE(cmd *cobra.Command, args
 	return nil
 }
 
+// StorageSchemaEncryptionRotateKEKRunE is the RunE for the authelia storage encryption rotate-kek command.
+func (ctx *CmdCtx) StorageSchemaEncryptionRotateKEKRunE(_ *cobra.Command, _ []string) (err error) {
+	defer func() {
+		_ = ctx.providers.StorageProvider.Close()
+	}()
+
+	if !ctx.config.Storage.KeyManagement.Enabled() {
+		return errors.New("key management must be configured to rotate the key-encryption key")
+	}
+
+	if err = ctx.CheckSchema(); err != nil {
+		return storageWrapCheckSchemaErr(err)
+	}
+
+	var result storage.DataKeyRotationResult
+
+	if result, err = ctx.providers.StorageProvider.SchemaEncryptionRotateKEK(ctx); err != nil {
+		return err
+	}
+
+	if len(result.Keys) == 0 {
+		fmt.Println("There are no data keys to rotate. The data key is generated when Authelia starts with key management configured.")
+
+		return nil
+	}
+
+	fmt.Printf("Completed the key-encryption key rotation for the data keys with ids %s. The data keys are now wrapped with the '%s' key-encryption key with id '%s'.\n", utils.StringJoinAnd(result.Keys), result.Provider, result.KEKID)
+
+	return nil
+}
+
 // StorageMigrateHistoryRunE is the RunE for the authelia storage migrate history command.
 func (ctx *CmdCtx) StorageMigrateHistoryRunE(_ *cobra.Command, _ []string) (err error) {
 	defer func() {
@@ -516,16 +556,50 @@ func (ctx *CmdCtx) StorageUserWebAuthnImportRunE(cmd *cobra.Command, args []stri
 		return fmt.Errorf("can't import a YAML file without WebAuthn credentials data")
 	}
 
+	var (
+		dryRun    bool
+		mapping   string
+		usernames map[string]string
+	)
+
+	if dryRun, err = cmd.Flags().GetBool(cmdFlagNameDryRun); err != nil {
+		return err
+	}
+
+	if mapping, err = cmd.Flags().GetString(cmdFlagNameUsernameMap); err != nil {
+		return err
+	}
+
+	if usernames, err = storageReadUsernameMap(mapping); err != nil {
+		return err
+	}
+
 	if err = ctx.CheckSchema(); err != nil {
 		return storageWrapCheckSchemaErr(err)
 	}
 
 	for _, device := range export.WebAuthnCredentials {
+		if username, ok := usernames[device.Username]; ok {
+			device.Username = username
+		}
+
+		if dryRun {
+			fmt.Printf("Would import the WebAuthn credential with description '%s' and key id '%s' for user '%s'\n", device.Description, device.KID, device.Username)
+
+			continue
+		}
+
 		if err = ctx.providers.StorageProvider.SaveWebAuthnCredential(ctx, device); err != nil {
 			return err
 		}
 	}
 
+	if dryRun {
+		fmt.Printf("Successfully validated %d WebAuthn credentials from the YAML file '%s' without importing them into the database\n", len(export.WebAuthnCredentials), filename)
+
+		return nil
+	}
+
 	fmt.Printf(cliOutputFmtSuccessfulUserImportFile, len(export.WebAuthnCredentials), "WebAuthn credentials", "YAML", filename)
 
 	return nil
@@ -654,6 +728,97 @@ func (ctx *CmdCtx) StorageUserWebAuthnDeleteRunE(cmd *cobra.Command, args []stri
 	return nil
 }
 
+// StorageUserTrustedDevicesListRunE is the RunE for the authelia storage user trusted-devices list command.
+func (ctx *CmdCtx) StorageUserTrustedDevicesListRunE(_ *cobra.Command, args []string) (err error) {
+	defer func() {
+		_ = ctx.providers.StorageProvider.Close()
+	}()
+
+	if err = ctx.CheckSchema(); err != nil {
+		return storageWrapCheckSchemaErr(err)
+	}
+
+	var devices []model.TrustedDevice
+
+	user := args[0]
+
+	if devices, err = ctx.providers.StorageProvider.LoadTrustedDevices(ctx, user, time.Now()); err != nil {
+		return fmt.Errorf("can't list trusted devices for user '%s': %w", user, err)
+	}
+
+	if len(devices) == 0 {
+		return fmt.Errorf("user '%s' has no trusted devices", user)
+	}
+
+	fmt.Printf("Trusted Devices for user '%s':\n\n", user)
+	fmt.Printf("ID\tCreated\tLast Used\tExpires\tDescription\n")
+
+	for _, device := range devices {
+		lastUsed := "never"
+
+		if device.LastUsedAt.Valid {
+			lastUsed = device.LastUsedAt.Time.Format(time.RFC3339)
+		}
+
+		fmt.Printf("%s\t%s\t%s\t%s\t%s\n", device.PublicID, device.CreatedAt.Format(time.RFC3339), lastUsed, device.ExpiresAt.Format(time.RFC3339), device.Description)
+	}
+
+	return nil
+}
+
+// StorageUserTrustedDevicesRevokeRunE is the RunE for the authelia storage user trusted-devices revoke command.
+func (ctx *CmdCtx) StorageUserTrustedDevicesRevokeRunE(cmd *cobra.Command, args []string) (err error) {
+	defer func() {
+		_ = ctx.providers.StorageProvider.Close()
+	}()
+
+	if err = ctx.CheckSchema(); err != nil {
+		return storageWrapCheckSchemaErr(err)
+	}
+
+	var all bool
+
+	if all, err = cmd.Flags().GetBool(cmdFlagNameAll); err != nil {
+		return err
+	}
+
+	user := args[0]
+
+	switch {
+	case all && len(args) == 2:
+		return fmt.Errorf("failed to revoke trusted devices: the id argument and the --%s flag can't be used together", cmdFlagNameAll)
+	case all:
+		if err = ctx.providers.StorageProvider.RevokeTrustedDevices(ctx, user, time.Now()); err != nil {
+			return fmt.Errorf("failed to revoke all trusted devices for user '%s': %w", user, err)
+		}
+
+		fmt.Printf("Successfully revoked all trusted devices for user '%s'\n", user)
+	case len(args) == 1:
+		return fmt.Errorf("failed to revoke trusted devices: either the id argument or the --%s flag must be provided", cmdFlagNameAll)
+	default:
+		var (
+			id      uuid.UUID
+			revoked bool
+		)
+
+		if id, err = uuid.Parse(args[1]); err != nil {
+			return fmt.Errorf("failed to revoke trusted device: the id '%s' is not a valid uuid", args[1])
+		}
+
+		if revoked, err = ctx.providers.StorageProvider.RevokeTrustedDevice(ctx, user, id, time.Now()); err != nil {
+			return fmt.Errorf("failed to revoke trusted device with id '%s' for user '%s': %w", id, user, err)
+		}
+
+		if !revoked {
+			return fmt.Errorf("failed to revoke trusted device with id '%s' for user '%s': the device doesn't exist or is already revoked", id, user)
+		}
+
+		fmt.Printf("Successfully revoked trusted device with id '%s' for user '%s'\n", id, user)
+	}
+
+	return nil
+}
+
 // StorageUserTOTPGenerateRunE is the RunE for the authelia storage user totp generate command.
 func (ctx *CmdCtx) StorageUserTOTPGenerateRunE(cmd *cobra.Command, args []string) (err error) {
 	defer func() {
@@ -850,6 +1015,16 @@ func (ctx *CmdCtx) StorageUserTOTPImportRunE(_ *cobra.Command, args []string) (e
 		return fmt.Errorf("can't import a YAML file without TOTP configuration data")
 	}
 
+	for _, config := range export.TOTPConfigurations {
+		if !utils.IsStringInSlice(config.Type, model.OneTimePasswordTypes) {
+			return fmt.Errorf("can't import a YAML file with a TOTP configuration for user '%s' with type '%s' as it must be one of %s", config.Username, config.Type, utils.StringJoinOr(model.OneTimePasswordTypes))
+		}
+
+		if config.Type == model.OneTimePasswordTypeSteam && config.Digits != 5 {
+			return fmt.Errorf("can't import a YAML file with a TOTP configuration for user '%s' with type '%s' as it must have 5 digits but it has %d", config.Username, config.Type, config.Digits)
+		}
+	}
+
 	if err = ctx.CheckSchema(); err != nil {
 		return storageWrapCheckSchemaErr(err)
 	}
@@ -865,19 +1040,207 @@ func (ctx *CmdCtx) StorageUserTOTPImportRunE(_ *cobra.Command, args []string) (e
 	return nil
 }
 
-func (ctx *CmdCtx) StorageUserTOTPExportURIRunE(_ *cobra.Command, _ []string) (err error) {
+// StorageUserTOTPImportCSVRunE is the RunE for the authelia storage user totp import csv command.
+func (ctx *CmdCtx) StorageUserTOTPImportCSVRunE(cmd *cobra.Command, args []string) (err error) {
+	return ctx.storageUserTOTPImportBulkRunE(cmd, args[0], "CSV", storageTOTPBulkParseCSV)
+}
+
+// StorageUserTOTPImportURIRunE is the RunE for the authelia storage user totp import uri command.
+func (ctx *CmdCtx) StorageUserTOTPImportURIRunE(cmd *cobra.Command, args []string) (err error) {
+	return ctx.storageUserTOTPImportBulkRunE(cmd, args[0], "URI", storageTOTPBulkParseURI)
+}
+
+const (
+	storageTOTPBulkResultImported = "imported"
+	storageTOTPBulkResultValid    = "valid"
+	storageTOTPBulkResultSkipped  = "skipped"
+	storageTOTPBulkResultFailed   = "failed"
+)
+
+//nolint:gocyclo
+func (ctx *CmdCtx) storageUserTOTPImportBulkRunE(cmd *cobra.Command, filename, format string, parse storageTOTPBulkParser) (err error) {
 	defer func() {
 		_ = ctx.providers.StorageProvider.Close()
 	}()
 
 	var (
-		configs []model.TOTPConfiguration
+		dryRun, force   bool
+		mapping, report string
+		usernames       map[string]string
+		stat            os.FileInfo
+		data            []byte
+		records         []storageTOTPBulkRecord
+	)
+
+	if dryRun, err = cmd.Flags().GetBool(cmdFlagNameDryRun); err != nil {
+		return err
+	}
+
+	if force, err = cmd.Flags().GetBool(cmdFlagNameForce); err != nil {
+		return err
+	}
+
+	if mapping, err = cmd.Flags().GetString(cmdFlagNameUsernameMap); err != nil {
+		return err
+	}
+
+	if report, err = cmd.Flags().GetString(cmdFlagNameReport); err != nil {
+		return err
+	}
+
+	if stat, err = os.Stat(filename); err != nil {
+		return fmt.Errorf("must specify a filename that exists but '%s' had an error opening it: %w", filename, err)
+	}
+
+	if stat.IsDir() {
+		return fmt.Errorf("must specify a filename that exists but '%s' is a directory", filename)
+	}
+
+	if data, err = os.ReadFile(filename); err != nil {
+		return err
+	}
+
+	if usernames, err = storageReadUsernameMap(mapping); err != nil {
+		return err
+	}
+
+	if records, err = parse(data, ctx.config.TOTP.Issuer, time.Now()); err != nil {
+		return fmt.Errorf("error parsing the %s file '%s': %w", format, filename, err)
+	}
+
+	if len(records) == 0 {
+		return fmt.Errorf("can't import a %s file without TOTP configuration data", format)
+	}
+
+	if err = ctx.CheckSchema(); err != nil {
+		return storageWrapCheckSchemaErr(err)
+	}
+
+	seen := map[string]int{}
+	counts := map[string]int{}
+
+	for i := range records {
+		record := &records[i]
+
+		switch {
+		case record.Err != nil:
+			break
+		default:
+			if username, ok := usernames[record.Config.Username]; ok {
+				record.Config.Username = username
+			}
+
+			if line, ok := seen[record.Config.Username]; ok {
+				record.Err = fmt.Errorf("the user already has a TOTP configuration on line %d of the file", line)
+
+				break
+			}
+
+			seen[record.Config.Username] = record.Line
+
+			if record.Err = storageTOTPBulkValidate(&ctx.config.TOTP, record.Config); record.Err != nil {
+				break
+			}
+
+			if _, err = ctx.providers.StorageProvider.LoadTOTPConfiguration(ctx, record.Config.Username); err == nil && !force {
+				record.Result, record.Err = storageTOTPBulkResultSkipped, fmt.Errorf("the user already has a TOTP configuration, use --force to overwrite it")
+
+				break
+			} else if err != nil && !errors.Is(err, storage.ErrNoTOTPConfiguration) {
+				return err
+			}
+
+			if dryRun {
+				record.Result = storageTOTPBulkResultValid
+
+				break
+			}
+
+			if record.Err = ctx.providers.StorageProvider.SaveTOTPConfiguration(ctx, *record.Config); record.Err == nil {
+				record.Result = storageTOTPBulkResultImported
+			}
+		}
+
+		if record.Err != nil && record.Result == "" {
+			record.Result = storageTOTPBulkResultFailed
+		}
+
+		counts[record.Result]++
+
+		if record.Err != nil {
+			fmt.Printf("Line %d: %s: user '%s': %v\n", record.Line, record.Result, record.Username(), record.Err)
+		} else {
+			fmt.Printf("Line %d: %s: user '%s'\n", record.Line, record.Result, record.Username())
+		}
+	}
+
+	if report != "" {
+		if err = storageTOTPBulkWriteReport(report, records); err != nil {
+			return err
+		}
+	}
+
+	if dryRun {
+		fmt.Printf("\nSuccessfully validated the %s file '%s' without importing it into the database: %d valid, %d skipped, %d failed\n", format, filename, counts[storageTOTPBulkResultValid], counts[storageTOTPBulkResultSkipped], counts[storageTOTPBulkResultFailed])
+	} else {
+		fmt.Printf("\nImported the %s file '%s' into the database: %d imported, %d skipped, %d failed\n", format, filename, counts[storageTOTPBulkResultImported], counts[storageTOTPBulkResultSkipped], counts[storageTOTPBulkResultFailed])
+	}
+
+	if n := counts[storageTOTPBulkResultFailed]; n != 0 {
+		return fmt.Errorf("%d of the %d records in the %s file '%s' failed", n, len(records), format, filename)
+	}
+
+	return nil
+}
+
+func storageTOTPBulkWriteReport(filename string, records []storageTOTPBulkRecord) (err error) {
+	buf := &bytes.Buffer{}
+
+	writer := csv.NewWriter(buf)
+
+	_ = writer.Write([]string{"line", "username", "result", "error"})
+
+	for _, record := range records {
+		var e string
+
+		if record.Err != nil {
+			e = record.Err.Error()
+		}
+
+		_ = writer.Write([]string{strconv.Itoa(record.Line), record.Username(), record.Result, e})
+	}
+
+	writer.Flush()
+
+	if err = writer.Error(); err != nil {
+		return fmt.Errorf("error writing the report file '%s': %w", filename, err)
+	}
+
+	if err = os.WriteFile(filename, buf.Bytes(), 0600); err != nil {
+		return fmt.Errorf("error writing the report file '%s': %w", filename, err)
+	}
+
+	return nil
+}
+
+func (ctx *CmdCtx) StorageUserTOTPExportURIRunE(cmd *cobra.Command, _ []string) (err error) {
+	defer func() {
+		_ = ctx.providers.StorageProvider.Close()
+	}()
+
+	var (
+		filename string
+		configs  []model.TOTPConfiguration
 	)
 
 	if err = ctx.CheckSchema(); err != nil {
 		return storageWrapCheckSchemaErr(err)
 	}
 
+	if filename, err = cmd.Flags().GetString(cmdFlagNameFile); err != nil {
+		return err
+	}
+
 	limit := 10
 	count := 0
 
@@ -901,6 +1264,16 @@ func (ctx *CmdCtx) StorageUserTOTPExportURIRunE(_ *cobra.Command, _ []string) (e
 		}
 	}
 
+	if filename != "" {
+		if err = os.WriteFile(filename, buf.Bytes(), 0600); err != nil {
+			return err
+		}
+
+		fmt.Printf(cliOutputFmtSuccessfulUserExportFile, count, "TOTP configurations", "URI", filename)
+
+		return nil
+	}
+
 	fmt.Print(buf.String())
 
 	fmt.Printf("\n\nSuccessfully exported %d TOTP configurations as TOTP URI's and printed them to the console\n", count)
@@ -932,7 +1305,7 @@ func (ctx *CmdCtx) StorageUserTOTPExportCSVRunE(cmd *cobra.Command, _ []string)
 
 	buf = &bytes.Buffer{}
 
-	buf.WriteString("issuer,username,algorithm,digits,period,secret\n")
+	buf.WriteString("issuer,username,algorithm,digits,period,secret,type,counter\n")
 
 	for page := 0; true; page++ {
 		if configs, err = ctx.providers.StorageProvider.LoadTOTPConfigurations(ctx, limit, page); err != nil {
@@ -940,7 +1313,7 @@ func (ctx *CmdCtx) StorageUserTOTPExportCSVRunE(cmd *cobra.Command, _ []string)
 		}
 
 		for _, c := range configs {
-			buf.WriteString(fmt.Sprintf("%s,%s,%s,%d,%d,%s\n", c.Issuer, c.Username, c.Algorithm, c.Digits, c.Period, string(c.Secret)))
+			buf.WriteString(fmt.Sprintf("%s,%s,%s,%d,%d,%s,%s,%d\n", c.Issuer, c.Username, c.Algorithm, c.Digits, c.Period, string(c.Secret), c.Type, c.Counter))
 		}
 
 		l := len(configs)
@@ -1277,3 +1650,396 @@ func (ctx *CmdCtx) StorageUserIdentifiersAddRunE(cmd *cobra.Command, args []stri
 
 	return nil
 }
+
+// StorageRegulationListRunE is the RunE for the authelia storage regulation list command.
+func (ctx *CmdCtx) StorageRegulationListRunE(_ *cobra.Command, _ []string) (err error) {
+	defer func() {
+		_ = ctx.providers.StorageProvider.Close()
+	}()
+
+	if err = ctx.CheckSchema(); err != nil {
+		return storageWrapCheckSchemaErr(err)
+	}
+
+	var bans []regulation.Ban
+
+	if bans, err = regulation.NewRegulator(ctx.config.Regulation, ctx.providers.StorageProvider, clock.New()).Bans(ctx); err != nil {
+		return fmt.Errorf("failed to list bans: %w", err)
+	}
+
+	if len(bans) == 0 {
+		fmt.Println("No users or IPs are currently banned")
+
+		return nil
+	}
+
+	fmt.Printf("Type\tValue\tSource\tTime\tExpires\tReason\n")
+
+	for _, ban := range bans {
+		fmt.Printf("%s\t%s\t%s\t%s\t%s\t%s\n", ban.Type, ban.Value, ban.Source, ban.Time.Format(time.RFC3339), fmtStorageRegulationExpires(ban.Expires), ban.Reason)
+	}
+
+	return nil
+}
+
+// StorageRegulationShowRunE is the RunE for the authelia storage regulation show command.
+func (ctx *CmdCtx) StorageRegulationShowRunE(_ *cobra.Command, args []string) (err error) {
+	defer func() {
+		_ = ctx.providers.StorageProvider.Close()
+	}()
+
+	if err = ctx.CheckSchema(); err != nil {
+		return storageWrapCheckSchemaErr(err)
+	}
+
+	var status *regulation.Status
+
+	if status, err = regulation.NewRegulator(ctx.config.Regulation, ctx.providers.StorageProvider, clock.New()).Status(ctx, args[0]); err != nil {
+		return fmt.Errorf("failed to show the regulation status of '%s': %w", args[0], err)
+	}
+
+	fmt.Printf("Regulation status for %s '%s':\n\n", status.Type, status.Value)
+
+	if len(status.Bans) == 0 {
+		fmt.Printf("No bans currently apply\n\n")
+	} else {
+		fmt.Printf("Source\tTime\tExpires\tReason\n")
+
+		for _, ban := range status.Bans {
+			fmt.Printf("%s\t%s\t%s\t%s\n", ban.Source, ban.Time.Format(time.RFC3339), fmtStorageRegulationExpires(ban.Expires), ban.Reason)
+		}
+
+		fmt.Println()
+	}
+
+	if len(status.Attempts) == 0 {
+		fmt.Println("No recent authentication attempts")
+
+		return nil
+	}
+
+	fmt.Printf("Time\tType\tSuccessful\tBanned\tUsername\tRemote IP\n")
+
+	for _, attempt := range status.Attempts {
+		fmt.Printf("%s\t%s\t%t\t%t\t%s\t%s\n", attempt.Time.Format(time.RFC3339), attempt.Type, attempt.Successful, attempt.Banned, attempt.Username, attempt.RemoteIP.IP)
+	}
+
+	return nil
+}
+
+// StorageRegulationClearRunE is the RunE for the authelia storage regulation clear command.
+func (ctx *CmdCtx) StorageRegulationClearRunE(_ *cobra.Command, args []string) (err error) {
+	defer func() {
+		_ = ctx.providers.StorageProvider.Close()
+	}()
+
+	if err = ctx.CheckSchema(); err != nil {
+		return storageWrapCheckSchemaErr(err)
+	}
+
+	if err = regulation.NewRegulator(ctx.config.Regulation, ctx.providers.StorageProvider, clock.New()).Clear(ctx, args[0]); err != nil {
+		return fmt.Errorf("failed to clear the bans of '%s': %w", args[0], err)
+	}
+
+	fmt.Printf("Successfully cleared the bans of '%s'\n", args[0])
+
+	return nil
+}
+
+// StorageRegulationBanRunE is the RunE for the authelia storage regulation ban command.
+func (ctx *CmdCtx) StorageRegulationBanRunE(cmd *cobra.Command, args []string) (err error) {
+	defer func() {
+		_ = ctx.providers.StorageProvider.Close()
+	}()
+
+	if err = ctx.CheckSchema(); err != nil {
+		return storageWrapCheckSchemaErr(err)
+	}
+
+	var (
+		value, reason string
+		duration      time.Duration
+	)
+
+	if value, err = cmd.Flags().GetString(cmdFlagNameDuration); err != nil {
+		return err
+	}
+
+	if value != "" {
+		if duration, err = utils.ParseDurationString(value); err != nil {
+			return fmt.Errorf("failed to parse duration string: %w", err)
+		}
+
+		if duration <= 0 {
+			return fmt.Errorf("the duration must be greater than 0 but it's configured as '%s'", value)
+		}
+	}
+
+	if reason, err = cmd.Flags().GetString(cmdFlagNameReason); err != nil {
+		return err
+	}
+
+	if err = regulation.NewRegulator(ctx.config.Regulation, ctx.providers.StorageProvider, clock.New()).Ban(ctx, args[0], duration, reason); err != nil {
+		return fmt.Errorf("failed to ban '%s': %w", args[0], err)
+	}
+
+	if duration == 0 {
+		fmt.Printf("Successfully banned '%s' permanently\n", args[0])
+	} else {
+		fmt.Printf("Successfully banned '%s' for %s\n", args[0], duration)
+	}
+
+	if ctx.config.Regulation.MaxRetries <= 0 {
+		fmt.Println("WARNING: the regulation is disabled so the ban will not be enforced until it's enabled")
+	}
+
+	return nil
+}
+
+func fmtStorageRegulationExpires(expires *time.Time) string {
+	if expires == nil {
+		return "never"
+	}
+
+	return expires.Format(time.RFC3339)
+}
+
+// StorageBackupRunE is the RunE for the authelia storage backup command.
+func (ctx *CmdCtx) StorageBackupRunE(cmd *cobra.Command, _ []string) (err error) {
+	defer func() {
+		_ = ctx.providers.StorageProvider.Close()
+	}()
+
+	if err = ctx.CheckSchema(); err != nil {
+		return storageWrapCheckSchemaErr(err)
+	}
+
+	var filename string
+
+	if filename, err = cmd.Flags().GetString(cmdFlagNameFile); err != nil {
+		return err
+	}
+
+	if _, err = os.Stat(filename); !os.IsNotExist(err) {
+		return fmt.Errorf("backup output filepath '%s' already exists", filename)
+	}
+
+	var file *os.File
+
+	if file, err = os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600); err != nil {
+		return fmt.Errorf("failed to create the backup file: %w", err)
+	}
+
+	var result storage.BackupResult
+
+	if result, err = ctx.providers.StorageProvider.SchemaBackup(ctx, file); err != nil {
+		_ = file.Close()
+		_ = os.Remove(filename)
+
+		return fmt.Errorf("failed to backup the storage: %w", err)
+	}
+
+	if err = file.Close(); err != nil {
+		return fmt.Errorf("failed to close the backup file: %w", err)
+	}
+
+	fmtStorageBackupResult(result)
+
+	fmt.Printf("\nSuccessfully wrote the backup of schema version %d with %d rows to the file '%s'\n", result.Header.SchemaVersion, result.Rows(), filename)
+
+	return nil
+}
+
+// StorageRestoreRunE is the RunE for the authelia storage restore command.
+func (ctx *CmdCtx) StorageRestoreRunE(cmd *cobra.Command, args []string) (err error) {
+	defer func() {
+		_ = ctx.providers.StorageProvider.Close()
+	}()
+
+	var force bool
+
+	if force, err = cmd.Flags().GetBool(cmdFlagNameForce); err != nil {
+		return err
+	}
+
+	var file *os.File
+
+	if file, err = os.Open(args[0]); err != nil {
+		return fmt.Errorf("failed to open the backup file: %w", err)
+	}
+
+	defer file.Close()
+
+	var result storage.BackupResult
+
+	if result, err = ctx.providers.StorageProvider.SchemaRestore(ctx, file, force); err != nil {
+		return fmt.Errorf("failed to restore the backup: %w", err)
+	}
+
+	fmtStorageBackupResult(result)
+
+	fmt.Printf("\nSuccessfully restored the backup of schema version %d with %d rows from the file '%s'\n", result.Header.SchemaVersion, result.Rows(), args[0])
+
+	return nil
+}
+
+// StorageMigrateBackendRunE is the RunE for the authelia storage migrate-backend command.
+func (ctx *CmdCtx) StorageMigrateBackendRunE(cmd *cobra.Command, _ []string) (err error) {
+	_ = ctx.providers.StorageProvider.Close()
+
+	var (
+		from, to string
+		force    bool
+	)
+
+	if to, err = cmd.Flags().GetString(cmdFlagNameTo); err != nil {
+		return err
+	}
+
+	if from, err = cmd.Flags().GetString(cmdFlagNameFrom); err != nil {
+		return err
+	}
+
+	if force, err = cmd.Flags().GetBool(cmdFlagNameForce); err != nil {
+		return err
+	}
+
+	if from == "" {
+		if from = getStorageBackendSource(ctx.config.Storage, to); from == "" {
+			return fmt.Errorf("the storage backend to copy the data from could not be determined: a storage backend other than '%s' must be configured", to)
+		}
+	}
+
+	if from == to {
+		return fmt.Errorf("the storage backend to copy the data from and to must not be the same but both are '%s'", to)
+	}
+
+	var src, dst storage.Provider
+
+	if src, err = getStorageProviderByName(ctx, from); err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = src.Close()
+	}()
+
+	if dst, err = getStorageProviderByName(ctx, to); err != nil {
+		return err
+	}
+
+	defer func() {
+		_ = dst.Close()
+	}()
+
+	if err = ctx.CheckProviderSchema(src); err != nil {
+		return fmt.Errorf("error checking the source storage backend '%s': %w", from, storageWrapCheckSchemaErr(err))
+	}
+
+	reader, writer := io.Pipe()
+
+	chBackup := make(chan error, 1)
+
+	go func() {
+		_, berr := src.SchemaBackup(ctx, writer)
+
+		_ = writer.CloseWithError(berr)
+
+		chBackup <- berr
+	}()
+
+	var result storage.BackupResult
+
+	result, err = dst.SchemaRestore(ctx, reader, force)
+
+	_ = reader.CloseWithError(err)
+
+	switch berr := <-chBackup; {
+	case berr != nil && (err == nil || errors.Is(err, berr)):
+		return fmt.Errorf("failed to read the data from the source storage backend '%s': %w", from, berr)
+	case err != nil:
+		return fmt.Errorf("failed to write the data to the destination storage backend '%s': %w", to, err)
+	}
+
+	fmt.Printf("Copied the data from the '%s' storage backend to the '%s' storage backend\n\n", from, to)
+
+	var srcRows, dstRows int
+
+	for _, table := range result.Tables {
+		if srcRows, err = src.SchemaTableRowCount(ctx, table.Name); err != nil {
+			return fmt.Errorf("failed to verify the number of rows in the table '%s' of the source storage backend '%s': %w", table.Name, from, err)
+		}
+
+		if dstRows, err = dst.SchemaTableRowCount(ctx, table.Name); err != nil {
+			return fmt.Errorf("failed to verify the number of rows in the table '%s' of the destination storage backend '%s': %w", table.Name, to, err)
+		}
+
+		if srcRows != dstRows {
+			return fmt.Errorf("failed to verify the number of rows in the table '%s': the source storage backend '%s' has %d rows but the destination storage backend '%s' has %d rows", table.Name, from, srcRows, to, dstRows)
+		}
+
+		fmt.Printf("\t%s: %d rows verified\n", table.Name, dstRows)
+	}
+
+	fmt.Printf("\nSuccessfully migrated schema version %d with %d rows from the '%s' storage backend to the '%s' storage backend\n", result.Header.SchemaVersion, result.Rows(), from, to)
+
+	return nil
+}
+
+func fmtStorageBackupResult(result storage.BackupResult) {
+	fmt.Printf("Backup Created: %s\n", result.Header.Created.Format(time.RFC3339))
+	fmt.Printf("Backup Provider: %s\n", result.Header.Provider)
+	fmt.Printf("Schema Version: %d\n\n", result.Header.SchemaVersion)
+
+	for _, table := range result.Tables {
+		fmt.Printf("\t%s: %d rows\n", table.Name, table.Rows)
+	}
+}
+
+// StoragePruneRunE is the RunE for the authelia storage prune command.
+func (ctx *CmdCtx) StoragePruneRunE(cmd *cobra.Command, _ []string) (err error) {
+	defer func() {
+		_ = ctx.providers.StorageProvider.Close()
+	}()
+
+	if err = ctx.CheckSchemaVersion(); err != nil {
+		return storageWrapCheckSchemaErr(err)
+	}
+
+	var dryRun bool
+
+	if dryRun, err = cmd.Flags().GetBool(cmdFlagNameDryRun); err != nil {
+		return err
+	}
+
+	results, err := storage.NewRetentionJanitor(&ctx.config.Storage.Retention, ctx.providers.StorageProvider, nil).Prune(ctx, dryRun)
+
+	var (
+		action = "Pruned"
+		total  int64
+	)
+
+	if dryRun {
+		action = "Would prune"
+	}
+
+	for _, result := range results {
+		total += result.Rows
+
+		fmt.Printf("%s %d rows from the '%s' table which exceeded the %s retention period of %s (before %s)\n", action, result.Rows, result.Table, result.Name, result.Retention, result.Before.Format(time.RFC3339))
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to prune the storage: %w", err)
+	}
+
+	if len(results) == 0 {
+		fmt.Println("No data is configured to be pruned as all data is retained indefinitely")
+
+		return nil
+	}
+
+	fmt.Printf("\n%s %d rows in total\n", action, total)
+
+	return nil
+}
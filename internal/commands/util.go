@@ -240,6 +240,18 @@ func loadXEnvCLIConfigValues(cmd *cobra.Command) (configs []string, filters []co
 	return
 }
 
+func loadXEnvCLISecretResolvers(cmd *cobra.Command) (names []string, resolvers []configuration.SecretResolver, err error) {
+	if names, _, err = loadXEnvCLIStringSliceValue(cmd, cmdFlagEnvNameConfigSecretResolvers, cmdFlagNameConfigExpSecretResolvers); err != nil {
+		return nil, nil, err
+	}
+
+	if resolvers, err = configuration.NewSecretResolvers(names); err != nil {
+		return nil, nil, fmt.Errorf("error occurred loading configuration: flag '--%s' is invalid: %w", cmdFlagNameConfigExpSecretResolvers, err)
+	}
+
+	return names, resolvers, nil
+}
+
 func loadXNormalizedPaths(paths []string, result XEnvCLIResult) ([]string, error) {
 	var (
 		configs, files, dirs []string
@@ -249,6 +261,12 @@ func loadXNormalizedPaths(paths []string, result XEnvCLIResult) ([]string, error
 	var stat os.FileInfo
 
 	for _, path := range paths {
+		if configuration.IsRemoteSourcePath(path) {
+			configs = append(configs, path)
+
+			continue
+		}
+
 		if path, err = filepath.Abs(path); err != nil {
 			return nil, fmt.Errorf("failed to determine absolute path for '%s': %w", path, err)
 		}
@@ -172,6 +172,11 @@ func TestLoadXNormalizedPaths(t *testing.T) {
 			[]string{ayml, otherdir},
 			"",
 		},
+		{"ShouldAllowRemoteSources",
+			XEnvCLIResultCLIImplicit, []string{ayml, "https://config.example.com/configuration.yml", "consul://127.0.0.1:8500/authelia/configuration.yml"},
+			[]string{ayml, "https://config.example.com/configuration.yml", "consul://127.0.0.1:8500/authelia/configuration.yml"},
+			"",
+		},
 		{"ShouldRaiseErrOnOverlappingFilesDirectories",
 			XEnvCLIResultCLIImplicit, []string{ayml, configdir},
 			nil, fmt.Sprintf("failed to load config directory '%s': the config file '%s' is in that directory which is not supported", configdir, ayml),
@@ -19,10 +19,46 @@ const (
 
 	constWindows = "windows"
 
+	diffRedacted = "<redacted>"
+
 	extYML  = ".yml"
 	extYAML = ".yaml"
 )
 
+const (
+	schemeHTTP        = "http"
+	schemeHTTPS       = "https"
+	schemeConsul      = "consul"
+	schemeConsulHTTPS = "consul+https"
+	schemeEtcd        = "etcd"
+	schemeEtcdHTTPS   = "etcd+https"
+)
+
+const (
+	envRemotePublicKey = "X_AUTHELIA_CONFIG_REMOTE_PUBLIC_KEY"
+	envConsulToken     = "CONSUL_HTTP_TOKEN"
+
+	headerRemoteSignature = "X-Authelia-Signature"
+	headerConsulToken     = "X-Consul-Token"
+	headerConsulIndex     = "X-Consul-Index"
+
+	remoteSourceTimeout = time.Second * 30
+)
+
+const (
+	secretResolverVault = "vault"
+	secretResolverExec  = "exec"
+
+	envVaultAddress   = "VAULT_ADDR"
+	envVaultToken     = "VAULT_TOKEN"
+	envVaultNamespace = "VAULT_NAMESPACE"
+
+	headerVaultToken     = "X-Vault-Token"
+	headerVaultNamespace = "X-Vault-Namespace"
+
+	secretResolverTimeout = time.Second * 30
+)
+
 const (
 	filterField     = "filter"
 	filterTemplate  = "template"
@@ -42,6 +78,7 @@ const (
 	errFmtSecretOSError         = "secrets: error loading secret path %s into key '%s': %w"
 	errFmtSecretOSPermission    = "secrets: error loading secret path %s into key '%s': file permission error occurred: %w"
 	errFmtSecretOSNotExist      = "secrets: error loading secret path %s into key '%s': file does not exist error occurred: %w"
+	errFmtSecretReference       = "secret references: error resolving the reference for key '%s' using the '%s' resolver: %w"
 	errFmtGenerateConfiguration = "error occurred generating configuration: %+v"
 
 	errFmtDecodeHookCouldNotParse           = "could not decode '%s' to a %s%s: %w"
@@ -76,7 +113,7 @@ const (
 // envSecretSuffixes.
 // Make sure you update these at the same time.
 var (
-	secretSuffix          = []string{"key", "secret", "password", "token", "certificate_chain"}
+	secretSuffix          = []string{"key", "secret", "password", "token", "certificate_chain", "pin"}
 	secretExclusionPrefix = []string{"identity_providers.oidc.lifespans."}
 	secretExclusionExact  = []string{"server.tls.key", "authentication_backend.disable_reset_password", "tls_key"}
 )
@@ -120,9 +120,17 @@ func DecodeTimeDuration(f, expectedType reflect.Type, prefixType string, data an
 	case f.Kind() == reflect.String:
 		dataStr := data.(string)
 
-		if result, err = utils.ParseDurationString(dataStr); err != nil {
+		// Negative values are parsed without the sign and then negated so that values such as '-1' which are valid
+		// integers in a configuration file are also valid when provided as a string via the environment or CLI.
+		value, negative := strings.TrimPrefix(dataStr, "-"), strings.HasPrefix(dataStr, "-")
+
+		if result, err = utils.ParseDurationString(value); err != nil {
 			return time.Duration(0), fmt.Errorf(errFmtDecodeHookCouldNotParse, dataStr, prefixType, expectedType, err)
 		}
+
+		if negative {
+			result = -result
+		}
 	case f.Kind() == reflect.Int:
 		seconds := data.(int)
 
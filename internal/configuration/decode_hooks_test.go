@@ -349,6 +349,25 @@ func TestToTimeDurationHookFunc(t *testing.T) {
 			err:    "could not decode 'abc' to a time.Duration: could not parse 'abc' as a duration",
 			decode: true,
 		},
+		{
+			desc:   "ShouldDecodeNegativeNumericString",
+			have:   "-1",
+			want:   -time.Second,
+			decode: true,
+		},
+		{
+			desc:   "ShouldDecodeNegativeDurationString",
+			have:   "-2h",
+			want:   -time.Hour * 2,
+			decode: true,
+		},
+		{
+			desc:   "ShouldNotDecodeInvalidNegativeString",
+			have:   "-abc",
+			want:   time.Duration(0),
+			err:    "could not decode '-abc' to a time.Duration: could not parse 'abc' as a duration",
+			decode: true,
+		},
 		{
 			desc:   "ShouldDecodeIntToSeconds",
 			have:   60,
@@ -0,0 +1,188 @@
+package configuration
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Diff compares two configurations and returns the keys which have changed in the order they're defined. The values
+// are only included for simple types and are always redacted for secret keys.
+func Diff(before, after any) (changes []Change) {
+	diff("", reflect.ValueOf(before), reflect.ValueOf(after), &changes)
+
+	return changes
+}
+
+// Change represents a key which has changed between two configurations.
+type Change struct {
+	Key    string `json:"key"`
+	Before string `json:"before,omitempty"`
+	After  string `json:"after,omitempty"`
+}
+
+// HasPrefix returns true if the key of the Change is equal to or is a descendant of any of the provided keys.
+func (c Change) HasPrefix(keys ...string) bool {
+	for _, key := range keys {
+		if c.Key == key || strings.HasPrefix(c.Key, key+constDelimiter) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func diff(key string, before, after reflect.Value, changes *[]Change) {
+	if before.Kind() == reflect.Pointer {
+		switch {
+		case before.IsNil() && after.IsNil():
+			return
+		case before.IsNil() || after.IsNil():
+			*changes = append(*changes, newChange(key, before, after))
+
+			return
+		}
+
+		before, after = before.Elem(), after.Elem()
+	}
+
+	switch {
+	case before.Kind() == reflect.Struct && diffIsSection(before.Type()):
+		t := before.Type()
+
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+
+			if !field.IsExported() {
+				continue
+			}
+
+			name, squash := diffFieldName(field)
+
+			switch {
+			case squash:
+				diff(key, before.Field(i), after.Field(i), changes)
+			case name != "":
+				diff(diffJoinKey(key, name), before.Field(i), after.Field(i), changes)
+			}
+		}
+	case before.Kind() == reflect.Map && before.Type().Key().Kind() == reflect.String:
+		names := map[string]struct{}{}
+
+		for _, k := range before.MapKeys() {
+			names[k.String()] = struct{}{}
+		}
+
+		for _, k := range after.MapKeys() {
+			names[k.String()] = struct{}{}
+		}
+
+		sorted := make([]string, 0, len(names))
+
+		for name := range names {
+			sorted = append(sorted, name)
+		}
+
+		sort.Strings(sorted)
+
+		for _, name := range sorted {
+			k := reflect.ValueOf(name).Convert(before.Type().Key())
+
+			b, a := before.MapIndex(k), after.MapIndex(k)
+
+			if !b.IsValid() || !a.IsValid() {
+				*changes = append(*changes, Change{Key: diffJoinKey(key, name)})
+
+				continue
+			}
+
+			diff(diffJoinKey(key, name), b, a, changes)
+		}
+	default:
+		if !reflect.DeepEqual(before.Interface(), after.Interface()) {
+			*changes = append(*changes, newChange(key, before, after))
+		}
+	}
+}
+
+// diffIsSection returns true if the struct type has at least one field which represents a configuration key, otherwise
+// the struct is considered a single value.
+func diffIsSection(t reflect.Type) bool {
+	for i := 0; i < t.NumField(); i++ {
+		if !t.Field(i).IsExported() {
+			continue
+		}
+
+		if name, squash := diffFieldName(t.Field(i)); squash || name != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func diffFieldName(field reflect.StructField) (name string, squash bool) {
+	tag, ok := field.Tag.Lookup("koanf")
+	if !ok || tag == "-" {
+		return "", false
+	}
+
+	name, opts, _ := strings.Cut(tag, ",")
+
+	return name, name == "" && opts == "squash"
+}
+
+func diffJoinKey(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+
+	return prefix + constDelimiter + name
+}
+
+func newChange(key string, before, after reflect.Value) (change Change) {
+	change.Key = key
+
+	if IsSecretKey(key) {
+		change.Before, change.After = diffRedacted, diffRedacted
+
+		return change
+	}
+
+	change.Before, change.After = diffValueString(before), diffValueString(after)
+
+	return change
+}
+
+func diffValueString(value reflect.Value) string {
+	if !value.IsValid() || (value.Kind() == reflect.Pointer && value.IsNil()) {
+		return ""
+	}
+
+	if stringer, ok := value.Interface().(fmt.Stringer); ok {
+		return stringer.String()
+	}
+
+	if value.CanAddr() {
+		if stringer, ok := value.Addr().Interface().(fmt.Stringer); ok {
+			return stringer.String()
+		}
+	}
+
+	switch value.Kind() {
+	case reflect.Pointer:
+		return diffValueString(value.Elem())
+	case reflect.Bool, reflect.String, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return fmt.Sprint(value.Interface())
+	case reflect.Slice:
+		switch value.Type().Elem().Kind() {
+		case reflect.String, reflect.Int, reflect.Bool:
+			return fmt.Sprint(value.Interface())
+		}
+	}
+
+	return ""
+}
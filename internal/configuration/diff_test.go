@@ -0,0 +1,119 @@
+package configuration
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestDiff(t *testing.T) {
+	testCases := []struct {
+		name     string
+		before   *schema.Configuration
+		after    *schema.Configuration
+		expected []Change
+	}{
+		{
+			"ShouldReturnNoChangesWhenEqual",
+			&schema.Configuration{Theme: "dark"},
+			&schema.Configuration{Theme: "dark"},
+			nil,
+		},
+		{
+			"ShouldReturnSimpleChanges",
+			&schema.Configuration{Theme: "dark", Log: schema.Log{Level: "info"}},
+			&schema.Configuration{Theme: "light", Log: schema.Log{Level: "debug"}},
+			[]Change{
+				{Key: "theme", Before: "dark", After: "light"},
+				{Key: "log.level", Before: "info", After: "debug"},
+			},
+		},
+		{
+			"ShouldReturnDurationAndSliceChanges",
+			&schema.Configuration{Regulation: schema.Regulation{BanTime: time.Minute, Administration: schema.RegulationAdministration{Groups: []string{"admins"}}}},
+			&schema.Configuration{Regulation: schema.Regulation{BanTime: time.Hour, Administration: schema.RegulationAdministration{Groups: []string{"admins", "ops"}}}},
+			[]Change{
+				{Key: "regulation.ban_time", Before: "1m0s", After: "1h0m0s"},
+				{Key: "regulation.administration.groups", Before: "[admins]", After: "[admins ops]"},
+			},
+		},
+		{
+			"ShouldRedactSecrets",
+			&schema.Configuration{TrustedDevice: schema.TrustedDevice{Secret: "abc"}},
+			&schema.Configuration{TrustedDevice: schema.TrustedDevice{Secret: "xyz"}},
+			[]Change{
+				{Key: "trusted_device.secret", Before: "<redacted>", After: "<redacted>"},
+			},
+		},
+		{
+			"ShouldReturnSectionAddedAndRemoved",
+			&schema.Configuration{Notifier: schema.Notifier{FileSystem: &schema.NotifierFileSystem{Filename: "/tmp/a"}}},
+			&schema.Configuration{Notifier: schema.Notifier{SMTP: &schema.NotifierSMTP{Username: "abc"}}},
+			[]Change{
+				{Key: "notifier.filesystem"},
+				{Key: "notifier.smtp"},
+			},
+		},
+		{
+			"ShouldReturnNestedPointerChanges",
+			&schema.Configuration{Notifier: schema.Notifier{FileSystem: &schema.NotifierFileSystem{Filename: "/tmp/a"}}},
+			&schema.Configuration{Notifier: schema.Notifier{FileSystem: &schema.NotifierFileSystem{Filename: "/tmp/b"}}},
+			[]Change{
+				{Key: "notifier.filesystem.filename", Before: "/tmp/a", After: "/tmp/b"},
+			},
+		},
+		{
+			"ShouldReturnStringerChanges",
+			&schema.Configuration{PrivacyPolicy: schema.PrivacyPolicy{PolicyURL: &url.URL{Scheme: "https", Host: "a.example.com"}}},
+			&schema.Configuration{PrivacyPolicy: schema.PrivacyPolicy{PolicyURL: &url.URL{Scheme: "https", Host: "b.example.com"}}},
+			[]Change{
+				{Key: "privacy_policy.policy_url", Before: "https://a.example.com", After: "https://b.example.com"},
+			},
+		},
+		{
+			"ShouldReturnMapChanges",
+			&schema.Configuration{Server: schema.Server{Endpoints: schema.ServerEndpoints{Authz: map[string]schema.ServerEndpointsAuthz{
+				"forward-auth": {Implementation: "ForwardAuth"},
+				"legacy":       {Implementation: "Legacy"},
+			}}}},
+			&schema.Configuration{Server: schema.Server{Endpoints: schema.ServerEndpoints{Authz: map[string]schema.ServerEndpointsAuthz{
+				"auth-request": {Implementation: "AuthRequest"},
+				"forward-auth": {Implementation: "ExtAuthz"},
+			}}}},
+			[]Change{
+				{Key: "server.endpoints.authz.auth-request"},
+				{Key: "server.endpoints.authz.forward-auth.implementation", Before: "ForwardAuth", After: "ExtAuthz"},
+				{Key: "server.endpoints.authz.legacy"},
+			},
+		},
+		{
+			"ShouldReturnSliceOfStructChanges",
+			&schema.Configuration{AccessControl: schema.AccessControl{Rules: []schema.AccessControlRule{{Policy: "one_factor"}}}},
+			&schema.Configuration{AccessControl: schema.AccessControl{Rules: []schema.AccessControlRule{{Policy: "two_factor"}}}},
+			[]Change{
+				{Key: "access_control.rules"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, Diff(tc.before, tc.after))
+		})
+	}
+}
+
+func TestChangeHasPrefix(t *testing.T) {
+	change := Change{Key: "notifier.smtp.address"}
+
+	assert.True(t, change.HasPrefix("notifier"))
+	assert.True(t, change.HasPrefix("log", "notifier.smtp"))
+	assert.True(t, change.HasPrefix("notifier.smtp.address"))
+	assert.False(t, change.HasPrefix("notifier.sm"))
+	assert.False(t, change.HasPrefix("notifier.smtp.address.host"))
+	assert.False(t, change.HasPrefix())
+}
@@ -0,0 +1,213 @@
+package configuration
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// Dump returns a yaml.Node representing the configuration with the values of secret keys redacted. If the provenance
+// is not nil each key is annotated with a comment describing the origin of its value, and the descriptions are also
+// returned keyed by the configuration key. Keys without an origin are described as having the default value.
+func Dump(config any, provenance Provenance) (node *yaml.Node, origins map[string]string) {
+	d := &dumper{provenance: provenance}
+
+	if provenance != nil {
+		d.origins = map[string]string{}
+	}
+
+	if node = d.value("", reflect.ValueOf(config), false); node == nil {
+		node = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	}
+
+	return &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{node}}, d.origins
+}
+
+type dumper struct {
+	provenance Provenance
+	origins    map[string]string
+}
+
+// value returns the yaml.Node for the value of the key, or nil if the value should be omitted. The annotated value is
+// true if an ancestor of the key has already been annotated with its origin.
+func (d *dumper) value(key string, value reflect.Value, annotated bool) (node *yaml.Node) {
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+
+		value = value.Elem()
+	}
+
+	if !value.IsValid() {
+		return nil
+	}
+
+	if value.Kind() == reflect.Struct && diffIsSection(value.Type()) {
+		node = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+
+		d.fields(node, key, value, annotated)
+
+		return node
+	}
+
+	if IsSecretKey(strings.ReplaceAll(key, "[]", "")) {
+		if value.IsZero() {
+			return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str"}
+		}
+
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: diffRedacted}
+	}
+
+	if s, ok := dumpStringer(value); ok {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: s}
+	}
+
+	switch value.Kind() {
+	case reflect.Map:
+		if value.IsNil() || value.Type().Key().Kind() != reflect.String {
+			return nil
+		}
+
+		node = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+
+		names := make([]string, 0, value.Len())
+
+		for _, k := range value.MapKeys() {
+			names = append(names, k.String())
+		}
+
+		sort.Strings(names)
+
+		for _, name := range names {
+			d.entry(node, diffJoinKey(key, name), name, value.MapIndex(reflect.ValueOf(name).Convert(value.Type().Key())), annotated)
+		}
+
+		return node
+	case reflect.Slice, reflect.Array:
+		if value.Kind() == reflect.Slice && value.IsNil() {
+			return nil
+		}
+
+		node = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+
+		for i := 0; i < value.Len(); i++ {
+			if child := d.value(key+"[]", value.Index(i), true); child != nil {
+				node.Content = append(node.Content, child)
+			}
+		}
+
+		return node
+	case reflect.Bool, reflect.String, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		node = &yaml.Node{}
+
+		if err := node.Encode(value.Interface()); err != nil {
+			return nil
+		}
+
+		return node
+	default:
+		return nil
+	}
+}
+
+func (d *dumper) fields(node *yaml.Node, key string, value reflect.Value, annotated bool) {
+	t := value.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		if !field.IsExported() {
+			continue
+		}
+
+		name, squash := diffFieldName(field)
+
+		switch {
+		case squash:
+			v := value.Field(i)
+
+			if v.Kind() == reflect.Pointer {
+				if v.IsNil() {
+					continue
+				}
+
+				v = v.Elem()
+			}
+
+			if v.Kind() == reflect.Struct {
+				d.fields(node, key, v, annotated)
+			}
+		case name != "":
+			d.entry(node, diffJoinKey(key, name), name, value.Field(i), annotated)
+		}
+	}
+}
+
+// entry appends the key and the value of the key to the mapping node annotating it with the origin of the value if
+// required.
+func (d *dumper) entry(node *yaml.Node, key, name string, value reflect.Value, annotated bool) {
+	var (
+		description string
+		described   bool
+	)
+
+	if d.provenance != nil && !annotated {
+		description, described = d.provenance.Describe(key)
+	}
+
+	child := d.value(key, value, annotated || described)
+	if child == nil {
+		return
+	}
+
+	keyNode := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: name}
+
+	if d.provenance != nil && !annotated {
+		if !described && child.Kind != yaml.MappingNode {
+			description, described = "default", true
+		}
+
+		if described {
+			keyNode.LineComment = description
+
+			d.origins[key] = description
+		}
+	}
+
+	node.Content = append(node.Content, keyNode, child)
+}
+
+func dumpStringer(value reflect.Value) (s string, ok bool) {
+	if interval, isInterval := value.Interface().(schema.RefreshIntervalDuration); isInterval {
+		switch {
+		case interval.Always():
+			return schema.ProfileRefreshAlways, true
+		case interval.Never():
+			return schema.ProfileRefreshDisabled, true
+		default:
+			return interval.Value().String(), true
+		}
+	}
+
+	var stringer fmt.Stringer
+
+	if stringer, ok = value.Interface().(fmt.Stringer); ok {
+		return stringer.String(), true
+	}
+
+	if value.CanAddr() {
+		if stringer, ok = value.Addr().Interface().(fmt.Stringer); ok {
+			return stringer.String(), true
+		}
+	}
+
+	return "", false
+}
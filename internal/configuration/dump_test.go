@@ -0,0 +1,105 @@
+package configuration
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestDump(t *testing.T) {
+	config := &schema.Configuration{
+		Theme: "dark",
+		Log:   schema.Log{Level: "debug", KeepStdout: true},
+		Regulation: schema.Regulation{
+			MaxRetries: 3,
+			FindTime:   time.Minute,
+		},
+		Session: schema.Session{
+			Secret: "insecure_session_secret",
+		},
+	}
+
+	node, origins := Dump(config, nil)
+
+	assert.Nil(t, origins)
+
+	var actual map[string]any
+
+	require.NoError(t, node.Decode(&actual))
+
+	assert.Equal(t, "dark", actual["theme"])
+	assert.Equal(t, map[string]any{"level": "debug", "format": "", "file_path": "", "keep_stdout": true}, actual["log"])
+	assert.Equal(t, 3, actual["regulation"].(map[string]any)["max_retries"])
+	assert.Equal(t, "1m0s", actual["regulation"].(map[string]any)["find_time"])
+	assert.Equal(t, "<redacted>", actual["session"].(map[string]any)["secret"])
+
+	assert.NotContains(t, actual["notifier"], "smtp")
+}
+
+type testDumpConfiguration struct {
+	Name    string            `koanf:"name"`
+	Port    int               `koanf:"port"`
+	URL     *url.URL          `koanf:"url"`
+	Nested  testDumpNested    `koanf:"nested"`
+	Pointer *testDumpNested   `koanf:"pointer"`
+	Items   []testDumpNested  `koanf:"items"`
+	Values  map[string]string `koanf:"values"`
+	Ignored string            `koanf:"-"`
+}
+
+type testDumpNested struct {
+	Path     string        `koanf:"path"`
+	Password string        `koanf:"password"`
+	Timeout  time.Duration `koanf:"timeout"`
+}
+
+func TestDumpWithProvenance(t *testing.T) {
+	config := &testDumpConfiguration{
+		Name:    "example",
+		Port:    9091,
+		URL:     &url.URL{Scheme: "https", Host: "example.com"},
+		Nested:  testDumpNested{Path: "/a", Password: "abc", Timeout: time.Second},
+		Items:   []testDumpNested{{Path: "/b", Password: "xyz"}},
+		Values:  map[string]string{"b": "2", "a": "1"},
+		Ignored: "ignored",
+	}
+
+	provenance := Provenance{
+		"name":        {Source: NewMapSource(nil), Key: "name"},
+		"nested.path": {Source: NewEnvironmentSource(DefaultEnvPrefix, DefaultEnvDelimiter), Key: "nested.path"},
+		"items":       {Source: NewCommandLineSourceWithMapping(nil, nil, false, false), Key: "items"},
+	}
+
+	node, origins := Dump(config, provenance)
+
+	out, err := yaml.Marshal(node)
+
+	require.NoError(t, err)
+
+	assert.Equal(t, `name: example # command defaults
+port: 9091 # default
+url: https://example.com # default
+nested:
+    path: /a # environment variable AUTHELIA_NESTED_PATH
+    password: <redacted> # default
+    timeout: 1s # default
+items: # command-line
+    - path: /b
+      password: <redacted>
+      timeout: 0s
+values:
+    a: "1" # default
+    b: "2" # default
+`, string(out))
+
+	assert.Equal(t, "command-line", origins["items"])
+	assert.Equal(t, "default", origins["values.a"])
+	assert.NotContains(t, origins, "items[].path")
+	assert.NotContains(t, origins, "pointer")
+}
@@ -0,0 +1,576 @@
+package configuration
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/authelia/jsonschema"
+	"gopkg.in/yaml.v3"
+
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// NewJSONSchema returns the JSON Schema for the type of the value such as the schema.Configuration or the
+// authentication.FileUserDatabase.
+func NewJSONSchema(v any) *jsonschema.Schema {
+	r := &jsonschema.Reflector{
+		RequiredFromJSONSchemaTags: true,
+		Mapper:                     JSONSchemaMapper,
+	}
+
+	return r.Reflect(v)
+}
+
+// JSONSchemaMapper returns the JSON Schema for types which are decoded from strings by the decode hooks, otherwise it
+// returns nil.
+func JSONSchemaMapper(t reflect.Type) *jsonschema.Schema {
+	switch t.String() {
+	case "regexp.Regexp", "*regexp.Regexp":
+		return &jsonschema.Schema{
+			Type:   jsonschema.TypeString,
+			Format: jsonschema.FormatStringRegex,
+		}
+	case "time.Duration", "*time.Duration":
+		return &jsonschema.Schema{
+			OneOf: []*jsonschema.Schema{
+				{
+					Type:    jsonschema.TypeString,
+					Pattern: `^\d+\s*(y|M|w|d|h|m|s|ms|((year|month|week|day|hour|minute|second|millisecond)s?))(\s*\d+\s*(y|M|w|d|h|m|s|ms|((year|month|week|day|hour|minute|second|millisecond)s?)))*$`,
+				},
+				{
+					Type:        jsonschema.TypeInteger,
+					Description: "The duration in seconds",
+				},
+			},
+		}
+	case "schema.CryptographicKey":
+		return &jsonschema.Schema{
+			Type:    jsonschema.TypeString,
+			Pattern: `^-{5}BEGIN (((RSA|EC) )?(PRIVATE|PUBLIC) KEY|CERTIFICATE)-{5}\n([a-zA-Z0-9\/+]{1,64}\n)+([a-zA-Z0-9\/+]{1,64}[=]{0,2})\n-{5}END (((RSA|EC) )?(PRIVATE|PUBLIC) KEY|CERTIFICATE)-{5}\n?$`,
+		}
+	case "schema.CryptographicPrivateKey":
+		return &jsonschema.Schema{
+			Type:    jsonschema.TypeString,
+			Pattern: `^-{5}BEGIN ((RSA|EC) )?PRIVATE KEY-{5}\n([a-zA-Z0-9\/+]{1,64}\n)+([a-zA-Z0-9\/+]{1,64}[=]{0,2})\n-{5}END ((RSA|EC) )?PRIVATE KEY-{5}\n?$`,
+		}
+	case "rsa.PrivateKey", "*rsa.PrivateKey":
+		return &jsonschema.Schema{
+			Type:    jsonschema.TypeString,
+			Pattern: `^-{5}(BEGIN (RSA )?PRIVATE KEY-{5}\n([a-zA-Z0-9\/+]{1,64}\n)+([a-zA-Z0-9\/+]{1,64}[=]{0,2})\n-{5}END (RSA )?PRIVATE KEY-{5}\n?)+$`,
+		}
+	case "ecdsa.PrivateKey", "*.ecdsa.PrivateKey":
+		return &jsonschema.Schema{
+			Type:    jsonschema.TypeString,
+			Pattern: `^-{5}(BEGIN ((EC )?PRIVATE KEY-{5}\n([a-zA-Z0-9\/+]{1,64}\n)+([a-zA-Z0-9\/+]{1,64}[=]{0,2})\n-{5}END (EC )?PRIVATE KEY-{5}\n?)+$`,
+		}
+	case "mail.Address", "*mail.Address":
+		return &jsonschema.Schema{
+			Type:   jsonschema.TypeString,
+			Format: jsonschema.FormatStringEmail,
+		}
+	}
+
+	return nil
+}
+
+// JSONSchemaViolation describes a value in a YAML or JSON document which does not conform to a JSON Schema.
+type JSONSchemaViolation struct {
+	File    string `json:"file"`
+	Path    string `json:"path"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	Level   string `json:"level"`
+	Message string `json:"message"`
+}
+
+// String returns the JSONSchemaViolation in the common file:line:column format.
+func (v JSONSchemaViolation) String() string {
+	if v.Path == "" {
+		return fmt.Sprintf("%s:%d:%d: %s: %s", v.File, v.Line, v.Column, v.Level, v.Message)
+	}
+
+	return fmt.Sprintf("%s:%d:%d: %s: %s: %s", v.File, v.Line, v.Column, v.Level, v.Path, v.Message)
+}
+
+// ValidateJSONSchema validates the YAML or JSON document against the JSON Schema without loading it and returns the
+// violations with the path, line, and column of each. Keys which are deprecated are reported as warnings, and keys
+// which have been replaced by another key in the deprecations are reported as warnings or errors depending on if they
+// are automatically mapped. Single values are permitted where a list is expected as they're decoded as a list with a
+// single item, and the oneOf keyword is treated as anyOf as it is only used to describe alternative formats.
+func ValidateJSONSchema(s *jsonschema.Schema, path string, data []byte) (violations []JSONSchemaViolation) {
+	v := &jsonschemaValidator{root: s, file: path, patterns: map[string]*regexp.Regexp{}}
+
+	node := &yaml.Node{}
+
+	if err := yaml.Unmarshal(data, node); err != nil {
+		v.parse(err)
+
+		return v.violations
+	}
+
+	if len(node.Content) == 0 {
+		node = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Line: 1, Column: 1}
+	} else {
+		node = node.Content[0]
+	}
+
+	v.validate(node, s, "")
+
+	return v.violations
+}
+
+const (
+	// JSONSchemaViolationLevelError is the JSONSchemaViolation level for values which will cause errors.
+	JSONSchemaViolationLevelError = "error"
+
+	// JSONSchemaViolationLevelWarning is the JSONSchemaViolation level for values which will cause warnings.
+	JSONSchemaViolationLevelWarning = "warning"
+)
+
+var (
+	reJSONSchemaYAMLError = regexp.MustCompile(`^yaml: line (\d+): (.+)$`)
+	reJSONSchemaIndex     = regexp.MustCompile(`\[\d+]`)
+)
+
+type jsonschemaValidator struct {
+	root       *jsonschema.Schema
+	file       string
+	patterns   map[string]*regexp.Regexp
+	violations []JSONSchemaViolation
+}
+
+func (v *jsonschemaValidator) parse(err error) {
+	violation := JSONSchemaViolation{File: v.file, Level: JSONSchemaViolationLevelError, Message: err.Error()}
+
+	if matches := reJSONSchemaYAMLError.FindStringSubmatch(err.Error()); matches != nil {
+		violation.Line, _ = strconv.Atoi(matches[1])
+		violation.Message = matches[2]
+	}
+
+	v.violations = append(v.violations, violation)
+}
+
+func (v *jsonschemaValidator) push(node *yaml.Node, level, path, format string, args ...any) {
+	v.violations = append(v.violations, JSONSchemaViolation{
+		File:    v.file,
+		Path:    path,
+		Line:    node.Line,
+		Column:  node.Column,
+		Level:   level,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (v *jsonschemaValidator) validate(node *yaml.Node, s *jsonschema.Schema, path string) {
+	if s == nil {
+		return
+	}
+
+	node = jsonschemaAlias(node)
+
+	if s.Ref != "" {
+		v.validate(node, v.ref(s.Ref), path)
+	}
+
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!null" {
+		return
+	}
+
+	for _, sub := range s.AllOf {
+		v.validate(node, sub, path)
+	}
+
+	v.alternatives(node, s.OneOf, path)
+	v.alternatives(node, s.AnyOf, path)
+
+	if !v.typed(node, s, path) {
+		return
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		v.object(node, s, path)
+	case yaml.SequenceNode:
+		v.array(node, s, path)
+	case yaml.ScalarNode:
+		v.scalar(node, s, path)
+	}
+}
+
+func (v *jsonschemaValidator) ref(ref string) *jsonschema.Schema {
+	name, ok := strings.CutPrefix(ref, "#/$defs/")
+	if !ok {
+		return nil
+	}
+
+	return v.root.Definitions[name]
+}
+
+func (v *jsonschemaValidator) alternatives(node *yaml.Node, schemas []*jsonschema.Schema, path string) {
+	if len(schemas) == 0 {
+		return
+	}
+
+	var types, keys []string
+
+	for _, s := range schemas {
+		sub := &jsonschemaValidator{root: v.root, file: v.file, patterns: v.patterns}
+
+		sub.validate(node, s, path)
+
+		if !sub.failed() {
+			return
+		}
+
+		if s.Ref != "" {
+			if ref := v.ref(s.Ref); ref != nil {
+				s = ref
+			}
+		}
+
+		if s.Type != "" {
+			types = append(types, s.Type)
+		}
+
+		keys = append(keys, s.Required...)
+	}
+
+	switch {
+	case len(types) != 0:
+		break
+	case len(keys) != 0:
+		v.push(node, JSONSchemaViolationLevelError, path, "expected one of the keys %s to be configured", utils.StringJoinOr(keys))
+
+		return
+	default:
+		v.push(node, JSONSchemaViolationLevelError, path, "the value does not match any of the permitted formats")
+
+		return
+	}
+
+	v.push(node, JSONSchemaViolationLevelError, path, "the value does not match any of the permitted formats: expected one of %s but got %s", utils.StringJoinOr(types), jsonschemaType(node))
+}
+
+func (v *jsonschemaValidator) failed() bool {
+	for _, violation := range v.violations {
+		if violation.Level == JSONSchemaViolationLevelError {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (v *jsonschemaValidator) typed(node *yaml.Node, s *jsonschema.Schema, path string) (ok bool) {
+	switch s.Type {
+	case jsonschema.TypeObject:
+		ok = node.Kind == yaml.MappingNode
+	case jsonschema.TypeArray:
+		ok = node.Kind == yaml.SequenceNode || node.Kind == yaml.ScalarNode
+	case jsonschema.TypeString:
+		ok = node.Kind == yaml.ScalarNode
+	case jsonschema.TypeInteger:
+		ok = node.Kind == yaml.ScalarNode && (node.Tag == "!!int" || node.Tag == "!!str" && jsonschemaIsInteger(node.Value))
+	case jsonschema.TypeNumber:
+		ok = node.Kind == yaml.ScalarNode && (node.Tag == "!!int" || node.Tag == "!!float" || node.Tag == "!!str" && jsonschemaIsNumber(node.Value))
+	case jsonschema.TypeBoolean:
+		ok = node.Kind == yaml.ScalarNode && (node.Tag == "!!bool" || node.Tag == "!!str" && jsonschemaIsBoolean(node.Value))
+	default:
+		return true
+	}
+
+	if !ok {
+		v.push(node, JSONSchemaViolationLevelError, path, "expected a value of type %s but got %s", s.Type, jsonschemaType(node))
+	}
+
+	return ok
+}
+
+func (v *jsonschemaValidator) object(node *yaml.Node, s *jsonschema.Schema, path string) {
+	seen := map[string]bool{}
+
+	for _, pair := range jsonschemaPairs(node) {
+		key, value := pair[0], pair[1]
+		name := key.Value
+		full := diffJoinKey(path, name)
+
+		seen[name] = true
+
+		if s.Properties != nil {
+			if property, ok := s.Properties.Get(name); ok {
+				if ps, ok := property.(*jsonschema.Schema); ok {
+					if ps.Deprecated {
+						v.push(key, JSONSchemaViolationLevelWarning, full, "the key is deprecated")
+					}
+
+					v.validate(value, ps, full)
+				}
+
+				continue
+			}
+		}
+
+		if v.patternProperties(value, s, name, full) {
+			continue
+		}
+
+		switch s.AdditionalProperties {
+		case nil, jsonschema.TrueSchema:
+			continue
+		case jsonschema.FalseSchema:
+			if !v.deprecated(key, full) {
+				v.push(key, JSONSchemaViolationLevelError, full, "configuration key not expected: %s", full)
+			}
+		default:
+			v.validate(value, s.AdditionalProperties, full)
+		}
+	}
+
+	for _, name := range s.Required {
+		if !seen[name] {
+			v.push(node, JSONSchemaViolationLevelError, diffJoinKey(path, name), "the key is required but it's absent")
+		}
+	}
+}
+
+func (v *jsonschemaValidator) patternProperties(node *yaml.Node, s *jsonschema.Schema, name, path string) (matched bool) {
+	patterns := make([]string, 0, len(s.PatternProperties))
+
+	for pattern := range s.PatternProperties {
+		patterns = append(patterns, pattern)
+	}
+
+	sort.Strings(patterns)
+
+	for _, pattern := range patterns {
+		if re := v.pattern(pattern); re != nil && re.MatchString(name) {
+			matched = true
+
+			v.validate(node, s.PatternProperties[pattern], path)
+		}
+	}
+
+	return matched
+}
+
+func (v *jsonschemaValidator) deprecated(node *yaml.Node, path string) bool {
+	key := reJSONSchemaIndex.ReplaceAllString(path, "[]")
+
+	if d, ok := deprecations[key]; ok {
+		if d.AutoMap {
+			v.push(node, JSONSchemaViolationLevelWarning, path, errFmtAutoMapKey, d.Key, d.Version.String(), d.NewKey, d.Version.NextMajor().String())
+		} else {
+			v.push(node, JSONSchemaViolationLevelError, path, "invalid configuration key '%s' was replaced by '%s'", d.Key, d.NewKey)
+		}
+
+		return true
+	}
+
+	for _, dm := range deprecationsMKM {
+		for _, k := range dm.Keys {
+			if k == key {
+				v.push(node, JSONSchemaViolationLevelWarning, path, "configuration key '%s' is deprecated in %s and has been replaced by '%s'", key, dm.Version.String(), dm.NewKey)
+
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (v *jsonschemaValidator) array(node *yaml.Node, s *jsonschema.Schema, path string) {
+	n := len(node.Content)
+
+	if s.MinItems != 0 && n < s.MinItems {
+		v.push(node, JSONSchemaViolationLevelError, path, "expected at least %d items but got %d", s.MinItems, n)
+	}
+
+	if s.MaxItems != 0 && n > s.MaxItems {
+		v.push(node, JSONSchemaViolationLevelError, path, "expected at most %d items but got %d", s.MaxItems, n)
+	}
+
+	values := map[string]bool{}
+
+	for i, item := range node.Content {
+		item = jsonschemaAlias(item)
+
+		index := fmt.Sprintf("%s[%d]", path, i)
+
+		if s.UniqueItems && item.Kind == yaml.ScalarNode {
+			if values[item.Value] {
+				v.push(item, JSONSchemaViolationLevelError, index, "the value '%s' is duplicated", item.Value)
+			}
+
+			values[item.Value] = true
+		}
+
+		v.validate(item, s.Items, index)
+	}
+}
+
+func (v *jsonschemaValidator) scalar(node *yaml.Node, s *jsonschema.Schema, path string) {
+	if len(s.Enum) != 0 {
+		options := make([]string, len(s.Enum))
+
+		for i, option := range s.Enum {
+			options[i] = fmt.Sprint(option)
+		}
+
+		if !utils.IsStringInSlice(node.Value, options) {
+			v.push(node, JSONSchemaViolationLevelError, path, "the value '%s' is not one of %s", node.Value, utils.StringJoinOr(options))
+		}
+	}
+
+	if s.Pattern != "" {
+		if re := v.pattern(s.Pattern); re != nil && !re.MatchString(node.Value) {
+			v.push(node, JSONSchemaViolationLevelError, path, "the value does not match the pattern '%s'", s.Pattern)
+		}
+	}
+
+	if n := utf8.RuneCountInString(node.Value); s.MinLength != 0 && n < s.MinLength {
+		v.push(node, JSONSchemaViolationLevelError, path, "expected a value with a length of at least %d but got %d", s.MinLength, n)
+	} else if s.MaxLength != 0 && n > s.MaxLength {
+		v.push(node, JSONSchemaViolationLevelError, path, "expected a value with a length of at most %d but got %d", s.MaxLength, n)
+	}
+
+	if s.Type == jsonschema.TypeInteger || s.Type == jsonschema.TypeNumber {
+		v.numeric(node, s, path)
+	}
+
+	v.format(node, s, path)
+}
+
+func (v *jsonschemaValidator) numeric(node *yaml.Node, s *jsonschema.Schema, path string) {
+	var value float64
+
+	if i, err := strconv.ParseInt(node.Value, 0, 64); err == nil {
+		value = float64(i)
+	} else if value, err = strconv.ParseFloat(node.Value, 64); err != nil {
+		return
+	}
+
+	minimum, maximum := float64(s.Minimum), float64(s.Maximum)
+
+	switch {
+	case s.Minimum != 0 && s.ExclusiveMinimum && value <= minimum:
+		v.push(node, JSONSchemaViolationLevelError, path, "expected a value greater than %d but got %s", s.Minimum, node.Value)
+	case s.Minimum != 0 && value < minimum:
+		v.push(node, JSONSchemaViolationLevelError, path, "expected a value of at least %d but got %s", s.Minimum, node.Value)
+	case s.Maximum != 0 && s.ExclusiveMaximum && value >= maximum:
+		v.push(node, JSONSchemaViolationLevelError, path, "expected a value less than %d but got %s", s.Maximum, node.Value)
+	case s.Maximum != 0 && value > maximum:
+		v.push(node, JSONSchemaViolationLevelError, path, "expected a value of at most %d but got %s", s.Maximum, node.Value)
+	}
+}
+
+func (v *jsonschemaValidator) format(node *yaml.Node, s *jsonschema.Schema, path string) {
+	var err error
+
+	switch s.Format {
+	case jsonschema.FormatStringRegex:
+		_, err = regexp.Compile(node.Value)
+	case jsonschema.FormatStringEmail:
+		_, err = mail.ParseAddress(node.Value)
+	case jsonschema.FormatStringURI:
+		_, err = url.Parse(node.Value)
+	default:
+		return
+	}
+
+	if err != nil {
+		v.push(node, JSONSchemaViolationLevelError, path, "the value is not a valid %s: %v", s.Format, err)
+	}
+}
+
+func (v *jsonschemaValidator) pattern(pattern string) *regexp.Regexp {
+	re, ok := v.patterns[pattern]
+	if !ok {
+		re, _ = regexp.Compile(pattern)
+
+		v.patterns[pattern] = re
+	}
+
+	return re
+}
+
+func jsonschemaAlias(node *yaml.Node) *yaml.Node {
+	for node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+
+	return node
+}
+
+// jsonschemaPairs returns the key and value pairs of the mapping node including the pairs from merge keys.
+func jsonschemaPairs(node *yaml.Node) (pairs [][2]*yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+
+		if key.Tag != "!!merge" {
+			pairs = append(pairs, [2]*yaml.Node{key, value})
+
+			continue
+		}
+
+		switch value = jsonschemaAlias(value); value.Kind {
+		case yaml.MappingNode:
+			pairs = append(pairs, jsonschemaPairs(value)...)
+		case yaml.SequenceNode:
+			for _, item := range value.Content {
+				if item = jsonschemaAlias(item); item.Kind == yaml.MappingNode {
+					pairs = append(pairs, jsonschemaPairs(item)...)
+				}
+			}
+		}
+	}
+
+	return pairs
+}
+
+func jsonschemaType(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return jsonschema.TypeObject
+	case yaml.SequenceNode:
+		return jsonschema.TypeArray
+	}
+
+	switch node.Tag {
+	case "!!int":
+		return jsonschema.TypeInteger
+	case "!!float":
+		return jsonschema.TypeNumber
+	case "!!bool":
+		return jsonschema.TypeBoolean
+	case "!!null":
+		return jsonschema.TypeNull
+	default:
+		return jsonschema.TypeString
+	}
+}
+
+func jsonschemaIsInteger(value string) bool {
+	_, err := strconv.ParseInt(value, 0, 64)
+
+	return err == nil
+}
+
+func jsonschemaIsNumber(value string) bool {
+	_, err := strconv.ParseFloat(value, 64)
+
+	return err == nil
+}
+
+func jsonschemaIsBoolean(value string) bool {
+	_, err := strconv.ParseBool(value)
+
+	return err == nil
+}
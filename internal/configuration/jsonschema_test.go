@@ -0,0 +1,138 @@
+package configuration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/authelia/jsonschema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testJSONSchemaConfiguration struct {
+	Theme   string                        `json:"theme" jsonschema:"enum=light,enum=dark"`
+	Port    int                           `json:"port" jsonschema:"minimum=1,maximum=65535"`
+	Timeout time.Duration                 `json:"timeout"`
+	Enabled bool                          `json:"enabled"`
+	Legacy  string                        `json:"legacy" jsonschema:"deprecated"`
+	Rules   []testJSONSchemaRule          `json:"rules"`
+	Users   map[string]testJSONSchemaUser `json:"users"`
+}
+
+type testJSONSchemaRule struct {
+	Domain      []string `json:"domain" jsonschema:"oneof_required=Domain,uniqueItems"`
+	DomainRegex []string `json:"domain_regex" jsonschema:"oneof_required=Domain Regex"`
+	Policy      string   `json:"policy" jsonschema:"required,enum=bypass,enum=deny"`
+}
+
+type testJSONSchemaUser struct {
+	Email string `json:"email" jsonschema:"format=email"`
+}
+
+func TestNewJSONSchema(t *testing.T) {
+	s := NewJSONSchema(&testJSONSchemaConfiguration{})
+
+	require.NotNil(t, s)
+	require.Contains(t, s.Definitions, "testJSONSchemaConfiguration")
+
+	timeout, ok := s.Definitions["testJSONSchemaConfiguration"].Properties.Get("timeout")
+	require.True(t, ok)
+	require.Len(t, timeout.(*jsonschema.Schema).OneOf, 2)
+
+	assert.Equal(t, jsonschema.TypeString, timeout.(*jsonschema.Schema).OneOf[0].Type)
+	assert.Equal(t, jsonschema.TypeInteger, timeout.(*jsonschema.Schema).OneOf[1].Type)
+}
+
+func TestValidateJSONSchema(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     string
+		expected []JSONSchemaViolation
+	}{
+		{
+			"ShouldValidateEmpty",
+			"",
+			nil,
+		},
+		{
+			"ShouldValidateValid",
+			"theme: dark\nport: 9091\ntimeout: 1h\nenabled: true\nrules:\n  - domain: example.com\n    policy: bypass\n  - domain:\n      - a.example.com\n      - b.example.com\n    policy: deny\nusers:\n  john:\n    email: john@example.com\n",
+			nil,
+		},
+		{
+			"ShouldValidateValidJSON",
+			`{"theme": "light", "port": "9091", "timeout": 60, "rules": [{"domain_regex": "^.*$", "policy": "deny"}]}`,
+			nil,
+		},
+		{
+			"ShouldValidateAnchorsAndMergeKeys",
+			"defaults: &defaults\n  policy: deny\nrules:\n  - <<: *defaults\n    domain: example.com\n",
+			[]JSONSchemaViolation{
+				{File: "config.yml", Path: "defaults", Line: 1, Column: 1, Level: JSONSchemaViolationLevelError, Message: "configuration key not expected: defaults"},
+			},
+		},
+		{
+			"ShouldReportUnknownKeys",
+			"theme: dark\nrules:\n  - policy: deny\n    domains: example.com\n",
+			[]JSONSchemaViolation{
+				{File: "config.yml", Path: "rules[0]", Line: 3, Column: 5, Level: JSONSchemaViolationLevelError, Message: "expected one of the keys 'domain' or 'domain_regex' to be configured"},
+				{File: "config.yml", Path: "rules[0].domains", Line: 4, Column: 5, Level: JSONSchemaViolationLevelError, Message: "configuration key not expected: rules[0].domains"},
+			},
+		},
+		{
+			"ShouldReportInvalidValues",
+			"theme: blue\nport: 0x10000\ntimeout: 1 fortnight\nenabled: maybe\n",
+			[]JSONSchemaViolation{
+				{File: "config.yml", Path: "theme", Line: 1, Column: 8, Level: JSONSchemaViolationLevelError, Message: "the value 'blue' is not one of 'light' or 'dark'"},
+				{File: "config.yml", Path: "port", Line: 2, Column: 7, Level: JSONSchemaViolationLevelError, Message: "expected a value of at most 65535 but got 0x10000"},
+				{File: "config.yml", Path: "timeout", Line: 3, Column: 10, Level: JSONSchemaViolationLevelError, Message: "the value does not match any of the permitted formats: expected one of 'string' or 'integer' but got string"},
+				{File: "config.yml", Path: "enabled", Line: 4, Column: 10, Level: JSONSchemaViolationLevelError, Message: "expected a value of type boolean but got string"},
+			},
+		},
+		{
+			"ShouldReportStructuralErrors",
+			"rules:\n  policy: deny\nusers:\n  - john\n",
+			[]JSONSchemaViolation{
+				{File: "config.yml", Path: "rules", Line: 2, Column: 3, Level: JSONSchemaViolationLevelError, Message: "expected a value of type array but got object"},
+				{File: "config.yml", Path: "users", Line: 4, Column: 3, Level: JSONSchemaViolationLevelError, Message: "expected a value of type object but got array"},
+			},
+		},
+		{
+			"ShouldReportRequiredAndDuplicates",
+			"rules:\n  - domain:\n      - example.com\n      - example.com\nusers:\n  john:\n    email: not an email\n",
+			[]JSONSchemaViolation{
+				{File: "config.yml", Path: "rules[0].domain[1]", Line: 4, Column: 9, Level: JSONSchemaViolationLevelError, Message: "the value 'example.com' is duplicated"},
+				{File: "config.yml", Path: "rules[0].policy", Line: 2, Column: 5, Level: JSONSchemaViolationLevelError, Message: "the key is required but it's absent"},
+				{File: "config.yml", Path: "users.john.email", Line: 7, Column: 12, Level: JSONSchemaViolationLevelError, Message: "the value is not a valid email: mail: no angle-addr"},
+			},
+		},
+		{
+			"ShouldReportDeprecatedKeys",
+			"legacy: value\njwt_secret: abc\n",
+			[]JSONSchemaViolation{
+				{File: "config.yml", Path: "legacy", Line: 1, Column: 1, Level: JSONSchemaViolationLevelWarning, Message: "the key is deprecated"},
+				{File: "config.yml", Path: "jwt_secret", Line: 2, Column: 1, Level: JSONSchemaViolationLevelWarning, Message: "configuration key 'jwt_secret' is deprecated in 4.38.0 and has been replaced by 'identity_validation.reset_password.jwt_secret': you are not required to make any changes as this has been automatically mapped for you, but to stop this warning being logged you will need to adjust your configuration, and this configuration key and auto-mapping is likely to be removed in 5.0.0"},
+			},
+		},
+		{
+			"ShouldReportParseErrors",
+			"theme: dark\n  port: 9091\n",
+			[]JSONSchemaViolation{
+				{File: "config.yml", Line: 2, Level: JSONSchemaViolationLevelError, Message: "mapping values are not allowed in this context"},
+			},
+		},
+	}
+
+	s := NewJSONSchema(&testJSONSchemaConfiguration{})
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, ValidateJSONSchema(s, "config.yml", []byte(tc.have)))
+		})
+	}
+}
+
+func TestJSONSchemaViolationString(t *testing.T) {
+	assert.Equal(t, "config.yml:4:5: error: rules[0].domains: configuration key not expected: rules[0].domains", JSONSchemaViolation{File: "config.yml", Path: "rules[0].domains", Line: 4, Column: 5, Level: JSONSchemaViolationLevelError, Message: "configuration key not expected: rules[0].domains"}.String())
+	assert.Equal(t, "config.yml:2:0: error: mapping values are not allowed in this context", JSONSchemaViolation{File: "config.yml", Line: 2, Level: JSONSchemaViolationLevelError, Message: "mapping values are not allowed in this context"}.String())
+}
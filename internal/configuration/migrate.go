@@ -0,0 +1,494 @@
+package configuration
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/model"
+)
+
+// Migration represents a deprecated configuration key or keys found in a YAML document by MigrateYAML.
+type Migration struct {
+	Keys    []string
+	NewKey  string
+	Version model.SemanticVersion
+
+	// Manual is true if the deprecated keys were not migrated and must be migrated manually.
+	Manual bool
+	Reason string
+}
+
+// String returns a human readable description of the Migration.
+func (m Migration) String() string {
+	if m.Manual {
+		return fmt.Sprintf("configuration %s deprecated in %s must be manually migrated to '%s': %s", migrationKeysString(m.Keys), m.Version, m.NewKey, m.Reason)
+	}
+
+	return fmt.Sprintf("configuration %s deprecated in %s migrated to '%s'", migrationKeysString(m.Keys), m.Version, m.NewKey)
+}
+
+func migrationKeysString(keys []string) string {
+	if len(keys) == 1 {
+		return fmt.Sprintf("key '%s' was", keys[0])
+	}
+
+	return fmt.Sprintf("keys %s were", strJoinAnd(keys))
+}
+
+// MigrateYAML rewrites the YAML document replacing the deprecated configuration keys with their replacements. The
+// comments, ordering, and template expressions of the document are preserved, however the document is re-encoded so
+// blank lines are removed and the indentation is normalized. The document is returned unmodified if no keys were
+// migrated.
+func MigrateYAML(data []byte) (migrated []byte, migrations []Migration, err error) {
+	templates := &migrateTemplates{}
+
+	root := &yaml.Node{}
+
+	if err = yaml.Unmarshal(templates.protect(data), root); err != nil {
+		return nil, nil, fmt.Errorf("error occurred parsing the YAML document: %w", err)
+	}
+
+	if len(root.Content) == 0 || root.Content[0].Kind != yaml.MappingNode {
+		return data, nil, nil
+	}
+
+	doc := root.Content[0]
+
+	for _, d := range migrateSortedDeprecations() {
+		migrations = append(migrations, migrateDeprecation(doc, d)...)
+	}
+
+	for _, d := range deprecationsMKM {
+		if migration, ok := migrateMultiKeyMappedDeprecation(doc, d); ok {
+			migrations = append(migrations, migration)
+		}
+	}
+
+	var changed bool
+
+	for _, migration := range migrations {
+		if !migration.Manual {
+			changed = true
+
+			break
+		}
+	}
+
+	if !changed {
+		return data, migrations, nil
+	}
+
+	buf := &bytes.Buffer{}
+
+	if bytes.HasPrefix(data, []byte("---")) {
+		buf.WriteString("---\n")
+	}
+
+	encoder := yaml.NewEncoder(buf)
+
+	encoder.SetIndent(2)
+
+	if err = encoder.Encode(root); err != nil {
+		return nil, nil, fmt.Errorf("error occurred encoding the YAML document: %w", err)
+	}
+
+	if err = encoder.Close(); err != nil {
+		return nil, nil, fmt.Errorf("error occurred encoding the YAML document: %w", err)
+	}
+
+	return templates.restore(buf.Bytes()), migrations, nil
+}
+
+// migrateSortedDeprecations returns the deprecations ordered by version so deprecations which were themselves later
+// deprecated are migrated in the order they occurred.
+func migrateSortedDeprecations() (ds []Deprecation) {
+	ds = make([]Deprecation, 0, len(deprecations))
+
+	for _, d := range deprecations {
+		ds = append(ds, d)
+	}
+
+	sort.Slice(ds, func(i, j int) bool {
+		if ds[i].Version.Equal(ds[j].Version) {
+			return ds[i].Key < ds[j].Key
+		}
+
+		return ds[i].Version.LessThan(ds[j].Version)
+	})
+
+	return ds
+}
+
+func migrateDeprecation(doc *yaml.Node, d Deprecation) (migrations []Migration) {
+	prefix, subkey, ok := strings.Cut(d.Key, "[]"+constDelimiter)
+	if !ok {
+		if migration, found := migrateKey(doc, d, migratePath(d.Key), migratePath(d.NewKey)); found {
+			migrations = append(migrations, migration)
+		}
+
+		return migrations
+	}
+
+	parent, i := migrateLookup(doc, migratePath(prefix))
+	if parent == nil || parent.Content[i+1].Kind != yaml.SequenceNode {
+		return nil
+	}
+
+	newkey := strings.TrimPrefix(d.NewKey, prefix+"[]"+constDelimiter)
+
+	for _, item := range parent.Content[i+1].Content {
+		if item.Kind != yaml.MappingNode {
+			continue
+		}
+
+		if migration, found := migrateKey(item, d, migratePath(subkey), migratePath(newkey)); found {
+			migrations = append(migrations, migration)
+		}
+	}
+
+	return migrations
+}
+
+func migrateKey(root *yaml.Node, d Deprecation, from, to []string) (migration Migration, found bool) {
+	parent, i := migrateLookup(root, from)
+	if parent == nil {
+		return migration, false
+	}
+
+	migration = Migration{Keys: []string{d.Key}, NewKey: d.NewKey, Version: d.Version}
+
+	if !d.AutoMap {
+		migration.Manual, migration.Reason = true, "the value can not be automatically converted to the new format"
+
+		return migration, true
+	}
+
+	if p, _ := migrateLookup(root, to); p != nil {
+		migration.Manual, migration.Reason = true, "the new key is also defined"
+
+		return migration, true
+	}
+
+	value := parent.Content[i+1]
+
+	if d.MapFunc != nil {
+		var (
+			v   any
+			err error
+		)
+
+		if err = value.Decode(&v); err == nil {
+			value, err = migrateValueNode(d.MapFunc(v), value)
+		}
+
+		if err != nil {
+			migration.Manual, migration.Reason = true, err.Error()
+
+			return migration, true
+		}
+	}
+
+	if err := migrateSet(root, from, to, value); err != nil {
+		migration.Manual, migration.Reason = true, err.Error()
+	}
+
+	return migration, true
+}
+
+func migrateMultiKeyMappedDeprecation(doc *yaml.Node, d MultiKeyMappedDeprecation) (migration Migration, found bool) {
+	var (
+		keys     = map[string]any{}
+		original *yaml.Node
+	)
+
+	for _, key := range d.Keys {
+		parent, i := migrateLookup(doc, migratePath(key))
+		if parent == nil {
+			continue
+		}
+
+		var v any
+
+		if err := parent.Content[i+1].Decode(&v); err != nil {
+			return Migration{Keys: []string{key}, NewKey: d.NewKey, Version: d.Version, Manual: true, Reason: err.Error()}, true
+		}
+
+		if len(migration.Keys) == 0 {
+			original = parent.Content[i+1]
+		}
+
+		keys[key] = v
+
+		migration.Keys = append(migration.Keys, key)
+	}
+
+	if len(migration.Keys) == 0 {
+		return migration, false
+	}
+
+	migration.NewKey, migration.Version = d.NewKey, d.Version
+
+	if p, _ := migrateLookup(doc, migratePath(d.NewKey)); p != nil {
+		migration.Manual, migration.Reason = true, "the new key is also defined"
+
+		return migration, true
+	}
+
+	val := schema.NewStructValidator()
+
+	d.MapFunc(d, keys, val)
+
+	if errs := val.Errors(); len(errs) != 0 {
+		migration.Manual, migration.Reason = true, errs[0].Error()
+
+		return migration, true
+	}
+
+	value, err := migrateValueNode(keys[d.NewKey], original)
+	if err != nil {
+		migration.Manual, migration.Reason = true, err.Error()
+
+		return migration, true
+	}
+
+	if err = migrateSet(doc, migratePath(migration.Keys[0]), migratePath(d.NewKey), value); err != nil {
+		migration.Manual, migration.Reason = true, err.Error()
+
+		return migration, true
+	}
+
+	for _, key := range migration.Keys[1:] {
+		path := migratePath(key)
+
+		if parent, i := migrateLookup(doc, path); parent != nil {
+			migrateRemove(parent, i)
+			migratePrune(doc, path)
+		}
+	}
+
+	return migration, true
+}
+
+// migrateSet moves the key at the from path to the to path with the provided value. If the parent of both paths is the
+// same the key is renamed in place, otherwise it's appended to the new parent creating it if necessary.
+func migrateSet(root *yaml.Node, from, to []string, value *yaml.Node) (err error) {
+	parent, i := migrateLookup(root, from)
+
+	key, name := parent.Content[i], to[len(to)-1]
+
+	if strings.Join(from[:len(from)-1], constDelimiter) == strings.Join(to[:len(to)-1], constDelimiter) {
+		key.Value, parent.Content[i+1] = name, value
+
+		return nil
+	}
+
+	if migrateIsTemplated(parent, i) {
+		return errors.New("the key is adjacent to a template expression which would no longer apply to it if it was moved")
+	}
+
+	var target *yaml.Node
+
+	if target, err = migrateEnsure(root, to[:len(to)-1]); err != nil {
+		return err
+	}
+
+	parent, i = migrateLookup(root, from)
+
+	migrateRemove(parent, i)
+
+	key.Value = name
+
+	target.Content = append(target.Content, key, value)
+
+	migratePrune(root, from)
+
+	return nil
+}
+
+// migrateIsTemplated returns true if the comments surrounding the key at the index of the mapping node contain a
+// template expression, which is the case when the key is wrapped in a template action such as a conditional.
+func migrateIsTemplated(parent *yaml.Node, index int) bool {
+	nodes := []*yaml.Node{parent.Content[index], parent.Content[index+1]}
+
+	if index+2 < len(parent.Content) {
+		nodes = append(nodes, parent.Content[index+2])
+	}
+
+	for _, node := range nodes {
+		for _, comment := range []string{node.HeadComment, node.LineComment, node.FootComment} {
+			if strings.Contains(comment, migrateTemplateMarkerPrefix) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// migrateLookup returns the mapping node which contains the key at the path and the index of the key within the content
+// of that mapping node, or nil and -1 if the path doesn't exist.
+func migrateLookup(node *yaml.Node, path []string) (parent *yaml.Node, index int) {
+	for i, name := range path {
+		if node == nil || node.Kind != yaml.MappingNode {
+			return nil, -1
+		}
+
+		if index = migrateIndex(node, name); index == -1 {
+			return nil, -1
+		}
+
+		if i == len(path)-1 {
+			return node, index
+		}
+
+		node = node.Content[index+1]
+	}
+
+	return nil, -1
+}
+
+// migrateEnsure returns the mapping node at the path creating any of the mapping nodes which do not exist.
+func migrateEnsure(node *yaml.Node, path []string) (target *yaml.Node, err error) {
+	for j, name := range path {
+		i := migrateIndex(node, name)
+
+		if i == -1 {
+			child := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: name}, child)
+
+			node = child
+
+			continue
+		}
+
+		child := node.Content[i+1]
+
+		switch {
+		case child.Kind == yaml.MappingNode:
+			break
+		case child.Kind == yaml.ScalarNode && child.Tag == "!!null":
+			child.Kind, child.Tag, child.Value = yaml.MappingNode, "!!map", ""
+		default:
+			return nil, fmt.Errorf("the key '%s' is not a map", strings.Join(path[:j+1], constDelimiter))
+		}
+
+		node = child
+	}
+
+	return node, nil
+}
+
+func migrateIndex(node *yaml.Node, name string) int {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func migrateRemove(parent *yaml.Node, index int) {
+	parent.Content = append(parent.Content[:index], parent.Content[index+2:]...)
+}
+
+// migratePrune removes the ancestors of the path which are empty mapping nodes.
+func migratePrune(root *yaml.Node, path []string) {
+	for n := len(path) - 1; n > 0; n-- {
+		parent, i := migrateLookup(root, path[:n])
+		if parent == nil {
+			return
+		}
+
+		if child := parent.Content[i+1]; child.Kind != yaml.MappingNode || len(child.Content) != 0 {
+			return
+		}
+
+		migrateRemove(parent, i)
+	}
+}
+
+func migrateValueNode(value any, original *yaml.Node) (node *yaml.Node, err error) {
+	node = &yaml.Node{}
+
+	if err = node.Encode(value); err != nil {
+		return nil, fmt.Errorf("error occurred encoding the new value: %w", err)
+	}
+
+	if original == nil {
+		return node, nil
+	}
+
+	if node.Kind == yaml.ScalarNode && original.Kind == yaml.ScalarNode {
+		node.Style = original.Style
+	}
+
+	node.HeadComment, node.LineComment, node.FootComment = original.HeadComment, original.LineComment, original.FootComment
+
+	return node, nil
+}
+
+func migratePath(key string) []string {
+	return strings.Split(key, constDelimiter)
+}
+
+const migrateTemplateMarkerPrefix = "__AUTHELIA_MIGRATE_TEMPLATE_"
+
+var (
+	reMigrateTemplateLine   = regexp.MustCompile(`(?m)^([ \t]*)((?:\{\{.*?}}[ \t]*)+)$`)
+	reMigrateTemplate       = regexp.MustCompile(`\{\{.*?}}`)
+	reMigrateTemplateMarker = regexp.MustCompile(`(#[ \t]*)?__AUTHELIA_MIGRATE_TEMPLATE_(\d+)__`)
+)
+
+// migrateTemplates replaces the template expressions of a document with markers which are valid YAML so the document
+// can be parsed, and restores them after the document is encoded. Template expressions which are the only content of a
+// line are replaced with a comment so they retain their position relative to the surrounding keys.
+type migrateTemplates struct {
+	expressions []string
+	lines       []bool
+}
+
+func (t *migrateTemplates) protect(data []byte) []byte {
+	data = reMigrateTemplateLine.ReplaceAllFunc(data, func(match []byte) []byte {
+		expression := bytes.TrimLeft(match, " \t")
+
+		return append(match[:len(match)-len(expression):len(match)-len(expression)], "# "+t.marker(string(expression), true)...)
+	})
+
+	return reMigrateTemplate.ReplaceAllFunc(data, func(match []byte) []byte {
+		return []byte(t.marker(string(match), false))
+	})
+}
+
+func (t *migrateTemplates) marker(expression string, line bool) string {
+	t.expressions = append(t.expressions, expression)
+	t.lines = append(t.lines, line)
+
+	return fmt.Sprintf("%s%d__", migrateTemplateMarkerPrefix, len(t.expressions)-1)
+}
+
+func (t *migrateTemplates) restore(data []byte) []byte {
+	return reMigrateTemplateMarker.ReplaceAllFunc(data, func(match []byte) []byte {
+		submatches := reMigrateTemplateMarker.FindSubmatch(match)
+
+		i, err := strconv.Atoi(string(submatches[2]))
+		if err != nil || i >= len(t.expressions) {
+			return match
+		}
+
+		if t.lines[i] {
+			return []byte(t.expressions[i])
+		}
+
+		return append(submatches[1], t.expressions[i]...)
+	})
+}
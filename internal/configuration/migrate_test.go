@@ -0,0 +1,112 @@
+package configuration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateYAML(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     string
+		expected string
+		keys     []string
+		manual   []bool
+	}{
+		{
+			"ShouldNotModifyCurrentConfiguration",
+			"# Comment.\nlog:\n    level: 'debug'\n",
+			"# Comment.\nlog:\n    level: 'debug'\n",
+			nil,
+			nil,
+		},
+		{
+			"ShouldMigrateKeysPreservingComments",
+			"---\n# Log.\nlog_level: 'debug' # Level.\ntheme: 'dark'\nauthentication_backend:\n  ldap:\n    # Skip.\n    skip_verify: true\n    user: 'cn=admin'\n",
+			"---\ntheme: 'dark'\nauthentication_backend:\n  ldap:\n    user: 'cn=admin'\n    tls:\n      # Skip.\n      skip_verify: true\nlog:\n  # Log.\n  level: 'debug' # Level.\n",
+			[]string{"authentication_backend.ldap.skip_verify", "log_level"},
+			[]bool{false, false},
+		},
+		{
+			"ShouldMigrateKeysInListItems",
+			"identity_providers:\n  oidc:\n    clients:\n      - id: 'abc'\n        public: true\n      - id: 'xyz'\n",
+			"identity_providers:\n  oidc:\n    clients:\n      - client_id: 'abc'\n        public: true\n      - client_id: 'xyz'\n",
+			[]string{"identity_providers.oidc.clients[].id", "identity_providers.oidc.clients[].id"},
+			[]bool{false, false},
+		},
+		{
+			"ShouldPreserveTemplateExpressions",
+			"log_level: {{ env \"LOG_LEVEL\" }}\ntheme: '{{ env \"THEME\" }}'\n",
+			"theme: '{{ env \"THEME\" }}'\nlog:\n  level: {{ env \"LOG_LEVEL\" }}\n",
+			[]string{"log_level"},
+			[]bool{false},
+		},
+		{
+			"ShouldNotMoveKeysWrappedInTemplateActions",
+			"{{- if true }}\njwt_secret: 'abc'\n{{- end }}\ntheme: 'dark'\nlog_level: 'debug'\n",
+			"{{- if true }}\njwt_secret: 'abc'\n{{- end }}\ntheme: 'dark'\nlog:\n  level: 'debug'\n",
+			[]string{"log_level", "jwt_secret"},
+			[]bool{false, true},
+		},
+		{
+			"ShouldNotMigrateKeysWhichCanNotBeAutomaticallyMapped",
+			"notifier:\n  smtp:\n    trusted_cert: '/certs'\n",
+			"notifier:\n  smtp:\n    trusted_cert: '/certs'\n",
+			[]string{"notifier.smtp.trusted_cert"},
+			[]bool{true},
+		},
+		{
+			"ShouldNotModifyBlankLinesOfCurrentConfiguration",
+			"theme: 'dark'\n\nlog:\n    level: 'debug'\n",
+			"theme: 'dark'\n\nlog:\n    level: 'debug'\n",
+			nil,
+			nil,
+		},
+		{
+			"ShouldRemoveBlankLinesWhenMigratingKeys",
+			"log_level: 'debug'\n\ntheme: 'dark'\n",
+			"theme: 'dark'\nlog:\n  level: 'debug'\n",
+			[]string{"log_level"},
+			[]bool{false},
+		},
+		{
+			"ShouldNotMigrateKeysWhenNewKeyExists",
+			"log_level: 'debug'\nlog:\n  level: 'info'\n",
+			"log_level: 'debug'\nlog:\n  level: 'info'\n",
+			[]string{"log_level"},
+			[]bool{true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, migrations, err := MigrateYAML([]byte(tc.have))
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, string(actual))
+
+			var (
+				keys   []string
+				manual []bool
+			)
+
+			for _, migration := range migrations {
+				keys = append(keys, migration.Keys...)
+				manual = append(manual, migration.Manual)
+			}
+
+			assert.Equal(t, tc.keys, keys)
+			assert.Equal(t, tc.manual, manual)
+		})
+	}
+}
+
+func TestMigrateYAMLShouldErrorOnInvalidYAML(t *testing.T) {
+	actual, migrations, err := MigrateYAML([]byte("log_level: [abc\n"))
+
+	assert.Nil(t, actual)
+	assert.Nil(t, migrations)
+	assert.EqualError(t, err, "error occurred parsing the YAML document: yaml: line 1: did not find expected ',' or ']'")
+}
@@ -0,0 +1,139 @@
+package configuration
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+
+	"github.com/knadh/koanf/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// Provenance records the Origin of the value of each configuration key.
+type Provenance map[string]Origin
+
+// Origin is the Source which supplied the value of a configuration key, and the key the Source supplied it as which
+// differs from the configuration key if the Source supplied a deprecated key.
+type Origin struct {
+	Source Source
+	Key    string
+}
+
+// Describe returns a description of the Origin of the value of the configuration key such as the file path and line
+// number or the name of the environment variable.
+func (p Provenance) Describe(key string) (description string, ok bool) {
+	var origin Origin
+
+	if origin, ok = p[key]; !ok {
+		return "", false
+	}
+
+	if describer, ok := origin.Source.(provenanceDescriber); ok {
+		return describer.describe(origin.Key), true
+	}
+
+	return origin.Source.Name(), true
+}
+
+// record the keys of the koanf.Koanf which have been added or modified compared to the flattened keys from before the
+// Source was merged.
+func (p Provenance) record(before map[string]any, ko *koanf.Koanf, source Source) {
+	for key, value := range ko.All() {
+		if previous, ok := before[key]; ok && reflect.DeepEqual(previous, value) {
+			continue
+		}
+
+		p[key] = Origin{Source: source, Key: key}
+	}
+}
+
+// remap the deprecated keys to their replacements in the same way the deprecated keys are remapped when loading.
+func (p Provenance) remap(ds map[string]Deprecation, dms []MultiKeyMappedDeprecation) {
+	for key, d := range ds {
+		origin, ok := p[key]
+		if !ok || !d.AutoMap {
+			continue
+		}
+
+		if _, ok = p[d.NewKey]; !ok {
+			p[d.NewKey] = origin
+		}
+
+		delete(p, key)
+	}
+
+	for _, dm := range dms {
+		for _, key := range dm.Keys {
+			origin, ok := p[key]
+			if !ok {
+				continue
+			}
+
+			if _, ok = p[dm.NewKey]; !ok {
+				p[dm.NewKey] = origin
+			}
+
+			delete(p, key)
+		}
+	}
+}
+
+type provenanceDescriber interface {
+	describe(key string) (description string)
+}
+
+func (s *FileSource) describe(key string) (description string) {
+	if s.positions == nil {
+		s.positions = map[string]string{}
+
+		if files, err := s.ReadFiles(); err == nil {
+			for _, file := range files {
+				node := &yaml.Node{}
+
+				if err = yaml.Unmarshal(file.Data, node); err != nil || len(node.Content) == 0 {
+					continue
+				}
+
+				provenanceFilePositions(node.Content[0], "", file.Path, s.positions)
+			}
+		}
+	}
+
+	if position, ok := s.positions[key]; ok {
+		return fmt.Sprintf("file %s", position)
+	}
+
+	return fmt.Sprintf("file %s", s.path)
+}
+
+func provenanceFilePositions(node *yaml.Node, prefix, path string, positions map[string]string) {
+	if node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := diffJoinKey(prefix, node.Content[i].Value)
+
+		positions[key] = fmt.Sprintf("%s:%d", path, node.Content[i].Line)
+
+		provenanceFilePositions(node.Content[i+1], key, path, positions)
+	}
+}
+
+func (s *EnvironmentSource) describe(key string) (description string) {
+	return fmt.Sprintf("environment variable %s", ToEnvironmentKey(key, s.prefix, s.delimiter))
+}
+
+func (s *SecretsSource) describe(key string) (description string) {
+	name := ToEnvironmentSecretKey(key, s.prefix, s.delimiter)
+
+	return fmt.Sprintf("secret file %s from environment variable %s", os.Getenv(name), name)
+}
+
+func (s *MapSource) describe(_ string) (description string) {
+	return "command defaults"
+}
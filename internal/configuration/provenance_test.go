@@ -0,0 +1,73 @@
+package configuration
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestLoadAdvancedWithProvenance(t *testing.T) {
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "config.yml")
+	secret := filepath.Join(dir, "secret")
+
+	require.NoError(t, testCreateFile(path, "theme: 'dark'\nlog:\n  level: 'info'\n  format: 'json'\nlogs_file: '/var/log/authelia.log'\n", 0600))
+	require.NoError(t, testCreateFile(secret, "abc", 0600))
+
+	testSetEnv(t, "LOG_LEVEL", "debug")
+	testSetEnv(t, "SESSION_SECRET_FILE", secret)
+
+	val := schema.NewStructValidator()
+
+	_, provenance, err := LoadAdvancedWithProvenance(val, "", &schema.Configuration{}, NewDefaultSources([]string{path}, DefaultEnvPrefix, DefaultEnvDelimiter)...)
+
+	require.NoError(t, err)
+
+	testCases := []struct {
+		key      string
+		expected string
+	}{
+		{"theme", fmt.Sprintf("file %s:1", path)},
+		{"log.format", fmt.Sprintf("file %s:4", path)},
+		{"log.level", "environment variable AUTHELIA_LOG_LEVEL"},
+		{"log.file_path", fmt.Sprintf("file %s:5", path)},
+		{"session.secret", fmt.Sprintf("secret file %s from environment variable AUTHELIA_SESSION_SECRET_FILE", secret)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.key, func(t *testing.T) {
+			actual, ok := provenance.Describe(tc.key)
+
+			assert.True(t, ok)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+
+	_, ok := provenance.Describe("logs_file")
+
+	assert.False(t, ok)
+}
+
+func TestLoadAdvancedShouldNotRecordProvenance(t *testing.T) {
+	val := schema.NewStructValidator()
+
+	keys, err := LoadAdvanced(val, "", &schema.Configuration{}, NewMapSource(map[string]any{"theme": "dark"}))
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"theme"}, keys)
+
+	_, provenance, err := LoadAdvancedWithProvenance(val, "", &schema.Configuration{}, NewMapSource(map[string]any{"theme": "dark"}))
+
+	require.NoError(t, err)
+
+	actual, ok := provenance.Describe("theme")
+
+	assert.True(t, ok)
+	assert.Equal(t, "command defaults", actual)
+}
@@ -20,6 +20,19 @@ func Load(val *schema.StructValidator, sources ...Source) (keys []string, config
 
 // LoadAdvanced is intended to give more flexibility over loading a particular path to a specific interface.
 func LoadAdvanced(val *schema.StructValidator, path string, result any, sources ...Source) (keys []string, err error) {
+	return loadAdvanced(val, path, result, nil, sources...)
+}
+
+// LoadAdvancedWithProvenance is the same as LoadAdvanced except it also returns the Provenance of each key.
+func LoadAdvancedWithProvenance(val *schema.StructValidator, path string, result any, sources ...Source) (keys []string, provenance Provenance, err error) {
+	provenance = Provenance{}
+
+	keys, err = loadAdvanced(val, path, result, provenance, sources...)
+
+	return keys, provenance, err
+}
+
+func loadAdvanced(val *schema.StructValidator, path string, result any, provenance Provenance, sources ...Source) (keys []string, err error) {
 	if val == nil {
 		return keys, errNoValidator
 	}
@@ -29,7 +42,7 @@ func LoadAdvanced(val *schema.StructValidator, path string, result any, sources
 		StrictMerge: false,
 	})
 
-	if err = loadSources(ko, val, sources...); err != nil {
+	if err = loadSources(ko, val, provenance, sources...); err != nil {
 		return ko.Keys(), err
 	}
 
@@ -39,6 +52,10 @@ func LoadAdvanced(val *schema.StructValidator, path string, result any, sources
 		return koanfGetKeys(ko), err
 	}
 
+	if provenance != nil {
+		provenance.remap(deprecations, deprecationsMKM)
+	}
+
 	unmarshal(final, val, path, result)
 
 	return koanfGetKeys(final), nil
@@ -82,11 +99,13 @@ func unmarshal(ko *koanf.Koanf, val *schema.StructValidator, path string, o any)
 	}
 }
 
-func loadSources(ko *koanf.Koanf, val *schema.StructValidator, sources ...Source) (err error) {
+func loadSources(ko *koanf.Koanf, val *schema.StructValidator, provenance Provenance, sources ...Source) (err error) {
 	if len(sources) == 0 {
 		return errNoSources
 	}
 
+	var before map[string]any
+
 	for _, source := range sources {
 		if err = source.Load(val); err != nil {
 			val.Push(fmt.Errorf("failed to load configuration from %s source: %+v", source.Name(), err))
@@ -94,11 +113,19 @@ func loadSources(ko *koanf.Koanf, val *schema.StructValidator, sources ...Source
 			continue
 		}
 
+		if provenance != nil {
+			before = ko.All()
+		}
+
 		if err = source.Merge(ko, val); err != nil {
 			val.Push(fmt.Errorf("failed to merge configuration from %s source: %+v", source.Name(), err))
 
 			continue
 		}
+
+		if provenance != nil {
+			provenance.record(before, ko, source)
+		}
 	}
 
 	return nil
@@ -125,6 +125,25 @@ func TestShouldParseLargeIntegerDurations(t *testing.T) {
 	assert.Equal(t, time.Minute*5, config.AuthenticationBackend.RefreshInterval.Value())
 }
 
+func TestShouldParseNegativeRetentionDurationsFromEnv(t *testing.T) {
+	testSetEnv(t, "SESSION_SECRET", "abc")
+	testSetEnv(t, "STORAGE_MYSQL_PASSWORD", "abc")
+	testSetEnv(t, "IDENTITY_VALIDATION_RESET_PASSWORD_JWT_SECRET", "abc")
+	testSetEnv(t, "AUTHENTICATION_BACKEND_LDAP_PASSWORD", "abc")
+	testSetEnv(t, "STORAGE_RETENTION_AUTHENTICATION_LOGS", "-1")
+	testSetEnv(t, "STORAGE_RETENTION_TOTP_HISTORY", "-1d")
+
+	val := schema.NewStructValidator()
+	_, config, err := Load(val, NewDefaultSources([]string{"./test_resources/config.yml"}, DefaultEnvPrefix, DefaultEnvDelimiter)...)
+
+	assert.NoError(t, err)
+	assert.Len(t, val.Errors(), 0)
+	assert.Len(t, val.Warnings(), 0)
+
+	assert.Equal(t, -time.Second, config.Storage.Retention.AuthenticationLogs)
+	assert.Equal(t, -time.Hour*24, config.Storage.Retention.TOTPHistory)
+}
+
 func TestShouldValidateConfigurationWithEnv(t *testing.T) {
 	testSetEnv(t, "SESSION_SECRET", "abc")
 	testSetEnv(t, "STORAGE_MYSQL_PASSWORD", "abc")
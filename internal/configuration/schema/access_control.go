@@ -1,5 +1,9 @@
 package schema
 
+import (
+	"time"
+)
+
 // AccessControl represents the configuration related to ACLs.
 type AccessControl struct {
 	// The default policy if no other policy matches the request.
@@ -28,6 +32,16 @@ type AccessControlRule struct {
 	Resources    AccessControlRuleRegex     `koanf:"resources" json:"resources" jsonschema:"title=Resources or Paths" jsonschema_description:"The regex patterns to match the resource paths that this rule applies to."`
 	Methods      AccessControlRuleMethods   `koanf:"methods" json:"methods" jsonschema:"enum=GET,enum=HEAD,enum=POST,enum=PUT,enum=DELETE,enum=CONNECT,enum=OPTIONS,enum=TRACE,enum=PATCH,enum=PROPFIND,enum=PROPPATCH,enum=MKCOL,enum=COPY,enum=MOVE,enum=LOCK,enum=UNLOCK" jsonschema_description:"The list of request methods this rule applies to."`
 	Query        [][]AccessControlRuleQuery `koanf:"query" json:"query" jsonschema:"title=Query Rules" jsonschema_description:"The list of query parameter rules this rule applies to."`
+	AMR          []string                   `koanf:"amr" json:"amr" jsonschema:"uniqueItems,enum=hwk,enum=otp,enum=sms,enum=email,title=Authentication Methods References" jsonschema_description:"The list of Authentication Methods References of which at least one must have been used to satisfy the two_factor policy of this rule."`
+
+	MaxAuthenticationAge AccessControlRuleMaxAuthenticationAge `koanf:"max_authentication_age" json:"max_authentication_age" jsonschema:"title=Maximum Authentication Age" jsonschema_description:"The maximum age of each authentication factor for this rule to be satisfied."`
+	DisableTrustedDevice bool                                  `koanf:"disable_trusted_device" json:"disable_trusted_device" jsonschema:"default=false,title=Disable Trusted Device" jsonschema_description:"Disables satisfying the two_factor policy of this rule with a trusted device."`
+}
+
+// AccessControlRuleMaxAuthenticationAge represents the maximum age of each authentication factor for an ACL rule.
+type AccessControlRuleMaxAuthenticationAge struct {
+	OneFactor time.Duration `koanf:"one_factor" json:"one_factor" jsonschema:"title=One Factor" jsonschema_description:"The maximum age of the first factor authentication after which the user must re-authenticate."`
+	TwoFactor time.Duration `koanf:"two_factor" json:"two_factor" jsonschema:"title=Two Factor" jsonschema_description:"The maximum age of the second factor authentication after which the user must re-authenticate."`
 }
 
 // AccessControlRuleQuery represents the ACL query criteria.
@@ -8,7 +8,7 @@ import (
 type Configuration struct {
 	Theme                 string `koanf:"theme" json:"theme" jsonschema:"default=light,enum=auto,enum=light,enum=dark,enum=grey,title=Theme Name" jsonschema_description:"The name of the theme to apply to the web UI."`
 	CertificatesDirectory string `koanf:"certificates_directory" json:"certificates_directory" jsonschema:"title=Certificates Directory Path" jsonschema_description:"The path to a directory which is used to determine the certificates that are trusted."`
-	Default2FAMethod      string `koanf:"default_2fa_method" json:"default_2fa_method" jsonschema:"enum=totp,enum=webauthn,enum=mobile_push,title=Default 2FA method" jsonschema_description:"When a user logs in for the first time this is the 2FA method configured for them."`
+	Default2FAMethod      string `koanf:"default_2fa_method" json:"default_2fa_method" jsonschema:"enum=totp,enum=webauthn,enum=mobile_push,enum=email,title=Default 2FA method" jsonschema_description:"When a user logs in for the first time this is the 2FA method configured for them."`
 
 	Log                   Log                   `koanf:"log" json:"log" jsonschema:"title=Log" jsonschema_description:"Logging Configuration."`
 	IdentityProviders     IdentityProviders     `koanf:"identity_providers" json:"identity_providers" jsonschema:"title=Identity Providers" jsonschema_description:"Identity Providers Configuration."`
@@ -24,9 +24,13 @@ type Configuration struct {
 	Server                Server                `koanf:"server" json:"server" jsonschema:"title=Server" jsonschema_description:"Server Configuration."`
 	Telemetry             Telemetry             `koanf:"telemetry" json:"telemetry" jsonschema:"title=Telemetry" jsonschema_description:"Telemetry Configuration."`
 	WebAuthn              WebAuthn              `koanf:"webauthn" json:"webauthn" jsonschema:"title=WebAuthn" jsonschema_description:"WebAuthn Configuration."`
+	RecoveryCodes         RecoveryCodes         `koanf:"recovery_codes" json:"recovery_codes" jsonschema:"title=Recovery Codes" jsonschema_description:"Recovery Codes Configuration."`
+	EmailOneTimeCode      EmailOneTimeCode      `koanf:"email_one_time_code" json:"email_one_time_code" jsonschema:"title=Email One-Time Code" jsonschema_description:"Email One-Time Code Configuration."`
+	TrustedDevice         TrustedDevice         `koanf:"trusted_device" json:"trusted_device" jsonschema:"title=Trusted Device" jsonschema_description:"Trusted Device Configuration."`
 	PasswordPolicy        PasswordPolicy        `koanf:"password_policy" json:"password_policy" jsonschema:"title=Password Policy" jsonschema_description:"Password Policy Configuration."`
 	PrivacyPolicy         PrivacyPolicy         `koanf:"privacy_policy" json:"privacy_policy" jsonschema:"title=Privacy Policy" jsonschema_description:"Privacy Policy Configuration."`
 	IdentityValidation    IdentityValidation    `koanf:"identity_validation" json:"identity_validation" jsonschema:"title=Identity Validation" jsonschema_description:"Identity Validation Configuration."`
+	Reload                Reload                `koanf:"reload" json:"reload" jsonschema:"title=Reload" jsonschema_description:"Configuration Reload Configuration."`
 
 	// Deprecated: Use the session cookies option with the same name instead.
 	DefaultRedirectionURL *url.URL `koanf:"default_redirection_url" json:"default_redirection_url" jsonschema:"deprecated,format=uri,title=The default redirection URL"`
@@ -108,6 +108,22 @@ const (
 	TOTPSecretSizeMinimum = 20
 )
 
+const (
+	// RecoveryCodesCountMinimum is the minimum number of recovery codes.
+	RecoveryCodesCountMinimum = 4
+
+	// RecoveryCodesCountMaximum is the maximum number of recovery codes.
+	RecoveryCodesCountMaximum = 32
+)
+
+const (
+	// EmailOneTimeCodeCharactersMinimum is the minimum number of characters in an Email One-Time Code.
+	EmailOneTimeCodeCharactersMinimum = 6
+
+	// EmailOneTimeCodeCharactersMaximum is the maximum number of characters in an Email One-Time Code.
+	EmailOneTimeCodeCharactersMaximum = 20
+)
+
 var (
 	// regexpHasScheme checks if a string has a scheme. Valid characters for schemes include alphanumeric, hyphen,
 	// period, and plus characters.
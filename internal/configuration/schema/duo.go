@@ -7,4 +7,5 @@ type DuoAPI struct {
 	IntegrationKey       string `koanf:"integration_key" json:"integration_key" jsonschema:"title=Integration Key" jsonschema_description:"The Integration Key provided by your Duo API dashboard."`
 	SecretKey            string `koanf:"secret_key" json:"secret_key" jsonschema:"title=Secret Key" jsonschema_description:"The Secret Key provided by your Duo API dashboard."`
 	EnableSelfEnrollment bool   `koanf:"enable_self_enrollment" json:"enable_self_enrollment" jsonschema:"default=false,title=Enable Self Enrollment" jsonschema_description:"Enable the Self Enrollment flow."`
+	UniversalPrompt      bool   `koanf:"universal_prompt" json:"universal_prompt" jsonschema:"default=false,title=Universal Prompt" jsonschema_description:"Enables the Duo Universal Prompt which redirects users to the Duo hosted prompt. Requires the Client ID and Client Secret of a Duo Web SDK application in the integration_key and secret_key options."`
 }
@@ -0,0 +1,31 @@
+package schema
+
+import (
+	"time"
+)
+
+// EmailOneTimeCode represents the configuration related to the Email One-Time Code second factor method.
+type EmailOneTimeCode struct {
+	Enable       bool                      `koanf:"enable" json:"enable" jsonschema:"default=false,title=Enable" jsonschema_description:"Enables the Email One-Time Code second factor method."`
+	Characters   int                       `koanf:"characters" json:"characters" jsonschema:"default=8,minimum=6,maximum=20,title=Characters" jsonschema_description:"Number of characters in the generated One-Time Codes."`
+	CodeLifespan time.Duration             `koanf:"code_lifespan" json:"code_lifespan" jsonschema:"default=5 minutes,title=Code Lifespan" jsonschema_description:"The lifespan of the randomly generated One-Time Code after which it's considered invalid."`
+	RateLimit    EmailOneTimeCodeRateLimit `koanf:"rate_limit" json:"rate_limit" jsonschema:"title=Rate Limit" jsonschema_description:"The rate limit configuration for sending One-Time Codes."`
+}
+
+// EmailOneTimeCodeRateLimit represents the configuration related to rate limiting the Email One-Time Code second
+// factor method.
+type EmailOneTimeCodeRateLimit struct {
+	Amount int           `koanf:"amount" json:"amount" jsonschema:"default=3,minimum=1,title=Amount" jsonschema_description:"The maximum number of One-Time Codes which can be sent to a user within the period."`
+	Period time.Duration `koanf:"period" json:"period" jsonschema:"default=10 minutes,title=Period" jsonschema_description:"The amount of time to consider when determining the number of One-Time Codes sent to a user."`
+}
+
+// DefaultEmailOneTimeCodeConfiguration represents default configuration parameters for the Email One-Time Code second
+// factor method.
+var DefaultEmailOneTimeCodeConfiguration = EmailOneTimeCode{
+	Characters:   8,
+	CodeLifespan: time.Minute * 5,
+	RateLimit: EmailOneTimeCodeRateLimit{
+		Amount: 3,
+		Period: time.Minute * 10,
+	},
+}
@@ -53,6 +53,7 @@ type IdentityProvidersOpenIDConnectPolicy struct {
 type IdentityProvidersOpenIDConnectPolicyRule struct {
 	Policy   string                    `koanf:"policy" json:"policy" jsonschema:"enum=one_factor,enum=two_factor,enum=deny,title=Policy" jsonschema_description:"The policy to apply to this rule."`
 	Subjects AccessControlRuleSubjects `koanf:"subject" json:"subject" jsonschema:"title=Subject" jsonschema_description:"Allows tuning the token lifespans for the authorize code grant."`
+	AMR      []string                  `koanf:"amr" json:"amr" jsonschema:"uniqueItems,enum=hwk,enum=otp,enum=sms,enum=email,title=Authentication Methods References" jsonschema_description:"The list of Authentication Methods References of which at least one must have been used to satisfy the two_factor policy of this rule."`
 }
 
 // IdentityProvidersOpenIDConnectDiscovery is information discovered during validation reused for the discovery handlers.
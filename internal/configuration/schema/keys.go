@@ -81,6 +81,7 @@ var Keys = []string{
 	"identity_providers.oidc.authorization_policies.*.rules",
 	"identity_providers.oidc.authorization_policies.*.rules[].policy",
 	"identity_providers.oidc.authorization_policies.*.rules[].subject",
+	"identity_providers.oidc.authorization_policies.*.rules[].amr",
 	"identity_providers.oidc.lifespans.access_token",
 	"identity_providers.oidc.lifespans.authorize_code",
 	"identity_providers.oidc.lifespans.id_token",
@@ -220,6 +221,7 @@ var Keys = []string{
 	"totp.period",
 	"totp.skew",
 	"totp.secret_size",
+	"totp.hotp_look_ahead",
 	"totp.allowed_algorithms",
 	"totp.allowed_digits",
 	"totp.allowed_periods",
@@ -229,6 +231,7 @@ var Keys = []string{
 	"duo_api.integration_key",
 	"duo_api.secret_key",
 	"duo_api.enable_self_enrollment",
+	"duo_api.universal_prompt",
 	"access_control.default_policy",
 	"access_control.networks",
 	"access_control.networks[].name",
@@ -245,6 +248,10 @@ var Keys = []string{
 	"access_control.rules[].query[][].key",
 	"access_control.rules[].query[][].value",
 	"access_control.rules[].query",
+	"access_control.rules[].amr",
+	"access_control.rules[].max_authentication_age.one_factor",
+	"access_control.rules[].max_authentication_age.two_factor",
+	"access_control.rules[].disable_trusted_device",
 	"ntp.address",
 	"ntp.version",
 	"ntp.max_desync",
@@ -253,12 +260,21 @@ var Keys = []string{
 	"regulation.max_retries",
 	"regulation.find_time",
 	"regulation.ban_time",
+	"regulation.backoff.multiplier",
+	"regulation.backoff.max_ban_time",
+	"regulation.lockout.max_bans",
+	"regulation.lockout.window",
+	"regulation.lockout.identity_verification_unlock",
+	"regulation.administration.enable",
+	"regulation.administration.groups",
 	"storage.local.path",
 	"storage.mysql.address",
 	"storage.mysql.database",
 	"storage.mysql.username",
 	"storage.mysql.password",
 	"storage.mysql.timeout",
+	"storage.mysql.replicas.addresses",
+	"storage.mysql.replicas.health_check_interval",
 	"storage.mysql.tls.minimum_version",
 	"storage.mysql.tls.maximum_version",
 	"storage.mysql.tls.skip_verify",
@@ -270,6 +286,8 @@ var Keys = []string{
 	"storage.postgres.username",
 	"storage.postgres.password",
 	"storage.postgres.timeout",
+	"storage.postgres.replicas.addresses",
+	"storage.postgres.replicas.health_check_interval",
 	"storage.postgres.schema",
 	"storage.postgres.tls.minimum_version",
 	"storage.postgres.tls.maximum_version",
@@ -282,6 +300,24 @@ var Keys = []string{
 	"storage.postgres.ssl.certificate",
 	"storage.postgres.ssl.key",
 	"storage.encryption_key",
+	"storage.key_management.file.path",
+	"storage.key_management.file.previous_paths",
+	"storage.key_management.command.command",
+	"storage.key_management.command.args",
+	"storage.key_management.command.key_id",
+	"storage.key_management.command.timeout",
+	"storage.key_management.software_hsm.path",
+	"storage.key_management.software_hsm.pin",
+	"storage.key_management.software_hsm.key_label",
+	"storage.retention.disable",
+	"storage.retention.interval",
+	"storage.retention.batch_size",
+	"storage.retention.authentication_logs",
+	"storage.retention.totp_history",
+	"storage.retention.identity_verification",
+	"storage.retention.one_time_code",
+	"storage.retention.oauth2_sessions",
+	"storage.retention.oauth2_blacklisted_jti",
 	"notifier.disable_startup_check",
 	"notifier.filesystem.filename",
 	"notifier.smtp.address",
@@ -303,7 +339,35 @@ var Keys = []string{
 	"notifier.smtp.tls.certificate_chain",
 	"notifier.smtp.host",
 	"notifier.smtp.port",
+	"notifier.webhook.address",
+	"notifier.webhook.secret",
+	"notifier.webhook.timeout",
+	"notifier.webhook.max_retries",
+	"notifier.webhook.retry_interval",
+	"notifier.webhook.headers",
+	"notifier.webhook.tls.minimum_version",
+	"notifier.webhook.tls.maximum_version",
+	"notifier.webhook.tls.skip_verify",
+	"notifier.webhook.tls.server_name",
+	"notifier.webhook.tls.private_key",
+	"notifier.webhook.tls.certificate_chain",
 	"notifier.template_path",
+	"notifier.events.one_time_password_added.disable",
+	"notifier.events.one_time_password_removed.disable",
+	"notifier.events.webauthn_credential_added.disable",
+	"notifier.events.webauthn_credential_removed.disable",
+	"notifier.events.duo_device_changed.disable",
+	"notifier.events.new_login.disable",
+	"notifier.events.account_locked.disable",
+	"notifier.events.openid_connect_consent_granted.disable",
+	"notifier.events.recovery_code_used.disable",
+	"notifier.events.recovery_codes_generated.disable",
+	"notifier.queue.enable",
+	"notifier.queue.workers",
+	"notifier.queue.poll_interval",
+	"notifier.queue.max_attempts",
+	"notifier.queue.retry_interval",
+	"notifier.queue.max_retry_interval",
 	"server.address",
 	"server.asset_path",
 	"server.disable_healthcheck",
@@ -335,6 +399,27 @@ var Keys = []string{
 	"webauthn.attestation_conveyance_preference",
 	"webauthn.user_verification",
 	"webauthn.timeout",
+	"webauthn.enable_passkey_login",
+	"webauthn.enable_passkey_two_factor",
+	"webauthn.metadata.enabled",
+	"webauthn.metadata.path",
+	"webauthn.metadata.root_certificate",
+	"webauthn.metadata.validate_trust_anchor",
+	"webauthn.metadata.validate_entry",
+	"webauthn.metadata.validate_entry_permit_zero_aaguid",
+	"webauthn.metadata.validate_status",
+	"webauthn.filtering.permitted_aaguids",
+	"webauthn.filtering.prohibited_aaguids",
+	"recovery_codes.disable",
+	"recovery_codes.count",
+	"email_one_time_code.enable",
+	"email_one_time_code.characters",
+	"email_one_time_code.code_lifespan",
+	"email_one_time_code.rate_limit.amount",
+	"email_one_time_code.rate_limit.period",
+	"trusted_device.enable",
+	"trusted_device.secret",
+	"trusted_device.lifespan",
 	"password_policy.standard.enabled",
 	"password_policy.standard.min_length",
 	"password_policy.standard.max_length",
@@ -355,5 +440,9 @@ var Keys = []string{
 	"identity_validation.elevated_session.characters",
 	"identity_validation.elevated_session.require_second_factor",
 	"identity_validation.elevated_session.skip_second_factor",
+	"reload.watch",
+	"reload.poll_interval",
+	"reload.administration.enable",
+	"reload.administration.groups",
 	"default_redirection_url",
 }
@@ -12,7 +12,39 @@ type Notifier struct {
 	DisableStartupCheck bool                `koanf:"disable_startup_check" json:"disable_startup_check" jsonschema:"default=false,title=Disable Startup Check" jsonschema_description:"Disables the notifier startup checks."`
 	FileSystem          *NotifierFileSystem `koanf:"filesystem" json:"filesystem" jsonschema:"title=File System" jsonschema_description:"The File System notifier."`
 	SMTP                *NotifierSMTP       `koanf:"smtp" json:"smtp" jsonschema:"title=SMTP" jsonschema_description:"The SMTP notifier."`
+	Webhook             *NotifierWebhook    `koanf:"webhook" json:"webhook" jsonschema:"title=Webhook" jsonschema_description:"The Webhook notifier."`
 	TemplatePath        string              `koanf:"template_path" json:"template_path" jsonschema:"title=Template Path" jsonschema_description:"The path for notifier template overrides."`
+	Events              NotifierEvents      `koanf:"events" json:"events" jsonschema:"title=Events" jsonschema_description:"The security event notification settings."`
+	Queue               NotifierQueue       `koanf:"queue" json:"queue" jsonschema:"title=Queue" jsonschema_description:"The asynchronous notification queue settings."`
+}
+
+// NotifierQueue represents the configuration of the durable asynchronous notification queue.
+type NotifierQueue struct {
+	Enable           bool          `koanf:"enable" json:"enable" jsonschema:"default=false,title=Enable" jsonschema_description:"Enables the durable notification queue which delivers notifications in the background."`
+	Workers          int           `koanf:"workers" json:"workers" jsonschema:"default=1,title=Workers" jsonschema_description:"The number of notifications delivered concurrently."`
+	PollInterval     time.Duration `koanf:"poll_interval" json:"poll_interval" jsonschema:"default=1 second,title=Poll Interval" jsonschema_description:"The interval between checks for notifications which are due for delivery."`
+	MaxAttempts      int           `koanf:"max_attempts" json:"max_attempts" jsonschema:"default=10,title=Maximum Attempts" jsonschema_description:"The maximum number of delivery attempts before a notification is moved to the dead state."`
+	RetryInterval    time.Duration `koanf:"retry_interval" json:"retry_interval" jsonschema:"default=30 seconds,title=Retry Interval" jsonschema_description:"The initial interval between delivery attempts which is doubled after each failed attempt."`
+	MaxRetryInterval time.Duration `koanf:"max_retry_interval" json:"max_retry_interval" jsonschema:"default=1 hour,title=Maximum Retry Interval" jsonschema_description:"The maximum interval between delivery attempts."`
+}
+
+// NotifierEvents represents the configuration of the individual security event notifications.
+type NotifierEvents struct {
+	OneTimePasswordAdded        NotifierEvent `koanf:"one_time_password_added" json:"one_time_password_added" jsonschema:"title=One-Time Password Added" jsonschema_description:"The notification sent when a One-Time Password is registered."`
+	OneTimePasswordRemoved      NotifierEvent `koanf:"one_time_password_removed" json:"one_time_password_removed" jsonschema:"title=One-Time Password Removed" jsonschema_description:"The notification sent when a One-Time Password is removed."`
+	WebAuthnCredentialAdded     NotifierEvent `koanf:"webauthn_credential_added" json:"webauthn_credential_added" jsonschema:"title=WebAuthn Credential Added" jsonschema_description:"The notification sent when a WebAuthn Credential is registered."`
+	WebAuthnCredentialRemoved   NotifierEvent `koanf:"webauthn_credential_removed" json:"webauthn_credential_removed" jsonschema:"title=WebAuthn Credential Removed" jsonschema_description:"The notification sent when a WebAuthn Credential is removed."`
+	DuoDeviceChanged            NotifierEvent `koanf:"duo_device_changed" json:"duo_device_changed" jsonschema:"title=Duo Device Changed" jsonschema_description:"The notification sent when the preferred Duo device or method is changed."`
+	NewLogin                    NotifierEvent `koanf:"new_login" json:"new_login" jsonschema:"title=New Login" jsonschema_description:"The notification sent when a user logs in from a remote IP or user agent which has not been seen before."`
+	AccountLocked               NotifierEvent `koanf:"account_locked" json:"account_locked" jsonschema:"title=Account Locked" jsonschema_description:"The notification sent when an account is locked by regulation."`
+	OpenIDConnectConsentGranted NotifierEvent `koanf:"openid_connect_consent_granted" json:"openid_connect_consent_granted" jsonschema:"title=OpenID Connect Consent Granted" jsonschema_description:"The notification sent when a user grants consent to an OpenID Connect 1.0 client for the first time."`
+	RecoveryCodeUsed            NotifierEvent `koanf:"recovery_code_used" json:"recovery_code_used" jsonschema:"title=Recovery Code Used" jsonschema_description:"The notification sent when a recovery code is used in place of a second factor."`
+	RecoveryCodesGenerated      NotifierEvent `koanf:"recovery_codes_generated" json:"recovery_codes_generated" jsonschema:"title=Recovery Codes Generated" jsonschema_description:"The notification sent when a new set of recovery codes is generated."`
+}
+
+// NotifierEvent represents the configuration of an individual security event notification.
+type NotifierEvent struct {
+	Disable bool `koanf:"disable" json:"disable" jsonschema:"default=false,title=Disable" jsonschema_description:"Disables this notification."`
 }
 
 // NotifierFileSystem represents the configuration of the notifier writing emails in a file.
@@ -42,6 +74,17 @@ type NotifierSMTP struct {
 	Port int `koanf:"port" json:"port" jsonschema:"deprecated"`
 }
 
+// NotifierWebhook represents the configuration of the HTTP endpoint to send notifications to.
+type NotifierWebhook struct {
+	Address       *url.URL          `koanf:"address" json:"address" jsonschema:"format=uri,title=Address" jsonschema_description:"The URL of the HTTP endpoint notifications are sent to."`
+	Secret        string            `koanf:"secret" json:"secret" jsonschema:"title=Secret" jsonschema_description:"The secret used to sign the notification payload with HMAC-SHA256."`
+	Timeout       time.Duration     `koanf:"timeout" json:"timeout" jsonschema:"default=5 seconds,title=Timeout" jsonschema_description:"The timeout for the delivery of a notification including any retries."`
+	MaxRetries    int               `koanf:"max_retries" json:"max_retries" jsonschema:"default=3,title=Maximum Retries" jsonschema_description:"The maximum number of times a failed request is retried, -1 disables retries."`
+	RetryInterval time.Duration     `koanf:"retry_interval" json:"retry_interval" jsonschema:"default=1 second,title=Retry Interval" jsonschema_description:"The initial interval between retries which is doubled after each failed attempt."`
+	Headers       map[string]string `koanf:"headers" json:"headers" jsonschema:"title=Headers" jsonschema_description:"Additional headers sent with each request."`
+	TLS           *TLS              `koanf:"tls" json:"tls" jsonschema:"title=TLS" jsonschema_description:"The HTTP endpoint TLS connection properties."`
+}
+
 // DefaultSMTPNotifierConfiguration represents default configuration parameters for the SMTP notifier.
 var DefaultSMTPNotifierConfiguration = NotifierSMTP{
 	Address:             &AddressSMTP{Address{true, false, -1, 25, &url.URL{Scheme: AddressSchemeSMTP, Host: "localhost:25"}}},
@@ -53,3 +96,28 @@ var DefaultSMTPNotifierConfiguration = NotifierSMTP{
 		MinimumVersion: TLSVersion{tls.VersionTLS12},
 	},
 }
+
+// WebhookNotifierMaximumRetries is the maximum number of retries permitted for the Webhook notifier.
+const WebhookNotifierMaximumRetries = 10
+
+// WebhookNotifierMaximumRetryInterval is the maximum interval between retries permitted for the Webhook notifier.
+const WebhookNotifierMaximumRetryInterval = time.Second * 10
+
+// DefaultWebhookNotifierConfiguration represents default configuration parameters for the Webhook notifier.
+var DefaultWebhookNotifierConfiguration = NotifierWebhook{
+	Timeout:       time.Second * 5,
+	MaxRetries:    3,
+	RetryInterval: time.Second,
+	TLS: &TLS{
+		MinimumVersion: TLSVersion{tls.VersionTLS12},
+	},
+}
+
+// DefaultNotifierQueueConfiguration represents default configuration parameters for the notification queue.
+var DefaultNotifierQueueConfiguration = NotifierQueue{
+	Workers:          1,
+	PollInterval:     time.Second,
+	MaxAttempts:      10,
+	RetryInterval:    time.Second * 30,
+	MaxRetryInterval: time.Hour,
+}
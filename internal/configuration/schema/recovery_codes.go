@@ -0,0 +1,13 @@
+package schema
+
+// RecoveryCodes represents the configuration related to the recovery codes which can be used in place of a second
+// factor.
+type RecoveryCodes struct {
+	Disable bool `koanf:"disable" json:"disable" jsonschema:"default=false,title=Disable" jsonschema_description:"Disables the recovery codes functionality."`
+	Count   int  `koanf:"count" json:"count" jsonschema:"default=10,minimum=4,maximum=32,title=Count" jsonschema_description:"The number of recovery codes generated for a user."`
+}
+
+// DefaultRecoveryCodesConfiguration represents default configuration parameters for recovery codes.
+var DefaultRecoveryCodesConfiguration = RecoveryCodes{
+	Count: 10,
+}
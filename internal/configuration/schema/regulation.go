@@ -9,6 +9,30 @@ type Regulation struct {
 	MaxRetries int           `koanf:"max_retries" json:"max_retries" jsonschema:"default=3,title=Maximum Retries" jsonschema_description:"The maximum number of failed attempts permitted before banning a user."`
 	FindTime   time.Duration `koanf:"find_time" json:"find_time" jsonschema:"default=2 minutes,title=Find Time" jsonschema_description:"The amount of time to consider when determining the number of failed attempts."`
 	BanTime    time.Duration `koanf:"ban_time" json:"ban_time" jsonschema:"default=5 minutes,title=Ban Time" jsonschema_description:"The amount of time to ban the user for when it's determined the maximum retries has been exceeded."`
+
+	Backoff RegulationBackoff `koanf:"backoff" json:"backoff" jsonschema:"title=Backoff" jsonschema_description:"The progressive ban time configuration for repeat offenders."`
+	Lockout RegulationLockout `koanf:"lockout" json:"lockout" jsonschema:"title=Lockout" jsonschema_description:"The permanent lockout configuration for repeat offenders."`
+
+	Administration RegulationAdministration `koanf:"administration" json:"administration" jsonschema:"title=Administration" jsonschema_description:"The regulation administration endpoint configuration."`
+}
+
+// RegulationBackoff represents the configuration related to the progressive increase of the ban time.
+type RegulationBackoff struct {
+	Multiplier int           `koanf:"multiplier" json:"multiplier" jsonschema:"default=1,minimum=1,title=Multiplier" jsonschema_description:"The multiplier applied to the ban time for every subsequent ban within the lockout window. A value of 1 disables the progressive backoff."`
+	MaxBanTime time.Duration `koanf:"max_ban_time" json:"max_ban_time" jsonschema:"default=1 day,title=Maximum Ban Time" jsonschema_description:"The maximum amount of time a progressive ban can last."`
+}
+
+// RegulationLockout represents the configuration related to the permanent lockout of an account.
+type RegulationLockout struct {
+	MaxBans                    int           `koanf:"max_bans" json:"max_bans" jsonschema:"default=0,title=Maximum Bans" jsonschema_description:"The number of bans within the window which results in the account being locked until it's unlocked. A value of 0 disables the lockout."`
+	Window                     time.Duration `koanf:"window" json:"window" jsonschema:"default=1 day,title=Window" jsonschema_description:"The amount of time to consider when determining the number of bans."`
+	IdentityVerificationUnlock bool          `koanf:"identity_verification_unlock" json:"identity_verification_unlock" jsonschema:"default=false,title=Identity Verification Unlock" jsonschema_description:"Allows users to unlock their own account after completing an identity verification via email."`
+}
+
+// RegulationAdministration represents the configuration related to the regulation administration endpoint.
+type RegulationAdministration struct {
+	Enable bool     `koanf:"enable" json:"enable" jsonschema:"default=false,title=Enable" jsonschema_description:"Enables the regulation administration endpoint."`
+	Groups []string `koanf:"groups" json:"groups" jsonschema:"title=Groups" jsonschema_description:"The list of groups which are allowed to access the regulation administration endpoint."`
 }
 
 // DefaultRegulationConfiguration represents default configuration parameters for the regulator.
@@ -16,4 +40,11 @@ var DefaultRegulationConfiguration = Regulation{
 	MaxRetries: 3,
 	FindTime:   time.Minute * 2,
 	BanTime:    time.Minute * 5,
+	Backoff: RegulationBackoff{
+		Multiplier: 1,
+		MaxBanTime: time.Hour * 24,
+	},
+	Lockout: RegulationLockout{
+		Window: time.Hour * 24,
+	},
 }
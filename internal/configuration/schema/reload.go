@@ -0,0 +1,24 @@
+package schema
+
+import (
+	"time"
+)
+
+// Reload represents the configuration related to reloading the configuration while Authelia is running.
+type Reload struct {
+	Watch        bool          `koanf:"watch" json:"watch" jsonschema:"default=false,title=Watch" jsonschema_description:"Reloads the configuration automatically when the configuration files are modified."`
+	PollInterval time.Duration `koanf:"poll_interval" json:"poll_interval" jsonschema:"default=1 minute,title=Poll Interval" jsonschema_description:"The interval at which the remote configuration sources are checked for modifications when watching is enabled."`
+
+	Administration ReloadAdministration `koanf:"administration" json:"administration" jsonschema:"title=Administration" jsonschema_description:"The configuration reload administration endpoint configuration."`
+}
+
+// ReloadAdministration represents the configuration related to the configuration reload administration endpoint.
+type ReloadAdministration struct {
+	Enable bool     `koanf:"enable" json:"enable" jsonschema:"default=false,title=Enable" jsonschema_description:"Enables the configuration reload administration endpoint."`
+	Groups []string `koanf:"groups" json:"groups" jsonschema:"title=Groups" jsonschema_description:"The list of groups which are allowed to access the configuration reload administration endpoint."`
+}
+
+// DefaultReloadConfiguration represents default configuration parameters for reloading the configuration.
+var DefaultReloadConfiguration = Reload{
+	PollInterval: time.Minute,
+}
@@ -13,6 +13,58 @@ type Storage struct {
 	PostgreSQL *StoragePostgreSQL `koanf:"postgres" json:"postgres" jsonschema:"title=PostgreSQL" jsonschema_description:"The PostgreSQL Storage configuration settings."`
 
 	EncryptionKey string `koanf:"encryption_key" json:"encryption_key" jsonschema:"title=Encryption Key" jsonschema_description:"The Storage Encryption Key used to secure security sensitive values in the storage engine."`
+
+	KeyManagement StorageKeyManagement `koanf:"key_management" json:"key_management" jsonschema:"title=Key Management" jsonschema_description:"The Storage Key Management configuration settings which enable envelope encryption of the storage."`
+
+	Retention StorageRetention `koanf:"retention" json:"retention" jsonschema:"title=Retention" jsonschema_description:"The Storage Retention configuration settings which control the automatic pruning of expired data."`
+}
+
+// StorageKeyManagement represents the configuration of the key-encryption key provider which wraps the storage data
+// key. Only one provider may be configured.
+type StorageKeyManagement struct {
+	File        *StorageKeyManagementFile        `koanf:"file" json:"file" jsonschema:"title=File" jsonschema_description:"The File key-encryption key provider configuration settings."`
+	Command     *StorageKeyManagementCommand     `koanf:"command" json:"command" jsonschema:"title=Command" jsonschema_description:"The Command key-encryption key provider configuration settings."`
+	SoftwareHSM *StorageKeyManagementSoftwareHSM `koanf:"software_hsm" json:"software_hsm" jsonschema:"title=Software HSM" jsonschema_description:"The Software HSM key-encryption key provider configuration settings."`
+}
+
+// Enabled returns true if a key-encryption key provider is configured.
+func (c StorageKeyManagement) Enabled() bool {
+	return c.File != nil || c.Command != nil || c.SoftwareHSM != nil
+}
+
+// StorageKeyManagementFile represents the configuration of the file key-encryption key provider.
+type StorageKeyManagementFile struct {
+	Path          string   `koanf:"path" json:"path" jsonschema:"title=Path" jsonschema_description:"The path to the file containing the current key-encryption key."`
+	PreviousPaths []string `koanf:"previous_paths" json:"previous_paths" jsonschema:"title=Previous Paths" jsonschema_description:"The paths to the files containing previous key-encryption keys which are only used to unwrap the data key."`
+}
+
+// StorageKeyManagementCommand represents the configuration of the command key-encryption key provider.
+type StorageKeyManagementCommand struct {
+	Command string        `koanf:"command" json:"command" jsonschema:"title=Command" jsonschema_description:"The path to the executable which wraps and unwraps the data key."`
+	Args    []string      `koanf:"args" json:"args" jsonschema:"title=Arguments" jsonschema_description:"The arguments passed to the command before the operation and key identifier arguments."`
+	KeyID   string        `koanf:"key_id" json:"key_id" jsonschema:"title=Key ID" jsonschema_description:"The identifier of the current key-encryption key which is passed to the command."`
+	Timeout time.Duration `koanf:"timeout" json:"timeout" jsonschema:"default=5 seconds,title=Timeout" jsonschema_description:"The maximum amount of time the command may run for."`
+}
+
+// StorageKeyManagementSoftwareHSM represents the configuration of the software HSM key-encryption key provider.
+type StorageKeyManagementSoftwareHSM struct {
+	Path     string `koanf:"path" json:"path" jsonschema:"title=Path" jsonschema_description:"The path to the keystore file."`
+	PIN      string `koanf:"pin" json:"pin" jsonschema:"title=PIN" jsonschema_description:"The PIN which protects the keys in the keystore."`
+	KeyLabel string `koanf:"key_label" json:"key_label" jsonschema:"default=authelia,title=Key Label" jsonschema_description:"The label of the current key-encryption key in the keystore."`
+}
+
+// StorageRetention represents the configuration of the automatic pruning of expired data from the storage.
+type StorageRetention struct {
+	Disable   bool          `koanf:"disable" json:"disable" jsonschema:"default=false,title=Disable" jsonschema_description:"Disables the automatic pruning of expired data."`
+	Interval  time.Duration `koanf:"interval" json:"interval" jsonschema:"default=1 hour,title=Interval" jsonschema_description:"The interval between each automatic pruning of expired data."`
+	BatchSize int           `koanf:"batch_size" json:"batch_size" jsonschema:"default=1000,minimum=1,title=Batch Size" jsonschema_description:"The maximum number of rows deleted from a table in a single statement."`
+
+	AuthenticationLogs   time.Duration `koanf:"authentication_logs" json:"authentication_logs" jsonschema:"default=90 days,title=Authentication Logs" jsonschema_description:"The amount of time authentication logs are retained for. A negative value retains them indefinitely."`
+	TOTPHistory          time.Duration `koanf:"totp_history" json:"totp_history" jsonschema:"default=7 days,title=TOTP History" jsonschema_description:"The amount of time one-time password history is retained for. A negative value retains it indefinitely."`
+	IdentityVerification time.Duration `koanf:"identity_verification" json:"identity_verification" jsonschema:"default=7 days,title=Identity Verification" jsonschema_description:"The amount of time identity verification tokens are retained for after they expire. A negative value retains them indefinitely."`
+	OneTimeCode          time.Duration `koanf:"one_time_code" json:"one_time_code" jsonschema:"default=7 days,title=One-Time Code" jsonschema_description:"The amount of time one-time codes are retained for after they expire. A negative value retains them indefinitely."`
+	OAuth2Sessions       time.Duration `koanf:"oauth2_sessions" json:"oauth2_sessions" jsonschema:"default=30 days,title=OAuth 2.0 Sessions" jsonschema_description:"The amount of time OAuth 2.0 sessions are retained for after they're requested. A negative value retains them indefinitely."`
+	OAuth2BlacklistedJTI time.Duration `koanf:"oauth2_blacklisted_jti" json:"oauth2_blacklisted_jti" jsonschema:"default=1 day,title=OAuth 2.0 Blacklisted JTI" jsonschema_description:"The amount of time blacklisted JWT identifiers are retained for after they expire. A negative value retains them indefinitely."`
 }
 
 // StorageLocal represents the configuration when using local storage.
@@ -27,6 +79,15 @@ type StorageSQL struct {
 	Username string        `koanf:"username" json:"username" jsonschema:"title=Username" jsonschema_description:"The username to use to authenticate."`
 	Password string        `koanf:"password" json:"password" jsonschema:"title=Password" jsonschema_description:"The password to use to authenticate."`
 	Timeout  time.Duration `koanf:"timeout" json:"timeout" jsonschema:"default=5 seconds,title=Timeout" jsonschema_description:"The timeout for the database connection."`
+
+	Replicas StorageSQLReplicas `koanf:"replicas" json:"replicas" jsonschema:"title=Replicas" jsonschema_description:"The read replica configuration."`
+}
+
+// StorageSQLReplicas represents the configuration of the read replicas of a SQL database. The replicas use the same
+// database, credentials, and TLS configuration as the primary.
+type StorageSQLReplicas struct {
+	Addresses           []*AddressTCP `koanf:"addresses" json:"addresses" jsonschema:"title=Addresses" jsonschema_description:"The addresses of the read replicas."`
+	HealthCheckInterval time.Duration `koanf:"health_check_interval" json:"health_check_interval" jsonschema:"default=10 seconds,title=Health Check Interval" jsonschema_description:"The interval between each health check of the read replicas."`
 }
 
 // StorageMySQL represents the configuration of a MySQL database.
@@ -58,6 +119,33 @@ type StoragePostgreSQLSSL struct {
 // DefaultSQLStorageConfiguration represents the default SQL configuration.
 var DefaultSQLStorageConfiguration = StorageSQL{
 	Timeout: 5 * time.Second,
+	Replicas: StorageSQLReplicas{
+		HealthCheckInterval: 10 * time.Second,
+	},
+}
+
+// DefaultStorageKeyManagementCommandConfiguration represents the default command key-encryption key provider
+// configuration.
+var DefaultStorageKeyManagementCommandConfiguration = StorageKeyManagementCommand{
+	Timeout: 5 * time.Second,
+}
+
+// DefaultStorageKeyManagementSoftwareHSMConfiguration represents the default software HSM key-encryption key provider
+// configuration.
+var DefaultStorageKeyManagementSoftwareHSMConfiguration = StorageKeyManagementSoftwareHSM{
+	KeyLabel: "authelia",
+}
+
+// DefaultStorageRetentionConfiguration represents the default storage retention configuration.
+var DefaultStorageRetentionConfiguration = StorageRetention{
+	Interval:             time.Hour,
+	BatchSize:            1000,
+	AuthenticationLogs:   time.Hour * 24 * 90,
+	TOTPHistory:          time.Hour * 24 * 7,
+	IdentityVerification: time.Hour * 24 * 7,
+	OneTimeCode:          time.Hour * 24 * 7,
+	OAuth2Sessions:       time.Hour * 24 * 30,
+	OAuth2BlacklistedJTI: time.Hour * 24,
 }
 
 // DefaultMySQLStorageConfiguration represents the default MySQL configuration.
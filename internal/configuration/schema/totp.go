@@ -9,6 +9,7 @@ type TOTP struct {
 	DefaultPeriod    int    `koanf:"period" json:"period" jsonschema:"default=30,title=Period" jsonschema_description:"The period value for generated TOTP keys."`
 	Skew             *int   `koanf:"skew" json:"skew" jsonschema:"default=1,title=Skew" jsonschema_description:"The permitted skew for generated TOTP keys."`
 	SecretSize       int    `koanf:"secret_size" json:"secret_size" jsonschema:"default=32,minimum=20,title=Secret Size" jsonschema_description:"The secret size for generated TOTP keys."`
+	HOTPLookAhead    *int   `koanf:"hotp_look_ahead" json:"hotp_look_ahead" jsonschema:"default=10,minimum=0,title=HOTP Look Ahead" jsonschema_description:"The number of counter values beyond the expected counter which are accepted for imported HOTP keys."`
 
 	AllowedAlgorithms []string `koanf:"allowed_algorithms" json:"allowed_algorithms" jsonschema:"title=Allowed Algorithms,enum=SHA1,enum=SHA256,enum=SHA512,default=SHA1" jsonschema_description:"List of algorithms the user is allowed to select in addition to the default."`
 	AllowedDigits     []int    `koanf:"allowed_digits" json:"allowed_digits" jsonschema:"title=Allowed Digits,enum=6,enum=8,default=6" jsonschema_description:"List of digits the user is allowed to select in addition to the default."`
@@ -17,7 +18,10 @@ type TOTP struct {
 	DisableReuseSecurityPolicy bool `koanf:"disable_reuse_security_policy" json:"disable_reuse_security_policy" jsonschema:"title=Disable Reuse Security Policy,default=false" jsonschema_description:"Disables the security policy that prevents reuse of a TOTP code."`
 }
 
-var defaultTOTPSkew = 1
+var (
+	defaultTOTPSkew          = 1
+	defaultTOTPHOTPLookAhead = 10
+)
 
 // DefaultTOTPConfiguration represents default configuration parameters for TOTP generation.
 var DefaultTOTPConfiguration = TOTP{
@@ -26,6 +30,7 @@ var DefaultTOTPConfiguration = TOTP{
 	DefaultDigits:     6,
 	DefaultPeriod:     30,
 	Skew:              &defaultTOTPSkew,
+	HOTPLookAhead:     &defaultTOTPHOTPLookAhead,
 	SecretSize:        TOTPSecretSizeDefault,
 	AllowedAlgorithms: []string{TOTPAlgorithmSHA1},
 	AllowedDigits:     []int{6},
@@ -0,0 +1,18 @@
+package schema
+
+import (
+	"time"
+)
+
+// TrustedDevice represents the configuration related to trusted devices which allow users to skip the second factor
+// on browsers they've explicitly chosen to trust.
+type TrustedDevice struct {
+	Enable   bool          `koanf:"enable" json:"enable" jsonschema:"default=false,title=Enable" jsonschema_description:"Enables the option for users to trust a device after successful second factor authentication."`
+	Secret   string        `koanf:"secret" json:"secret" jsonschema:"title=Secret" jsonschema_description:"The secret used to encrypt and sign the trusted device cookie."`
+	Lifespan time.Duration `koanf:"lifespan" json:"lifespan" jsonschema:"default=30 days,title=Lifespan" jsonschema_description:"The length of time a device remains trusted after the user has chosen to trust it."`
+}
+
+// DefaultTrustedDeviceConfiguration represents default configuration parameters for trusted devices.
+var DefaultTrustedDeviceConfiguration = TrustedDevice{
+	Lifespan: time.Hour * 24 * 30,
+}
@@ -15,6 +15,31 @@ type WebAuthn struct {
 	UserVerification     protocol.UserVerificationRequirement `koanf:"user_verification" json:"user_verification" jsonschema:"default=preferred,enum=discouraged,enum=preferred,enum=required,title=User Verification" jsonschema_description:"The default user verification preference for all WebAuthn credentials."`
 
 	Timeout time.Duration `koanf:"timeout" json:"timeout" jsonschema:"default=60 seconds,title=Timeout" jsonschema_description:"The default timeout for all WebAuthn ceremonies."`
+
+	EnablePasskeyLogin     bool `koanf:"enable_passkey_login" json:"enable_passkey_login" jsonschema:"default=false,title=Enable Passkey Login" jsonschema_description:"Enables logging in with a discoverable WebAuthn credential (passkey) without a username or password."`
+	EnablePasskeyTwoFactor bool `koanf:"enable_passkey_two_factor" json:"enable_passkey_two_factor" jsonschema:"default=false,title=Enable Passkey Two Factor" jsonschema_description:"Allows passkey logins which perform user verification to satisfy the two_factor authorization policy."`
+
+	Metadata  WebAuthnMetadata  `koanf:"metadata" json:"metadata" jsonschema:"title=Metadata" jsonschema_description:"The FIDO Metadata Service configuration used to validate authenticators during registration."`
+	Filtering WebAuthnFiltering `koanf:"filtering" json:"filtering" jsonschema:"title=Filtering" jsonschema_description:"The authenticator filtering configuration applied during registration."`
+}
+
+// WebAuthnMetadata represents the FIDO Metadata Service configuration for WebAuthn.
+type WebAuthnMetadata struct {
+	Enabled bool   `koanf:"enabled" json:"enabled" jsonschema:"default=false,title=Enabled" jsonschema_description:"Enables loading the FIDO Metadata Service BLOB."`
+	Path    string `koanf:"path" json:"path" jsonschema:"title=Path" jsonschema_description:"The path to a locally supplied FIDO Metadata Service BLOB."`
+
+	RootCertificate X509CertificateChain `koanf:"root_certificate" json:"root_certificate" jsonschema:"title=Root Certificate" jsonschema_description:"The root certificate used to validate the FIDO Metadata Service BLOB signature, defaults to the FIDO Alliance root certificate."`
+
+	ValidateTrustAnchor           bool `koanf:"validate_trust_anchor" json:"validate_trust_anchor" jsonschema:"default=false,title=Validate Trust Anchor" jsonschema_description:"Requires the attestation statement certificate chain to be issued by a trust anchor listed in the metadata."`
+	ValidateEntry                 bool `koanf:"validate_entry" json:"validate_entry" jsonschema:"default=false,title=Validate Entry" jsonschema_description:"Requires the authenticator to have an entry in the metadata."`
+	ValidateEntryPermitZeroAAGUID bool `koanf:"validate_entry_permit_zero_aaguid" json:"validate_entry_permit_zero_aaguid" jsonschema:"default=false,title=Validate Entry Permit Zero AAGUID" jsonschema_description:"Permits authenticators with a zero AAGUID when the validate_entry option is enabled."`
+	ValidateStatus                bool `koanf:"validate_status" json:"validate_status" jsonschema:"default=false,title=Validate Status" jsonschema_description:"Prohibits authenticators with an undesired status report in the metadata."`
+}
+
+// WebAuthnFiltering represents the authenticator filtering configuration for WebAuthn.
+type WebAuthnFiltering struct {
+	PermittedAAGUIDs  []string `koanf:"permitted_aaguids" json:"permitted_aaguids" jsonschema:"title=Permitted AAGUIDs" jsonschema_description:"The list of AAGUIDs which are exclusively permitted to be registered."`
+	ProhibitedAAGUIDs []string `koanf:"prohibited_aaguids" json:"prohibited_aaguids" jsonschema:"title=Prohibited AAGUIDs" jsonschema_description:"The list of AAGUIDs which are prohibited from being registered."`
 }
 
 // DefaultWebAuthnConfiguration describes the default values for the WebAuthn.
@@ -0,0 +1,307 @@
+package configuration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/knadh/koanf/v2"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// NewSecretResolvers returns a list of SecretResolver provided they are valid. The resolvers are configured using the
+// environment.
+func NewSecretResolvers(names []string) (resolvers []SecretResolver, err error) {
+	resolvers = make([]SecretResolver, len(names))
+
+	resolverMap := map[string]int{}
+
+	for i, name := range names {
+		name = strings.ToLower(name)
+
+		switch name {
+		case secretResolverVault:
+			if resolvers[i], err = NewVaultSecretResolverFromEnvironment(); err != nil {
+				return nil, err
+			}
+		case secretResolverExec:
+			resolvers[i] = NewExecSecretResolver()
+		default:
+			return nil, fmt.Errorf("invalid secret resolver named '%s'", name)
+		}
+
+		if _, ok := resolverMap[name]; ok {
+			return nil, fmt.Errorf("duplicate secret resolver named '%s'", name)
+		}
+
+		resolverMap[name] = 1
+	}
+
+	return resolvers, nil
+}
+
+// NewSecretReferencesSource returns a configuration.Source which resolves the secret references using the resolvers.
+// It should always be the last source so the references from all other sources are resolved.
+func NewSecretReferencesSource(resolvers ...SecretResolver) (source *SecretReferencesSource) {
+	source = &SecretReferencesSource{
+		resolvers: make(map[string]SecretResolver, len(resolvers)),
+	}
+
+	for _, resolver := range resolvers {
+		source.resolvers[resolver.Name()] = resolver
+	}
+
+	return source
+}
+
+// Name of the Source.
+func (s *SecretReferencesSource) Name() (name string) {
+	return "secret references"
+}
+
+// Merge resolves the secret references within the provided koanf.Koanf. Only the values of secret keys which have the
+// prefix of one of the resolvers followed by a colon are resolved, and the resolved values are never logged.
+func (s *SecretReferencesSource) Merge(ko *koanf.Koanf, val *schema.StructValidator) (err error) {
+	if len(s.resolvers) == 0 {
+		return nil
+	}
+
+	ctx := context.Background()
+
+	for _, key := range ko.Keys() {
+		value, changed := s.resolve(ctx, key, ko.Get(key), val)
+		if !changed {
+			continue
+		}
+
+		if err = ko.Set(key, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Load is a noop as the SecretReferencesSource only modifies the values of other sources when merging.
+func (s *SecretReferencesSource) Load(_ *schema.StructValidator) (err error) {
+	return nil
+}
+
+func (s *SecretReferencesSource) resolve(ctx context.Context, key string, value any, val *schema.StructValidator) (resolved any, changed bool) {
+	switch v := value.(type) {
+	case string:
+		if !IsSecretKey(strings.ReplaceAll(key, "[]", "")) {
+			return v, false
+		}
+
+		name, reference, ok := strings.Cut(v, ":")
+		if !ok {
+			return v, false
+		}
+
+		resolver, ok := s.resolvers[name]
+		if !ok {
+			return v, false
+		}
+
+		var err error
+
+		if resolved, err = resolver.Resolve(ctx, reference); err != nil {
+			val.Push(fmt.Errorf(errFmtSecretReference, key, name, err))
+
+			return v, false
+		}
+
+		return resolved, true
+	case []any:
+		items := make([]any, len(v))
+
+		for i, item := range v {
+			var c bool
+
+			items[i], c = s.resolve(ctx, key+"[]", item, val)
+
+			changed = changed || c
+		}
+
+		return items, changed
+	case map[string]any:
+		items := make(map[string]any, len(v))
+
+		for k, item := range v {
+			var c bool
+
+			items[k], c = s.resolve(ctx, key+constDelimiter+k, item, val)
+
+			changed = changed || c
+		}
+
+		return items, changed
+	default:
+		return value, false
+	}
+}
+
+// NewVaultSecretResolverFromEnvironment returns a new VaultSecretResolver configured using the standard VAULT_ADDR,
+// VAULT_TOKEN, and VAULT_NAMESPACE environment variables.
+func NewVaultSecretResolverFromEnvironment() (resolver *VaultSecretResolver, err error) {
+	var (
+		address *url.URL
+		value   string
+	)
+
+	if value = os.Getenv(envVaultAddress); value == "" {
+		return nil, fmt.Errorf("the '%s' secret resolver requires the '%s' environment variable", secretResolverVault, envVaultAddress)
+	}
+
+	if address, err = url.Parse(value); err != nil {
+		return nil, fmt.Errorf("the '%s' secret resolver could not parse the '%s' environment variable: %w", secretResolverVault, envVaultAddress, err)
+	}
+
+	return NewVaultSecretResolver(address, os.Getenv(envVaultToken), os.Getenv(envVaultNamespace), nil), nil
+}
+
+// NewVaultSecretResolver returns a new VaultSecretResolver. If the client is nil a client with the default timeout is
+// used.
+func NewVaultSecretResolver(address *url.URL, token, namespace string, client *http.Client) (resolver *VaultSecretResolver) {
+	if client == nil {
+		client = &http.Client{Timeout: secretResolverTimeout}
+	}
+
+	return &VaultSecretResolver{
+		client:    client,
+		address:   address,
+		token:     token,
+		namespace: namespace,
+	}
+}
+
+// Name of the SecretResolver.
+func (r *VaultSecretResolver) Name() (name string) {
+	return secretResolverVault
+}
+
+// Resolve the reference in the format of '<path>#<field>' such as 'secret/data/authelia#jwt_secret' where the path is
+// the full API path of the KV v2 secret including the mount and the data segment.
+func (r *VaultSecretResolver) Resolve(ctx context.Context, reference string) (value string, err error) {
+	path, field, ok := strings.Cut(reference, "#")
+	if !ok || path == "" || field == "" {
+		return "", errors.New("the reference must be in the format '<path>#<field>'")
+	}
+
+	var req *http.Request
+
+	if req, err = http.NewRequestWithContext(ctx, http.MethodGet, r.address.JoinPath("v1", path).String(), nil); err != nil {
+		return "", err
+	}
+
+	if r.token != "" {
+		req.Header.Set(headerVaultToken, r.token)
+	}
+
+	if r.namespace != "" {
+		req.Header.Set(headerVaultNamespace, r.namespace)
+	}
+
+	var resp *http.Response
+
+	if resp, err = r.client.Do(req); err != nil {
+		return "", err
+	}
+
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		break
+	case http.StatusNotFound:
+		return "", fmt.Errorf("the secret at path '%s' does not exist", path)
+	default:
+		var e vaultErrorResponse
+
+		if err = json.NewDecoder(resp.Body).Decode(&e); err == nil && len(e.Errors) != 0 {
+			return "", fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, strings.Join(e.Errors, ", "))
+		}
+
+		return "", fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	var secret vaultSecretResponse
+
+	if err = json.NewDecoder(resp.Body).Decode(&secret); err != nil {
+		return "", fmt.Errorf("error decoding the secret at path '%s': %w", path, err)
+	}
+
+	raw, ok := secret.Data.Data[field]
+	if !ok {
+		return "", fmt.Errorf("the secret at path '%s' does not have the field '%s'", path, field)
+	}
+
+	if value, ok = raw.(string); !ok {
+		return "", fmt.Errorf("the field '%s' of the secret at path '%s' is not a string", field, path)
+	}
+
+	return value, nil
+}
+
+type vaultSecretResponse struct {
+	Data struct {
+		Data map[string]any `json:"data"`
+	} `json:"data"`
+}
+
+type vaultErrorResponse struct {
+	Errors []string `json:"errors"`
+}
+
+// NewExecSecretResolver returns a new ExecSecretResolver.
+func NewExecSecretResolver() (resolver *ExecSecretResolver) {
+	return &ExecSecretResolver{
+		timeout: secretResolverTimeout,
+	}
+}
+
+// Name of the SecretResolver.
+func (r *ExecSecretResolver) Name() (name string) {
+	return secretResolverExec
+}
+
+// Resolve the reference which is a command and its arguments separated by whitespace such as
+// '/usr/bin/pass show authelia/jwt_secret'. The command is not executed by a shell and the value is the standard output
+// of the command with the trailing newline removed. The output of the command is never included in errors.
+func (r *ExecSecretResolver) Resolve(ctx context.Context, reference string) (value string, err error) {
+	args := strings.Fields(reference)
+	if len(args) == 0 {
+		return "", errors.New("the reference must contain a command")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...) //nolint:gosec // The command is provided by the administrator.
+
+	cmd.WaitDelay = time.Second
+
+	var out []byte
+
+	if out, err = cmd.Output(); err != nil {
+		var e *exec.ExitError
+
+		if errors.As(err, &e) {
+			return "", fmt.Errorf("the command '%s' exited with code %d", args[0], e.ExitCode())
+		}
+
+		return "", fmt.Errorf("the command '%s' could not be executed: %w", args[0], err)
+	}
+
+	return strings.TrimRight(string(out), "\r\n"), nil
+}
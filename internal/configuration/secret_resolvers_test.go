@@ -0,0 +1,223 @@
+package configuration
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"runtime"
+	"testing"
+
+	"github.com/knadh/koanf/providers/confmap"
+	"github.com/knadh/koanf/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestNewSecretResolvers(t *testing.T) {
+	t.Setenv(envVaultAddress, "https://vault.example.com:8200")
+
+	testCases := []struct {
+		name     string
+		have     []string
+		expected []string
+		err      string
+	}{
+		{"ShouldReturnNoResolvers", nil, []string{}, ""},
+		{"ShouldReturnVault", []string{"vault"}, []string{"vault"}, ""},
+		{"ShouldReturnExec", []string{"EXEC"}, []string{"exec"}, ""},
+		{"ShouldReturnAll", []string{"vault", "exec"}, []string{"vault", "exec"}, ""},
+		{"ShouldErrorOnInvalidName", []string{"abc"}, nil, "invalid secret resolver named 'abc'"},
+		{"ShouldErrorOnDuplicateName", []string{"exec", "Exec"}, nil, "duplicate secret resolver named 'exec'"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resolvers, err := NewSecretResolvers(tc.have)
+
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				assert.Nil(t, resolvers)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			names := make([]string, len(resolvers))
+
+			for i, resolver := range resolvers {
+				names[i] = resolver.Name()
+			}
+
+			assert.Equal(t, tc.expected, names)
+		})
+	}
+}
+
+func TestNewSecretResolversShouldErrorWithoutVaultAddress(t *testing.T) {
+	t.Setenv(envVaultAddress, "")
+
+	resolvers, err := NewSecretResolvers([]string{"vault"})
+
+	assert.EqualError(t, err, "the 'vault' secret resolver requires the 'VAULT_ADDR' environment variable")
+	assert.Nil(t, resolvers)
+}
+
+func TestSecretReferencesSource(t *testing.T) {
+	ko := koanf.New(constDelimiter)
+
+	require.NoError(t, ko.Load(confmap.Provider(map[string]any{
+		"identity_validation.reset_password.jwt_secret": "test:jwt",
+		"session.secret":            "not-a-reference",
+		"session.name":              "test:name",
+		"storage.postgres.password": "other:password",
+		"notifier.smtp.password":    "test:missing",
+		"identity_providers.oidc.clients": []any{
+			map[string]any{"client_id": "test:id", "client_secret": "test:client"},
+		},
+	}, constDelimiter), nil))
+
+	val := schema.NewStructValidator()
+
+	source := NewSecretReferencesSource(&testSecretResolver{values: map[string]string{"jwt": "resolved-jwt", "name": "resolved-name", "client": "resolved-client"}})
+
+	assert.Equal(t, "secret references", source.Name())
+	assert.NoError(t, source.Load(val))
+	require.NoError(t, source.Merge(ko, val))
+
+	assert.Equal(t, "resolved-jwt", ko.String("identity_validation.reset_password.jwt_secret"))
+	assert.Equal(t, "not-a-reference", ko.String("session.secret"))
+	assert.Equal(t, "test:name", ko.String("session.name"))
+	assert.Equal(t, "other:password", ko.String("storage.postgres.password"))
+	assert.Equal(t, "test:missing", ko.String("notifier.smtp.password"))
+
+	clients, ok := ko.Get("identity_providers.oidc.clients").([]any)
+	require.True(t, ok)
+	require.Len(t, clients, 1)
+
+	client, ok := clients[0].(map[string]any)
+	require.True(t, ok)
+
+	assert.Equal(t, "test:id", client["client_id"])
+	assert.Equal(t, "resolved-client", client["client_secret"])
+
+	require.Len(t, val.Errors(), 1)
+	assert.EqualError(t, val.Errors()[0], "secret references: error resolving the reference for key 'notifier.smtp.password' using the 'test' resolver: not found")
+}
+
+func TestVaultSecretResolver(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Header.Get(headerVaultToken) != "s.token":
+			w.WriteHeader(http.StatusForbidden)
+
+			_ = json.NewEncoder(w).Encode(vaultErrorResponse{Errors: []string{"permission denied"}})
+		case r.Header.Get(headerVaultNamespace) != "ns1":
+			w.WriteHeader(http.StatusBadRequest)
+		case r.URL.Path == "/v1/secret/data/authelia":
+			_, _ = w.Write([]byte(`{"data":{"data":{"jwt_secret":"abc123","number":5},"metadata":{"version":1}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+
+			_ = json.NewEncoder(w).Encode(vaultErrorResponse{})
+		}
+	}))
+
+	defer server.Close()
+
+	address, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name      string
+		token     string
+		reference string
+		expected  string
+		err       string
+	}{
+		{"ShouldResolve", "s.token", "secret/data/authelia#jwt_secret", "abc123", ""},
+		{"ShouldErrorOnMissingField", "s.token", "secret/data/authelia#other", "", "the secret at path 'secret/data/authelia' does not have the field 'other'"},
+		{"ShouldErrorOnNonString", "s.token", "secret/data/authelia#number", "", "the field 'number' of the secret at path 'secret/data/authelia' is not a string"},
+		{"ShouldErrorOnMissingSecret", "s.token", "secret/data/other#jwt_secret", "", "the secret at path 'secret/data/other' does not exist"},
+		{"ShouldErrorOnPermissionDenied", "s.bad", "secret/data/authelia#jwt_secret", "", "unexpected status code 403: permission denied"},
+		{"ShouldErrorOnInvalidFormat", "s.token", "secret/data/authelia", "", "the reference must be in the format '<path>#<field>'"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			resolver := NewVaultSecretResolver(address, tc.token, "ns1", server.Client())
+
+			assert.Equal(t, "vault", resolver.Name())
+
+			value, err := resolver.Resolve(context.Background(), tc.reference)
+
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				assert.Equal(t, "", value)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, value)
+			}
+		})
+	}
+}
+
+func TestExecSecretResolver(t *testing.T) {
+	if runtime.GOOS == constWindows {
+		t.Skip("skipping test on windows")
+	}
+
+	testCases := []struct {
+		name      string
+		reference string
+		expected  string
+		err       string
+	}{
+		{"ShouldResolve", "echo abc123", "abc123", ""},
+		{"ShouldResolveTrimmingTrailingNewlines", "printf abc\\n\\n", "abc", ""},
+		{"ShouldErrorOnExitCode", "false", "", "the command 'false' exited with code 1"},
+		{"ShouldErrorOnMissingCommand", "/nonexistent/command", "", "the command '/nonexistent/command' could not be executed: fork/exec /nonexistent/command: no such file or directory"},
+		{"ShouldErrorOnEmpty", "  ", "", "the reference must contain a command"},
+	}
+
+	resolver := NewExecSecretResolver()
+
+	assert.Equal(t, "exec", resolver.Name())
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			value, err := resolver.Resolve(context.Background(), tc.reference)
+
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				assert.Equal(t, "", value)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, value)
+			}
+		})
+	}
+}
+
+type testSecretResolver struct {
+	values map[string]string
+}
+
+func (r *testSecretResolver) Name() (name string) {
+	return "test"
+}
+
+func (r *testSecretResolver) Resolve(_ context.Context, reference string) (value string, err error) {
+	var ok bool
+
+	if value, ok = r.values[reference]; !ok {
+		return "", errors.New("not found")
+	}
+
+	return value, nil
+}
@@ -356,11 +356,10 @@ func (s *MapSource) Load(_ *schema.StructValidator) (err error) {
 	return s.koanf.Load(confmap.Provider(s.m, constDelimiter), nil)
 }
 
-// NewDefaultSources returns a slice of Source configured to load from specified YAML files.
+// NewDefaultSources returns a slice of Source configured to load from specified YAML files or remote URLs.
 func NewDefaultSources(paths []string, prefix, delimiter string, additionalSources ...Source) (sources []Source) {
-	fileSources := NewFileSources(paths)
-	for _, source := range fileSources {
-		sources = append(sources, source)
+	for _, path := range paths {
+		sources = append(sources, NewPathSource(path))
 	}
 
 	sources = append(sources, NewEnvironmentSource(prefix, delimiter))
@@ -373,11 +372,10 @@ func NewDefaultSources(paths []string, prefix, delimiter string, additionalSourc
 	return sources
 }
 
-// NewDefaultSourcesFiltered returns a slice of Source configured to load from specified YAML files.
+// NewDefaultSourcesFiltered returns a slice of Source configured to load from specified YAML files or remote URLs.
 func NewDefaultSourcesFiltered(paths []string, filters []BytesFilter, prefix, delimiter string, additionalSources ...Source) (sources []Source) {
-	fileSources := NewFilteredFileSources(paths, filters)
-	for _, source := range fileSources {
-		sources = append(sources, source)
+	for _, path := range paths {
+		sources = append(sources, NewPathSource(path, filters...))
 	}
 
 	sources = append(sources, NewEnvironmentSource(prefix, delimiter))
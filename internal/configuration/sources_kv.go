@@ -0,0 +1,252 @@
+package configuration
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/knadh/koanf/parsers/yaml"
+	"github.com/knadh/koanf/providers/rawbytes"
+	"github.com/knadh/koanf/v2"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// NewKeyValueSource returns a configuration.Source configured to load the YAML document stored at the specified key of
+// a KeyValueStore.
+func NewKeyValueSource(store KeyValueStore, key string, filters ...BytesFilter) (source *KeyValueSource) {
+	return &KeyValueSource{
+		koanf:   koanf.New(constDelimiter),
+		store:   store,
+		key:     key,
+		filters: filters,
+	}
+}
+
+// Name of the Source.
+func (s *KeyValueSource) Name() (name string) {
+	return fmt.Sprintf("%s key(%s)", s.store.Name(), s.key)
+}
+
+// Merge the KeyValueSource koanf.Koanf into the provided one.
+func (s *KeyValueSource) Merge(ko *koanf.Koanf, _ *schema.StructValidator) (err error) {
+	return ko.Merge(s.koanf)
+}
+
+// Load the Source into the KeyValueSource koanf.Koanf.
+func (s *KeyValueSource) Load(_ *schema.StructValidator) (err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), remoteSourceTimeout)
+
+	defer cancel()
+
+	var data []byte
+
+	if data, s.revision, err = s.store.Get(ctx, s.key); err != nil {
+		return fmt.Errorf("error loading %s: %w", s.Name(), err)
+	}
+
+	if data, err = filterBytes(data, s.filters); err != nil {
+		return err
+	}
+
+	return s.koanf.Load(rawbytes.Provider(data), yaml.Parser())
+}
+
+// Changed returns true if the revision of the key has changed since it was last loaded or checked.
+func (s *KeyValueSource) Changed(ctx context.Context) (changed bool, err error) {
+	var revision string
+
+	if _, revision, err = s.store.Get(ctx, s.key); err != nil {
+		return false, fmt.Errorf("error checking %s: %w", s.Name(), err)
+	}
+
+	changed, s.revision = revision != s.revision, revision
+
+	return changed, nil
+}
+
+// NewConsulKeyValueStore returns a new ConsulKeyValueStore for the agent at the host of the URL. The query of the URL
+// is passed to the API which allows specifying parameters such as the datacenter.
+func NewConsulKeyValueStore(u *url.URL, opts RemoteSourceOptions) (store *ConsulKeyValueStore) {
+	return &ConsulKeyValueStore{
+		client: remoteClient(opts),
+		base:   remoteBaseURL(u, u.Scheme == schemeConsulHTTPS),
+		token:  opts.Token,
+	}
+}
+
+// Name of the KeyValueStore.
+func (s *ConsulKeyValueStore) Name() (name string) {
+	return fmt.Sprintf("consul(%s)", s.base.Host)
+}
+
+// Get the value of a key and the revision of the key. The revision is the X-Consul-Index of the response.
+func (s *ConsulKeyValueStore) Get(ctx context.Context, key string) (value []byte, revision string, err error) {
+	u := s.base.JoinPath("v1", "kv", key)
+
+	query := u.Query()
+
+	query.Set("raw", "")
+
+	u.RawQuery = query.Encode()
+
+	var req *http.Request
+
+	if req, err = http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil); err != nil {
+		return nil, "", err
+	}
+
+	if s.token != "" {
+		req.Header.Set(headerConsulToken, s.token)
+	}
+
+	var resp *http.Response
+
+	if resp, err = s.client.Do(req); err != nil {
+		return nil, "", err
+	}
+
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		break
+	case http.StatusNotFound:
+		return nil, "", fmt.Errorf("the key '%s' does not exist", key)
+	default:
+		return nil, "", fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	if value, err = io.ReadAll(resp.Body); err != nil {
+		return nil, "", err
+	}
+
+	return value, resp.Header.Get(headerConsulIndex), nil
+}
+
+// NewEtcdKeyValueStore returns a new EtcdKeyValueStore for the etcd server at the host of the URL. If the URL has
+// user information it's used to authenticate.
+func NewEtcdKeyValueStore(u *url.URL, opts RemoteSourceOptions) (store *EtcdKeyValueStore) {
+	store = &EtcdKeyValueStore{
+		client: remoteClient(opts),
+		base:   remoteBaseURL(u, u.Scheme == schemeEtcdHTTPS),
+	}
+
+	store.base.RawQuery = ""
+
+	if u.User != nil {
+		store.username = u.User.Username()
+		store.password, _ = u.User.Password()
+	}
+
+	return store
+}
+
+// Name of the KeyValueStore.
+func (s *EtcdKeyValueStore) Name() (name string) {
+	return fmt.Sprintf("etcd(%s)", s.base.Host)
+}
+
+// Get the value of a key and the revision of the key. The revision is the mod_revision of the key.
+func (s *EtcdKeyValueStore) Get(ctx context.Context, key string) (value []byte, revision string, err error) {
+	var token string
+
+	if s.username != "" {
+		var auth etcdAuthenticateResponse
+
+		if err = s.do(ctx, "", "/v3/auth/authenticate", etcdAuthenticateRequest{Name: s.username, Password: s.password}, &auth); err != nil {
+			return nil, "", fmt.Errorf("error authenticating: %w", err)
+		}
+
+		token = auth.Token
+	}
+
+	var result etcdRangeResponse
+
+	if err = s.do(ctx, token, "/v3/kv/range", etcdRangeRequest{Key: base64.StdEncoding.EncodeToString([]byte(key))}, &result); err != nil {
+		return nil, "", err
+	}
+
+	if len(result.KVs) == 0 {
+		return nil, "", fmt.Errorf("the key '%s' does not exist", key)
+	}
+
+	if value, err = base64.StdEncoding.DecodeString(result.KVs[0].Value); err != nil {
+		return nil, "", fmt.Errorf("error decoding the value of the key '%s': %w", key, err)
+	}
+
+	return value, result.KVs[0].ModRevision, nil
+}
+
+func (s *EtcdKeyValueStore) do(ctx context.Context, token, path string, body, v any) (err error) {
+	var data []byte
+
+	if data, err = json.Marshal(body); err != nil {
+		return err
+	}
+
+	var req *http.Request
+
+	if req, err = http.NewRequestWithContext(ctx, http.MethodPost, s.base.JoinPath(path).String(), bytes.NewReader(data)); err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	if token != "" {
+		req.Header.Set("Authorization", token)
+	}
+
+	var resp *http.Response
+
+	if resp, err = s.client.Do(req); err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var e etcdErrorResponse
+
+		if err = json.NewDecoder(resp.Body).Decode(&e); err == nil && e.Message != "" {
+			return errors.New(e.Message)
+		}
+
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+type etcdAuthenticateRequest struct {
+	Name     string `json:"name"`
+	Password string `json:"password"`
+}
+
+type etcdAuthenticateResponse struct {
+	Token string `json:"token"`
+}
+
+type etcdRangeRequest struct {
+	Key string `json:"key"`
+}
+
+type etcdRangeResponse struct {
+	KVs []etcdKeyValue `json:"kvs"`
+}
+
+type etcdKeyValue struct {
+	Key         string `json:"key"`
+	Value       string `json:"value"`
+	ModRevision string `json:"mod_revision"`
+}
+
+type etcdErrorResponse struct {
+	Message string `json:"message"`
+}
@@ -0,0 +1,170 @@
+package configuration
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyValueSourceConsul(t *testing.T) {
+	var (
+		document = []byte("theme: dark\nlog:\n  level: debug\n")
+		index    = 10
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Header.Get(headerConsulToken) != "abc123":
+			w.WriteHeader(http.StatusForbidden)
+		case r.URL.Path != "/v1/kv/authelia/configuration.yml":
+			w.WriteHeader(http.StatusNotFound)
+		case !r.URL.Query().Has("raw") || r.URL.Query().Get("dc") != "dc1":
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			w.Header().Set(headerConsulIndex, strconv.Itoa(index))
+
+			_, _ = w.Write(document)
+		}
+	}))
+
+	defer server.Close()
+
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	opts := RemoteSourceOptions{Client: server.Client(), Token: "abc123"}
+
+	source := NewRemoteSource("consul://"+u.Host+"/authelia/configuration.yml?dc=dc1", opts, &testBytesFilter{})
+	require.NotNil(t, source)
+
+	require.NoError(t, source.Load(nil))
+
+	kv := source.(*KeyValueSource)
+
+	assert.Equal(t, "dark", kv.koanf.String("theme"))
+	assert.Equal(t, "debug", kv.koanf.String("log.level"))
+	assert.Equal(t, "filtered", kv.koanf.String("filter"))
+
+	changed, err := source.Changed(context.Background())
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	index = 11
+
+	changed, err = source.Changed(context.Background())
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	source = NewRemoteSource("consul://"+u.Host+"/authelia/missing.yml?dc=dc1", opts)
+	require.NotNil(t, source)
+
+	assert.EqualError(t, source.Load(nil), "error loading consul("+u.Host+") key(authelia/missing.yml): the key 'authelia/missing.yml' does not exist")
+
+	source = NewRemoteSource("consul://"+u.Host+"/authelia/configuration.yml?dc=dc1", RemoteSourceOptions{Client: server.Client()})
+	require.NotNil(t, source)
+
+	assert.EqualError(t, source.Load(nil), "error loading consul("+u.Host+") key(authelia/configuration.yml): unexpected status code 403")
+}
+
+func TestKeyValueSourceEtcd(t *testing.T) {
+	var (
+		document = []byte("theme: dark\nlog:\n  level: debug\n")
+		revision = 5
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v3/auth/authenticate":
+			var req etcdAuthenticateRequest
+
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Name != "authelia" || req.Password != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+
+				_ = json.NewEncoder(w).Encode(etcdErrorResponse{Message: "etcdserver: authentication failed, invalid user ID or password"})
+
+				return
+			}
+
+			_ = json.NewEncoder(w).Encode(etcdAuthenticateResponse{Token: "token123"})
+		case "/v3/kv/range":
+			if r.Header.Get("Authorization") != "token123" {
+				w.WriteHeader(http.StatusUnauthorized)
+
+				_ = json.NewEncoder(w).Encode(etcdErrorResponse{Message: "etcdserver: user name is empty"})
+
+				return
+			}
+
+			var req etcdRangeRequest
+
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+
+				return
+			}
+
+			key, _ := base64.StdEncoding.DecodeString(req.Key)
+
+			var resp etcdRangeResponse
+
+			if string(key) == "/authelia/configuration.yml" {
+				resp.KVs = []etcdKeyValue{
+					{
+						Key:         req.Key,
+						Value:       base64.StdEncoding.EncodeToString(document),
+						ModRevision: strconv.Itoa(revision),
+					},
+				}
+			}
+
+			_ = json.NewEncoder(w).Encode(resp)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	defer server.Close()
+
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	opts := RemoteSourceOptions{Client: server.Client()}
+
+	source := NewRemoteSource("etcd://authelia:secret@"+u.Host+"/authelia/configuration.yml", opts)
+	require.NotNil(t, source)
+
+	require.NoError(t, source.Load(nil))
+
+	kv := source.(*KeyValueSource)
+
+	assert.Equal(t, "dark", kv.koanf.String("theme"))
+	assert.Equal(t, "debug", kv.koanf.String("log.level"))
+
+	changed, err := source.Changed(context.Background())
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	revision = 6
+
+	changed, err = source.Changed(context.Background())
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	source = NewRemoteSource("etcd://authelia:secret@"+u.Host+"/authelia/missing.yml", opts)
+	require.NotNil(t, source)
+
+	assert.EqualError(t, source.Load(nil), "error loading etcd("+u.Host+") key(/authelia/missing.yml): the key '/authelia/missing.yml' does not exist")
+
+	source = NewRemoteSource("etcd://authelia:bad@"+u.Host+"/authelia/configuration.yml", opts)
+	require.NotNil(t, source)
+
+	assert.EqualError(t, source.Load(nil), "error loading etcd("+u.Host+") key(/authelia/configuration.yml): error authenticating: etcdserver: authentication failed, invalid user ID or password")
+}
@@ -0,0 +1,280 @@
+package configuration
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/knadh/koanf/parsers/yaml"
+	"github.com/knadh/koanf/providers/rawbytes"
+	"github.com/knadh/koanf/v2"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// IsRemoteSourcePath returns true if the path is a URL with a scheme supported by NewRemoteSource.
+func IsRemoteSourcePath(path string) bool {
+	u, err := url.Parse(path)
+	if err != nil {
+		return false
+	}
+
+	switch u.Scheme {
+	case schemeHTTP, schemeHTTPS, schemeConsul, schemeConsulHTTPS, schemeEtcd, schemeEtcdHTTPS:
+		return u.Host != ""
+	default:
+		return false
+	}
+}
+
+// NewRemoteSourceOptionsFromEnvironment returns the RemoteSourceOptions configured via the environment.
+func NewRemoteSourceOptionsFromEnvironment() (opts RemoteSourceOptions) {
+	return RemoteSourceOptions{
+		PublicKeyPath: os.Getenv(envRemotePublicKey),
+		Token:         os.Getenv(envConsulToken),
+	}
+}
+
+// NewRemoteSource returns a RemoteSource configured to load from the specified URL, or nil if the path is not a
+// supported remote URL. The HTTP(S) URLs load the document at the URL, the Consul and etcd URLs load the document
+// stored at the key represented by the URL path.
+func NewRemoteSource(path string, opts RemoteSourceOptions, filters ...BytesFilter) (source RemoteSource) {
+	if !IsRemoteSourcePath(path) {
+		return nil
+	}
+
+	u, _ := url.Parse(path)
+
+	switch u.Scheme {
+	case schemeHTTP, schemeHTTPS:
+		return NewHTTPSource(u, opts, filters...)
+	case schemeConsul, schemeConsulHTTPS:
+		return NewKeyValueSource(NewConsulKeyValueStore(u, opts), strings.TrimPrefix(u.Path, "/"), filters...)
+	default:
+		return NewKeyValueSource(NewEtcdKeyValueStore(u, opts), u.Path, filters...)
+	}
+}
+
+// NewPathSource returns a RemoteSource if the path is a supported remote URL, otherwise it returns a FileSource.
+func NewPathSource(path string, filters ...BytesFilter) (source Source) {
+	if remote := NewRemoteSource(path, NewRemoteSourceOptionsFromEnvironment(), filters...); remote != nil {
+		return remote
+	}
+
+	return NewFilteredFileSource(path, filters...)
+}
+
+// NewHTTPSource returns a configuration.Source configured to load from a specified HTTP(S) URL.
+func NewHTTPSource(u *url.URL, opts RemoteSourceOptions, filters ...BytesFilter) (source *HTTPSource) {
+	return &HTTPSource{
+		koanf:   koanf.New(constDelimiter),
+		url:     u,
+		opts:    opts,
+		filters: filters,
+	}
+}
+
+// Name of the Source.
+func (s *HTTPSource) Name() (name string) {
+	return fmt.Sprintf("http url(%s)", s.url.Redacted())
+}
+
+// Merge the HTTPSource koanf.Koanf into the provided one.
+func (s *HTTPSource) Merge(ko *koanf.Koanf, _ *schema.StructValidator) (err error) {
+	return ko.Merge(s.koanf)
+}
+
+// Load the Source into the HTTPSource koanf.Koanf.
+func (s *HTTPSource) Load(_ *schema.StructValidator) (err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), remoteSourceTimeout)
+
+	defer cancel()
+
+	var data []byte
+
+	if data, _, err = s.fetch(ctx, false); err != nil {
+		return err
+	}
+
+	if data, err = filterBytes(data, s.filters); err != nil {
+		return err
+	}
+
+	return s.koanf.Load(rawbytes.Provider(data), yaml.Parser())
+}
+
+// Changed performs a conditional request using the ETag of the last response, and returns true if the document has
+// been modified. If the server doesn't provide an ETag the digest of the document is compared instead.
+func (s *HTTPSource) Changed(ctx context.Context) (changed bool, err error) {
+	_, changed, err = s.fetch(ctx, true)
+
+	return changed, err
+}
+
+func (s *HTTPSource) fetch(ctx context.Context, conditional bool) (data []byte, changed bool, err error) {
+	var req *http.Request
+
+	if req, err = http.NewRequestWithContext(ctx, http.MethodGet, s.url.String(), nil); err != nil {
+		return nil, false, fmt.Errorf("error creating request for %s: %w", s.Name(), err)
+	}
+
+	if conditional && s.etag != "" {
+		req.Header.Set("If-None-Match", s.etag)
+	}
+
+	var resp *http.Response
+
+	if resp, err = remoteClient(s.opts).Do(req); err != nil {
+		return nil, false, fmt.Errorf("error performing request for %s: %w", s.Name(), err)
+	}
+
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		break
+	case http.StatusNotModified:
+		if conditional {
+			return nil, false, nil
+		}
+
+		fallthrough
+	default:
+		return nil, false, fmt.Errorf("error performing request for %s: unexpected status code %d", s.Name(), resp.StatusCode)
+	}
+
+	if data, err = io.ReadAll(resp.Body); err != nil {
+		return nil, false, fmt.Errorf("error reading response for %s: %w", s.Name(), err)
+	}
+
+	if err = s.verify(data, resp.Header.Get(headerRemoteSignature)); err != nil {
+		return nil, false, fmt.Errorf("error verifying response for %s: %w", s.Name(), err)
+	}
+
+	etag, digest := resp.Header.Get("ETag"), sha256.Sum256(data)
+
+	if etag != "" {
+		changed = etag != s.etag
+	} else {
+		changed = digest != s.digest
+	}
+
+	s.etag, s.digest = etag, digest
+
+	return data, changed, nil
+}
+
+func (s *HTTPSource) verify(data []byte, signature string) (err error) {
+	if s.opts.PublicKeyPath == "" {
+		return nil
+	}
+
+	if s.key == nil {
+		if s.key, err = loadRemotePublicKey(s.opts.PublicKeyPath); err != nil {
+			return err
+		}
+	}
+
+	if signature == "" {
+		return fmt.Errorf("the response does not have a '%s' header", headerRemoteSignature)
+	}
+
+	var sig []byte
+
+	if sig, err = base64.StdEncoding.DecodeString(signature); err != nil {
+		return fmt.Errorf("the '%s' header is not valid base64: %w", headerRemoteSignature, err)
+	}
+
+	if !verifyRemoteSignature(s.key, data, sig) {
+		return errors.New("the signature is not valid")
+	}
+
+	return nil
+}
+
+func loadRemotePublicKey(path string) (key crypto.PublicKey, err error) {
+	var data []byte
+
+	if data, err = os.ReadFile(path); err != nil {
+		return nil, fmt.Errorf("error reading public key: %w", err)
+	}
+
+	var decoded any
+
+	if decoded, err = utils.ParseX509FromPEM(data); err != nil {
+		return nil, fmt.Errorf("error parsing public key: %w", err)
+	}
+
+	switch k := decoded.(type) {
+	case ed25519.PublicKey, *ecdsa.PublicKey, *rsa.PublicKey:
+		return k, nil
+	default:
+		return nil, fmt.Errorf("error parsing public key: the key type %T is not supported", decoded)
+	}
+}
+
+// verifyRemoteSignature verifies the signature of the data. The Ed25519 signatures are over the raw data, the ECDSA
+// and RSA (PKCS #1 v1.5) signatures are over the SHA-256 digest of the data.
+func verifyRemoteSignature(key crypto.PublicKey, data, signature []byte) bool {
+	switch k := key.(type) {
+	case ed25519.PublicKey:
+		return ed25519.Verify(k, data, signature)
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(data)
+
+		return ecdsa.VerifyASN1(k, digest[:], signature)
+	case *rsa.PublicKey:
+		digest := sha256.Sum256(data)
+
+		return rsa.VerifyPKCS1v15(k, crypto.SHA256, digest[:], signature) == nil
+	default:
+		return false
+	}
+}
+
+func remoteClient(opts RemoteSourceOptions) *http.Client {
+	if opts.Client != nil {
+		return opts.Client
+	}
+
+	return &http.Client{Timeout: remoteSourceTimeout}
+}
+
+func remoteBaseURL(u *url.URL, secure bool) *url.URL {
+	base := &url.URL{Scheme: schemeHTTP, Host: u.Host, RawQuery: u.RawQuery}
+
+	if secure {
+		base.Scheme = schemeHTTPS
+	}
+
+	return base
+}
+
+func filterBytes(in []byte, filters []BytesFilter) (out []byte, err error) {
+	if len(in) == 0 || len(filters) == 0 {
+		return in, nil
+	}
+
+	out = bytes.Clone(in)
+
+	for _, filter := range filters {
+		if out, err = filter.Filter(out); err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
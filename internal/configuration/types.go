@@ -1,6 +1,13 @@
 package configuration
 
 import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"net/http"
+	"net/url"
+	"time"
+
 	"github.com/knadh/koanf/v2"
 	"github.com/spf13/pflag"
 
@@ -16,9 +23,10 @@ type Source interface {
 
 // FileSource is a file configuration.Source.
 type FileSource struct {
-	koanf   *koanf.Koanf
-	path    string
-	filters []BytesFilter
+	koanf     *koanf.Koanf
+	path      string
+	filters   []BytesFilter
+	positions map[string]string
 }
 
 // BytesSource is a raw bytes configuration.Source.
@@ -60,3 +68,98 @@ type File struct {
 	Path string
 	Data []byte
 }
+
+// RemoteSource is a configuration.Source which loads the configuration from a remote location and is able to detect
+// modifications to the remote configuration.
+type RemoteSource interface {
+	Source
+
+	// Changed returns true if the remote configuration has been modified since it was last loaded or checked.
+	Changed(ctx context.Context) (changed bool, err error)
+}
+
+// RemoteSourceOptions are the options used by the RemoteSource implementations.
+type RemoteSourceOptions struct {
+	// Client is the http.Client used for requests, if nil a client with the default timeout is used.
+	Client *http.Client
+
+	// PublicKeyPath is the path to a PEM encoded public key. If configured the HTTPSource requires the payload to have a
+	// valid signature from the matching private key.
+	PublicKeyPath string
+
+	// Token is the ACL token used by the Consul key-value store.
+	Token string
+}
+
+// HTTPSource is a configuration.Source which loads a YAML document from a HTTP(S) URL.
+type HTTPSource struct {
+	koanf   *koanf.Koanf
+	url     *url.URL
+	opts    RemoteSourceOptions
+	filters []BytesFilter
+	key     crypto.PublicKey
+
+	etag   string
+	digest [sha256.Size]byte
+}
+
+// KeyValueStore is an abstract representation of a remote key-value store.
+type KeyValueStore interface {
+	// Name of the KeyValueStore.
+	Name() (name string)
+
+	// Get the value of a key and the revision of the key, the revision changes every time the key is modified.
+	Get(ctx context.Context, key string) (value []byte, revision string, err error)
+}
+
+// KeyValueSource is a configuration.Source which loads a YAML document from a single key of a KeyValueStore.
+type KeyValueSource struct {
+	koanf    *koanf.Koanf
+	store    KeyValueStore
+	key      string
+	filters  []BytesFilter
+	revision string
+}
+
+// ConsulKeyValueStore is a KeyValueStore backed by the Consul KV HTTP API.
+type ConsulKeyValueStore struct {
+	client *http.Client
+	base   *url.URL
+	token  string
+}
+
+// EtcdKeyValueStore is a KeyValueStore backed by the etcd v3 JSON gateway API.
+type EtcdKeyValueStore struct {
+	client   *http.Client
+	base     *url.URL
+	username string
+	password string
+}
+
+// SecretResolver is an abstract representation of an external secret store which resolves secret references.
+type SecretResolver interface {
+	// Name of the SecretResolver which is also the prefix of the references it resolves.
+	Name() (name string)
+
+	// Resolve the reference to the secret value. The reference is the value without the prefix.
+	Resolve(ctx context.Context, reference string) (value string, err error)
+}
+
+// SecretReferencesSource is a configuration.Source which replaces the secret references within the values of the
+// secret keys of the previously merged sources with the values resolved by a SecretResolver.
+type SecretReferencesSource struct {
+	resolvers map[string]SecretResolver
+}
+
+// VaultSecretResolver is a SecretResolver which resolves references to the fields of HashiCorp Vault KV v2 secrets.
+type VaultSecretResolver struct {
+	client    *http.Client
+	address   *url.URL
+	token     string
+	namespace string
+}
+
+// ExecSecretResolver is a SecretResolver which resolves references by executing a command and using the output.
+type ExecSecretResolver struct {
+	timeout time.Duration
+}
@@ -107,6 +107,10 @@ func ValidateRules(config *schema.Configuration, validator *schema.StructValidat
 
 		validateQuery(i, rule, config, validator)
 
+		validateAMR(rulePosition, rule, validator)
+
+		validateMaxAuthenticationAge(rulePosition, rule, validator)
+
 		if rule.Policy == policyBypass {
 			validateBypass(rulePosition, rule, validator)
 		}
@@ -170,6 +174,40 @@ func validateMethods(rulePosition int, rule schema.AccessControlRule, validator
 	}
 }
 
+func validateAMR(rulePosition int, rule schema.AccessControlRule, validator *schema.StructValidator) {
+	if len(rule.AMR) == 0 {
+		return
+	}
+
+	invalid, duplicates := validateList(rule.AMR, validACLRuleAMR, true)
+
+	if len(invalid) != 0 {
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleInvalidEntries, ruleDescriptor(rulePosition, rule), "amr", utils.StringJoinOr(validACLRuleAMR), utils.StringJoinAnd(invalid)))
+	}
+
+	if len(duplicates) != 0 {
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleInvalidDuplicates, ruleDescriptor(rulePosition, rule), "amr", utils.StringJoinAnd(duplicates)))
+	}
+
+	if rule.Policy != policyTwoFactor {
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleAMRInvalidPolicy, ruleDescriptor(rulePosition, rule), rule.Policy))
+	}
+}
+
+func validateMaxAuthenticationAge(rulePosition int, rule schema.AccessControlRule, validator *schema.StructValidator) {
+	if rule.MaxAuthenticationAge.OneFactor < 0 {
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleMaxAgeNegative, ruleDescriptor(rulePosition, rule), "one_factor", rule.MaxAuthenticationAge.OneFactor))
+	} else if rule.MaxAuthenticationAge.OneFactor > 0 && rule.Policy != policyOneFactor && rule.Policy != policyTwoFactor {
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleMaxAgeInvalidPolicy, ruleDescriptor(rulePosition, rule), "one_factor", utils.StringJoinOr([]string{policyOneFactor, policyTwoFactor}), rule.Policy))
+	}
+
+	if rule.MaxAuthenticationAge.TwoFactor < 0 {
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleMaxAgeNegative, ruleDescriptor(rulePosition, rule), "two_factor", rule.MaxAuthenticationAge.TwoFactor))
+	} else if rule.MaxAuthenticationAge.TwoFactor > 0 && rule.Policy != policyTwoFactor {
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleMaxAgeInvalidPolicy, ruleDescriptor(rulePosition, rule), "two_factor", utils.StringJoinOr([]string{policyTwoFactor}), rule.Policy))
+	}
+}
+
 //nolint:gocyclo
 func validateQuery(i int, rule schema.AccessControlRule, config *schema.Configuration, validator *schema.StructValidator) {
 	for j := 0; j < len(config.AccessControl.Rules[i].Query); j++ {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
@@ -215,6 +216,119 @@ func (suite *AccessControl) TestShouldRaiseErrorDuplicateMethod() {
 	suite.Assert().EqualError(suite.validator.Errors()[0], "access_control: rule #1 (domain 'public.example.com'): option 'methods' must have unique values but the values 'GET' are duplicated")
 }
 
+func (suite *AccessControl) TestShouldValidateAMR() {
+	suite.config.AccessControl.Rules = []schema.AccessControlRule{
+		{
+			Domains: []string{"admin.example.com"},
+			Policy:  "two_factor",
+			AMR:     []string{"hwk", "email"},
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Assert().Len(suite.validator.Errors(), 0)
+}
+
+func (suite *AccessControl) TestShouldRaiseErrorInvalidAMR() {
+	suite.config.AccessControl.Rules = []schema.AccessControlRule{
+		{
+			Domains: []string{"admin.example.com"},
+			Policy:  "two_factor",
+			AMR:     []string{"hwk", "pwd", "hwk"},
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 2)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], "access_control: rule #1 (domain 'admin.example.com'): option 'amr' must only have the values 'hwk', 'otp', 'sms', or 'email' but the values 'pwd' are present")
+	suite.Assert().EqualError(suite.validator.Errors()[1], "access_control: rule #1 (domain 'admin.example.com'): option 'amr' must have unique values but the values 'hwk' are duplicated")
+}
+
+func (suite *AccessControl) TestShouldRaiseErrorAMRWithoutTwoFactorPolicy() {
+	suite.config.AccessControl.Rules = []schema.AccessControlRule{
+		{
+			Domains: []string{"admin.example.com"},
+			Policy:  "one_factor",
+			AMR:     []string{"hwk"},
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 1)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], "access_control: rule #1 (domain 'admin.example.com'): option 'amr' is only supported when the 'policy' option is 'two_factor' but it's configured as 'one_factor'")
+}
+
+func (suite *AccessControl) TestShouldValidateMaxAuthenticationAge() {
+	suite.config.AccessControl.Rules = []schema.AccessControlRule{
+		{
+			Domains:   []string{"admin.example.com"},
+			Resources: []regexp.Regexp{*regexp.MustCompile("^/admin.*$")},
+			Policy:    "two_factor",
+			MaxAuthenticationAge: schema.AccessControlRuleMaxAuthenticationAge{
+				OneFactor: time.Hour * 8,
+				TwoFactor: time.Minute * 15,
+			},
+		},
+		{
+			Domains: []string{"app.example.com"},
+			Policy:  "one_factor",
+			MaxAuthenticationAge: schema.AccessControlRuleMaxAuthenticationAge{
+				OneFactor: time.Hour,
+			},
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Assert().Len(suite.validator.Errors(), 0)
+}
+
+func (suite *AccessControl) TestShouldRaiseErrorInvalidMaxAuthenticationAge() {
+	suite.config.AccessControl.Rules = []schema.AccessControlRule{
+		{
+			Domains: []string{"admin.example.com"},
+			Policy:  "two_factor",
+			MaxAuthenticationAge: schema.AccessControlRuleMaxAuthenticationAge{
+				OneFactor: -time.Minute,
+				TwoFactor: -time.Minute,
+			},
+		},
+		{
+			Domains: []string{"app.example.com"},
+			Policy:  "one_factor",
+			MaxAuthenticationAge: schema.AccessControlRuleMaxAuthenticationAge{
+				TwoFactor: time.Minute,
+			},
+		},
+		{
+			Domains: []string{"public.example.com"},
+			Policy:  "bypass",
+			MaxAuthenticationAge: schema.AccessControlRuleMaxAuthenticationAge{
+				OneFactor: time.Minute,
+			},
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 4)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], "access_control: rule #1 (domain 'admin.example.com'): max_authentication_age: option 'one_factor' must be 0 or more but it's configured as '-1m0s'")
+	suite.Assert().EqualError(suite.validator.Errors()[1], "access_control: rule #1 (domain 'admin.example.com'): max_authentication_age: option 'two_factor' must be 0 or more but it's configured as '-1m0s'")
+	suite.Assert().EqualError(suite.validator.Errors()[2], "access_control: rule #2 (domain 'app.example.com'): max_authentication_age: option 'two_factor' is only supported when the 'policy' option is 'two_factor' but it's configured as 'one_factor'")
+	suite.Assert().EqualError(suite.validator.Errors()[3], "access_control: rule #3 (domain 'public.example.com'): max_authentication_age: option 'one_factor' is only supported when the 'policy' option is 'one_factor' or 'two_factor' but it's configured as 'bypass'")
+}
+
 func (suite *AccessControl) TestShouldRaiseErrorInvalidSubject() {
 	domains := []string{"public.example.com"}
 	subjects := [][]string{{testInvalid}}
@@ -45,6 +45,12 @@ func ValidateConfiguration(config *schema.Configuration, validator *schema.Struc
 
 	ValidateWebAuthn(config, validator)
 
+	ValidateRecoveryCodes(config, validator)
+
+	ValidateEmailOneTimeCode(config, validator)
+
+	ValidateTrustedDevice(config, validator)
+
 	ValidateAuthenticationBackend(&config.AuthenticationBackend, validator)
 
 	ValidateAccessControl(config, validator)
@@ -65,6 +71,8 @@ func ValidateConfiguration(config *schema.Configuration, validator *schema.Struc
 
 	ValidateIdentityProviders(ctx, &config.IdentityProviders, validator)
 
+	ValidateStorageRetention(config, validator)
+
 	ValidateIdentityValidation(config, validator)
 
 	ValidateNTP(config, validator)
@@ -72,6 +80,8 @@ func ValidateConfiguration(config *schema.Configuration, validator *schema.Struc
 	ValidatePasswordPolicy(&config.PasswordPolicy, validator)
 
 	ValidatePrivacyPolicy(&config.PrivacyPolicy, validator)
+
+	ValidateReload(config, validator)
 }
 
 func validateDefault2FAMethod(config *schema.Configuration, validator *schema.StructValidator) {
@@ -99,6 +109,10 @@ func validateDefault2FAMethod(config *schema.Configuration, validator *schema.St
 		enabledMethods = append(enabledMethods, "mobile_push")
 	}
 
+	if config.EmailOneTimeCode.Enable {
+		enabledMethods = append(enabledMethods, "email")
+	}
+
 	if !utils.IsStringInSlice(config.Default2FAMethod, enabledMethods) {
 		validator.Push(fmt.Errorf(errFmtInvalidDefault2FAMethodDisabled, utils.StringJoinOr(enabledMethods), config.Default2FAMethod))
 	}
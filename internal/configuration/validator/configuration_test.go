@@ -68,7 +68,7 @@ func TestShouldEnsureNotifierConfigIsProvided(t *testing.T) {
 
 	ValidateConfiguration(&config, validator)
 	require.Len(t, validator.Errors(), 1)
-	assert.EqualError(t, validator.Errors()[0], "notifier: you must ensure either the 'smtp' or 'filesystem' notifier is configured")
+	assert.EqualError(t, validator.Errors()[0], "notifier: you must ensure either the 'smtp', 'filesystem', or 'webhook' notifier is configured")
 }
 
 func TestShouldAddDefaultAccessControl(t *testing.T) {
@@ -276,13 +276,30 @@ func TestValidateDefault2FAMethod(t *testing.T) {
 				"option 'default_2fa_method' must be one of the enabled options 'totp' or 'webauthn' but it's configured as 'mobile_push'",
 			},
 		},
+		{
+			desc: "ShouldAllowEnabledMethodEmail",
+			have: &schema.Configuration{
+				Default2FAMethod: "email",
+				EmailOneTimeCode: schema.EmailOneTimeCode{Enable: true},
+			},
+		},
+		{
+			desc: "ShouldNotAllowDisabledMethodEmail",
+			have: &schema.Configuration{
+				Default2FAMethod: "email",
+				DuoAPI:           schema.DuoAPI{Disable: true},
+			},
+			expectedErrs: []string{
+				"option 'default_2fa_method' must be one of the enabled options 'totp' or 'webauthn' but it's configured as 'email'",
+			},
+		},
 		{
 			desc: "ShouldNotAllowInvalidMethodDuo",
 			have: &schema.Configuration{
 				Default2FAMethod: "duo",
 			},
 			expectedErrs: []string{
-				"option 'default_2fa_method' must be one of 'totp', 'webauthn', or 'mobile_push' but it's configured as 'duo'",
+				"option 'default_2fa_method' must be one of 'totp', 'webauthn', 'mobile_push', or 'email' but it's configured as 'duo'",
 			},
 		},
 	}
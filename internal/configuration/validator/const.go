@@ -28,6 +28,12 @@ const (
 	durationZero = time.Duration(0)
 )
 
+// Duo constants.
+const (
+	duoUniversalPromptClientIDLength     = 20
+	duoUniversalPromptClientSecretLength = 40
+)
+
 const (
 	digestSHA1   = "sha1"
 	digestSHA224 = "sha224"
@@ -62,8 +68,8 @@ const (
 
 // Notifier Error constants.
 const (
-	errFmtNotifierMultipleConfigured = "notifier: please ensure only one of the 'smtp' or 'filesystem' notifier is configured"
-	errFmtNotifierNotConfigured      = "notifier: you must ensure either the 'smtp' or 'filesystem' notifier " +
+	errFmtNotifierMultipleConfigured = "notifier: please ensure only one of the 'smtp', 'filesystem', or 'webhook' notifier is configured"
+	errFmtNotifierNotConfigured      = "notifier: you must ensure either the 'smtp', 'filesystem', or 'webhook' notifier " +
 		"is configured"
 	errFmtNotifierTemplatePathNotExist            = "notifier: option 'template_path' refers to location '%s' which does not exist"
 	errFmtNotifierTemplatePathUnknownError        = "notifier: option 'template_path' refers to location '%s' which couldn't be opened: %w"
@@ -72,6 +78,15 @@ const (
 	errFmtNotifierSMTPTLSConfigInvalid            = "notifier: smtp: tls: %w"
 	errFmtNotifierSMTPAddress                     = "notifier: smtp: option 'address' with value '%s' is invalid: %w"
 	errFmtNotifierSMTPAddressLegacyAndModern      = "notifier: smtp: option 'host' and 'port' can't be configured at the same time as 'address'"
+	errFmtNotifierWebhookNotConfigured            = "notifier: webhook: option '%s' is required"
+	errFmtNotifierWebhookAddressScheme            = "notifier: webhook: option 'address' with value '%s' is invalid: scheme must be one of 'http' or 'https' but it's configured as '%s'"
+	errFmtNotifierWebhookMustBeAboveZero          = "notifier: webhook: option '%s' must be above zero but it's configured as '%s'"
+	errFmtNotifierWebhookMaxRetriesTooLow         = "notifier: webhook: option 'max_retries' must be -1 to disable retries or above zero but it's configured as '%d'"
+	errFmtNotifierWebhookMaxRetriesTooHigh        = "notifier: webhook: option 'max_retries' must be less than or equal to '%d' but it's configured as '%d'"
+	errFmtNotifierWebhookRetryIntervalTooHigh     = "notifier: webhook: option 'retry_interval' must be less than or equal to '%s' but it's configured as '%s'"
+	errFmtNotifierWebhookTLSConfigInvalid         = "notifier: webhook: tls: %w"
+	errFmtNotifierQueueMustBeAboveZero            = "notifier: queue: option '%s' must be above zero but it's configured as '%v'"
+	errFmtNotifierQueueMaxRetryIntervalTooLow     = "notifier: queue: option 'max_retry_interval' must be greater than or equal to the 'retry_interval' value of '%s' but it's configured as '%s'"
 
 	errFmtNotifierStartTlsDisabled = "notifier: smtp: option 'disable_starttls' is enabled: " +
 		"opportunistic STARTTLS is explicitly disabled which means all emails will be sent insecurely over plaintext " +
@@ -137,6 +152,29 @@ const (
 	errFmtTOTPInvalidDigits           = "totp: option 'digits' must be 6 or 8 but it's configured as '%d'"
 	errFmtTOTPInvalidAllowedDigit     = "totp: option 'allowed_digits' must only have the values 6 or 8 but one of the values is '%d'"
 	errFmtTOTPInvalidSecretSize       = "totp: option 'secret_size' must be %d or higher but it's configured as '%d'" //nolint:gosec
+	errFmtTOTPInvalidHOTPLookAhead    = "totp: option 'hotp_look_ahead' must be 0 or higher but it's configured as '%d'"
+)
+
+// Recovery Codes Error constants.
+const (
+	errFmtRecoveryCodesInvalidCount = "recovery_codes: option 'count' must be between %d and %d but it's configured as '%d'"
+)
+
+// Email One-Time Code Error constants.
+const (
+	errFmtEmailOneTimeCodeInvalidCharacters      = "email_one_time_code: option 'characters' must be between %d and %d but it's configured as '%d'"
+	errFmtEmailOneTimeCodeInvalidRateLimitAmount = "email_one_time_code: rate_limit: option 'amount' must be 1 or more but it's configured as '%d'"
+)
+
+// Trusted Device Error constants.
+const (
+	errStrTrustedDeviceSecret         = "trusted_device: option 'secret' is required when the trusted device functionality is enabled"
+	errStrTrustedDeviceSecretTooShort = "trusted_device: option 'secret' must be 20 characters or longer"
+)
+
+// Reload Error constants.
+const (
+	errStrReloadAdministrationNoGroups = "reload: administration: option 'groups' must have at least one group when the administration endpoint is enabled"
 )
 
 // Storage Error constants.
@@ -149,6 +187,18 @@ const (
 	errFmtStorageOptionAddressConflictWithHostPort = "storage: %s: option 'host' and 'port' can't be configured at the same time as 'address'"
 	errFmtStorageFailedToConvertHostPortToAddress  = "storage: %s: option 'address' failed to parse options 'host' and 'port' as address: %w"
 
+	errFmtStorageKeyManagementOptionMustBeProvided   = "storage: key_management: %s: option '%s' is required"
+	errFmtStorageKeyManagementMultipleProviders      = "storage: key_management: only one provider may be configured but %s are configured"
+	errFmtStorageKeyManagementCommandTimeout         = "storage: key_management: command: option 'timeout' must be above zero but it's configured as '%s'"
+	errStrStorageKeyManagementSoftwareHSMPINTooShort = "storage: key_management: software_hsm: option 'pin' must be 8 characters or longer"
+
+	errFmtStorageRetentionMustBeAboveZero = "storage: retention: option '%s' must be above zero but it's configured as '%v'"
+	errFmtStorageRetentionTooShort        = "storage: retention: option '%s' must be greater than or equal to the %s option '%s' value of '%s' but it's configured as '%s'"
+
+	errFmtStorageReplicaAddress                 = "storage: %s: replicas: option 'addresses' with value '%s' is invalid: %w"
+	errFmtStorageReplicaAddressSameAsPrimary    = "storage: %s: replicas: option 'addresses' with value '%s' is invalid: the address is the same as the primary 'address'"
+	errFmtStorageReplicaHealthCheckIntervalZero = "storage: %s: replicas: option 'health_check_interval' must be above zero but it's configured as '%s'"
+
 	errFmtStorageTLSConfigInvalid                 = "storage: %s: tls: %w"
 	errFmtStoragePostgreSQLInvalidSSLMode         = "storage: postgres: ssl: option 'mode' must be one of %s but it's configured as '%s'"
 	errFmtStoragePostgreSQLInvalidSSLAndTLSConfig = "storage: postgres: can't define both 'tls' and 'ssl' configuration options"
@@ -198,6 +248,8 @@ const (
 	errFmtOIDCPolicyRuleMissingOption    = "identity_providers: oidc: authorization_policies: policy '%s': rules: rule #%d: option '%s' is required"
 	errFmtOIDCPolicyInvalidDefaultPolicy = "identity_providers: oidc: authorization_policies: policy '%s': option 'default_policy' must be one of %s but it's configured as '%s'"
 	errFmtOIDCPolicyRuleInvalidPolicy    = "identity_providers: oidc: authorization_policies: policy '%s': rules: rule #%d: option 'policy' must be one of %s but it's configured as '%s'"
+	errFmtOIDCPolicyRuleInvalidAMR       = "identity_providers: oidc: authorization_policies: policy '%s': rules: rule #%d: option 'amr' must only have the values %s but the values %s are present"
+	errFmtOIDCPolicyRuleInvalidAMRPolicy = "identity_providers: oidc: authorization_policies: policy '%s': rules: rule #%d: option 'amr' is only supported when the 'policy' option is 'two_factor' but it's configured as '%s'"
 
 	errFmtOIDCClientsDuplicateID = "identity_providers: oidc: clients: option 'id' must be unique for every client but one or more clients share the following 'id' values %s"
 	errFmtOIDCClientsWithEmptyID = "identity_providers: oidc: clients: option 'id' is required but was absent on the clients in positions %s"
@@ -302,6 +354,19 @@ const (
 const (
 	errFmtWebAuthnConveyancePreference = "webauthn: option 'attestation_conveyance_preference' must be one of %s but it's configured as '%s'"
 	errFmtWebAuthnUserVerification     = "webauthn: option 'user_verification' must be one of %s but it's configured as '%s'"
+	errFmtWebAuthnPasskeyDisabled      = "webauthn: option 'enable_passkey_login' must not be enabled when the 'disable' option is enabled"
+	errFmtWebAuthnPasskeyTwoFactor     = "webauthn: option 'enable_passkey_two_factor' must not be enabled unless the 'enable_passkey_login' option is also enabled"
+	errFmtWebAuthnPasskeyUserVerify    = "webauthn: option 'enable_passkey_two_factor' is enabled but the 'user_verification' option is configured as '%s' which means passkey logins will rarely if ever satisfy the two_factor policy"
+
+	errFmtWebAuthnMetadataPath             = "webauthn: metadata: option 'path' is required when the 'enabled' option is enabled"
+	errFmtWebAuthnMetadataPathNotExist     = "webauthn: metadata: option 'path' refers to location '%s' which does not exist"
+	errFmtWebAuthnMetadataPathUnknownError = "webauthn: metadata: option 'path' refers to location '%s' which couldn't be opened: %w"
+	errFmtWebAuthnMetadataTrustAnchor      = "webauthn: metadata: option 'validate_trust_anchor' requires the 'attestation_conveyance_preference' option to be one of %s but it's configured as '%s'"
+	errFmtWebAuthnMetadataNotEnabled       = "webauthn: metadata: option '%s' must not be enabled unless the 'enabled' option is also enabled"
+	errFmtWebAuthnMetadataPermitZeroAAGUID = "webauthn: metadata: option 'validate_entry_permit_zero_aaguid' must not be enabled unless the 'validate_entry' option is also enabled"
+	errFmtWebAuthnFilteringInvalidAAGUID   = "webauthn: filtering: option '%s' contains an invalid AAGUID '%s': %w"
+	errFmtWebAuthnFilteringExclusive       = "webauthn: filtering: option 'permitted_aaguids' and 'prohibited_aaguids' are mutually exclusive"
+	errFmtWebAuthnFilteringPermitted       = "webauthn: filtering: option 'permitted_aaguids' requires the metadata 'enabled', 'validate_trust_anchor', and 'validate_entry' options to be enabled as the authenticator aaguid can't be trusted otherwise"
 )
 
 // Access Control error constants.
@@ -330,6 +395,9 @@ const (
 		"invalid: must start with 'user:' or 'group:'"
 	errFmtAccessControlRuleInvalidEntries              = "access_control: rule %s: option '%s' must only have the values %s but the values %s are present"
 	errFmtAccessControlRuleInvalidDuplicates           = "access_control: rule %s: option '%s' must have unique values but the values %s are duplicated"
+	errFmtAccessControlRuleAMRInvalidPolicy            = "access_control: rule %s: option 'amr' is only supported when the 'policy' option is 'two_factor' but it's configured as '%s'"
+	errFmtAccessControlRuleMaxAgeNegative              = "access_control: rule %s: max_authentication_age: option '%s' must be 0 or more but it's configured as '%s'"
+	errFmtAccessControlRuleMaxAgeInvalidPolicy         = "access_control: rule %s: max_authentication_age: option '%s' is only supported when the 'policy' option is %s but it's configured as '%s'"
 	errFmtAccessControlRuleQueryInvalid                = "access_control: rule %s: query: option 'operator' must be one of %s but it's configured as '%s'"
 	errFmtAccessControlRuleQueryInvalidNoValue         = "access_control: rule %s: query: option '%s' is required but it's absent"
 	errFmtAccessControlRuleQueryInvalidNoValueOperator = "access_control: rule %s: query: option '%s' must be present when the option 'operator' is '%s' but it's absent"
@@ -384,7 +452,13 @@ const (
 
 // Regulation Error Consts.
 const (
-	errFmtRegulationFindTimeGreaterThanBanTime = "regulation: option 'find_time' must be less than or equal to option 'ban_time'"
+	errFmtRegulationFindTimeGreaterThanBanTime       = "regulation: option 'find_time' must be less than or equal to option 'ban_time'"
+	errFmtRegulationBackoffMultiplier                = "regulation: backoff: option 'multiplier' must be 1 or more but it's configured as '%d'"
+	errFmtRegulationBackoffMaxBanTimeLessThanBanTime = "regulation: backoff: option 'max_ban_time' must be more than or equal to option 'ban_time'"
+	errFmtRegulationLockoutMaxBans                   = "regulation: lockout: option 'max_bans' must be 0 or more but it's configured as '%d'"
+	errFmtRegulationLockoutWindowLessThanBanTime     = "regulation: lockout: option 'window' must be more than or equal to option 'ban_time'"
+	errFmtRegulationLockoutRequiresMaxRetries        = "regulation: lockout: option 'max_bans' can't be configured when the option 'max_retries' is disabled"
+	errFmtRegulationAdministrationNoGroups           = "regulation: administration: option 'groups' must have at least one group when the administration endpoint is enabled"
 )
 
 // Server Error constants.
@@ -422,7 +496,8 @@ const (
 )
 
 const (
-	errFmtDuoMissingOption = "duo_api: option '%s' is required when duo is enabled but it's absent"
+	errFmtDuoMissingOption                = "duo_api: option '%s' is required when duo is enabled but it's absent"
+	errFmtDuoUniversalPromptInvalidLength = "duo_api: option '%s' must be %d characters when 'universal_prompt' is enabled but it's %d characters"
 )
 
 // Error constants.
@@ -509,9 +584,10 @@ var (
 	validACLHTTPMethodVerbs = append(validRFC7231HTTPMethodVerbs, validRFC4918HTTPMethodVerbs...)
 	validACLRulePolicies    = []string{policyBypass, policyOneFactor, policyTwoFactor, policyDeny}
 	validACLRuleOperators   = []string{operatorPresent, operatorAbsent, operatorEqual, operatorNotEqual, operatorPattern, operatorNotPattern}
+	validACLRuleAMR         = []string{oidc.AMRHardwareSecuredKey, oidc.AMROneTimePassword, oidc.AMRShortMessageService, oidc.AMREmailOneTimeCode}
 )
 
-var validDefault2FAMethods = []string{"totp", "webauthn", "mobile_push"}
+var validDefault2FAMethods = []string{"totp", "webauthn", "mobile_push", "email"}
 
 const (
 	attrOIDCKey                   = "key"
@@ -31,4 +31,16 @@ func ValidateDuo(config *schema.Configuration, validator *schema.StructValidator
 	if config.DuoAPI.SecretKey == "" {
 		validator.Push(fmt.Errorf(errFmtDuoMissingOption, "secret_key"))
 	}
+
+	if !config.DuoAPI.UniversalPrompt {
+		return
+	}
+
+	if n := len(config.DuoAPI.IntegrationKey); n != 0 && n != duoUniversalPromptClientIDLength {
+		validator.Push(fmt.Errorf(errFmtDuoUniversalPromptInvalidLength, "integration_key", duoUniversalPromptClientIDLength, n))
+	}
+
+	if n := len(config.DuoAPI.SecretKey); n != 0 && n != duoUniversalPromptClientSecretLength {
+		validator.Push(fmt.Errorf(errFmtDuoUniversalPromptInvalidLength, "secret_key", duoUniversalPromptClientSecretLength, n))
+	}
 }
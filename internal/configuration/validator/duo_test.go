@@ -40,6 +40,40 @@ func TestValidateDuo(t *testing.T) {
 				SecretKey:      "test",
 			},
 		},
+		{
+			desc: "ShouldNotRaiseErrorsUniversalPrompt",
+			have: &schema.Configuration{DuoAPI: schema.DuoAPI{
+				Hostname:        "test",
+				IntegrationKey:  "DIXXXXXXXXXXXXXXXXXX",
+				SecretKey:       "deadbeefdeadbeefdeadbeefdeadbeefdeadbeef",
+				UniversalPrompt: true,
+			}},
+			expected: schema.DuoAPI{
+				Hostname:        "test",
+				IntegrationKey:  "DIXXXXXXXXXXXXXXXXXX",
+				SecretKey:       "deadbeefdeadbeefdeadbeefdeadbeefdeadbeef",
+				UniversalPrompt: true,
+			},
+		},
+		{
+			desc: "ShouldRaiseErrorsUniversalPromptInvalidLength",
+			have: &schema.Configuration{DuoAPI: schema.DuoAPI{
+				Hostname:        "test",
+				IntegrationKey:  "test",
+				SecretKey:       "test",
+				UniversalPrompt: true,
+			}},
+			expected: schema.DuoAPI{
+				Hostname:        "test",
+				IntegrationKey:  "test",
+				SecretKey:       "test",
+				UniversalPrompt: true,
+			},
+			errs: []string{
+				"duo_api: option 'integration_key' must be 20 characters when 'universal_prompt' is enabled but it's 4 characters",
+				"duo_api: option 'secret_key' must be 40 characters when 'universal_prompt' is enabled but it's 4 characters",
+			},
+		},
 		{
 			desc: "ShouldDetectMissingSecretKey",
 			have: &schema.Configuration{DuoAPI: schema.DuoAPI{
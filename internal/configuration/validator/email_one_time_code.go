@@ -0,0 +1,36 @@
+package validator
+
+import (
+	"fmt"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// ValidateEmailOneTimeCode validates and updates the Email One-Time Code configuration.
+func ValidateEmailOneTimeCode(config *schema.Configuration, validator *schema.StructValidator) {
+	if !config.EmailOneTimeCode.Enable {
+		return
+	}
+
+	switch {
+	case config.EmailOneTimeCode.Characters == 0:
+		config.EmailOneTimeCode.Characters = schema.DefaultEmailOneTimeCodeConfiguration.Characters
+	case config.EmailOneTimeCode.Characters < schema.EmailOneTimeCodeCharactersMinimum, config.EmailOneTimeCode.Characters > schema.EmailOneTimeCodeCharactersMaximum:
+		validator.Push(fmt.Errorf(errFmtEmailOneTimeCodeInvalidCharacters, schema.EmailOneTimeCodeCharactersMinimum, schema.EmailOneTimeCodeCharactersMaximum, config.EmailOneTimeCode.Characters))
+	}
+
+	if config.EmailOneTimeCode.CodeLifespan <= 0 {
+		config.EmailOneTimeCode.CodeLifespan = schema.DefaultEmailOneTimeCodeConfiguration.CodeLifespan
+	}
+
+	switch {
+	case config.EmailOneTimeCode.RateLimit.Amount == 0:
+		config.EmailOneTimeCode.RateLimit.Amount = schema.DefaultEmailOneTimeCodeConfiguration.RateLimit.Amount
+	case config.EmailOneTimeCode.RateLimit.Amount < 0:
+		validator.Push(fmt.Errorf(errFmtEmailOneTimeCodeInvalidRateLimitAmount, config.EmailOneTimeCode.RateLimit.Amount))
+	}
+
+	if config.EmailOneTimeCode.RateLimit.Period <= 0 {
+		config.EmailOneTimeCode.RateLimit.Period = schema.DefaultEmailOneTimeCodeConfiguration.RateLimit.Period
+	}
+}
@@ -0,0 +1,122 @@
+package validator
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestValidateEmailOneTimeCode(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		have     schema.EmailOneTimeCode
+		expected schema.EmailOneTimeCode
+		errs     []string
+	}{
+		{
+			desc:     "ShouldNotSetDefaultValuesWhenNotEnabled",
+			expected: schema.EmailOneTimeCode{},
+		},
+		{
+			desc: "ShouldSetDefaultValues",
+			have: schema.EmailOneTimeCode{Enable: true},
+			expected: schema.EmailOneTimeCode{
+				Enable:       true,
+				Characters:   schema.DefaultEmailOneTimeCodeConfiguration.Characters,
+				CodeLifespan: schema.DefaultEmailOneTimeCodeConfiguration.CodeLifespan,
+				RateLimit:    schema.DefaultEmailOneTimeCodeConfiguration.RateLimit,
+			},
+		},
+		{
+			desc: "ShouldAllowCustomValues",
+			have: schema.EmailOneTimeCode{
+				Enable:       true,
+				Characters:   10,
+				CodeLifespan: time.Minute * 2,
+				RateLimit: schema.EmailOneTimeCodeRateLimit{
+					Amount: 5,
+					Period: time.Hour,
+				},
+			},
+			expected: schema.EmailOneTimeCode{
+				Enable:       true,
+				Characters:   10,
+				CodeLifespan: time.Minute * 2,
+				RateLimit: schema.EmailOneTimeCodeRateLimit{
+					Amount: 5,
+					Period: time.Hour,
+				},
+			},
+		},
+		{
+			desc: "ShouldSetDefaultValuesForNegativeDurations",
+			have: schema.EmailOneTimeCode{
+				Enable:       true,
+				CodeLifespan: -1,
+				RateLimit: schema.EmailOneTimeCodeRateLimit{
+					Period: -1,
+				},
+			},
+			expected: schema.EmailOneTimeCode{
+				Enable:       true,
+				Characters:   schema.DefaultEmailOneTimeCodeConfiguration.Characters,
+				CodeLifespan: schema.DefaultEmailOneTimeCodeConfiguration.CodeLifespan,
+				RateLimit:    schema.DefaultEmailOneTimeCodeConfiguration.RateLimit,
+			},
+		},
+		{
+			desc: "ShouldRaiseErrorWhenCharactersTooLow",
+			have: schema.EmailOneTimeCode{Enable: true, Characters: 4},
+			errs: []string{
+				"email_one_time_code: option 'characters' must be between 6 and 20 but it's configured as '4'",
+			},
+		},
+		{
+			desc: "ShouldRaiseErrorWhenCharactersTooHigh",
+			have: schema.EmailOneTimeCode{Enable: true, Characters: 21},
+			errs: []string{
+				"email_one_time_code: option 'characters' must be between 6 and 20 but it's configured as '21'",
+			},
+		},
+		{
+			desc: "ShouldRaiseErrorWhenRateLimitAmountNegative",
+			have: schema.EmailOneTimeCode{Enable: true, RateLimit: schema.EmailOneTimeCodeRateLimit{Amount: -1}},
+			errs: []string{
+				"email_one_time_code: rate_limit: option 'amount' must be 1 or more but it's configured as '-1'",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			validator := schema.NewStructValidator()
+			config := &schema.Configuration{EmailOneTimeCode: tc.have}
+
+			ValidateEmailOneTimeCode(config, validator)
+
+			errs := validator.Errors()
+
+			assert.Len(t, validator.Warnings(), 0)
+
+			if len(tc.errs) == 0 {
+				assert.Len(t, errs, 0)
+				assert.Equal(t, tc.expected, config.EmailOneTimeCode)
+			} else {
+				expectedErrs := len(tc.errs)
+
+				require.Len(t, errs, expectedErrs)
+
+				for i := 0; i < expectedErrs; i++ {
+					t.Run(fmt.Sprintf("Err%d", i+1), func(t *testing.T) {
+						assert.EqualError(t, errs[i], tc.errs[i])
+					})
+				}
+			}
+		})
+	}
+}
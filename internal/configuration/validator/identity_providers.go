@@ -101,6 +101,16 @@ func validateOIDCAuthorizationPolicies(config *schema.IdentityProvidersOpenIDCon
 			if len(rule.Subjects) == 0 {
 				validator.Push(fmt.Errorf(errFmtOIDCPolicyRuleMissingOption, name, i+1, "subject"))
 			}
+
+			if len(rule.AMR) != 0 {
+				if invalid, _ := validateList(rule.AMR, validACLRuleAMR, false); len(invalid) != 0 {
+					validator.Push(fmt.Errorf(errFmtOIDCPolicyRuleInvalidAMR, name, i+1, utils.StringJoinOr(validACLRuleAMR), utils.StringJoinAnd(invalid)))
+				}
+
+				if policy.Rules[i].Policy != policyTwoFactor {
+					validator.Push(fmt.Errorf(errFmtOIDCPolicyRuleInvalidAMRPolicy, name, i+1, policy.Rules[i].Policy))
+				}
+			}
 		}
 
 		config.AuthorizationPolicies[name] = policy
@@ -3969,6 +3969,52 @@ func TestValidateOIDCAuthorizationPolicies(t *testing.T) {
 				"identity_providers: oidc: authorization_policies: policy 'example': rules: rule #1: option 'policy' must be one of 'one_factor', 'two_factor', and 'deny' but it's configured as 'xyz'",
 			},
 		},
+		{
+			"ShouldErrorBadAMRValues",
+			&schema.IdentityProvidersOpenIDConnect{
+				AuthorizationPolicies: map[string]schema.IdentityProvidersOpenIDConnectPolicy{
+					"example": {
+						DefaultPolicy: "two_factor",
+						Rules: []schema.IdentityProvidersOpenIDConnectPolicyRule{
+							{
+								Policy: "one_factor",
+								Subjects: [][]string{
+									{"user:john"},
+								},
+								AMR: []string{"hwk", "pwd"},
+							},
+						},
+					},
+				},
+			},
+			[]string{"one_factor", "two_factor", "example"},
+			nil,
+			[]string{
+				"identity_providers: oidc: authorization_policies: policy 'example': rules: rule #1: option 'amr' is only supported when the 'policy' option is 'two_factor' but it's configured as 'one_factor'",
+				"identity_providers: oidc: authorization_policies: policy 'example': rules: rule #1: option 'amr' must only have the values 'hwk', 'otp', 'sms', or 'email' but the values 'pwd' are present",
+			},
+		},
+		{
+			"ShouldAllowAMRValues",
+			&schema.IdentityProvidersOpenIDConnect{
+				AuthorizationPolicies: map[string]schema.IdentityProvidersOpenIDConnectPolicy{
+					"example": {
+						DefaultPolicy: "two_factor",
+						Rules: []schema.IdentityProvidersOpenIDConnectPolicyRule{
+							{
+								Subjects: [][]string{
+									{"group:admins"},
+								},
+								AMR: []string{"hwk"},
+							},
+						},
+					},
+				},
+			},
+			[]string{"one_factor", "two_factor", "example"},
+			nil,
+			nil,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -9,16 +9,19 @@ import (
 
 // ValidateNotifier validates and update notifier configuration.
 func ValidateNotifier(config *schema.Notifier, validator *schema.StructValidator) {
-	if config.SMTP == nil && config.FileSystem == nil {
+	switch n := countNotifiers(config); {
+	case n == 0:
 		validator.Push(fmt.Errorf(errFmtNotifierNotConfigured))
 
 		return
-	} else if config.SMTP != nil && config.FileSystem != nil {
+	case n > 1:
 		validator.Push(fmt.Errorf(errFmtNotifierMultipleConfigured))
 
 		return
 	}
 
+	validateNotifierQueue(&config.Queue, validator)
+
 	if config.FileSystem != nil {
 		if config.FileSystem.Filename == "" {
 			validator.Push(fmt.Errorf(errFmtNotifierFileSystemFileNameNotConfigured))
@@ -27,11 +30,78 @@ func ValidateNotifier(config *schema.Notifier, validator *schema.StructValidator
 		return
 	}
 
+	if config.Webhook != nil {
+		validateWebhookNotifier(config.Webhook, validator)
+
+		validateNotifierTemplates(config, validator)
+
+		return
+	}
+
 	validateSMTPNotifier(config.SMTP, validator)
 
 	validateNotifierTemplates(config, validator)
 }
 
+func countNotifiers(config *schema.Notifier) (n int) {
+	if config.SMTP != nil {
+		n++
+	}
+
+	if config.FileSystem != nil {
+		n++
+	}
+
+	if config.Webhook != nil {
+		n++
+	}
+
+	return n
+}
+
+func validateNotifierQueue(config *schema.NotifierQueue, validator *schema.StructValidator) {
+	switch {
+	case config.Workers == 0:
+		config.Workers = schema.DefaultNotifierQueueConfiguration.Workers
+	case config.Workers < 0:
+		validator.Push(fmt.Errorf(errFmtNotifierQueueMustBeAboveZero, "workers", config.Workers))
+	}
+
+	switch {
+	case config.PollInterval == 0:
+		config.PollInterval = schema.DefaultNotifierQueueConfiguration.PollInterval
+	case config.PollInterval < 0:
+		validator.Push(fmt.Errorf(errFmtNotifierQueueMustBeAboveZero, "poll_interval", config.PollInterval))
+	}
+
+	switch {
+	case config.MaxAttempts == 0:
+		config.MaxAttempts = schema.DefaultNotifierQueueConfiguration.MaxAttempts
+	case config.MaxAttempts < 0:
+		validator.Push(fmt.Errorf(errFmtNotifierQueueMustBeAboveZero, "max_attempts", config.MaxAttempts))
+	}
+
+	switch {
+	case config.RetryInterval == 0:
+		config.RetryInterval = schema.DefaultNotifierQueueConfiguration.RetryInterval
+	case config.RetryInterval < 0:
+		validator.Push(fmt.Errorf(errFmtNotifierQueueMustBeAboveZero, "retry_interval", config.RetryInterval))
+	}
+
+	switch {
+	case config.MaxRetryInterval == 0:
+		config.MaxRetryInterval = schema.DefaultNotifierQueueConfiguration.MaxRetryInterval
+
+		if config.MaxRetryInterval < config.RetryInterval {
+			config.MaxRetryInterval = config.RetryInterval
+		}
+	case config.MaxRetryInterval < 0:
+		validator.Push(fmt.Errorf(errFmtNotifierQueueMustBeAboveZero, "max_retry_interval", config.MaxRetryInterval))
+	case config.MaxRetryInterval < config.RetryInterval:
+		validator.Push(fmt.Errorf(errFmtNotifierQueueMaxRetryIntervalTooLow, config.RetryInterval, config.MaxRetryInterval))
+	}
+}
+
 func validateNotifierTemplates(config *schema.Notifier, validator *schema.StructValidator) {
 	if config.TemplatePath == "" {
 		return
@@ -114,3 +184,58 @@ func validateSMTPNotifierAddress(config *schema.NotifierSMTP, validator *schema.
 		}
 	}
 }
+
+func validateWebhookNotifier(config *schema.NotifierWebhook, validator *schema.StructValidator) {
+	switch {
+	case config.Address == nil || config.Address.String() == "":
+		validator.Push(fmt.Errorf(errFmtNotifierWebhookNotConfigured, "address"))
+	case config.Address.Scheme != schemeHTTP && config.Address.Scheme != schemeHTTPS:
+		validator.Push(fmt.Errorf(errFmtNotifierWebhookAddressScheme, config.Address.String(), config.Address.Scheme))
+	}
+
+	if config.Secret == "" {
+		validator.Push(fmt.Errorf(errFmtNotifierWebhookNotConfigured, "secret"))
+	}
+
+	switch {
+	case config.Timeout == 0:
+		config.Timeout = schema.DefaultWebhookNotifierConfiguration.Timeout
+	case config.Timeout < 0:
+		validator.Push(fmt.Errorf(errFmtNotifierWebhookMustBeAboveZero, "timeout", config.Timeout))
+	}
+
+	switch {
+	case config.MaxRetries == 0:
+		config.MaxRetries = schema.DefaultWebhookNotifierConfiguration.MaxRetries
+	case config.MaxRetries < -1:
+		validator.Push(fmt.Errorf(errFmtNotifierWebhookMaxRetriesTooLow, config.MaxRetries))
+	case config.MaxRetries > schema.WebhookNotifierMaximumRetries:
+		validator.Push(fmt.Errorf(errFmtNotifierWebhookMaxRetriesTooHigh, schema.WebhookNotifierMaximumRetries, config.MaxRetries))
+	}
+
+	switch {
+	case config.RetryInterval == 0:
+		config.RetryInterval = schema.DefaultWebhookNotifierConfiguration.RetryInterval
+	case config.RetryInterval < 0:
+		validator.Push(fmt.Errorf(errFmtNotifierWebhookMustBeAboveZero, "retry_interval", config.RetryInterval))
+	case config.RetryInterval > schema.WebhookNotifierMaximumRetryInterval:
+		validator.Push(fmt.Errorf(errFmtNotifierWebhookRetryIntervalTooHigh, schema.WebhookNotifierMaximumRetryInterval, config.RetryInterval))
+	}
+
+	if config.TLS == nil {
+		config.TLS = &schema.TLS{}
+	}
+
+	configDefaultTLS := &schema.TLS{
+		MinimumVersion: schema.DefaultWebhookNotifierConfiguration.TLS.MinimumVersion,
+		MaximumVersion: schema.DefaultWebhookNotifierConfiguration.TLS.MaximumVersion,
+	}
+
+	if config.Address != nil {
+		configDefaultTLS.ServerName = config.Address.Hostname()
+	}
+
+	if err := ValidateTLSConfig(config.TLS, configDefaultTLS); err != nil {
+		validator.Push(fmt.Errorf(errFmtNotifierWebhookTLSConfigInvalid, err))
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"net/mail"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -29,6 +30,8 @@ func (suite *NotifierSuite) SetupTest() {
 		Sender:   mail.Address{Name: "Authelia", Address: "authelia@example.com"},
 	}
 	suite.config.FileSystem = nil
+	suite.config.Webhook = nil
+	suite.config.Queue = schema.NotifierQueue{}
 }
 
 /*
@@ -282,6 +285,172 @@ func (suite *NotifierSuite) TestFileShouldEnsureFilenameIsProvided() {
 	suite.EqualError(suite.validator.Errors()[0], errFmtNotifierFileSystemFileNameNotConfigured)
 }
 
+/*
+Webhook Tests.
+*/
+func (suite *NotifierSuite) TestQueueShouldSetDefaults() {
+	suite.config.Queue.Enable = true
+
+	ValidateNotifier(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Len(suite.validator.Errors(), 0)
+
+	suite.Equal(schema.DefaultNotifierQueueConfiguration.Workers, suite.config.Queue.Workers)
+	suite.Equal(schema.DefaultNotifierQueueConfiguration.PollInterval, suite.config.Queue.PollInterval)
+	suite.Equal(schema.DefaultNotifierQueueConfiguration.MaxAttempts, suite.config.Queue.MaxAttempts)
+	suite.Equal(schema.DefaultNotifierQueueConfiguration.RetryInterval, suite.config.Queue.RetryInterval)
+	suite.Equal(schema.DefaultNotifierQueueConfiguration.MaxRetryInterval, suite.config.Queue.MaxRetryInterval)
+}
+
+func (suite *NotifierSuite) TestQueueShouldRaiseMaxRetryIntervalDefault() {
+	suite.config.Queue.RetryInterval = time.Hour * 2
+
+	ValidateNotifier(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Len(suite.validator.Errors(), 0)
+
+	suite.Equal(time.Hour*2, suite.config.Queue.MaxRetryInterval)
+}
+
+func (suite *NotifierSuite) TestQueueShouldErrorOnInvalidOptions() {
+	suite.config.Queue = schema.NotifierQueue{
+		Workers:          -1,
+		PollInterval:     -1,
+		MaxAttempts:      -1,
+		RetryInterval:    time.Minute,
+		MaxRetryInterval: time.Second,
+	}
+
+	ValidateNotifier(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 4)
+
+	suite.EqualError(suite.validator.Errors()[0], "notifier: queue: option 'workers' must be above zero but it's configured as '-1'")
+	suite.EqualError(suite.validator.Errors()[1], "notifier: queue: option 'poll_interval' must be above zero but it's configured as '-1ns'")
+	suite.EqualError(suite.validator.Errors()[2], "notifier: queue: option 'max_attempts' must be above zero but it's configured as '-1'")
+	suite.EqualError(suite.validator.Errors()[3], "notifier: queue: option 'max_retry_interval' must be greater than or equal to the 'retry_interval' value of '1m0s' but it's configured as '1s'")
+}
+
+func (suite *NotifierSuite) TestWebhookShouldSetDefaults() {
+	suite.config.SMTP = nil
+	suite.config.Webhook = &schema.NotifierWebhook{
+		Address: MustParseURL("https://notify.example.com/api/v1/messages"),
+		Secret:  "abc123",
+	}
+
+	ValidateNotifier(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Len(suite.validator.Errors(), 0)
+
+	suite.Equal(schema.DefaultWebhookNotifierConfiguration.Timeout, suite.config.Webhook.Timeout)
+	suite.Equal(schema.DefaultWebhookNotifierConfiguration.MaxRetries, suite.config.Webhook.MaxRetries)
+	suite.Equal(schema.DefaultWebhookNotifierConfiguration.RetryInterval, suite.config.Webhook.RetryInterval)
+	suite.Require().NotNil(suite.config.Webhook.TLS)
+	suite.Equal("notify.example.com", suite.config.Webhook.TLS.ServerName)
+	suite.Equal(uint16(tls.VersionTLS12), suite.config.Webhook.TLS.MinimumVersion.Value)
+}
+
+func (suite *NotifierSuite) TestWebhookShouldDisableRetries() {
+	suite.config.SMTP = nil
+	suite.config.Webhook = &schema.NotifierWebhook{
+		Address:    MustParseURL("https://notify.example.com/api/v1/messages"),
+		Secret:     "abc123",
+		MaxRetries: -1,
+	}
+
+	ValidateNotifier(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Len(suite.validator.Errors(), 0)
+
+	suite.Equal(-1, suite.config.Webhook.MaxRetries)
+}
+
+func (suite *NotifierSuite) TestWebhookShouldErrorOnNegativeMaxRetries() {
+	suite.config.SMTP = nil
+	suite.config.Webhook = &schema.NotifierWebhook{
+		Address:    MustParseURL("https://notify.example.com/api/v1/messages"),
+		Secret:     "abc123",
+		MaxRetries: -2,
+	}
+
+	ValidateNotifier(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 1)
+
+	suite.EqualError(suite.validator.Errors()[0], "notifier: webhook: option 'max_retries' must be -1 to disable retries or above zero but it's configured as '-2'")
+	suite.Equal(-2, suite.config.Webhook.MaxRetries)
+}
+
+func (suite *NotifierSuite) TestWebhookShouldErrorOnMissingOptions() {
+	suite.config.SMTP = nil
+	suite.config.Webhook = &schema.NotifierWebhook{}
+
+	ValidateNotifier(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 2)
+
+	suite.EqualError(suite.validator.Errors()[0], "notifier: webhook: option 'address' is required")
+	suite.EqualError(suite.validator.Errors()[1], "notifier: webhook: option 'secret' is required")
+}
+
+func (suite *NotifierSuite) TestWebhookShouldErrorOnInvalidOptions() {
+	suite.config.SMTP = nil
+	suite.config.Webhook = &schema.NotifierWebhook{
+		Address:       MustParseURL("ftp://notify.example.com"),
+		Secret:        "abc123",
+		Timeout:       -time.Second,
+		RetryInterval: -time.Second,
+	}
+
+	ValidateNotifier(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 3)
+
+	suite.EqualError(suite.validator.Errors()[0], "notifier: webhook: option 'address' with value 'ftp://notify.example.com' is invalid: scheme must be one of 'http' or 'https' but it's configured as 'ftp'")
+	suite.EqualError(suite.validator.Errors()[1], "notifier: webhook: option 'timeout' must be above zero but it's configured as '-1s'")
+	suite.EqualError(suite.validator.Errors()[2], "notifier: webhook: option 'retry_interval' must be above zero but it's configured as '-1s'")
+}
+
+func (suite *NotifierSuite) TestWebhookShouldErrorOnOptionsTooHigh() {
+	suite.config.SMTP = nil
+	suite.config.Webhook = &schema.NotifierWebhook{
+		Address:       MustParseURL("https://notify.example.com"),
+		Secret:        "abc123",
+		MaxRetries:    11,
+		RetryInterval: time.Minute,
+	}
+
+	ValidateNotifier(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 2)
+
+	suite.EqualError(suite.validator.Errors()[0], "notifier: webhook: option 'max_retries' must be less than or equal to '10' but it's configured as '11'")
+	suite.EqualError(suite.validator.Errors()[1], "notifier: webhook: option 'retry_interval' must be less than or equal to '10s' but it's configured as '1m0s'")
+}
+
+func (suite *NotifierSuite) TestWebhookShouldErrorWhenConfiguredWithOtherNotifiers() {
+	suite.config.Webhook = &schema.NotifierWebhook{
+		Address: MustParseURL("https://notify.example.com"),
+		Secret:  "abc123",
+	}
+
+	ValidateNotifier(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 1)
+
+	suite.EqualError(suite.validator.Errors()[0], errFmtNotifierMultipleConfigured)
+}
+
 func TestNotifierSuite(t *testing.T) {
 	suite.Run(t, new(NotifierSuite))
 }
@@ -0,0 +1,21 @@
+package validator
+
+import (
+	"fmt"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// ValidateRecoveryCodes validates and updates the recovery codes configuration.
+func ValidateRecoveryCodes(config *schema.Configuration, validator *schema.StructValidator) {
+	if config.RecoveryCodes.Disable {
+		return
+	}
+
+	switch {
+	case config.RecoveryCodes.Count == 0:
+		config.RecoveryCodes.Count = schema.DefaultRecoveryCodesConfiguration.Count
+	case config.RecoveryCodes.Count < schema.RecoveryCodesCountMinimum, config.RecoveryCodes.Count > schema.RecoveryCodesCountMaximum:
+		validator.Push(fmt.Errorf(errFmtRecoveryCodesInvalidCount, schema.RecoveryCodesCountMinimum, schema.RecoveryCodesCountMaximum, config.RecoveryCodes.Count))
+	}
+}
@@ -0,0 +1,77 @@
+package validator
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestValidateRecoveryCodes(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		have     schema.RecoveryCodes
+		expected schema.RecoveryCodes
+		errs     []string
+	}{
+		{
+			desc:     "ShouldSetDefaultValues",
+			expected: schema.DefaultRecoveryCodesConfiguration,
+		},
+		{
+			desc:     "ShouldNotSetDefaultValuesWhenDisabled",
+			have:     schema.RecoveryCodes{Disable: true},
+			expected: schema.RecoveryCodes{Disable: true},
+		},
+		{
+			desc:     "ShouldAllowCustomCount",
+			have:     schema.RecoveryCodes{Count: 16},
+			expected: schema.RecoveryCodes{Count: 16},
+		},
+		{
+			desc: "ShouldRaiseErrorWhenCountTooLow",
+			have: schema.RecoveryCodes{Count: 2},
+			errs: []string{
+				"recovery_codes: option 'count' must be between 4 and 32 but it's configured as '2'",
+			},
+		},
+		{
+			desc: "ShouldRaiseErrorWhenCountTooHigh",
+			have: schema.RecoveryCodes{Count: 100},
+			errs: []string{
+				"recovery_codes: option 'count' must be between 4 and 32 but it's configured as '100'",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			validator := schema.NewStructValidator()
+			config := &schema.Configuration{RecoveryCodes: tc.have}
+
+			ValidateRecoveryCodes(config, validator)
+
+			errs := validator.Errors()
+
+			assert.Len(t, validator.Warnings(), 0)
+
+			if len(tc.errs) == 0 {
+				assert.Len(t, errs, 0)
+				assert.Equal(t, tc.expected, config.RecoveryCodes)
+			} else {
+				expectedErrs := len(tc.errs)
+
+				require.Len(t, errs, expectedErrs)
+
+				for i := 0; i < expectedErrs; i++ {
+					t.Run(fmt.Sprintf("Err%d", i+1), func(t *testing.T) {
+						assert.EqualError(t, errs[i], tc.errs[i])
+					})
+				}
+			}
+		})
+	}
+}
@@ -19,4 +19,46 @@ func ValidateRegulation(config *schema.Configuration, validator *schema.StructVa
 	if config.Regulation.FindTime > config.Regulation.BanTime {
 		validator.Push(fmt.Errorf(errFmtRegulationFindTimeGreaterThanBanTime))
 	}
+
+	validateRegulationBackoff(config, validator)
+	validateRegulationLockout(config, validator)
+
+	if config.Regulation.Administration.Enable && len(config.Regulation.Administration.Groups) == 0 {
+		validator.Push(fmt.Errorf(errFmtRegulationAdministrationNoGroups))
+	}
+}
+
+func validateRegulationBackoff(config *schema.Configuration, validator *schema.StructValidator) {
+	switch {
+	case config.Regulation.Backoff.Multiplier == 0:
+		config.Regulation.Backoff.Multiplier = schema.DefaultRegulationConfiguration.Backoff.Multiplier
+	case config.Regulation.Backoff.Multiplier < 0:
+		validator.Push(fmt.Errorf(errFmtRegulationBackoffMultiplier, config.Regulation.Backoff.Multiplier))
+	}
+
+	if config.Regulation.Backoff.MaxBanTime <= 0 {
+		config.Regulation.Backoff.MaxBanTime = max(config.Regulation.BanTime, schema.DefaultRegulationConfiguration.Backoff.MaxBanTime)
+	}
+
+	if config.Regulation.Backoff.MaxBanTime < config.Regulation.BanTime {
+		validator.Push(fmt.Errorf(errFmtRegulationBackoffMaxBanTimeLessThanBanTime))
+	}
+}
+
+func validateRegulationLockout(config *schema.Configuration, validator *schema.StructValidator) {
+	if config.Regulation.Lockout.MaxBans < 0 {
+		validator.Push(fmt.Errorf(errFmtRegulationLockoutMaxBans, config.Regulation.Lockout.MaxBans))
+	}
+
+	if config.Regulation.Lockout.Window <= 0 {
+		config.Regulation.Lockout.Window = schema.DefaultRegulationConfiguration.Lockout.Window
+	}
+
+	if config.Regulation.Lockout.MaxBans > 0 && config.Regulation.Lockout.Window < config.Regulation.BanTime {
+		validator.Push(fmt.Errorf(errFmtRegulationLockoutWindowLessThanBanTime))
+	}
+
+	if config.Regulation.Lockout.MaxBans > 0 && config.Regulation.MaxRetries <= 0 {
+		validator.Push(fmt.Errorf(errFmtRegulationLockoutRequiresMaxRetries))
+	}
 }
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 )
@@ -52,3 +53,87 @@ func TestShouldRaiseErrorWhenFindTimeLessThanBanTime(t *testing.T) {
 	assert.Len(t, validator.Errors(), 1)
 	assert.EqualError(t, validator.Errors()[0], "regulation: option 'find_time' must be less than or equal to option 'ban_time'")
 }
+
+func TestShouldSetDefaultRegulationBackoffAndLockoutWhenUnset(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := newDefaultRegulationConfig()
+
+	ValidateRegulation(&config, validator)
+
+	assert.Len(t, validator.Errors(), 0)
+	assert.Equal(t, schema.DefaultRegulationConfiguration.Backoff.Multiplier, config.Regulation.Backoff.Multiplier)
+	assert.Equal(t, schema.DefaultRegulationConfiguration.Backoff.MaxBanTime, config.Regulation.Backoff.MaxBanTime)
+	assert.Equal(t, schema.DefaultRegulationConfiguration.Lockout.Window, config.Regulation.Lockout.Window)
+	assert.Equal(t, 0, config.Regulation.Lockout.MaxBans)
+}
+
+func TestShouldSetDefaultRegulationBackoffMaxBanTimeToBanTimeWhenLonger(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := newDefaultRegulationConfig()
+
+	config.Regulation.MaxRetries = 3
+	config.Regulation.BanTime = time.Hour * 48
+	config.Regulation.FindTime = time.Minute
+
+	ValidateRegulation(&config, validator)
+
+	assert.Len(t, validator.Errors(), 0)
+	assert.Len(t, validator.Warnings(), 0)
+	assert.Equal(t, time.Hour*48, config.Regulation.Backoff.MaxBanTime)
+	assert.Equal(t, schema.DefaultRegulationConfiguration.Backoff.Multiplier, config.Regulation.Backoff.Multiplier)
+}
+
+func TestShouldRaiseErrorsWhenRegulationBackoffAndLockoutInvalid(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     schema.Regulation
+		expected []string
+	}{
+		{
+			"ShouldRaiseErrorNegativeMultiplier",
+			schema.Regulation{MaxRetries: 3, Backoff: schema.RegulationBackoff{Multiplier: -1}},
+			[]string{"regulation: backoff: option 'multiplier' must be 1 or more but it's configured as '-1'"},
+		},
+		{
+			"ShouldRaiseErrorMaxBanTimeLessThanBanTime",
+			schema.Regulation{MaxRetries: 3, BanTime: time.Hour, FindTime: time.Minute, Backoff: schema.RegulationBackoff{Multiplier: 2, MaxBanTime: time.Minute}},
+			[]string{"regulation: backoff: option 'max_ban_time' must be more than or equal to option 'ban_time'"},
+		},
+		{
+			"ShouldRaiseErrorNegativeMaxBans",
+			schema.Regulation{MaxRetries: 3, Lockout: schema.RegulationLockout{MaxBans: -1}},
+			[]string{"regulation: lockout: option 'max_bans' must be 0 or more but it's configured as '-1'"},
+		},
+		{
+			"ShouldRaiseErrorWindowLessThanBanTime",
+			schema.Regulation{MaxRetries: 3, BanTime: time.Hour, FindTime: time.Minute, Backoff: schema.RegulationBackoff{MaxBanTime: time.Hour * 2}, Lockout: schema.RegulationLockout{MaxBans: 3, Window: time.Minute}},
+			[]string{"regulation: lockout: option 'window' must be more than or equal to option 'ban_time'"},
+		},
+		{
+			"ShouldRaiseErrorLockoutWithoutMaxRetries",
+			schema.Regulation{Lockout: schema.RegulationLockout{MaxBans: 3}},
+			[]string{"regulation: lockout: option 'max_bans' can't be configured when the option 'max_retries' is disabled"},
+		},
+		{
+			"ShouldRaiseErrorAdministrationWithoutGroups",
+			schema.Regulation{MaxRetries: 3, Administration: schema.RegulationAdministration{Enable: true}},
+			[]string{"regulation: administration: option 'groups' must have at least one group when the administration endpoint is enabled"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			validator := schema.NewStructValidator()
+			config := &schema.Configuration{Regulation: tc.have}
+
+			ValidateRegulation(config, validator)
+
+			assert.Len(t, validator.Warnings(), 0)
+			require.Len(t, validator.Errors(), len(tc.expected))
+
+			for i, expected := range tc.expected {
+				assert.EqualError(t, validator.Errors()[i], expected)
+			}
+		})
+	}
+}
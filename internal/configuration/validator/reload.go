@@ -0,0 +1,18 @@
+package validator
+
+import (
+	"errors"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// ValidateReload validates and updates the configuration reload configuration.
+func ValidateReload(config *schema.Configuration, validator *schema.StructValidator) {
+	if config.Reload.PollInterval <= 0 {
+		config.Reload.PollInterval = schema.DefaultReloadConfiguration.PollInterval
+	}
+
+	if config.Reload.Administration.Enable && len(config.Reload.Administration.Groups) == 0 {
+		validator.Push(errors.New(errStrReloadAdministrationNoGroups))
+	}
+}
@@ -0,0 +1,66 @@
+package validator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestValidateReload(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		have     schema.Reload
+		expected time.Duration
+		errs     []string
+	}{
+		{
+			desc: "ShouldNotRaiseErrorsWhenNotConfigured",
+		},
+		{
+			desc: "ShouldNotRaiseErrorsWhenAdministrationNotEnabled",
+			have: schema.Reload{Watch: true},
+		},
+		{
+			desc: "ShouldNotRaiseErrorsWhenAdministrationHasGroups",
+			have: schema.Reload{Administration: schema.ReloadAdministration{Enable: true, Groups: []string{"admins"}}},
+		},
+		{
+			desc:     "ShouldNotOverrideConfiguredPollInterval",
+			have:     schema.Reload{PollInterval: time.Second * 30},
+			expected: time.Second * 30,
+		},
+		{
+			desc: "ShouldRaiseErrorWhenAdministrationHasNoGroups",
+			have: schema.Reload{Administration: schema.ReloadAdministration{Enable: true}},
+			errs: []string{
+				"reload: administration: option 'groups' must have at least one group when the administration endpoint is enabled",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			val := schema.NewStructValidator()
+			config := &schema.Configuration{Reload: tc.have}
+
+			ValidateReload(config, val)
+
+			if tc.expected == 0 {
+				assert.Equal(t, schema.DefaultReloadConfiguration.PollInterval, config.Reload.PollInterval)
+			} else {
+				assert.Equal(t, tc.expected, config.Reload.PollInterval)
+			}
+
+			assert.Len(t, val.Warnings(), 0)
+			require.Len(t, val.Errors(), len(tc.errs))
+
+			for i, err := range tc.errs {
+				assert.EqualError(t, val.Errors()[i], err)
+			}
+		})
+	}
+}
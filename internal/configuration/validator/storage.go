@@ -3,6 +3,7 @@ package validator
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/utils"
@@ -23,11 +24,70 @@ func ValidateStorage(config schema.Storage, validator *schema.StructValidator) {
 		validateLocalStorageConfiguration(config.Local, validator)
 	}
 
-	if config.EncryptionKey == "" {
-		validator.Push(errors.New(errStrStorageEncryptionKeyMustBeProvided))
-	} else if len(config.EncryptionKey) < 20 {
+	switch {
+	case config.EncryptionKey == "":
+		if !config.KeyManagement.Enabled() {
+			validator.Push(errors.New(errStrStorageEncryptionKeyMustBeProvided))
+		}
+	case len(config.EncryptionKey) < 20:
 		validator.Push(errors.New(errStrStorageEncryptionKeyTooShort))
 	}
+
+	validateStorageKeyManagement(&config.KeyManagement, validator)
+}
+
+func validateStorageKeyManagement(config *schema.StorageKeyManagement, validator *schema.StructValidator) {
+	var configured []string
+
+	if config.File != nil {
+		configured = append(configured, "file")
+
+		if config.File.Path == "" {
+			validator.Push(fmt.Errorf(errFmtStorageKeyManagementOptionMustBeProvided, "file", "path"))
+		}
+	}
+
+	if config.Command != nil {
+		configured = append(configured, "command")
+
+		if config.Command.Command == "" {
+			validator.Push(fmt.Errorf(errFmtStorageKeyManagementOptionMustBeProvided, "command", "command"))
+		}
+
+		if config.Command.KeyID == "" {
+			validator.Push(fmt.Errorf(errFmtStorageKeyManagementOptionMustBeProvided, "command", "key_id"))
+		}
+
+		switch {
+		case config.Command.Timeout == 0:
+			config.Command.Timeout = schema.DefaultStorageKeyManagementCommandConfiguration.Timeout
+		case config.Command.Timeout < 0:
+			validator.Push(fmt.Errorf(errFmtStorageKeyManagementCommandTimeout, config.Command.Timeout))
+		}
+	}
+
+	if config.SoftwareHSM != nil {
+		configured = append(configured, "software_hsm")
+
+		if config.SoftwareHSM.Path == "" {
+			validator.Push(fmt.Errorf(errFmtStorageKeyManagementOptionMustBeProvided, "software_hsm", "path"))
+		}
+
+		switch n := len(config.SoftwareHSM.PIN); {
+		case n == 0:
+			validator.Push(fmt.Errorf(errFmtStorageKeyManagementOptionMustBeProvided, "software_hsm", "pin"))
+		case n < 8:
+			validator.Push(errors.New(errStrStorageKeyManagementSoftwareHSMPINTooShort))
+		}
+
+		if config.SoftwareHSM.KeyLabel == "" {
+			config.SoftwareHSM.KeyLabel = schema.DefaultStorageKeyManagementSoftwareHSMConfiguration.KeyLabel
+		}
+	}
+
+	if len(configured) > 1 {
+		validator.Push(fmt.Errorf(errFmtStorageKeyManagementMultipleProviders, utils.StringJoinAnd(configured)))
+	}
 }
 
 func validateSQLConfiguration(config, defaults *schema.StorageSQL, validator *schema.StructValidator, provider string) {
@@ -56,6 +116,43 @@ func validateSQLConfiguration(config, defaults *schema.StorageSQL, validator *sc
 	if config.Timeout == 0 {
 		config.Timeout = schema.DefaultSQLStorageConfiguration.Timeout
 	}
+
+	validateSQLReplicasConfiguration(config, defaults, validator, provider)
+}
+
+func validateSQLReplicasConfiguration(config, defaults *schema.StorageSQL, validator *schema.StructValidator, provider string) {
+	if len(config.Replicas.Addresses) == 0 {
+		return
+	}
+
+	var err error
+
+	for _, address := range config.Replicas.Addresses {
+		if address == nil {
+			continue
+		}
+
+		if err = address.ValidateSQL(); err != nil {
+			validator.Push(fmt.Errorf(errFmtStorageReplicaAddress, provider, address.String(), err))
+
+			continue
+		}
+
+		if address.IsTCP() && address.Port() == 0 {
+			address.SetPort(defaults.Address.Port())
+		}
+
+		if config.Address != nil && address.String() == config.Address.String() {
+			validator.Push(fmt.Errorf(errFmtStorageReplicaAddressSameAsPrimary, provider, address.String()))
+		}
+	}
+
+	switch {
+	case config.Replicas.HealthCheckInterval == 0:
+		config.Replicas.HealthCheckInterval = schema.DefaultSQLStorageConfiguration.Replicas.HealthCheckInterval
+	case config.Replicas.HealthCheckInterval < 0:
+		validator.Push(fmt.Errorf(errFmtStorageReplicaHealthCheckIntervalZero, provider, config.Replicas.HealthCheckInterval))
+	}
 }
 
 func validateMySQLConfiguration(config *schema.StorageMySQL, validator *schema.StructValidator) {
@@ -114,3 +211,95 @@ func validateLocalStorageConfiguration(config *schema.StorageLocal, validator *s
 		validator.Push(fmt.Errorf(errFmtStorageOptionMustBeProvided, "local", "path"))
 	}
 }
+
+// ValidateStorageRetention validates and updates the storage retention configuration.
+func ValidateStorageRetention(config *schema.Configuration, validator *schema.StructValidator) {
+	retention := &config.Storage.Retention
+
+	switch {
+	case retention.Interval == 0:
+		retention.Interval = schema.DefaultStorageRetentionConfiguration.Interval
+	case retention.Interval < 0:
+		validator.Push(fmt.Errorf(errFmtStorageRetentionMustBeAboveZero, "interval", retention.Interval))
+	}
+
+	switch {
+	case retention.BatchSize == 0:
+		retention.BatchSize = schema.DefaultStorageRetentionConfiguration.BatchSize
+	case retention.BatchSize < 0:
+		validator.Push(fmt.Errorf(errFmtStorageRetentionMustBeAboveZero, "batch_size", retention.BatchSize))
+	}
+
+	if retention.AuthenticationLogs == 0 {
+		retention.AuthenticationLogs = schema.DefaultStorageRetentionConfiguration.AuthenticationLogs
+	}
+
+	if retention.TOTPHistory == 0 {
+		retention.TOTPHistory = schema.DefaultStorageRetentionConfiguration.TOTPHistory
+	}
+
+	if retention.IdentityVerification == 0 {
+		retention.IdentityVerification = schema.DefaultStorageRetentionConfiguration.IdentityVerification
+	}
+
+	if retention.OneTimeCode == 0 {
+		retention.OneTimeCode = schema.DefaultStorageRetentionConfiguration.OneTimeCode
+	}
+
+	if retention.OAuth2Sessions == 0 {
+		retention.OAuth2Sessions = schema.DefaultStorageRetentionConfiguration.OAuth2Sessions
+	}
+
+	if retention.OAuth2BlacklistedJTI == 0 {
+		retention.OAuth2BlacklistedJTI = schema.DefaultStorageRetentionConfiguration.OAuth2BlacklistedJTI
+	}
+
+	if retention.AuthenticationLogs > 0 {
+		if option, lookback := getRegulationLookback(config.Regulation); retention.AuthenticationLogs < lookback {
+			validator.Push(fmt.Errorf(errFmtStorageRetentionTooShort, "authentication_logs", "regulation", option, lookback, retention.AuthenticationLogs))
+		}
+	}
+
+	if retention.OAuth2Sessions > 0 && config.IdentityProviders.OIDC != nil {
+		if lifespan := getMaximumRefreshTokenLifespan(config.IdentityProviders.OIDC.Lifespans); retention.OAuth2Sessions < lifespan {
+			validator.Push(fmt.Errorf(errFmtStorageRetentionTooShort, "oauth2_sessions", "identity_providers: oidc: lifespans", "refresh_token", lifespan, retention.OAuth2Sessions))
+		}
+	}
+}
+
+// getRegulationLookback returns the longest period of authentication history which is considered by the regulation
+// along with the name of the option which configures it.
+func getRegulationLookback(config schema.Regulation) (option string, lookback time.Duration) {
+	option, lookback = "find_time", config.FindTime
+
+	if config.BanTime > lookback {
+		option, lookback = "ban_time", config.BanTime
+	}
+
+	if (config.Lockout.MaxBans > 0 || config.Backoff.Multiplier > 1) && config.Lockout.Window > lookback {
+		option, lookback = "lockout: window", config.Lockout.Window
+	}
+
+	return option, lookback
+}
+
+func getMaximumRefreshTokenLifespan(config schema.IdentityProvidersOpenIDConnectLifespans) (lifespan time.Duration) {
+	lifespan = config.RefreshToken
+
+	for _, custom := range config.Custom {
+		for _, value := range []time.Duration{
+			custom.RefreshToken,
+			custom.Grants.AuthorizeCode.RefreshToken,
+			custom.Grants.Implicit.RefreshToken,
+			custom.Grants.ClientCredentials.RefreshToken,
+			custom.Grants.RefreshToken.RefreshToken,
+			custom.Grants.JWTBearer.RefreshToken,
+		} {
+			if value > lifespan {
+				lifespan = value
+			}
+		}
+	}
+
+	return lifespan
+}
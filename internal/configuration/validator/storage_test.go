@@ -3,7 +3,10 @@ package validator
 import (
 	"crypto/tls"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
@@ -21,6 +24,7 @@ func (suite *StorageSuite) SetupTest() {
 	suite.config.Local = nil
 	suite.config.PostgreSQL = nil
 	suite.config.MySQL = nil
+	suite.config.KeyManagement = schema.StorageKeyManagement{}
 }
 
 func (suite *StorageSuite) TestShouldValidateOneStorageIsConfigured() {
@@ -416,6 +420,286 @@ func (suite *StorageSuite) TestShouldRaiseErrorOnShortEncryptionKey() {
 	suite.Assert().EqualError(suite.val.Errors()[0], "storage: option 'encryption_key' must be 20 characters or longer")
 }
 
+func (suite *StorageSuite) TestShouldNotRaiseErrorOnNoEncryptionKeyWithKeyManagement() {
+	suite.config.EncryptionKey = ""
+	suite.config.Local = &schema.StorageLocal{
+		Path: "/this/is/a/path",
+	}
+	suite.config.KeyManagement.File = &schema.StorageKeyManagementFile{
+		Path: "/config/kek.key",
+	}
+
+	ValidateStorage(suite.config, suite.val)
+
+	suite.Require().Len(suite.val.Warnings(), 0)
+	suite.Require().Len(suite.val.Errors(), 0)
+}
+
+func (suite *StorageSuite) TestShouldSetKeyManagementDefaults() {
+	suite.config.Local = &schema.StorageLocal{
+		Path: "/this/is/a/path",
+	}
+	suite.config.KeyManagement.Command = &schema.StorageKeyManagementCommand{
+		Command: "/usr/local/bin/kek",
+		KeyID:   "authelia",
+	}
+
+	ValidateStorage(suite.config, suite.val)
+
+	suite.Require().Len(suite.val.Warnings(), 0)
+	suite.Require().Len(suite.val.Errors(), 0)
+	suite.Assert().Equal(schema.DefaultStorageKeyManagementCommandConfiguration.Timeout, suite.config.KeyManagement.Command.Timeout)
+
+	suite.config.KeyManagement.Command = nil
+	suite.config.KeyManagement.SoftwareHSM = &schema.StorageKeyManagementSoftwareHSM{
+		Path: "/config/keystore.json",
+		PIN:  "a_very_long_pin",
+	}
+
+	ValidateStorage(suite.config, suite.val)
+
+	suite.Require().Len(suite.val.Warnings(), 0)
+	suite.Require().Len(suite.val.Errors(), 0)
+	suite.Assert().Equal("authelia", suite.config.KeyManagement.SoftwareHSM.KeyLabel)
+}
+
+func (suite *StorageSuite) TestShouldRaiseErrorsOnInvalidKeyManagement() {
+	suite.config.Local = &schema.StorageLocal{
+		Path: "/this/is/a/path",
+	}
+	suite.config.KeyManagement = schema.StorageKeyManagement{
+		File:        &schema.StorageKeyManagementFile{},
+		Command:     &schema.StorageKeyManagementCommand{Timeout: -1},
+		SoftwareHSM: &schema.StorageKeyManagementSoftwareHSM{PIN: "abc"},
+	}
+
+	ValidateStorage(suite.config, suite.val)
+
+	suite.Require().Len(suite.val.Warnings(), 0)
+	suite.Require().Len(suite.val.Errors(), 7)
+	suite.Assert().EqualError(suite.val.Errors()[0], "storage: key_management: file: option 'path' is required")
+	suite.Assert().EqualError(suite.val.Errors()[1], "storage: key_management: command: option 'command' is required")
+	suite.Assert().EqualError(suite.val.Errors()[2], "storage: key_management: command: option 'key_id' is required")
+	suite.Assert().EqualError(suite.val.Errors()[3], "storage: key_management: command: option 'timeout' must be above zero but it's configured as '-1ns'")
+	suite.Assert().EqualError(suite.val.Errors()[4], "storage: key_management: software_hsm: option 'path' is required")
+	suite.Assert().EqualError(suite.val.Errors()[5], "storage: key_management: software_hsm: option 'pin' must be 8 characters or longer")
+	suite.Assert().EqualError(suite.val.Errors()[6], "storage: key_management: only one provider may be configured but 'file', 'command', and 'software_hsm' are configured")
+}
+
+func (suite *StorageSuite) TestShouldSetReplicaDefaults() {
+	suite.config.MySQL = &schema.StorageMySQL{
+		StorageSQL: schema.StorageSQL{
+			Address: &schema.AddressTCP{
+				Address: MustParseAddress("tcp://mysql:3306"),
+			},
+			Username: "myuser",
+			Password: "pass",
+			Database: "database",
+			Replicas: schema.StorageSQLReplicas{
+				Addresses: []*schema.AddressTCP{
+					{Address: MustParseAddress("tcp://replica1")},
+					{Address: MustParseAddress("tcp://replica2:3307")},
+				},
+			},
+		},
+	}
+
+	ValidateStorage(suite.config, suite.val)
+
+	suite.Assert().Len(suite.val.Warnings(), 0)
+	suite.Assert().Len(suite.val.Errors(), 0)
+
+	suite.Assert().Equal("tcp://replica1:3306", suite.config.MySQL.Replicas.Addresses[0].String())
+	suite.Assert().Equal("tcp://replica2:3307", suite.config.MySQL.Replicas.Addresses[1].String())
+	suite.Assert().Equal(time.Second*10, suite.config.MySQL.Replicas.HealthCheckInterval)
+}
+
+func (suite *StorageSuite) TestShouldRaiseErrorsOnInvalidReplicas() {
+	suite.config.PostgreSQL = &schema.StoragePostgreSQL{
+		StorageSQL: schema.StorageSQL{
+			Address: &schema.AddressTCP{
+				Address: MustParseAddress("tcp://postgres:5432"),
+			},
+			Username: "myuser",
+			Password: "pass",
+			Database: "database",
+			Replicas: schema.StorageSQLReplicas{
+				Addresses: []*schema.AddressTCP{
+					{Address: MustParseAddress("udp://replica1:5432")},
+					{Address: MustParseAddress("tcp://postgres")},
+				},
+				HealthCheckInterval: -1,
+			},
+		},
+	}
+
+	ValidateStorage(suite.config, suite.val)
+
+	suite.Require().Len(suite.val.Warnings(), 0)
+	suite.Require().Len(suite.val.Errors(), 3)
+	suite.Assert().EqualError(suite.val.Errors()[0], "storage: postgres: replicas: option 'addresses' with value 'udp://replica1:5432' is invalid: scheme must be one of 'tcp', 'tcp4', 'tcp6', or 'unix' but is configured as 'udp'")
+	suite.Assert().EqualError(suite.val.Errors()[1], "storage: postgres: replicas: option 'addresses' with value 'tcp://postgres:5432' is invalid: the address is the same as the primary 'address'")
+	suite.Assert().EqualError(suite.val.Errors()[2], "storage: postgres: replicas: option 'health_check_interval' must be above zero but it's configured as '-1ns'")
+}
+
 func TestShouldRunStorageSuite(t *testing.T) {
 	suite.Run(t, new(StorageSuite))
 }
+
+func TestValidateStorageRetention(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     schema.Configuration
+		expected schema.StorageRetention
+		errs     []string
+	}{
+		{
+			"ShouldSetDefaults",
+			schema.Configuration{},
+			schema.DefaultStorageRetentionConfiguration,
+			nil,
+		},
+		{
+			"ShouldAllowNegativeRetention",
+			schema.Configuration{
+				Storage: schema.Storage{
+					Retention: schema.StorageRetention{
+						Disable:            true,
+						Interval:           time.Minute,
+						BatchSize:          10,
+						AuthenticationLogs: -1,
+						OAuth2Sessions:     -1,
+					},
+				},
+				Regulation: schema.Regulation{FindTime: time.Hour},
+			},
+			schema.StorageRetention{
+				Disable:              true,
+				Interval:             time.Minute,
+				BatchSize:            10,
+				AuthenticationLogs:   -1,
+				TOTPHistory:          schema.DefaultStorageRetentionConfiguration.TOTPHistory,
+				IdentityVerification: schema.DefaultStorageRetentionConfiguration.IdentityVerification,
+				OneTimeCode:          schema.DefaultStorageRetentionConfiguration.OneTimeCode,
+				OAuth2Sessions:       -1,
+				OAuth2BlacklistedJTI: schema.DefaultStorageRetentionConfiguration.OAuth2BlacklistedJTI,
+			},
+			nil,
+		},
+		{
+			"ShouldRaiseErrorsNegativeIntervalAndBatchSize",
+			schema.Configuration{
+				Storage: schema.Storage{
+					Retention: schema.StorageRetention{
+						Interval:  -1,
+						BatchSize: -1,
+					},
+				},
+			},
+			schema.StorageRetention{
+				Interval:             -1,
+				BatchSize:            -1,
+				AuthenticationLogs:   schema.DefaultStorageRetentionConfiguration.AuthenticationLogs,
+				TOTPHistory:          schema.DefaultStorageRetentionConfiguration.TOTPHistory,
+				IdentityVerification: schema.DefaultStorageRetentionConfiguration.IdentityVerification,
+				OneTimeCode:          schema.DefaultStorageRetentionConfiguration.OneTimeCode,
+				OAuth2Sessions:       schema.DefaultStorageRetentionConfiguration.OAuth2Sessions,
+				OAuth2BlacklistedJTI: schema.DefaultStorageRetentionConfiguration.OAuth2BlacklistedJTI,
+			},
+			[]string{
+				"storage: retention: option 'interval' must be above zero but it's configured as '-1ns'",
+				"storage: retention: option 'batch_size' must be above zero but it's configured as '-1'",
+			},
+		},
+		{
+			"ShouldRaiseErrorAuthenticationLogsShorterThanRegulation",
+			schema.Configuration{
+				Storage: schema.Storage{
+					Retention: schema.StorageRetention{
+						AuthenticationLogs: time.Hour,
+					},
+				},
+				Regulation: schema.Regulation{
+					FindTime: time.Minute,
+					BanTime:  time.Minute * 5,
+					Lockout: schema.RegulationLockout{
+						MaxBans: 3,
+						Window:  time.Hour * 24,
+					},
+				},
+			},
+			schema.StorageRetention{
+				Interval:             schema.DefaultStorageRetentionConfiguration.Interval,
+				BatchSize:            schema.DefaultStorageRetentionConfiguration.BatchSize,
+				AuthenticationLogs:   time.Hour,
+				TOTPHistory:          schema.DefaultStorageRetentionConfiguration.TOTPHistory,
+				IdentityVerification: schema.DefaultStorageRetentionConfiguration.IdentityVerification,
+				OneTimeCode:          schema.DefaultStorageRetentionConfiguration.OneTimeCode,
+				OAuth2Sessions:       schema.DefaultStorageRetentionConfiguration.OAuth2Sessions,
+				OAuth2BlacklistedJTI: schema.DefaultStorageRetentionConfiguration.OAuth2BlacklistedJTI,
+			},
+			[]string{
+				"storage: retention: option 'authentication_logs' must be greater than or equal to the regulation option 'lockout: window' value of '24h0m0s' but it's configured as '1h0m0s'",
+			},
+		},
+		{
+			"ShouldRaiseErrorOAuth2SessionsShorterThanRefreshToken",
+			schema.Configuration{
+				Storage: schema.Storage{
+					Retention: schema.StorageRetention{
+						OAuth2Sessions: time.Hour * 24,
+					},
+				},
+				IdentityProviders: schema.IdentityProviders{
+					OIDC: &schema.IdentityProvidersOpenIDConnect{
+						Lifespans: schema.IdentityProvidersOpenIDConnectLifespans{
+							IdentityProvidersOpenIDConnectLifespanToken: schema.IdentityProvidersOpenIDConnectLifespanToken{
+								RefreshToken: time.Hour,
+							},
+							Custom: map[string]schema.IdentityProvidersOpenIDConnectLifespan{
+								"long": {
+									Grants: schema.IdentityProvidersOpenIDConnectLifespanGrants{
+										RefreshToken: schema.IdentityProvidersOpenIDConnectLifespanToken{
+											RefreshToken: time.Hour * 48,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			schema.StorageRetention{
+				Interval:             schema.DefaultStorageRetentionConfiguration.Interval,
+				BatchSize:            schema.DefaultStorageRetentionConfiguration.BatchSize,
+				AuthenticationLogs:   schema.DefaultStorageRetentionConfiguration.AuthenticationLogs,
+				TOTPHistory:          schema.DefaultStorageRetentionConfiguration.TOTPHistory,
+				IdentityVerification: schema.DefaultStorageRetentionConfiguration.IdentityVerification,
+				OneTimeCode:          schema.DefaultStorageRetentionConfiguration.OneTimeCode,
+				OAuth2Sessions:       time.Hour * 24,
+				OAuth2BlacklistedJTI: schema.DefaultStorageRetentionConfiguration.OAuth2BlacklistedJTI,
+			},
+			[]string{
+				"storage: retention: option 'oauth2_sessions' must be greater than or equal to the identity_providers: oidc: lifespans option 'refresh_token' value of '48h0m0s' but it's configured as '24h0m0s'",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			val := schema.NewStructValidator()
+
+			ValidateStorageRetention(&tc.have, val)
+
+			assert.Equal(t, tc.expected, tc.have.Storage.Retention)
+
+			errs := val.Errors()
+
+			require.Len(t, errs, len(tc.errs))
+
+			for i, err := range errs {
+				assert.EqualError(t, err, tc.errs[i])
+			}
+		})
+	}
+}
@@ -26,6 +26,12 @@ func ValidateTOTP(config *schema.Configuration, validator *schema.StructValidato
 		config.TOTP.Skew = schema.DefaultTOTPConfiguration.Skew
 	}
 
+	if config.TOTP.HOTPLookAhead == nil {
+		config.TOTP.HOTPLookAhead = schema.DefaultTOTPConfiguration.HOTPLookAhead
+	} else if *config.TOTP.HOTPLookAhead < 0 {
+		validator.Push(fmt.Errorf(errFmtTOTPInvalidHOTPLookAhead, *config.TOTP.HOTPLookAhead))
+	}
+
 	if config.TOTP.SecretSize == 0 {
 		config.TOTP.SecretSize = schema.DefaultTOTPConfiguration.SecretSize
 	} else if config.TOTP.SecretSize < schema.TOTPSecretSizeMinimum {
@@ -11,6 +11,8 @@ import (
 )
 
 func TestValidateTOTP(t *testing.T) {
+	negative := -1
+
 	testCases := []struct {
 		desc     string
 		have     schema.TOTP
@@ -105,6 +107,17 @@ func TestValidateTOTP(t *testing.T) {
 				"totp: option 'secret_size' must be 20 or higher but it's configured as '10'",
 			},
 		},
+		{
+			desc: "ShouldRaiseErrorWhenInvalidHOTPLookAhead",
+			have: schema.TOTP{
+				Skew:          schema.DefaultTOTPConfiguration.Skew,
+				HOTPLookAhead: &negative,
+				Issuer:        "abc",
+			},
+			errs: []string{
+				"totp: option 'hotp_look_ahead' must be 0 or higher but it's configured as '-1'",
+			},
+		},
 		{
 			desc: "ShouldRaiseErrorWhenInvalidTOTPAllowedValues",
 			have: schema.TOTP{
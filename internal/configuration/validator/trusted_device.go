@@ -0,0 +1,24 @@
+package validator
+
+import (
+	"errors"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// ValidateTrustedDevice validates and updates the Trusted Device configuration.
+func ValidateTrustedDevice(config *schema.Configuration, validator *schema.StructValidator) {
+	if !config.TrustedDevice.Enable {
+		return
+	}
+
+	if config.TrustedDevice.Secret == "" {
+		validator.Push(errors.New(errStrTrustedDeviceSecret))
+	} else if len(config.TrustedDevice.Secret) < 20 {
+		validator.Push(errors.New(errStrTrustedDeviceSecretTooShort))
+	}
+
+	if config.TrustedDevice.Lifespan <= 0 {
+		config.TrustedDevice.Lifespan = schema.DefaultTrustedDeviceConfiguration.Lifespan
+	}
+}
@@ -0,0 +1,93 @@
+package validator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestValidateTrustedDevice(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		have     schema.TrustedDevice
+		expected schema.TrustedDevice
+		errs     []string
+	}{
+		{
+			desc:     "ShouldNotSetDefaultValuesWhenNotEnabled",
+			expected: schema.TrustedDevice{},
+		},
+		{
+			desc: "ShouldSetDefaultValues",
+			have: schema.TrustedDevice{Enable: true, Secret: "abcdefghijklmnopqrstuvwxyz"},
+			expected: schema.TrustedDevice{
+				Enable:   true,
+				Secret:   "abcdefghijklmnopqrstuvwxyz",
+				Lifespan: schema.DefaultTrustedDeviceConfiguration.Lifespan,
+			},
+		},
+		{
+			desc: "ShouldAllowCustomValues",
+			have: schema.TrustedDevice{Enable: true, Secret: "abcdefghijklmnopqrstuvwxyz", Lifespan: time.Hour * 24 * 7},
+			expected: schema.TrustedDevice{
+				Enable:   true,
+				Secret:   "abcdefghijklmnopqrstuvwxyz",
+				Lifespan: time.Hour * 24 * 7,
+			},
+		},
+		{
+			desc: "ShouldSetDefaultValuesForNegativeLifespan",
+			have: schema.TrustedDevice{Enable: true, Secret: "abcdefghijklmnopqrstuvwxyz", Lifespan: -1},
+			expected: schema.TrustedDevice{
+				Enable:   true,
+				Secret:   "abcdefghijklmnopqrstuvwxyz",
+				Lifespan: schema.DefaultTrustedDeviceConfiguration.Lifespan,
+			},
+		},
+		{
+			desc: "ShouldRaiseErrorWhenNoSecret",
+			have: schema.TrustedDevice{Enable: true},
+			expected: schema.TrustedDevice{
+				Enable:   true,
+				Lifespan: schema.DefaultTrustedDeviceConfiguration.Lifespan,
+			},
+			errs: []string{
+				"trusted_device: option 'secret' is required when the trusted device functionality is enabled",
+			},
+		},
+		{
+			desc: "ShouldRaiseErrorWhenSecretTooShort",
+			have: schema.TrustedDevice{Enable: true, Secret: "abc"},
+			expected: schema.TrustedDevice{
+				Enable:   true,
+				Secret:   "abc",
+				Lifespan: schema.DefaultTrustedDeviceConfiguration.Lifespan,
+			},
+			errs: []string{
+				"trusted_device: option 'secret' must be 20 characters or longer",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.desc, func(t *testing.T) {
+			val := schema.NewStructValidator()
+			config := &schema.Configuration{TrustedDevice: tc.have}
+
+			ValidateTrustedDevice(config, val)
+
+			assert.Len(t, val.Warnings(), 0)
+			require.Len(t, val.Errors(), len(tc.errs))
+
+			for i, err := range tc.errs {
+				assert.EqualError(t, val.Errors()[i], err)
+			}
+
+			assert.Equal(t, tc.expected, config.TrustedDevice)
+		})
+	}
+}
@@ -2,6 +2,10 @@ package validator
 
 import (
 	"fmt"
+	"os"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/google/uuid"
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/utils"
@@ -30,4 +34,89 @@ func ValidateWebAuthn(config *schema.Configuration, validator *schema.StructVali
 	case !utils.IsStringInSlice(string(config.WebAuthn.UserVerification), validWebAuthnUserVerificationRequirement):
 		validator.Push(fmt.Errorf(errFmtWebAuthnUserVerification, utils.StringJoinOr(validWebAuthnConveyancePreferences), config.WebAuthn.UserVerification))
 	}
+
+	switch {
+	case config.WebAuthn.EnablePasskeyLogin && config.WebAuthn.Disable:
+		validator.Push(fmt.Errorf(errFmtWebAuthnPasskeyDisabled))
+	case config.WebAuthn.EnablePasskeyTwoFactor && !config.WebAuthn.EnablePasskeyLogin:
+		validator.Push(fmt.Errorf(errFmtWebAuthnPasskeyTwoFactor))
+	case config.WebAuthn.EnablePasskeyTwoFactor && config.WebAuthn.UserVerification == protocol.VerificationDiscouraged:
+		validator.PushWarning(fmt.Errorf(errFmtWebAuthnPasskeyUserVerify, config.WebAuthn.UserVerification))
+	}
+
+	validateWebAuthnMetadata(config, validator)
+	validateWebAuthnFiltering(config, validator)
+}
+
+func validateWebAuthnMetadata(config *schema.Configuration, validator *schema.StructValidator) {
+	metadata := &config.WebAuthn.Metadata
+
+	if !metadata.Enabled {
+		options := []struct {
+			name    string
+			enabled bool
+		}{
+			{"validate_trust_anchor", metadata.ValidateTrustAnchor},
+			{"validate_entry", metadata.ValidateEntry},
+			{"validate_entry_permit_zero_aaguid", metadata.ValidateEntryPermitZeroAAGUID},
+			{"validate_status", metadata.ValidateStatus},
+		}
+
+		for _, option := range options {
+			if option.enabled {
+				validator.Push(fmt.Errorf(errFmtWebAuthnMetadataNotEnabled, option.name))
+			}
+		}
+
+		return
+	}
+
+	switch _, err := os.Stat(metadata.Path); {
+	case metadata.Path == "":
+		validator.Push(fmt.Errorf(errFmtWebAuthnMetadataPath))
+	case os.IsNotExist(err):
+		validator.Push(fmt.Errorf(errFmtWebAuthnMetadataPathNotExist, metadata.Path))
+	case err != nil:
+		validator.Push(fmt.Errorf(errFmtWebAuthnMetadataPathUnknownError, metadata.Path, err))
+	}
+
+	if metadata.ValidateTrustAnchor && config.WebAuthn.ConveyancePreference == protocol.PreferNoAttestation {
+		validator.Push(fmt.Errorf(errFmtWebAuthnMetadataTrustAnchor, utils.StringJoinOr([]string{string(protocol.PreferIndirectAttestation), string(protocol.PreferDirectAttestation)}), config.WebAuthn.ConveyancePreference))
+	}
+
+	if metadata.ValidateEntryPermitZeroAAGUID && !metadata.ValidateEntry {
+		validator.Push(fmt.Errorf(errFmtWebAuthnMetadataPermitZeroAAGUID))
+	}
+}
+
+func validateWebAuthnFiltering(config *schema.Configuration, validator *schema.StructValidator) {
+	filtering := &config.WebAuthn.Filtering
+
+	if len(filtering.PermittedAAGUIDs) != 0 && len(filtering.ProhibitedAAGUIDs) != 0 {
+		validator.Push(fmt.Errorf(errFmtWebAuthnFilteringExclusive))
+	}
+
+	// The AAGUID is asserted by the authenticator itself so it can only be relied upon to permit an authenticator when
+	// the attestation is validated against the trust anchors of the authenticator metadata entry. The attestation
+	// conveyance preference required by the trust anchor validation is checked by validateWebAuthnMetadata.
+	if len(filtering.PermittedAAGUIDs) != 0 && (!config.WebAuthn.Metadata.Enabled || !config.WebAuthn.Metadata.ValidateTrustAnchor || !config.WebAuthn.Metadata.ValidateEntry) {
+		validator.Push(fmt.Errorf(errFmtWebAuthnFilteringPermitted))
+	}
+
+	validateWebAuthnFilteringAAGUIDs("permitted_aaguids", filtering.PermittedAAGUIDs, validator)
+	validateWebAuthnFilteringAAGUIDs("prohibited_aaguids", filtering.ProhibitedAAGUIDs, validator)
+}
+
+func validateWebAuthnFilteringAAGUIDs(name string, aaguids []string, validator *schema.StructValidator) {
+	for i, value := range aaguids {
+		aaguid, err := uuid.Parse(value)
+		if err != nil {
+			validator.Push(fmt.Errorf(errFmtWebAuthnFilteringInvalidAAGUID, name, value, err))
+
+			continue
+		}
+
+		// Normalize the AAGUID so it can be compared with the string representation of the authenticator AAGUID.
+		aaguids[i] = aaguid.String()
+	}
 }
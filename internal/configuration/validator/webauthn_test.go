@@ -1,6 +1,8 @@
 package validator
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -96,3 +98,169 @@ func TestWebAuthnShouldRaiseErrorsOnInvalidOptions(t *testing.T) {
 	assert.EqualError(t, validator.Errors()[0], "webauthn: option 'attestation_conveyance_preference' must be one of 'none', 'indirect', or 'direct' but it's configured as 'no'")
 	assert.EqualError(t, validator.Errors()[1], "webauthn: option 'user_verification' must be one of 'none', 'indirect', or 'direct' but it's configured as 'yes'")
 }
+
+func TestWebAuthnPasskeyOptions(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     schema.WebAuthn
+		warnings []string
+		errors   []string
+	}{
+		{
+			"ShouldAllowPasskeyLogin",
+			schema.WebAuthn{EnablePasskeyLogin: true, EnablePasskeyTwoFactor: true},
+			nil,
+			nil,
+		},
+		{
+			"ShouldErrorPasskeyLoginWhenDisabled",
+			schema.WebAuthn{Disable: true, EnablePasskeyLogin: true},
+			nil,
+			[]string{"webauthn: option 'enable_passkey_login' must not be enabled when the 'disable' option is enabled"},
+		},
+		{
+			"ShouldErrorPasskeyTwoFactorWithoutPasskeyLogin",
+			schema.WebAuthn{EnablePasskeyTwoFactor: true},
+			nil,
+			[]string{"webauthn: option 'enable_passkey_two_factor' must not be enabled unless the 'enable_passkey_login' option is also enabled"},
+		},
+		{
+			"ShouldWarnPasskeyTwoFactorWithUserVerificationDiscouraged",
+			schema.WebAuthn{EnablePasskeyLogin: true, EnablePasskeyTwoFactor: true, UserVerification: protocol.VerificationDiscouraged},
+			[]string{"webauthn: option 'enable_passkey_two_factor' is enabled but the 'user_verification' option is configured as 'discouraged' which means passkey logins will rarely if ever satisfy the two_factor policy"},
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			validator := schema.NewStructValidator()
+			config := &schema.Configuration{WebAuthn: tc.have}
+
+			ValidateWebAuthn(config, validator)
+
+			require.Len(t, validator.Warnings(), len(tc.warnings))
+			require.Len(t, validator.Errors(), len(tc.errors))
+
+			for i, warning := range tc.warnings {
+				assert.EqualError(t, validator.Warnings()[i], warning)
+			}
+
+			for i, err := range tc.errors {
+				assert.EqualError(t, validator.Errors()[i], err)
+			}
+		})
+	}
+}
+
+func TestWebAuthnMetadataAndFilteringOptions(t *testing.T) {
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "mds.jwt")
+
+	require.NoError(t, os.WriteFile(path, []byte("blob"), 0600))
+
+	testCases := []struct {
+		name     string
+		have     schema.WebAuthn
+		expected schema.WebAuthnFiltering
+		errors   []string
+	}{
+		{
+			"ShouldAllowMetadata",
+			schema.WebAuthn{Metadata: schema.WebAuthnMetadata{Enabled: true, Path: path, ValidateTrustAnchor: true, ValidateEntry: true, ValidateEntryPermitZeroAAGUID: true, ValidateStatus: true}},
+			schema.WebAuthnFiltering{},
+			nil,
+		},
+		{
+			"ShouldErrorMetadataWithoutPath",
+			schema.WebAuthn{Metadata: schema.WebAuthnMetadata{Enabled: true}},
+			schema.WebAuthnFiltering{},
+			[]string{"webauthn: metadata: option 'path' is required when the 'enabled' option is enabled"},
+		},
+		{
+			"ShouldErrorMetadataPathNotExist",
+			schema.WebAuthn{Metadata: schema.WebAuthnMetadata{Enabled: true, Path: filepath.Join(dir, "missing.jwt")}},
+			schema.WebAuthnFiltering{},
+			[]string{"webauthn: metadata: option 'path' refers to location '" + filepath.Join(dir, "missing.jwt") + "' which does not exist"},
+		},
+		{
+			"ShouldErrorMetadataOptionsWhenNotEnabled",
+			schema.WebAuthn{Metadata: schema.WebAuthnMetadata{ValidateTrustAnchor: true, ValidateStatus: true}},
+			schema.WebAuthnFiltering{},
+			[]string{
+				"webauthn: metadata: option 'validate_trust_anchor' must not be enabled unless the 'enabled' option is also enabled",
+				"webauthn: metadata: option 'validate_status' must not be enabled unless the 'enabled' option is also enabled",
+			},
+		},
+		{
+			"ShouldErrorTrustAnchorWithoutAttestation",
+			schema.WebAuthn{ConveyancePreference: protocol.PreferNoAttestation, Metadata: schema.WebAuthnMetadata{Enabled: true, Path: path, ValidateTrustAnchor: true}},
+			schema.WebAuthnFiltering{},
+			[]string{"webauthn: metadata: option 'validate_trust_anchor' requires the 'attestation_conveyance_preference' option to be one of 'indirect' or 'direct' but it's configured as 'none'"},
+		},
+		{
+			"ShouldErrorPermitZeroAAGUIDWithoutValidateEntry",
+			schema.WebAuthn{Metadata: schema.WebAuthnMetadata{Enabled: true, Path: path, ValidateEntryPermitZeroAAGUID: true}},
+			schema.WebAuthnFiltering{},
+			[]string{"webauthn: metadata: option 'validate_entry_permit_zero_aaguid' must not be enabled unless the 'validate_entry' option is also enabled"},
+		},
+		{
+			"ShouldNormalizeAAGUIDs",
+			schema.WebAuthn{Metadata: schema.WebAuthnMetadata{Enabled: true, Path: path, ValidateTrustAnchor: true, ValidateEntry: true}, Filtering: schema.WebAuthnFiltering{PermittedAAGUIDs: []string{"CB69481E-8FF7-4039-93EC-0A2729A154A8", "ee882879721c491397753dfcce97072a"}}},
+			schema.WebAuthnFiltering{PermittedAAGUIDs: []string{"cb69481e-8ff7-4039-93ec-0a2729a154a8", "ee882879-721c-4913-9775-3dfcce97072a"}},
+			nil,
+		},
+		{
+			"ShouldErrorInvalidAAGUID",
+			schema.WebAuthn{Filtering: schema.WebAuthnFiltering{ProhibitedAAGUIDs: []string{"abc"}}},
+			schema.WebAuthnFiltering{ProhibitedAAGUIDs: []string{"abc"}},
+			[]string{"webauthn: filtering: option 'prohibited_aaguids' contains an invalid AAGUID 'abc': invalid UUID length: 3"},
+		},
+		{
+			"ShouldErrorPermittedAndProhibited",
+			schema.WebAuthn{Filtering: schema.WebAuthnFiltering{PermittedAAGUIDs: []string{"cb69481e-8ff7-4039-93ec-0a2729a154a8"}, ProhibitedAAGUIDs: []string{"ee882879-721c-4913-9775-3dfcce97072a"}}},
+			schema.WebAuthnFiltering{PermittedAAGUIDs: []string{"cb69481e-8ff7-4039-93ec-0a2729a154a8"}, ProhibitedAAGUIDs: []string{"ee882879-721c-4913-9775-3dfcce97072a"}},
+			[]string{
+				"webauthn: filtering: option 'permitted_aaguids' and 'prohibited_aaguids' are mutually exclusive",
+				"webauthn: filtering: option 'permitted_aaguids' requires the metadata 'enabled', 'validate_trust_anchor', and 'validate_entry' options to be enabled as the authenticator aaguid can't be trusted otherwise",
+			},
+		},
+		{
+			"ShouldErrorPermittedWithoutMetadata",
+			schema.WebAuthn{Filtering: schema.WebAuthnFiltering{PermittedAAGUIDs: []string{"cb69481e-8ff7-4039-93ec-0a2729a154a8"}}},
+			schema.WebAuthnFiltering{PermittedAAGUIDs: []string{"cb69481e-8ff7-4039-93ec-0a2729a154a8"}},
+			[]string{"webauthn: filtering: option 'permitted_aaguids' requires the metadata 'enabled', 'validate_trust_anchor', and 'validate_entry' options to be enabled as the authenticator aaguid can't be trusted otherwise"},
+		},
+		{
+			"ShouldErrorPermittedWithoutTrustAnchor",
+			schema.WebAuthn{Metadata: schema.WebAuthnMetadata{Enabled: true, Path: path, ValidateEntry: true}, Filtering: schema.WebAuthnFiltering{PermittedAAGUIDs: []string{"cb69481e-8ff7-4039-93ec-0a2729a154a8"}}},
+			schema.WebAuthnFiltering{PermittedAAGUIDs: []string{"cb69481e-8ff7-4039-93ec-0a2729a154a8"}},
+			[]string{"webauthn: filtering: option 'permitted_aaguids' requires the metadata 'enabled', 'validate_trust_anchor', and 'validate_entry' options to be enabled as the authenticator aaguid can't be trusted otherwise"},
+		},
+		{
+			"ShouldErrorPermittedWithoutAttestation",
+			schema.WebAuthn{ConveyancePreference: protocol.PreferNoAttestation, Metadata: schema.WebAuthnMetadata{Enabled: true, Path: path, ValidateTrustAnchor: true, ValidateEntry: true}, Filtering: schema.WebAuthnFiltering{PermittedAAGUIDs: []string{"cb69481e-8ff7-4039-93ec-0a2729a154a8"}}},
+			schema.WebAuthnFiltering{PermittedAAGUIDs: []string{"cb69481e-8ff7-4039-93ec-0a2729a154a8"}},
+			[]string{"webauthn: metadata: option 'validate_trust_anchor' requires the 'attestation_conveyance_preference' option to be one of 'indirect' or 'direct' but it's configured as 'none'"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			validator := schema.NewStructValidator()
+			config := &schema.Configuration{WebAuthn: tc.have}
+
+			ValidateWebAuthn(config, validator)
+
+			require.Len(t, validator.Warnings(), 0)
+			require.Len(t, validator.Errors(), len(tc.errors))
+
+			for i, err := range tc.errors {
+				assert.EqualError(t, validator.Errors()[i], err)
+			}
+
+			assert.Equal(t, tc.expected, config.WebAuthn.Filtering)
+		})
+	}
+}
@@ -1,5 +1,9 @@
 package duo
 
+import (
+	"time"
+)
+
 // Duo Methods.
 const (
 	// Push Method - The device is activated for Duo Push.
@@ -14,3 +18,24 @@ const (
 
 // PossibleMethods is the set of all possible Duo 2FA methods.
 var PossibleMethods = []string{Push} // OTP, Phone, SMS.
+
+// Universal Prompt constants.
+const (
+	// UniversalPromptResult is the result returned to the frontend when it should redirect to the Universal Prompt.
+	UniversalPromptResult = "universal_prompt"
+
+	universalPromptPathHealthCheck = "/oauth/v1/health_check"
+	universalPromptPathAuthorize   = "/oauth/v1/authorize"
+	universalPromptPathToken       = "/oauth/v1/token"
+
+	universalPromptClientAssertionType = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+	universalPromptGrantType           = "authorization_code"
+	universalPromptResponseType        = "code"
+	universalPromptScope               = "openid"
+
+	universalPromptStatOK      = "OK"
+	universalPromptResultAllow = "allow"
+
+	universalPromptJWTLifespan = 5 * time.Minute
+	universalPromptLeeway      = time.Minute
+)
@@ -1,10 +1,13 @@
 package duo
 
 import (
+	"context"
 	"encoding/json"
+	"net/http"
 	"net/url"
 
 	duoapi "github.com/duosecurity/duo_api_golang"
+	"github.com/golang-jwt/jwt/v5"
 
 	"github.com/authelia/authelia/v4/internal/middlewares"
 	"github.com/authelia/authelia/v4/internal/session"
@@ -57,3 +60,72 @@ type PreAuthResponse struct {
 	Devices         []Device `json:"devices"`
 	EnrollPortalURL string   `json:"enroll_portal_url"`
 }
+
+// UniversalPrompt is the interface for the Duo Universal Prompt which is the OIDC based Duo Web v4 SDK.
+type UniversalPrompt interface {
+	HealthCheck(ctx context.Context) (err error)
+	AuthURL(username, state, nonce, redirectURI string) (uri string, err error)
+	Exchange(ctx context.Context, code, redirectURI, username, nonce string) (claims *UniversalPromptClaims, err error)
+}
+
+// UniversalPromptClient is the implementation of the UniversalPrompt interface.
+type UniversalPromptClient struct {
+	clientID     string
+	clientSecret []byte
+	hostname     string
+	client       *http.Client
+}
+
+// UniversalPromptClaims are the claims of the ID Token returned from the Universal Prompt token endpoint.
+type UniversalPromptClaims struct {
+	AuthResult        UniversalPromptAuthResult  `json:"auth_result"`
+	AuthContext       UniversalPromptAuthContext `json:"auth_context"`
+	PreferredUsername string                     `json:"preferred_username"`
+	Nonce             string                     `json:"nonce"`
+
+	jwt.RegisteredClaims
+}
+
+// UniversalPromptAuthResult is the result of the Universal Prompt authentication.
+type UniversalPromptAuthResult struct {
+	Result        string `json:"result"`
+	Status        string `json:"status"`
+	StatusMessage string `json:"status_msg"`
+}
+
+// UniversalPromptAuthContext is the context of the Universal Prompt authentication.
+type UniversalPromptAuthContext struct {
+	Factor string `json:"factor"`
+	Result string `json:"result"`
+	Reason string `json:"reason"`
+	TxID   string `json:"txid"`
+}
+
+type universalPromptHealthCheckResponse struct {
+	Stat          string `json:"stat"`
+	Message       string `json:"message"`
+	MessageDetail string `json:"message_detail"`
+}
+
+type universalPromptTokenResponse struct {
+	IDToken     string `json:"id_token"`
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
+	TokenType   string `json:"token_type"`
+
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+type universalPromptRequestClaims struct {
+	ResponseType        string `json:"response_type"`
+	Scope               string `json:"scope"`
+	ClientID            string `json:"client_id"`
+	RedirectURI         string `json:"redirect_uri"`
+	State               string `json:"state"`
+	Nonce               string `json:"nonce"`
+	Username            string `json:"duo_uname"`
+	UseDuoCodeAttribute bool   `json:"use_duo_code_attribute"`
+
+	jwt.RegisteredClaims
+}
@@ -0,0 +1,211 @@
+package duo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// NewUniversalPromptClient creates a new UniversalPromptClient. The clientID and clientSecret are the Client ID and
+// Client Secret of a Duo Web SDK application and the hostname is the API hostname of the Duo account.
+func NewUniversalPromptClient(clientID, clientSecret, hostname string, client *http.Client) *UniversalPromptClient {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	return &UniversalPromptClient{
+		clientID:     clientID,
+		clientSecret: []byte(clientSecret),
+		hostname:     hostname,
+		client:       client,
+	}
+}
+
+// HealthCheck checks the Duo service is available before redirecting the user to the Universal Prompt.
+func (c *UniversalPromptClient) HealthCheck(ctx context.Context) (err error) {
+	var assertion string
+
+	endpoint := c.endpoint(universalPromptPathHealthCheck)
+
+	if assertion, err = c.clientAssertion(endpoint); err != nil {
+		return fmt.Errorf("error performing the health check: %w", err)
+	}
+
+	form := url.Values{}
+
+	form.Set("client_id", c.clientID)
+	form.Set("client_assertion", assertion)
+
+	response := &universalPromptHealthCheckResponse{}
+
+	if err = c.post(ctx, endpoint, form, response); err != nil {
+		return fmt.Errorf("error performing the health check: %w", err)
+	}
+
+	if response.Stat != universalPromptStatOK {
+		return fmt.Errorf("error performing the health check: the service responded with message '%s' and detail '%s'", response.Message, response.MessageDetail)
+	}
+
+	return nil
+}
+
+// AuthURL returns the URL of the Universal Prompt the user should be redirected to.
+func (c *UniversalPromptClient) AuthURL(username, state, nonce, redirectURI string) (uri string, err error) {
+	claims := &universalPromptRequestClaims{
+		ResponseType:        universalPromptResponseType,
+		Scope:               universalPromptScope,
+		ClientID:            c.clientID,
+		RedirectURI:         redirectURI,
+		State:               state,
+		Nonce:               nonce,
+		Username:            username,
+		UseDuoCodeAttribute: true,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    c.clientID,
+			Audience:  jwt.ClaimStrings{c.endpoint("")},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(universalPromptJWTLifespan)),
+		},
+	}
+
+	var request string
+
+	if request, err = jwt.NewWithClaims(jwt.SigningMethodHS512, claims).SignedString(c.clientSecret); err != nil {
+		return "", fmt.Errorf("error signing the request object: %w", err)
+	}
+
+	query := url.Values{}
+
+	query.Set("response_type", universalPromptResponseType)
+	query.Set("client_id", c.clientID)
+	query.Set("request", request)
+
+	return c.endpoint(universalPromptPathAuthorize) + "?" + query.Encode(), nil
+}
+
+// Exchange exchanges the authorization code returned by the Universal Prompt for an ID Token, validates the ID Token,
+// and ensures the authentication was successful for the expected user.
+func (c *UniversalPromptClient) Exchange(ctx context.Context, code, redirectURI, username, nonce string) (claims *UniversalPromptClaims, err error) {
+	if code == "" {
+		return nil, fmt.Errorf("error exchanging the authorization code: the authorization code is empty")
+	}
+
+	var assertion string
+
+	endpoint := c.endpoint(universalPromptPathToken)
+
+	if assertion, err = c.clientAssertion(endpoint); err != nil {
+		return nil, fmt.Errorf("error exchanging the authorization code: %w", err)
+	}
+
+	form := url.Values{}
+
+	form.Set("grant_type", universalPromptGrantType)
+	form.Set("code", code)
+	form.Set("redirect_uri", redirectURI)
+	form.Set("client_assertion_type", universalPromptClientAssertionType)
+	form.Set("client_assertion", assertion)
+
+	response := &universalPromptTokenResponse{}
+
+	if err = c.post(ctx, endpoint, form, response); err != nil {
+		return nil, fmt.Errorf("error exchanging the authorization code: %w", err)
+	}
+
+	if response.IDToken == "" {
+		return nil, fmt.Errorf("error exchanging the authorization code: the service responded with error '%s' and description '%s'", response.Error, response.ErrorDescription)
+	}
+
+	claims = &UniversalPromptClaims{}
+
+	parser := jwt.NewParser(
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS512.Alg()}),
+		jwt.WithAudience(c.clientID),
+		jwt.WithIssuer(endpoint),
+		jwt.WithIssuedAt(),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(universalPromptLeeway),
+	)
+
+	if _, err = parser.ParseWithClaims(response.IDToken, claims, c.keyfunc); err != nil {
+		return nil, fmt.Errorf("error validating the id token: %w", err)
+	}
+
+	if claims.PreferredUsername != username {
+		return nil, fmt.Errorf("error validating the id token: the preferred username '%s' does not match the expected username '%s'", claims.PreferredUsername, username)
+	}
+
+	if claims.Nonce != nonce {
+		return nil, fmt.Errorf("error validating the id token: the nonce does not match the expected nonce")
+	}
+
+	if claims.AuthResult.Result != universalPromptResultAllow {
+		return claims, fmt.Errorf("the authentication result was '%s' with status '%s' and message '%s'", claims.AuthResult.Result, claims.AuthResult.Status, claims.AuthResult.StatusMessage)
+	}
+
+	return claims, nil
+}
+
+func (c *UniversalPromptClient) endpoint(path string) string {
+	return "https://" + c.hostname + path
+}
+
+func (c *UniversalPromptClient) keyfunc(_ *jwt.Token) (key any, err error) {
+	return c.clientSecret, nil
+}
+
+// clientAssertion generates the signed JWT used to authenticate the client with the given endpoint.
+func (c *UniversalPromptClient) clientAssertion(endpoint string) (assertion string, err error) {
+	now := time.Now()
+
+	claims := &jwt.RegisteredClaims{
+		Issuer:    c.clientID,
+		Subject:   c.clientID,
+		Audience:  jwt.ClaimStrings{endpoint},
+		ExpiresAt: jwt.NewNumericDate(now.Add(universalPromptJWTLifespan)),
+		IssuedAt:  jwt.NewNumericDate(now),
+		ID:        uuid.NewString(),
+	}
+
+	if assertion, err = jwt.NewWithClaims(jwt.SigningMethodHS512, claims).SignedString(c.clientSecret); err != nil {
+		return "", fmt.Errorf("error signing the client assertion: %w", err)
+	}
+
+	return assertion, nil
+}
+
+func (c *UniversalPromptClient) post(ctx context.Context, endpoint string, form url.Values, v any) (err error) {
+	var (
+		request  *http.Request
+		response *http.Response
+	)
+
+	if request, err = http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode())); err != nil {
+		return err
+	}
+
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Accept", "application/json")
+
+	if response, err = c.client.Do(request); err != nil {
+		return err
+	}
+
+	defer response.Body.Close()
+
+	if response.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("the service responded with status code %d", response.StatusCode)
+	}
+
+	if err = json.NewDecoder(response.Body).Decode(v); err != nil {
+		return fmt.Errorf("error decoding the response with status code %d: %w", response.StatusCode, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,278 @@
+package duo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testClientID     = "DIXXXXXXXXXXXXXXXXXX"
+	testClientSecret = "deadbeefdeadbeefdeadbeefdeadbeefdeadbeef"
+	testRedirectURI  = "https://auth.example.com/2fa/push-notification"
+)
+
+// testUniversalPromptServer is a local stand-in implementing the Duo Universal Prompt OIDC endpoints.
+type testUniversalPromptServer struct {
+	*httptest.Server
+
+	result string
+	health string
+
+	mu       sync.Mutex
+	requests map[string]*universalPromptRequestClaims
+}
+
+func newTestUniversalPromptServer(t *testing.T) *testUniversalPromptServer {
+	s := &testUniversalPromptServer{
+		result:   universalPromptResultAllow,
+		health:   universalPromptStatOK,
+		requests: map[string]*universalPromptRequestClaims{},
+	}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc(universalPromptPathHealthCheck, s.handleHealthCheck)
+	mux.HandleFunc(universalPromptPathAuthorize, s.handleAuthorize)
+	mux.HandleFunc(universalPromptPathToken, s.handleToken)
+
+	s.Server = httptest.NewTLSServer(mux)
+
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+func (s *testUniversalPromptServer) hostname() string {
+	return strings.TrimPrefix(s.URL, "https://")
+}
+
+func (s *testUniversalPromptServer) keyfunc(_ *jwt.Token) (any, error) {
+	return []byte(testClientSecret), nil
+}
+
+func (s *testUniversalPromptServer) validAssertion(r *http.Request) bool {
+	_, err := jwt.Parse(r.PostFormValue("client_assertion"), s.keyfunc,
+		jwt.WithValidMethods([]string{"HS512"}), jwt.WithAudience(s.URL+r.URL.Path), jwt.WithIssuer(testClientID), jwt.WithSubject(testClientID))
+
+	return err == nil
+}
+
+func (s *testUniversalPromptServer) handleHealthCheck(w http.ResponseWriter, r *http.Request) {
+	if !s.validAssertion(r) || r.PostFormValue("client_id") != testClientID {
+		s.json(w, http.StatusBadRequest, map[string]any{"stat": "FAIL", "message": "invalid_client", "message_detail": "The client assertion is invalid."})
+
+		return
+	}
+
+	s.json(w, http.StatusOK, map[string]any{"stat": s.health, "message": "unavailable", "message_detail": "The service is unavailable."})
+}
+
+func (s *testUniversalPromptServer) handleAuthorize(w http.ResponseWriter, r *http.Request) {
+	claims := &universalPromptRequestClaims{}
+
+	if _, err := jwt.ParseWithClaims(r.URL.Query().Get("request"), claims, s.keyfunc, jwt.WithValidMethods([]string{"HS512"}), jwt.WithAudience(s.URL)); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	code := claims.State + "-code"
+
+	s.mu.Lock()
+	s.requests[code] = claims
+	s.mu.Unlock()
+
+	query := url.Values{}
+
+	query.Set("state", claims.State)
+	query.Set("duo_code", code)
+
+	http.Redirect(w, r, claims.RedirectURI+"?"+query.Encode(), http.StatusFound)
+}
+
+func (s *testUniversalPromptServer) handleToken(w http.ResponseWriter, r *http.Request) {
+	if !s.validAssertion(r) {
+		s.json(w, http.StatusBadRequest, map[string]any{"error": "invalid_client", "error_description": "The client assertion is invalid."})
+
+		return
+	}
+
+	s.mu.Lock()
+	request, ok := s.requests[r.PostFormValue("code")]
+	delete(s.requests, r.PostFormValue("code"))
+	s.mu.Unlock()
+
+	if !ok || request.RedirectURI != r.PostFormValue("redirect_uri") {
+		s.json(w, http.StatusBadRequest, map[string]any{"error": "invalid_grant", "error_description": "The authorization code is invalid."})
+
+		return
+	}
+
+	now := time.Now()
+
+	claims := &UniversalPromptClaims{
+		AuthResult:        UniversalPromptAuthResult{Result: s.result, Status: s.result, StatusMessage: "Login Successful"},
+		PreferredUsername: request.Username,
+		Nonce:             request.Nonce,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    s.URL + universalPromptPathToken,
+			Audience:  jwt.ClaimStrings{testClientID},
+			Subject:   request.Username,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+		},
+	}
+
+	token, _ := jwt.NewWithClaims(jwt.SigningMethodHS512, claims).SignedString([]byte(testClientSecret))
+
+	s.json(w, http.StatusOK, map[string]any{"id_token": token, "access_token": "access", "expires_in": 3600, "token_type": "Bearer"})
+}
+
+func (s *testUniversalPromptServer) json(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func (s *testUniversalPromptServer) authorize(t *testing.T, uri string) (state, code string) {
+	client := s.Client()
+
+	client.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+
+	response, err := client.Get(uri)
+	require.NoError(t, err)
+
+	defer response.Body.Close()
+
+	require.Equal(t, http.StatusFound, response.StatusCode)
+
+	location, err := url.Parse(response.Header.Get("Location"))
+	require.NoError(t, err)
+
+	return location.Query().Get("state"), location.Query().Get("duo_code")
+}
+
+func TestUniversalPromptClient(t *testing.T) {
+	server := newTestUniversalPromptServer(t)
+
+	client := NewUniversalPromptClient(testClientID, testClientSecret, server.hostname(), server.Client())
+
+	require.NoError(t, client.HealthCheck(context.Background()))
+
+	uri, err := client.AuthURL("john", "abc", "xyz", testRedirectURI)
+	require.NoError(t, err)
+
+	state, code := server.authorize(t, uri)
+
+	assert.Equal(t, "abc", state)
+	assert.Equal(t, "abc-code", code)
+
+	claims, err := client.Exchange(context.Background(), code, testRedirectURI, "john", "xyz")
+	require.NoError(t, err)
+
+	assert.Equal(t, "john", claims.PreferredUsername)
+	assert.Equal(t, "allow", claims.AuthResult.Result)
+}
+
+func TestUniversalPromptClientShouldFailExchange(t *testing.T) {
+	testCases := []struct {
+		name     string
+		result   string
+		username string
+		nonce    string
+		code     func(code string) string
+		expected string
+	}{
+		{
+			"ShouldFailDeny",
+			"deny",
+			"john",
+			"xyz",
+			nil,
+			"the authentication result was 'deny' with status 'deny' and message 'Login Successful'",
+		},
+		{
+			"ShouldFailUsername",
+			universalPromptResultAllow,
+			"harry",
+			"xyz",
+			nil,
+			"error validating the id token: the preferred username 'john' does not match the expected username 'harry'",
+		},
+		{
+			"ShouldFailNonce",
+			universalPromptResultAllow,
+			"john",
+			"abc",
+			nil,
+			"error validating the id token: the nonce does not match the expected nonce",
+		},
+		{
+			"ShouldFailCode",
+			universalPromptResultAllow,
+			"john",
+			"xyz",
+			func(code string) string { return code + "x" },
+			"error exchanging the authorization code: the service responded with error 'invalid_grant' and description 'The authorization code is invalid.'",
+		},
+		{
+			"ShouldFailEmptyCode",
+			universalPromptResultAllow,
+			"john",
+			"xyz",
+			func(code string) string { return "" },
+			"error exchanging the authorization code: the authorization code is empty",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newTestUniversalPromptServer(t)
+
+			server.result = tc.result
+
+			client := NewUniversalPromptClient(testClientID, testClientSecret, server.hostname(), server.Client())
+
+			uri, err := client.AuthURL("john", "abc", "xyz", testRedirectURI)
+			require.NoError(t, err)
+
+			_, code := server.authorize(t, uri)
+
+			if tc.code != nil {
+				code = tc.code(code)
+			}
+
+			_, err = client.Exchange(context.Background(), code, testRedirectURI, tc.username, tc.nonce)
+
+			assert.EqualError(t, err, tc.expected)
+		})
+	}
+}
+
+func TestUniversalPromptClientShouldFailHealthCheck(t *testing.T) {
+	server := newTestUniversalPromptServer(t)
+
+	client := NewUniversalPromptClient(testClientID, "bad", server.hostname(), server.Client())
+
+	assert.EqualError(t, client.HealthCheck(context.Background()), "error performing the health check: the service responded with message 'invalid_client' and detail 'The client assertion is invalid.'")
+
+	server.health = "FAIL"
+
+	client = NewUniversalPromptClient(testClientID, testClientSecret, server.hostname(), server.Client())
+
+	assert.EqualError(t, client.HealthCheck(context.Background()), "error performing the health check: the service responded with message 'unavailable' and detail 'The service is unavailable.'")
+}
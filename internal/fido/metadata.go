@@ -0,0 +1,281 @@
+package fido
+
+import (
+	"crypto/sha1" //nolint:gosec // SHA1 is required to calculate the FIDO attestation certificate key identifier.
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-webauthn/webauthn/metadata"
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/logging"
+)
+
+// NewMetadataProvider creates a new MetadataProvider. The BLOB signature is validated against the configured root
+// certificate or the FIDO Alliance root certificate if one isn't configured.
+func NewMetadataProvider(config *schema.WebAuthnMetadata) (provider *MetadataProvider) {
+	provider = &MetadataProvider{
+		config: config,
+		roots:  x509.NewCertPool(),
+		log:    logging.Logger(),
+	}
+
+	if config.RootCertificate.HasCertificates() {
+		for _, certificate := range config.RootCertificate.Certificates() {
+			provider.roots.AddCert(certificate)
+		}
+	} else if certificate, err := parseCertificateBase64(metadata.ProductionMDSRoot); err == nil {
+		provider.roots.AddCert(certificate)
+	}
+
+	return provider
+}
+
+// StartupCheck implements the startup check provider interface.
+func (p *MetadataProvider) StartupCheck() (err error) {
+	return p.Load()
+}
+
+// Load reads the BLOB from the configured path, validates the signature, and replaces the currently loaded entries.
+func (p *MetadataProvider) Load() (err error) {
+	var data []byte
+
+	if data, err = os.ReadFile(p.config.Path); err != nil {
+		return fmt.Errorf("error reading the metadata blob: %w", err)
+	}
+
+	return p.load(data, time.Now())
+}
+
+// Validate validates the attestation of a newly created credential against the loaded metadata in accordance with the
+// configuration.
+func (p *MetadataProvider) Validate(now time.Time, attestation *protocol.AttestationObject) (result Result, err error) {
+	var (
+		aaguid uuid.UUID
+		chain  []*x509.Certificate
+	)
+
+	if aaguid, err = uuid.FromBytes(attestation.AuthData.AttData.AAGUID); err != nil {
+		return result, fmt.Errorf("error parsing the authenticator aaguid: %w", err)
+	}
+
+	if chain, err = attestationCertificateChain(attestation); err != nil {
+		return result, fmt.Errorf("error parsing the attestation statement certificate chain: %w", err)
+	}
+
+	if result.Entry = p.lookup(aaguid, chain); result.Entry == nil {
+		switch {
+		case p.config.ValidateEntry && (aaguid != uuid.Nil || !p.config.ValidateEntryPermitZeroAAGUID):
+			return result, fmt.Errorf("the authenticator with aaguid '%s' does not have an entry in the metadata", aaguid)
+		case p.config.ValidateTrustAnchor:
+			// The trust anchors are part of the entry so an authenticator without an entry can never be trusted.
+			return result, fmt.Errorf("the authenticator with aaguid '%s' attestation could not be validated against the metadata trust anchors: the authenticator does not have an entry in the metadata", aaguid)
+		}
+
+		return result, nil
+	}
+
+	if p.config.ValidateStatus {
+		for _, report := range result.Entry.StatusReports {
+			if metadata.IsUndesiredAuthenticatorStatus(report.Status) {
+				return result, fmt.Errorf("the authenticator with aaguid '%s' has the undesired status '%s' in the metadata", aaguid, report.Status)
+			}
+		}
+	}
+
+	if p.config.ValidateTrustAnchor {
+		if err = result.Entry.validateTrustAnchor(now, chain); err != nil {
+			return result, fmt.Errorf("the authenticator with aaguid '%s' attestation could not be validated against the metadata trust anchors: %w", aaguid, err)
+		}
+	}
+
+	return result, nil
+}
+
+func (p *MetadataProvider) lookup(aaguid uuid.UUID, chain []*x509.Certificate) (entry *BlobEntry) {
+	p.mu.RLock()
+
+	defer p.mu.RUnlock()
+
+	if aaguid != uuid.Nil {
+		return p.entries[aaguid]
+	}
+
+	if len(chain) == 0 {
+		return nil
+	}
+
+	// Authenticators without an AAGUID such as FIDO U2F authenticators are identified by the attestation certificate.
+	if kid, err := certificateKeyIdentifier(chain[0]); err == nil {
+		return p.keys[kid]
+	}
+
+	return nil
+}
+
+func (p *MetadataProvider) load(data []byte, now time.Time) (err error) {
+	claims := &blobClaims{}
+
+	parser := jwt.NewParser(jwt.WithValidMethods(validBlobSigningMethods))
+
+	if _, err = parser.ParseWithClaims(strings.TrimSpace(string(data)), claims, p.keyfunc(now)); err != nil {
+		return fmt.Errorf("error parsing the metadata blob: %w", err)
+	}
+
+	blob := &claims.Blob
+
+	entries := make(map[uuid.UUID]*BlobEntry)
+	keys := make(map[string]*BlobEntry)
+
+	for i := range blob.Entries {
+		entry := &blob.Entries[i]
+
+		if aaguid, err := uuid.Parse(entry.AAGUID); err == nil && aaguid != uuid.Nil {
+			entries[aaguid] = entry
+		}
+
+		for _, kid := range entry.AttestationCertificateKeyIdentifiers {
+			keys[strings.ToLower(kid)] = entry
+		}
+	}
+
+	if next, err := blob.nextUpdate(); err == nil && next.Before(now) {
+		p.log.Warnf("The FIDO Metadata Service BLOB with number %d was due to be updated on %s, a newer BLOB should be obtained", blob.Number, blob.NextUpdate)
+	}
+
+	p.mu.Lock()
+
+	p.entries, p.keys = entries, keys
+
+	p.mu.Unlock()
+
+	p.log.Debugf("Loaded the FIDO Metadata Service BLOB with number %d containing %d entries", blob.Number, len(blob.Entries))
+
+	return nil
+}
+
+func (p *MetadataProvider) keyfunc(now time.Time) jwt.Keyfunc {
+	return func(token *jwt.Token) (key any, err error) {
+		raw, ok := token.Header["x5c"].([]any)
+		if !ok || len(raw) == 0 {
+			return nil, fmt.Errorf("the header does not contain a certificate chain")
+		}
+
+		chain := make([]*x509.Certificate, len(raw))
+
+		for i, value := range raw {
+			var encoded string
+
+			if encoded, ok = value.(string); !ok {
+				return nil, fmt.Errorf("the header certificate chain contains a value which is not a string")
+			}
+
+			if chain[i], err = parseCertificateBase64(encoded); err != nil {
+				return nil, fmt.Errorf("the header certificate chain contains an invalid certificate: %w", err)
+			}
+		}
+
+		if err = verifyCertificateChain(p.roots, now, chain); err != nil {
+			return nil, fmt.Errorf("the header certificate chain could not be verified: %w", err)
+		}
+
+		return chain[0].PublicKey, nil
+	}
+}
+
+func (e *BlobEntry) validateTrustAnchor(now time.Time, chain []*x509.Certificate) (err error) {
+	if len(chain) == 0 {
+		return fmt.Errorf("the attestation statement does not contain a certificate chain")
+	}
+
+	roots := x509.NewCertPool()
+
+	for _, encoded := range e.MetadataStatement.AttestationRootCertificates {
+		var certificate *x509.Certificate
+
+		if certificate, err = parseCertificateBase64(encoded); err != nil {
+			return fmt.Errorf("the metadata contains an invalid attestation root certificate: %w", err)
+		}
+
+		roots.AddCert(certificate)
+	}
+
+	return verifyCertificateChain(roots, now, chain)
+}
+
+func attestationCertificateChain(attestation *protocol.AttestationObject) (chain []*x509.Certificate, err error) {
+	raw, ok := attestation.AttStatement["x5c"].([]any)
+	if !ok {
+		return nil, nil
+	}
+
+	chain = make([]*x509.Certificate, len(raw))
+
+	for i, value := range raw {
+		var der []byte
+
+		if der, ok = value.([]byte); !ok {
+			return nil, fmt.Errorf("the certificate chain contains a value which is not a certificate")
+		}
+
+		if chain[i], err = x509.ParseCertificate(der); err != nil {
+			return nil, err
+		}
+	}
+
+	return chain, nil
+}
+
+func verifyCertificateChain(roots *x509.CertPool, now time.Time, chain []*x509.Certificate) (err error) {
+	intermediates := x509.NewCertPool()
+
+	for _, certificate := range chain[1:] {
+		intermediates.AddCert(certificate)
+	}
+
+	_, err = chain[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   now,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+
+	return err
+}
+
+func parseCertificateBase64(encoded string) (certificate *x509.Certificate, err error) {
+	var der []byte
+
+	if der, err = base64.StdEncoding.DecodeString(encoded); err != nil {
+		return nil, err
+	}
+
+	return x509.ParseCertificate(der)
+}
+
+// certificateKeyIdentifier returns the hex encoded SHA-1 hash of the subject public key of a certificate as described
+// by the attestationCertificateKeyIdentifiers metadata value.
+func certificateKeyIdentifier(certificate *x509.Certificate) (kid string, err error) {
+	var spki struct {
+		Algorithm pkix.AlgorithmIdentifier
+		PublicKey asn1.BitString
+	}
+
+	if _, err = asn1.Unmarshal(certificate.RawSubjectPublicKeyInfo, &spki); err != nil {
+		return "", err
+	}
+
+	sum := sha1.Sum(spki.PublicKey.Bytes) //nolint:gosec // SHA1 is required to calculate the FIDO attestation certificate key identifier.
+
+	return hex.EncodeToString(sum[:]), nil
+}
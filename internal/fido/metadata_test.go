@@ -0,0 +1,279 @@
+package fido
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+var (
+	testAAGUID        = uuid.MustParse("cb69481e-8ff7-4039-93ec-0a2729a154a8")
+	testAAGUIDRevoked = uuid.MustParse("ee882879-721c-4913-9775-3dfcce97072a")
+	testAAGUIDMissing = uuid.MustParse("fa2b99dc-9e39-4257-8f92-4a30d23c4118")
+)
+
+func TestMetadataProvider(t *testing.T) {
+	now := time.Now()
+
+	mdsRoot, mdsRootKey := testCertificate(t, "MDS Root", nil, nil, true)
+	mdsLeaf, mdsLeafKey := testCertificate(t, "MDS Signer", mdsRoot, mdsRootKey, false)
+
+	attRoot, attRootKey := testCertificate(t, "Attestation Root", nil, nil, true)
+	attLeaf, _ := testCertificate(t, "Attestation", attRoot, attRootKey, false)
+
+	otherRoot, otherRootKey := testCertificate(t, "Other Root", nil, nil, true)
+	otherLeaf, _ := testCertificate(t, "Other Attestation", otherRoot, otherRootKey, false)
+
+	kid, err := certificateKeyIdentifier(attLeaf)
+	require.NoError(t, err)
+
+	blob := testSignBlob(t, mdsLeaf, mdsLeafKey, map[string]any{
+		"no":         1,
+		"nextUpdate": now.Add(time.Hour * 24).Format(layoutDate),
+		"entries": []any{
+			map[string]any{
+				"aaguid": testAAGUID.String(),
+				"metadataStatement": map[string]any{
+					"description":                 "Example Security Key",
+					"attestationTypes":            []string{"basic_full"},
+					"attestationRootCertificates": []string{base64.StdEncoding.EncodeToString(attRoot.Raw)},
+				},
+				"statusReports": []any{map[string]any{"status": "FIDO_CERTIFIED_L1"}},
+			},
+			map[string]any{
+				"aaguid": testAAGUIDRevoked.String(),
+				"metadataStatement": map[string]any{
+					"description":                 "Example Revoked Security Key",
+					"attestationRootCertificates": []string{base64.StdEncoding.EncodeToString(attRoot.Raw)},
+				},
+				"statusReports": []any{map[string]any{"status": "FIDO_CERTIFIED_L1"}, map[string]any{"status": "REVOKED"}},
+			},
+			map[string]any{
+				"attestationCertificateKeyIdentifiers": []string{kid},
+				"metadataStatement": map[string]any{
+					"description":                 "Example U2F Security Key",
+					"attestationRootCertificates": []string{base64.StdEncoding.EncodeToString(attRoot.Raw)},
+				},
+			},
+		},
+	})
+
+	config := &schema.WebAuthnMetadata{
+		Enabled:             true,
+		Path:                filepath.Join(t.TempDir(), "mds.jwt"),
+		RootCertificate:     schema.NewX509CertificateChainFromCerts([]*x509.Certificate{mdsRoot}),
+		ValidateTrustAnchor: true,
+		ValidateEntry:       true,
+		ValidateStatus:      true,
+	}
+
+	require.NoError(t, os.WriteFile(config.Path, []byte(blob), 0600))
+
+	provider := NewMetadataProvider(config)
+
+	require.NoError(t, provider.StartupCheck())
+
+	testCases := []struct {
+		name     string
+		aaguid   uuid.UUID
+		chain    []*x509.Certificate
+		expected string
+		err      string
+	}{
+		{
+			"ShouldValidate",
+			testAAGUID,
+			[]*x509.Certificate{attLeaf},
+			"Example Security Key",
+			"",
+		},
+		{
+			"ShouldValidateByKeyIdentifier",
+			uuid.Nil,
+			[]*x509.Certificate{attLeaf},
+			"Example U2F Security Key",
+			"",
+		},
+		{
+			"ShouldFailMissingEntry",
+			testAAGUIDMissing,
+			[]*x509.Certificate{attLeaf},
+			"",
+			"the authenticator with aaguid 'fa2b99dc-9e39-4257-8f92-4a30d23c4118' does not have an entry in the metadata",
+		},
+		{
+			"ShouldFailUndesiredStatus",
+			testAAGUIDRevoked,
+			[]*x509.Certificate{attLeaf},
+			"Example Revoked Security Key",
+			"the authenticator with aaguid 'ee882879-721c-4913-9775-3dfcce97072a' has the undesired status 'REVOKED' in the metadata",
+		},
+		{
+			"ShouldFailUntrustedAttestation",
+			testAAGUID,
+			[]*x509.Certificate{otherLeaf},
+			"Example Security Key",
+			"the authenticator with aaguid 'cb69481e-8ff7-4039-93ec-0a2729a154a8' attestation could not be validated against the metadata trust anchors: x509: certificate signed by unknown authority",
+		},
+		{
+			"ShouldFailSelfAttestation",
+			testAAGUID,
+			nil,
+			"Example Security Key",
+			"the authenticator with aaguid 'cb69481e-8ff7-4039-93ec-0a2729a154a8' attestation could not be validated against the metadata trust anchors: the attestation statement does not contain a certificate chain",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := provider.Validate(now, testAttestationObject(tc.aaguid, tc.chain))
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+
+			assert.Equal(t, tc.expected, result.Model())
+		})
+	}
+}
+
+func TestMetadataProviderShouldPermitZeroAAGUID(t *testing.T) {
+	provider := &MetadataProvider{
+		config: &schema.WebAuthnMetadata{Enabled: true, ValidateEntry: true, ValidateEntryPermitZeroAAGUID: true},
+	}
+
+	result, err := provider.Validate(time.Now(), testAttestationObject(uuid.Nil, nil))
+
+	assert.NoError(t, err)
+	assert.Nil(t, result.Entry)
+
+	_, err = provider.Validate(time.Now(), testAttestationObject(testAAGUID, nil))
+
+	assert.EqualError(t, err, "the authenticator with aaguid 'cb69481e-8ff7-4039-93ec-0a2729a154a8' does not have an entry in the metadata")
+}
+
+func TestMetadataProviderShouldFailTrustAnchorWithoutEntry(t *testing.T) {
+	provider := &MetadataProvider{
+		config: &schema.WebAuthnMetadata{Enabled: true, ValidateTrustAnchor: true},
+	}
+
+	result, err := provider.Validate(time.Now(), testAttestationObject(testAAGUIDMissing, nil))
+
+	assert.EqualError(t, err, "the authenticator with aaguid 'fa2b99dc-9e39-4257-8f92-4a30d23c4118' attestation could not be validated against the metadata trust anchors: the authenticator does not have an entry in the metadata")
+	assert.Nil(t, result.Entry)
+
+	_, err = provider.Validate(time.Now(), testAttestationObject(uuid.Nil, nil))
+
+	assert.EqualError(t, err, "the authenticator with aaguid '00000000-0000-0000-0000-000000000000' attestation could not be validated against the metadata trust anchors: the authenticator does not have an entry in the metadata")
+}
+
+func TestMetadataProviderShouldFailUntrustedBlob(t *testing.T) {
+	mdsRoot, _ := testCertificate(t, "MDS Root", nil, nil, true)
+
+	otherRoot, otherRootKey := testCertificate(t, "Other Root", nil, nil, true)
+	otherLeaf, otherLeafKey := testCertificate(t, "Other Signer", otherRoot, otherRootKey, false)
+
+	provider := NewMetadataProvider(&schema.WebAuthnMetadata{
+		Enabled:         true,
+		RootCertificate: schema.NewX509CertificateChainFromCerts([]*x509.Certificate{mdsRoot}),
+	})
+
+	blob := testSignBlob(t, otherLeaf, otherLeafKey, map[string]any{"no": 1, "entries": []any{}})
+
+	err := provider.load([]byte(blob), time.Now())
+
+	assert.ErrorContains(t, err, "error parsing the metadata blob: token is unverifiable: error while executing keyfunc: the header certificate chain could not be verified: x509: certificate signed by unknown authority")
+}
+
+func TestMetadataProviderShouldFailReadBlob(t *testing.T) {
+	provider := NewMetadataProvider(&schema.WebAuthnMetadata{Enabled: true, Path: filepath.Join(t.TempDir(), "missing.jwt")})
+
+	assert.ErrorContains(t, provider.StartupCheck(), "error reading the metadata blob: open ")
+}
+
+func testAttestationObject(aaguid uuid.UUID, chain []*x509.Certificate) *protocol.AttestationObject {
+	attestation := &protocol.AttestationObject{
+		Format:       "packed",
+		AttStatement: map[string]any{},
+	}
+
+	attestation.AuthData.AttData.AAGUID = aaguid[:]
+
+	if len(chain) != 0 {
+		x5c := make([]any, len(chain))
+
+		for i, certificate := range chain {
+			x5c[i] = certificate.Raw
+		}
+
+		attestation.AttStatement["x5c"] = x5c
+	}
+
+	return attestation
+}
+
+func testSignBlob(t *testing.T, certificate *x509.Certificate, key crypto.Signer, claims map[string]any) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims(claims))
+
+	token.Header["x5c"] = []string{base64.StdEncoding.EncodeToString(certificate.Raw)}
+
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+
+	return signed
+}
+
+func testCertificate(t *testing.T, name string, parent *x509.Certificate, parentKey crypto.Signer, ca bool) (certificate *x509.Certificate, key *ecdsa.PrivateKey) {
+	var err error
+
+	key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		BasicConstraintsValid: true,
+		IsCA:                  ca,
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+	}
+
+	if ca {
+		template.KeyUsage |= x509.KeyUsageCertSign
+	}
+
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, key.Public(), parentKey)
+	require.NoError(t, err)
+
+	certificate, err = x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return certificate, key
+}
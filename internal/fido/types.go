@@ -0,0 +1,88 @@
+package fido
+
+import (
+	"crypto/x509"
+	"sync"
+	"time"
+
+	"github.com/go-webauthn/webauthn/metadata"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// MetadataProvider validates WebAuthn authenticators against a locally supplied FIDO Metadata Service BLOB.
+type MetadataProvider struct {
+	config *schema.WebAuthnMetadata
+	roots  *x509.CertPool
+	log    *logrus.Logger
+
+	mu      sync.RWMutex
+	entries map[uuid.UUID]*BlobEntry
+	keys    map[string]*BlobEntry
+}
+
+// Blob represents the decoded payload of a FIDO Metadata Service BLOB. Only the values required to validate
+// authenticators are decoded.
+type Blob struct {
+	Number     int         `json:"no"`
+	NextUpdate string      `json:"nextUpdate"`
+	Entries    []BlobEntry `json:"entries"`
+}
+
+// BlobEntry represents a single authenticator entry in a FIDO Metadata Service BLOB.
+type BlobEntry struct {
+	AAGUID                               string            `json:"aaguid"`
+	AttestationCertificateKeyIdentifiers []string          `json:"attestationCertificateKeyIdentifiers"`
+	MetadataStatement                    MetadataStatement `json:"metadataStatement"`
+	StatusReports                        []StatusReport    `json:"statusReports"`
+}
+
+// MetadataStatement represents the metadata statement of an authenticator entry.
+type MetadataStatement struct {
+	Description                 string                                  `json:"description"`
+	AttestationTypes            []metadata.AuthenticatorAttestationType `json:"attestationTypes"`
+	AttestationRootCertificates []string                                `json:"attestationRootCertificates"`
+}
+
+// StatusReport represents a status report of an authenticator entry.
+type StatusReport struct {
+	Status        metadata.AuthenticatorStatus `json:"status"`
+	EffectiveDate string                       `json:"effectiveDate"`
+}
+
+// Result describes the outcome of a successful validation.
+type Result struct {
+	// Entry is the matched metadata entry if any.
+	Entry *BlobEntry
+}
+
+// Model returns the authenticator model description from the matched entry, or an empty string if there was no match.
+func (r Result) Model() string {
+	if r.Entry == nil {
+		return ""
+	}
+
+	return r.Entry.MetadataStatement.Description
+}
+
+type blobClaims struct {
+	Blob
+
+	jwt.RegisteredClaims
+}
+
+const (
+	layoutDate = "2006-01-02"
+)
+
+var (
+	validBlobSigningMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+)
+
+// nextUpdate parses the NextUpdate value of the Blob.
+func (b *Blob) nextUpdate() (next time.Time, err error) {
+	return time.Parse(layoutDate, b.NextUpdate)
+}
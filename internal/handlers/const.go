@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"errors"
+	"time"
 
 	"github.com/valyala/fasthttp"
 )
@@ -9,12 +10,19 @@ import (
 const (
 	// ActionResetPassword is the string representation of the action for which the token has been produced.
 	ActionResetPassword = "ResetPassword"
+
+	// ActionRegulationUnlock is the string representation of the action for which the token has been produced.
+	ActionRegulationUnlock = "RegulationUnlock"
 )
 
 const (
 	anonymous = "<anonymous>"
 )
 
+const (
+	configurationReloadTriggerEndpoint = "endpoint"
+)
+
 var (
 	headerAuthorization   = []byte(fasthttp.HeaderAuthorization)
 	headerWWWAuthenticate = []byte(fasthttp.HeaderWWWAuthenticate)
@@ -40,6 +48,8 @@ var (
 const (
 	queryArgRD         = "rd"
 	queryArgRM         = "rm"
+	queryArgAMR        = "amr"
+	queryArgReauth     = "reauth"
 	queryArgID         = "id"
 	queryArgAuth       = "auth"
 	queryArgConsentID  = "consent_id"
@@ -70,6 +80,9 @@ const (
 	messageSecurityKeyDuplicateName              = "Another one of your security keys is already registered with that display name."
 	messageUnableToResetPassword                 = "Unable to reset your password."
 	messageMFAValidationFailed                   = "Authentication failed, please retry later."
+	messageUnableToGenerateRecoveryCodes         = "Unable to generate recovery codes."
+	messageUnableToSendEmailOneTimeCode          = "Unable to send the one-time code."
+	messageEmailOneTimeCodeRateLimited           = "Too many one-time codes have been requested, please retry later."
 	messagePasswordWeak                          = "Your supplied password does not meet the password policy requirements."
 )
 
@@ -86,6 +99,7 @@ const (
 	logFmtErrSessionRegenerate    = "Could not regenerate session during %s authentication for user '%s'"
 	logFmtErrSessionReset         = "Could not reset session during %s authentication for user '%s'"
 	logFmtErrSessionSave          = "Could not save session with the %s during %s %s for user '%s'"
+	logFmtErrDuoUniversalPrompt   = "Failed to perform Duo Universal Prompt authentication for user '%s'"
 	logFmtErrObtainProfileDetails = "Could not obtain profile details during %s authentication for user '%s'"
 	logFmtTraceProfileDetails     = "Profile details for user '%s' => groups: %s, emails %s"
 )
@@ -130,8 +144,14 @@ const (
 	deny   = "deny"
 	enroll = "enroll"
 	auth   = "auth"
+
+	duoUniversalPromptRandomLength = 36
+	duoUniversalPromptLifespan     = 5 * time.Minute
 )
 
+// duoUniversalPromptRedirectPath is the path of the portal the Duo Universal Prompt redirects the user back to.
+var duoUniversalPromptRedirectPath = []string{"2fa", "push-notification"}
+
 const ldapPasswordComplexityCode = "0000052D."
 
 var ldapPasswordComplexityCodes = []string{
@@ -143,6 +163,23 @@ var ldapPasswordComplexityErrors = []string{
 	"LDAP Result Code 19 \"Constraint Violation\": Password is too young to change",
 }
 
+const (
+	recoveryCodeLength = 10
+
+	// The recovery code hash parameters are intentionally lighter than the password defaults as every unused code is
+	// checked on each attempt; the codes themselves have roughly 49 bits of entropy and attempts are regulated.
+	recoveryCodeHashIterations  = 2
+	recoveryCodeHashMemory      = 19456
+	recoveryCodeHashParallelism = 1
+)
+
+const (
+	// trustedDeviceCookieName is the name of the cookie which identifies a trusted device.
+	trustedDeviceCookieName = "authelia_trusted_device"
+
+	trustedDeviceUserValueID = "deviceID"
+)
+
 const (
 	errStrReqBodyParse        = "error parsing the request body"
 	errStrRespBody            = "error occurred writing the response body"
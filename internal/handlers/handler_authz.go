@@ -3,6 +3,7 @@ package handlers
 import (
 	"fmt"
 	"net/url"
+	"strings"
 
 	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/authorization"
@@ -62,7 +63,7 @@ func (authz *Authz) Handler(ctx *middlewares.AutheliaCtx) {
 	authn.Object = object
 	authn.Method = friendlyMethod(authn.Object.Method)
 
-	ruleHasSubject, required := ctx.Providers.Authorizer.GetRequiredLevel(
+	ruleHasSubject, required, requirements := ctx.Providers.Authorizer.GetRequiredLevel(
 		authorization.Subject{
 			Username: authn.Details.Username,
 			Groups:   authn.Details.Groups,
@@ -82,7 +83,7 @@ func (authz *Authz) Handler(ctx *middlewares.AutheliaCtx) {
 			case strategy.HeaderStrategy():
 				ctx.Logger.WithError(err).Error("Error occurred while attempting to authenticate a request")
 
-				strategy.HandleUnauthorized(ctx, authn, authz.getRedirectionURL(&object, autheliaURL))
+				strategy.HandleUnauthorized(ctx, authn, authz.getRedirectionURL(&object, autheliaURL, requirements.AMR, authorization.Bypass))
 
 				return
 			}
@@ -91,7 +92,31 @@ func (authz *Authz) Handler(ctx *middlewares.AutheliaCtx) {
 		ctx.Logger.WithError(err).Debug("Error occurred while attempting to authenticate a request but the matched rule was a bypass rule")
 	}
 
-	switch isAuthzResult(authn.Level, required, ruleHasSubject) {
+	result := isAuthzResult(authn.Level, authn.AMR, required, requirements.AMR, ruleHasSubject)
+
+	reauth := authorization.Bypass
+
+	if result == AuthzResultAuthorized && required != authorization.Bypass && authn.Type == AuthnTypeCookie {
+		if reauth = requirements.MaxAuthenticationAge.GetReauthenticationLevel(ctx.Clock.Now(), authn.FirstFactorAt, authn.SecondFactorAt); reauth != authorization.Bypass {
+			ctx.Logger.Debugf("Access to '%s' requires user '%s' to re-authenticate with %s as the maximum authentication age has been exceeded", object.URL.String(), authn.Username, reauth)
+
+			result = AuthzResultUnauthorized
+		}
+	}
+
+	if result == AuthzResultAuthorized && required == authorization.TwoFactor && authn.TrustedDevice && requirements.MaxAuthenticationAge.TwoFactor > 0 {
+		ctx.Logger.Debugf("Access to '%s' requires user '%s' to re-authenticate with %s as the matched rule has a maximum second factor authentication age which a trusted device does not satisfy", object.URL.String(), authn.Username, authorization.TwoFactor)
+
+		result, reauth = AuthzResultUnauthorized, authorization.TwoFactor
+	}
+
+	if result == AuthzResultAuthorized && required == authorization.TwoFactor && authn.TrustedDevice && requirements.DisableTrustedDevice {
+		ctx.Logger.Debugf("Access to '%s' requires user '%s' to re-authenticate with %s as the matched rule does not permit trusted devices", object.URL.String(), authn.Username, authorization.TwoFactor)
+
+		result, reauth = AuthzResultUnauthorized, authorization.TwoFactor
+	}
+
+	switch result {
 	case AuthzResultForbidden:
 		ctx.Logger.Infof("Access to '%s' is forbidden to user '%s'", object.URL.String(), authn.Username)
 		ctx.ReplyForbidden()
@@ -104,7 +129,7 @@ func (authz *Authz) Handler(ctx *middlewares.AutheliaCtx) {
 			handler = authz.handleUnauthorized
 		}
 
-		handler(ctx, authn, authz.getRedirectionURL(&object, autheliaURL))
+		handler(ctx, authn, authz.getRedirectionURL(&object, autheliaURL, requirements.AMR, reauth))
 	case AuthzResultAuthorized:
 		authz.handleAuthorized(ctx, authn)
 	}
@@ -134,7 +159,7 @@ func (authz *Authz) getAutheliaURL(ctx *middlewares.AutheliaCtx, provider *sessi
 	return nil, fmt.Errorf("authelia url lookup failed")
 }
 
-func (authz *Authz) getRedirectionURL(object *authorization.Object, autheliaURL *url.URL) (redirectionURL *url.URL) {
+func (authz *Authz) getRedirectionURL(object *authorization.Object, autheliaURL *url.URL, amr []string, reauth authorization.Level) (redirectionURL *url.URL) {
 	if autheliaURL == nil {
 		return nil
 	}
@@ -153,6 +178,14 @@ func (authz *Authz) getRedirectionURL(object *authorization.Object, autheliaURL
 		qry.Set(queryArgRM, object.Method)
 	}
 
+	if len(amr) != 0 {
+		qry.Set(queryArgAMR, strings.Join(amr, " "))
+	}
+
+	if reauth != authorization.Bypass {
+		qry.Set(queryArgReauth, reauth.String())
+	}
+
 	redirectionURL.RawQuery = qry.Encode()
 
 	return redirectionURL
@@ -136,8 +136,12 @@ func (s *CookieSessionAuthnStrategy) Get(ctx *middlewares.AutheliaCtx, provider
 			Emails:      userSession.Emails,
 			Groups:      userSession.Groups,
 		},
-		Level: userSession.AuthenticationLevel,
-		Type:  AuthnTypeCookie,
+		Level:          userSession.AuthenticationLevel,
+		AMR:            userSession.AuthenticationMethodRefs.MarshalRFC8176(),
+		Type:           AuthnTypeCookie,
+		FirstFactorAt:  time.Unix(userSession.FirstFactorAuthnTimestamp, 0),
+		SecondFactorAt: time.Unix(userSession.SecondFactorAuthnTimestamp, 0),
+		TrustedDevice:  userSession.TrustedDevice,
 	}, nil
 }
 
@@ -558,6 +562,8 @@ func handleVerifyGETAuthorizationBearerIntrospection(ctx context.Context, provid
 		return "", osession.ClientID, true, authentication.OneFactor, nil
 	}
 
+	authn.AMR = osession.DefaultSession.Claims.AuthenticationMethodsReferences
+
 	if oidc.NewAuthenticationMethodsReferencesFromClaim(osession.DefaultSession.Claims.AuthenticationMethodsReferences).MultiFactorAuthentication() {
 		level = authentication.TwoFactor
 	} else {
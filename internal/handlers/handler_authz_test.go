@@ -12,6 +12,7 @@ import (
 	"go.uber.org/mock/gomock"
 
 	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/authorization"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/middlewares"
 	"github.com/authelia/authelia/v4/internal/mocks"
@@ -1583,6 +1584,432 @@ func (s *AuthzSuite) TestShouldNotRedirectRequestsForBypassACLWhenInactiveForToo
 	}
 }
 
+func (s *AuthzSuite) TestShouldRedirectWhenAuthenticationMethodsInsufficient() {
+	if s.setRequest == nil {
+		s.T().Skip()
+	}
+
+	builder := s.Builder()
+
+	builder = builder.WithStrategies(
+		NewCookieSessionAuthnStrategy(schema.NewRefreshIntervalDuration(5 * time.Minute)),
+	)
+
+	authz := builder.Build()
+
+	mock := mocks.NewMockAutheliaCtx(s.T())
+
+	defer mock.Close()
+
+	mock.Ctx.Clock = &mock.Clock
+
+	mock.Clock.Set(time.Now())
+
+	mock.Ctx.Configuration.Session.Cookies[0].Inactivity = testInactivity
+
+	mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&schema.Configuration{
+		AccessControl: schema.AccessControl{
+			DefaultPolicy: "deny",
+			Rules: []schema.AccessControlRule{
+				{
+					Domains: []string{"two-factor.example.com"},
+					Policy:  "two_factor",
+					AMR:     []string{"hwk"},
+				},
+			},
+		},
+	})
+
+	s.ConfigureMockSessionProviderWithAutomaticAutheliaURLs(mock)
+
+	targetURI := s.RequireParseRequestURI("https://two-factor.example.com")
+
+	s.setRequest(mock.Ctx, fasthttp.MethodGet, targetURI, true, false)
+
+	userSession, err := mock.Ctx.GetSession()
+	s.Require().NoError(err)
+
+	userSession.Username = testUsername
+	userSession.AuthenticationLevel = authentication.TwoFactor
+	userSession.AuthenticationMethodRefs.UsernameAndPassword = true
+	userSession.AuthenticationMethodRefs.TOTP = true
+	userSession.LastActivity = mock.Clock.Now().Unix()
+	userSession.RefreshTTL = mock.Clock.Now().Add(5 * time.Minute)
+
+	s.Require().NoError(mock.Ctx.SaveSession(userSession))
+
+	authz.Handler(mock.Ctx)
+
+	switch s.implementation {
+	case AuthzImplAuthRequest, AuthzImplLegacy:
+		s.Equal(fasthttp.StatusUnauthorized, mock.Ctx.Response.StatusCode())
+	default:
+		s.Equal(fasthttp.StatusFound, mock.Ctx.Response.StatusCode())
+		location := s.RequireParseRequestURI(mock.Ctx.Configuration.Session.Cookies[0].AutheliaURL.String())
+
+		if location.Path == "" {
+			location.Path = "/"
+		}
+
+		query := location.Query()
+		query.Set(queryArgRD, targetURI.String())
+		query.Set(queryArgRM, fasthttp.MethodGet)
+		query.Set(queryArgAMR, "hwk")
+
+		location.RawQuery = query.Encode()
+
+		s.Equal(location.String(), string(mock.Ctx.Response.Header.Peek(fasthttp.HeaderLocation)))
+	}
+}
+
+// This test checks that a recovery code satisfies a rule which requires the otp authentication method as a recovery
+// code is a one-time password and is equivalent to TOTP in the AMR values.
+func (s *AuthzSuite) TestShouldAuthorizeRecoveryCodeWhenAuthenticationMethodsRequireOneTimePassword() {
+	if s.setRequest == nil {
+		s.T().Skip()
+	}
+
+	builder := s.Builder()
+
+	builder = builder.WithStrategies(
+		NewCookieSessionAuthnStrategy(schema.NewRefreshIntervalDuration(5 * time.Minute)),
+	)
+
+	authz := builder.Build()
+
+	mock := mocks.NewMockAutheliaCtx(s.T())
+
+	defer mock.Close()
+
+	mock.Ctx.Clock = &mock.Clock
+
+	mock.Clock.Set(time.Now())
+
+	mock.Ctx.Configuration.Session.Cookies[0].Inactivity = testInactivity
+
+	mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&schema.Configuration{
+		AccessControl: schema.AccessControl{
+			DefaultPolicy: "deny",
+			Rules: []schema.AccessControlRule{
+				{
+					Domains: []string{"two-factor.example.com"},
+					Policy:  "two_factor",
+					AMR:     []string{"otp"},
+				},
+			},
+		},
+	})
+
+	s.ConfigureMockSessionProviderWithAutomaticAutheliaURLs(mock)
+
+	targetURI := s.RequireParseRequestURI("https://two-factor.example.com")
+
+	s.setRequest(mock.Ctx, fasthttp.MethodGet, targetURI, true, false)
+
+	userSession, err := mock.Ctx.GetSession()
+	s.Require().NoError(err)
+
+	userSession.Username = testUsername
+	userSession.AuthenticationLevel = authentication.TwoFactor
+	userSession.AuthenticationMethodRefs.UsernameAndPassword = true
+	userSession.AuthenticationMethodRefs.RecoveryCode = true
+	userSession.LastActivity = mock.Clock.Now().Unix()
+	userSession.RefreshTTL = mock.Clock.Now().Add(5 * time.Minute)
+
+	s.Require().NoError(mock.Ctx.SaveSession(userSession))
+
+	authz.Handler(mock.Ctx)
+
+	s.Equal(fasthttp.StatusOK, mock.Ctx.Response.StatusCode())
+}
+
+func (s *AuthzSuite) TestShouldRedirectWhenMaxAuthenticationAgeExceeded() {
+	if s.setRequest == nil {
+		s.T().Skip()
+	}
+
+	builder := s.Builder()
+
+	builder = builder.WithStrategies(
+		NewCookieSessionAuthnStrategy(schema.NewRefreshIntervalDuration(5 * time.Minute)),
+	)
+
+	authz := builder.Build()
+
+	mock := mocks.NewMockAutheliaCtx(s.T())
+
+	defer mock.Close()
+
+	mock.Ctx.Clock = &mock.Clock
+
+	mock.Clock.Set(time.Now())
+
+	mock.Ctx.Configuration.Session.Cookies[0].Inactivity = testInactivity
+
+	mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&schema.Configuration{
+		AccessControl: schema.AccessControl{
+			DefaultPolicy: "deny",
+			Rules: []schema.AccessControlRule{
+				{
+					Domains: []string{"two-factor.example.com"},
+					Policy:  "two_factor",
+					MaxAuthenticationAge: schema.AccessControlRuleMaxAuthenticationAge{
+						TwoFactor: 15 * time.Minute,
+					},
+				},
+			},
+		},
+	})
+
+	s.ConfigureMockSessionProviderWithAutomaticAutheliaURLs(mock)
+
+	targetURI := s.RequireParseRequestURI("https://two-factor.example.com")
+
+	s.setRequest(mock.Ctx, fasthttp.MethodGet, targetURI, true, false)
+
+	userSession, err := mock.Ctx.GetSession()
+	s.Require().NoError(err)
+
+	userSession.Username = testUsername
+	userSession.AuthenticationLevel = authentication.TwoFactor
+	userSession.AuthenticationMethodRefs.UsernameAndPassword = true
+	userSession.AuthenticationMethodRefs.TOTP = true
+	userSession.FirstFactorAuthnTimestamp = mock.Clock.Now().Add(-30 * time.Minute).Unix()
+	userSession.SecondFactorAuthnTimestamp = mock.Clock.Now().Add(-20 * time.Minute).Unix()
+	userSession.LastActivity = mock.Clock.Now().Unix()
+	userSession.RefreshTTL = mock.Clock.Now().Add(5 * time.Minute)
+
+	s.Require().NoError(mock.Ctx.SaveSession(userSession))
+
+	authz.Handler(mock.Ctx)
+
+	switch s.implementation {
+	case AuthzImplAuthRequest, AuthzImplLegacy:
+		s.Equal(fasthttp.StatusUnauthorized, mock.Ctx.Response.StatusCode())
+	default:
+		s.Equal(fasthttp.StatusFound, mock.Ctx.Response.StatusCode())
+		location := s.RequireParseRequestURI(mock.Ctx.Configuration.Session.Cookies[0].AutheliaURL.String())
+
+		if location.Path == "" {
+			location.Path = "/"
+		}
+
+		query := location.Query()
+		query.Set(queryArgRD, targetURI.String())
+		query.Set(queryArgRM, fasthttp.MethodGet)
+		query.Set(queryArgReauth, "two_factor")
+
+		location.RawQuery = query.Encode()
+
+		s.Equal(location.String(), string(mock.Ctx.Response.Header.Peek(fasthttp.HeaderLocation)))
+	}
+}
+
+func (s *AuthzSuite) TestShouldRedirectWhenTrustedDeviceDisabled() {
+	if s.setRequest == nil {
+		s.T().Skip()
+	}
+
+	builder := s.Builder()
+
+	builder = builder.WithStrategies(
+		NewCookieSessionAuthnStrategy(schema.NewRefreshIntervalDuration(5 * time.Minute)),
+	)
+
+	authz := builder.Build()
+
+	testCases := []struct {
+		name     string
+		domain   string
+		expected bool
+	}{
+		{"ShouldRedirect", "two-factor.example.com", false},
+		{"ShouldAllow", "trusted.example.com", true},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			mock := mocks.NewMockAutheliaCtx(s.T())
+
+			defer mock.Close()
+
+			mock.Ctx.Clock = &mock.Clock
+
+			mock.Clock.Set(time.Now())
+
+			mock.Ctx.Configuration.Session.Cookies[0].Inactivity = testInactivity
+
+			mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&schema.Configuration{
+				AccessControl: schema.AccessControl{
+					DefaultPolicy: "deny",
+					Rules: []schema.AccessControlRule{
+						{
+							Domains:              []string{"two-factor.example.com"},
+							Policy:               "two_factor",
+							DisableTrustedDevice: true,
+						},
+						{
+							Domains: []string{"trusted.example.com"},
+							Policy:  "two_factor",
+						},
+					},
+				},
+			})
+
+			s.ConfigureMockSessionProviderWithAutomaticAutheliaURLs(mock)
+
+			targetURI := s.RequireParseRequestURI("https://" + tc.domain)
+
+			s.setRequest(mock.Ctx, fasthttp.MethodGet, targetURI, true, false)
+
+			userSession, err := mock.Ctx.GetSession()
+			s.Require().NoError(err)
+
+			userSession.Username = testUsername
+			userSession.AuthenticationLevel = authentication.TwoFactor
+			userSession.AuthenticationMethodRefs.UsernameAndPassword = true
+			userSession.TrustedDevice = true
+			userSession.FirstFactorAuthnTimestamp = mock.Clock.Now().Unix()
+			userSession.SecondFactorAuthnTimestamp = mock.Clock.Now().Unix()
+			userSession.LastActivity = mock.Clock.Now().Unix()
+			userSession.RefreshTTL = mock.Clock.Now().Add(5 * time.Minute)
+
+			s.Require().NoError(mock.Ctx.SaveSession(userSession))
+
+			authz.Handler(mock.Ctx)
+
+			if tc.expected {
+				s.Equal(fasthttp.StatusOK, mock.Ctx.Response.StatusCode())
+
+				return
+			}
+
+			switch s.implementation {
+			case AuthzImplAuthRequest, AuthzImplLegacy:
+				s.Equal(fasthttp.StatusUnauthorized, mock.Ctx.Response.StatusCode())
+			default:
+				s.Equal(fasthttp.StatusFound, mock.Ctx.Response.StatusCode())
+				location := s.RequireParseRequestURI(mock.Ctx.Configuration.Session.Cookies[0].AutheliaURL.String())
+
+				if location.Path == "" {
+					location.Path = "/"
+				}
+
+				query := location.Query()
+				query.Set(queryArgRD, targetURI.String())
+				query.Set(queryArgRM, fasthttp.MethodGet)
+				query.Set(queryArgReauth, "two_factor")
+
+				location.RawQuery = query.Encode()
+
+				s.Equal(location.String(), string(mock.Ctx.Response.Header.Peek(fasthttp.HeaderLocation)))
+			}
+		})
+	}
+}
+
+func (s *AuthzSuite) TestShouldRedirectWhenTrustedDeviceAndMaxAuthenticationAge() {
+	if s.setRequest == nil {
+		s.T().Skip()
+	}
+
+	builder := s.Builder()
+
+	builder = builder.WithStrategies(
+		NewCookieSessionAuthnStrategy(schema.NewRefreshIntervalDuration(5 * time.Minute)),
+	)
+
+	authz := builder.Build()
+
+	testCases := []struct {
+		name     string
+		trusted  bool
+		expected bool
+	}{
+		{"ShouldRedirectTrustedDevice", true, false},
+		{"ShouldAllowSecondFactor", false, true},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			mock := mocks.NewMockAutheliaCtx(s.T())
+
+			defer mock.Close()
+
+			mock.Ctx.Clock = &mock.Clock
+
+			mock.Clock.Set(time.Now())
+
+			mock.Ctx.Configuration.Session.Cookies[0].Inactivity = testInactivity
+
+			mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&schema.Configuration{
+				AccessControl: schema.AccessControl{
+					DefaultPolicy: "deny",
+					Rules: []schema.AccessControlRule{
+						{
+							Domains: []string{"two-factor.example.com"},
+							Policy:  "two_factor",
+							MaxAuthenticationAge: schema.AccessControlRuleMaxAuthenticationAge{
+								TwoFactor: 15 * time.Minute,
+							},
+						},
+					},
+				},
+			})
+
+			s.ConfigureMockSessionProviderWithAutomaticAutheliaURLs(mock)
+
+			targetURI := s.RequireParseRequestURI("https://two-factor.example.com")
+
+			s.setRequest(mock.Ctx, fasthttp.MethodGet, targetURI, true, false)
+
+			userSession, err := mock.Ctx.GetSession()
+			s.Require().NoError(err)
+
+			userSession.Username = testUsername
+			userSession.AuthenticationLevel = authentication.TwoFactor
+			userSession.AuthenticationMethodRefs.UsernameAndPassword = true
+			userSession.AuthenticationMethodRefs.TOTP = !tc.trusted
+			userSession.TrustedDevice = tc.trusted
+			userSession.FirstFactorAuthnTimestamp = mock.Clock.Now().Unix()
+			userSession.SecondFactorAuthnTimestamp = mock.Clock.Now().Unix()
+			userSession.LastActivity = mock.Clock.Now().Unix()
+			userSession.RefreshTTL = mock.Clock.Now().Add(5 * time.Minute)
+
+			s.Require().NoError(mock.Ctx.SaveSession(userSession))
+
+			authz.Handler(mock.Ctx)
+
+			if tc.expected {
+				s.Equal(fasthttp.StatusOK, mock.Ctx.Response.StatusCode())
+
+				return
+			}
+
+			switch s.implementation {
+			case AuthzImplAuthRequest, AuthzImplLegacy:
+				s.Equal(fasthttp.StatusUnauthorized, mock.Ctx.Response.StatusCode())
+			default:
+				s.Equal(fasthttp.StatusFound, mock.Ctx.Response.StatusCode())
+				location := s.RequireParseRequestURI(mock.Ctx.Configuration.Session.Cookies[0].AutheliaURL.String())
+
+				if location.Path == "" {
+					location.Path = "/"
+				}
+
+				query := location.Query()
+				query.Set(queryArgRD, targetURI.String())
+				query.Set(queryArgRM, fasthttp.MethodGet)
+				query.Set(queryArgReauth, "two_factor")
+
+				location.RawQuery = query.Encode()
+
+				s.Equal(location.String(), string(mock.Ctx.Response.Header.Peek(fasthttp.HeaderLocation)))
+			}
+		})
+	}
+}
+
 func (s *AuthzSuite) TestShouldFailToParsePortalURL() {
 	if s.setRequest == nil {
 		s.T().Skip()
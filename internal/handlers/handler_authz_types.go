@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"net/url"
+	"time"
 
 	oauthelia2 "authelia.com/provider/oauth2"
 
@@ -76,9 +77,19 @@ type Authn struct {
 
 	Details authentication.UserDetails
 	Level   authentication.Level
+	AMR     []string
 	Object  authorization.Object
 	Type    AuthnType
 
+	// FirstFactorAt and SecondFactorAt are the times each factor was last performed. They're only known for the
+	// AuthnTypeCookie type.
+	FirstFactorAt  time.Time
+	SecondFactorAt time.Time
+
+	// TrustedDevice is true when the second factor was satisfied by a trusted device. It's only known for the
+	// AuthnTypeCookie type.
+	TrustedDevice bool
+
 	Header HeaderAuthorization
 }
 
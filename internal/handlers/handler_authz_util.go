@@ -26,7 +26,7 @@ func friendlyUsername(username string) (fusername string) {
 	}
 }
 
-func isAuthzResult(level authentication.Level, required authorization.Level, ruleHasSubject bool) AuthzResult {
+func isAuthzResult(level authentication.Level, amr []string, required authorization.Level, requiredAMR []string, ruleHasSubject bool) AuthzResult {
 	switch {
 	case required == authorization.Bypass:
 		return AuthzResultAuthorized
@@ -36,7 +36,7 @@ func isAuthzResult(level authentication.Level, required authorization.Level, rul
 		// possible without some more advanced logic.
 		return AuthzResultForbidden
 	case required == authorization.OneFactor && level >= authentication.OneFactor,
-		required == authorization.TwoFactor && level >= authentication.TwoFactor:
+		required == authorization.TwoFactor && level >= authentication.TwoFactor && authorization.IsAuthMethodsSufficient(amr, requiredAMR):
 		return AuthzResultAuthorized
 	default:
 		return AuthzResultUnauthorized
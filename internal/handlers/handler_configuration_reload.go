@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/middlewares"
+)
+
+// ConfigurationReloadPOST reloads the configuration and responds with the changes which were applied and the changes
+// which require a restart.
+func ConfigurationReloadPOST(ctx *middlewares.AutheliaCtx) {
+	if !isAdministrator(ctx, "configuration reload", ctx.Configuration.Reload.Administration.Groups) {
+		return
+	}
+
+	if ctx.Providers.ConfigurationReloader == nil {
+		ctx.Logger.Error("Error occurred reloading the configuration: the configuration reloader is not available")
+
+		ctx.SetStatusCode(fasthttp.StatusServiceUnavailable)
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	result, err := ctx.Providers.ConfigurationReloader.ReloadConfiguration(configurationReloadTriggerEndpoint)
+	if err != nil {
+		ctx.Logger.WithError(err).Error("Error occurred reloading the configuration, the existing configuration is still in use")
+
+		ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	if err = ctx.SetJSONBody(result); err != nil {
+		ctx.Logger.WithError(err).Errorf("Unable to set configuration reload response in body")
+	}
+}
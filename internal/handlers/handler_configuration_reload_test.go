@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/mocks"
+)
+
+type testConfigurationReloader struct {
+	result  *middlewares.ConfigurationReloadResult
+	err     error
+	trigger string
+}
+
+func (r *testConfigurationReloader) Current() (config *schema.Configuration, providers *middlewares.Providers) {
+	return nil, nil
+}
+
+func (r *testConfigurationReloader) ReloadConfiguration(trigger string) (result *middlewares.ConfigurationReloadResult, err error) {
+	r.trigger = trigger
+
+	return r.result, r.err
+}
+
+func TestConfigurationReloadPOST(t *testing.T) {
+	testCases := []struct {
+		name     string
+		level    authentication.Level
+		groups   []string
+		reloader *testConfigurationReloader
+		nilRel   bool
+		code     int
+		expected *middlewares.ConfigurationReloadResult
+		trigger  string
+	}{
+		{
+			"ShouldReload",
+			authentication.TwoFactor,
+			[]string{"admins"},
+			&testConfigurationReloader{result: &middlewares.ConfigurationReloadResult{Applied: []string{"log.level"}, Restart: []string{"server.address"}}},
+			false,
+			fasthttp.StatusOK,
+			&middlewares.ConfigurationReloadResult{Applied: []string{"log.level"}, Restart: []string{"server.address"}},
+			configurationReloadTriggerEndpoint,
+		},
+		{
+			"ShouldDenyOneFactor",
+			authentication.OneFactor,
+			[]string{"admins"},
+			&testConfigurationReloader{},
+			false,
+			fasthttp.StatusForbidden,
+			nil,
+			"",
+		},
+		{
+			"ShouldDenyNonAdministrator",
+			authentication.TwoFactor,
+			[]string{"dev"},
+			&testConfigurationReloader{},
+			false,
+			fasthttp.StatusForbidden,
+			nil,
+			"",
+		},
+		{
+			"ShouldFailReload",
+			authentication.TwoFactor,
+			[]string{"admins"},
+			&testConfigurationReloader{err: errors.New("invalid configuration")},
+			false,
+			fasthttp.StatusInternalServerError,
+			nil,
+			configurationReloadTriggerEndpoint,
+		},
+		{
+			"ShouldFailNoReloader",
+			authentication.TwoFactor,
+			[]string{"admins"},
+			nil,
+			true,
+			fasthttp.StatusServiceUnavailable,
+			nil,
+			"",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := mocks.NewMockAutheliaCtx(t)
+			defer mock.Close()
+
+			mock.Ctx.Configuration.Reload.Administration.Enable = true
+			mock.Ctx.Configuration.Reload.Administration.Groups = []string{"admins"}
+
+			if !tc.nilRel {
+				mock.Ctx.Providers.ConfigurationReloader = tc.reloader
+			}
+
+			userSession, err := mock.Ctx.GetSession()
+			require.NoError(t, err)
+
+			userSession.Username = testUsername
+			userSession.Groups = tc.groups
+			userSession.AuthenticationLevel = tc.level
+
+			require.NoError(t, mock.Ctx.SaveSession(userSession))
+
+			ConfigurationReloadPOST(mock.Ctx)
+
+			switch {
+			case tc.expected != nil:
+				mock.Assert200OK(t, tc.expected)
+			case tc.code == fasthttp.StatusForbidden:
+				assert.Equal(t, fasthttp.StatusForbidden, mock.Ctx.Response.StatusCode())
+			default:
+				mock.AssertKO(t, messageOperationFailed, tc.code)
+			}
+
+			if tc.reloader != nil {
+				assert.Equal(t, tc.trigger, tc.reloader.trigger)
+			}
+		})
+	}
+}
@@ -32,11 +32,18 @@ func FirstFactorPOST(delayFunc middlewares.TimingAttackDelayFunc) middlewares.Re
 		}
 
 		if bannedUntil, err := ctx.Providers.Regulator.Regulate(ctx, bodyJSON.Username); err != nil {
-			if errors.Is(err, regulation.ErrUserIsBanned) {
+			switch {
+			case errors.Is(err, regulation.ErrUserIsBanned), errors.Is(err, regulation.ErrIPIsBanned):
 				_ = markAuthenticationAttempt(ctx, false, &bannedUntil, bodyJSON.Username, regulation.AuthType1FA, nil)
 
 				respondUnauthorized(ctx, messageAuthenticationFailed)
 
+				return
+			case errors.Is(err, regulation.ErrUserIsLocked):
+				_ = markAuthenticationAttempt(ctx, false, &bannedUntil, bodyJSON.Username, regulation.AuthType1FA, err)
+
+				respondUnauthorized(ctx, messageAuthenticationFailed)
+
 				return
 			}
 
@@ -136,6 +143,12 @@ func FirstFactorPOST(delayFunc middlewares.TimingAttackDelayFunc) middlewares.Re
 
 		userSession.SetOneFactor(ctx.Clock.Now(), userDetails, keepMeLoggedIn)
 
+		trusted := handleTrustedDeviceFirstFactor(ctx, userSession.Username)
+
+		if trusted {
+			userSession.SetTwoFactorTrustedDevice(ctx.Clock.Now())
+		}
+
 		if ctx.Configuration.AuthenticationBackend.RefreshInterval.Update() {
 			userSession.RefreshTTL = ctx.Clock.Now().Add(ctx.Configuration.AuthenticationBackend.RefreshInterval.Value())
 		}
@@ -148,11 +161,16 @@ func FirstFactorPOST(delayFunc middlewares.TimingAttackDelayFunc) middlewares.Re
 			return
 		}
 
+		ctxLogEventNewLogin(ctx, userSession.Username)
+
 		successful = true
 
-		if bodyJSON.Workflow == workflowOpenIDConnect {
+		switch {
+		case bodyJSON.Workflow == workflowOpenIDConnect:
 			handleOIDCWorkflowResponse(ctx, &userSession, bodyJSON.TargetURL, bodyJSON.WorkflowID)
-		} else {
+		case trusted:
+			Handle2FAResponse(ctx, bodyJSON.TargetURL)
+		default:
 			Handle1FAResponse(ctx, bodyJSON.TargetURL, bodyJSON.RequestMethod, userSession.Username, userSession.Groups)
 		}
 	}
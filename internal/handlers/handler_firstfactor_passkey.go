@@ -0,0 +1,310 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/regulation"
+	"github.com/authelia/authelia/v4/internal/session"
+)
+
+// FirstFactorPasskeyGET handler starts the discoverable assertion ceremony used for passwordless authentication.
+func FirstFactorPasskeyGET(ctx *middlewares.AutheliaCtx) {
+	var (
+		w           *webauthn.WebAuthn
+		userSession session.UserSession
+		err         error
+	)
+
+	if userSession, err = ctx.GetSession(); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred generating a WebAuthn passkey authentication challenge: %s", errStrUserSessionData)
+
+		respondUnauthorized(ctx, messageAuthenticationFailed)
+
+		return
+	}
+
+	if w, err = handleNewWebAuthn(ctx); err != nil {
+		ctx.Logger.WithError(err).Error("Error occurred generating a WebAuthn passkey authentication challenge: error occurred provisioning the configuration")
+
+		respondUnauthorized(ctx, messageAuthenticationFailed)
+
+		return
+	}
+
+	var (
+		assertion *protocol.CredentialAssertion
+		data      session.WebAuthn
+	)
+
+	if assertion, data.SessionData, err = w.BeginDiscoverableLogin(webauthn.WithUserVerification(ctx.Configuration.WebAuthn.UserVerification)); err != nil {
+		ctx.Logger.WithError(formatWebAuthnError(err)).Error("Error occurred generating a WebAuthn passkey authentication challenge: error occurred starting the authentication session")
+
+		respondUnauthorized(ctx, messageAuthenticationFailed)
+
+		return
+	}
+
+	userSession.WebAuthn = &data
+
+	if err = ctx.SaveSession(userSession); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred generating a WebAuthn passkey authentication challenge: %s", errStrUserSessionDataSave)
+
+		respondUnauthorized(ctx, messageAuthenticationFailed)
+
+		return
+	}
+
+	if err = ctx.SetJSONBody(assertion); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred generating a WebAuthn passkey authentication challenge: %s", errStrRespBody)
+
+		respondUnauthorized(ctx, messageAuthenticationFailed)
+
+		return
+	}
+}
+
+// FirstFactorPasskeyPOST handler completes the discoverable assertion ceremony, establishing the identity of the user
+// from the user handle of the credential.
+//
+//nolint:gocyclo
+func FirstFactorPasskeyPOST(delayFunc middlewares.TimingAttackDelayFunc) middlewares.RequestHandler {
+	return func(ctx *middlewares.AutheliaCtx) {
+		var successful bool
+
+		requestTime := time.Now()
+
+		if delayFunc != nil {
+			defer delayFunc(ctx, requestTime, &successful)
+		}
+
+		var (
+			userSession session.UserSession
+
+			err error
+
+			w    *webauthn.WebAuthn
+			c    *webauthn.Credential
+			user *model.WebAuthnUser
+
+			bannedUntil time.Time
+			errRegulate error
+
+			bodyJSON bodyFirstFactorPasskeyRequest
+
+			assertionResponse *protocol.ParsedCredentialAssertionData
+		)
+
+		if err = ctx.ParseBody(&bodyJSON); err != nil {
+			ctx.Logger.WithError(err).Errorf(logFmtErrParseRequestBody, regulation.AuthTypePasskey)
+
+			respondUnauthorized(ctx, messageAuthenticationFailed)
+
+			return
+		}
+
+		if assertionResponse, err = protocol.ParseCredentialRequestResponseBody(bytes.NewReader(bodyJSON.Response)); err != nil {
+			ctx.Logger.WithError(formatWebAuthnError(err)).Errorf(logFmtErrParseRequestBody, regulation.AuthTypePasskey)
+
+			respondUnauthorized(ctx, messageAuthenticationFailed)
+
+			return
+		}
+
+		provider, err := ctx.GetSessionProvider()
+		if err != nil {
+			ctx.Logger.WithError(err).Error("Failed to get session provider during passkey attempt")
+
+			respondUnauthorized(ctx, messageAuthenticationFailed)
+
+			return
+		}
+
+		if userSession, err = provider.GetSession(ctx.RequestCtx); err != nil {
+			ctx.Logger.WithError(err).Errorf("Error occurred validating a WebAuthn passkey authentication challenge: %s", errStrUserSessionData)
+
+			respondUnauthorized(ctx, messageAuthenticationFailed)
+
+			return
+		}
+
+		if userSession.WebAuthn == nil || userSession.WebAuthn.SessionData == nil {
+			ctx.Logger.WithError(fmt.Errorf("challenge session data is not present")).Errorf("Error occurred validating a WebAuthn passkey authentication challenge: %s", errStrUserSessionData)
+
+			respondUnauthorized(ctx, messageAuthenticationFailed)
+
+			return
+		}
+
+		if w, err = handleNewWebAuthn(ctx); err != nil {
+			ctx.Logger.WithError(err).Error("Error occurred validating a WebAuthn passkey authentication challenge: error occurred provisioning the configuration")
+
+			respondUnauthorized(ctx, messageAuthenticationFailed)
+
+			return
+		}
+
+		handler := func(_, userHandle []byte) (webauthn.User, error) {
+			if user, err = handleGetWebAuthnUserByUserHandle(ctx, w.Config.RPID, userHandle); err != nil {
+				return nil, err
+			}
+
+			// Regulation is performed once the user is known so banned users don't have their assertion validated.
+			if bannedUntil, errRegulate = ctx.Providers.Regulator.Regulate(ctx, user.Username); errRegulate != nil {
+				return nil, errRegulate
+			}
+
+			return user, nil
+		}
+
+		if c, err = w.ValidateDiscoverableLogin(handler, *userSession.WebAuthn.SessionData, assertionResponse); err != nil {
+			switch {
+			case user == nil:
+				ctx.Logger.WithError(formatWebAuthnError(err)).Error("Error occurred validating a WebAuthn passkey authentication challenge: error occurred looking up the user from the credential user handle")
+			case errors.Is(errRegulate, regulation.ErrUserIsBanned), errors.Is(errRegulate, regulation.ErrIPIsBanned):
+				_ = markAuthenticationAttempt(ctx, false, &bannedUntil, user.Username, regulation.AuthTypePasskey, nil)
+			case errors.Is(errRegulate, regulation.ErrUserIsLocked):
+				_ = markAuthenticationAttempt(ctx, false, &bannedUntil, user.Username, regulation.AuthTypePasskey, errRegulate)
+			case errRegulate != nil:
+				ctx.Logger.WithError(errRegulate).Errorf(logFmtErrRegulationFail, regulation.AuthTypePasskey, user.Username)
+			default:
+				_ = markAuthenticationAttempt(ctx, false, nil, user.Username, regulation.AuthTypePasskey, formatWebAuthnError(err))
+			}
+
+			respondUnauthorized(ctx, messageAuthenticationFailed)
+
+			return
+		}
+
+		var found bool
+
+		for _, credential := range user.Credentials {
+			if bytes.Equal(credential.KID.Bytes(), c.ID) {
+				credential.UpdateSignInInfo(w.Config, ctx.Clock.Now().UTC(), c.Authenticator)
+
+				found = true
+
+				if err = ctx.Providers.StorageProvider.UpdateWebAuthnCredentialSignIn(ctx, credential); err != nil {
+					ctx.Logger.WithError(err).Errorf("Error occurred validating a WebAuthn passkey authentication challenge for user '%s': error occurred saving the credential sign-in information to the storage backend", user.Username)
+
+					respondUnauthorized(ctx, messageAuthenticationFailed)
+
+					return
+				}
+
+				break
+			}
+		}
+
+		if !found {
+			ctx.Logger.WithError(fmt.Errorf("credential was not found")).Errorf("Error occurred validating a WebAuthn passkey authentication challenge for user '%s': error occurred saving the credential sign-in information to storage", user.Username)
+
+			respondUnauthorized(ctx, messageAuthenticationFailed)
+
+			return
+		}
+
+		if c.Authenticator.CloneWarning {
+			ctx.Logger.WithError(fmt.Errorf("authenticator sign count indicates that it is cloned")).Errorf("Error occurred validating a WebAuthn passkey authentication challenge for user '%s': error occurred validating the authenticator response", user.Username)
+
+			respondUnauthorized(ctx, messageAuthenticationFailed)
+
+			return
+		}
+
+		if err = markAuthenticationAttempt(ctx, true, nil, user.Username, regulation.AuthTypePasskey, nil); err != nil {
+			respondUnauthorized(ctx, messageAuthenticationFailed)
+
+			return
+		}
+
+		// Reset all values from previous session except OIDC workflow before regenerating the cookie.
+		if err = ctx.SaveSession(provider.NewDefaultUserSession()); err != nil {
+			ctx.Logger.WithError(err).Errorf(logFmtErrSessionReset, regulation.AuthTypePasskey, user.Username)
+
+			respondUnauthorized(ctx, messageAuthenticationFailed)
+
+			return
+		}
+
+		if err = ctx.RegenerateSession(); err != nil {
+			ctx.Logger.WithError(err).Errorf(logFmtErrSessionRegenerate, regulation.AuthTypePasskey, user.Username)
+
+			respondUnauthorized(ctx, messageAuthenticationFailed)
+
+			return
+		}
+
+		keepMeLoggedIn := !provider.Config.DisableRememberMe && bodyJSON.KeepMeLoggedIn != nil && *bodyJSON.KeepMeLoggedIn
+
+		if keepMeLoggedIn {
+			if err = provider.UpdateExpiration(ctx.RequestCtx, provider.Config.RememberMe); err != nil {
+				ctx.Logger.WithError(err).Errorf(logFmtErrSessionSave, "updated expiration", regulation.AuthTypePasskey, logFmtActionAuthentication, user.Username)
+
+				respondUnauthorized(ctx, messageAuthenticationFailed)
+
+				return
+			}
+		}
+
+		var details *authentication.UserDetails
+
+		if details, err = ctx.Providers.UserProvider.GetDetails(user.Username); err != nil {
+			ctx.Logger.WithError(err).Errorf(logFmtErrObtainProfileDetails, regulation.AuthTypePasskey, user.Username)
+
+			respondUnauthorized(ctx, messageAuthenticationFailed)
+
+			return
+		}
+
+		ctx.Logger.Tracef(logFmtTraceProfileDetails, user.Username, details.Groups, details.Emails)
+
+		userPresence, userVerified := assertionResponse.Response.AuthenticatorData.Flags.HasUserPresent(), assertionResponse.Response.AuthenticatorData.Flags.HasUserVerified()
+
+		userSession.SetOneFactorPasskey(ctx.Clock.Now(), details, keepMeLoggedIn, userPresence, userVerified)
+
+		twoFactor := userVerified && ctx.Configuration.WebAuthn.EnablePasskeyTwoFactor
+
+		switch {
+		case twoFactor:
+			userSession.SetTwoFactorPasskey(ctx.Clock.Now())
+		case handleTrustedDeviceFirstFactor(ctx, userSession.Username):
+			twoFactor = true
+
+			userSession.SetTwoFactorTrustedDevice(ctx.Clock.Now())
+		}
+
+		if ctx.Configuration.AuthenticationBackend.RefreshInterval.Update() {
+			userSession.RefreshTTL = ctx.Clock.Now().Add(ctx.Configuration.AuthenticationBackend.RefreshInterval.Value())
+		}
+
+		if err = ctx.SaveSession(userSession); err != nil {
+			ctx.Logger.WithError(err).Errorf(logFmtErrSessionSave, "updated profile", regulation.AuthTypePasskey, logFmtActionAuthentication, user.Username)
+
+			respondUnauthorized(ctx, messageAuthenticationFailed)
+
+			return
+		}
+
+		ctxLogEventNewLogin(ctx, userSession.Username)
+
+		successful = true
+
+		switch {
+		case bodyJSON.Workflow == workflowOpenIDConnect:
+			handleOIDCWorkflowResponse(ctx, &userSession, bodyJSON.TargetURL, bodyJSON.WorkflowID)
+		case twoFactor:
+			Handle2FAResponse(ctx, bodyJSON.TargetURL)
+		default:
+			Handle1FAResponse(ctx, bodyJSON.TargetURL, bodyJSON.RequestMethod, userSession.Username, userSession.Groups)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/mocks"
+	"github.com/authelia/authelia/v4/internal/session"
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+func TestFirstFactorPasskeyGET(t *testing.T) {
+	testCases := []struct {
+		name             string
+		setup            func(t *testing.T, mock *mocks.MockAutheliaCtx)
+		expected         *regexp.Regexp
+		expectedStatus   int
+		validateResponse func(t *testing.T, mock *mocks.MockAutheliaCtx)
+	}{
+		{
+			"ShouldSuccess",
+			nil,
+			regexp.MustCompile(`^\{"status":"OK","data":\{"publicKey":\{"challenge":"[a-zA-Z0-9/_-]+={0,2}","timeout":60000,"rpId":"login.example.com","userVerification":"preferred"}}}$`),
+			fasthttp.StatusOK,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				us, err := mock.Ctx.GetSession()
+
+				require.NoError(t, err)
+
+				assert.True(t, us.IsAnonymous())
+
+				require.NotNil(t, us.WebAuthn)
+				require.NotNil(t, us.WebAuthn.SessionData)
+
+				assert.Nil(t, us.WebAuthn.UserID)
+				assert.Len(t, us.WebAuthn.AllowedCredentialIDs, 0)
+			},
+		},
+		{
+			"ShouldHandleBadOrigin",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				mock.Ctx.Request.Header.Set("X-Original-URL", "!@NJK#N!@#IKJ!@NJK")
+			},
+			regexp.MustCompile(`^\{"status":"KO","message":"Authentication failed. Check your credentials."}`),
+			fasthttp.StatusUnauthorized,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				AssertLogEntryMessageAndError(t, mock.Hook.LastEntry(), "Error occurred generating a WebAuthn passkey authentication challenge: error occurred provisioning the configuration", "failed to parse X-Original-URL header: parse \"!@NJK#N!@#IKJ!@NJK\": invalid URI for request")
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := mocks.NewMockAutheliaCtx(t)
+
+			defer mock.Close()
+
+			mock.Ctx.Configuration.WebAuthn = schema.DefaultWebAuthnConfiguration
+			mock.Ctx.Configuration.WebAuthn.EnablePasskeyLogin = true
+
+			mock.Ctx.Request.Header.Set("X-Original-URL", "https://login.example.com:8080")
+
+			if tc.setup != nil {
+				tc.setup(t, mock)
+			}
+
+			FirstFactorPasskeyGET(mock.Ctx)
+
+			assert.Equal(t, tc.expectedStatus, mock.Ctx.Response.StatusCode())
+			assert.Regexp(t, tc.expected, string(mock.Ctx.Response.Body()))
+
+			if tc.validateResponse != nil {
+				tc.validateResponse(t, mock)
+			}
+		})
+	}
+}
+
+func TestFirstFactorPasskeyPOST(t *testing.T) {
+	const (
+		dataReqFmt     = `{"response":{"id":"rwOwV8WCh1hrE0M6mvaoRGpGHidqK6IlhkDJ2xERhPU","rawId":"rwOwV8WCh1hrE0M6mvaoRGpGHidqK6IlhkDJ2xERhPU","response":{"authenticatorData":"DGygg5w6VoNVeDP2GKJVZmXfKgiJZHh9U4ULStTTvtwFAAAAAw","clientDataJSON":"%s","signature":"MEQCIBlJ2Fxf6ZwLNTCQglz0AW0pD4HlU8W5Yk696jjfxVxhAiAhAMkLh8iKyhW6zSmzwfQDjMF2nKjVHzEs7jLHRPDZ2A","userHandle":"dXNlcg"},"type":"public-key","clientExtensionResults":{},"authenticatorAttachment":"platform"},"targetURL":null}`
+		dataClientJSON = `{"type":"webauthn.get","challenge":"in1cL-oWfSjSd7uuwUvv2ndOAmRXb0cOAbUoTtAqvGE","origin":"https://login.example.com:8080","crossOrigin":false}`
+	)
+
+	dataReqGood := fmt.Sprintf(dataReqFmt, base64.RawURLEncoding.EncodeToString([]byte(dataClientJSON)))
+
+	setSessionData := func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+		us, err := mock.Ctx.GetSession()
+
+		require.NoError(t, err)
+
+		us.WebAuthn = &session.WebAuthn{
+			SessionData: &webauthn.SessionData{
+				Challenge:        "in1cL-oWfSjSd7uuwUvv2ndOAmRXb0cOAbUoTtAqvGE",
+				UserVerification: "preferred",
+			},
+		}
+
+		require.NoError(t, mock.Ctx.SaveSession(us))
+	}
+
+	testCases := []struct {
+		name      string
+		setup     func(t *testing.T, mock *mocks.MockAutheliaCtx)
+		have      string
+		expectedf func(t *testing.T, mock *mocks.MockAutheliaCtx)
+	}{
+		{
+			"ShouldFailBadBody",
+			nil,
+			"not json",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				AssertLogEntryMessageAndError(t, mock.Hook.LastEntry(), "Failed to parse Passkey request body", "unable to parse body: invalid character 'o' in literal null (expecting 'u')")
+			},
+		},
+		{
+			"ShouldFailNoSessionData",
+			nil,
+			dataReqGood,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				AssertLogEntryMessageAndError(t, mock.Hook.LastEntry(), "Error occurred validating a WebAuthn passkey authentication challenge: error occurred retrieving the user session data", "challenge session data is not present")
+			},
+		},
+		{
+			"ShouldFailUnknownUserHandle",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				setSessionData(t, mock)
+
+				mock.StorageMock.
+					EXPECT().
+					LoadWebAuthnUserByUserID(mock.Ctx, "login.example.com", "user").
+					Return(nil, storage.ErrNoWebAuthnUser)
+			},
+			dataReqGood,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				AssertLogEntryMessageAndError(t, mock.Hook.LastEntry(), "Error occurred validating a WebAuthn passkey authentication challenge: error occurred looking up the user from the credential user handle", "Failed to lookup Client-side Discoverable Credential: no WebAuthn user found")
+
+				us, err := mock.Ctx.GetSession()
+
+				require.NoError(t, err)
+
+				assert.Equal(t, authentication.NotAuthenticated, us.AuthenticationLevel)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := mocks.NewMockAutheliaCtx(t)
+
+			defer mock.Close()
+
+			mock.Ctx.Configuration.WebAuthn = schema.DefaultWebAuthnConfiguration
+			mock.Ctx.Configuration.WebAuthn.EnablePasskeyLogin = true
+
+			mock.Ctx.Request.Header.Set("X-Original-URL", "https://login.example.com:8080")
+
+			if tc.setup != nil {
+				tc.setup(t, mock)
+			}
+
+			mock.Ctx.Request.SetBodyString(tc.have)
+
+			FirstFactorPasskeyPOST(nil)(mock.Ctx)
+
+			assert.Equal(t, fasthttp.StatusUnauthorized, mock.Ctx.Response.StatusCode())
+			assert.Equal(t, `{"status":"KO","message":"Authentication failed. Check your credentials."}`, string(mock.Ctx.Response.Body()))
+
+			if tc.expectedf != nil {
+				tc.expectedf(t, mock)
+			}
+		})
+	}
+}
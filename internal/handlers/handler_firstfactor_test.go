@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"fmt"
+	"net/mail"
 	"net/url"
 	"testing"
 
@@ -201,6 +202,16 @@ func (s *FirstFactorSuite) TestShouldAuthenticateUserWithRememberMeChecked() {
 		AppendAuthenticationLog(s.mock.Ctx, gomock.Any()).
 		Return(nil)
 
+	s.mock.StorageMock.
+		EXPECT().
+		LoadKnownLoginSummary(s.mock.Ctx, "test", gomock.Any(), gomock.Any()).
+		Return(&model.KnownLoginSummary{}, nil)
+
+	s.mock.StorageMock.
+		EXPECT().
+		SaveKnownLogin(s.mock.Ctx, gomock.Any()).
+		Return(nil)
+
 	s.mock.Ctx.Request.SetBodyString(`{
 		"username": "test",
 		"password": "hello",
@@ -242,6 +253,16 @@ func (s *FirstFactorSuite) TestShouldAuthenticateUserWithRememberMeUnchecked() {
 		AppendAuthenticationLog(s.mock.Ctx, gomock.Any()).
 		Return(nil)
 
+	s.mock.StorageMock.
+		EXPECT().
+		LoadKnownLoginSummary(s.mock.Ctx, "test", gomock.Any(), gomock.Any()).
+		Return(&model.KnownLoginSummary{}, nil)
+
+	s.mock.StorageMock.
+		EXPECT().
+		SaveKnownLogin(s.mock.Ctx, gomock.Any()).
+		Return(nil)
+
 	s.mock.Ctx.Request.SetBodyString(`{
 		"username": "test",
 		"password": "hello",
@@ -287,6 +308,16 @@ func (s *FirstFactorSuite) TestShouldSaveUsernameFromAuthenticationBackendInSess
 		AppendAuthenticationLog(s.mock.Ctx, gomock.Any()).
 		Return(nil)
 
+	s.mock.StorageMock.
+		EXPECT().
+		LoadKnownLoginSummary(s.mock.Ctx, "Test", gomock.Any(), gomock.Any()).
+		Return(&model.KnownLoginSummary{}, nil)
+
+	s.mock.StorageMock.
+		EXPECT().
+		SaveKnownLogin(s.mock.Ctx, gomock.Any()).
+		Return(nil)
+
 	s.mock.Ctx.Request.SetBodyString(`{
 		"username": "test",
 		"password": "hello",
@@ -309,6 +340,86 @@ func (s *FirstFactorSuite) TestShouldSaveUsernameFromAuthenticationBackendInSess
 	assert.Equal(s.T(), []string{"dev", "admins"}, userSession.Groups)
 }
 
+func (s *FirstFactorSuite) TestShouldNotifyUserOfNewLogin() {
+	s.mock.UserProviderMock.
+		EXPECT().
+		CheckUserPassword(gomock.Eq("test"), gomock.Eq("hello")).
+		Return(true, nil)
+
+	s.mock.UserProviderMock.
+		EXPECT().
+		GetDetails(gomock.Eq("test")).
+		Return(&authentication.UserDetails{
+			Username:    "test",
+			DisplayName: "Test",
+			Emails:      []string{"test@example.com"},
+			Groups:      []string{"dev", "admins"},
+		}, nil).
+		Times(2)
+
+	s.mock.StorageMock.
+		EXPECT().
+		AppendAuthenticationLog(s.mock.Ctx, gomock.Any()).
+		Return(nil)
+
+	s.mock.StorageMock.
+		EXPECT().
+		LoadKnownLoginSummary(s.mock.Ctx, "test", gomock.Any(), gomock.Any()).
+		Return(&model.KnownLoginSummary{Total: 2, RemoteIP: 0, UserAgent: 2}, nil)
+
+	s.mock.StorageMock.
+		EXPECT().
+		SaveKnownLogin(s.mock.Ctx, gomock.Any()).
+		Return(nil)
+
+	s.mock.NotifierMock.
+		EXPECT().
+		Send(s.mock.Ctx, mail.Address{Name: "Test", Address: "test@example.com"}, "New Login", gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	s.mock.Ctx.Request.SetBodyString(`{
+		"username": "test",
+		"password": "hello",
+		"keepMeLoggedIn": false
+	}`)
+	FirstFactorPOST(nil)(s.mock.Ctx)
+
+	assert.Equal(s.T(), fasthttp.StatusOK, s.mock.Ctx.Response.StatusCode())
+	assert.Equal(s.T(), []byte("{\"status\":\"OK\"}"), s.mock.Ctx.Response.Body())
+}
+
+func (s *FirstFactorSuite) TestShouldNotTrackKnownLoginsWhenNewLoginNotificationDisabled() {
+	s.mock.Ctx.Configuration.Notifier.Events.NewLogin.Disable = true
+
+	s.mock.UserProviderMock.
+		EXPECT().
+		CheckUserPassword(gomock.Eq("test"), gomock.Eq("hello")).
+		Return(true, nil)
+
+	s.mock.UserProviderMock.
+		EXPECT().
+		GetDetails(gomock.Eq("test")).
+		Return(&authentication.UserDetails{
+			Username: "test",
+			Emails:   []string{"test@example.com"},
+			Groups:   []string{"dev", "admins"},
+		}, nil)
+
+	s.mock.StorageMock.
+		EXPECT().
+		AppendAuthenticationLog(s.mock.Ctx, gomock.Any()).
+		Return(nil)
+
+	s.mock.Ctx.Request.SetBodyString(`{
+		"username": "test",
+		"password": "hello",
+		"keepMeLoggedIn": false
+	}`)
+	FirstFactorPOST(nil)(s.mock.Ctx)
+
+	assert.Equal(s.T(), fasthttp.StatusOK, s.mock.Ctx.Response.StatusCode())
+}
+
 type FirstFactorRedirectionSuite struct {
 	suite.Suite
 
@@ -345,6 +456,16 @@ func (s *FirstFactorRedirectionSuite) SetupTest() {
 		EXPECT().
 		AppendAuthenticationLog(s.mock.Ctx, gomock.Any()).
 		Return(nil)
+
+	s.mock.StorageMock.
+		EXPECT().
+		LoadKnownLoginSummary(s.mock.Ctx, "test", gomock.Any(), gomock.Any()).
+		Return(&model.KnownLoginSummary{}, nil)
+
+	s.mock.StorageMock.
+		EXPECT().
+		SaveKnownLogin(s.mock.Ctx, gomock.Any()).
+		Return(nil)
 }
 
 func (s *FirstFactorRedirectionSuite) TearDownTest() {
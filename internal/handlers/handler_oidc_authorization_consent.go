@@ -34,7 +34,7 @@ func handleOIDCAuthorizationConsent(ctx *middlewares.AutheliaCtx, issuer *url.UR
 	switch {
 	case userSession.IsAnonymous():
 		handler = handleOIDCAuthorizationConsentNotAuthenticated
-	case authorization.IsAuthLevelSufficient(userSession.AuthenticationLevel, level):
+	case isOIDCAuthenticationSufficient(ctx, client, userSession):
 		if subject, err = ctx.Providers.OpenIDConnect.GetSubject(ctx, client.GetSectorIdentifierURI(), userSession.Username); err != nil {
 			ctx.Logger.Errorf(logFmtErrConsentCantGetSubject, requester.GetID(), client.GetID(), client.GetConsentPolicy(), userSession.Username, client.GetSectorIdentifierURI(), err)
 
@@ -134,7 +134,7 @@ func handleOIDCAuthorizationConsentRedirect(ctx *middlewares.AutheliaCtx, issuer
 	userSession session.UserSession, rw http.ResponseWriter, r *http.Request, requester oauthelia2.AuthorizeRequester) {
 	var location *url.URL
 
-	if client.IsAuthenticationLevelSufficient(userSession.AuthenticationLevel, authorization.Subject{Username: userSession.Username, Groups: userSession.Groups, IP: ctx.RemoteIP()}) {
+	if isOIDCAuthenticationSufficient(ctx, client, userSession) {
 		location, _ = url.ParseRequestURI(issuer.String())
 		location.Path = path.Join(location.Path, oidc.EndpointPathConsent)
 
@@ -147,6 +147,15 @@ func handleOIDCAuthorizationConsentRedirect(ctx *middlewares.AutheliaCtx, issuer
 	} else {
 		location = handleOIDCAuthorizationConsentGetRedirectionURL(ctx, issuer, consent, requester, r.Form)
 
+		policy := client.GetAuthorizationPolicy()
+
+		if amr := policy.GetRequiredAMR(authorization.Subject{Username: userSession.Username, Groups: userSession.Groups, IP: ctx.RemoteIP()}); len(amr) != 0 {
+			query := location.Query()
+			query.Set(queryArgAMR, strings.Join(amr, " "))
+
+			location.RawQuery = query.Encode()
+		}
+
 		ctx.Logger.Debugf(logFmtDbgConsentAuthenticationSufficiency, requester.GetID(), client.GetID(), client.GetConsentPolicy(), userSession.AuthenticationLevel.String(), "insufficient", client.GetAuthorizationPolicyRequiredLevel(authorization.Subject{Username: userSession.Username, Groups: userSession.Groups, IP: ctx.RemoteIP()}))
 	}
 
@@ -263,3 +272,12 @@ func verifyOIDCUserAuthorizedForConsent(ctx *middlewares.AutheliaCtx, client oid
 
 	return nil
 }
+
+// isOIDCAuthenticationSufficient returns true if the user session has both a sufficient authentication level and has
+// used sufficient authentication methods for the client authorization policy.
+func isOIDCAuthenticationSufficient(ctx *middlewares.AutheliaCtx, client oidc.Client, userSession session.UserSession) (sufficient bool) {
+	subject := authorization.Subject{Username: userSession.Username, Groups: userSession.Groups, IP: ctx.RemoteIP()}
+
+	return client.IsAuthenticationLevelSufficient(userSession.AuthenticationLevel, subject) &&
+		client.IsAuthenticationMethodsSufficient(userSession.AuthenticationMethodRefs.MarshalRFC8176(), subject)
+}
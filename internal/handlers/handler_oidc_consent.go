@@ -6,15 +6,16 @@ import (
 	"fmt"
 	"net/url"
 	"path"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 
-	"github.com/authelia/authelia/v4/internal/authorization"
 	"github.com/authelia/authelia/v4/internal/middlewares"
 	"github.com/authelia/authelia/v4/internal/model"
 	"github.com/authelia/authelia/v4/internal/oidc"
 	"github.com/authelia/authelia/v4/internal/session"
+	"github.com/authelia/authelia/v4/internal/templates"
 )
 
 // OpenIDConnectConsentGET handles requests to provide consent for OpenID Connect.
@@ -87,7 +88,7 @@ func OpenIDConnectConsentPOST(ctx *middlewares.AutheliaCtx) {
 		return
 	}
 
-	if !client.IsAuthenticationLevelSufficient(userSession.AuthenticationLevel, authorization.Subject{Username: userSession.Username, Groups: userSession.Groups, IP: ctx.RemoteIP()}) {
+	if !isOIDCAuthenticationSufficient(ctx, client, userSession) {
 		ctx.Logger.Errorf("User '%s' can't consent to authorization request for client with id '%s' as they are not sufficiently authenticated",
 			userSession.Username, consent.ClientID)
 		ctx.SetJSONError(messageOperationFailed)
@@ -127,6 +128,18 @@ func OpenIDConnectConsentPOST(ctx *middlewares.AutheliaCtx) {
 		}
 	}
 
+	var notify bool
+
+	if bodyJSON.Consent && isEventNotificationEnabled(&ctx.Configuration.Notifier.Events, templates.TemplateNameEmailEventOpenIDConnectConsentGranted) {
+		var count int
+
+		if count, err = ctx.Providers.StorageProvider.LoadOAuth2ConsentSessionsAuthorizedCount(ctx, consent.ClientID, consent.Subject.UUID); err != nil {
+			ctx.Logger.WithError(err).Errorf("Consent session with id '%s' for user '%s': failed to determine if the user has previously consented to the client with id '%s'", consent.ChallengeID, userSession.Username, consent.ClientID)
+		} else {
+			notify = count == 0
+		}
+	}
+
 	if err = ctx.Providers.StorageProvider.SaveOAuth2ConsentSessionResponse(ctx, *consent, bodyJSON.Consent); err != nil {
 		ctx.Logger.Errorf("Failed to save the consent session response to the database: %+v", err)
 		ctx.SetJSONError(messageOperationFailed)
@@ -134,6 +147,10 @@ func OpenIDConnectConsentPOST(ctx *middlewares.AutheliaCtx) {
 		return
 	}
 
+	if notify {
+		ctxLogEvent(ctx, userSession.Username, templates.TemplateNameEmailEventOpenIDConnectConsentGranted, eventLogActionConsentGranted, map[string]any{eventLogKeyAction: eventLogActionConsentGranted, eventLogKeyCategory: eventLogCategoryOpenIDConnect, eventLogKeyClient: client.GetName(), eventLogKeyScopes: strings.Join(consent.GrantedScopes, " ")})
+	}
+
 	var (
 		redirectURI *url.URL
 		query       url.Values
@@ -215,7 +232,7 @@ func handleOpenIDConnectConsentGetSessionsAndClient(ctx *middlewares.AutheliaCtx
 		}
 	}
 
-	if !client.IsAuthenticationLevelSufficient(userSession.AuthenticationLevel, authorization.Subject{Username: userSession.Username, Groups: userSession.Groups, IP: ctx.RemoteIP()}) {
+	if !isOIDCAuthenticationSufficient(ctx, client, userSession) {
 		ctx.Logger.Errorf("Unable to perform OpenID Connect Consent for user '%s' and client id '%s': the user is not sufficiently authenticated", userSession.Username, consent.ClientID)
 		ctx.ReplyForbidden()
 
@@ -0,0 +1,292 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-crypt/crypt"
+	"github.com/go-crypt/crypt/algorithm"
+	"github.com/go-crypt/crypt/algorithm/argon2"
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/random"
+	"github.com/authelia/authelia/v4/internal/regulation"
+	"github.com/authelia/authelia/v4/internal/session"
+	"github.com/authelia/authelia/v4/internal/templates"
+)
+
+// RecoveryCodePOST validates a recovery code provided by the user and if it's valid consumes it and marks the session
+// as having completed second factor authentication.
+//
+//nolint:gocyclo
+func RecoveryCodePOST(ctx *middlewares.AutheliaCtx) {
+	var (
+		userSession session.UserSession
+		bodyJSON    bodySignRecoveryCodeRequest
+		codes       []model.RecoveryCode
+		code        *model.RecoveryCode
+		bannedUntil time.Time
+		consumed    bool
+		err         error
+	)
+
+	if userSession, err = ctx.GetSession(); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred validating a recovery code authentication: %s", errStrUserSessionData)
+
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+		ctx.SetJSONError(messageMFAValidationFailed)
+
+		return
+	}
+
+	if userSession.IsAnonymous() {
+		ctx.Logger.WithError(errUserAnonymous).Error("Error occurred validating a recovery code authentication")
+
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+		ctx.SetJSONError(messageMFAValidationFailed)
+
+		return
+	}
+
+	if err = ctx.ParseBody(&bodyJSON); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred validating a recovery code authentication for user '%s': %s", userSession.Username, errStrReqBodyParse)
+
+		ctx.SetStatusCode(fasthttp.StatusBadRequest)
+		ctx.SetJSONError(messageMFAValidationFailed)
+
+		return
+	}
+
+	if bannedUntil, err = ctx.Providers.Regulator.Regulate(ctx, userSession.Username); err != nil {
+		switch {
+		case errors.Is(err, regulation.ErrUserIsBanned), errors.Is(err, regulation.ErrIPIsBanned):
+			_ = markAuthenticationAttempt(ctx, false, &bannedUntil, userSession.Username, regulation.AuthTypeRecoveryCode, nil)
+		case errors.Is(err, regulation.ErrUserIsLocked):
+			_ = markAuthenticationAttempt(ctx, false, &bannedUntil, userSession.Username, regulation.AuthTypeRecoveryCode, err)
+		default:
+			ctx.Logger.WithError(err).Errorf(logFmtErrRegulationFail, regulation.AuthTypeRecoveryCode, userSession.Username)
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+		ctx.SetJSONError(messageMFAValidationFailed)
+
+		return
+	}
+
+	if codes, err = ctx.Providers.StorageProvider.LoadRecoveryCodes(ctx, userSession.Username); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred validating a recovery code authentication for user '%s': error occurred retrieving the recovery codes from the storage backend", userSession.Username)
+
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+		ctx.SetJSONError(messageMFAValidationFailed)
+
+		return
+	}
+
+	input := normalizeRecoveryCode(bodyJSON.Code)
+
+	for i := range codes {
+		var valid bool
+
+		if valid, err = crypt.CheckPassword(input, codes[i].Hash); err != nil {
+			ctx.Logger.WithError(err).Warnf("Error occurred validating a recovery code authentication for user '%s': error occurred decoding the recovery code with id '%d'", userSession.Username, codes[i].ID)
+
+			continue
+		}
+
+		if valid {
+			code = &codes[i]
+
+			break
+		}
+	}
+
+	if code == nil {
+		_ = markAuthenticationAttempt(ctx, false, nil, userSession.Username, regulation.AuthTypeRecoveryCode, fmt.Errorf("the user input did not match any unused recovery code"))
+
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+		ctx.SetJSONError(messageMFAValidationFailed)
+
+		return
+	}
+
+	if consumed, err = ctx.Providers.StorageProvider.ConsumeRecoveryCode(ctx, code.ID, ctx.Clock.Now()); err != nil || !consumed {
+		if err == nil {
+			err = fmt.Errorf("the recovery code was already used")
+		}
+
+		ctx.Logger.WithError(err).Errorf("Error occurred validating a recovery code authentication for user '%s': error occurred marking the recovery code as used in the storage backend", userSession.Username)
+
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+		ctx.SetJSONError(messageMFAValidationFailed)
+
+		return
+	}
+
+	if err = markAuthenticationAttempt(ctx, true, nil, userSession.Username, regulation.AuthTypeRecoveryCode, nil); err != nil {
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+		ctx.SetJSONError(messageMFAValidationFailed)
+
+		return
+	}
+
+	if err = ctx.RegenerateSession(); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred validating a recovery code authentication for user '%s': error regenerating the user session", userSession.Username)
+
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+		ctx.SetJSONError(messageMFAValidationFailed)
+
+		return
+	}
+
+	userSession.SetTwoFactorRecoveryCode(ctx.Clock.Now())
+
+	if err = ctx.SaveSession(userSession); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred validating a recovery code authentication for user '%s': %s", userSession.Username, errStrUserSessionDataSave)
+
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+		ctx.SetJSONError(messageMFAValidationFailed)
+
+		return
+	}
+
+	ctxLogEvent(ctx, userSession.Username, templates.TemplateNameEmailEventRecoveryCodeUsed, eventLogActionRecoveryCodeUsed, map[string]any{eventLogKeyAction: eventLogActionRecoveryCodeUsed, eventLogKeyCategory: eventLogCategoryRecoveryCode, eventLogKeyRemaining: len(codes) - 1})
+
+	if bodyJSON.Workflow == workflowOpenIDConnect {
+		handleOIDCWorkflowResponse(ctx, &userSession, bodyJSON.TargetURL, bodyJSON.WorkflowID)
+	} else {
+		Handle2FAResponse(ctx, bodyJSON.TargetURL)
+	}
+}
+
+// RecoveryCodesPUT generates a new set of recovery codes for the user replacing any existing codes, and returns them in
+// plain text. This is the only time the plain text codes are available.
+func RecoveryCodesPUT(ctx *middlewares.AutheliaCtx) {
+	var (
+		userSession session.UserSession
+		codes       []string
+		err         error
+	)
+
+	if userSession, err = ctx.GetSession(); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred generating recovery codes: %s", errStrUserSessionData)
+
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+		ctx.SetJSONError(messageUnableToGenerateRecoveryCodes)
+
+		return
+	}
+
+	if userSession.IsAnonymous() {
+		ctx.Logger.WithError(errUserAnonymous).Error("Error occurred generating recovery codes")
+
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+		ctx.SetJSONError(messageUnableToGenerateRecoveryCodes)
+
+		return
+	}
+
+	if codes, err = handleRecoveryCodesGenerate(ctx, userSession.Username); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred generating recovery codes for user '%s'", userSession.Username)
+
+		ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+		ctx.SetJSONError(messageUnableToGenerateRecoveryCodes)
+
+		return
+	}
+
+	if err = ctx.SetJSONBody(bodyRecoveryCodesResponse{RecoveryCodes: codes}); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred generating recovery codes for user '%s': %s", userSession.Username, errStrRespBody)
+
+		return
+	}
+
+	ctxLogEvent(ctx, userSession.Username, templates.TemplateNameEmailEventRecoveryCodesGenerated, eventLogActionRecoveryCodesGenerated, map[string]any{eventLogKeyAction: eventLogActionRecoveryCodesGenerated, eventLogKeyCategory: eventLogCategoryRecoveryCode})
+}
+
+// handleRecoveryCodesFirstEnrollment generates recovery codes for a user who has just enrolled a second factor method
+// provided recovery codes are enabled and the user doesn't have any unused recovery codes. The plain text codes are
+// returned so they can be displayed to the user, and are nil if no codes were generated. Errors are logged rather than
+// returned as they must not cause the enrollment itself to fail.
+func handleRecoveryCodesFirstEnrollment(ctx *middlewares.AutheliaCtx, username string) (codes []string) {
+	if ctx.Configuration.RecoveryCodes.Disable {
+		return nil
+	}
+
+	var (
+		existing []model.RecoveryCode
+		err      error
+	)
+
+	if existing, err = ctx.Providers.StorageProvider.LoadRecoveryCodes(ctx, username); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred generating recovery codes for user '%s': error occurred retrieving the recovery codes from the storage backend", username)
+
+		return nil
+	}
+
+	if len(existing) != 0 {
+		return nil
+	}
+
+	if codes, err = handleRecoveryCodesGenerate(ctx, username); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred generating recovery codes for user '%s'", username)
+
+		return nil
+	}
+
+	ctxLogEvent(ctx, username, templates.TemplateNameEmailEventRecoveryCodesGenerated, eventLogActionRecoveryCodesGenerated, map[string]any{eventLogKeyAction: eventLogActionRecoveryCodesGenerated, eventLogKeyCategory: eventLogCategoryRecoveryCode})
+
+	return codes
+}
+
+func handleRecoveryCodesGenerate(ctx *middlewares.AutheliaCtx, username string) (codes []string, err error) {
+	var (
+		hasher *argon2.Hasher
+		digest algorithm.Digest
+	)
+
+	if hasher, err = argon2.New(
+		argon2.WithVariantID(),
+		argon2.WithT(recoveryCodeHashIterations),
+		argon2.WithM(recoveryCodeHashMemory),
+		argon2.WithP(recoveryCodeHashParallelism),
+	); err != nil {
+		return nil, fmt.Errorf("error occurred initializing the hasher: %w", err)
+	}
+
+	n := ctx.Configuration.RecoveryCodes.Count
+	if n <= 0 {
+		n = schema.DefaultRecoveryCodesConfiguration.Count
+	}
+
+	now := ctx.Clock.Now()
+
+	codes = make([]string, n)
+	records := make([]model.RecoveryCode, n)
+
+	for i := 0; i < n; i++ {
+		value := ctx.Providers.Random.StringCustom(recoveryCodeLength, random.CharSetUnambiguousUpper)
+
+		if digest, err = hasher.Hash(value); err != nil {
+			return nil, fmt.Errorf("error occurred hashing a recovery code: %w", err)
+		}
+
+		codes[i] = fmt.Sprintf("%s-%s", value[:recoveryCodeLength/2], value[recoveryCodeLength/2:])
+		records[i] = model.RecoveryCode{CreatedAt: now, Username: username, Hash: digest.Encode()}
+	}
+
+	if err = ctx.Providers.StorageProvider.SaveRecoveryCodes(ctx, username, records); err != nil {
+		return nil, fmt.Errorf("error occurred saving the recovery codes to the storage backend: %w", err)
+	}
+
+	return codes, nil
+}
+
+// normalizeRecoveryCode removes formatting characters the user may have entered alongside a recovery code.
+func normalizeRecoveryCode(code string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(code)))
+}
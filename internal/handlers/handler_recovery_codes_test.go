@@ -0,0 +1,300 @@
+package handlers
+
+import (
+	"fmt"
+	"net/mail"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/go-crypt/crypt/algorithm/argon2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+	"go.uber.org/mock/gomock"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/mocks"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/regulation"
+)
+
+func TestNormalizeRecoveryCode(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     string
+		expected string
+	}{
+		{"ShouldNotModifyNormalized", "ABCDE23467", "ABCDE23467"},
+		{"ShouldRemoveSeparator", "ABCDE-23467", "ABCDE23467"},
+		{"ShouldUppercase", "abcde-23467", "ABCDE23467"},
+		{"ShouldRemoveSpaces", " abcde 23467 ", "ABCDE23467"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, normalizeRecoveryCode(tc.have))
+		})
+	}
+}
+
+func TestRecoveryCodePOST(t *testing.T) {
+	hasher, err := argon2.New(argon2.WithVariantID(), argon2.WithT(recoveryCodeHashIterations), argon2.WithM(recoveryCodeHashMemory), argon2.WithP(recoveryCodeHashParallelism))
+	require.NoError(t, err)
+
+	digest, err := hasher.Hash("ABCDE23467")
+	require.NoError(t, err)
+
+	codes := []model.RecoveryCode{{ID: 1, Username: testUsername, Hash: digest.Encode()}}
+
+	testCases := []struct {
+		name           string
+		anonymous      bool
+		have           string
+		setup          func(t *testing.T, mock *mocks.MockAutheliaCtx)
+		expected       string
+		expectedStatus int
+		expectedf      func(t *testing.T, mock *mocks.MockAutheliaCtx)
+	}{
+		{
+			"ShouldFailAnonymous",
+			true,
+			`{"code":"ABCDE-23467"}`,
+			nil,
+			`{"status":"KO","message":"Authentication failed, please retry later."}`,
+			fasthttp.StatusForbidden,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				AssertLogEntryMessageAndError(t, mock.Hook.LastEntry(), "Error occurred validating a recovery code authentication", "user is anonymous")
+			},
+		},
+		{
+			"ShouldFailBadBody",
+			false,
+			`not json`,
+			nil,
+			`{"status":"KO","message":"Authentication failed, please retry later."}`,
+			fasthttp.StatusBadRequest,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				AssertLogEntryMessageAndError(t, mock.Hook.LastEntry(), "Error occurred validating a recovery code authentication for user 'john': error parsing the request body", "unable to parse body: invalid character 'o' in literal null (expecting 'u')")
+			},
+		},
+		{
+			"ShouldFailLoadError",
+			false,
+			`{"code":"ABCDE-23467"}`,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				mock.StorageMock.EXPECT().
+					LoadRecoveryCodes(mock.Ctx, testUsername).
+					Return(nil, fmt.Errorf("failed to connect"))
+			},
+			`{"status":"KO","message":"Authentication failed, please retry later."}`,
+			fasthttp.StatusForbidden,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				AssertLogEntryMessageAndError(t, mock.Hook.LastEntry(), "Error occurred validating a recovery code authentication for user 'john': error occurred retrieving the recovery codes from the storage backend", "failed to connect")
+			},
+		},
+		{
+			"ShouldFailInvalidCode",
+			false,
+			`{"code":"ABCDE-23468"}`,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				gomock.InOrder(
+					mock.StorageMock.EXPECT().
+						LoadRecoveryCodes(mock.Ctx, testUsername).
+						Return(codes, nil),
+					mock.StorageMock.EXPECT().
+						AppendAuthenticationLog(mock.Ctx, model.AuthenticationAttempt{
+							Username:   testUsername,
+							Successful: false,
+							Time:       mock.Clock.Now(),
+							Type:       regulation.AuthTypeRecoveryCode,
+							RemoteIP:   model.NewNullIPFromString("0.0.0.0"),
+						}).
+						Return(nil),
+				)
+			},
+			`{"status":"KO","message":"Authentication failed, please retry later."}`,
+			fasthttp.StatusForbidden,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				AssertLogEntryMessageAndError(t, mock.Hook.LastEntry(), "Unsuccessful Recovery authentication attempt by user 'john'", "the user input did not match any unused recovery code")
+			},
+		},
+		{
+			"ShouldFailAlreadyConsumed",
+			false,
+			`{"code":"ABCDE-23467"}`,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				gomock.InOrder(
+					mock.StorageMock.EXPECT().
+						LoadRecoveryCodes(mock.Ctx, testUsername).
+						Return(codes, nil),
+					mock.StorageMock.EXPECT().
+						ConsumeRecoveryCode(mock.Ctx, 1, mock.Clock.Now()).
+						Return(false, nil),
+				)
+			},
+			`{"status":"KO","message":"Authentication failed, please retry later."}`,
+			fasthttp.StatusForbidden,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				AssertLogEntryMessageAndError(t, mock.Hook.LastEntry(), "Error occurred validating a recovery code authentication for user 'john': error occurred marking the recovery code as used in the storage backend", "the recovery code was already used")
+			},
+		},
+		{
+			"ShouldSucceed",
+			false,
+			`{"code":"abcde-23467"}`,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				gomock.InOrder(
+					mock.StorageMock.EXPECT().
+						LoadRecoveryCodes(mock.Ctx, testUsername).
+						Return(codes, nil),
+					mock.StorageMock.EXPECT().
+						ConsumeRecoveryCode(mock.Ctx, 1, mock.Clock.Now()).
+						Return(true, nil),
+					mock.StorageMock.EXPECT().
+						AppendAuthenticationLog(mock.Ctx, model.AuthenticationAttempt{
+							Username:   testUsername,
+							Successful: true,
+							Time:       mock.Clock.Now(),
+							Type:       regulation.AuthTypeRecoveryCode,
+							RemoteIP:   model.NewNullIPFromString("0.0.0.0"),
+						}).
+						Return(nil),
+					mock.UserProviderMock.EXPECT().
+						GetDetails(testUsername).
+						Return(&authentication.UserDetails{Username: testUsername, DisplayName: testDisplayName, Emails: []string{"john@example.com"}}, nil),
+					mock.NotifierMock.EXPECT().
+						Send(mock.Ctx, mail.Address{Name: testDisplayName, Address: "john@example.com"}, "Recovery Code Used", gomock.Any(), gomock.Any()).
+						Return(nil),
+				)
+			},
+			`{"status":"OK"}`,
+			fasthttp.StatusOK,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				us, err := mock.Ctx.GetSession()
+
+				require.NoError(t, err)
+
+				assert.Equal(t, authentication.TwoFactor, us.AuthenticationLevel)
+				assert.True(t, us.AuthenticationMethodRefs.RecoveryCode)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := mocks.NewMockAutheliaCtx(t)
+
+			defer mock.Close()
+
+			mock.Clock.Set(time.Unix(1701295903, 0))
+			mock.Ctx.Clock = &mock.Clock
+
+			if !tc.anonymous {
+				us, err := mock.Ctx.GetSession()
+
+				require.NoError(t, err)
+
+				us.Username = testUsername
+				us.AuthenticationLevel = authentication.OneFactor
+
+				require.NoError(t, mock.Ctx.SaveSession(us))
+			}
+
+			if tc.setup != nil {
+				tc.setup(t, mock)
+			}
+
+			mock.Ctx.Request.SetBodyString(tc.have)
+
+			RecoveryCodePOST(mock.Ctx)
+
+			assert.Equal(t, tc.expectedStatus, mock.Ctx.Response.StatusCode())
+			assert.Equal(t, tc.expected, string(mock.Ctx.Response.Body()))
+
+			if tc.expectedf != nil {
+				tc.expectedf(t, mock)
+			}
+		})
+	}
+}
+
+func TestHandleRecoveryCodesFirstEnrollment(t *testing.T) {
+	testCases := []struct {
+		name     string
+		disable  bool
+		setup    func(t *testing.T, mock *mocks.MockAutheliaCtx)
+		expected int
+	}{
+		{
+			"ShouldNotGenerateWhenDisabled",
+			true,
+			nil,
+			0,
+		},
+		{
+			"ShouldNotGenerateWhenUnusedCodesExist",
+			false,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				mock.StorageMock.EXPECT().
+					LoadRecoveryCodes(mock.Ctx, testUsername).
+					Return([]model.RecoveryCode{{ID: 1, Username: testUsername}}, nil)
+			},
+			0,
+		},
+		{
+			"ShouldNotGenerateWhenLoadFails",
+			false,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				mock.StorageMock.EXPECT().
+					LoadRecoveryCodes(mock.Ctx, testUsername).
+					Return(nil, fmt.Errorf("failed to connect"))
+			},
+			0,
+		},
+		{
+			"ShouldGenerate",
+			false,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				gomock.InOrder(
+					mock.StorageMock.EXPECT().
+						LoadRecoveryCodes(mock.Ctx, testUsername).
+						Return(nil, nil),
+					mock.StorageMock.EXPECT().
+						SaveRecoveryCodes(mock.Ctx, testUsername, gomock.Len(4)).
+						Return(nil),
+					mock.UserProviderMock.EXPECT().
+						GetDetails(testUsername).
+						Return(&authentication.UserDetails{Username: testUsername, DisplayName: testDisplayName, Emails: []string{"john@example.com"}}, nil),
+					mock.NotifierMock.EXPECT().
+						Send(mock.Ctx, mail.Address{Name: testDisplayName, Address: "john@example.com"}, "Recovery Codes Generated", gomock.Any(), gomock.Any()).
+						Return(nil),
+				)
+			},
+			4,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := mocks.NewMockAutheliaCtx(t)
+
+			defer mock.Close()
+
+			mock.Ctx.Configuration.RecoveryCodes.Disable = tc.disable
+			mock.Ctx.Configuration.RecoveryCodes.Count = 4
+
+			if tc.setup != nil {
+				tc.setup(t, mock)
+			}
+
+			codes := handleRecoveryCodesFirstEnrollment(mock.Ctx, testUsername)
+
+			require.Len(t, codes, tc.expected)
+
+			for _, code := range codes {
+				assert.Regexp(t, regexp.MustCompile(`^[A-Z2-9]{5}-[A-Z2-9]{5}$`), code)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
 	"net/url"
 	"strings"
@@ -9,6 +10,8 @@ import (
 	"github.com/authelia/authelia/v4/internal/middlewares"
 	"github.com/authelia/authelia/v4/internal/model"
 	"github.com/authelia/authelia/v4/internal/session"
+	"github.com/authelia/authelia/v4/internal/storage"
+	"github.com/authelia/authelia/v4/internal/templates"
 	"github.com/authelia/authelia/v4/internal/utils"
 )
 
@@ -112,6 +115,13 @@ func DuoDevicePOST(ctx *middlewares.AutheliaCtx) {
 		return
 	}
 
+	var device *model.DuoDevice
+
+	if device, err = ctx.Providers.StorageProvider.LoadPreferredDuoDevice(ctx, userSession.Username); err != nil && !errors.Is(err, storage.ErrNoDuoDevice) {
+		ctx.Error(fmt.Errorf("unable to load preferred Duo device and method: %w", err), messageMFAValidationFailed)
+		return
+	}
+
 	ctx.Logger.Debugf("Save new preferred Duo device and method of user %s to %s using %s", userSession.Username, bodyJSON.Device, bodyJSON.Method)
 	err = ctx.Providers.StorageProvider.SavePreferredDuoDevice(ctx, model.DuoDevice{Username: userSession.Username, Device: bodyJSON.Device, Method: bodyJSON.Method})
 
@@ -120,6 +130,10 @@ func DuoDevicePOST(ctx *middlewares.AutheliaCtx) {
 		return
 	}
 
+	if device != nil && (device.Device != bodyJSON.Device || device.Method != bodyJSON.Method) {
+		ctxLogEvent(ctx, userSession.Username, templates.TemplateNameEmailEventDuoDeviceChanged, eventLogActionDuoDeviceChanged, map[string]any{eventLogKeyAction: eventLogActionDuoDeviceChanged, eventLogKeyCategory: eventLogCategoryDuo, eventLogKeyDevice: bodyJSON.Device, eventLogKeyMethod: bodyJSON.Method})
+	}
+
 	ctx.ReplyOK()
 }
 
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"fmt"
+	"net/mail"
 	"net/url"
 	"testing"
 
@@ -11,10 +12,12 @@ import (
 	"github.com/valyala/fasthttp"
 	"go.uber.org/mock/gomock"
 
+	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/duo"
 	"github.com/authelia/authelia/v4/internal/mocks"
 	"github.com/authelia/authelia/v4/internal/model"
 	"github.com/authelia/authelia/v4/internal/session"
+	"github.com/authelia/authelia/v4/internal/storage"
 )
 
 type RegisterDuoDeviceSuite struct {
@@ -131,6 +134,9 @@ func (s *RegisterDuoDeviceSuite) TestShouldRespondWithDeny() {
 
 func (s *RegisterDuoDeviceSuite) TestShouldRespondOK() {
 	s.mock.Ctx.Request.SetBodyString("{\"device\":\"1234567890123456\", \"method\":\"push\"}")
+	s.mock.StorageMock.EXPECT().
+		LoadPreferredDuoDevice(gomock.Eq(s.mock.Ctx), gomock.Eq("john")).
+		Return(nil, storage.ErrNoDuoDevice)
 	s.mock.StorageMock.EXPECT().
 		SavePreferredDuoDevice(gomock.Eq(s.mock.Ctx), gomock.Eq(model.DuoDevice{Username: "john", Device: "1234567890123456", Method: "push"})).
 		Return(nil)
@@ -140,6 +146,26 @@ func (s *RegisterDuoDeviceSuite) TestShouldRespondOK() {
 	assert.Equal(s.T(), fasthttp.StatusOK, s.mock.Ctx.Response.StatusCode())
 }
 
+func (s *RegisterDuoDeviceSuite) TestShouldRespondOKAndNotifyOnChange() {
+	s.mock.Ctx.Request.SetBodyString("{\"device\":\"1234567890123456\", \"method\":\"push\"}")
+	s.mock.StorageMock.EXPECT().
+		LoadPreferredDuoDevice(gomock.Eq(s.mock.Ctx), gomock.Eq("john")).
+		Return(&model.DuoDevice{Username: "john", Device: "6543210987654321", Method: "push"}, nil)
+	s.mock.StorageMock.EXPECT().
+		SavePreferredDuoDevice(gomock.Eq(s.mock.Ctx), gomock.Eq(model.DuoDevice{Username: "john", Device: "1234567890123456", Method: "push"})).
+		Return(nil)
+	s.mock.UserProviderMock.EXPECT().
+		GetDetails("john").
+		Return(&authentication.UserDetails{Username: "john", DisplayName: "John Smith", Emails: []string{"john@example.com"}}, nil)
+	s.mock.NotifierMock.EXPECT().
+		Send(s.mock.Ctx, mail.Address{Name: "John Smith", Address: "john@example.com"}, "Duo Device Changed", gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	DuoDevicePOST(s.mock.Ctx)
+
+	assert.Equal(s.T(), fasthttp.StatusOK, s.mock.Ctx.Response.StatusCode())
+}
+
 func (s *RegisterDuoDeviceSuite) TestShouldRespondKOOnInvalidMethod() {
 	s.mock.Ctx.Request.SetBodyString("{\"device\":\"1234567890123456\", \"method\":\"testfailure\"}")
 
@@ -11,6 +11,7 @@ import (
 	"github.com/authelia/authelia/v4/internal/model"
 	"github.com/authelia/authelia/v4/internal/regulation"
 	"github.com/authelia/authelia/v4/internal/session"
+	"github.com/authelia/authelia/v4/internal/templates"
 	"github.com/authelia/authelia/v4/internal/utils"
 )
 
@@ -196,6 +197,7 @@ func TOTPRegisterPOST(ctx *middlewares.AutheliaCtx) {
 		CreatedAt: ctx.Clock.Now(),
 		Username:  userSession.Username,
 		Issuer:    userSession.TOTP.Issuer,
+		Type:      model.OneTimePasswordTypeTOTP,
 		Algorithm: userSession.TOTP.Algorithm,
 		Period:    userSession.TOTP.Period,
 		Digits:    userSession.TOTP.Digits,
@@ -221,7 +223,7 @@ func TOTPRegisterPOST(ctx *middlewares.AutheliaCtx) {
 	}
 
 	if !ctx.Configuration.TOTP.DisableReuseSecurityPolicy {
-		if err = ctx.Providers.StorageProvider.SaveTOTPHistory(ctx, userSession.Username, step*uint64(config.Period)); err != nil {
+		if err = ctx.Providers.StorageProvider.SaveTOTPHistory(ctx, userSession.Username, config.HistoryStep(step)); err != nil {
 			ctx.Logger.WithError(err).Errorf("Error occurred validating a TOTP registration session for user '%s': error occurred saving the TOTP history to the storage backend", userSession.Username)
 
 			ctx.SetStatusCode(fasthttp.StatusForbidden)
@@ -251,7 +253,15 @@ func TOTPRegisterPOST(ctx *middlewares.AutheliaCtx) {
 		return
 	}
 
-	ctxLogEvent(ctx, userSession.Username, eventLogAction2FAAdded, map[string]any{eventLogKeyAction: eventLogAction2FAAdded, eventLogKeyCategory: eventLogCategoryOneTimePassword})
+	ctxLogEvent(ctx, userSession.Username, templates.TemplateNameEmailEventOneTimePasswordAdded, eventLogAction2FAAdded, map[string]any{eventLogKeyAction: eventLogAction2FAAdded, eventLogKeyCategory: eventLogCategoryOneTimePassword})
+
+	if codes := handleRecoveryCodesFirstEnrollment(ctx, userSession.Username); len(codes) != 0 {
+		if err = ctx.SetJSONBody(bodyRecoveryCodesResponse{RecoveryCodes: codes}); err != nil {
+			ctx.Logger.WithError(err).Errorf("Error occurred validating a TOTP registration session for user '%s': %s", userSession.Username, errStrRespBody)
+		}
+
+		return
+	}
 
 	ctx.ReplyOK()
 }
@@ -344,7 +354,7 @@ func TOTPConfigurationDELETE(ctx *middlewares.AutheliaCtx) {
 		return
 	}
 
-	ctxLogEvent(ctx, userSession.Username, eventLogAction2FARemoved, map[string]any{eventLogKeyAction: eventLogAction2FARemoved, eventLogKeyCategory: eventLogCategoryOneTimePassword})
+	ctxLogEvent(ctx, userSession.Username, templates.TemplateNameEmailEventOneTimePasswordRemoved, eventLogAction2FARemoved, map[string]any{eventLogKeyAction: eventLogAction2FARemoved, eventLogKeyCategory: eventLogCategoryOneTimePassword})
 
 	ctx.ReplyOK()
 }
@@ -506,7 +506,7 @@ func TestTOTPRegisterPOST(t *testing.T) {
 				gomock.InOrder(
 					mock.TOTPMock.
 						EXPECT().
-						Validate(mock.Ctx, "012345", &model.TOTPConfiguration{CreatedAt: mock.Clock.Now(), Username: testUsername, Issuer: "abc", Algorithm: "SHA1", Period: 30, Digits: 6, Secret: []byte(testBASE32TOTPSecret)}).
+						Validate(mock.Ctx, "012345", &model.TOTPConfiguration{CreatedAt: mock.Clock.Now(), Username: testUsername, Issuer: "abc", Type: model.OneTimePasswordTypeTOTP, Algorithm: "SHA1", Period: 30, Digits: 6, Secret: []byte(testBASE32TOTPSecret)}).
 						Return(false, uint64(0), nil),
 				)
 			},
@@ -541,7 +541,7 @@ func TestTOTPRegisterPOST(t *testing.T) {
 				gomock.InOrder(
 					mock.TOTPMock.
 						EXPECT().
-						Validate(mock.Ctx, "012345", &model.TOTPConfiguration{CreatedAt: mock.Clock.Now(), Username: testUsername, Issuer: "abc", Algorithm: "SHA1", Period: 30, Digits: 6, Secret: []byte(testBASE32TOTPSecret)}).
+						Validate(mock.Ctx, "012345", &model.TOTPConfiguration{CreatedAt: mock.Clock.Now(), Username: testUsername, Issuer: "abc", Type: model.OneTimePasswordTypeTOTP, Algorithm: "SHA1", Period: 30, Digits: 6, Secret: []byte(testBASE32TOTPSecret)}).
 						Return(false, uint64(0), fmt.Errorf("pink staple")),
 				)
 			},
@@ -576,7 +576,7 @@ func TestTOTPRegisterPOST(t *testing.T) {
 				gomock.InOrder(
 					mock.TOTPMock.
 						EXPECT().
-						Validate(mock.Ctx, "012345", &model.TOTPConfiguration{CreatedAt: mock.Clock.Now(), Username: testUsername, Issuer: "abc", Algorithm: "SHA1", Period: 30, Digits: 6, Secret: []byte(testBASE32TOTPSecret)}).
+						Validate(mock.Ctx, "012345", &model.TOTPConfiguration{CreatedAt: mock.Clock.Now(), Username: testUsername, Issuer: "abc", Type: model.OneTimePasswordTypeTOTP, Algorithm: "SHA1", Period: 30, Digits: 6, Secret: []byte(testBASE32TOTPSecret)}).
 						Return(true, getStepTOTP(mock.Ctx, -1), nil),
 					mock.StorageMock.
 						EXPECT().
@@ -584,7 +584,7 @@ func TestTOTPRegisterPOST(t *testing.T) {
 						Return(nil),
 					mock.StorageMock.
 						EXPECT().
-						SaveTOTPConfiguration(mock.Ctx, model.TOTPConfiguration{CreatedAt: mock.Clock.Now(), Username: testUsername, Issuer: "abc", Algorithm: "SHA1", Period: 30, Digits: 6, Secret: []byte(testBASE32TOTPSecret)}).
+						SaveTOTPConfiguration(mock.Ctx, model.TOTPConfiguration{CreatedAt: mock.Clock.Now(), Username: testUsername, Issuer: "abc", Type: model.OneTimePasswordTypeTOTP, Algorithm: "SHA1", Period: 30, Digits: 6, Secret: []byte(testBASE32TOTPSecret)}).
 						Return(nil),
 					mock.UserProviderMock.
 						EXPECT().
@@ -636,11 +636,11 @@ func TestTOTPRegisterPOST(t *testing.T) {
 				gomock.InOrder(
 					mock.TOTPMock.
 						EXPECT().
-						Validate(mock.Ctx, "012345", &model.TOTPConfiguration{CreatedAt: mock.Clock.Now(), Username: testUsername, Issuer: "abc", Algorithm: "SHA1", Period: 30, Digits: 6, Secret: []byte(testBASE32TOTPSecret)}).
+						Validate(mock.Ctx, "012345", &model.TOTPConfiguration{CreatedAt: mock.Clock.Now(), Username: testUsername, Issuer: "abc", Type: model.OneTimePasswordTypeTOTP, Algorithm: "SHA1", Period: 30, Digits: 6, Secret: []byte(testBASE32TOTPSecret)}).
 						Return(true, getStepTOTP(mock.Ctx, -1), nil),
 					mock.StorageMock.
 						EXPECT().
-						SaveTOTPConfiguration(mock.Ctx, model.TOTPConfiguration{CreatedAt: mock.Clock.Now(), Username: testUsername, Issuer: "abc", Algorithm: "SHA1", Period: 30, Digits: 6, Secret: []byte(testBASE32TOTPSecret)}).
+						SaveTOTPConfiguration(mock.Ctx, model.TOTPConfiguration{CreatedAt: mock.Clock.Now(), Username: testUsername, Issuer: "abc", Type: model.OneTimePasswordTypeTOTP, Algorithm: "SHA1", Period: 30, Digits: 6, Secret: []byte(testBASE32TOTPSecret)}).
 						Return(nil),
 					mock.UserProviderMock.
 						EXPECT().
@@ -681,7 +681,7 @@ func TestTOTPRegisterPOST(t *testing.T) {
 				gomock.InOrder(
 					mock.TOTPMock.
 						EXPECT().
-						Validate(mock.Ctx, "012345", &model.TOTPConfiguration{CreatedAt: mock.Clock.Now(), Username: testUsername, Issuer: "abc", Algorithm: "SHA1", Period: 30, Digits: 6, Secret: []byte(testBASE32TOTPSecret)}).
+						Validate(mock.Ctx, "012345", &model.TOTPConfiguration{CreatedAt: mock.Clock.Now(), Username: testUsername, Issuer: "abc", Type: model.OneTimePasswordTypeTOTP, Algorithm: "SHA1", Period: 30, Digits: 6, Secret: []byte(testBASE32TOTPSecret)}).
 						Return(true, getStepTOTP(mock.Ctx, -1), nil),
 					mock.StorageMock.
 						EXPECT().
@@ -689,7 +689,7 @@ func TestTOTPRegisterPOST(t *testing.T) {
 						Return(nil),
 					mock.StorageMock.
 						EXPECT().
-						SaveTOTPConfiguration(mock.Ctx, model.TOTPConfiguration{CreatedAt: mock.Clock.Now(), Username: testUsername, Issuer: "abc", Algorithm: "SHA1", Period: 30, Digits: 6, Secret: []byte(testBASE32TOTPSecret)}).
+						SaveTOTPConfiguration(mock.Ctx, model.TOTPConfiguration{CreatedAt: mock.Clock.Now(), Username: testUsername, Issuer: "abc", Type: model.OneTimePasswordTypeTOTP, Algorithm: "SHA1", Period: 30, Digits: 6, Secret: []byte(testBASE32TOTPSecret)}).
 						Return(nil),
 					mock.UserProviderMock.
 						EXPECT().
@@ -732,7 +732,7 @@ func TestTOTPRegisterPOST(t *testing.T) {
 				gomock.InOrder(
 					mock.TOTPMock.
 						EXPECT().
-						Validate(mock.Ctx, "012345", &model.TOTPConfiguration{CreatedAt: mock.Ctx.Clock.Now(), Username: testUsername, Issuer: "abc", Algorithm: "SHA1", Period: 30, Digits: 6, Secret: []byte(testBASE32TOTPSecret)}).
+						Validate(mock.Ctx, "012345", &model.TOTPConfiguration{CreatedAt: mock.Ctx.Clock.Now(), Username: testUsername, Issuer: "abc", Type: model.OneTimePasswordTypeTOTP, Algorithm: "SHA1", Period: 30, Digits: 6, Secret: []byte(testBASE32TOTPSecret)}).
 						Return(true, getStepTOTP(mock.Ctx, -1), nil),
 					mock.StorageMock.
 						EXPECT().
@@ -740,7 +740,7 @@ func TestTOTPRegisterPOST(t *testing.T) {
 						Return(nil),
 					mock.StorageMock.
 						EXPECT().
-						SaveTOTPConfiguration(mock.Ctx, model.TOTPConfiguration{CreatedAt: mock.Clock.Now(), Username: testUsername, Issuer: "abc", Algorithm: "SHA1", Period: 30, Digits: 6, Secret: []byte(testBASE32TOTPSecret)}).
+						SaveTOTPConfiguration(mock.Ctx, model.TOTPConfiguration{CreatedAt: mock.Clock.Now(), Username: testUsername, Issuer: "abc", Type: model.OneTimePasswordTypeTOTP, Algorithm: "SHA1", Period: 30, Digits: 6, Secret: []byte(testBASE32TOTPSecret)}).
 						Return(nil),
 					mock.UserProviderMock.
 						EXPECT().
@@ -779,7 +779,7 @@ func TestTOTPRegisterPOST(t *testing.T) {
 				gomock.InOrder(
 					mock.TOTPMock.
 						EXPECT().
-						Validate(mock.Ctx, "012345", &model.TOTPConfiguration{CreatedAt: mock.Ctx.Clock.Now(), Username: testUsername, Issuer: "abc", Algorithm: "SHA1", Period: 30, Digits: 6, Secret: []byte(testBASE32TOTPSecret)}).
+						Validate(mock.Ctx, "012345", &model.TOTPConfiguration{CreatedAt: mock.Ctx.Clock.Now(), Username: testUsername, Issuer: "abc", Type: model.OneTimePasswordTypeTOTP, Algorithm: "SHA1", Period: 30, Digits: 6, Secret: []byte(testBASE32TOTPSecret)}).
 						Return(true, getStepTOTP(mock.Ctx, -1), nil),
 					mock.StorageMock.
 						EXPECT().
@@ -787,7 +787,7 @@ func TestTOTPRegisterPOST(t *testing.T) {
 						Return(nil),
 					mock.StorageMock.
 						EXPECT().
-						SaveTOTPConfiguration(mock.Ctx, model.TOTPConfiguration{CreatedAt: mock.Clock.Now(), Username: testUsername, Issuer: "abc", Algorithm: "SHA1", Period: 30, Digits: 6, Secret: []byte(testBASE32TOTPSecret)}).
+						SaveTOTPConfiguration(mock.Ctx, model.TOTPConfiguration{CreatedAt: mock.Clock.Now(), Username: testUsername, Issuer: "abc", Type: model.OneTimePasswordTypeTOTP, Algorithm: "SHA1", Period: 30, Digits: 6, Secret: []byte(testBASE32TOTPSecret)}).
 						Return(nil),
 					mock.UserProviderMock.
 						EXPECT().
@@ -826,7 +826,7 @@ func TestTOTPRegisterPOST(t *testing.T) {
 				gomock.InOrder(
 					mock.TOTPMock.
 						EXPECT().
-						Validate(mock.Ctx, "012345", &model.TOTPConfiguration{CreatedAt: mock.Clock.Now(), Username: testUsername, Issuer: "abc", Algorithm: "SHA1", Period: 30, Digits: 6, Secret: []byte(testBASE32TOTPSecret)}).
+						Validate(mock.Ctx, "012345", &model.TOTPConfiguration{CreatedAt: mock.Clock.Now(), Username: testUsername, Issuer: "abc", Type: model.OneTimePasswordTypeTOTP, Algorithm: "SHA1", Period: 30, Digits: 6, Secret: []byte(testBASE32TOTPSecret)}).
 						Return(true, getStepTOTP(mock.Ctx, -1), nil),
 					mock.StorageMock.
 						EXPECT().
@@ -834,7 +834,7 @@ func TestTOTPRegisterPOST(t *testing.T) {
 						Return(nil),
 					mock.StorageMock.
 						EXPECT().
-						SaveTOTPConfiguration(mock.Ctx, model.TOTPConfiguration{CreatedAt: mock.Clock.Now(), Username: testUsername, Issuer: "abc", Algorithm: "SHA1", Period: 30, Digits: 6, Secret: []byte(testBASE32TOTPSecret)}).
+						SaveTOTPConfiguration(mock.Ctx, model.TOTPConfiguration{CreatedAt: mock.Clock.Now(), Username: testUsername, Issuer: "abc", Type: model.OneTimePasswordTypeTOTP, Algorithm: "SHA1", Period: 30, Digits: 6, Secret: []byte(testBASE32TOTPSecret)}).
 						Return(fmt.Errorf("failed to connect")),
 				)
 			},
@@ -853,6 +853,7 @@ func TestTOTPRegisterPOST(t *testing.T) {
 			defer mock.Close()
 
 			mock.Ctx.Configuration.TOTP = tc.config
+			mock.Ctx.Configuration.RecoveryCodes.Disable = true
 			mock.Ctx.Request.SetBodyString(tc.have)
 			mock.Clock.Set(time.Unix(1701295903, 0))
 			mock.Ctx.Clock = &mock.Clock
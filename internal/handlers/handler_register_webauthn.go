@@ -13,6 +13,7 @@ import (
 	"github.com/authelia/authelia/v4/internal/middlewares"
 	"github.com/authelia/authelia/v4/internal/model"
 	"github.com/authelia/authelia/v4/internal/session"
+	"github.com/authelia/authelia/v4/internal/templates"
 )
 
 // WebAuthnRegistrationPUT returns the attestation challenge from the server.
@@ -61,6 +62,15 @@ func WebAuthnRegistrationPUT(ctx *middlewares.AutheliaCtx) {
 		return
 	}
 
+	if bodyJSON.Passkey && !ctx.Configuration.WebAuthn.EnablePasskeyLogin {
+		ctx.Logger.WithError(fmt.Errorf("passkey login is disabled")).Errorf("Error occurred generating a WebAuthn registration challenge for user '%s': error occurred validating the passkey registration", userSession.Username)
+
+		ctx.SetStatusCode(fasthttp.StatusBadRequest)
+		ctx.SetJSONError(messageUnableToRegisterSecurityKey)
+
+		return
+	}
+
 	if w, err = handleNewWebAuthn(ctx); err != nil {
 		ctx.Logger.WithError(err).Errorf("Error occurred generating a WebAuthn registration challenge for user '%s': error occurred provisioning the configuration", userSession.Username)
 
@@ -100,6 +110,15 @@ func WebAuthnRegistrationPUT(ctx *middlewares.AutheliaCtx) {
 		webauthn.WithResidentKeyRequirement(protocol.ResidentKeyRequirementDiscouraged),
 	}
 
+	if bodyJSON.Passkey {
+		// Passkeys are commonly stored by platform authenticators and must be discoverable to allow usernameless login.
+		opts = append(opts, webauthn.WithAuthenticatorSelection(protocol.AuthenticatorSelection{
+			RequireResidentKey: protocol.ResidentKeyRequired(),
+			ResidentKey:        protocol.ResidentKeyRequirementRequired,
+			UserVerification:   w.Config.AuthenticatorSelection.UserVerification,
+		}))
+	}
+
 	data := session.WebAuthn{
 		Description: bodyJSON.Description,
 	}
@@ -219,8 +238,20 @@ func WebAuthnRegistrationPOST(ctx *middlewares.AutheliaCtx) {
 		return
 	}
 
+	var authenticator string
+
+	if authenticator, err = handleWebAuthnCredentialCreationValidate(ctx, response); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred validating a WebAuthn registration challenge for user '%s': the authenticator is not permitted", userSession.Username)
+
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+		ctx.SetJSONError(messageUnableToRegisterSecurityKey)
+
+		return
+	}
+
 	credential := model.NewWebAuthnCredential(ctx, w.Config.RPID, userSession.Username, userSession.WebAuthn.Description, c)
 
+	credential.Model = authenticator
 	credential.Discoverable = handleWebAuthnCredentialCreationIsDiscoverable(ctx, response)
 
 	if err = ctx.Providers.StorageProvider.SaveWebAuthnCredential(ctx, credential); err != nil {
@@ -232,10 +263,17 @@ func WebAuthnRegistrationPOST(ctx *middlewares.AutheliaCtx) {
 		return
 	}
 
-	ctx.ReplyOK()
-	ctx.SetStatusCode(fasthttp.StatusCreated)
+	ctxLogEvent(ctx, userSession.Username, templates.TemplateNameEmailEventWebAuthnCredentialAdded, eventLogAction2FAAdded, map[string]any{eventLogKeyAction: eventLogAction2FAAdded, eventLogKeyCategory: eventLogCategoryWebAuthnCredential, eventLogKeyDescription: credential.Description})
 
-	ctxLogEvent(ctx, userSession.Username, eventLogAction2FAAdded, map[string]any{eventLogKeyAction: eventLogAction2FAAdded, eventLogKeyCategory: eventLogCategoryWebAuthnCredential, eventLogKeyDescription: credential.Description})
+	if codes := handleRecoveryCodesFirstEnrollment(ctx, userSession.Username); len(codes) != 0 {
+		if err = ctx.SetJSONBody(bodyRecoveryCodesResponse{RecoveryCodes: codes}); err != nil {
+			ctx.Logger.WithError(err).Errorf("Error occurred validating a WebAuthn registration challenge for user '%s': %s", userSession.Username, errStrRespBody)
+		}
+	} else {
+		ctx.ReplyOK()
+	}
+
+	ctx.SetStatusCode(fasthttp.StatusCreated)
 }
 
 // WebAuthnRegistrationDELETE deletes any active WebAuthn registration session..
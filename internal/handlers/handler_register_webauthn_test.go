@@ -61,6 +61,61 @@ func TestWebAuthnRegistrationPUT(t *testing.T) {
 			fasthttp.StatusOK,
 			nil,
 		},
+		{
+			"ShouldSuccessPasskey",
+			&schema.WebAuthn{
+				DisplayName:          schema.DefaultWebAuthnConfiguration.DisplayName,
+				Timeout:              schema.DefaultWebAuthnConfiguration.Timeout,
+				ConveyancePreference: schema.DefaultWebAuthnConfiguration.ConveyancePreference,
+				UserVerification:     schema.DefaultWebAuthnConfiguration.UserVerification,
+				EnablePasskeyLogin:   true,
+			},
+			`{"description":"test","passkey":true}`,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				us, err := mock.Ctx.GetSession()
+
+				require.NoError(t, err)
+
+				us.Username = testUsername
+				us.AuthenticationLevel = authentication.OneFactor
+
+				require.NoError(t, mock.Ctx.SaveSession(us))
+
+				gomock.InOrder(
+					mock.StorageMock.
+						EXPECT().
+						LoadWebAuthnUser(mock.Ctx, exampleDotCom, testUsername).
+						Return(&model.WebAuthnUser{ID: 1, RPID: exampleDotCom, Username: testUsername, UserID: "ZytlJlVuWzdgN2BxTyI8Uy9uS2xpJSdsT2ZsJUA5UEBve1c2NENCKDNSWWphaGVCJEhlQ3wpYT9HQGBwIi8zQA=="}, nil),
+					mock.StorageMock.
+						EXPECT().
+						LoadWebAuthnCredentialsByUsername(mock.Ctx, exampleDotCom, testUsername).
+						Return(nil, nil),
+				)
+			},
+			regexp.MustCompile(`^\{"status":"OK","data":\{"publicKey":\{"rp":\{"name":"Authelia","id":"example.com"},"user":\{"name":"john","displayName":"john","id":"ZytlJlVuWzdgN2BxTyI8Uy9uS2xpJSdsT2ZsJUA5UEBve1c2NENCKDNSWWphaGVCJEhlQ3wpYT9HQGBwIi8zQA=="},"challenge":"[a-zA-Z0-9/_-]+={0,2}","pubKeyCredParams":\[\{"type":"public-key","alg":-7},\{"type":"public-key","alg":-35},\{"type":"public-key","alg":-36},\{"type":"public-key","alg":-257},\{"type":"public-key","alg":-258},\{"type":"public-key","alg":-259},\{"type":"public-key","alg":-37},\{"type":"public-key","alg":-38},\{"type":"public-key","alg":-39},\{"type":"public-key","alg":-8}],"timeout":60000,"authenticatorSelection":\{"requireResidentKey":true,"residentKey":"required","userVerification":"preferred"},"attestation":"indirect","extensions":\{"credProps":true}}}}$`),
+			fasthttp.StatusOK,
+			nil,
+		},
+		{
+			"ShouldErrorOnPasskeyWhenPasskeyLoginDisabled",
+			&schema.DefaultWebAuthnConfiguration,
+			`{"description":"test","passkey":true}`,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				us, err := mock.Ctx.GetSession()
+
+				require.NoError(t, err)
+
+				us.Username = testUsername
+				us.AuthenticationLevel = authentication.OneFactor
+
+				require.NoError(t, mock.Ctx.SaveSession(us))
+			},
+			regexp.MustCompile(`^\{"status":"KO","message":"Unable to register your security key."}$`),
+			fasthttp.StatusBadRequest,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				AssertLogEntryMessageAndError(t, mock.Hook.LastEntry(), "Error occurred generating a WebAuthn registration challenge for user 'john': error occurred validating the passkey registration", "passkey login is disabled")
+			},
+		},
 		{
 			"ShouldErrorOnInvalidOrigin",
 			&schema.DefaultWebAuthnConfiguration,
@@ -862,6 +917,60 @@ func TestWebAuthnRegistrationPOST(t *testing.T) {
 				AssertLogEntryMessageAndError(t, mock.Hook.LastEntry(), "Error occurred validating a WebAuthn registration challenge for user 'john': error comparing the response to the WebAuthn session data", "Error validating the authenticator response (verification_error): RP Hash mismatch. Expected 0c6ca0839c3a5683557833f618a2556665df2a088964787d53850b4ad4d3bedc and Received a379a6f6eeafb9a55e378c118034e2751e682fab9f2d30ab13d2125586ce1947")
 			},
 		},
+		{
+			"ShouldFailProhibitedAAGUID",
+			&schema.WebAuthn{
+				DisplayName:          schema.DefaultWebAuthnConfiguration.DisplayName,
+				Timeout:              schema.DefaultWebAuthnConfiguration.Timeout,
+				ConveyancePreference: schema.DefaultWebAuthnConfiguration.ConveyancePreference,
+				UserVerification:     schema.DefaultWebAuthnConfiguration.UserVerification,
+				Filtering: schema.WebAuthnFiltering{
+					ProhibitedAAGUIDs: []string{"01020304-0506-0708-0102-030405060708"},
+				},
+			},
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				us, err := mock.Ctx.GetSession()
+
+				require.NoError(t, err)
+
+				us.Username = testUsername
+				us.AuthenticationLevel = authentication.OneFactor
+				us.WebAuthn = &session.WebAuthn{
+					Description: "test",
+					SessionData: &webauthn.SessionData{
+						Challenge:        "aq_AXdvsDMsKW_1aY31XQhU17ZMg1i0TK013DwukB2U",
+						UserID:           decode("OiRQc3wmemUzdHlkVjhVSk5Pe35YMCRCOklLYzVzIkMpaEglNkF5dnVKRSlTPCJbRDZDP102WXpiYXdNekRiTA=="),
+						Expires:          time.Now().Add(time.Minute),
+						UserVerification: "preferred",
+					},
+				}
+
+				require.NoError(t, mock.Ctx.SaveSession(us))
+
+				gomock.InOrder(
+					mock.StorageMock.
+						EXPECT().
+						LoadWebAuthnUser(mock.Ctx, "login.example.com", testUsername).
+						Return(&model.WebAuthnUser{ID: 1, RPID: "login.example.com", Username: testUsername, UserID: string(decode("OiRQc3wmemUzdHlkVjhVSk5Pe35YMCRCOklLYzVzIkMpaEglNkF5dnVKRSlTPCJbRDZDP102WXpiYXdNekRiTA=="))}, nil),
+					mock.StorageMock.
+						EXPECT().
+						LoadWebAuthnCredentialsByUsername(mock.Ctx, "login.example.com", testUsername).
+						Return(nil, nil),
+				)
+			},
+			dataPOSTGood,
+			`{"status":"KO","message":"Unable to register your security key."}`,
+			fasthttp.StatusForbidden,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				us, err := mock.Ctx.GetSession()
+
+				require.NoError(t, err)
+
+				assert.Nil(t, us.WebAuthn)
+
+				AssertLogEntryMessageAndError(t, mock.Hook.LastEntry(), "Error occurred validating a WebAuthn registration challenge for user 'john': the authenticator is not permitted", "the authenticator with aaguid '01020304-0506-0708-0102-030405060708' is prohibited")
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -874,6 +983,8 @@ func TestWebAuthnRegistrationPOST(t *testing.T) {
 				mock.Ctx.Configuration.WebAuthn = *tc.config
 			}
 
+			mock.Ctx.Configuration.RecoveryCodes.Disable = true
+
 			mock.Ctx.Request.Header.Set("X-Original-URL", "https://login.example.com:8080")
 
 			if tc.setup != nil {
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/regulation"
+)
+
+// RegulationBansGET returns all of the bans which currently apply to users and IPs.
+func RegulationBansGET(ctx *middlewares.AutheliaCtx) {
+	if !isAdministrator(ctx, "regulation", ctx.Configuration.Regulation.Administration.Groups) {
+		return
+	}
+
+	bans, err := ctx.Providers.Regulator.Bans(ctx)
+	if err != nil {
+		ctx.Logger.WithError(err).Error("Error occurred loading the regulation bans")
+
+		ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	response := RegulationBansResponse{Bans: make([]RegulationBan, 0, len(bans))}
+
+	for _, ban := range bans {
+		response.Bans = append(response.Bans, newRegulationBan(ban))
+	}
+
+	if err = ctx.SetJSONBody(response); err != nil {
+		ctx.Logger.WithError(err).Errorf("Unable to set regulation bans response in body")
+	}
+}
+
+// RegulationBanGET returns the bans which currently apply to a user or an IP along with the recent authentication
+// attempts.
+func RegulationBanGET(ctx *middlewares.AutheliaCtx) {
+	if !isAdministrator(ctx, "regulation", ctx.Configuration.Regulation.Administration.Groups) {
+		return
+	}
+
+	value, ok := ctx.UserValue("value").(string)
+	if !ok || len(value) == 0 {
+		ctx.Logger.Error("Error occurred loading the regulation status: the value wasn't set")
+
+		ctx.SetStatusCode(fasthttp.StatusBadRequest)
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	status, err := ctx.Providers.Regulator.Status(ctx, value)
+	if err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred loading the regulation status of '%s'", value)
+
+		ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	response := RegulationStatusResponse{
+		Type:     status.Type,
+		Value:    status.Value,
+		Bans:     make([]RegulationBan, 0, len(status.Bans)),
+		Attempts: make([]RegulationAttempt, 0, len(status.Attempts)),
+	}
+
+	for _, ban := range status.Bans {
+		response.Bans = append(response.Bans, newRegulationBan(ban))
+	}
+
+	for _, attempt := range status.Attempts {
+		a := RegulationAttempt{
+			Time:       attempt.Time,
+			Type:       attempt.Type,
+			Successful: attempt.Successful,
+			Banned:     attempt.Banned,
+			Username:   attempt.Username,
+		}
+
+		if attempt.RemoteIP.IP != nil {
+			a.RemoteIP = attempt.RemoteIP.IP.String()
+		}
+
+		response.Attempts = append(response.Attempts, a)
+	}
+
+	if err = ctx.SetJSONBody(response); err != nil {
+		ctx.Logger.WithError(err).Errorf("Unable to set regulation status response in body")
+	}
+}
+
+func newRegulationBan(ban regulation.Ban) RegulationBan {
+	return RegulationBan{
+		Type:    ban.Type,
+		Value:   ban.Value,
+		Source:  ban.Source,
+		Time:    ban.Time,
+		Expires: ban.Expires,
+		Reason:  ban.Reason,
+	}
+}
@@ -0,0 +1,367 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+	"go.uber.org/mock/gomock"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/mocks"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/regulation"
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+func TestRegulationBansGET(t *testing.T) {
+	testCases := []struct {
+		name     string
+		level    authentication.Level
+		groups   []string
+		setup    func(t *testing.T, mock *mocks.MockAutheliaCtx)
+		code     int
+		expected func(now time.Time) *RegulationBansResponse
+	}{
+		{
+			"ShouldListBans",
+			authentication.TwoFactor,
+			[]string{"admins"},
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				now := mock.Clock.Now()
+
+				gomock.InOrder(
+					mock.StorageMock.EXPECT().
+						LoadBannedUsers(mock.Ctx, now, 100, 0).
+						Return([]model.BannedUser{
+							{Time: now.Add(-time.Minute), Expires: sql.NullTime{Time: now.Add(time.Hour), Valid: true}, Username: "harry", Source: regulation.BanSourceManual, Reason: sql.NullString{String: "suspicious", Valid: true}},
+						}, nil),
+					mock.StorageMock.EXPECT().
+						LoadBannedIPs(mock.Ctx, now, 100, 0).
+						Return([]model.BannedIP{
+							{Time: now.Add(-time.Minute), IP: model.NewIP(net.ParseIP("192.168.1.1")), Source: regulation.BanSourceManual},
+						}, nil),
+					mock.StorageMock.EXPECT().
+						LoadRegulationLockouts(mock.Ctx, 100, 0).
+						Return(nil, storage.ErrNoRegulationLockout),
+					mock.StorageMock.EXPECT().
+						LoadAuthenticationLogsRegulated(mock.Ctx, now.Add(-time.Minute*5), 3, 100, 0).
+						Return([]model.AuthenticationAttempt{
+							{Username: testUsername, Successful: false, Time: now.Add(-time.Second * 10)},
+							{Username: testUsername, Successful: false, Time: now.Add(-time.Second * 20)},
+							{Username: testUsername, Successful: false, Time: now.Add(-time.Second * 30)},
+						}, nil),
+				)
+			},
+			fasthttp.StatusOK,
+			func(now time.Time) *RegulationBansResponse {
+				return &RegulationBansResponse{Bans: []RegulationBan{
+					{Type: regulation.BanTypeUser, Value: "harry", Source: regulation.BanSourceManual, Time: now.Add(-time.Minute), Expires: testRegulationTimePointer(now, time.Hour), Reason: "suspicious"},
+					{Type: regulation.BanTypeIP, Value: "192.168.1.1", Source: regulation.BanSourceManual, Time: now.Add(-time.Minute)},
+					{Type: regulation.BanTypeUser, Value: testUsername, Source: regulation.BanSourceRegulation, Time: now.Add(-time.Second * 10), Expires: testRegulationTimePointer(now, time.Minute*5-time.Second*10)},
+				}}
+			},
+		},
+		{
+			"ShouldListNoBans",
+			authentication.TwoFactor,
+			[]string{"admins"},
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				now := mock.Clock.Now()
+
+				gomock.InOrder(
+					mock.StorageMock.EXPECT().
+						LoadBannedUsers(mock.Ctx, now, 100, 0).
+						Return(nil, storage.ErrNoBannedUsers),
+					mock.StorageMock.EXPECT().
+						LoadBannedIPs(mock.Ctx, now, 100, 0).
+						Return(nil, storage.ErrNoBannedIPs),
+					mock.StorageMock.EXPECT().
+						LoadRegulationLockouts(mock.Ctx, 100, 0).
+						Return(nil, storage.ErrNoRegulationLockout),
+					mock.StorageMock.EXPECT().
+						LoadAuthenticationLogsRegulated(mock.Ctx, now.Add(-time.Minute*5), 3, 100, 0).
+						Return(nil, nil),
+				)
+			},
+			fasthttp.StatusOK,
+			func(now time.Time) *RegulationBansResponse {
+				return &RegulationBansResponse{Bans: []RegulationBan{}}
+			},
+		},
+		{
+			"ShouldDenyOneFactor",
+			authentication.OneFactor,
+			[]string{"admins"},
+			nil,
+			fasthttp.StatusForbidden,
+			nil,
+		},
+		{
+			"ShouldDenyNonAdministrator",
+			authentication.TwoFactor,
+			[]string{"dev"},
+			nil,
+			fasthttp.StatusForbidden,
+			nil,
+		},
+		{
+			"ShouldFailStorageError",
+			authentication.TwoFactor,
+			[]string{"admins"},
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				mock.StorageMock.EXPECT().
+					LoadBannedUsers(mock.Ctx, mock.Clock.Now(), 100, 0).
+					Return(nil, errors.New("bad conn"))
+			},
+			fasthttp.StatusInternalServerError,
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := mocks.NewMockAutheliaCtx(t)
+			defer mock.Close()
+
+			setupRegulationBansMock(t, mock, tc.level, tc.groups)
+
+			if tc.setup != nil {
+				tc.setup(t, mock)
+			}
+
+			RegulationBansGET(mock.Ctx)
+
+			switch {
+			case tc.expected != nil:
+				mock.Assert200OK(t, tc.expected(mock.Clock.Now()))
+			case tc.code == fasthttp.StatusForbidden:
+				assert.Equal(t, fasthttp.StatusForbidden, mock.Ctx.Response.StatusCode())
+			default:
+				mock.AssertKO(t, messageOperationFailed, tc.code)
+			}
+		})
+	}
+}
+
+func TestRegulationBanGET(t *testing.T) {
+	testCases := []struct {
+		name     string
+		level    authentication.Level
+		groups   []string
+		value    any
+		setup    func(t *testing.T, mock *mocks.MockAutheliaCtx)
+		code     int
+		expected func(now time.Time) *RegulationStatusResponse
+	}{
+		{
+			"ShouldShowUserStatus",
+			authentication.TwoFactor,
+			[]string{"admins"},
+			testUsername,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				now := mock.Clock.Now()
+
+				attempts := []model.AuthenticationAttempt{
+					{Username: testUsername, Type: regulation.AuthType1FA, Successful: false, Time: now.Add(-time.Second * 10), RemoteIP: model.NewNullIP(net.ParseIP("192.168.1.1"))},
+					{Username: testUsername, Type: regulation.AuthType1FA, Successful: false, Time: now.Add(-time.Second * 20)},
+					{Username: testUsername, Type: regulation.AuthType1FA, Successful: false, Time: now.Add(-time.Second * 30)},
+				}
+
+				gomock.InOrder(
+					mock.StorageMock.EXPECT().
+						LoadBannedUser(mock.Ctx, testUsername, now, now.Add(-time.Minute*5)).
+						Return(nil, nil),
+					mock.StorageMock.EXPECT().
+						LoadAuthenticationLogs(mock.Ctx, testUsername, now.Add(-time.Minute*5), 10, 0).
+						Return(attempts, nil),
+					mock.StorageMock.EXPECT().
+						LoadAuthenticationLogsByUsername(mock.Ctx, testUsername, now.Add(-time.Minute*5), 100, 0).
+						Return(attempts, nil),
+				)
+			},
+			fasthttp.StatusOK,
+			func(now time.Time) *RegulationStatusResponse {
+				return &RegulationStatusResponse{
+					Type:  regulation.BanTypeUser,
+					Value: testUsername,
+					Bans: []RegulationBan{
+						{Type: regulation.BanTypeUser, Value: testUsername, Source: regulation.BanSourceRegulation, Time: now.Add(-time.Second * 10), Expires: testRegulationTimePointer(now, time.Minute*5-time.Second*10)},
+					},
+					Attempts: []RegulationAttempt{
+						{Time: now.Add(-time.Second * 10), Type: regulation.AuthType1FA, Username: testUsername, RemoteIP: "192.168.1.1"},
+						{Time: now.Add(-time.Second * 20), Type: regulation.AuthType1FA, Username: testUsername},
+						{Time: now.Add(-time.Second * 30), Type: regulation.AuthType1FA, Username: testUsername},
+					},
+				}
+			},
+		},
+		{
+			"ShouldShowIPStatus",
+			authentication.TwoFactor,
+			[]string{"admins"},
+			"192.168.1.1",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				now := mock.Clock.Now()
+				ip := model.NewIP(net.ParseIP("192.168.1.1"))
+
+				gomock.InOrder(
+					mock.StorageMock.EXPECT().
+						LoadBannedIP(mock.Ctx, ip, now).
+						Return([]model.BannedIP{{Time: now.Add(-time.Minute), IP: ip, Source: regulation.BanSourceManual, Reason: sql.NullString{String: "suspicious", Valid: true}}}, nil),
+					mock.StorageMock.EXPECT().
+						LoadAuthenticationLogsByRemoteIP(mock.Ctx, ip, now.Add(-time.Minute*5), 100, 0).
+						Return([]model.AuthenticationAttempt{
+							{Username: testUsername, Type: regulation.AuthType1FA, Successful: true, Time: now.Add(-time.Minute * 2), RemoteIP: model.NewNullIP(net.ParseIP("192.168.1.1"))},
+						}, nil),
+				)
+			},
+			fasthttp.StatusOK,
+			func(now time.Time) *RegulationStatusResponse {
+				return &RegulationStatusResponse{
+					Type:  regulation.BanTypeIP,
+					Value: "192.168.1.1",
+					Bans: []RegulationBan{
+						{Type: regulation.BanTypeIP, Value: "192.168.1.1", Source: regulation.BanSourceManual, Time: now.Add(-time.Minute), Reason: "suspicious"},
+					},
+					Attempts: []RegulationAttempt{
+						{Time: now.Add(-time.Minute * 2), Type: regulation.AuthType1FA, Successful: true, Username: testUsername, RemoteIP: "192.168.1.1"},
+					},
+				}
+			},
+		},
+		{
+			"ShouldShowEmptyStatusWhenNotFound",
+			authentication.TwoFactor,
+			[]string{"admins"},
+			"harry",
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				now := mock.Clock.Now()
+
+				gomock.InOrder(
+					mock.StorageMock.EXPECT().
+						LoadBannedUser(mock.Ctx, "harry", now, now.Add(-time.Minute*5)).
+						Return(nil, nil),
+					mock.StorageMock.EXPECT().
+						LoadAuthenticationLogs(mock.Ctx, "harry", now.Add(-time.Minute*5), 10, 0).
+						Return(nil, nil),
+					mock.StorageMock.EXPECT().
+						LoadAuthenticationLogsByUsername(mock.Ctx, "harry", now.Add(-time.Minute*5), 100, 0).
+						Return(nil, storage.ErrNoAuthenticationLogs),
+				)
+			},
+			fasthttp.StatusOK,
+			func(now time.Time) *RegulationStatusResponse {
+				return &RegulationStatusResponse{
+					Type:     regulation.BanTypeUser,
+					Value:    "harry",
+					Bans:     []RegulationBan{},
+					Attempts: []RegulationAttempt{},
+				}
+			},
+		},
+		{
+			"ShouldFailWithoutValue",
+			authentication.TwoFactor,
+			[]string{"admins"},
+			nil,
+			nil,
+			fasthttp.StatusBadRequest,
+			nil,
+		},
+		{
+			"ShouldDenyOneFactor",
+			authentication.OneFactor,
+			[]string{"admins"},
+			testUsername,
+			nil,
+			fasthttp.StatusForbidden,
+			nil,
+		},
+		{
+			"ShouldDenyNonAdministrator",
+			authentication.TwoFactor,
+			[]string{"dev"},
+			testUsername,
+			nil,
+			fasthttp.StatusForbidden,
+			nil,
+		},
+		{
+			"ShouldFailStorageError",
+			authentication.TwoFactor,
+			[]string{"admins"},
+			testUsername,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				mock.StorageMock.EXPECT().
+					LoadBannedUser(mock.Ctx, testUsername, mock.Clock.Now(), gomock.Any()).
+					Return(nil, errors.New("bad conn"))
+			},
+			fasthttp.StatusInternalServerError,
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := mocks.NewMockAutheliaCtx(t)
+			defer mock.Close()
+
+			setupRegulationBansMock(t, mock, tc.level, tc.groups)
+
+			if tc.value != nil {
+				mock.Ctx.SetUserValue("value", tc.value)
+			}
+
+			if tc.setup != nil {
+				tc.setup(t, mock)
+			}
+
+			RegulationBanGET(mock.Ctx)
+
+			switch {
+			case tc.expected != nil:
+				mock.Assert200OK(t, tc.expected(mock.Clock.Now()))
+			case tc.code == fasthttp.StatusForbidden:
+				assert.Equal(t, fasthttp.StatusForbidden, mock.Ctx.Response.StatusCode())
+			default:
+				mock.AssertKO(t, messageOperationFailed, tc.code)
+			}
+		})
+	}
+}
+
+func setupRegulationBansMock(t *testing.T, mock *mocks.MockAutheliaCtx, level authentication.Level, groups []string) {
+	mock.Ctx.Configuration.Regulation = schema.Regulation{
+		MaxRetries: 3,
+		FindTime:   time.Minute * 2,
+		BanTime:    time.Minute * 5,
+		Administration: schema.RegulationAdministration{
+			Enable: true,
+			Groups: []string{"admins"},
+		},
+	}
+
+	mock.Ctx.Providers.Regulator = regulation.NewRegulator(mock.Ctx.Configuration.Regulation, mock.StorageMock, &mock.Clock)
+
+	userSession, err := mock.Ctx.GetSession()
+	require.NoError(t, err)
+
+	userSession.Username = testUsername
+	userSession.Groups = groups
+	userSession.AuthenticationLevel = level
+
+	require.NoError(t, mock.Ctx.SaveSession(userSession))
+}
+
+func testRegulationTimePointer(now time.Time, offset time.Duration) *time.Time {
+	value := now.Add(offset)
+
+	return &value
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/regulation"
+	"github.com/authelia/authelia/v4/internal/session"
+	"github.com/authelia/authelia/v4/internal/templates"
+)
+
+// RegulationUnlockDELETE handler for deleting regulation unlock JWT's.
+func RegulationUnlockDELETE(ctx *middlewares.AutheliaCtx) {
+	handleIdentityVerificationDELETE(ctx, ActionRegulationUnlock)
+}
+
+func identityRetrieverLockedFromStorage(ctx *middlewares.AutheliaCtx) (identity *session.Identity, err error) {
+	if identity, err = identityRetrieverFromStorage(ctx); err != nil {
+		return nil, err
+	}
+
+	if _, err = ctx.Providers.Regulator.Regulate(ctx, identity.Username); !errors.Is(err, regulation.ErrUserIsLocked) {
+		return nil, fmt.Errorf("user %s is not locked", identity.Username)
+	}
+
+	return identity, nil
+}
+
+// RegulationUnlockIdentityStart is the handler for initiating the identity validation for unlocking a locked account.
+// We need to ensure the attacker cannot perform user enumeration by always replying with 200 whatever what happens in backend.
+var RegulationUnlockIdentityStart = middlewares.IdentityVerificationStart(middlewares.IdentityVerificationStartArgs{
+	MailTitle:               "Unlock your account",
+	MailButtonContent:       "Unlock",
+	MailButtonRevokeContent: "Revoke",
+	TargetEndpoint:          "/regulation/unlock",
+	RevokeEndpoint:          "/revoke/regulation/unlock",
+	ActionClaim:             ActionRegulationUnlock,
+	IdentityRetrieverFunc:   identityRetrieverLockedFromStorage,
+}, middlewares.TimingAttackDelay(10, 250, 85, time.Millisecond*500, false))
+
+func regulationUnlockIdentityFinish(ctx *middlewares.AutheliaCtx, username string) {
+	if err := ctx.Providers.Regulator.Unlock(ctx, username, regulation.UnlockMethodIdentityVerification); err != nil {
+		ctx.Error(fmt.Errorf("unable to unlock user '%s': %w", username, err), messageOperationFailed)
+
+		return
+	}
+
+	ctx.Logger.Infof("User '%s' has been unlocked after completing an identity verification", username)
+
+	ctxLogEvent(ctx, username, templates.TemplateNameEmailEvent, eventLogActionAccountUnlocked, map[string]any{eventLogKeyAction: eventLogActionAccountUnlocked, eventLogKeyCategory: eventLogCategoryRegulation})
+
+	ctx.ReplyOK()
+}
+
+// RegulationUnlockIdentityFinish the handler for finishing the identity validation for unlocking a locked account.
+var RegulationUnlockIdentityFinish = middlewares.IdentityVerificationFinish(
+	middlewares.IdentityVerificationFinishArgs{ActionClaim: ActionRegulationUnlock}, regulationUnlockIdentityFinish)
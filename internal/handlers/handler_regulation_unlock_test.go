@@ -0,0 +1,413 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+	"go.uber.org/mock/gomock"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/mocks"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/regulation"
+	"github.com/authelia/authelia/v4/internal/storage"
+	"github.com/authelia/authelia/v4/internal/templates"
+)
+
+func TestRegulationUnlockIdentityStart(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     string
+		setup    func(t *testing.T, mock *mocks.MockAutheliaCtx)
+		expected string
+	}{
+		{
+			"ShouldSendEmailWhenUserIsLocked",
+			`{"username":"john"}`,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				gomock.InOrder(
+					mock.UserProviderMock.
+						EXPECT().
+						GetDetails(testUsername).
+						Return(&authentication.UserDetails{Username: testUsername, DisplayName: testDisplayName, Emails: []string{"john@example.com"}}, nil),
+				)
+
+				setupRegulationUnlockLockout(mock, &model.RegulationLockout{Username: testUsername, LockedAt: mock.Clock.Now().Add(-time.Hour), Bans: 3})
+
+				gomock.InOrder(
+					mock.StorageMock.
+						EXPECT().
+						SaveIdentityVerification(mock.Ctx, gomock.Any()).
+						Return(nil),
+					mock.NotifierMock.
+						EXPECT().
+						Send(mock.Ctx, mail.Address{Name: testDisplayName, Address: "john@example.com"}, "Unlock your account", gomock.Any(), gomock.Any()).
+						DoAndReturn(func(_ context.Context, _ mail.Address, _ string, _ *templates.EmailTemplate, data any) error {
+							values, ok := data.(templates.EmailIdentityVerificationJWTValues)
+							require.True(t, ok)
+
+							link, err := url.Parse(values.LinkURL)
+							require.NoError(t, err)
+
+							revocation, err := url.Parse(values.RevocationLinkURL)
+							require.NoError(t, err)
+
+							assert.Equal(t, "/regulation/unlock", link.Path)
+							assert.NotEmpty(t, link.Query().Get("token"))
+							assert.Equal(t, "/revoke/regulation/unlock", revocation.Path)
+							assert.Equal(t, link.Query().Get("token"), revocation.Query().Get("token"))
+
+							return nil
+						}),
+				)
+			},
+			"",
+		},
+		{
+			"ShouldNotSendEmailWhenUserIsNotLocked",
+			`{"username":"john"}`,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				mock.UserProviderMock.
+					EXPECT().
+					GetDetails(testUsername).
+					Return(&authentication.UserDetails{Username: testUsername, DisplayName: testDisplayName, Emails: []string{"john@example.com"}}, nil)
+
+				setupRegulationUnlockLockout(mock, nil)
+			},
+			"user john is not locked",
+		},
+		{
+			"ShouldNotSendEmailWhenUserWasUnlocked",
+			`{"username":"john"}`,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				mock.UserProviderMock.
+					EXPECT().
+					GetDetails(testUsername).
+					Return(&authentication.UserDetails{Username: testUsername, DisplayName: testDisplayName, Emails: []string{"john@example.com"}}, nil)
+
+				setupRegulationUnlockLockout(mock, &model.RegulationLockout{Username: testUsername, LockedAt: mock.Clock.Now().Add(-time.Hour), Bans: 3, UnlockedAt: sql.NullTime{Time: mock.Clock.Now().Add(-time.Minute), Valid: true}, UnlockedMethod: sql.NullString{String: regulation.UnlockMethodAdministrator, Valid: true}})
+			},
+			"user john is not locked",
+		},
+		{
+			"ShouldNotSendEmailWhenUserHasNoEmail",
+			`{"username":"john"}`,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				mock.UserProviderMock.
+					EXPECT().
+					GetDetails(testUsername).
+					Return(&authentication.UserDetails{Username: testUsername, DisplayName: testDisplayName}, nil)
+			},
+			"user john has no email address configured",
+		},
+		{
+			"ShouldNotSendEmailWhenUserDoesNotExist",
+			`{"username":"john"}`,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				mock.UserProviderMock.
+					EXPECT().
+					GetDetails(testUsername).
+					Return(nil, authentication.ErrUserNotFound)
+			},
+			"user not found",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := mocks.NewMockAutheliaCtx(t)
+
+			defer mock.Close()
+
+			setupRegulationUnlockMock(mock)
+
+			mock.Ctx.Request.Header.Set(fasthttp.HeaderXForwardedProto, "https")
+			mock.Ctx.Request.SetBodyString(tc.have)
+
+			tc.setup(t, mock)
+
+			RegulationUnlockIdentityStart(mock.Ctx)
+
+			mock.Assert200OK(t, nil)
+
+			if tc.expected == "" {
+				assert.Nil(t, mock.Hook.LastEntry())
+			} else {
+				require.NotNil(t, mock.Hook.LastEntry())
+				assert.Equal(t, tc.expected, mock.Hook.LastEntry().Message)
+			}
+		})
+	}
+}
+
+func TestRegulationUnlockIdentityFinish(t *testing.T) {
+	testCases := []struct {
+		name     string
+		action   string
+		setup    func(t *testing.T, mock *mocks.MockAutheliaCtx, verification model.IdentityVerification)
+		expected string
+		err      string
+	}{
+		{
+			"ShouldUnlockUser",
+			ActionRegulationUnlock,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx, verification model.IdentityVerification) {
+				gomock.InOrder(
+					mock.StorageMock.
+						EXPECT().
+						FindIdentityVerification(mock.Ctx, verification.JTI.String()).
+						Return(true, nil),
+					mock.StorageMock.
+						EXPECT().
+						ConsumeIdentityVerification(mock.Ctx, verification.JTI.String(), model.NewNullIP(mock.Ctx.RemoteIP())).
+						Return(nil),
+					mock.StorageMock.
+						EXPECT().
+						UnlockRegulationLockout(mock.Ctx, testUsername, regulation.UnlockMethodIdentityVerification, mock.Clock.Now()).
+						Return(nil),
+					mock.StorageMock.
+						EXPECT().
+						LoadBannedUser(mock.Ctx, testUsername, mock.Clock.Now(), gomock.Any()).
+						Return(nil, nil),
+					mock.StorageMock.
+						EXPECT().
+						LoadRegulationLockout(mock.Ctx, testUsername).
+						Return(&model.RegulationLockout{Username: testUsername, LockedAt: mock.Clock.Now().Add(-time.Minute), Bans: 3, UnlockedAt: sql.NullTime{Time: mock.Clock.Now(), Valid: true}}, nil),
+					mock.StorageMock.
+						EXPECT().
+						LoadAuthenticationLogs(mock.Ctx, testUsername, mock.Clock.Now(), gomock.Any(), 0).
+						Return(nil, storage.ErrNoAuthenticationLogs),
+					mock.UserProviderMock.
+						EXPECT().
+						GetDetails(testUsername).
+						Return(&authentication.UserDetails{Username: testUsername, DisplayName: testDisplayName, Emails: []string{"john@example.com"}}, nil),
+					mock.NotifierMock.
+						EXPECT().
+						Send(mock.Ctx, mail.Address{Name: testDisplayName, Address: "john@example.com"}, eventLogActionAccountUnlocked, gomock.Any(), gomock.Any()).
+						Return(nil),
+				)
+			},
+			"",
+			"",
+		},
+		{
+			"ShouldFailToUnlockUserWhenStorageFails",
+			ActionRegulationUnlock,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx, verification model.IdentityVerification) {
+				gomock.InOrder(
+					mock.StorageMock.
+						EXPECT().
+						FindIdentityVerification(mock.Ctx, verification.JTI.String()).
+						Return(true, nil),
+					mock.StorageMock.
+						EXPECT().
+						ConsumeIdentityVerification(mock.Ctx, verification.JTI.String(), model.NewNullIP(mock.Ctx.RemoteIP())).
+						Return(nil),
+					mock.StorageMock.
+						EXPECT().
+						UnlockRegulationLockout(mock.Ctx, testUsername, regulation.UnlockMethodIdentityVerification, mock.Clock.Now()).
+						Return(fmt.Errorf("bad conn")),
+				)
+			},
+			messageOperationFailed,
+			"unable to unlock user 'john': bad conn",
+		},
+		{
+			"ShouldFailToUnlockUserWithResetPasswordToken",
+			ActionResetPassword,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx, verification model.IdentityVerification) {
+				mock.StorageMock.
+					EXPECT().
+					FindIdentityVerification(mock.Ctx, verification.JTI.String()).
+					Return(true, nil)
+			},
+			messageOperationFailed,
+			"Error occurred handling the identity verification token, the token action 'ResetPassword' does not match the endpoint action 'RegulationUnlock' which is not allowed",
+		},
+		{
+			"ShouldFailToUnlockUserWithUsedToken",
+			ActionRegulationUnlock,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx, verification model.IdentityVerification) {
+				mock.StorageMock.
+					EXPECT().
+					FindIdentityVerification(mock.Ctx, verification.JTI.String()).
+					Return(false, nil)
+			},
+			"The identity verification token has already been used",
+			"Error occurred looking up identity verification during the validation phase, the token was not found in the database which could indicate it was never generated or was already used",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := mocks.NewMockAutheliaCtx(t)
+
+			defer mock.Close()
+
+			setupRegulationUnlockMock(mock)
+
+			token, verification := newRegulationUnlockToken(t, mock, tc.action)
+
+			mock.Ctx.Request.SetBodyString(fmt.Sprintf(`{"token":"%s"}`, token))
+
+			tc.setup(t, mock, verification)
+
+			RegulationUnlockIdentityFinish(mock.Ctx)
+
+			if tc.expected == "" {
+				mock.Assert200OK(t, nil)
+			} else {
+				mock.Assert200KO(t, tc.expected)
+				require.NotNil(t, mock.Hook.LastEntry())
+				assert.Equal(t, tc.err, mock.Hook.LastEntry().Message)
+			}
+		})
+	}
+}
+
+func TestRegulationUnlockDELETE(t *testing.T) {
+	testCases := []struct {
+		name   string
+		action string
+		setup  func(t *testing.T, mock *mocks.MockAutheliaCtx, verification model.IdentityVerification)
+		err    string
+	}{
+		{
+			"ShouldRevokeToken",
+			ActionRegulationUnlock,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx, verification model.IdentityVerification) {
+				gomock.InOrder(
+					mock.StorageMock.
+						EXPECT().
+						LoadIdentityVerification(mock.Ctx, verification.JTI.String()).
+						Return(&verification, nil),
+					mock.StorageMock.
+						EXPECT().
+						RevokeIdentityVerification(mock.Ctx, verification.JTI.String(), model.NewNullIP(mock.Ctx.RemoteIP())).
+						Return(nil),
+				)
+			},
+			"",
+		},
+		{
+			"ShouldNotRevokeTokenAlreadyRevoked",
+			ActionRegulationUnlock,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx, verification model.IdentityVerification) {
+				verification.RevokedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+				mock.StorageMock.
+					EXPECT().
+					LoadIdentityVerification(mock.Ctx, verification.JTI.String()).
+					Return(&verification, nil)
+			},
+			"Error occurred revoking identity verification token as it's already revoked",
+		},
+		{
+			"ShouldNotRevokeResetPasswordToken",
+			ActionResetPassword,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx, verification model.IdentityVerification) {},
+			"Error occurred revoking the identity verification token, the token action 'ResetPassword' does not match the endpoint action 'RegulationUnlock' which is not allowed",
+		},
+		{
+			"ShouldNotRevokeTokenWhenStorageFails",
+			ActionRegulationUnlock,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx, verification model.IdentityVerification) {
+				mock.StorageMock.
+					EXPECT().
+					LoadIdentityVerification(mock.Ctx, verification.JTI.String()).
+					Return(nil, fmt.Errorf("bad conn"))
+			},
+			"Error occurred looking up identity verification during the revocation phase",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := mocks.NewMockAutheliaCtx(t)
+
+			defer mock.Close()
+
+			setupRegulationUnlockMock(mock)
+
+			token, verification := newRegulationUnlockToken(t, mock, tc.action)
+
+			mock.Ctx.Request.SetBodyString(fmt.Sprintf(`{"token":"%s"}`, token))
+
+			tc.setup(t, mock, verification)
+
+			RegulationUnlockDELETE(mock.Ctx)
+
+			if tc.err == "" {
+				mock.Assert200OK(t, nil)
+			} else {
+				mock.Assert200KO(t, messageOperationFailed)
+				require.NotNil(t, mock.Hook.LastEntry())
+				assert.Equal(t, tc.err, mock.Hook.LastEntry().Message)
+			}
+		})
+	}
+}
+
+func setupRegulationUnlockMock(mock *mocks.MockAutheliaCtx) {
+	mock.Ctx.Configuration.IdentityValidation.ResetPassword.JWTSecret = "abc"
+	mock.Ctx.Configuration.Regulation = schema.Regulation{
+		MaxRetries: 3,
+		FindTime:   time.Minute * 2,
+		BanTime:    time.Minute * 5,
+		Lockout: schema.RegulationLockout{
+			MaxBans:                    3,
+			Window:                     time.Hour * 24,
+			IdentityVerificationUnlock: true,
+		},
+	}
+
+	mock.Ctx.Providers.Regulator = regulation.NewRegulator(mock.Ctx.Configuration.Regulation, mock.StorageMock, &mock.Clock)
+}
+
+func setupRegulationUnlockLockout(mock *mocks.MockAutheliaCtx, lockout *model.RegulationLockout) {
+	err := storage.ErrNoRegulationLockout
+
+	if lockout != nil {
+		err = nil
+	}
+
+	gomock.InOrder(
+		mock.StorageMock.
+			EXPECT().
+			LoadBannedIP(mock.Ctx, gomock.Any(), mock.Clock.Now()).
+			Return(nil, nil),
+		mock.StorageMock.
+			EXPECT().
+			LoadBannedUser(mock.Ctx, testUsername, mock.Clock.Now(), gomock.Any()).
+			Return(nil, nil),
+		mock.StorageMock.
+			EXPECT().
+			LoadRegulationLockout(mock.Ctx, testUsername).
+			Return(lockout, err),
+		mock.StorageMock.
+			EXPECT().
+			LoadAuthenticationLogs(mock.Ctx, testUsername, gomock.Any(), gomock.Any(), 0).
+			Return(nil, nil),
+	)
+}
+
+func newRegulationUnlockToken(t *testing.T, mock *mocks.MockAutheliaCtx, action string) (string, model.IdentityVerification) {
+	verification := model.NewIdentityVerification(uuid.New(), testUsername, action, mock.Ctx.RemoteIP(), time.Minute*5)
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, verification.ToIdentityVerificationClaim()).SignedString([]byte(mock.Ctx.Configuration.IdentityValidation.ResetPassword.JWTSecret))
+
+	require.NoError(t, err)
+
+	return token, verification
+}
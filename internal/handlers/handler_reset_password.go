@@ -17,6 +17,13 @@ import (
 
 // ResetPasswordDELETE handler for deleting password reset JWT's.
 func ResetPasswordDELETE(ctx *middlewares.AutheliaCtx) {
+	handleIdentityVerificationDELETE(ctx, ActionResetPassword)
+}
+
+// handleIdentityVerificationDELETE revokes an identity verification JWT for a given action.
+//
+//nolint:gocyclo
+func handleIdentityVerificationDELETE(ctx *middlewares.AutheliaCtx, action string) {
 	var (
 		token        *jwt.Token
 		verification *model.IdentityVerification
@@ -28,7 +35,7 @@ func ResetPasswordDELETE(ctx *middlewares.AutheliaCtx) {
 	body := &bodyRequestPasswordResetDELETE{}
 
 	if err = ctx.ParseBody(body); err != nil {
-		ctx.Error(fmt.Errorf("error occurred parsing reset password delete body: %w", err), messageOperationFailed)
+		ctx.Error(fmt.Errorf("error occurred parsing identity verification delete body: %w", err), messageOperationFailed)
 		return
 	}
 
@@ -86,8 +93,8 @@ func ResetPasswordDELETE(ctx *middlewares.AutheliaCtx) {
 		return
 	}
 
-	if verification.Action != ActionResetPassword {
-		ctx.Logger.Errorf("Error occurred revoking the identity verification token, the token action '%s' does not match the endpoint action '%s' which is not allowed", claims.Action, ActionResetPassword)
+	if verification.Action != action {
+		ctx.Logger.Errorf("Error occurred revoking the identity verification token, the token action '%s' does not match the endpoint action '%s' which is not allowed", claims.Action, action)
 		ctx.SetJSONError(messageOperationFailed)
 
 		return
@@ -4,7 +4,6 @@ import (
 	"crypto/subtle"
 	"encoding/base64"
 	"fmt"
-	"path"
 	"strings"
 
 	"github.com/google/uuid"
@@ -164,22 +163,13 @@ func UserSessionElevationPOST(ctx *middlewares.AutheliaCtx) {
 		return
 	}
 
-	deleteID := base64.RawURLEncoding.EncodeToString(otp.PublicID[:])
-
-	linkURL := ctx.RootURL()
-
-	query := linkURL.Query()
-
-	query.Set("id", deleteID)
-
-	linkURL.Path = path.Join(linkURL.Path, "/revoke/one-time-code")
-	linkURL.RawQuery = query.Encode()
+	deleteID, revocationURL := ctxOneTimeCodeRevocation(ctx, otp)
 
 	identity := userSession.Identity()
 
 	data := templates.EmailIdentityVerificationOTCValues{
 		Title:              "Confirm your identity",
-		RevocationLinkURL:  linkURL.String(),
+		RevocationLinkURL:  revocationURL,
 		RevocationLinkText: "Revoke",
 		DisplayName:        identity.DisplayName,
 		RemoteIP:           ctx.RemoteIP().String(),
@@ -405,8 +395,8 @@ func UserSessionElevateDELETE(ctx *middlewares.AutheliaCtx) {
 		return
 	}
 
-	if code.Intent != model.OTCIntentUserSessionElevation {
-		ctx.Logger.WithError(fmt.Errorf("the code challenge has the '%s' intent but the '%s' intent is required", code.Intent, model.OTCIntentUserSessionElevation)).Errorf("Error occurred revoking user session elevation One-Time Code challenge")
+	if code.Intent != model.OTCIntentUserSessionElevation && code.Intent != model.OTCIntentSecondFactorEmail {
+		ctx.Logger.WithError(fmt.Errorf("the code challenge has the '%s' intent but the '%s' or '%s' intent is required", code.Intent, model.OTCIntentUserSessionElevation, model.OTCIntentSecondFactorEmail)).Errorf("Error occurred revoking user session elevation One-Time Code challenge")
 
 		ctx.SetJSONError(messageOperationFailed)
 
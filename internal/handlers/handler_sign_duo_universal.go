@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"fmt"
+
+	"github.com/authelia/authelia/v4/internal/duo"
+	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/random"
+	"github.com/authelia/authelia/v4/internal/regulation"
+	"github.com/authelia/authelia/v4/internal/session"
+)
+
+// DuoUniversalPromptPOST handler for signing in with the Duo Universal Prompt. The initial request responds with the
+// URL of the Universal Prompt the user should be redirected to, and the request made after the Universal Prompt
+// redirects the user back with the state and authorization code completes the sign in.
+func DuoUniversalPromptPOST(prompt duo.UniversalPrompt) middlewares.RequestHandler {
+	return func(ctx *middlewares.AutheliaCtx) {
+		var (
+			bodyJSON = &bodySignDuoRequest{}
+
+			userSession session.UserSession
+			err         error
+		)
+
+		if err = ctx.ParseBody(bodyJSON); err != nil {
+			ctx.Logger.WithError(err).Errorf(logFmtErrParseRequestBody, regulation.AuthTypeDuo)
+
+			respondUnauthorized(ctx, messageMFAValidationFailed)
+
+			return
+		}
+
+		if userSession, err = ctx.GetSession(); err != nil {
+			ctx.Error(fmt.Errorf("error occurred retrieving user session: %w", err), messageMFAValidationFailed)
+			return
+		}
+
+		if bodyJSON.Code == "" {
+			handleDuoUniversalPromptAuthURL(ctx, &userSession, prompt, bodyJSON)
+		} else {
+			handleDuoUniversalPromptExchange(ctx, &userSession, prompt, bodyJSON)
+		}
+	}
+}
+
+func handleDuoUniversalPromptAuthURL(ctx *middlewares.AutheliaCtx, userSession *session.UserSession, prompt duo.UniversalPrompt, bodyJSON *bodySignDuoRequest) {
+	var (
+		uri string
+		err error
+	)
+
+	if err = prompt.HealthCheck(ctx); err != nil {
+		ctx.Logger.WithError(err).Errorf(logFmtErrDuoUniversalPrompt, userSession.Username)
+
+		respondUnauthorized(ctx, messageMFAValidationFailed)
+
+		return
+	}
+
+	data := &session.Duo{
+		State:      ctx.Providers.Random.StringCustom(duoUniversalPromptRandomLength, random.CharSetAlphaNumeric),
+		Nonce:      ctx.Providers.Random.StringCustom(duoUniversalPromptRandomLength, random.CharSetAlphaNumeric),
+		TargetURL:  bodyJSON.TargetURL,
+		Workflow:   bodyJSON.Workflow,
+		WorkflowID: bodyJSON.WorkflowID,
+		Expires:    ctx.Clock.Now().Add(duoUniversalPromptLifespan),
+	}
+
+	if uri, err = prompt.AuthURL(userSession.Username, data.State, data.Nonce, duoUniversalPromptRedirectURI(ctx)); err != nil {
+		ctx.Logger.WithError(err).Errorf(logFmtErrDuoUniversalPrompt, userSession.Username)
+
+		respondUnauthorized(ctx, messageMFAValidationFailed)
+
+		return
+	}
+
+	userSession.Duo = data
+
+	if err = ctx.SaveSession(*userSession); err != nil {
+		ctx.Logger.WithError(err).Errorf(logFmtErrSessionSave, "duo universal prompt session data", regulation.AuthTypeDuo, logFmtActionAuthentication, userSession.Username)
+
+		respondUnauthorized(ctx, messageMFAValidationFailed)
+
+		return
+	}
+
+	if err = ctx.SetJSONBody(DuoSignResponse{Result: duo.UniversalPromptResult, Redirect: uri}); err != nil {
+		ctx.Logger.WithError(err).Errorf("Unable to set JSON body in response")
+	}
+}
+
+func handleDuoUniversalPromptExchange(ctx *middlewares.AutheliaCtx, userSession *session.UserSession, prompt duo.UniversalPrompt, bodyJSON *bodySignDuoRequest) {
+	var err error
+
+	data := userSession.Duo
+
+	switch {
+	case data == nil:
+		ctx.Logger.Errorf("Failed to perform Duo Universal Prompt authentication for user '%s': the session does not have a Duo Universal Prompt authentication in progress", userSession.Username)
+
+		respondUnauthorized(ctx, messageMFAValidationFailed)
+
+		return
+	case ctx.Clock.Now().After(data.Expires):
+		err = fmt.Errorf("the Duo Universal Prompt authentication expired at %s", data.Expires)
+	case subtle.ConstantTimeCompare([]byte(data.State), []byte(bodyJSON.State)) != 1:
+		err = fmt.Errorf("the state does not match the expected state")
+	default:
+		_, err = prompt.Exchange(ctx, bodyJSON.Code, duoUniversalPromptRedirectURI(ctx), userSession.Username, data.Nonce)
+	}
+
+	userSession.Duo = nil
+
+	if err != nil {
+		_ = markAuthenticationAttempt(ctx, false, nil, userSession.Username, regulation.AuthTypeDuo, err)
+
+		if err = ctx.SaveSession(*userSession); err != nil {
+			ctx.Logger.WithError(err).Errorf(logFmtErrSessionSave, "removal of the duo universal prompt session data", regulation.AuthTypeDuo, logFmtActionAuthentication, userSession.Username)
+		}
+
+		respondUnauthorized(ctx, messageMFAValidationFailed)
+
+		return
+	}
+
+	if err = markAuthenticationAttempt(ctx, true, nil, userSession.Username, regulation.AuthTypeDuo, nil); err != nil {
+		respondUnauthorized(ctx, messageMFAValidationFailed)
+		return
+	}
+
+	HandleAllow(ctx, userSession, &bodySignDuoRequest{TargetURL: data.TargetURL, Workflow: data.Workflow, WorkflowID: data.WorkflowID})
+}
+
+func duoUniversalPromptRedirectURI(ctx *middlewares.AutheliaCtx) string {
+	return ctx.RootURL().JoinPath(duoUniversalPromptRedirectPath...).String()
+}
@@ -0,0 +1,197 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/valyala/fasthttp"
+	"go.uber.org/mock/gomock"
+
+	"github.com/authelia/authelia/v4/internal/duo"
+	"github.com/authelia/authelia/v4/internal/mocks"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/regulation"
+	"github.com/authelia/authelia/v4/internal/session"
+)
+
+const (
+	testDuoUniversalPromptRedirectURI = "https://auth.example.com/2fa/push-notification"
+	testDuoUniversalPromptAuthURL     = "https://api-123456.duosecurity.com/oauth/v1/authorize?client_id=DIXXXXXXXXXXXXXXXXXX"
+)
+
+type SecondFactorDuoUniversalPromptPostSuite struct {
+	suite.Suite
+	mock   *mocks.MockAutheliaCtx
+	prompt *mocks.MockDuoUniversalPrompt
+}
+
+func (s *SecondFactorDuoUniversalPromptPostSuite) SetupTest() {
+	s.mock = mocks.NewMockAutheliaCtx(s.T())
+	s.prompt = mocks.NewMockDuoUniversalPrompt(s.mock.Ctrl)
+
+	s.mock.Ctx.Request.Header.Set(fasthttp.HeaderXForwardedProto, "https")
+	s.mock.Ctx.Request.Header.Set(fasthttp.HeaderXForwardedHost, "auth.example.com")
+
+	userSession, err := s.mock.Ctx.GetSession()
+	s.Require().NoError(err)
+
+	userSession.Username = testUsername
+
+	s.Require().NoError(s.mock.Ctx.SaveSession(userSession))
+}
+
+func (s *SecondFactorDuoUniversalPromptPostSuite) TearDownTest() {
+	s.mock.Close()
+}
+
+func (s *SecondFactorDuoUniversalPromptPostSuite) setBody(body bodySignDuoRequest) {
+	bodyBytes, err := json.Marshal(body)
+	s.Require().NoError(err)
+
+	s.mock.Ctx.Request.SetBody(bodyBytes)
+}
+
+func (s *SecondFactorDuoUniversalPromptPostSuite) setSessionData(data *session.Duo) {
+	userSession, err := s.mock.Ctx.GetSession()
+	s.Require().NoError(err)
+
+	userSession.Duo = data
+
+	s.Require().NoError(s.mock.Ctx.SaveSession(userSession))
+}
+
+func (s *SecondFactorDuoUniversalPromptPostSuite) expectAuthenticationLog(successful bool) {
+	s.mock.StorageMock.
+		EXPECT().
+		AppendAuthenticationLog(s.mock.Ctx, gomock.Eq(model.AuthenticationAttempt{
+			Username:   testUsername,
+			Successful: successful,
+			Banned:     false,
+			Time:       s.mock.Clock.Now(),
+			Type:       regulation.AuthTypeDuo,
+			RemoteIP:   model.NewNullIPFromString("0.0.0.0"),
+		})).
+		Return(nil)
+}
+
+func (s *SecondFactorDuoUniversalPromptPostSuite) TestShouldRespondWithAuthURL() {
+	gomock.InOrder(
+		s.prompt.EXPECT().HealthCheck(s.mock.Ctx).Return(nil),
+		s.prompt.EXPECT().AuthURL(testUsername, gomock.Any(), gomock.Any(), testDuoUniversalPromptRedirectURI).Return(testDuoUniversalPromptAuthURL, nil),
+	)
+
+	s.setBody(bodySignDuoRequest{TargetURL: "https://example.com"})
+
+	DuoUniversalPromptPOST(s.prompt)(s.mock.Ctx)
+
+	s.mock.Assert200OK(s.T(), DuoSignResponse{
+		Result:   duo.UniversalPromptResult,
+		Redirect: testDuoUniversalPromptAuthURL,
+	})
+
+	userSession, err := s.mock.Ctx.GetSession()
+	s.Require().NoError(err)
+
+	s.Require().NotNil(userSession.Duo)
+	s.Len(userSession.Duo.State, duoUniversalPromptRandomLength)
+	s.Len(userSession.Duo.Nonce, duoUniversalPromptRandomLength)
+	s.Equal("https://example.com", userSession.Duo.TargetURL)
+	s.True(s.mock.Clock.Now().Add(duoUniversalPromptLifespan).Equal(userSession.Duo.Expires))
+}
+
+func (s *SecondFactorDuoUniversalPromptPostSuite) TestShouldFailHealthCheck() {
+	s.prompt.EXPECT().HealthCheck(s.mock.Ctx).Return(errors.New("error performing the health check: the service responded with message 'unavailable' and detail 'The service is unavailable.'"))
+
+	s.setBody(bodySignDuoRequest{})
+
+	DuoUniversalPromptPOST(s.prompt)(s.mock.Ctx)
+
+	s.mock.Assert401KO(s.T(), messageMFAValidationFailed)
+	AssertLogEntryMessageAndError(s.T(), s.mock.Hook.LastEntry(), "Failed to perform Duo Universal Prompt authentication for user 'john'", "error performing the health check: the service responded with message 'unavailable' and detail 'The service is unavailable.'")
+}
+
+func (s *SecondFactorDuoUniversalPromptPostSuite) TestShouldAllowAccess() {
+	s.setSessionData(&session.Duo{State: "abc", Nonce: "xyz", TargetURL: "https://example.com", Expires: s.mock.Clock.Now().Add(time.Minute)})
+
+	s.prompt.EXPECT().
+		Exchange(s.mock.Ctx, "code", testDuoUniversalPromptRedirectURI, testUsername, "xyz").
+		Return(&duo.UniversalPromptClaims{PreferredUsername: testUsername, Nonce: "xyz", AuthResult: duo.UniversalPromptAuthResult{Result: allow}}, nil)
+
+	s.expectAuthenticationLog(true)
+
+	s.setBody(bodySignDuoRequest{State: "abc", Code: "code"})
+
+	DuoUniversalPromptPOST(s.prompt)(s.mock.Ctx)
+
+	s.mock.Assert200OK(s.T(), redirectResponse{
+		Redirect: "https://example.com",
+	})
+
+	userSession, err := s.mock.Ctx.GetSession()
+	s.Require().NoError(err)
+
+	s.Nil(userSession.Duo)
+	s.True(userSession.AuthenticationMethodRefs.Duo)
+}
+
+func (s *SecondFactorDuoUniversalPromptPostSuite) TestShouldDenyAccessExchangeError() {
+	s.setSessionData(&session.Duo{State: "abc", Nonce: "xyz", Expires: s.mock.Clock.Now().Add(time.Minute)})
+
+	s.prompt.EXPECT().
+		Exchange(s.mock.Ctx, "code", testDuoUniversalPromptRedirectURI, testUsername, "xyz").
+		Return(nil, errors.New("the authentication result was 'deny' with status 'deny' and message 'Login Denied'"))
+
+	s.expectAuthenticationLog(false)
+
+	s.setBody(bodySignDuoRequest{State: "abc", Code: "code"})
+
+	DuoUniversalPromptPOST(s.prompt)(s.mock.Ctx)
+
+	s.mock.Assert401KO(s.T(), messageMFAValidationFailed)
+
+	userSession, err := s.mock.Ctx.GetSession()
+	s.Require().NoError(err)
+
+	s.Nil(userSession.Duo)
+}
+
+func (s *SecondFactorDuoUniversalPromptPostSuite) TestShouldDenyAccessStateMismatch() {
+	s.setSessionData(&session.Duo{State: "abc", Nonce: "xyz", Expires: s.mock.Clock.Now().Add(time.Minute)})
+
+	s.expectAuthenticationLog(false)
+
+	s.setBody(bodySignDuoRequest{State: "bad", Code: "code"})
+
+	DuoUniversalPromptPOST(s.prompt)(s.mock.Ctx)
+
+	s.mock.Assert401KO(s.T(), messageMFAValidationFailed)
+}
+
+func (s *SecondFactorDuoUniversalPromptPostSuite) TestShouldDenyAccessExpired() {
+	s.setSessionData(&session.Duo{State: "abc", Nonce: "xyz", Expires: s.mock.Clock.Now().Add(-time.Minute)})
+
+	s.expectAuthenticationLog(false)
+
+	s.setBody(bodySignDuoRequest{State: "abc", Code: "code"})
+
+	DuoUniversalPromptPOST(s.prompt)(s.mock.Ctx)
+
+	s.mock.Assert401KO(s.T(), messageMFAValidationFailed)
+}
+
+func (s *SecondFactorDuoUniversalPromptPostSuite) TestShouldDenyAccessNoSessionData() {
+	s.setBody(bodySignDuoRequest{State: "abc", Code: "code"})
+
+	DuoUniversalPromptPOST(s.prompt)(s.mock.Ctx)
+
+	s.mock.Assert401KO(s.T(), messageMFAValidationFailed)
+	AssertLogEntryMessageAndError(s.T(), s.mock.Hook.LastEntry(), "Failed to perform Duo Universal Prompt authentication for user 'john': the session does not have a Duo Universal Prompt authentication in progress", "")
+}
+
+func TestRunSecondFactorDuoUniversalPromptPostSuite(t *testing.T) {
+	s := new(SecondFactorDuoUniversalPromptPostSuite)
+	suite.Run(t, s)
+}
@@ -0,0 +1,275 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/regulation"
+	"github.com/authelia/authelia/v4/internal/session"
+	"github.com/authelia/authelia/v4/internal/templates"
+)
+
+// EmailOneTimeCodePUT generates a new Email One-Time Code and sends it to the users email address.
+func EmailOneTimeCodePUT(ctx *middlewares.AutheliaCtx) {
+	var (
+		userSession session.UserSession
+		count       int
+		err         error
+	)
+
+	if userSession, err = ctx.GetSession(); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred sending an Email One-Time Code: %s", errStrUserSessionData)
+
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+		ctx.SetJSONError(messageUnableToSendEmailOneTimeCode)
+
+		return
+	}
+
+	if userSession.IsAnonymous() {
+		ctx.Logger.WithError(errUserAnonymous).Error("Error occurred sending an Email One-Time Code")
+
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+		ctx.SetJSONError(messageUnableToSendEmailOneTimeCode)
+
+		return
+	}
+
+	identity := userSession.Identity()
+
+	if identity.Email == "" {
+		ctx.Logger.Errorf("Error occurred sending an Email One-Time Code for user '%s': the user doesn't have an email address", userSession.Username)
+
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+		ctx.SetJSONError(messageUnableToSendEmailOneTimeCode)
+
+		return
+	}
+
+	config := ctx.Configuration.EmailOneTimeCode
+
+	if count, err = ctx.Providers.StorageProvider.CountOneTimeCodes(ctx, userSession.Username, model.OTCIntentSecondFactorEmail, ctx.Clock.Now().Add(-config.RateLimit.Period)); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred sending an Email One-Time Code for user '%s': error occurred counting the previously issued codes in the storage backend", userSession.Username)
+
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+		ctx.SetJSONError(messageUnableToSendEmailOneTimeCode)
+
+		return
+	}
+
+	if count >= config.RateLimit.Amount {
+		ctx.Logger.Errorf("Error occurred sending an Email One-Time Code for user '%s': the user has been issued %d codes within the last %s which exceeds the rate limit", userSession.Username, count, config.RateLimit.Period)
+
+		ctx.SetStatusCode(fasthttp.StatusTooManyRequests)
+		ctx.SetJSONError(messageEmailOneTimeCodeRateLimited)
+
+		return
+	}
+
+	var (
+		otp       *model.OneTimeCode
+		signature string
+	)
+
+	if otp, err = model.NewOneTimeCode(ctx, userSession.Username, config.Characters, config.CodeLifespan); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred sending an Email One-Time Code for user '%s': error occurred generating the code", userSession.Username)
+
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+		ctx.SetJSONError(messageUnableToSendEmailOneTimeCode)
+
+		return
+	}
+
+	otp.Intent = model.OTCIntentSecondFactorEmail
+
+	if signature, err = ctx.Providers.StorageProvider.SaveOneTimeCode(ctx, *otp); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred sending an Email One-Time Code for user '%s': error occurred saving the code to the storage backend", userSession.Username)
+
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+		ctx.SetJSONError(messageUnableToSendEmailOneTimeCode)
+
+		return
+	}
+
+	_, revocationURL := ctxOneTimeCodeRevocation(ctx, otp)
+
+	data := templates.EmailIdentityVerificationOTCValues{
+		Title:              "Sign in with a one-time code",
+		RevocationLinkURL:  revocationURL,
+		RevocationLinkText: "Revoke",
+		DisplayName:        identity.DisplayName,
+		RemoteIP:           ctx.RemoteIP().String(),
+		OneTimeCode:        string(otp.Code),
+	}
+
+	ctx.Logger.WithFields(map[string]any{"signature": signature, "id": otp.PublicID.String(), "username": identity.Username}).
+		Debug("Sending an email to user with an Email One-Time Code for second factor authentication")
+
+	if err = ctx.Providers.Notifier.Send(ctx, identity.Address(), data.Title, ctx.Providers.Templates.GetIdentityVerificationOTCEmailTemplate(), data); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred sending an Email One-Time Code for user '%s': error occurred sending the user the notification", userSession.Username)
+
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+		ctx.SetJSONError(messageUnableToSendEmailOneTimeCode)
+
+		return
+	}
+
+	ctx.ReplyOK()
+}
+
+// EmailOneTimeCodePOST validates an Email One-Time Code provided by the user and if it's valid consumes it and marks
+// the session as having completed second factor authentication.
+//
+//nolint:gocyclo
+func EmailOneTimeCodePOST(ctx *middlewares.AutheliaCtx) {
+	var (
+		userSession session.UserSession
+		bodyJSON    bodySignEmailRequest
+		code        *model.OneTimeCode
+		bannedUntil time.Time
+		err         error
+	)
+
+	if userSession, err = ctx.GetSession(); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred validating an Email One-Time Code authentication: %s", errStrUserSessionData)
+
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+		ctx.SetJSONError(messageMFAValidationFailed)
+
+		return
+	}
+
+	if userSession.IsAnonymous() {
+		ctx.Logger.WithError(errUserAnonymous).Error("Error occurred validating an Email One-Time Code authentication")
+
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+		ctx.SetJSONError(messageMFAValidationFailed)
+
+		return
+	}
+
+	if err = ctx.ParseBody(&bodyJSON); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred validating an Email One-Time Code authentication for user '%s': %s", userSession.Username, errStrReqBodyParse)
+
+		ctx.SetStatusCode(fasthttp.StatusBadRequest)
+		ctx.SetJSONError(messageMFAValidationFailed)
+
+		return
+	}
+
+	bodyJSON.Code = strings.TrimSpace(strings.ToUpper(bodyJSON.Code))
+
+	if n := len(bodyJSON.Code); n > schema.EmailOneTimeCodeCharactersMaximum {
+		ctx.Logger.Errorf("Error occurred validating an Email One-Time Code authentication for user '%s': expected maximum code length is %d but the user provided code was %d characters in length", userSession.Username, schema.EmailOneTimeCodeCharactersMaximum, n)
+
+		ctx.SetStatusCode(fasthttp.StatusBadRequest)
+		ctx.SetJSONError(messageMFAValidationFailed)
+
+		return
+	}
+
+	if bannedUntil, err = ctx.Providers.Regulator.Regulate(ctx, userSession.Username); err != nil {
+		switch {
+		case errors.Is(err, regulation.ErrUserIsBanned), errors.Is(err, regulation.ErrIPIsBanned):
+			_ = markAuthenticationAttempt(ctx, false, &bannedUntil, userSession.Username, regulation.AuthTypeEmail, nil)
+		case errors.Is(err, regulation.ErrUserIsLocked):
+			_ = markAuthenticationAttempt(ctx, false, &bannedUntil, userSession.Username, regulation.AuthTypeEmail, err)
+		default:
+			ctx.Logger.WithError(err).Errorf(logFmtErrRegulationFail, regulation.AuthTypeEmail, userSession.Username)
+		}
+
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+		ctx.SetJSONError(messageMFAValidationFailed)
+
+		return
+	}
+
+	if code, err = ctx.Providers.StorageProvider.LoadOneTimeCode(ctx, userSession.Username, model.OTCIntentSecondFactorEmail, bodyJSON.Code); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred validating an Email One-Time Code authentication for user '%s': error occurred retrieving the code from the storage backend", userSession.Username)
+
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+		ctx.SetJSONError(messageMFAValidationFailed)
+
+		return
+	}
+
+	if err = validateEmailOneTimeCode(ctx, code, bodyJSON.Code); err != nil {
+		_ = markAuthenticationAttempt(ctx, false, nil, userSession.Username, regulation.AuthTypeEmail, err)
+
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+		ctx.SetJSONError(messageMFAValidationFailed)
+
+		return
+	}
+
+	code.Consume(ctx)
+
+	if err = ctx.Providers.StorageProvider.ConsumeOneTimeCode(ctx, code); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred validating an Email One-Time Code authentication for user '%s': error occurred saving the consumption of the code to the storage backend", userSession.Username)
+
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+		ctx.SetJSONError(messageMFAValidationFailed)
+
+		return
+	}
+
+	if err = markAuthenticationAttempt(ctx, true, nil, userSession.Username, regulation.AuthTypeEmail, nil); err != nil {
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+		ctx.SetJSONError(messageMFAValidationFailed)
+
+		return
+	}
+
+	if err = ctx.RegenerateSession(); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred validating an Email One-Time Code authentication for user '%s': error regenerating the user session", userSession.Username)
+
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+		ctx.SetJSONError(messageMFAValidationFailed)
+
+		return
+	}
+
+	userSession.SetTwoFactorEmail(ctx.Clock.Now())
+
+	if err = ctx.SaveSession(userSession); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred validating an Email One-Time Code authentication for user '%s': %s", userSession.Username, errStrUserSessionDataSave)
+
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+		ctx.SetJSONError(messageMFAValidationFailed)
+
+		return
+	}
+
+	if bodyJSON.Workflow == workflowOpenIDConnect {
+		handleOIDCWorkflowResponse(ctx, &userSession, bodyJSON.TargetURL, bodyJSON.WorkflowID)
+	} else {
+		Handle2FAResponse(ctx, bodyJSON.TargetURL)
+	}
+}
+
+func validateEmailOneTimeCode(ctx *middlewares.AutheliaCtx, code *model.OneTimeCode, input string) (err error) {
+	switch {
+	case code == nil:
+		return fmt.Errorf("the code didn't match any recorded codes")
+	case code.ExpiresAt.Before(ctx.Clock.Now()):
+		return fmt.Errorf("the code has expired")
+	case code.RevokedAt.Valid:
+		return fmt.Errorf("the code has been revoked")
+	case code.ConsumedAt.Valid:
+		return fmt.Errorf("the code has already been consumed")
+	case code.Intent != model.OTCIntentSecondFactorEmail:
+		return fmt.Errorf("the code has the '%s' intent but the '%s' intent is required", code.Intent, model.OTCIntentSecondFactorEmail)
+	case subtle.ConstantTimeCompare(code.Code, []byte(input)) != 1:
+		return fmt.Errorf("the code does not match the code stored in the storage backend")
+	default:
+		return nil
+	}
+}
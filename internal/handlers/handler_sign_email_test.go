@@ -0,0 +1,371 @@
+package handlers
+
+import (
+	"fmt"
+	"net"
+	"net/mail"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+	"go.uber.org/mock/gomock"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/mocks"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/random"
+	"github.com/authelia/authelia/v4/internal/regulation"
+	"github.com/authelia/authelia/v4/internal/templates"
+)
+
+func TestEmailOneTimeCodePUT(t *testing.T) {
+	testCases := []struct {
+		name           string
+		anonymous      bool
+		emails         []string
+		setup          func(t *testing.T, mock *mocks.MockAutheliaCtx)
+		expected       string
+		expectedStatus int
+		expectedf      func(t *testing.T, mock *mocks.MockAutheliaCtx)
+	}{
+		{
+			"ShouldFailAnonymous",
+			true,
+			nil,
+			nil,
+			`{"status":"KO","message":"Unable to send the one-time code."}`,
+			fasthttp.StatusForbidden,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				AssertLogEntryMessageAndError(t, mock.Hook.LastEntry(), "Error occurred sending an Email One-Time Code", "user is anonymous")
+			},
+		},
+		{
+			"ShouldFailNoEmail",
+			false,
+			nil,
+			nil,
+			`{"status":"KO","message":"Unable to send the one-time code."}`,
+			fasthttp.StatusForbidden,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				AssertLogEntryMessageAndError(t, mock.Hook.LastEntry(), "Error occurred sending an Email One-Time Code for user 'john': the user doesn't have an email address", "")
+			},
+		},
+		{
+			"ShouldFailCountError",
+			false,
+			[]string{"john@example.com"},
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				mock.StorageMock.EXPECT().
+					CountOneTimeCodes(mock.Ctx, testUsername, model.OTCIntentSecondFactorEmail, mock.Clock.Now().Add(-time.Minute*10)).
+					Return(0, fmt.Errorf("failed to connect"))
+			},
+			`{"status":"KO","message":"Unable to send the one-time code."}`,
+			fasthttp.StatusForbidden,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				AssertLogEntryMessageAndError(t, mock.Hook.LastEntry(), "Error occurred sending an Email One-Time Code for user 'john': error occurred counting the previously issued codes in the storage backend", "failed to connect")
+			},
+		},
+		{
+			"ShouldFailRateLimited",
+			false,
+			[]string{"john@example.com"},
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				mock.StorageMock.EXPECT().
+					CountOneTimeCodes(mock.Ctx, testUsername, model.OTCIntentSecondFactorEmail, mock.Clock.Now().Add(-time.Minute*10)).
+					Return(3, nil)
+			},
+			`{"status":"KO","message":"Too many one-time codes have been requested, please retry later."}`,
+			fasthttp.StatusTooManyRequests,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				AssertLogEntryMessageAndError(t, mock.Hook.LastEntry(), "Error occurred sending an Email One-Time Code for user 'john': the user has been issued 3 codes within the last 10m0s which exceeds the rate limit", "")
+			},
+		},
+		{
+			"ShouldSendCode",
+			false,
+			[]string{"john@example.com"},
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				gomock.InOrder(
+					mock.StorageMock.EXPECT().
+						CountOneTimeCodes(mock.Ctx, testUsername, model.OTCIntentSecondFactorEmail, mock.Clock.Now().Add(-time.Minute*10)).
+						Return(2, nil),
+					mock.RandomMock.EXPECT().
+						Read(gomock.Any()).
+						SetArg(0, []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x22, 0x09, 0x10, 0x11, 0x12, 0x13, 0x14, 0x15}).
+						Return(16, nil),
+					mock.RandomMock.EXPECT().
+						BytesCustomErr(8, []byte(random.CharSetUnambiguousUpper)).
+						Return([]byte("ABC123AB"), nil),
+					mock.StorageMock.EXPECT().
+						SaveOneTimeCode(mock.Ctx, model.OneTimeCode{
+							PublicID:  uuid.Must(uuid.Parse("01020304-0506-4722-8910-111213141500")),
+							IssuedAt:  mock.Clock.Now(),
+							IssuedIP:  model.NewIP(net.ParseIP("0.0.0.0")),
+							ExpiresAt: mock.Clock.Now().Add(time.Minute * 5),
+							Username:  testUsername,
+							Intent:    model.OTCIntentSecondFactorEmail,
+							Code:      []byte("ABC123AB"),
+						}).
+						Return("abc123", nil),
+					mock.NotifierMock.EXPECT().Send(mock.Ctx, mail.Address{Name: testDisplayName, Address: "john@example.com"}, "Sign in with a one-time code", gomock.Any(), templates.EmailIdentityVerificationOTCValues{
+						Title:              "Sign in with a one-time code",
+						RevocationLinkURL:  "http://example.com/revoke/one-time-code?id=AQIDBAUGRyKJEBESExQVAA",
+						RevocationLinkText: "Revoke",
+						DisplayName:        testDisplayName,
+						RemoteIP:           "0.0.0.0",
+						OneTimeCode:        "ABC123AB",
+					}).
+						Return(nil),
+				)
+			},
+			`{"status":"OK"}`,
+			fasthttp.StatusOK,
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := mocks.NewMockAutheliaCtx(t)
+
+			defer mock.Close()
+
+			mock.Ctx.Configuration.EmailOneTimeCode.Enable = true
+			mock.Ctx.Configuration.EmailOneTimeCode.Characters = 8
+			mock.Ctx.Configuration.EmailOneTimeCode.CodeLifespan = time.Minute * 5
+			mock.Ctx.Configuration.EmailOneTimeCode.RateLimit.Amount = 3
+			mock.Ctx.Configuration.EmailOneTimeCode.RateLimit.Period = time.Minute * 10
+
+			mock.Ctx.Clock = &mock.Clock
+			mock.Ctx.Providers.Random = mock.RandomMock
+
+			if !tc.anonymous {
+				us, err := mock.Ctx.GetSession()
+
+				require.NoError(t, err)
+
+				us.Username = testUsername
+				us.DisplayName = testDisplayName
+				us.Emails = tc.emails
+				us.AuthenticationLevel = authentication.OneFactor
+
+				require.NoError(t, mock.Ctx.SaveSession(us))
+			}
+
+			if tc.setup != nil {
+				tc.setup(t, mock)
+			}
+
+			EmailOneTimeCodePUT(mock.Ctx)
+
+			assert.Equal(t, tc.expectedStatus, mock.Ctx.Response.StatusCode())
+			assert.Equal(t, tc.expected, string(mock.Ctx.Response.Body()))
+
+			if tc.expectedf != nil {
+				tc.expectedf(t, mock)
+			}
+		})
+	}
+}
+
+func TestEmailOneTimeCodePOST(t *testing.T) {
+	testCases := []struct {
+		name           string
+		anonymous      bool
+		have           string
+		setup          func(t *testing.T, mock *mocks.MockAutheliaCtx)
+		expected       string
+		expectedStatus int
+		expectedf      func(t *testing.T, mock *mocks.MockAutheliaCtx)
+	}{
+		{
+			"ShouldFailAnonymous",
+			true,
+			`{"code":"ABC123AB"}`,
+			nil,
+			`{"status":"KO","message":"Authentication failed, please retry later."}`,
+			fasthttp.StatusForbidden,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				AssertLogEntryMessageAndError(t, mock.Hook.LastEntry(), "Error occurred validating an Email One-Time Code authentication", "user is anonymous")
+			},
+		},
+		{
+			"ShouldFailBadBody",
+			false,
+			`not json`,
+			nil,
+			`{"status":"KO","message":"Authentication failed, please retry later."}`,
+			fasthttp.StatusBadRequest,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				AssertLogEntryMessageAndError(t, mock.Hook.LastEntry(), "Error occurred validating an Email One-Time Code authentication for user 'john': error parsing the request body", "unable to parse body: invalid character 'o' in literal null (expecting 'u')")
+			},
+		},
+		{
+			"ShouldFailCodeTooLong",
+			false,
+			`{"code":"ABCDEFGHIJKLMNOPQRSTUVWXYZ"}`,
+			nil,
+			`{"status":"KO","message":"Authentication failed, please retry later."}`,
+			fasthttp.StatusBadRequest,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				AssertLogEntryMessageAndError(t, mock.Hook.LastEntry(), "Error occurred validating an Email One-Time Code authentication for user 'john': expected maximum code length is 20 but the user provided code was 26 characters in length", "")
+			},
+		},
+		{
+			"ShouldFailLoadError",
+			false,
+			`{"code":"ABC123AB"}`,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				mock.StorageMock.EXPECT().
+					LoadOneTimeCode(mock.Ctx, testUsername, model.OTCIntentSecondFactorEmail, "ABC123AB").
+					Return(nil, fmt.Errorf("failed to connect"))
+			},
+			`{"status":"KO","message":"Authentication failed, please retry later."}`,
+			fasthttp.StatusForbidden,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				AssertLogEntryMessageAndError(t, mock.Hook.LastEntry(), "Error occurred validating an Email One-Time Code authentication for user 'john': error occurred retrieving the code from the storage backend", "failed to connect")
+			},
+		},
+		{
+			"ShouldFailInvalidCode",
+			false,
+			`{"code":"ABC123AC"}`,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				gomock.InOrder(
+					mock.StorageMock.EXPECT().
+						LoadOneTimeCode(mock.Ctx, testUsername, model.OTCIntentSecondFactorEmail, "ABC123AC").
+						Return(nil, nil),
+					mock.StorageMock.EXPECT().
+						AppendAuthenticationLog(mock.Ctx, model.AuthenticationAttempt{
+							Username:   testUsername,
+							Successful: false,
+							Time:       mock.Clock.Now(),
+							Type:       regulation.AuthTypeEmail,
+							RemoteIP:   model.NewNullIPFromString("0.0.0.0"),
+						}).
+						Return(nil),
+				)
+			},
+			`{"status":"KO","message":"Authentication failed, please retry later."}`,
+			fasthttp.StatusForbidden,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				AssertLogEntryMessageAndError(t, mock.Hook.LastEntry(), "Unsuccessful Email authentication attempt by user 'john'", "the code didn't match any recorded codes")
+			},
+		},
+		{
+			"ShouldFailExpiredCode",
+			false,
+			`{"code":"ABC123AB"}`,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				gomock.InOrder(
+					mock.StorageMock.EXPECT().
+						LoadOneTimeCode(mock.Ctx, testUsername, model.OTCIntentSecondFactorEmail, "ABC123AB").
+						Return(&model.OneTimeCode{
+							ID:        1,
+							ExpiresAt: mock.Clock.Now().Add(-time.Second),
+							Username:  testUsername,
+							Intent:    model.OTCIntentSecondFactorEmail,
+							Code:      []byte("ABC123AB"),
+						}, nil),
+					mock.StorageMock.EXPECT().
+						AppendAuthenticationLog(mock.Ctx, model.AuthenticationAttempt{
+							Username:   testUsername,
+							Successful: false,
+							Time:       mock.Clock.Now(),
+							Type:       regulation.AuthTypeEmail,
+							RemoteIP:   model.NewNullIPFromString("0.0.0.0"),
+						}).
+						Return(nil),
+				)
+			},
+			`{"status":"KO","message":"Authentication failed, please retry later."}`,
+			fasthttp.StatusForbidden,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				AssertLogEntryMessageAndError(t, mock.Hook.LastEntry(), "Unsuccessful Email authentication attempt by user 'john'", "the code has expired")
+			},
+		},
+		{
+			"ShouldSucceed",
+			false,
+			`{"code":" abc123ab "}`,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				code := &model.OneTimeCode{
+					ID:        1,
+					ExpiresAt: mock.Clock.Now().Add(time.Minute),
+					Username:  testUsername,
+					Intent:    model.OTCIntentSecondFactorEmail,
+					Code:      []byte("ABC123AB"),
+				}
+
+				gomock.InOrder(
+					mock.StorageMock.EXPECT().
+						LoadOneTimeCode(mock.Ctx, testUsername, model.OTCIntentSecondFactorEmail, "ABC123AB").
+						Return(code, nil),
+					mock.StorageMock.EXPECT().
+						ConsumeOneTimeCode(mock.Ctx, code).
+						Return(nil),
+					mock.StorageMock.EXPECT().
+						AppendAuthenticationLog(mock.Ctx, model.AuthenticationAttempt{
+							Username:   testUsername,
+							Successful: true,
+							Time:       mock.Clock.Now(),
+							Type:       regulation.AuthTypeEmail,
+							RemoteIP:   model.NewNullIPFromString("0.0.0.0"),
+						}).
+						Return(nil),
+				)
+			},
+			`{"status":"OK"}`,
+			fasthttp.StatusOK,
+			func(t *testing.T, mock *mocks.MockAutheliaCtx) {
+				us, err := mock.Ctx.GetSession()
+
+				require.NoError(t, err)
+
+				assert.Equal(t, authentication.TwoFactor, us.AuthenticationLevel)
+				assert.True(t, us.AuthenticationMethodRefs.Email)
+				assert.False(t, us.AuthenticationMethodRefs.TOTP)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := mocks.NewMockAutheliaCtx(t)
+
+			defer mock.Close()
+
+			mock.Clock.Set(time.Unix(1701295903, 0))
+			mock.Ctx.Clock = &mock.Clock
+
+			if !tc.anonymous {
+				us, err := mock.Ctx.GetSession()
+
+				require.NoError(t, err)
+
+				us.Username = testUsername
+				us.AuthenticationLevel = authentication.OneFactor
+
+				require.NoError(t, mock.Ctx.SaveSession(us))
+			}
+
+			if tc.setup != nil {
+				tc.setup(t, mock)
+			}
+
+			mock.Ctx.Request.SetBodyString(tc.have)
+
+			EmailOneTimeCodePOST(mock.Ctx)
+
+			assert.Equal(t, tc.expectedStatus, mock.Ctx.Response.StatusCode())
+			assert.Equal(t, tc.expected, string(mock.Ctx.Response.Body()))
+
+			if tc.expectedf != nil {
+				tc.expectedf(t, mock)
+			}
+		})
+	}
+}
@@ -83,7 +83,58 @@ func (s *HandlerSignTOTPSuite) TestShouldRedirectUserToDefaultURL() {
 			Return(nil),
 		s.mock.StorageMock.
 			EXPECT().
-			UpdateTOTPConfigurationSignIn(s.mock.Ctx, gomock.Any(), gomock.Any()).
+			UpdateTOTPConfigurationSignIn(s.mock.Ctx, gomock.Any(), gomock.Any(), uint64(0)).
+			Return(nil),
+	)
+
+	s.mock.Ctx.Configuration.Session.Cookies[0].DefaultRedirectionURL = testRedirectionURL
+
+	bodyBytes, err := json.Marshal(bodySignTOTPRequest{
+		Token: "123456",
+	})
+	s.Require().NoError(err)
+	s.mock.Ctx.Request.SetBody(bodyBytes)
+
+	TimeBasedOneTimePasswordPOST(s.mock.Ctx)
+	s.mock.Assert200OK(s.T(), redirectResponse{
+		Redirect: testRedirectionURLString,
+	})
+}
+
+func (s *HandlerSignTOTPSuite) TestShouldAdvanceHOTPCounter() {
+	config := model.TOTPConfiguration{ID: 1, Username: testUsername, Type: model.OneTimePasswordTypeHOTP, Digits: 6, Secret: []byte("secret"), Counter: 5, Algorithm: "SHA1"}
+
+	gomock.InOrder(
+		s.mock.StorageMock.
+			EXPECT().
+			LoadTOTPConfiguration(s.mock.Ctx, gomock.Any()).
+			Return(&config, nil),
+		s.mock.TOTPMock.
+			EXPECT().
+			Validate(s.mock.Ctx, gomock.Eq("123456"), gomock.Eq(&config)).
+			Return(true, uint64(7), nil),
+		s.mock.StorageMock.
+			EXPECT().
+			ExistsTOTPHistory(s.mock.Ctx, testUsername, uint64(7)).
+			Return(false, nil),
+		s.mock.StorageMock.
+			EXPECT().
+			SaveTOTPHistory(s.mock.Ctx, testUsername, uint64(7)).
+			Return(nil),
+		s.mock.StorageMock.
+			EXPECT().
+			AppendAuthenticationLog(s.mock.Ctx, gomock.Eq(model.AuthenticationAttempt{
+				Username:   testUsername,
+				Successful: true,
+				Banned:     false,
+				Time:       s.mock.Clock.Now(),
+				Type:       regulation.AuthTypeTOTP,
+				RemoteIP:   model.NewNullIPFromString("0.0.0.0"),
+			})).
+			Return(nil),
+		s.mock.StorageMock.
+			EXPECT().
+			UpdateTOTPConfigurationSignIn(s.mock.Ctx, 1, gomock.Any(), uint64(8)).
 			Return(nil),
 	)
 
@@ -133,7 +184,7 @@ func (s *HandlerSignTOTPSuite) TestShouldFailWhenTOTPSignInInfoFailsToUpdate() {
 			})),
 		s.mock.StorageMock.
 			EXPECT().
-			UpdateTOTPConfigurationSignIn(s.mock.Ctx, gomock.Any(), gomock.Any()).
+			UpdateTOTPConfigurationSignIn(s.mock.Ctx, gomock.Any(), gomock.Any(), uint64(0)).
 			Return(errors.New("failed to perform update")),
 	)
 
@@ -182,7 +233,7 @@ func (s *HandlerSignTOTPSuite) TestShouldNotReturnRedirectURL() {
 			Return(nil),
 		s.mock.StorageMock.
 			EXPECT().
-			UpdateTOTPConfigurationSignIn(s.mock.Ctx, gomock.Any(), gomock.Any()).
+			UpdateTOTPConfigurationSignIn(s.mock.Ctx, gomock.Any(), gomock.Any(), uint64(0)).
 			Return(nil),
 	)
 
@@ -237,7 +288,7 @@ func (s *HandlerSignTOTPSuite) TestShouldRedirectUserToSafeTargetURL() {
 			Return(nil),
 		s.mock.StorageMock.
 			EXPECT().
-			UpdateTOTPConfigurationSignIn(s.mock.Ctx, gomock.Any(), gomock.Any()).
+			UpdateTOTPConfigurationSignIn(s.mock.Ctx, gomock.Any(), gomock.Any(), uint64(0)).
 			Return(nil),
 	)
 
@@ -298,7 +349,7 @@ func (s *HandlerSignTOTPSuite) TestShouldRedirectUserToSafeTargetURLDisableReuse
 			Return(nil),
 		s.mock.StorageMock.
 			EXPECT().
-			UpdateTOTPConfigurationSignIn(s.mock.Ctx, gomock.Any(), gomock.Any()).
+			UpdateTOTPConfigurationSignIn(s.mock.Ctx, gomock.Any(), gomock.Any(), uint64(0)).
 			Return(nil),
 	)
 
@@ -346,7 +397,7 @@ func (s *HandlerSignTOTPSuite) TestShouldNotRedirectToUnsafeURL() {
 			Return(nil),
 		s.mock.StorageMock.
 			EXPECT().
-			UpdateTOTPConfigurationSignIn(s.mock.Ctx, gomock.Any(), gomock.Any()).
+			UpdateTOTPConfigurationSignIn(s.mock.Ctx, gomock.Any(), gomock.Any(), uint64(0)).
 			Return(nil),
 	)
 
@@ -395,7 +446,7 @@ func (s *HandlerSignTOTPSuite) TestShouldRegenerateSessionForPreventingSessionFi
 			Return(nil),
 		s.mock.StorageMock.
 			EXPECT().
-			UpdateTOTPConfigurationSignIn(s.mock.Ctx, gomock.Any(), gomock.Any()).
+			UpdateTOTPConfigurationSignIn(s.mock.Ctx, gomock.Any(), gomock.Any(), uint64(0)).
 			Return(nil),
 	)
 
@@ -801,7 +852,7 @@ func (s *HandlerSignTOTPSuite) TestShouldReturnErrorOnInvalidTokenLength() {
 		res[0][1],
 		string(s.mock.Ctx.Request.Header.Cookie("authelia_session")))
 
-	AssertLogEntryMessageAndError(s.T(), s.mock.Hook.LastEntry(), "Error occurred validating a TOTP authentication for user 'john': expected code length is 6 or 8 but the user provided code was 3 characters in length", "")
+	AssertLogEntryMessageAndError(s.T(), s.mock.Hook.LastEntry(), "Error occurred validating a TOTP authentication for user 'john': expected code length is 5, 6, or 8 but the user provided code was 3 characters in length", "")
 }
 
 func TestRunHandlerSignTOTPSuite(t *testing.T) {
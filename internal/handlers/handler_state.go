@@ -23,6 +23,7 @@ func StateGET(ctx *middlewares.AutheliaCtx) {
 	stateResponse := StateResponse{
 		Username:            userSession.Username,
 		AuthenticationLevel: userSession.AuthenticationLevel,
+		AMR:                 userSession.AuthenticationMethodRefs.MarshalRFC8176(),
 	}
 
 	if uri := ctx.GetDefaultRedirectionURL(); uri != nil {
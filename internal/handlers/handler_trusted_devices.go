@@ -0,0 +1,350 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/session"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// trustedDeviceCookieValue is the encrypted value of the trusted device cookie.
+type trustedDeviceCookieValue struct {
+	ID       uuid.UUID `json:"id"`
+	Username string    `json:"username"`
+	Secret   string    `json:"secret"`
+}
+
+// TrustedDevicePOST trusts the current device for the user after they've completed second factor authentication with
+// a second factor method.
+func TrustedDevicePOST(ctx *middlewares.AutheliaCtx) {
+	var (
+		userSession session.UserSession
+		device      *model.TrustedDevice
+		err         error
+	)
+
+	if userSession, err = ctx.GetSession(); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred trusting device: %s", errStrUserSessionData)
+
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	if userSession.IsAnonymous() {
+		ctx.Logger.WithError(errUserAnonymous).Error("Error occurred trusting device")
+
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	if userSession.AuthenticationLevel != authentication.TwoFactor || userSession.TrustedDevice {
+		ctx.Logger.Errorf("Error occurred trusting device for user '%s': the user has not completed second factor authentication with a second factor method", userSession.Username)
+
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	// Revoke the device the browser was previously trusted as if any, this only occurs when the previously trusted device
+	// was revoked, expired, or the rule required the second factor regardless.
+	if value, err := getTrustedDeviceCookie(ctx); err == nil && value != nil && value.Username == userSession.Username {
+		if _, err = ctx.Providers.StorageProvider.RevokeTrustedDevice(ctx, userSession.Username, value.ID, ctx.Clock.Now()); err != nil {
+			ctx.Logger.WithError(err).Errorf("Error occurred revoking the previously trusted device for user '%s'", userSession.Username)
+		}
+	}
+
+	if device, err = model.NewTrustedDevice(ctx, userSession.Username, string(ctx.UserAgent()), ctx.Configuration.TrustedDevice.Lifespan); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred trusting device for user '%s': error occurred generating the trusted device", userSession.Username)
+
+		ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	if err = ctx.Providers.StorageProvider.SaveTrustedDevice(ctx, *device); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred trusting device for user '%s': error occurred saving the trusted device to the storage backend", userSession.Username)
+
+		ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	if err = setTrustedDeviceCookie(ctx, device); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred trusting device for user '%s': error occurred setting the trusted device cookie", userSession.Username)
+
+		ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	ctx.Logger.Debugf("User '%s' trusted the device with id '%s' until %s", userSession.Username, device.PublicID, device.ExpiresAt.Format(time.RFC3339))
+
+	ctx.ReplyOK()
+}
+
+// TrustedDevicesGET returns the trusted devices of the current user.
+func TrustedDevicesGET(ctx *middlewares.AutheliaCtx) {
+	var (
+		userSession session.UserSession
+		devices     []model.TrustedDevice
+		err         error
+	)
+
+	if userSession, err = ctx.GetSession(); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred loading trusted devices: %s", errStrUserSessionData)
+
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	if userSession.IsAnonymous() {
+		ctx.Logger.WithError(errUserAnonymous).Error("Error occurred loading trusted devices")
+
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	if devices, err = ctx.Providers.StorageProvider.LoadTrustedDevices(ctx, userSession.Username, ctx.Clock.Now()); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred loading trusted devices for user '%s': error occurred loading the trusted devices from the storage backend", userSession.Username)
+
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	current := uuid.Nil
+
+	if value, err := getTrustedDeviceCookie(ctx); err == nil && value != nil {
+		current = value.ID
+	}
+
+	data := make([]model.TrustedDeviceData, len(devices))
+
+	for i, device := range devices {
+		data[i] = device.ToData()
+		data[i].Current = device.PublicID == current
+	}
+
+	if err = ctx.SetJSONBody(data); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred loading trusted devices for user '%s': %s", userSession.Username, errStrRespBody)
+	}
+}
+
+// TrustedDeviceDELETE revokes a trusted device of the current user.
+func TrustedDeviceDELETE(ctx *middlewares.AutheliaCtx) {
+	var (
+		userSession session.UserSession
+		id          uuid.UUID
+		revoked     bool
+		err         error
+	)
+
+	if userSession, err = ctx.GetSession(); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred revoking trusted device: %s", errStrUserSessionData)
+
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	if userSession.IsAnonymous() {
+		ctx.Logger.WithError(errUserAnonymous).Error("Error occurred revoking trusted device")
+
+		ctx.SetStatusCode(fasthttp.StatusForbidden)
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	if id, err = getTrustedDeviceIDFromContext(ctx); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred revoking trusted device for user '%s': error occurred trying to determine the device ID", userSession.Username)
+
+		ctx.SetStatusCode(fasthttp.StatusBadRequest)
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	if revoked, err = ctx.Providers.StorageProvider.RevokeTrustedDevice(ctx, userSession.Username, id, ctx.Clock.Now()); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred revoking trusted device for user '%s': error occurred revoking the trusted device in the storage backend", userSession.Username)
+
+		ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	if !revoked {
+		ctx.Logger.Errorf("Error occurred revoking trusted device for user '%s': the trusted device with id '%s' does not exist or was already revoked", userSession.Username, id)
+
+		ctx.SetStatusCode(fasthttp.StatusNotFound)
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	if value, err := getTrustedDeviceCookie(ctx); err == nil && value != nil && value.ID == id {
+		deleteTrustedDeviceCookie(ctx)
+	}
+
+	ctx.ReplyOK()
+}
+
+// handleTrustedDeviceFirstFactor returns true if the request has a trusted device cookie which is valid for the user
+// that just completed first factor authentication, in which case the second factor is satisfied. Errors are logged
+// rather than returned as they must not cause the first factor authentication itself to fail.
+func handleTrustedDeviceFirstFactor(ctx *middlewares.AutheliaCtx, username string) (trusted bool) {
+	if !ctx.Configuration.TrustedDevice.Enable {
+		return false
+	}
+
+	var (
+		value  *trustedDeviceCookieValue
+		device *model.TrustedDevice
+		err    error
+	)
+
+	if value, err = getTrustedDeviceCookie(ctx); err != nil {
+		ctx.Logger.WithError(err).Debugf("Error occurred checking the trusted device for user '%s'", username)
+
+		deleteTrustedDeviceCookie(ctx)
+
+		return false
+	}
+
+	// The cookie belongs to a different user or doesn't exist, it's left intact as this browser may be shared.
+	if value == nil || value.Username != username {
+		return false
+	}
+
+	if device, err = ctx.Providers.StorageProvider.LoadTrustedDevice(ctx, username, value.ID, []byte(value.Secret)); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred checking the trusted device for user '%s': error occurred loading the trusted device from the storage backend", username)
+
+		return false
+	}
+
+	if device == nil || !device.Valid(ctx.Clock.Now()) {
+		ctx.Logger.Debugf("The trusted device with id '%s' for user '%s' does not exist, has expired, or has been revoked", value.ID, username)
+
+		deleteTrustedDeviceCookie(ctx)
+
+		return false
+	}
+
+	device.UpdateSignIn(ctx)
+
+	if err = ctx.Providers.StorageProvider.UpdateTrustedDeviceSignIn(ctx, *device); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred updating the trusted device with id '%s' for user '%s'", device.PublicID, username)
+	}
+
+	ctx.Logger.Debugf("User '%s' satisfied the second factor with the trusted device with id '%s'", username, device.PublicID)
+
+	return true
+}
+
+func getTrustedDeviceIDFromContext(ctx *middlewares.AutheliaCtx) (id uuid.UUID, err error) {
+	value, ok := ctx.UserValue(trustedDeviceUserValueID).(string)
+	if !ok {
+		return uuid.Nil, fmt.Errorf("error occurred retrieving the trusted device ID from context: the user value wasn't set")
+	}
+
+	if id, err = uuid.Parse(value); err != nil {
+		return uuid.Nil, fmt.Errorf("error occurred retrieving the trusted device ID from context: failed to parse '%s' as a uuid: %w", value, err)
+	}
+
+	return id, nil
+}
+
+func trustedDeviceCookieKey(ctx *middlewares.AutheliaCtx) *[32]byte {
+	key := sha256.Sum256([]byte(ctx.Configuration.TrustedDevice.Secret))
+
+	return &key
+}
+
+func setTrustedDeviceCookie(ctx *middlewares.AutheliaCtx, device *model.TrustedDevice) (err error) {
+	var data, encrypted []byte
+
+	if data, err = json.Marshal(&trustedDeviceCookieValue{ID: device.PublicID, Username: device.Username, Secret: string(device.Secret)}); err != nil {
+		return err
+	}
+
+	if encrypted, err = utils.Encrypt(data, trustedDeviceCookieKey(ctx)); err != nil {
+		return err
+	}
+
+	cookie := fasthttp.AcquireCookie()
+
+	defer fasthttp.ReleaseCookie(cookie)
+
+	cookie.SetKey(trustedDeviceCookieName)
+	cookie.SetValue(base64.RawURLEncoding.EncodeToString(encrypted))
+	cookie.SetPath("/")
+	cookie.SetExpire(device.ExpiresAt)
+	cookie.SetHTTPOnly(true)
+	cookie.SetSecure(true)
+	cookie.SetSameSite(fasthttp.CookieSameSiteLaxMode)
+
+	ctx.Response.Header.SetCookie(cookie)
+
+	return nil
+}
+
+// getTrustedDeviceCookie returns the decrypted value of the trusted device cookie, or nil if the request doesn't have
+// the cookie.
+func getTrustedDeviceCookie(ctx *middlewares.AutheliaCtx) (value *trustedDeviceCookieValue, err error) {
+	raw := ctx.Request.Header.Cookie(trustedDeviceCookieName)
+
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	var encrypted, data []byte
+
+	if encrypted, err = base64.RawURLEncoding.DecodeString(string(raw)); err != nil {
+		return nil, fmt.Errorf("error decoding the trusted device cookie: %w", err)
+	}
+
+	if data, err = utils.Decrypt(encrypted, trustedDeviceCookieKey(ctx)); err != nil {
+		return nil, fmt.Errorf("error decrypting the trusted device cookie: %w", err)
+	}
+
+	value = &trustedDeviceCookieValue{}
+
+	if err = json.Unmarshal(data, value); err != nil {
+		return nil, fmt.Errorf("error unmarshalling the trusted device cookie: %w", err)
+	}
+
+	if value.ID == uuid.Nil || value.Username == "" || value.Secret == "" {
+		return nil, errors.New("error validating the trusted device cookie: the cookie is missing required values")
+	}
+
+	return value, nil
+}
+
+func deleteTrustedDeviceCookie(ctx *middlewares.AutheliaCtx) {
+	ctx.Response.Header.DelClientCookie(trustedDeviceCookieName)
+}
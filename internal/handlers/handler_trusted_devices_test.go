@@ -0,0 +1,381 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"github.com/valyala/fasthttp"
+	"go.uber.org/mock/gomock"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/mocks"
+	"github.com/authelia/authelia/v4/internal/model"
+)
+
+const (
+	testTrustedDeviceSecret = "abcdefghijklmnopqrstuvwxyz0123456789"
+)
+
+var (
+	testTrustedDeviceID = uuid.MustParse("2f1e7a3b-5c4d-4e6f-8a9b-0c1d2e3f4a5b")
+)
+
+type TrustedDeviceSuite struct {
+	suite.Suite
+
+	mock *mocks.MockAutheliaCtx
+}
+
+func (s *TrustedDeviceSuite) SetupTest() {
+	s.mock = mocks.NewMockAutheliaCtx(s.T())
+
+	s.mock.Ctx.Configuration.TrustedDevice.Enable = true
+	s.mock.Ctx.Configuration.TrustedDevice.Secret = testTrustedDeviceSecret
+	s.mock.Ctx.Configuration.TrustedDevice.Lifespan = time.Hour * 24 * 30
+
+	s.mock.Ctx.Request.Header.Set(fasthttp.HeaderUserAgent, "Mozilla/5.0 (X11; Linux x86_64)")
+}
+
+func (s *TrustedDeviceSuite) TearDownTest() {
+	s.mock.Close()
+}
+
+func (s *TrustedDeviceSuite) setSession(level authentication.Level, trusted bool) {
+	userSession, err := s.mock.Ctx.GetSession()
+	s.Require().NoError(err)
+
+	userSession.Username = testUsername
+	userSession.AuthenticationLevel = level
+	userSession.AuthenticationMethodRefs.UsernameAndPassword = true
+	userSession.AuthenticationMethodRefs.TOTP = level == authentication.TwoFactor && !trusted
+	userSession.TrustedDevice = trusted
+
+	s.Require().NoError(s.mock.Ctx.SaveSession(userSession))
+}
+
+// setRequestCookie sets the trusted device cookie on the request using the value the server would have set.
+func (s *TrustedDeviceSuite) setRequestCookie(username, secret string) {
+	s.Require().NoError(setTrustedDeviceCookie(s.mock.Ctx, &model.TrustedDevice{
+		PublicID:  testTrustedDeviceID,
+		Username:  username,
+		Secret:    []byte(secret),
+		ExpiresAt: s.mock.Clock.Now().Add(time.Hour),
+	}))
+
+	cookie := &fasthttp.Cookie{}
+	cookie.SetKey(trustedDeviceCookieName)
+
+	s.Require().True(s.mock.Ctx.Response.Header.Cookie(cookie))
+
+	s.mock.Ctx.Request.Header.SetCookie(trustedDeviceCookieName, string(cookie.Value()))
+	s.mock.Ctx.Response.Header.DelAllCookies()
+}
+
+func (s *TrustedDeviceSuite) responseCookie() (cookie *fasthttp.Cookie, ok bool) {
+	cookie = &fasthttp.Cookie{}
+	cookie.SetKey(trustedDeviceCookieName)
+
+	return cookie, s.mock.Ctx.Response.Header.Cookie(cookie)
+}
+
+func (s *TrustedDeviceSuite) TestShouldTrustDevice() {
+	s.setSession(authentication.TwoFactor, false)
+
+	var saved model.TrustedDevice
+
+	s.mock.StorageMock.EXPECT().
+		SaveTrustedDevice(s.mock.Ctx, gomock.Any()).
+		DoAndReturn(func(_ any, device model.TrustedDevice) error {
+			saved = device
+
+			return nil
+		})
+
+	TrustedDevicePOST(s.mock.Ctx)
+
+	s.mock.Assert200OK(s.T(), nil)
+
+	s.Equal(testUsername, saved.Username)
+	s.Equal("Mozilla/5.0 (X11; Linux x86_64)", saved.Description)
+	s.Len(saved.Secret, model.TrustedDeviceSecretLength)
+	s.True(s.mock.Clock.Now().Add(time.Hour * 24 * 30).Equal(saved.ExpiresAt))
+
+	cookie, ok := s.responseCookie()
+	s.Require().True(ok)
+
+	s.True(cookie.HTTPOnly())
+	s.True(cookie.Secure())
+
+	s.mock.Ctx.Request.Header.SetCookie(trustedDeviceCookieName, string(cookie.Value()))
+
+	value, err := getTrustedDeviceCookie(s.mock.Ctx)
+	s.Require().NoError(err)
+	s.Require().NotNil(value)
+
+	s.Equal(saved.PublicID, value.ID)
+	s.Equal(testUsername, value.Username)
+	s.Equal(string(saved.Secret), value.Secret)
+}
+
+func (s *TrustedDeviceSuite) TestShouldTrustDeviceRevokingPrevious() {
+	s.setSession(authentication.TwoFactor, false)
+	s.setRequestCookie(testUsername, "previous")
+
+	gomock.InOrder(
+		s.mock.StorageMock.EXPECT().
+			RevokeTrustedDevice(s.mock.Ctx, testUsername, testTrustedDeviceID, s.mock.Clock.Now()).
+			Return(true, nil),
+		s.mock.StorageMock.EXPECT().
+			SaveTrustedDevice(s.mock.Ctx, gomock.Any()).
+			Return(nil),
+	)
+
+	TrustedDevicePOST(s.mock.Ctx)
+
+	s.mock.Assert200OK(s.T(), nil)
+}
+
+func (s *TrustedDeviceSuite) TestShouldNotTrustDeviceOneFactor() {
+	s.setSession(authentication.OneFactor, false)
+
+	TrustedDevicePOST(s.mock.Ctx)
+
+	s.mock.Assert403KO(s.T(), messageOperationFailed)
+	AssertLogEntryMessageAndError(s.T(), s.mock.Hook.LastEntry(), "Error occurred trusting device for user 'john': the user has not completed second factor authentication with a second factor method", "")
+
+	_, ok := s.responseCookie()
+	s.False(ok)
+}
+
+func (s *TrustedDeviceSuite) TestShouldNotTrustDeviceFromTrustedDevice() {
+	s.setSession(authentication.TwoFactor, true)
+
+	TrustedDevicePOST(s.mock.Ctx)
+
+	s.mock.Assert403KO(s.T(), messageOperationFailed)
+}
+
+func (s *TrustedDeviceSuite) TestShouldNotTrustDeviceStorageError() {
+	s.setSession(authentication.TwoFactor, false)
+
+	s.mock.StorageMock.EXPECT().
+		SaveTrustedDevice(s.mock.Ctx, gomock.Any()).
+		Return(errors.New("bad conn"))
+
+	TrustedDevicePOST(s.mock.Ctx)
+
+	s.mock.Assert500KO(s.T(), messageOperationFailed)
+	AssertLogEntryMessageAndError(s.T(), s.mock.Hook.LastEntry(), "Error occurred trusting device for user 'john': error occurred saving the trusted device to the storage backend", "bad conn")
+
+	_, ok := s.responseCookie()
+	s.False(ok)
+}
+
+func (s *TrustedDeviceSuite) TestShouldListTrustedDevices() {
+	s.setSession(authentication.OneFactor, false)
+	s.setRequestCookie(testUsername, "secret")
+
+	other := uuid.MustParse("7d8c9b0a-1e2f-4a3b-9c4d-5e6f7a8b9c0d")
+
+	s.mock.StorageMock.EXPECT().
+		LoadTrustedDevices(s.mock.Ctx, testUsername, s.mock.Clock.Now()).
+		Return([]model.TrustedDevice{
+			{PublicID: testTrustedDeviceID, Username: testUsername, Description: "Current", CreatedIP: model.NewIP(nil)},
+			{PublicID: other, Username: testUsername, Description: "Other", CreatedIP: model.NewIP(nil)},
+		}, nil)
+
+	TrustedDevicesGET(s.mock.Ctx)
+
+	var data []model.TrustedDeviceData
+
+	s.mock.GetResponseData(s.T(), &data)
+
+	s.Require().Len(data, 2)
+
+	s.Equal(testTrustedDeviceID.String(), data[0].ID)
+	s.True(data[0].Current)
+	s.Equal(other.String(), data[1].ID)
+	s.False(data[1].Current)
+}
+
+func (s *TrustedDeviceSuite) TestShouldRevokeTrustedDevice() {
+	s.setSession(authentication.OneFactor, false)
+	s.setRequestCookie(testUsername, "secret")
+
+	s.mock.Ctx.SetUserValue(trustedDeviceUserValueID, testTrustedDeviceID.String())
+
+	s.mock.StorageMock.EXPECT().
+		RevokeTrustedDevice(s.mock.Ctx, testUsername, testTrustedDeviceID, s.mock.Clock.Now()).
+		Return(true, nil)
+
+	TrustedDeviceDELETE(s.mock.Ctx)
+
+	s.mock.Assert200OK(s.T(), nil)
+
+	cookie, ok := s.responseCookie()
+	s.Require().True(ok)
+	s.Empty(cookie.Value())
+}
+
+func (s *TrustedDeviceSuite) TestShouldNotRevokeTrustedDeviceNotFound() {
+	s.setSession(authentication.OneFactor, false)
+
+	s.mock.Ctx.SetUserValue(trustedDeviceUserValueID, testTrustedDeviceID.String())
+
+	s.mock.StorageMock.EXPECT().
+		RevokeTrustedDevice(s.mock.Ctx, testUsername, testTrustedDeviceID, s.mock.Clock.Now()).
+		Return(false, nil)
+
+	TrustedDeviceDELETE(s.mock.Ctx)
+
+	s.mock.Assert404KO(s.T(), messageOperationFailed)
+}
+
+func (s *TrustedDeviceSuite) TestShouldNotRevokeTrustedDeviceInvalidID() {
+	s.setSession(authentication.OneFactor, false)
+
+	s.mock.Ctx.SetUserValue(trustedDeviceUserValueID, "abc")
+
+	TrustedDeviceDELETE(s.mock.Ctx)
+
+	s.mock.AssertKO(s.T(), messageOperationFailed, fasthttp.StatusBadRequest)
+	AssertLogEntryMessageAndError(s.T(), s.mock.Hook.LastEntry(), "Error occurred revoking trusted device for user 'john': error occurred trying to determine the device ID", "error occurred retrieving the trusted device ID from context: failed to parse 'abc' as a uuid: invalid UUID length: 3")
+}
+
+func (s *TrustedDeviceSuite) TestShouldSatisfySecondFactorWithTrustedDevice() {
+	s.setRequestCookie(testUsername, "secret")
+
+	device := &model.TrustedDevice{
+		ID:        1,
+		PublicID:  testTrustedDeviceID,
+		Username:  testUsername,
+		ExpiresAt: s.mock.Clock.Now().Add(time.Hour),
+	}
+
+	gomock.InOrder(
+		s.mock.StorageMock.EXPECT().
+			LoadTrustedDevice(s.mock.Ctx, testUsername, testTrustedDeviceID, []byte("secret")).
+			Return(device, nil),
+		s.mock.StorageMock.EXPECT().
+			UpdateTrustedDeviceSignIn(s.mock.Ctx, gomock.Any()).
+			DoAndReturn(func(_ any, device model.TrustedDevice) error {
+				s.True(device.LastUsedAt.Valid)
+				s.NotNil(device.LastUsedIP.IP)
+
+				return nil
+			}),
+	)
+
+	s.True(handleTrustedDeviceFirstFactor(s.mock.Ctx, testUsername))
+}
+
+func (s *TrustedDeviceSuite) TestShouldNotSatisfySecondFactorWithTrustedDevice() {
+	testCases := []struct {
+		name    string
+		setup   func(s *TrustedDeviceSuite)
+		deleted bool
+	}{
+		{
+			"ShouldNotSatisfyDisabled",
+			func(s *TrustedDeviceSuite) {
+				s.setRequestCookie(testUsername, "secret")
+
+				s.mock.Ctx.Configuration.TrustedDevice.Enable = false
+			},
+			false,
+		},
+		{
+			"ShouldNotSatisfyNoCookie",
+			func(s *TrustedDeviceSuite) {},
+			false,
+		},
+		{
+			"ShouldNotSatisfyOtherUser",
+			func(s *TrustedDeviceSuite) {
+				s.setRequestCookie("harry", "secret")
+			},
+			false,
+		},
+		{
+			"ShouldNotSatisfyInvalidCookie",
+			func(s *TrustedDeviceSuite) {
+				s.mock.Ctx.Request.Header.SetCookie(trustedDeviceCookieName, "abc")
+			},
+			true,
+		},
+		{
+			"ShouldNotSatisfyWrongSecret",
+			func(s *TrustedDeviceSuite) {
+				s.setRequestCookie(testUsername, "secret")
+
+				s.mock.Ctx.Configuration.TrustedDevice.Secret = "zyxwvutsrqponmlkjihgfedcba9876543210"
+			},
+			true,
+		},
+		{
+			"ShouldNotSatisfyNotFound",
+			func(s *TrustedDeviceSuite) {
+				s.setRequestCookie(testUsername, "secret")
+
+				s.mock.StorageMock.EXPECT().
+					LoadTrustedDevice(s.mock.Ctx, testUsername, testTrustedDeviceID, []byte("secret")).
+					Return(nil, nil)
+			},
+			true,
+		},
+		{
+			"ShouldNotSatisfyRevoked",
+			func(s *TrustedDeviceSuite) {
+				s.setRequestCookie(testUsername, "secret")
+
+				s.mock.StorageMock.EXPECT().
+					LoadTrustedDevice(s.mock.Ctx, testUsername, testTrustedDeviceID, []byte("secret")).
+					Return(&model.TrustedDevice{
+						PublicID:  testTrustedDeviceID,
+						Username:  testUsername,
+						ExpiresAt: s.mock.Clock.Now().Add(time.Hour),
+						RevokedAt: sql.NullTime{Valid: true, Time: s.mock.Clock.Now()},
+					}, nil)
+			},
+			true,
+		},
+		{
+			"ShouldNotSatisfyStorageError",
+			func(s *TrustedDeviceSuite) {
+				s.setRequestCookie(testUsername, "secret")
+
+				s.mock.StorageMock.EXPECT().
+					LoadTrustedDevice(s.mock.Ctx, testUsername, testTrustedDeviceID, []byte("secret")).
+					Return(nil, errors.New("bad conn"))
+			},
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.TearDownTest()
+			s.SetupTest()
+
+			tc.setup(s)
+
+			s.False(handleTrustedDeviceFirstFactor(s.mock.Ctx, testUsername))
+
+			cookie, ok := s.responseCookie()
+
+			s.Equal(tc.deleted, ok)
+
+			if ok {
+				s.Empty(cookie.Value())
+			}
+		})
+	}
+}
+
+func TestRunTrustedDeviceSuite(t *testing.T) {
+	suite.Run(t, &TrustedDeviceSuite{})
+}
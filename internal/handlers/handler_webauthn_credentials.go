@@ -12,6 +12,7 @@ import (
 	"github.com/authelia/authelia/v4/internal/model"
 	"github.com/authelia/authelia/v4/internal/session"
 	"github.com/authelia/authelia/v4/internal/storage"
+	"github.com/authelia/authelia/v4/internal/templates"
 )
 
 func getWebAuthnCredentialIDFromContext(ctx *middlewares.AutheliaCtx) (int, error) {
@@ -266,7 +267,7 @@ func WebAuthnCredentialDELETE(ctx *middlewares.AutheliaCtx) {
 		return
 	}
 
-	ctxLogEvent(ctx, userSession.Username, eventLogAction2FARemoved, map[string]any{eventLogKeyAction: eventLogAction2FARemoved, eventLogKeyCategory: eventLogCategoryWebAuthnCredential, eventLogKeyDescription: credential.Description})
+	ctxLogEvent(ctx, userSession.Username, templates.TemplateNameEmailEventWebAuthnCredentialRemoved, eventLogAction2FARemoved, map[string]any{eventLogKeyAction: eventLogAction2FARemoved, eventLogKeyCategory: eventLogCategoryWebAuthnCredential, eventLogKeyDescription: credential.Description})
 
 	ctx.ReplyOK()
 }
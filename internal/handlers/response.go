@@ -14,6 +14,7 @@ import (
 	"github.com/authelia/authelia/v4/internal/model"
 	"github.com/authelia/authelia/v4/internal/oidc"
 	"github.com/authelia/authelia/v4/internal/session"
+	"github.com/authelia/authelia/v4/internal/templates"
 )
 
 // Handle1FAResponse handle the redirection upon 1FA authentication.
@@ -42,7 +43,7 @@ func Handle1FAResponse(ctx *middlewares.AutheliaCtx, targetURI, requestMethod st
 		return
 	}
 
-	_, requiredLevel := ctx.Providers.Authorizer.GetRequiredLevel(
+	_, requiredLevel, _ := ctx.Providers.Authorizer.GetRequiredLevel(
 		authorization.Subject{
 			Username: username,
 			Groups:   groups,
@@ -211,10 +212,11 @@ func handleOIDCWorkflowResponseWithID(ctx *middlewares.AutheliaCtx, userSession
 		return
 	}
 
-	level := client.GetAuthorizationPolicyRequiredLevel(authorization.Subject{Username: userSession.Username, Groups: userSession.Groups, IP: ctx.RemoteIP()})
+	subject := authorization.Subject{Username: userSession.Username, Groups: userSession.Groups, IP: ctx.RemoteIP()}
+	level := client.GetAuthorizationPolicyRequiredLevel(subject)
 
 	switch {
-	case authorization.IsAuthLevelSufficient(userSession.AuthenticationLevel, level), level == authorization.Denied:
+	case authorization.IsAuthLevelSufficient(userSession.AuthenticationLevel, level) && client.IsAuthenticationMethodsSufficient(userSession.AuthenticationMethodRefs.MarshalRFC8176(), subject), level == authorization.Denied:
 		var (
 			targetURL *url.URL
 			form      url.Values
@@ -273,16 +275,35 @@ func markAuthenticationAttempt(ctx *middlewares.AutheliaCtx, successful bool, ba
 		switch {
 		case errAuth != nil:
 			ctx.Logger.WithError(errAuth).Errorf("Unsuccessful %s authentication attempt by user '%s'", authType, username)
+		case bannedUntil != nil && bannedUntil.IsZero():
+			ctx.Logger.Errorf("Unsuccessful %s authentication attempt by user '%s' and they are banned indefinitely", authType, username)
 		case bannedUntil != nil:
 			ctx.Logger.Errorf("Unsuccessful %s authentication attempt by user '%s' and they are banned until %s", authType, username, bannedUntil)
 		default:
 			ctx.Logger.Errorf("Unsuccessful %s authentication attempt by user '%s'", authType, username)
 		}
+
+		if bannedUntil == nil {
+			markAuthenticationLockout(ctx, username)
+		}
 	}
 
 	return nil
 }
 
+func markAuthenticationLockout(ctx *middlewares.AutheliaCtx, username string) {
+	locked, err := ctx.Providers.Regulator.Lockout(ctx, username)
+
+	switch {
+	case err != nil:
+		ctx.Logger.WithError(err).Errorf("Unable to evaluate the lockout status of user '%s'", username)
+	case locked:
+		ctx.Logger.Errorf("User '%s' has been locked as they have exceeded the maximum number of bans", username)
+
+		ctxLogEvent(ctx, username, templates.TemplateNameEmailEventAccountLocked, eventLogActionAccountLocked, map[string]any{eventLogKeyAction: eventLogActionAccountLocked, eventLogKeyCategory: eventLogCategoryRegulation})
+	}
+}
+
 func respondUnauthorized(ctx *middlewares.AutheliaCtx, message string) {
 	ctx.SetStatusCode(fasthttp.StatusUnauthorized)
 	ctx.SetJSONError(message)
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"fmt"
+	"net/mail"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/mocks"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/regulation"
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+func TestMarkAuthenticationAttemptShouldLockoutForAllAuthTypes(t *testing.T) {
+	authTypes := []string{
+		regulation.AuthType1FA,
+		regulation.AuthTypePasskey,
+		regulation.AuthTypeTOTP,
+		regulation.AuthTypeWebAuthn,
+		regulation.AuthTypeDuo,
+		regulation.AuthTypeRecoveryCode,
+		regulation.AuthTypeEmail,
+	}
+
+	for _, authType := range authTypes {
+		t.Run(authType, func(t *testing.T) {
+			mock := mocks.NewMockAutheliaCtx(t)
+
+			defer mock.Close()
+
+			setupRegulationUnlockMock(mock)
+
+			now := mock.Clock.Now()
+
+			var attempts []model.AuthenticationAttempt
+
+			// Three bursts of failed attempts each resulting in a ban, ordered from the latest to the oldest.
+			for _, offset := range []time.Duration{time.Minute * 10, time.Minute * 20, time.Minute * 30} {
+				for i := 0; i < 3; i++ {
+					attempts = append(attempts, model.AuthenticationAttempt{Time: now.Add(-offset - time.Duration(i)*time.Second), Username: testUsername, Type: authType})
+				}
+			}
+
+			gomock.InOrder(
+				mock.StorageMock.
+					EXPECT().
+					AppendAuthenticationLog(mock.Ctx, gomock.Any()).
+					Return(nil),
+				mock.StorageMock.
+					EXPECT().
+					LoadBannedUser(mock.Ctx, testUsername, now, gomock.Any()).
+					Return(nil, nil),
+				mock.StorageMock.
+					EXPECT().
+					LoadRegulationLockout(mock.Ctx, testUsername).
+					Return(nil, storage.ErrNoRegulationLockout),
+				mock.StorageMock.
+					EXPECT().
+					LoadAuthenticationLogs(mock.Ctx, testUsername, gomock.Any(), gomock.Any(), 0).
+					Return(attempts, nil),
+				mock.StorageMock.
+					EXPECT().
+					SaveRegulationLockout(mock.Ctx, model.RegulationLockout{LockedAt: now, LockedIP: model.NewNullIP(mock.Ctx.RemoteIP()), Username: testUsername, Bans: 3}).
+					Return(nil),
+				mock.UserProviderMock.
+					EXPECT().
+					GetDetails(testUsername).
+					Return(&authentication.UserDetails{Username: testUsername, DisplayName: testDisplayName, Emails: []string{"john@example.com"}}, nil),
+				mock.NotifierMock.
+					EXPECT().
+					Send(mock.Ctx, mail.Address{Name: testDisplayName, Address: "john@example.com"}, eventLogActionAccountLocked, gomock.Any(), gomock.Any()).
+					Return(nil),
+			)
+
+			assert.NoError(t, markAuthenticationAttempt(mock.Ctx, false, nil, testUsername, authType, fmt.Errorf("bad credentials")))
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/url"
+	"time"
 
 	oauthelia2 "authelia.com/provider/oauth2"
 	"github.com/google/uuid"
@@ -31,6 +32,27 @@ type bodySignTOTPRequest struct {
 	WorkflowID string `json:"workflowID"`
 }
 
+// bodySignRecoveryCodeRequest is the model of the request body of the recovery code 2FA authentication endpoint.
+type bodySignRecoveryCodeRequest struct {
+	Code       string `json:"code" valid:"required"`
+	TargetURL  string `json:"targetURL"`
+	Workflow   string `json:"workflow"`
+	WorkflowID string `json:"workflowID"`
+}
+
+// bodySignEmailRequest is the model of the request body of the Email One-Time Code 2FA authentication endpoint.
+type bodySignEmailRequest struct {
+	Code       string `json:"code" valid:"required"`
+	TargetURL  string `json:"targetURL"`
+	Workflow   string `json:"workflow"`
+	WorkflowID string `json:"workflowID"`
+}
+
+// bodyRecoveryCodesResponse is the model of the response body containing newly generated recovery codes.
+type bodyRecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
 type bodyRegisterTOTP struct {
 	Algorithm string `json:"algorithm"`
 	Length    int    `json:"length"`
@@ -50,6 +72,17 @@ type bodySignWebAuthnRequest struct {
 	Response json.RawMessage `json:"response"`
 }
 
+// bodyFirstFactorPasskeyRequest is the model of the request body of the WebAuthn passkey 1FA authentication endpoint.
+type bodyFirstFactorPasskeyRequest struct {
+	TargetURL      string `json:"targetURL"`
+	RequestMethod  string `json:"requestMethod"`
+	Workflow       string `json:"workflow"`
+	WorkflowID     string `json:"workflowID"`
+	KeepMeLoggedIn *bool  `json:"keepMeLoggedIn"`
+
+	Response json.RawMessage `json:"response"`
+}
+
 // bodyGETUserSessionElevate is the  model of the request body of the User Session Elevation PUT endpoint.
 type bodyGETUserSessionElevate struct {
 	RequireSecondFactor bool `json:"require_second_factor"`
@@ -71,6 +104,7 @@ type bodyPUTUserSessionElevate struct {
 
 type bodyRegisterWebAuthnPUTRequest struct {
 	Description string `json:"description"`
+	Passkey     bool   `json:"passkey"`
 }
 
 type bodyEditWebAuthnCredentialRequest struct {
@@ -83,6 +117,10 @@ type bodySignDuoRequest struct {
 	Passcode   string `json:"passcode"`
 	Workflow   string `json:"workflow"`
 	WorkflowID string `json:"workflowID"`
+
+	// State and Code are the values returned by the Duo Universal Prompt.
+	State string `json:"state"`
+	Code  string `json:"duo_code"`
 }
 
 // bodyPreferred2FAMethod the selected 2FA method.
@@ -157,6 +195,7 @@ type DuoSignResponse struct {
 type StateResponse struct {
 	Username              string               `json:"username"`
 	AuthenticationLevel   authentication.Level `json:"authentication_level"`
+	AMR                   []string             `json:"amr,omitempty"`
 	DefaultRedirectionURL string               `json:"default_redirection_url"`
 }
 
@@ -186,6 +225,39 @@ type PasswordPolicyBody struct {
 	RequireSpecial   bool   `json:"require_special"`
 }
 
+// RegulationBansResponse represents the response sent by the regulation bans endpoint.
+type RegulationBansResponse struct {
+	Bans []RegulationBan `json:"bans"`
+}
+
+// RegulationStatusResponse represents the response sent by the regulation ban status endpoint.
+type RegulationStatusResponse struct {
+	Type     string              `json:"type"`
+	Value    string              `json:"value"`
+	Bans     []RegulationBan     `json:"bans"`
+	Attempts []RegulationAttempt `json:"attempts"`
+}
+
+// RegulationBan represents a ban which currently applies to a user or an IP.
+type RegulationBan struct {
+	Type    string     `json:"type"`
+	Value   string     `json:"value"`
+	Source  string     `json:"source"`
+	Time    time.Time  `json:"time"`
+	Expires *time.Time `json:"expires,omitempty"`
+	Reason  string     `json:"reason,omitempty"`
+}
+
+// RegulationAttempt represents an authentication attempt.
+type RegulationAttempt struct {
+	Time       time.Time `json:"time"`
+	Type       string    `json:"type"`
+	Successful bool      `json:"successful"`
+	Banned     bool      `json:"banned"`
+	Username   string    `json:"username"`
+	RemoteIP   string    `json:"remote_ip,omitempty"`
+}
+
 type handlerAuthorizationConsent func(
 	ctx *middlewares.AutheliaCtx, issuer *url.URL, client oidc.Client,
 	userSession session.UserSession, subject uuid.UUID,
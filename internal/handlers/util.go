@@ -1,31 +1,68 @@
 package handlers
 
 import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
+	"path"
 
 	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/session"
 	"github.com/authelia/authelia/v4/internal/templates"
+	"github.com/authelia/authelia/v4/internal/utils"
 )
 
 const (
 	eventLogKeyAction      = "Action"
 	eventLogKeyCategory    = "Category"
 	eventLogKeyDescription = "Description"
+	eventLogKeyDevice      = "Device"
+	eventLogKeyMethod      = "Method"
+	eventLogKeyUserAgent   = "User Agent"
+	eventLogKeyClient      = "Client"
+	eventLogKeyScopes      = "Scopes"
+	eventLogKeyRemaining   = "Remaining"
 
 	eventLogAction2FAAdded   = "Second Factor Method Added"
 	eventLogAction2FARemoved = "Second Factor Method Removed"
 
+	eventLogActionAccountLocked   = "Account Locked"
+	eventLogActionAccountUnlocked = "Account Unlocked"
+
+	eventLogActionDuoDeviceChanged = "Duo Device Changed"
+	eventLogActionNewLogin         = "New Login"
+	eventLogActionConsentGranted   = "Consent Granted"
+
+	eventLogActionRecoveryCodeUsed       = "Recovery Code Used"
+	eventLogActionRecoveryCodesGenerated = "Recovery Codes Generated"
+
 	eventLogCategoryOneTimePassword    = "One-Time Password"
 	eventLogCategoryWebAuthnCredential = "WebAuthn Credential" //nolint:gosec
+	eventLogCategoryRegulation         = "Regulation"
+	eventLogCategoryDuo                = "Duo"
+	eventLogCategoryAuthentication     = "Authentication"
+	eventLogCategoryOpenIDConnect      = "OpenID Connect"
+	eventLogCategoryRecoveryCode       = "Recovery Code"
 )
 
-func ctxLogEvent(ctx *middlewares.AutheliaCtx, username, description string, eventDetails map[string]any) {
+// ctxLogEvent notifies a user of an important event using the event template with the given name provided the
+// notification for this event has not been disabled.
+func ctxLogEvent(ctx *middlewares.AutheliaCtx, username, event, description string, eventDetails map[string]any) {
 	var (
 		details *authentication.UserDetails
 		err     error
 	)
 
+	if !isEventNotificationEnabled(&ctx.Configuration.Notifier.Events, event) {
+		ctx.Logger.Debugf("Skipping notification for event '%s' for user '%s' as it's disabled", event, username)
+
+		return
+	}
+
 	ctx.Logger.Debugf("Getting user details for notification")
 
 	// Send Notification.
@@ -52,8 +89,120 @@ func ctxLogEvent(ctx *middlewares.AutheliaCtx, username, description string, eve
 
 	ctx.Logger.Debugf("Sending an email to user %s (%s) to inform them of an important event.", username, addresses[0].String())
 
-	if err = ctx.Providers.Notifier.Send(ctx, addresses[0], description, ctx.Providers.Templates.GetEventEmailTemplate(), data); err != nil {
+	if err = ctx.Providers.Notifier.Send(ctx, addresses[0], description, ctx.Providers.Templates.GetEventEmailTemplateByName(event), data); err != nil {
 		ctx.Logger.WithError(err).Errorf("Error occurred sending notification to user '%s' while attempting to alert them of an important event", username)
 		return
 	}
 }
+
+// ctxLogEventNewLogin records the remote IP and user agent of a successful login and notifies the user if either of
+// them have not previously been seen for this user.
+func ctxLogEventNewLogin(ctx *middlewares.AutheliaCtx, username string) {
+	if !isEventNotificationEnabled(&ctx.Configuration.Notifier.Events, templates.TemplateNameEmailEventNewLogin) {
+		return
+	}
+
+	var (
+		summary *model.KnownLoginSummary
+		err     error
+	)
+
+	ip := model.NewIP(ctx.RemoteIP())
+	userAgent := ctx.UserAgent()
+	sum := sha256.Sum256(userAgent)
+	hash := hex.EncodeToString(sum[:])
+
+	if summary, err = ctx.Providers.StorageProvider.LoadKnownLoginSummary(ctx, username, ip, hash); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred loading the known logins for user '%s'", username)
+
+		return
+	}
+
+	now := ctx.Clock.Now()
+
+	if err = ctx.Providers.StorageProvider.SaveKnownLogin(ctx, model.KnownLogin{FirstSeen: now, LastSeen: now, Username: username, RemoteIP: ip, UserAgentHash: hash}); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred saving the known login for user '%s'", username)
+	}
+
+	if !summary.NewRemoteIP() && !summary.NewUserAgent() {
+		return
+	}
+
+	ctxLogEvent(ctx, username, templates.TemplateNameEmailEventNewLogin, eventLogActionNewLogin, map[string]any{eventLogKeyAction: eventLogActionNewLogin, eventLogKeyCategory: eventLogCategoryAuthentication, eventLogKeyUserAgent: string(userAgent)})
+}
+
+func isEventNotificationEnabled(config *schema.NotifierEvents, event string) (enabled bool) {
+	switch event {
+	case templates.TemplateNameEmailEventOneTimePasswordAdded:
+		return !config.OneTimePasswordAdded.Disable
+	case templates.TemplateNameEmailEventOneTimePasswordRemoved:
+		return !config.OneTimePasswordRemoved.Disable
+	case templates.TemplateNameEmailEventWebAuthnCredentialAdded:
+		return !config.WebAuthnCredentialAdded.Disable
+	case templates.TemplateNameEmailEventWebAuthnCredentialRemoved:
+		return !config.WebAuthnCredentialRemoved.Disable
+	case templates.TemplateNameEmailEventDuoDeviceChanged:
+		return !config.DuoDeviceChanged.Disable
+	case templates.TemplateNameEmailEventNewLogin:
+		return !config.NewLogin.Disable
+	case templates.TemplateNameEmailEventAccountLocked:
+		return !config.AccountLocked.Disable
+	case templates.TemplateNameEmailEventOpenIDConnectConsentGranted:
+		return !config.OpenIDConnectConsentGranted.Disable
+	case templates.TemplateNameEmailEventRecoveryCodeUsed:
+		return !config.RecoveryCodeUsed.Disable
+	case templates.TemplateNameEmailEventRecoveryCodesGenerated:
+		return !config.RecoveryCodesGenerated.Disable
+	default:
+		return true
+	}
+}
+
+// ctxOneTimeCodeRevocation returns the encoded public identifier of a one-time code and the URL which the user can
+// visit to revoke it.
+func ctxOneTimeCodeRevocation(ctx *middlewares.AutheliaCtx, code *model.OneTimeCode) (id, revocationURL string) {
+	id = base64.RawURLEncoding.EncodeToString(code.PublicID[:])
+
+	linkURL := ctx.RootURL()
+
+	query := linkURL.Query()
+
+	query.Set("id", id)
+
+	linkURL.Path = path.Join(linkURL.Path, "/revoke/one-time-code")
+	linkURL.RawQuery = query.Encode()
+
+	return id, linkURL.String()
+}
+
+// isAdministrator returns true if the user has authenticated with two-factor authentication and is a member of one of
+// the administration groups of the named endpoint, otherwise it replies with a forbidden response.
+func isAdministrator(ctx *middlewares.AutheliaCtx, name string, groups []string) bool {
+	var (
+		userSession session.UserSession
+		err         error
+	)
+
+	if userSession, err = ctx.GetSession(); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred checking %s administration access: %s", name, errStrUserSessionData)
+
+		ctx.ReplyForbidden()
+
+		return false
+	}
+
+	switch {
+	case userSession.AuthenticationLevel < authentication.TwoFactor:
+		err = fmt.Errorf("the user has not performed two-factor authentication")
+	case !utils.IsStringSliceContainsAny(groups, userSession.Groups):
+		err = fmt.Errorf("the user is not a member of any of the administration groups")
+	default:
+		return true
+	}
+
+	ctx.Logger.WithError(err).Warnf("User '%s' attempted to access the %s administration endpoint", userSession.Username, name)
+
+	ctx.ReplyForbidden()
+
+	return false
+}
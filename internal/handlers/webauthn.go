@@ -8,10 +8,13 @@ import (
 
 	"github.com/go-webauthn/webauthn/protocol"
 	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/google/uuid"
 
+	"github.com/authelia/authelia/v4/internal/fido"
 	"github.com/authelia/authelia/v4/internal/middlewares"
 	"github.com/authelia/authelia/v4/internal/model"
 	"github.com/authelia/authelia/v4/internal/random"
+	"github.com/authelia/authelia/v4/internal/utils"
 )
 
 const (
@@ -73,6 +76,22 @@ func handleGetWebAuthnUserByRPID(ctx *middlewares.AutheliaCtx, username, display
 	return user, nil
 }
 
+func handleGetWebAuthnUserByUserHandle(ctx *middlewares.AutheliaCtx, rpid string, userHandle []byte) (user *model.WebAuthnUser, err error) {
+	if user, err = ctx.Providers.StorageProvider.LoadWebAuthnUserByUserID(ctx, rpid, string(userHandle)); err != nil {
+		return nil, err
+	}
+
+	if user.DisplayName == "" {
+		user.DisplayName = user.Username
+	}
+
+	if user.Credentials, err = ctx.Providers.StorageProvider.LoadWebAuthnCredentialsByUsername(ctx, rpid, user.Username); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
 func handleNewWebAuthn(ctx *middlewares.AutheliaCtx) (w *webauthn.WebAuthn, err error) {
 	var (
 		origin *url.URL
@@ -144,3 +163,34 @@ func handleWebAuthnCredentialCreationIsDiscoverable(ctx *middlewares.AutheliaCtx
 
 	return false
 }
+
+// handleWebAuthnCredentialCreationValidate validates the authenticator of a newly created credential against the
+// filtering configuration and the FIDO Metadata Service if enabled, returning the authenticator model if known.
+func handleWebAuthnCredentialCreationValidate(ctx *middlewares.AutheliaCtx, response *protocol.ParsedCredentialCreationData) (authenticator string, err error) {
+	var aaguid uuid.UUID
+
+	if aaguid, err = uuid.FromBytes(response.Response.AttestationObject.AuthData.AttData.AAGUID); err != nil {
+		return "", fmt.Errorf("error parsing the authenticator aaguid: %w", err)
+	}
+
+	filtering := ctx.Configuration.WebAuthn.Filtering
+
+	switch {
+	case len(filtering.PermittedAAGUIDs) != 0 && !utils.IsStringInSlice(aaguid.String(), filtering.PermittedAAGUIDs):
+		return "", fmt.Errorf("the authenticator with aaguid '%s' is not permitted", aaguid)
+	case utils.IsStringInSlice(aaguid.String(), filtering.ProhibitedAAGUIDs):
+		return "", fmt.Errorf("the authenticator with aaguid '%s' is prohibited", aaguid)
+	}
+
+	if ctx.Providers.WebAuthnMetadata == nil {
+		return "", nil
+	}
+
+	var result fido.Result
+
+	if result, err = ctx.Providers.WebAuthnMetadata.Validate(ctx.Clock.Now(), &response.Response.AttestationObject); err != nil {
+		return "", err
+	}
+
+	return result.Model(), nil
+}
@@ -0,0 +1,61 @@
+package kms
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+)
+
+// seal encrypts the plaintext with AES-256-GCM binding the additional data to the ciphertext. The output has the form
+// nonce|ciphertext|tag.
+func seal(key, plaintext, additional []byte) (ciphertext []byte, err error) {
+	var gcm cipher.AEAD
+
+	if gcm, err = newGCM(key); err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return gcm.Seal(nonce, nonce, plaintext, additional), nil
+}
+
+// open decrypts ciphertext produced by seal.
+func open(key, ciphertext, additional []byte) (plaintext []byte, err error) {
+	var gcm cipher.AEAD
+
+	if gcm, err = newGCM(key); err != nil {
+		return nil, err
+	}
+
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, errors.New("malformed ciphertext")
+	}
+
+	return gcm.Open(nil, ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():], additional)
+}
+
+func newGCM(key []byte) (gcm cipher.AEAD, err error) {
+	var block cipher.Block
+
+	if block, err = aes.NewCipher(key); err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// fingerprint returns a stable identifier for a key which doesn't reveal the key.
+func fingerprint(key []byte) string {
+	sum := sha256.Sum256(key)
+
+	return hex.EncodeToString(sum[:keyIDSize])
+}
@@ -0,0 +1,98 @@
+package kms
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// NewCommandProvider returns a CommandProvider which delegates wrapping and unwrapping the data key to an external
+// command.
+func NewCommandProvider(config *schema.StorageKeyManagementCommand) *CommandProvider {
+	return &CommandProvider{
+		command: config.Command,
+		args:    config.Args,
+		keyID:   config.KeyID,
+		timeout: config.Timeout,
+	}
+}
+
+// CommandProvider is a key-encryption key Provider which executes a command to wrap and unwrap the data key. The
+// command is executed with the configured arguments followed by the operation ('wrap' or 'unwrap') and the key
+// identifier. The input is written to the standard input and the output is read from the standard output, both base64
+// encoded.
+type CommandProvider struct {
+	command string
+	args    []string
+	keyID   string
+	timeout time.Duration
+}
+
+// Name returns the name of the provider.
+func (p *CommandProvider) Name() string {
+	return ProviderNameCommand
+}
+
+// Wrap the data key with the current key-encryption key.
+func (p *CommandProvider) Wrap(ctx context.Context, key []byte) (id string, wrapped []byte, err error) {
+	if wrapped, err = p.exec(ctx, commandOperationWrap, p.keyID, key); err != nil {
+		return "", nil, err
+	}
+
+	return p.keyID, wrapped, nil
+}
+
+// Unwrap the wrapped data key with the key-encryption key with the given identifier.
+func (p *CommandProvider) Unwrap(ctx context.Context, id string, wrapped []byte) (key []byte, err error) {
+	if key, err = p.exec(ctx, commandOperationUnwrap, id, wrapped); err != nil {
+		return nil, err
+	}
+
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("%s: error unwrapping the data key with the key-encryption key with id '%s': the command returned a key with an invalid length of %d bytes", ProviderNameCommand, id, len(key))
+	}
+
+	return key, nil
+}
+
+func (p *CommandProvider) exec(ctx context.Context, operation, id string, input []byte) (output []byte, err error) {
+	if p.timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, p.timeout)
+
+		defer cancel()
+	}
+
+	args := make([]string, len(p.args), len(p.args)+2)
+
+	copy(args, p.args)
+
+	cmd := exec.CommandContext(ctx, p.command, append(args, operation, id)...) //nolint:gosec // The command is provided by the administrator.
+
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+
+	cmd.Stdin = strings.NewReader(base64.StdEncoding.EncodeToString(input))
+	cmd.Stdout, cmd.Stderr = stdout, stderr
+	cmd.WaitDelay = time.Second
+
+	if err = cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s: error performing the %s operation with the key-encryption key with id '%s': %w: %s", ProviderNameCommand, operation, id, err, msg)
+		}
+
+		return nil, fmt.Errorf("%s: error performing the %s operation with the key-encryption key with id '%s': %w", ProviderNameCommand, operation, id, err)
+	}
+
+	if output, err = base64.StdEncoding.DecodeString(strings.TrimSpace(stdout.String())); err != nil {
+		return nil, fmt.Errorf("%s: error performing the %s operation with the key-encryption key with id '%s': the command output is not valid base64: %w", ProviderNameCommand, operation, id, err)
+	}
+
+	return output, nil
+}
@@ -0,0 +1,80 @@
+package kms
+
+import (
+	"context"
+	"crypto/rand"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// testCommandScript is a trivial command provider which echoes the input back as the output.
+const testCommandScript = `#!/bin/sh
+case "$2" in
+  wrap|unwrap) ;;
+  *) echo "unknown operation $2" >&2; exit 1 ;;
+esac
+if [ "$3" = "missing" ]; then
+  echo "key $3 not found" >&2
+  exit 2
+fi
+if [ "$1" = "slow" ]; then
+  sleep 5
+fi
+if [ "$3" = "short" ]; then
+  echo "YWJj"
+  exit 0
+fi
+cat
+`
+
+func TestCommandProvider(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the command provider test requires a POSIX shell")
+	}
+
+	path := filepath.Join(t.TempDir(), "kms.sh")
+
+	require.NoError(t, os.WriteFile(path, []byte(testCommandScript), 0700)) //nolint:gosec
+
+	provider := NewCommandProvider(&schema.StorageKeyManagementCommand{
+		Command: path,
+		Args:    []string{"fast"},
+		KeyID:   "example",
+		Timeout: time.Second * 2,
+	})
+
+	assert.Equal(t, ProviderNameCommand, provider.Name())
+
+	key := make([]byte, KeySize)
+
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+
+	id, wrapped, err := provider.Wrap(context.Background(), key)
+	require.NoError(t, err)
+	assert.Equal(t, "example", id)
+
+	unwrapped, err := provider.Unwrap(context.Background(), id, wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, key, unwrapped)
+
+	_, err = provider.Unwrap(context.Background(), "short", wrapped)
+	assert.EqualError(t, err, "command: error unwrapping the data key with the key-encryption key with id 'short': the command returned a key with an invalid length of 3 bytes")
+
+	_, err = provider.Unwrap(context.Background(), "missing", wrapped)
+	assert.EqualError(t, err, "command: error performing the unwrap operation with the key-encryption key with id 'missing': exit status 2: key missing not found")
+
+	provider.args = []string{"slow"}
+	provider.timeout = time.Millisecond * 100
+
+	_, _, err = provider.Wrap(context.Background(), key)
+	assert.ErrorContains(t, err, "command: error performing the wrap operation with the key-encryption key with id 'example': signal: killed")
+}
@@ -0,0 +1,33 @@
+package kms
+
+const (
+	// ProviderNameFile is the name of the file key-encryption key provider.
+	ProviderNameFile = "file"
+
+	// ProviderNameCommand is the name of the command key-encryption key provider.
+	ProviderNameCommand = "command"
+
+	// ProviderNameSoftwareHSM is the name of the software HSM key-encryption key provider.
+	ProviderNameSoftwareHSM = "software_hsm"
+)
+
+const (
+	// KeySize is the size in bytes of the keys used with this package.
+	KeySize = 32
+
+	keyIDSize = 8
+)
+
+const (
+	commandOperationWrap   = "wrap"
+	commandOperationUnwrap = "unwrap"
+)
+
+const (
+	keystoreVersion = 1
+
+	keystoreArgon2Time    = 3
+	keystoreArgon2Memory  = 64 * 1024
+	keystoreArgon2Threads = 4
+	keystoreSaltSize      = 16
+)
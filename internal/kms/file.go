@@ -0,0 +1,94 @@
+package kms
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// NewFileProvider returns a FileProvider which loads the current and previous key-encryption keys from files. Each file
+// must contain a 256-bit key encoded as hex or base64.
+func NewFileProvider(config *schema.StorageKeyManagementFile) (provider *FileProvider, err error) {
+	provider = &FileProvider{
+		keys: map[string][]byte{},
+	}
+
+	var key []byte
+
+	if key, err = loadKeyFile(config.Path); err != nil {
+		return nil, err
+	}
+
+	provider.current = fingerprint(key)
+	provider.keys[provider.current] = key
+
+	for _, path := range config.PreviousPaths {
+		if key, err = loadKeyFile(path); err != nil {
+			return nil, err
+		}
+
+		provider.keys[fingerprint(key)] = key
+	}
+
+	return provider, nil
+}
+
+// FileProvider is a key-encryption key Provider which uses keys loaded from files. The identifier of each key is a
+// fingerprint of the key.
+type FileProvider struct {
+	current string
+	keys    map[string][]byte
+}
+
+// Name returns the name of the provider.
+func (p *FileProvider) Name() string {
+	return ProviderNameFile
+}
+
+// Wrap the data key with the current key-encryption key.
+func (p *FileProvider) Wrap(_ context.Context, key []byte) (id string, wrapped []byte, err error) {
+	if wrapped, err = seal(p.keys[p.current], key, []byte(p.current)); err != nil {
+		return "", nil, fmt.Errorf("%s: error wrapping the data key: %w", ProviderNameFile, err)
+	}
+
+	return p.current, wrapped, nil
+}
+
+// Unwrap the wrapped data key with the key-encryption key with the given identifier.
+func (p *FileProvider) Unwrap(_ context.Context, id string, wrapped []byte) (key []byte, err error) {
+	kek, ok := p.keys[id]
+	if !ok {
+		return nil, errUnknownKeyID(ProviderNameFile, id)
+	}
+
+	if key, err = open(kek, wrapped, []byte(id)); err != nil {
+		return nil, fmt.Errorf("%s: error unwrapping the data key with the key-encryption key with id '%s': %w", ProviderNameFile, id, err)
+	}
+
+	return key, nil
+}
+
+func loadKeyFile(path string) (key []byte, err error) {
+	var data []byte
+
+	if data, err = os.ReadFile(path); err != nil {
+		return nil, fmt.Errorf("%s: error reading the key-encryption key file '%s': %w", ProviderNameFile, path, err)
+	}
+
+	value := strings.TrimSpace(string(data))
+
+	if key, err = hex.DecodeString(value); err == nil && len(key) == KeySize {
+		return key, nil
+	}
+
+	if key, err = base64.StdEncoding.DecodeString(value); err == nil && len(key) == KeySize {
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("%s: error reading the key-encryption key file '%s': the file must contain a %d-bit key encoded as hex or base64", ProviderNameFile, path, KeySize*8)
+}
@@ -0,0 +1,114 @@
+package kms
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestFileProvider(t *testing.T) {
+	dir := t.TempDir()
+
+	current, previous := writeTestKeyFile(t, dir, "current.key", hex.EncodeToString), writeTestKeyFile(t, dir, "previous.key", base64.StdEncoding.EncodeToString)
+
+	old, err := NewFileProvider(&schema.StorageKeyManagementFile{Path: previous})
+	require.NoError(t, err)
+
+	provider, err := NewFileProvider(&schema.StorageKeyManagementFile{Path: current, PreviousPaths: []string{previous}})
+	require.NoError(t, err)
+
+	assert.Equal(t, ProviderNameFile, provider.Name())
+
+	key := make([]byte, KeySize)
+
+	_, err = rand.Read(key)
+	require.NoError(t, err)
+
+	id, wrapped, err := provider.Wrap(context.Background(), key)
+	require.NoError(t, err)
+	assert.Len(t, id, keyIDSize*2)
+	assert.NotEqual(t, key, wrapped)
+
+	unwrapped, err := provider.Unwrap(context.Background(), id, wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, key, unwrapped)
+
+	oldID, oldWrapped, err := old.Wrap(context.Background(), key)
+	require.NoError(t, err)
+	assert.NotEqual(t, id, oldID)
+
+	unwrapped, err = provider.Unwrap(context.Background(), oldID, oldWrapped)
+	require.NoError(t, err)
+	assert.Equal(t, key, unwrapped)
+
+	_, err = old.Unwrap(context.Background(), id, wrapped)
+	assert.EqualError(t, err, "file: the key-encryption key with id '"+id+"' is not available")
+
+	wrapped[len(wrapped)-1] ^= 0xff
+
+	_, err = provider.Unwrap(context.Background(), id, wrapped)
+	assert.ErrorContains(t, err, "file: error unwrapping the data key with the key-encryption key with id '"+id+"'")
+}
+
+func TestNewFileProviderErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	invalid := filepath.Join(dir, "invalid.key")
+
+	require.NoError(t, os.WriteFile(invalid, []byte("abc"), 0600))
+
+	testCases := []struct {
+		name     string
+		have     *schema.StorageKeyManagementFile
+		expected string
+	}{
+		{
+			"ShouldErrorMissingFile",
+			&schema.StorageKeyManagementFile{Path: filepath.Join(dir, "missing.key")},
+			"file: error reading the key-encryption key file '" + filepath.Join(dir, "missing.key") + "': open " + filepath.Join(dir, "missing.key") + ": no such file or directory",
+		},
+		{
+			"ShouldErrorInvalidFile",
+			&schema.StorageKeyManagementFile{Path: invalid},
+			"file: error reading the key-encryption key file '" + invalid + "': the file must contain a 256-bit key encoded as hex or base64",
+		},
+		{
+			"ShouldErrorInvalidPreviousFile",
+			&schema.StorageKeyManagementFile{Path: writeTestKeyFile(t, dir, "valid.key", hex.EncodeToString), PreviousPaths: []string{invalid}},
+			"file: error reading the key-encryption key file '" + invalid + "': the file must contain a 256-bit key encoded as hex or base64",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			provider, err := NewFileProvider(tc.have)
+
+			assert.Nil(t, provider)
+			assert.EqualError(t, err, tc.expected)
+		})
+	}
+}
+
+func writeTestKeyFile(t *testing.T, dir, name string, encode func(src []byte) string) string {
+	t.Helper()
+
+	key := make([]byte, KeySize)
+
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+
+	path := filepath.Join(dir, name)
+
+	require.NoError(t, os.WriteFile(path, []byte(encode(key)+"\n"), 0600))
+
+	return path
+}
@@ -0,0 +1,48 @@
+package kms
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// Provider is a key-encryption key provider which wraps and unwraps data keys.
+type Provider interface {
+	// Name returns the name of the provider.
+	Name() string
+
+	// Wrap the data key with the current key-encryption key returning the identifier of the key-encryption key and the
+	// wrapped data key.
+	Wrap(ctx context.Context, key []byte) (id string, wrapped []byte, err error)
+
+	// Unwrap the wrapped data key with the key-encryption key with the given identifier.
+	Unwrap(ctx context.Context, id string, wrapped []byte) (key []byte, err error)
+}
+
+// NewProvider returns the key-encryption key Provider for the configuration, or nil if key management is not
+// configured.
+func NewProvider(config *schema.StorageKeyManagement) (provider Provider, err error) {
+	switch {
+	case config == nil:
+		return nil, nil
+	case config.File != nil:
+		var file *FileProvider
+
+		if file, err = NewFileProvider(config.File); err != nil {
+			return nil, err
+		}
+
+		return file, nil
+	case config.Command != nil:
+		return NewCommandProvider(config.Command), nil
+	case config.SoftwareHSM != nil:
+		return NewSoftwareHSMProvider(config.SoftwareHSM), nil
+	default:
+		return nil, nil
+	}
+}
+
+func errUnknownKeyID(provider, id string) error {
+	return fmt.Errorf("%s: the key-encryption key with id '%s' is not available", provider, id)
+}
@@ -0,0 +1,32 @@
+package kms
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestNewProvider(t *testing.T) {
+	provider, err := NewProvider(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, provider)
+
+	provider, err = NewProvider(&schema.StorageKeyManagement{})
+	assert.NoError(t, err)
+	assert.Nil(t, provider)
+
+	provider, err = NewProvider(&schema.StorageKeyManagement{Command: &schema.StorageKeyManagementCommand{Command: "kms"}})
+	require.NoError(t, err)
+	assert.IsType(t, &CommandProvider{}, provider)
+
+	provider, err = NewProvider(&schema.StorageKeyManagement{SoftwareHSM: &schema.StorageKeyManagementSoftwareHSM{Path: "keystore.json"}})
+	require.NoError(t, err)
+	assert.IsType(t, &SoftwareHSMProvider{}, provider)
+
+	provider, err = NewProvider(&schema.StorageKeyManagement{File: &schema.StorageKeyManagementFile{Path: "/path/does/not/exist"}})
+	assert.True(t, provider == nil)
+	assert.EqualError(t, err, "file: error reading the key-encryption key file '/path/does/not/exist': open /path/does/not/exist: no such file or directory")
+}
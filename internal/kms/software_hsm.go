@@ -0,0 +1,226 @@
+package kms
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/argon2"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// NewSoftwareHSMProvider returns a SoftwareHSMProvider which uses the keystore at the configured path. The keystore
+// is a local stand-in for a PKCS#11 token: keys are addressed by label and identifier, and the key material is
+// protected at rest by the PIN.
+func NewSoftwareHSMProvider(config *schema.StorageKeyManagementSoftwareHSM) *SoftwareHSMProvider {
+	return &SoftwareHSMProvider{
+		path:  config.Path,
+		pin:   []byte(config.PIN),
+		label: config.KeyLabel,
+	}
+}
+
+// SoftwareHSMProvider is a key-encryption key Provider which uses keys stored in a PIN protected keystore file. The
+// key with the configured label is generated on first use if it does not exist.
+type SoftwareHSMProvider struct {
+	path  string
+	pin   []byte
+	label string
+
+	mu sync.Mutex
+}
+
+type softwareHSMKeystore struct {
+	Version int                 `json:"version"`
+	Salt    []byte              `json:"salt"`
+	Keys    []softwareHSMObject `json:"keys"`
+}
+
+type softwareHSMObject struct {
+	ID        string    `json:"id"`
+	Label     string    `json:"label"`
+	Value     []byte    `json:"value"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Name returns the name of the provider.
+func (p *SoftwareHSMProvider) Name() string {
+	return ProviderNameSoftwareHSM
+}
+
+// Wrap the data key with the key-encryption key with the configured label.
+func (p *SoftwareHSMProvider) Wrap(_ context.Context, key []byte) (id string, wrapped []byte, err error) {
+	p.mu.Lock()
+
+	defer p.mu.Unlock()
+
+	var (
+		keystore *softwareHSMKeystore
+		kek      []byte
+	)
+
+	if keystore, err = p.load(); err != nil {
+		return "", nil, err
+	}
+
+	if id, kek, err = p.current(keystore); err != nil {
+		return "", nil, err
+	}
+
+	if wrapped, err = seal(kek, key, []byte(id)); err != nil {
+		return "", nil, fmt.Errorf("%s: error wrapping the data key: %w", ProviderNameSoftwareHSM, err)
+	}
+
+	return id, wrapped, nil
+}
+
+// Unwrap the wrapped data key with the key-encryption key with the given identifier.
+func (p *SoftwareHSMProvider) Unwrap(_ context.Context, id string, wrapped []byte) (key []byte, err error) {
+	p.mu.Lock()
+
+	defer p.mu.Unlock()
+
+	var keystore *softwareHSMKeystore
+
+	if keystore, err = p.load(); err != nil {
+		return nil, err
+	}
+
+	pk := p.pinKey(keystore.Salt)
+
+	for _, object := range keystore.Keys {
+		if object.ID != id {
+			continue
+		}
+
+		var kek []byte
+
+		if kek, err = open(pk, object.Value, []byte(object.ID)); err != nil {
+			return nil, fmt.Errorf("%s: error decrypting the key-encryption key with id '%s': the pin is likely incorrect: %w", ProviderNameSoftwareHSM, id, err)
+		}
+
+		if key, err = open(kek, wrapped, []byte(id)); err != nil {
+			return nil, fmt.Errorf("%s: error unwrapping the data key with the key-encryption key with id '%s': %w", ProviderNameSoftwareHSM, id, err)
+		}
+
+		return key, nil
+	}
+
+	return nil, errUnknownKeyID(ProviderNameSoftwareHSM, id)
+}
+
+// current returns the identifier and value of the most recent key with the configured label, generating and saving
+// a new key if none exists.
+func (p *SoftwareHSMProvider) current(keystore *softwareHSMKeystore) (id string, kek []byte, err error) {
+	pk := p.pinKey(keystore.Salt)
+
+	for i := len(keystore.Keys) - 1; i >= 0; i-- {
+		object := keystore.Keys[i]
+
+		if object.Label != p.label {
+			continue
+		}
+
+		if kek, err = open(pk, object.Value, []byte(object.ID)); err != nil {
+			return "", nil, fmt.Errorf("%s: error decrypting the key-encryption key with label '%s': the pin is likely incorrect: %w", ProviderNameSoftwareHSM, p.label, err)
+		}
+
+		return object.ID, kek, nil
+	}
+
+	kek, raw := make([]byte, KeySize), make([]byte, keyIDSize)
+
+	if _, err = io.ReadFull(rand.Reader, kek); err != nil {
+		return "", nil, fmt.Errorf("%s: error generating the key-encryption key: %w", ProviderNameSoftwareHSM, err)
+	}
+
+	if _, err = io.ReadFull(rand.Reader, raw); err != nil {
+		return "", nil, fmt.Errorf("%s: error generating the key-encryption key: %w", ProviderNameSoftwareHSM, err)
+	}
+
+	object := softwareHSMObject{
+		ID:        hex.EncodeToString(raw),
+		Label:     p.label,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	if object.Value, err = seal(pk, kek, []byte(object.ID)); err != nil {
+		return "", nil, fmt.Errorf("%s: error generating the key-encryption key: %w", ProviderNameSoftwareHSM, err)
+	}
+
+	keystore.Keys = append(keystore.Keys, object)
+
+	if err = p.save(keystore); err != nil {
+		return "", nil, err
+	}
+
+	return object.ID, kek, nil
+}
+
+func (p *SoftwareHSMProvider) pinKey(salt []byte) []byte {
+	return argon2.IDKey(p.pin, salt, keystoreArgon2Time, keystoreArgon2Memory, keystoreArgon2Threads, KeySize)
+}
+
+func (p *SoftwareHSMProvider) load() (keystore *softwareHSMKeystore, err error) {
+	var data []byte
+
+	if data, err = os.ReadFile(p.path); err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%s: error reading the keystore '%s': %w", ProviderNameSoftwareHSM, p.path, err)
+		}
+
+		keystore = &softwareHSMKeystore{
+			Version: keystoreVersion,
+			Salt:    make([]byte, keystoreSaltSize),
+		}
+
+		if _, err = io.ReadFull(rand.Reader, keystore.Salt); err != nil {
+			return nil, fmt.Errorf("%s: error initializing the keystore '%s': %w", ProviderNameSoftwareHSM, p.path, err)
+		}
+
+		return keystore, nil
+	}
+
+	keystore = &softwareHSMKeystore{}
+
+	if err = json.Unmarshal(data, keystore); err != nil {
+		return nil, fmt.Errorf("%s: error parsing the keystore '%s': %w", ProviderNameSoftwareHSM, p.path, err)
+	}
+
+	if keystore.Version != keystoreVersion {
+		return nil, fmt.Errorf("%s: error parsing the keystore '%s': version %d is not supported", ProviderNameSoftwareHSM, p.path, keystore.Version)
+	}
+
+	return keystore, nil
+}
+
+func (p *SoftwareHSMProvider) save(keystore *softwareHSMKeystore) (err error) {
+	var data []byte
+
+	if data, err = json.MarshalIndent(keystore, "", "  "); err != nil {
+		return fmt.Errorf("%s: error saving the keystore '%s': %w", ProviderNameSoftwareHSM, p.path, err)
+	}
+
+	tmp := filepath.Join(filepath.Dir(p.path), fmt.Sprintf(".%s.tmp", filepath.Base(p.path)))
+
+	if err = os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("%s: error saving the keystore '%s': %w", ProviderNameSoftwareHSM, p.path, err)
+	}
+
+	if err = os.Rename(tmp, p.path); err != nil {
+		_ = os.Remove(tmp)
+
+		return fmt.Errorf("%s: error saving the keystore '%s': %w", ProviderNameSoftwareHSM, p.path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package kms
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestSoftwareHSMProvider(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keystore.json")
+
+	config := &schema.StorageKeyManagementSoftwareHSM{Path: path, PIN: "example-pin", KeyLabel: "authelia"}
+
+	provider := NewSoftwareHSMProvider(config)
+
+	assert.Equal(t, ProviderNameSoftwareHSM, provider.Name())
+
+	key := make([]byte, KeySize)
+
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+
+	id, wrapped, err := provider.Wrap(context.Background(), key)
+	require.NoError(t, err)
+	assert.Len(t, id, keyIDSize*2)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	unwrapped, err := provider.Unwrap(context.Background(), id, wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, key, unwrapped)
+
+	again, _, err := provider.Wrap(context.Background(), key)
+	require.NoError(t, err)
+	assert.Equal(t, id, again)
+
+	rotated := NewSoftwareHSMProvider(&schema.StorageKeyManagementSoftwareHSM{Path: path, PIN: "example-pin", KeyLabel: "authelia-2"})
+
+	rid, rwrapped, err := rotated.Wrap(context.Background(), key)
+	require.NoError(t, err)
+	assert.NotEqual(t, id, rid)
+
+	unwrapped, err = rotated.Unwrap(context.Background(), id, wrapped)
+	require.NoError(t, err)
+	assert.Equal(t, key, unwrapped)
+
+	unwrapped, err = provider.Unwrap(context.Background(), rid, rwrapped)
+	require.NoError(t, err)
+	assert.Equal(t, key, unwrapped)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	keystore := &softwareHSMKeystore{}
+
+	require.NoError(t, json.Unmarshal(data, keystore))
+	assert.Len(t, keystore.Keys, 2)
+
+	for _, object := range keystore.Keys {
+		assert.NotContains(t, string(object.Value), string(key))
+	}
+
+	_, err = provider.Unwrap(context.Background(), "0000000000000000", wrapped)
+	assert.EqualError(t, err, "software_hsm: the key-encryption key with id '0000000000000000' is not available")
+
+	invalid := NewSoftwareHSMProvider(&schema.StorageKeyManagementSoftwareHSM{Path: path, PIN: "invalid-pin", KeyLabel: "authelia"})
+
+	_, err = invalid.Unwrap(context.Background(), id, wrapped)
+	assert.ErrorContains(t, err, "software_hsm: error decrypting the key-encryption key with id '"+id+"': the pin is likely incorrect")
+
+	_, _, err = invalid.Wrap(context.Background(), key)
+	assert.ErrorContains(t, err, "software_hsm: error decrypting the key-encryption key with label 'authelia': the pin is likely incorrect")
+}
+
+func TestSoftwareHSMProviderInvalidKeystore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keystore.json")
+
+	provider := NewSoftwareHSMProvider(&schema.StorageKeyManagementSoftwareHSM{Path: path, PIN: "example-pin", KeyLabel: "authelia"})
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"version":2}`), 0600))
+
+	_, _, err := provider.Wrap(context.Background(), make([]byte, KeySize))
+	assert.EqualError(t, err, "software_hsm: error parsing the keystore '"+path+"': version 2 is not supported")
+
+	require.NoError(t, os.WriteFile(path, []byte(`not json`), 0600))
+
+	_, err = provider.Unwrap(context.Background(), "abc", nil)
+	assert.ErrorContains(t, err, "software_hsm: error parsing the keystore '"+path+"'")
+}
@@ -86,6 +86,11 @@ func ConfigureLogger(config schema.Log, log bool) (err error) {
 	return nil
 }
 
+// SetLevel sets the level of the default logger.
+func SetLevel(level string) {
+	setLevelStr(level, true)
+}
+
 func setLevelStr(level string, log bool) {
 	logrus.SetLevel(LogLevel(level).Level())
 
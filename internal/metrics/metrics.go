@@ -4,12 +4,14 @@ import (
 	"time"
 
 	"github.com/authelia/authelia/v4/internal/regulation"
+	"github.com/authelia/authelia/v4/internal/storage"
 )
 
 // Provider implementation.
 type Provider interface {
 	Recorder
 	regulation.MetricsRecorder
+	storage.MetricsRecorder
 }
 
 // Recorder of metrics.
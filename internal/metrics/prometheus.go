@@ -26,6 +26,7 @@ type Prometheus struct {
 	authzCounter    *prometheus.CounterVec
 	authnCounter    *prometheus.CounterVec
 	authn2FACounter *prometheus.CounterVec
+	storagePruned   *prometheus.CounterVec
 }
 
 // RecordRequest takes the statusCode string, requestMethod string, and the elapsed time.Duration to record the request and request duration metrics.
@@ -59,6 +60,11 @@ func (r *Prometheus) RecordAuthenticationDuration(success bool, elapsed time.Dur
 	r.authnDuration.WithLabelValues(strconv.FormatBool(success)).Observe(elapsed.Seconds())
 }
 
+// RecordStoragePrunedRows takes the table string and the number of rows to record the storage retention metrics.
+func (r *Prometheus) RecordStoragePrunedRows(table string, rows int64) {
+	r.storagePruned.WithLabelValues(table).Add(float64(rows))
+}
+
 func (r *Prometheus) register() {
 	r.authnDuration = promauto.NewHistogramVec(
 		prometheus.HistogramOpts{
@@ -125,4 +131,13 @@ func (r *Prometheus) register() {
 		},
 		[]string{"success", "banned", "type"},
 	)
+
+	r.storagePruned = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: "authelia",
+			Name:      "storage_pruned_rows",
+			Help:      "The number of rows pruned from the storage due to the retention policy.",
+		},
+		[]string{"table"},
+	)
 }
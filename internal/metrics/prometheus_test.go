@@ -17,4 +17,5 @@ func TestNewPrometheus(t *testing.T) {
 	p.RecordAuthn(true, false, "WebAuthn")
 	p.RecordAuthn(true, false, "1fa")
 	p.RecordAuthenticationDuration(true, time.Second)
+	p.RecordStoragePrunedRows("authentication_logs", 10)
 }
@@ -51,7 +51,7 @@ func NewAutheliaCtx(requestCTX *fasthttp.RequestCtx, configuration schema.Config
 
 // AvailableSecondFactorMethods returns the available 2FA methods.
 func (ctx *AutheliaCtx) AvailableSecondFactorMethods() (methods []string) {
-	methods = make([]string, 0, 3)
+	methods = make([]string, 0, 4)
 
 	if !ctx.Configuration.TOTP.Disable {
 		methods = append(methods, model.SecondFactorMethodTOTP)
@@ -65,6 +65,10 @@ func (ctx *AutheliaCtx) AvailableSecondFactorMethods() (methods []string) {
 		methods = append(methods, model.SecondFactorMethodDuo)
 	}
 
+	if ctx.Configuration.EmailOneTimeCode.Enable {
+		methods = append(methods, model.SecondFactorMethodEmail)
+	}
+
 	return methods
 }
 
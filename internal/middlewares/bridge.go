@@ -51,6 +51,14 @@ func (b *BridgeBuilder) Build() Bridge {
 		}
 
 		bridge := func(requestCtx *fasthttp.RequestCtx) {
+			if b.providers.ConfigurationReloader != nil {
+				config, providers := b.providers.ConfigurationReloader.Current()
+
+				next(NewAutheliaCtx(requestCtx, *config, *providers))
+
+				return
+			}
+
 			next(NewAutheliaCtx(requestCtx, b.config, b.providers))
 		}
 
@@ -8,6 +8,7 @@ import (
 	"github.com/authelia/authelia/v4/internal/authorization"
 	"github.com/authelia/authelia/v4/internal/clock"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/fido"
 	"github.com/authelia/authelia/v4/internal/metrics"
 	"github.com/authelia/authelia/v4/internal/notification"
 	"github.com/authelia/authelia/v4/internal/ntp"
@@ -48,6 +49,28 @@ type Providers struct {
 	TOTP            totp.Provider
 	PasswordPolicy  PasswordPolicyProvider
 	Random          random.Provider
+
+	WebAuthnMetadata *fido.MetadataProvider
+
+	ConfigurationReloader ConfigurationReloader
+}
+
+// ConfigurationReloader represents an implementation which reloads the configuration while Authelia is running.
+type ConfigurationReloader interface {
+	// Current returns the schema.Configuration and Providers which are currently in use.
+	Current() (config *schema.Configuration, providers *Providers)
+
+	// ReloadConfiguration reloads the configuration and applies the changes which can be applied without a restart.
+	ReloadConfiguration(trigger string) (result *ConfigurationReloadResult, err error)
+}
+
+// ConfigurationReloadResult represents the result of a configuration reload.
+type ConfigurationReloadResult struct {
+	// Applied is the list of changed keys which have been applied.
+	Applied []string `json:"applied"`
+
+	// Restart is the list of changed keys which require a restart to be applied.
+	Restart []string `json:"restart"`
 }
 
 // RequestHandler represents an Authelia request handler.
@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/authelia/authelia/v4/internal/duo (interfaces: UniversalPrompt)
+//
+// Generated by this command:
+//
+//	mockgen -package mocks -destination duo_universal_prompt.go -mock_names UniversalPrompt=MockDuoUniversalPrompt github.com/authelia/authelia/v4/internal/duo UniversalPrompt
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	duo "github.com/authelia/authelia/v4/internal/duo"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDuoUniversalPrompt is a mock of UniversalPrompt interface.
+type MockDuoUniversalPrompt struct {
+	ctrl     *gomock.Controller
+	recorder *MockDuoUniversalPromptMockRecorder
+}
+
+// MockDuoUniversalPromptMockRecorder is the mock recorder for MockDuoUniversalPrompt.
+type MockDuoUniversalPromptMockRecorder struct {
+	mock *MockDuoUniversalPrompt
+}
+
+// NewMockDuoUniversalPrompt creates a new mock instance.
+func NewMockDuoUniversalPrompt(ctrl *gomock.Controller) *MockDuoUniversalPrompt {
+	mock := &MockDuoUniversalPrompt{ctrl: ctrl}
+	mock.recorder = &MockDuoUniversalPromptMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDuoUniversalPrompt) EXPECT() *MockDuoUniversalPromptMockRecorder {
+	return m.recorder
+}
+
+// AuthURL mocks base method.
+func (m *MockDuoUniversalPrompt) AuthURL(arg0, arg1, arg2, arg3 string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AuthURL", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AuthURL indicates an expected call of AuthURL.
+func (mr *MockDuoUniversalPromptMockRecorder) AuthURL(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AuthURL", reflect.TypeOf((*MockDuoUniversalPrompt)(nil).AuthURL), arg0, arg1, arg2, arg3)
+}
+
+// Exchange mocks base method.
+func (m *MockDuoUniversalPrompt) Exchange(arg0 context.Context, arg1, arg2, arg3, arg4 string) (*duo.UniversalPromptClaims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exchange", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*duo.UniversalPromptClaims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exchange indicates an expected call of Exchange.
+func (mr *MockDuoUniversalPromptMockRecorder) Exchange(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exchange", reflect.TypeOf((*MockDuoUniversalPrompt)(nil).Exchange), arg0, arg1, arg2, arg3, arg4)
+}
+
+// HealthCheck mocks base method.
+func (m *MockDuoUniversalPrompt) HealthCheck(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HealthCheck", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HealthCheck indicates an expected call of HealthCheck.
+func (mr *MockDuoUniversalPromptMockRecorder) HealthCheck(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HealthCheck", reflect.TypeOf((*MockDuoUniversalPrompt)(nil).HealthCheck), arg0)
+}
@@ -12,6 +12,7 @@ package mocks
 import (
 	context "context"
 	sql "database/sql"
+	io "io"
 	reflect "reflect"
 	time "time"
 
@@ -73,6 +74,21 @@ func (mr *MockStorageMockRecorder) BeginTX(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTX", reflect.TypeOf((*MockStorage)(nil).BeginTX), arg0)
 }
 
+// ClaimQueuedNotification mocks base method.
+func (m *MockStorage) ClaimQueuedNotification(arg0 context.Context, arg1, arg2 int, arg3 time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimQueuedNotification", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimQueuedNotification indicates an expected call of ClaimQueuedNotification.
+func (mr *MockStorageMockRecorder) ClaimQueuedNotification(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimQueuedNotification", reflect.TypeOf((*MockStorage)(nil).ClaimQueuedNotification), arg0, arg1, arg2, arg3)
+}
+
 // Close mocks base method.
 func (m *MockStorage) Close() error {
 	m.ctrl.T.Helper()
@@ -129,6 +145,51 @@ func (mr *MockStorageMockRecorder) ConsumeOneTimeCode(arg0, arg1 any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeOneTimeCode", reflect.TypeOf((*MockStorage)(nil).ConsumeOneTimeCode), arg0, arg1)
 }
 
+// ConsumeRecoveryCode mocks base method.
+func (m *MockStorage) ConsumeRecoveryCode(arg0 context.Context, arg1 int, arg2 time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeRecoveryCode", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeRecoveryCode indicates an expected call of ConsumeRecoveryCode.
+func (mr *MockStorageMockRecorder) ConsumeRecoveryCode(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeRecoveryCode", reflect.TypeOf((*MockStorage)(nil).ConsumeRecoveryCode), arg0, arg1, arg2)
+}
+
+// CountOneTimeCodes mocks base method.
+func (m *MockStorage) CountOneTimeCodes(arg0 context.Context, arg1, arg2 string, arg3 time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOneTimeCodes", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOneTimeCodes indicates an expected call of CountOneTimeCodes.
+func (mr *MockStorageMockRecorder) CountOneTimeCodes(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOneTimeCodes", reflect.TypeOf((*MockStorage)(nil).CountOneTimeCodes), arg0, arg1, arg2, arg3)
+}
+
+// CountPrunable mocks base method.
+func (m *MockStorage) CountPrunable(arg0 context.Context, arg1 storage.PruneTarget, arg2 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPrunable", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPrunable indicates an expected call of CountPrunable.
+func (mr *MockStorageMockRecorder) CountPrunable(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPrunable", reflect.TypeOf((*MockStorage)(nil).CountPrunable), arg0, arg1, arg2)
+}
+
 // DeactivateOAuth2Session mocks base method.
 func (m *MockStorage) DeactivateOAuth2Session(arg0 context.Context, arg1 storage.OAuth2SessionType, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -171,6 +232,20 @@ func (mr *MockStorageMockRecorder) DeletePreferredDuoDevice(arg0, arg1 any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePreferredDuoDevice", reflect.TypeOf((*MockStorage)(nil).DeletePreferredDuoDevice), arg0, arg1)
 }
 
+// DeleteRecoveryCodes mocks base method.
+func (m *MockStorage) DeleteRecoveryCodes(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRecoveryCodes", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRecoveryCodes indicates an expected call of DeleteRecoveryCodes.
+func (mr *MockStorageMockRecorder) DeleteRecoveryCodes(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRecoveryCodes", reflect.TypeOf((*MockStorage)(nil).DeleteRecoveryCodes), arg0, arg1)
+}
+
 // DeleteTOTPConfiguration mocks base method.
 func (m *MockStorage) DeleteTOTPConfiguration(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -258,6 +333,111 @@ func (mr *MockStorageMockRecorder) LoadAuthenticationLogs(arg0, arg1, arg2, arg3
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadAuthenticationLogs", reflect.TypeOf((*MockStorage)(nil).LoadAuthenticationLogs), arg0, arg1, arg2, arg3, arg4)
 }
 
+// LoadAuthenticationLogsByRemoteIP mocks base method.
+func (m *MockStorage) LoadAuthenticationLogsByRemoteIP(arg0 context.Context, arg1 model.IP, arg2 time.Time, arg3, arg4 int) ([]model.AuthenticationAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadAuthenticationLogsByRemoteIP", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]model.AuthenticationAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadAuthenticationLogsByRemoteIP indicates an expected call of LoadAuthenticationLogsByRemoteIP.
+func (mr *MockStorageMockRecorder) LoadAuthenticationLogsByRemoteIP(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadAuthenticationLogsByRemoteIP", reflect.TypeOf((*MockStorage)(nil).LoadAuthenticationLogsByRemoteIP), arg0, arg1, arg2, arg3, arg4)
+}
+
+// LoadAuthenticationLogsByUsername mocks base method.
+func (m *MockStorage) LoadAuthenticationLogsByUsername(arg0 context.Context, arg1 string, arg2 time.Time, arg3, arg4 int) ([]model.AuthenticationAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadAuthenticationLogsByUsername", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]model.AuthenticationAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadAuthenticationLogsByUsername indicates an expected call of LoadAuthenticationLogsByUsername.
+func (mr *MockStorageMockRecorder) LoadAuthenticationLogsByUsername(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadAuthenticationLogsByUsername", reflect.TypeOf((*MockStorage)(nil).LoadAuthenticationLogsByUsername), arg0, arg1, arg2, arg3, arg4)
+}
+
+// LoadAuthenticationLogsRegulated mocks base method.
+func (m *MockStorage) LoadAuthenticationLogsRegulated(arg0 context.Context, arg1 time.Time, arg2, arg3, arg4 int) ([]model.AuthenticationAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadAuthenticationLogsRegulated", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]model.AuthenticationAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadAuthenticationLogsRegulated indicates an expected call of LoadAuthenticationLogsRegulated.
+func (mr *MockStorageMockRecorder) LoadAuthenticationLogsRegulated(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadAuthenticationLogsRegulated", reflect.TypeOf((*MockStorage)(nil).LoadAuthenticationLogsRegulated), arg0, arg1, arg2, arg3, arg4)
+}
+
+// LoadBannedIP mocks base method.
+func (m *MockStorage) LoadBannedIP(arg0 context.Context, arg1 model.IP, arg2 time.Time) ([]model.BannedIP, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadBannedIP", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]model.BannedIP)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadBannedIP indicates an expected call of LoadBannedIP.
+func (mr *MockStorageMockRecorder) LoadBannedIP(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadBannedIP", reflect.TypeOf((*MockStorage)(nil).LoadBannedIP), arg0, arg1, arg2)
+}
+
+// LoadBannedIPs mocks base method.
+func (m *MockStorage) LoadBannedIPs(arg0 context.Context, arg1 time.Time, arg2, arg3 int) ([]model.BannedIP, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadBannedIPs", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]model.BannedIP)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadBannedIPs indicates an expected call of LoadBannedIPs.
+func (mr *MockStorageMockRecorder) LoadBannedIPs(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadBannedIPs", reflect.TypeOf((*MockStorage)(nil).LoadBannedIPs), arg0, arg1, arg2, arg3)
+}
+
+// LoadBannedUser mocks base method.
+func (m *MockStorage) LoadBannedUser(arg0 context.Context, arg1 string, arg2, arg3 time.Time) ([]model.BannedUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadBannedUser", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]model.BannedUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadBannedUser indicates an expected call of LoadBannedUser.
+func (mr *MockStorageMockRecorder) LoadBannedUser(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadBannedUser", reflect.TypeOf((*MockStorage)(nil).LoadBannedUser), arg0, arg1, arg2, arg3)
+}
+
+// LoadBannedUsers mocks base method.
+func (m *MockStorage) LoadBannedUsers(arg0 context.Context, arg1 time.Time, arg2, arg3 int) ([]model.BannedUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadBannedUsers", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]model.BannedUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadBannedUsers indicates an expected call of LoadBannedUsers.
+func (mr *MockStorageMockRecorder) LoadBannedUsers(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadBannedUsers", reflect.TypeOf((*MockStorage)(nil).LoadBannedUsers), arg0, arg1, arg2, arg3)
+}
+
 // LoadIdentityVerification mocks base method.
 func (m *MockStorage) LoadIdentityVerification(arg0 context.Context, arg1 string) (*model.IdentityVerification, error) {
 	m.ctrl.T.Helper()
@@ -273,6 +453,21 @@ func (mr *MockStorageMockRecorder) LoadIdentityVerification(arg0, arg1 any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadIdentityVerification", reflect.TypeOf((*MockStorage)(nil).LoadIdentityVerification), arg0, arg1)
 }
 
+// LoadKnownLoginSummary mocks base method.
+func (m *MockStorage) LoadKnownLoginSummary(arg0 context.Context, arg1 string, arg2 model.IP, arg3 string) (*model.KnownLoginSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadKnownLoginSummary", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.KnownLoginSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadKnownLoginSummary indicates an expected call of LoadKnownLoginSummary.
+func (mr *MockStorageMockRecorder) LoadKnownLoginSummary(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadKnownLoginSummary", reflect.TypeOf((*MockStorage)(nil).LoadKnownLoginSummary), arg0, arg1, arg2, arg3)
+}
+
 // LoadOAuth2BlacklistedJTI mocks base method.
 func (m *MockStorage) LoadOAuth2BlacklistedJTI(arg0 context.Context, arg1 string) (*model.OAuth2BlacklistedJTI, error) {
 	m.ctrl.T.Helper()
@@ -318,6 +513,21 @@ func (mr *MockStorageMockRecorder) LoadOAuth2ConsentSessionByChallengeID(arg0, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadOAuth2ConsentSessionByChallengeID", reflect.TypeOf((*MockStorage)(nil).LoadOAuth2ConsentSessionByChallengeID), arg0, arg1)
 }
 
+// LoadOAuth2ConsentSessionsAuthorizedCount mocks base method.
+func (m *MockStorage) LoadOAuth2ConsentSessionsAuthorizedCount(arg0 context.Context, arg1 string, arg2 uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadOAuth2ConsentSessionsAuthorizedCount", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadOAuth2ConsentSessionsAuthorizedCount indicates an expected call of LoadOAuth2ConsentSessionsAuthorizedCount.
+func (mr *MockStorageMockRecorder) LoadOAuth2ConsentSessionsAuthorizedCount(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadOAuth2ConsentSessionsAuthorizedCount", reflect.TypeOf((*MockStorage)(nil).LoadOAuth2ConsentSessionsAuthorizedCount), arg0, arg1, arg2)
+}
+
 // LoadOAuth2PARContext mocks base method.
 func (m *MockStorage) LoadOAuth2PARContext(arg0 context.Context, arg1 string) (*model.OAuth2PARContext, error) {
 	m.ctrl.T.Helper()
@@ -438,6 +648,81 @@ func (mr *MockStorageMockRecorder) LoadPreferredDuoDevice(arg0, arg1 any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadPreferredDuoDevice", reflect.TypeOf((*MockStorage)(nil).LoadPreferredDuoDevice), arg0, arg1)
 }
 
+// LoadQueuedNotifications mocks base method.
+func (m *MockStorage) LoadQueuedNotifications(arg0 context.Context, arg1 string, arg2, arg3 int) ([]model.QueuedNotification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadQueuedNotifications", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]model.QueuedNotification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadQueuedNotifications indicates an expected call of LoadQueuedNotifications.
+func (mr *MockStorageMockRecorder) LoadQueuedNotifications(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadQueuedNotifications", reflect.TypeOf((*MockStorage)(nil).LoadQueuedNotifications), arg0, arg1, arg2, arg3)
+}
+
+// LoadQueuedNotificationsDue mocks base method.
+func (m *MockStorage) LoadQueuedNotificationsDue(arg0 context.Context, arg1 time.Time, arg2 int) ([]model.QueuedNotification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadQueuedNotificationsDue", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]model.QueuedNotification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadQueuedNotificationsDue indicates an expected call of LoadQueuedNotificationsDue.
+func (mr *MockStorageMockRecorder) LoadQueuedNotificationsDue(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadQueuedNotificationsDue", reflect.TypeOf((*MockStorage)(nil).LoadQueuedNotificationsDue), arg0, arg1, arg2)
+}
+
+// LoadRecoveryCodes mocks base method.
+func (m *MockStorage) LoadRecoveryCodes(arg0 context.Context, arg1 string) ([]model.RecoveryCode, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadRecoveryCodes", arg0, arg1)
+	ret0, _ := ret[0].([]model.RecoveryCode)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadRecoveryCodes indicates an expected call of LoadRecoveryCodes.
+func (mr *MockStorageMockRecorder) LoadRecoveryCodes(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadRecoveryCodes", reflect.TypeOf((*MockStorage)(nil).LoadRecoveryCodes), arg0, arg1)
+}
+
+// LoadRegulationLockout mocks base method.
+func (m *MockStorage) LoadRegulationLockout(arg0 context.Context, arg1 string) (*model.RegulationLockout, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadRegulationLockout", arg0, arg1)
+	ret0, _ := ret[0].(*model.RegulationLockout)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadRegulationLockout indicates an expected call of LoadRegulationLockout.
+func (mr *MockStorageMockRecorder) LoadRegulationLockout(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadRegulationLockout", reflect.TypeOf((*MockStorage)(nil).LoadRegulationLockout), arg0, arg1)
+}
+
+// LoadRegulationLockouts mocks base method.
+func (m *MockStorage) LoadRegulationLockouts(arg0 context.Context, arg1, arg2 int) ([]model.RegulationLockout, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadRegulationLockouts", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]model.RegulationLockout)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadRegulationLockouts indicates an expected call of LoadRegulationLockouts.
+func (mr *MockStorageMockRecorder) LoadRegulationLockouts(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadRegulationLockouts", reflect.TypeOf((*MockStorage)(nil).LoadRegulationLockouts), arg0, arg1, arg2)
+}
+
 // LoadTOTPConfiguration mocks base method.
 func (m *MockStorage) LoadTOTPConfiguration(arg0 context.Context, arg1 string) (*model.TOTPConfiguration, error) {
 	m.ctrl.T.Helper()
@@ -468,6 +753,36 @@ func (mr *MockStorageMockRecorder) LoadTOTPConfigurations(arg0, arg1, arg2 any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadTOTPConfigurations", reflect.TypeOf((*MockStorage)(nil).LoadTOTPConfigurations), arg0, arg1, arg2)
 }
 
+// LoadTrustedDevice mocks base method.
+func (m *MockStorage) LoadTrustedDevice(arg0 context.Context, arg1 string, arg2 uuid.UUID, arg3 []byte) (*model.TrustedDevice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadTrustedDevice", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*model.TrustedDevice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadTrustedDevice indicates an expected call of LoadTrustedDevice.
+func (mr *MockStorageMockRecorder) LoadTrustedDevice(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadTrustedDevice", reflect.TypeOf((*MockStorage)(nil).LoadTrustedDevice), arg0, arg1, arg2, arg3)
+}
+
+// LoadTrustedDevices mocks base method.
+func (m *MockStorage) LoadTrustedDevices(arg0 context.Context, arg1 string, arg2 time.Time) ([]model.TrustedDevice, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadTrustedDevices", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]model.TrustedDevice)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadTrustedDevices indicates an expected call of LoadTrustedDevices.
+func (mr *MockStorageMockRecorder) LoadTrustedDevices(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadTrustedDevices", reflect.TypeOf((*MockStorage)(nil).LoadTrustedDevices), arg0, arg1, arg2)
+}
+
 // LoadUserInfo mocks base method.
 func (m *MockStorage) LoadUserInfo(arg0 context.Context, arg1 string) (model.UserInfo, error) {
 	m.ctrl.T.Helper()
@@ -588,6 +903,108 @@ func (mr *MockStorageMockRecorder) LoadWebAuthnUser(arg0, arg1, arg2 any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadWebAuthnUser", reflect.TypeOf((*MockStorage)(nil).LoadWebAuthnUser), arg0, arg1, arg2)
 }
 
+// LoadWebAuthnUserByUserID mocks base method.
+func (m *MockStorage) LoadWebAuthnUserByUserID(arg0 context.Context, arg1, arg2 string) (*model.WebAuthnUser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadWebAuthnUserByUserID", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.WebAuthnUser)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadWebAuthnUserByUserID indicates an expected call of LoadWebAuthnUserByUserID.
+func (mr *MockStorageMockRecorder) LoadWebAuthnUserByUserID(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadWebAuthnUserByUserID", reflect.TypeOf((*MockStorage)(nil).LoadWebAuthnUserByUserID), arg0, arg1, arg2)
+}
+
+// Prune mocks base method.
+func (m *MockStorage) Prune(arg0 context.Context, arg1 storage.PruneTarget, arg2 time.Time, arg3 int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Prune", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Prune indicates an expected call of Prune.
+func (mr *MockStorageMockRecorder) Prune(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Prune", reflect.TypeOf((*MockStorage)(nil).Prune), arg0, arg1, arg2, arg3)
+}
+
+// PurgeQueuedNotifications mocks base method.
+func (m *MockStorage) PurgeQueuedNotifications(arg0 context.Context, arg1 string, arg2 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeQueuedNotifications", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeQueuedNotifications indicates an expected call of PurgeQueuedNotifications.
+func (mr *MockStorageMockRecorder) PurgeQueuedNotifications(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeQueuedNotifications", reflect.TypeOf((*MockStorage)(nil).PurgeQueuedNotifications), arg0, arg1, arg2)
+}
+
+// RetryQueuedNotification mocks base method.
+func (m *MockStorage) RetryQueuedNotification(arg0 context.Context, arg1 int, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryQueuedNotification", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RetryQueuedNotification indicates an expected call of RetryQueuedNotification.
+func (mr *MockStorageMockRecorder) RetryQueuedNotification(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryQueuedNotification", reflect.TypeOf((*MockStorage)(nil).RetryQueuedNotification), arg0, arg1, arg2)
+}
+
+// RetryQueuedNotifications mocks base method.
+func (m *MockStorage) RetryQueuedNotifications(arg0 context.Context, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RetryQueuedNotifications", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RetryQueuedNotifications indicates an expected call of RetryQueuedNotifications.
+func (mr *MockStorageMockRecorder) RetryQueuedNotifications(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RetryQueuedNotifications", reflect.TypeOf((*MockStorage)(nil).RetryQueuedNotifications), arg0, arg1)
+}
+
+// RevokeBannedIP mocks base method.
+func (m *MockStorage) RevokeBannedIP(arg0 context.Context, arg1 model.IP, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeBannedIP", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeBannedIP indicates an expected call of RevokeBannedIP.
+func (mr *MockStorageMockRecorder) RevokeBannedIP(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeBannedIP", reflect.TypeOf((*MockStorage)(nil).RevokeBannedIP), arg0, arg1, arg2)
+}
+
+// RevokeBannedUser mocks base method.
+func (m *MockStorage) RevokeBannedUser(arg0 context.Context, arg1 string, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeBannedUser", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeBannedUser indicates an expected call of RevokeBannedUser.
+func (mr *MockStorageMockRecorder) RevokeBannedUser(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeBannedUser", reflect.TypeOf((*MockStorage)(nil).RevokeBannedUser), arg0, arg1, arg2)
+}
+
 // RevokeIdentityVerification mocks base method.
 func (m *MockStorage) RevokeIdentityVerification(arg0 context.Context, arg1 string, arg2 model.NullIP) error {
 	m.ctrl.T.Helper()
@@ -658,6 +1075,35 @@ func (mr *MockStorageMockRecorder) RevokeOneTimeCode(arg0, arg1, arg2 any) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeOneTimeCode", reflect.TypeOf((*MockStorage)(nil).RevokeOneTimeCode), arg0, arg1, arg2)
 }
 
+// RevokeTrustedDevice mocks base method.
+func (m *MockStorage) RevokeTrustedDevice(arg0 context.Context, arg1 string, arg2 uuid.UUID, arg3 time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeTrustedDevice", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeTrustedDevice indicates an expected call of RevokeTrustedDevice.
+func (mr *MockStorageMockRecorder) RevokeTrustedDevice(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeTrustedDevice", reflect.TypeOf((*MockStorage)(nil).RevokeTrustedDevice), arg0, arg1, arg2, arg3)
+}
+
+// RevokeTrustedDevices mocks base method.
+func (m *MockStorage) RevokeTrustedDevices(arg0 context.Context, arg1 string, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeTrustedDevices", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeTrustedDevices indicates an expected call of RevokeTrustedDevices.
+func (mr *MockStorageMockRecorder) RevokeTrustedDevices(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeTrustedDevices", reflect.TypeOf((*MockStorage)(nil).RevokeTrustedDevices), arg0, arg1, arg2)
+}
+
 // Rollback mocks base method.
 func (m *MockStorage) Rollback(arg0 context.Context) error {
 	m.ctrl.T.Helper()
@@ -672,6 +1118,34 @@ func (mr *MockStorageMockRecorder) Rollback(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rollback", reflect.TypeOf((*MockStorage)(nil).Rollback), arg0)
 }
 
+// SaveBannedIP mocks base method.
+func (m *MockStorage) SaveBannedIP(arg0 context.Context, arg1 model.BannedIP) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveBannedIP", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveBannedIP indicates an expected call of SaveBannedIP.
+func (mr *MockStorageMockRecorder) SaveBannedIP(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveBannedIP", reflect.TypeOf((*MockStorage)(nil).SaveBannedIP), arg0, arg1)
+}
+
+// SaveBannedUser mocks base method.
+func (m *MockStorage) SaveBannedUser(arg0 context.Context, arg1 model.BannedUser) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveBannedUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveBannedUser indicates an expected call of SaveBannedUser.
+func (mr *MockStorageMockRecorder) SaveBannedUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveBannedUser", reflect.TypeOf((*MockStorage)(nil).SaveBannedUser), arg0, arg1)
+}
+
 // SaveIdentityVerification mocks base method.
 func (m *MockStorage) SaveIdentityVerification(arg0 context.Context, arg1 model.IdentityVerification) error {
 	m.ctrl.T.Helper()
@@ -686,6 +1160,20 @@ func (mr *MockStorageMockRecorder) SaveIdentityVerification(arg0, arg1 any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveIdentityVerification", reflect.TypeOf((*MockStorage)(nil).SaveIdentityVerification), arg0, arg1)
 }
 
+// SaveKnownLogin mocks base method.
+func (m *MockStorage) SaveKnownLogin(arg0 context.Context, arg1 model.KnownLogin) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveKnownLogin", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveKnownLogin indicates an expected call of SaveKnownLogin.
+func (mr *MockStorageMockRecorder) SaveKnownLogin(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveKnownLogin", reflect.TypeOf((*MockStorage)(nil).SaveKnownLogin), arg0, arg1)
+}
+
 // SaveOAuth2BlacklistedJTI mocks base method.
 func (m *MockStorage) SaveOAuth2BlacklistedJTI(arg0 context.Context, arg1 model.OAuth2BlacklistedJTI) error {
 	m.ctrl.T.Helper()
@@ -842,6 +1330,48 @@ func (mr *MockStorageMockRecorder) SavePreferredDuoDevice(arg0, arg1 any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePreferredDuoDevice", reflect.TypeOf((*MockStorage)(nil).SavePreferredDuoDevice), arg0, arg1)
 }
 
+// SaveQueuedNotification mocks base method.
+func (m *MockStorage) SaveQueuedNotification(arg0 context.Context, arg1 model.QueuedNotification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveQueuedNotification", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveQueuedNotification indicates an expected call of SaveQueuedNotification.
+func (mr *MockStorageMockRecorder) SaveQueuedNotification(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveQueuedNotification", reflect.TypeOf((*MockStorage)(nil).SaveQueuedNotification), arg0, arg1)
+}
+
+// SaveRecoveryCodes mocks base method.
+func (m *MockStorage) SaveRecoveryCodes(arg0 context.Context, arg1 string, arg2 []model.RecoveryCode) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveRecoveryCodes", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveRecoveryCodes indicates an expected call of SaveRecoveryCodes.
+func (mr *MockStorageMockRecorder) SaveRecoveryCodes(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveRecoveryCodes", reflect.TypeOf((*MockStorage)(nil).SaveRecoveryCodes), arg0, arg1, arg2)
+}
+
+// SaveRegulationLockout mocks base method.
+func (m *MockStorage) SaveRegulationLockout(arg0 context.Context, arg1 model.RegulationLockout) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveRegulationLockout", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveRegulationLockout indicates an expected call of SaveRegulationLockout.
+func (mr *MockStorageMockRecorder) SaveRegulationLockout(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveRegulationLockout", reflect.TypeOf((*MockStorage)(nil).SaveRegulationLockout), arg0, arg1)
+}
+
 // SaveTOTPConfiguration mocks base method.
 func (m *MockStorage) SaveTOTPConfiguration(arg0 context.Context, arg1 model.TOTPConfiguration) error {
 	m.ctrl.T.Helper()
@@ -870,6 +1400,20 @@ func (mr *MockStorageMockRecorder) SaveTOTPHistory(arg0, arg1, arg2 any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTOTPHistory", reflect.TypeOf((*MockStorage)(nil).SaveTOTPHistory), arg0, arg1, arg2)
 }
 
+// SaveTrustedDevice mocks base method.
+func (m *MockStorage) SaveTrustedDevice(arg0 context.Context, arg1 model.TrustedDevice) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTrustedDevice", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveTrustedDevice indicates an expected call of SaveTrustedDevice.
+func (mr *MockStorageMockRecorder) SaveTrustedDevice(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTrustedDevice", reflect.TypeOf((*MockStorage)(nil).SaveTrustedDevice), arg0, arg1)
+}
+
 // SaveUserOpaqueIdentifier mocks base method.
 func (m *MockStorage) SaveUserOpaqueIdentifier(arg0 context.Context, arg1 model.UserOpaqueIdentifier) error {
 	m.ctrl.T.Helper()
@@ -912,6 +1456,21 @@ func (mr *MockStorageMockRecorder) SaveWebAuthnUser(arg0, arg1 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveWebAuthnUser", reflect.TypeOf((*MockStorage)(nil).SaveWebAuthnUser), arg0, arg1)
 }
 
+// SchemaBackup mocks base method.
+func (m *MockStorage) SchemaBackup(arg0 context.Context, arg1 io.Writer) (storage.BackupResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SchemaBackup", arg0, arg1)
+	ret0, _ := ret[0].(storage.BackupResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SchemaBackup indicates an expected call of SchemaBackup.
+func (mr *MockStorageMockRecorder) SchemaBackup(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SchemaBackup", reflect.TypeOf((*MockStorage)(nil).SchemaBackup), arg0, arg1)
+}
+
 // SchemaEncryptionChangeKey mocks base method.
 func (m *MockStorage) SchemaEncryptionChangeKey(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -941,6 +1500,21 @@ func (mr *MockStorageMockRecorder) SchemaEncryptionCheckKey(arg0, arg1 any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SchemaEncryptionCheckKey", reflect.TypeOf((*MockStorage)(nil).SchemaEncryptionCheckKey), arg0, arg1)
 }
 
+// SchemaEncryptionRotateKEK mocks base method.
+func (m *MockStorage) SchemaEncryptionRotateKEK(arg0 context.Context) (storage.DataKeyRotationResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SchemaEncryptionRotateKEK", arg0)
+	ret0, _ := ret[0].(storage.DataKeyRotationResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SchemaEncryptionRotateKEK indicates an expected call of SchemaEncryptionRotateKEK.
+func (mr *MockStorageMockRecorder) SchemaEncryptionRotateKEK(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SchemaEncryptionRotateKEK", reflect.TypeOf((*MockStorage)(nil).SchemaEncryptionRotateKEK), arg0)
+}
+
 // SchemaLatestVersion mocks base method.
 func (m *MockStorage) SchemaLatestVersion() (int, error) {
 	m.ctrl.T.Helper()
@@ -1015,6 +1589,36 @@ func (mr *MockStorageMockRecorder) SchemaMigrationsUp(arg0, arg1 any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SchemaMigrationsUp", reflect.TypeOf((*MockStorage)(nil).SchemaMigrationsUp), arg0, arg1)
 }
 
+// SchemaRestore mocks base method.
+func (m *MockStorage) SchemaRestore(arg0 context.Context, arg1 io.Reader, arg2 bool) (storage.BackupResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SchemaRestore", arg0, arg1, arg2)
+	ret0, _ := ret[0].(storage.BackupResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SchemaRestore indicates an expected call of SchemaRestore.
+func (mr *MockStorageMockRecorder) SchemaRestore(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SchemaRestore", reflect.TypeOf((*MockStorage)(nil).SchemaRestore), arg0, arg1, arg2)
+}
+
+// SchemaTableRowCount mocks base method.
+func (m *MockStorage) SchemaTableRowCount(arg0 context.Context, arg1 string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SchemaTableRowCount", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SchemaTableRowCount indicates an expected call of SchemaTableRowCount.
+func (mr *MockStorageMockRecorder) SchemaTableRowCount(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SchemaTableRowCount", reflect.TypeOf((*MockStorage)(nil).SchemaTableRowCount), arg0, arg1)
+}
+
 // SchemaTables mocks base method.
 func (m *MockStorage) SchemaTables(arg0 context.Context) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -1059,6 +1663,20 @@ func (mr *MockStorageMockRecorder) StartupCheck() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartupCheck", reflect.TypeOf((*MockStorage)(nil).StartupCheck))
 }
 
+// UnlockRegulationLockout mocks base method.
+func (m *MockStorage) UnlockRegulationLockout(arg0 context.Context, arg1, arg2 string, arg3 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockRegulationLockout", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlockRegulationLockout indicates an expected call of UnlockRegulationLockout.
+func (mr *MockStorageMockRecorder) UnlockRegulationLockout(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockRegulationLockout", reflect.TypeOf((*MockStorage)(nil).UnlockRegulationLockout), arg0, arg1, arg2, arg3)
+}
+
 // UpdateOAuth2PARContext mocks base method.
 func (m *MockStorage) UpdateOAuth2PARContext(arg0 context.Context, arg1 model.OAuth2PARContext) error {
 	m.ctrl.T.Helper()
@@ -1073,18 +1691,46 @@ func (mr *MockStorageMockRecorder) UpdateOAuth2PARContext(arg0, arg1 any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOAuth2PARContext", reflect.TypeOf((*MockStorage)(nil).UpdateOAuth2PARContext), arg0, arg1)
 }
 
+// UpdateQueuedNotificationStatus mocks base method.
+func (m *MockStorage) UpdateQueuedNotificationStatus(arg0 context.Context, arg1 model.QueuedNotification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateQueuedNotificationStatus", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateQueuedNotificationStatus indicates an expected call of UpdateQueuedNotificationStatus.
+func (mr *MockStorageMockRecorder) UpdateQueuedNotificationStatus(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateQueuedNotificationStatus", reflect.TypeOf((*MockStorage)(nil).UpdateQueuedNotificationStatus), arg0, arg1)
+}
+
 // UpdateTOTPConfigurationSignIn mocks base method.
-func (m *MockStorage) UpdateTOTPConfigurationSignIn(arg0 context.Context, arg1 int, arg2 sql.NullTime) error {
+func (m *MockStorage) UpdateTOTPConfigurationSignIn(arg0 context.Context, arg1 int, arg2 sql.NullTime, arg3 uint64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateTOTPConfigurationSignIn", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "UpdateTOTPConfigurationSignIn", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateTOTPConfigurationSignIn indicates an expected call of UpdateTOTPConfigurationSignIn.
-func (mr *MockStorageMockRecorder) UpdateTOTPConfigurationSignIn(arg0, arg1, arg2 any) *gomock.Call {
+func (mr *MockStorageMockRecorder) UpdateTOTPConfigurationSignIn(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTOTPConfigurationSignIn", reflect.TypeOf((*MockStorage)(nil).UpdateTOTPConfigurationSignIn), arg0, arg1, arg2, arg3)
+}
+
+// UpdateTrustedDeviceSignIn mocks base method.
+func (m *MockStorage) UpdateTrustedDeviceSignIn(arg0 context.Context, arg1 model.TrustedDevice) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTrustedDeviceSignIn", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTrustedDeviceSignIn indicates an expected call of UpdateTrustedDeviceSignIn.
+func (mr *MockStorageMockRecorder) UpdateTrustedDeviceSignIn(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTOTPConfigurationSignIn", reflect.TypeOf((*MockStorage)(nil).UpdateTOTPConfigurationSignIn), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTrustedDeviceSignIn", reflect.TypeOf((*MockStorage)(nil).UpdateTrustedDeviceSignIn), arg0, arg1)
 }
 
 // UpdateWebAuthnCredentialDescription mocks base method.
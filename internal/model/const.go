@@ -20,6 +20,17 @@ const (
 
 	// SecondFactorMethodDuo method using Duo application to receive push notifications.
 	SecondFactorMethodDuo = "mobile_push"
+
+	// SecondFactorMethodEmail method using one-time codes delivered to the users email address.
+	SecondFactorMethodEmail = "email"
+)
+
+const (
+	// TrustedDeviceSecretLength is the number of characters in the randomly generated trusted device secret.
+	TrustedDeviceSecretLength = 64
+
+	// TrustedDeviceDescriptionMaxLength is the maximum length of a trusted device description.
+	TrustedDeviceDescriptionMaxLength = 255
 )
 
 var (
@@ -0,0 +1,33 @@
+package model
+
+import (
+	"time"
+)
+
+// KnownLogin represents a known login row in the database which records a remote IP and user agent combination a user
+// has successfully logged in from.
+type KnownLogin struct {
+	ID            int       `db:"id"`
+	FirstSeen     time.Time `db:"first_seen"`
+	LastSeen      time.Time `db:"last_seen"`
+	Username      string    `db:"username"`
+	RemoteIP      IP        `db:"remote_ip"`
+	UserAgentHash string    `db:"user_agent_hash"`
+}
+
+// KnownLoginSummary represents how many of the known logins for a user match a given remote IP and user agent.
+type KnownLoginSummary struct {
+	Total     int `db:"total"`
+	RemoteIP  int `db:"remote_ip"`
+	UserAgent int `db:"user_agent"`
+}
+
+// NewRemoteIP returns true if the user has previous known logins and none of them were from the remote IP.
+func (s KnownLoginSummary) NewRemoteIP() bool {
+	return s.Total != 0 && s.RemoteIP == 0
+}
+
+// NewUserAgent returns true if the user has previous known logins and none of them were from the user agent.
+func (s KnownLoginSummary) NewUserAgent() bool {
+	return s.Total != 0 && s.UserAgent == 0
+}
@@ -0,0 +1,32 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+const (
+	// QueuedNotificationStatusPending is the status of a queued notification which is awaiting delivery.
+	QueuedNotificationStatusPending = "pending"
+
+	// QueuedNotificationStatusSent is the status of a queued notification which has been delivered.
+	QueuedNotificationStatusSent = "sent"
+
+	// QueuedNotificationStatusDead is the status of a queued notification which has exhausted all delivery attempts.
+	QueuedNotificationStatusDead = "dead"
+)
+
+// QueuedNotification represents a notification queue row in the database which is delivered asynchronously.
+type QueuedNotification struct {
+	ID            int            `db:"id"`
+	CreatedAt     time.Time      `db:"created_at"`
+	NextAttemptAt time.Time      `db:"next_attempt_at"`
+	SentAt        sql.NullTime   `db:"sent_at"`
+	Attempts      int            `db:"attempts"`
+	Status        string         `db:"status"`
+	Recipient     string         `db:"recipient"`
+	Subject       string         `db:"subject"`
+	Template      string         `db:"template"`
+	Data          []byte         `db:"data"`
+	LastError     sql.NullString `db:"last_error"`
+}
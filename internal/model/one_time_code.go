@@ -14,6 +14,10 @@ const (
 	// OTCIntentUserSessionElevation is the intent value for a one-time code indicating it's used for user session
 	// elevation.
 	OTCIntentUserSessionElevation = "use"
+
+	// OTCIntentSecondFactorEmail is the intent value for a one-time code indicating it's used for second factor
+	// authentication via email.
+	OTCIntentSecondFactorEmail = "2fa"
 )
 
 // NewOneTimeCode returns a new OneTimeCode.
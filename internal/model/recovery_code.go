@@ -0,0 +1,15 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+// RecoveryCode represents a recovery code row in the database. The code itself is never stored, only the hash.
+type RecoveryCode struct {
+	ID        int          `db:"id"`
+	CreatedAt time.Time    `db:"created_at"`
+	UsedAt    sql.NullTime `db:"used_at"`
+	Username  string       `db:"username"`
+	Hash      string       `db:"hash"`
+}
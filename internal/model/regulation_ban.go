@@ -0,0 +1,38 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+// BannedUser represents a banned user row in the database.
+type BannedUser struct {
+	ID       int            `db:"id"`
+	Time     time.Time      `db:"time"`
+	Expires  sql.NullTime   `db:"expires"`
+	Revoked  sql.NullTime   `db:"revoked"`
+	Username string         `db:"username"`
+	Source   string         `db:"source"`
+	Reason   sql.NullString `db:"reason"`
+}
+
+// Active returns true if the ban has not been revoked and has not expired at the given time.
+func (b BannedUser) Active(now time.Time) bool {
+	return !b.Revoked.Valid && (!b.Expires.Valid || b.Expires.Time.After(now))
+}
+
+// BannedIP represents a banned IP row in the database.
+type BannedIP struct {
+	ID      int            `db:"id"`
+	Time    time.Time      `db:"time"`
+	Expires sql.NullTime   `db:"expires"`
+	Revoked sql.NullTime   `db:"revoked"`
+	IP      IP             `db:"ip"`
+	Source  string         `db:"source"`
+	Reason  sql.NullString `db:"reason"`
+}
+
+// Active returns true if the ban has not been revoked and has not expired at the given time.
+func (b BannedIP) Active(now time.Time) bool {
+	return !b.Revoked.Valid && (!b.Expires.Valid || b.Expires.Time.After(now))
+}
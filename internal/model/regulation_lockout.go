@@ -0,0 +1,22 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+// RegulationLockout represents a regulation lockout row in the database.
+type RegulationLockout struct {
+	ID             int            `db:"id"`
+	LockedAt       time.Time      `db:"locked"`
+	LockedIP       NullIP         `db:"locked_ip"`
+	Username       string         `db:"username"`
+	Bans           int            `db:"bans"`
+	UnlockedAt     sql.NullTime   `db:"unlocked"`
+	UnlockedMethod sql.NullString `db:"unlocked_method"`
+}
+
+// Active returns true if the lockout has not been unlocked.
+func (l RegulationLockout) Active() bool {
+	return !l.UnlockedAt.Valid
+}
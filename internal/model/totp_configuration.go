@@ -24,6 +24,21 @@ type TOTPOptions struct {
 	Periods []int `json:"periods"`
 }
 
+const (
+	// OneTimePasswordTypeTOTP is the type of a time-based one-time password configuration.
+	OneTimePasswordTypeTOTP = "totp"
+
+	// OneTimePasswordTypeHOTP is the type of a counter-based one-time password configuration.
+	OneTimePasswordTypeHOTP = "hotp"
+
+	// OneTimePasswordTypeSteam is the type of a time-based one-time password configuration which uses the Steam Guard
+	// alphanumeric encoding.
+	OneTimePasswordTypeSteam = "steam"
+)
+
+// OneTimePasswordTypes is the list of valid one-time password configuration types.
+var OneTimePasswordTypes = []string{OneTimePasswordTypeTOTP, OneTimePasswordTypeHOTP, OneTimePasswordTypeSteam}
+
 // TOTPConfiguration represents a users TOTP configuration row in the database.
 type TOTPConfiguration struct {
 	ID         int          `db:"id"`
@@ -31,9 +46,11 @@ type TOTPConfiguration struct {
 	LastUsedAt sql.NullTime `db:"last_used_at"`
 	Username   string       `db:"username"`
 	Issuer     string       `db:"issuer"`
+	Type       string       `db:"type"`
 	Algorithm  string       `db:"algorithm"`
 	Digits     uint         `db:"digits"`
 	Period     uint         `db:"period"`
+	Counter    uint64       `db:"counter"`
 	Secret     []byte       `db:"secret"`
 }
 
@@ -42,6 +59,7 @@ type TOTPConfigurationJSON struct {
 	CreatedAt  time.Time  `json:"created_at"`
 	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
 	Issuer     string     `json:"issuer"`
+	Type       string     `json:"type,omitempty"`
 	Algorithm  string     `json:"algorithm"`
 	Digits     int        `json:"digits"`
 	Period     int        `json:"period"`
@@ -52,6 +70,7 @@ func (c TOTPConfiguration) MarshalJSON() (data []byte, err error) {
 	o := TOTPConfigurationJSON{
 		CreatedAt: c.CreatedAt,
 		Issuer:    c.Issuer,
+		Type:      c.Type,
 		Algorithm: c.Algorithm,
 		Digits:    int(c.Digits),
 		Period:    int(c.Period),
@@ -64,6 +83,21 @@ func (c TOTPConfiguration) MarshalJSON() (data []byte, err error) {
 	return json.Marshal(o)
 }
 
+// IsCounterBased returns true if the TOTPConfiguration is a counter-based HOTP configuration.
+func (c *TOTPConfiguration) IsCounterBased() bool {
+	return c.Type == OneTimePasswordTypeHOTP
+}
+
+// HistoryStep returns the value used to record a successfully validated step in the history. For time-based
+// configurations this is the unix time of the step and for counter-based configurations this is the counter itself.
+func (c *TOTPConfiguration) HistoryStep(step uint64) uint64 {
+	if c.IsCounterBased() {
+		return step
+	}
+
+	return step * uint64(c.Period)
+}
+
 // HistorySince provides a reasonably accurate window for previously successful attempts to check for history.
 func (c *TOTPConfiguration) HistorySince(now time.Time, skew *int) time.Time {
 	var s int
@@ -93,13 +127,26 @@ func (c *TOTPConfiguration) URI() (uri string) {
 	v := url.Values{}
 	v.Set("secret", string(c.Secret))
 	v.Set("issuer", c.Issuer)
-	v.Set("period", strconv.FormatUint(uint64(c.Period), 10))
 	v.Set("algorithm", c.Algorithm)
 	v.Set("digits", strconv.Itoa(int(c.Digits)))
 
+	host := OneTimePasswordTypeTOTP
+
+	switch c.Type {
+	case OneTimePasswordTypeHOTP:
+		host = OneTimePasswordTypeHOTP
+
+		v.Set("counter", strconv.FormatUint(c.Counter, 10))
+	case OneTimePasswordTypeSteam:
+		v.Set("period", strconv.FormatUint(uint64(c.Period), 10))
+		v.Set("encoder", OneTimePasswordTypeSteam)
+	default:
+		v.Set("period", strconv.FormatUint(uint64(c.Period), 10))
+	}
+
 	u := url.URL{
 		Scheme:   "otpauth",
-		Host:     "totp",
+		Host:     host,
 		Path:     "/" + c.Issuer + ":" + c.Username,
 		RawQuery: v.Encode(),
 	}
@@ -107,9 +154,14 @@ func (c *TOTPConfiguration) URI() (uri string) {
 	return u.String()
 }
 
-// UpdateSignInInfo adjusts the values of the TOTPConfiguration after a sign in.
-func (c *TOTPConfiguration) UpdateSignInInfo(now time.Time) {
+// UpdateSignInInfo adjusts the values of the TOTPConfiguration after a sign in. For counter-based configurations the
+// counter is advanced past the validated step which resynchronises the configuration with the token.
+func (c *TOTPConfiguration) UpdateSignInInfo(now time.Time, step uint64) {
 	c.LastUsedAt = sql.NullTime{Time: now, Valid: true}
+
+	if c.IsCounterBased() {
+		c.Counter = step + 1
+	}
 }
 
 // Key returns the *otp.Key using TOTPConfiguration.URI with otp.NewKeyFromURL.
@@ -135,9 +187,11 @@ func (c *TOTPConfiguration) ToData() TOTPConfigurationData {
 		LastUsedAt: c.LastUsed(),
 		Username:   c.Username,
 		Issuer:     c.Issuer,
+		Type:       c.Type,
 		Algorithm:  c.Algorithm,
 		Digits:     c.Digits,
 		Period:     c.Period,
+		Counter:    c.Counter,
 		Secret:     base64.StdEncoding.EncodeToString(c.Secret),
 	}
 }
@@ -162,9 +216,15 @@ func (c *TOTPConfiguration) UnmarshalYAML(value *yaml.Node) (err error) {
 	c.CreatedAt = o.CreatedAt
 	c.Username = o.Username
 	c.Issuer = o.Issuer
+	c.Type = o.Type
 	c.Algorithm = o.Algorithm
 	c.Digits = o.Digits
 	c.Period = o.Period
+	c.Counter = o.Counter
+
+	if c.Type == "" {
+		c.Type = OneTimePasswordTypeTOTP
+	}
 
 	if o.LastUsedAt != nil {
 		c.LastUsedAt = sql.NullTime{Valid: true, Time: *o.LastUsedAt}
@@ -179,9 +239,11 @@ type TOTPConfigurationData struct {
 	LastUsedAt *time.Time `yaml:"last_used_at" json:"last_used_at" jsonschema:"title=Last Used At" jsonschema_description:"The time the configuration was last used at."`
 	Username   string     `yaml:"username" json:"username" jsonschema:"title=Username" jsonschema_description:"The username of the user this configuration belongs to."`
 	Issuer     string     `yaml:"issuer" json:"issuer" jsonschema:"title=Issuer" jsonschema_description:"The issuer name this was generated with."`
+	Type       string     `yaml:"type,omitempty" json:"type,omitempty" jsonschema:"title=Type,enum=totp,enum=hotp,enum=steam,default=totp" jsonschema_description:"The type of one-time password this configuration uses."`
 	Algorithm  string     `yaml:"algorithm" json:"algorithm" jsonschema:"title=Algorithm" jsonschema_description:"The algorithm this configuration uses."`
 	Digits     uint       `yaml:"digits" json:"digits" jsonschema:"title=Digits" jsonschema_description:"The number of digits this configuration uses."`
 	Period     uint       `yaml:"period" json:"period" jsonschema:"title=Period" jsonschema_description:"The period of time this configuration uses."`
+	Counter    uint64     `yaml:"counter,omitempty" json:"counter,omitempty" jsonschema:"title=Counter" jsonschema_description:"The next expected counter value for counter-based configurations."`
 	Secret     string     `yaml:"secret" json:"secret" jsonschema:"title=Secret" jsonschema_description:"The secret shared key for this configuration."`
 }
 
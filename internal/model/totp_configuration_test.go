@@ -159,6 +159,47 @@ func TestTOTPConfigurationImportExport(t *testing.T) {
 	}
 }
 
+func TestTOTPConfigurationCounterBased(t *testing.T) {
+	now := time.Now()
+
+	config := &TOTPConfiguration{
+		Username:  "john",
+		Issuer:    "Authelia",
+		Type:      OneTimePasswordTypeHOTP,
+		Algorithm: "SHA1",
+		Digits:    6,
+		Counter:   5,
+		Secret:    []byte("ABC123"),
+	}
+
+	assert.True(t, config.IsCounterBased())
+	assert.Equal(t, uint64(7), config.HistoryStep(7))
+	assert.Equal(t, "otpauth://hotp/Authelia:john?algorithm=SHA1&counter=5&digits=6&issuer=Authelia&secret=ABC123", config.URI())
+
+	config.UpdateSignInInfo(now, 7)
+
+	assert.Equal(t, uint64(8), config.Counter)
+	assert.Equal(t, sql.NullTime{Time: now, Valid: true}, config.LastUsedAt)
+
+	config = &TOTPConfiguration{
+		Username:  "john",
+		Issuer:    "Steam",
+		Type:      OneTimePasswordTypeSteam,
+		Algorithm: "SHA1",
+		Digits:    5,
+		Period:    30,
+		Secret:    []byte("ABC123"),
+	}
+
+	assert.False(t, config.IsCounterBased())
+	assert.Equal(t, uint64(210), config.HistoryStep(7))
+	assert.Equal(t, "otpauth://totp/Steam:john?algorithm=SHA1&digits=5&encoder=steam&issuer=Steam&period=30&secret=ABC123", config.URI())
+
+	config.UpdateSignInInfo(now, 7)
+
+	assert.Equal(t, uint64(0), config.Counter)
+}
+
 func MustRead(n int) []byte {
 	data := make([]byte, n)
 
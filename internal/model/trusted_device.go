@@ -0,0 +1,111 @@
+package model
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/authelia/authelia/v4/internal/random"
+)
+
+// NewTrustedDevice creates a new TrustedDevice for the given username with a randomly generated public identifier and
+// secret which expires after the given duration.
+func NewTrustedDevice(ctx Context, username, description string, duration time.Duration) (device *TrustedDevice, err error) {
+	var (
+		publicID uuid.UUID
+		secret   []byte
+	)
+
+	src := ctx.GetRandom()
+
+	if publicID, err = uuid.NewRandomFromReader(src); err != nil {
+		return nil, fmt.Errorf("failed to generate public id: %w", err)
+	}
+
+	if secret, err = src.BytesCustomErr(TrustedDeviceSecretLength, []byte(random.CharSetAlphaNumeric)); err != nil {
+		return nil, fmt.Errorf("failed to generate random bytes: %w", err)
+	}
+
+	if len(description) > TrustedDeviceDescriptionMaxLength {
+		description = description[:TrustedDeviceDescriptionMaxLength]
+	}
+
+	now := ctx.GetClock().Now()
+
+	return &TrustedDevice{
+		PublicID:    publicID,
+		CreatedAt:   now,
+		CreatedIP:   NewIP(ctx.RemoteIP()),
+		ExpiresAt:   now.Add(duration),
+		Username:    username,
+		Description: description,
+		Secret:      secret,
+	}, nil
+}
+
+// TrustedDevice represents a trusted device row in the database. The Secret is never stored, only the signature of it.
+type TrustedDevice struct {
+	ID          int          `db:"id"`
+	PublicID    uuid.UUID    `db:"public_id"`
+	Signature   string       `db:"signature"`
+	CreatedAt   time.Time    `db:"created_at"`
+	CreatedIP   IP           `db:"created_ip"`
+	LastUsedAt  sql.NullTime `db:"last_used_at"`
+	LastUsedIP  NullIP       `db:"last_used_ip"`
+	ExpiresAt   time.Time    `db:"expires_at"`
+	RevokedAt   sql.NullTime `db:"revoked_at"`
+	Username    string       `db:"username"`
+	Description string       `db:"description"`
+
+	Secret []byte `db:"-"`
+}
+
+// UpdateSignIn updates the values required when the trusted device is used to sign in.
+func (d *TrustedDevice) UpdateSignIn(ctx Context) {
+	d.LastUsedAt = sql.NullTime{Valid: true, Time: ctx.GetClock().Now()}
+	d.LastUsedIP = NewNullIP(ctx.RemoteIP())
+}
+
+// Valid returns true if the trusted device has not been revoked and has not expired at the given time.
+func (d *TrustedDevice) Valid(now time.Time) bool {
+	return !d.RevokedAt.Valid && d.ExpiresAt.After(now)
+}
+
+// ToData converts this TrustedDevice into a TrustedDeviceData.
+func (d *TrustedDevice) ToData() TrustedDeviceData {
+	o := TrustedDeviceData{
+		ID:          d.PublicID.String(),
+		CreatedAt:   d.CreatedAt,
+		CreatedIP:   d.CreatedIP.IP.String(),
+		ExpiresAt:   d.ExpiresAt,
+		Username:    d.Username,
+		Description: d.Description,
+	}
+
+	if d.LastUsedAt.Valid {
+		o.LastUsedAt = &d.LastUsedAt.Time
+	}
+
+	if d.LastUsedIP.IP != nil {
+		ip := d.LastUsedIP.IP.String()
+
+		o.LastUsedIP = &ip
+	}
+
+	return o
+}
+
+// TrustedDeviceData represents a TrustedDevice in a form suitable for the API and exports.
+type TrustedDeviceData struct {
+	ID          string     `yaml:"id" json:"id" jsonschema:"title=ID" jsonschema_description:"The public identifier of this trusted device."`
+	CreatedAt   time.Time  `yaml:"created_at" json:"created_at" jsonschema:"title=Created At" jsonschema_description:"The time this device was trusted."`
+	CreatedIP   string     `yaml:"created_ip" json:"created_ip" jsonschema:"title=Created IP" jsonschema_description:"The remote IP this device was trusted from."`
+	LastUsedAt  *time.Time `yaml:"last_used_at,omitempty" json:"last_used_at,omitempty" jsonschema:"title=Last Used At" jsonschema_description:"The last time this device was used to skip the second factor."`
+	LastUsedIP  *string    `yaml:"last_used_ip,omitempty" json:"last_used_ip,omitempty" jsonschema:"title=Last Used IP" jsonschema_description:"The last remote IP this device was used from."`
+	ExpiresAt   time.Time  `yaml:"expires_at" json:"expires_at" jsonschema:"title=Expires At" jsonschema_description:"The time this device is no longer trusted."`
+	Username    string     `yaml:"username" json:"username" jsonschema:"title=Username" jsonschema_description:"The username of the user this trusted device belongs to."`
+	Description string     `yaml:"description" json:"description" jsonschema:"title=Description" jsonschema_description:"The description of this trusted device."`
+	Current     bool       `yaml:"-" json:"current"`
+}
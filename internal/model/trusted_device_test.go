@@ -0,0 +1,65 @@
+package model
+
+import (
+	"database/sql"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrustedDevice_Valid(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	testCases := []struct {
+		name     string
+		have     TrustedDevice
+		expected bool
+	}{
+		{"ShouldBeValid", TrustedDevice{ExpiresAt: now.Add(time.Hour)}, true},
+		{"ShouldNotBeValidExpired", TrustedDevice{ExpiresAt: now.Add(-time.Hour)}, false},
+		{"ShouldNotBeValidRevoked", TrustedDevice{ExpiresAt: now.Add(time.Hour), RevokedAt: sql.NullTime{Valid: true, Time: now}}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.have.Valid(now))
+		})
+	}
+}
+
+func TestTrustedDevice_ToData(t *testing.T) {
+	now := time.Unix(1700000000, 0).UTC()
+	id := uuid.MustParse("b6a2e0a0-8b45-4a5e-9d2b-3c4f7c0e6a11")
+
+	device := TrustedDevice{
+		PublicID:    id,
+		CreatedAt:   now,
+		CreatedIP:   NewIP(net.ParseIP("192.168.1.1")),
+		ExpiresAt:   now.Add(time.Hour),
+		Username:    "john",
+		Description: "Firefox on Linux",
+		Secret:      []byte("secret"),
+	}
+
+	data := device.ToData()
+
+	assert.Equal(t, TrustedDeviceData{
+		ID:          id.String(),
+		CreatedAt:   now,
+		CreatedIP:   "192.168.1.1",
+		ExpiresAt:   now.Add(time.Hour),
+		Username:    "john",
+		Description: "Firefox on Linux",
+	}, data)
+
+	device.LastUsedAt = sql.NullTime{Valid: true, Time: now.Add(time.Minute)}
+	device.LastUsedIP = NewNullIP(net.ParseIP("192.168.1.2"))
+
+	data = device.ToData()
+
+	assert.Equal(t, now.Add(time.Minute), *data.LastUsedAt)
+	assert.Equal(t, "192.168.1.2", *data.LastUsedIP)
+}
@@ -20,6 +20,9 @@ type UserInfo struct {
 
 	// True if a duo device has been configured as the preferred.
 	HasDuo bool `db:"has_duo" json:"has_duo" valid:"required"`
+
+	// The number of unused recovery codes.
+	RecoveryCodes int `db:"recovery_codes" json:"recovery_codes"`
 }
 
 // SetDefaultPreferred2FAMethod configures the default method based on what is configured as available and the users available methods.
@@ -32,6 +35,7 @@ func (i *UserInfo) SetDefaultPreferred2FAMethod(methods []string, fallback strin
 	before := i.Method
 
 	totp, webauthn, duo := utils.IsStringInSlice(SecondFactorMethodTOTP, methods), utils.IsStringInSlice(SecondFactorMethodWebAuthn, methods), utils.IsStringInSlice(SecondFactorMethodDuo, methods)
+	email := utils.IsStringInSlice(SecondFactorMethodEmail, methods)
 
 	if i.Method == "" && utils.IsStringInSlice(fallback, methods) {
 		i.Method = fallback
@@ -40,13 +44,16 @@ func (i *UserInfo) SetDefaultPreferred2FAMethod(methods []string, fallback strin
 	}
 
 	if i.Method == "" {
-		i.setMethod(totp, webauthn, duo, methods, fallback)
+		i.setMethod(totp, webauthn, duo, email, methods, fallback)
 	}
 
 	return before != i.Method
 }
 
-func (i *UserInfo) setMethod(totp, webauthn, duo bool, methods []string, fallback string) {
+// setMethod selects the method in order of preference. Methods the user has registered are preferred, followed by the
+// fallback, followed by the Email One-Time Code method as it doesn't require registration, followed by any other
+// available method.
+func (i *UserInfo) setMethod(totp, webauthn, duo, email bool, methods []string, fallback string) {
 	switch {
 	case i.HasTOTP && totp:
 		i.Method = SecondFactorMethodTOTP
@@ -56,6 +63,8 @@ func (i *UserInfo) setMethod(totp, webauthn, duo bool, methods []string, fallbac
 		i.Method = SecondFactorMethodDuo
 	case fallback != "" && utils.IsStringInSlice(fallback, methods):
 		i.Method = fallback
+	case email:
+		i.Method = SecondFactorMethodEmail
 	case totp:
 		i.Method = SecondFactorMethodTOTP
 	case webauthn:
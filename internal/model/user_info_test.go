@@ -300,6 +300,54 @@ func TestUserInfo_SetDefaultMethod(t *testing.T) {
 			fallback: SecondFactorMethodDuo,
 			changed:  true,
 		},
+		{
+			have: UserInfo{
+				Method:      "",
+				HasDuo:      false,
+				HasTOTP:     false,
+				HasWebAuthn: false,
+			},
+			want: UserInfo{
+				Method:      SecondFactorMethodEmail,
+				HasDuo:      false,
+				HasTOTP:     false,
+				HasWebAuthn: false,
+			},
+			methods: []string{SecondFactorMethodTOTP, SecondFactorMethodWebAuthn, SecondFactorMethodEmail},
+			changed: true,
+		},
+		{
+			have: UserInfo{
+				Method:      "",
+				HasDuo:      false,
+				HasTOTP:     true,
+				HasWebAuthn: false,
+			},
+			want: UserInfo{
+				Method:      SecondFactorMethodTOTP,
+				HasDuo:      false,
+				HasTOTP:     true,
+				HasWebAuthn: false,
+			},
+			methods: []string{SecondFactorMethodTOTP, SecondFactorMethodEmail},
+			changed: true,
+		},
+		{
+			have: UserInfo{
+				Method:      SecondFactorMethodEmail,
+				HasDuo:      false,
+				HasTOTP:     true,
+				HasWebAuthn: false,
+			},
+			want: UserInfo{
+				Method:      SecondFactorMethodTOTP,
+				HasDuo:      false,
+				HasTOTP:     true,
+				HasWebAuthn: false,
+			},
+			methods: []string{SecondFactorMethodTOTP, SecondFactorMethodWebAuthn},
+			changed: true,
+		},
 	}
 
 	for i, tc := range testCases {
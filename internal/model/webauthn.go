@@ -167,6 +167,7 @@ type WebAuthnCredential struct {
 	KID             Base64        `db:"kid"`
 	AAGUID          uuid.NullUUID `db:"aaguid"`
 	AttestationType string        `db:"attestation_type"`
+	Model           string        `db:"model"`
 	Attachment      string        `db:"attachment"`
 	Transport       string        `db:"transport"`
 	SignCount       uint32        `db:"sign_count"`
@@ -230,6 +231,7 @@ func (c *WebAuthnCredential) ToData() WebAuthnCredentialData {
 		KID:             c.KID.String(),
 		AAGUID:          c.DataValueAAGUID(),
 		AttestationType: c.AttestationType,
+		Model:           c.Model,
 		Attachment:      c.Attachment,
 		SignCount:       c.SignCount,
 		CloneWarning:    c.CloneWarning,
@@ -321,6 +323,7 @@ type WebAuthnCredentialData struct {
 	KID             string     `yaml:"kid" json:"kid" jsonschema:"title=Public Key ID" jsonschema_description:"The Public Key ID of this credential."`
 	AAGUID          *string    `yaml:"aaguid,omitempty" json:"aaguid,omitempty" jsonschema:"title=AAGUID" jsonschema_description:"The Authenticator Attestation Global Unique Identifier of this credential."`
 	AttestationType string     `yaml:"attestation_type" json:"attestation_type" jsonschema:"title=Attestation Type" jsonschema_description:"The attestation format type this credential uses."`
+	Model           string     `yaml:"model,omitempty" json:"model,omitempty" jsonschema:"title=Model" jsonschema_description:"The authenticator model verified using the FIDO Metadata Service."`
 	Attachment      string     `yaml:"attachment" json:"attachment" jsonschema:"title=Attachment" jsonschema_description:"The last recorded credential attachment type."`
 	Transports      []string   `yaml:"transports" json:"transports" jsonschema:"title=Transports" jsonschema_description:"The last recorded credential transports."`
 	SignCount       uint32     `yaml:"sign_count" json:"sign_count" jsonschema:"title=Sign Count" jsonschema_description:"The last recorded credential sign count."`
@@ -341,6 +344,7 @@ func (c *WebAuthnCredentialData) ToCredential() (credential *WebAuthnCredential,
 		Username:        c.Username,
 		Description:     c.Description,
 		AttestationType: c.AttestationType,
+		Model:           c.Model,
 		Attachment:      c.Attachment,
 		Transport:       strings.Join(c.Transports, ","),
 		SignCount:       c.SignCount,
@@ -12,3 +12,20 @@ const (
 var (
 	posixDoubleNewLine = []byte(posixNewLine + posixNewLine)
 )
+
+const (
+	headerContentType = "Content-Type"
+	headerUserAgent   = "User-Agent"
+
+	webhookContentType     = "application/json; charset=utf-8"
+	webhookHeaderDelivery  = "X-Authelia-Delivery"
+	webhookHeaderTimestamp = "X-Authelia-Timestamp"
+	webhookHeaderSignature = "X-Authelia-Signature"
+
+	webhookSignaturePrefix = "sha256="
+	webhookUserAgentFmt    = "Authelia/%s"
+)
+
+const (
+	queueNotifierBatchFactor = 10
+)
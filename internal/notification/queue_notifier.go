@@ -0,0 +1,253 @@
+package notification
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/mail"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/authelia/authelia/v4/internal/clock"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/logging"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/storage"
+	"github.com/authelia/authelia/v4/internal/templates"
+)
+
+// NewQueueNotifier creates a QueueNotifier which durably queues notifications using the storage provider and delivers
+// them in the background using the underlying notifier.
+func NewQueueNotifier(config *schema.NotifierQueue, notifier Notifier, store storage.NotificationQueueProvider, provider *templates.Provider) *QueueNotifier {
+	return &QueueNotifier{
+		config:    config,
+		notifier:  notifier,
+		store:     store,
+		templates: provider,
+		clock:     clock.New(),
+		log:       logging.Logger().WithField("notifier", "queue"),
+	}
+}
+
+// QueueNotifier is a Notifier which saves notifications to the storage provider and returns immediately. The
+// notifications are delivered by the workers started by Run using the underlying Notifier, and are retried with an
+// exponential backoff until they're either delivered or have exhausted all attempts at which point they're considered
+// dead.
+type QueueNotifier struct {
+	config    *schema.NotifierQueue
+	notifier  Notifier
+	store     storage.NotificationQueueProvider
+	templates *templates.Provider
+	clock     clock.Provider
+	log       *logrus.Entry
+
+	mu sync.RWMutex
+}
+
+// StartupCheck implements the startup check provider interface by checking the underlying notifier.
+func (n *QueueNotifier) StartupCheck() (err error) {
+	return n.getNotifier().StartupCheck()
+}
+
+// SetNotifier replaces the underlying notifier used to deliver the queued notifications.
+func (n *QueueNotifier) SetNotifier(notifier Notifier) {
+	n.mu.Lock()
+
+	defer n.mu.Unlock()
+
+	n.notifier = notifier
+}
+
+func (n *QueueNotifier) getNotifier() Notifier {
+	n.mu.RLock()
+
+	defer n.mu.RUnlock()
+
+	return n.notifier
+}
+
+// Send a notification via the QueueNotifier which saves it to the queue for delivery by the workers.
+func (n *QueueNotifier) Send(ctx context.Context, recipient mail.Address, subject string, et *templates.EmailTemplate, data any) (err error) {
+	now := n.clock.Now()
+
+	notification := model.QueuedNotification{
+		CreatedAt:     now,
+		NextAttemptAt: now,
+		Status:        model.QueuedNotificationStatusPending,
+		Recipient:     recipient.String(),
+		Subject:       subject,
+		Template:      et.Name(),
+	}
+
+	if n.templates.GetEmailTemplateByName(notification.Template) == nil {
+		return fmt.Errorf("notifier: queue: failed to queue notification: template '%s' is not a known email template", notification.Template)
+	}
+
+	if notification.Data, err = json.Marshal(data); err != nil {
+		return fmt.Errorf("notifier: queue: failed to queue notification: error encoding template data: %w", err)
+	}
+
+	if err = n.store.SaveQueuedNotification(ctx, notification); err != nil {
+		return fmt.Errorf("notifier: queue: failed to queue notification: %w", err)
+	}
+
+	return nil
+}
+
+// Run the workers which deliver the queued notifications until the context is done. In-flight deliveries are allowed
+// to complete before returning.
+func (n *QueueNotifier) Run(ctx context.Context) (err error) {
+	ticker := time.NewTicker(n.config.PollInterval)
+
+	defer ticker.Stop()
+
+	for {
+		if n.process(ctx) {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// process delivers a single batch of the notifications which are due, returning true if the batch was full and there
+// are likely more notifications which are due.
+func (n *QueueNotifier) process(ctx context.Context) (full bool) {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	limit := n.config.Workers * queueNotifierBatchFactor
+
+	notifications, err := n.store.LoadQueuedNotificationsDue(ctx, n.clock.Now(), limit)
+	if err != nil {
+		if ctx.Err() == nil {
+			n.log.WithError(err).Error("Error occurred loading the notifications which are due for delivery")
+		}
+
+		return false
+	}
+
+	var (
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, n.config.Workers)
+	)
+
+	for _, notification := range notifications {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+
+			return false
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+
+		go func(notification model.QueuedNotification) {
+			defer func() {
+				<-sem
+
+				wg.Done()
+			}()
+
+			n.deliver(ctx, notification)
+		}(notification)
+	}
+
+	wg.Wait()
+
+	return len(notifications) == limit
+}
+
+func (n *QueueNotifier) deliver(ctx context.Context, notification model.QueuedNotification) {
+	log := n.log.WithFields(map[string]any{"id": notification.ID, "template": notification.Template, "attempt": notification.Attempts + 1})
+
+	// The next attempt time acts as a lease while the delivery is in progress, if the process stops before the status
+	// is updated then the notification becomes due again once the backoff for this attempt has elapsed.
+	next := n.clock.Now().Add(n.backoff(notification.Attempts + 1))
+
+	claimed, err := n.store.ClaimQueuedNotification(ctx, notification.ID, notification.Attempts, next)
+
+	switch {
+	case err != nil:
+		log.WithError(err).Error("Error occurred claiming the notification for delivery")
+
+		return
+	case !claimed:
+		log.Trace("Skipping notification which was claimed by another worker")
+
+		return
+	}
+
+	notification.Attempts++
+	notification.NextAttemptAt = next
+
+	if err = n.send(ctx, notification); err == nil {
+		notification.Status = model.QueuedNotificationStatusSent
+		notification.SentAt = sql.NullTime{Time: n.clock.Now(), Valid: true}
+		notification.LastError = sql.NullString{}
+
+		log.Debug("Notification delivered")
+	} else {
+		notification.LastError = sql.NullString{String: err.Error(), Valid: true}
+
+		if notification.Attempts >= n.config.MaxAttempts {
+			notification.Status = model.QueuedNotificationStatusDead
+
+			log.WithError(err).Error("Notification delivery failed and no attempts remain, the notification is now dead")
+		} else {
+			log.WithError(err).WithField("next_attempt", next).Warn("Notification delivery failed and will be retried")
+		}
+	}
+
+	if err = n.store.UpdateQueuedNotificationStatus(context.WithoutCancel(ctx), notification); err != nil {
+		log.WithError(err).Error("Error occurred updating the notification delivery status")
+	}
+}
+
+func (n *QueueNotifier) send(ctx context.Context, notification model.QueuedNotification) (err error) {
+	et := n.templates.GetEmailTemplateByName(notification.Template)
+	if et == nil {
+		return fmt.Errorf("template '%s' is not a known email template", notification.Template)
+	}
+
+	var recipient *mail.Address
+
+	if recipient, err = mail.ParseAddress(notification.Recipient); err != nil {
+		return fmt.Errorf("error parsing recipient: %w", err)
+	}
+
+	var data any
+
+	if len(notification.Data) != 0 {
+		if err = json.Unmarshal(notification.Data, &data); err != nil {
+			return fmt.Errorf("error decoding template data: %w", err)
+		}
+	}
+
+	return n.getNotifier().Send(ctx, *recipient, notification.Subject, et, data)
+}
+
+// backoff returns the interval before the given attempt is retried which doubles after each attempt up to the
+// configured maximum.
+func (n *QueueNotifier) backoff(attempt int) (interval time.Duration) {
+	interval = n.config.RetryInterval
+
+	for i := 1; i < attempt && interval < n.config.MaxRetryInterval; i++ {
+		interval *= 2
+	}
+
+	if interval > n.config.MaxRetryInterval {
+		return n.config.MaxRetryInterval
+	}
+
+	return interval
+}
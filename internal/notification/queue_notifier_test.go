@@ -0,0 +1,385 @@
+package notification_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/mail"
+	"testing"
+	tt "text/template"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/mocks"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/notification"
+	"github.com/authelia/authelia/v4/internal/templates"
+)
+
+func TestQueueNotifierSend(t *testing.T) {
+	provider := newTestQueueTemplates(t)
+
+	testCases := []struct {
+		name     string
+		template *templates.EmailTemplate
+		data     any
+		setup    func(t *testing.T, store *mocks.MockStorage)
+		err      string
+	}{
+		{
+			"ShouldQueueNotification",
+			provider.GetEventEmailTemplate(),
+			templates.EmailEventValues{Title: "Test", DisplayName: "John Smith", Details: map[string]any{"Action": "Test"}},
+			func(t *testing.T, store *mocks.MockStorage) {
+				store.
+					EXPECT().
+					SaveQueuedNotification(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, queued model.QueuedNotification) error {
+						assert.Equal(t, model.QueuedNotificationStatusPending, queued.Status)
+						assert.Equal(t, `"John Smith" <john@example.com>`, queued.Recipient)
+						assert.Equal(t, "Test Subject", queued.Subject)
+						assert.Equal(t, templates.TemplateNameEmailEvent, queued.Template)
+						assert.Equal(t, 0, queued.Attempts)
+						assert.Equal(t, queued.CreatedAt, queued.NextAttemptAt)
+						assert.JSONEq(t, `{"Title":"Test","DisplayName":"John Smith","Details":{"Action":"Test"},"RemoteIP":""}`, string(queued.Data))
+
+						return nil
+					})
+			},
+			"",
+		},
+		{
+			"ShouldFailUnknownTemplate",
+			&templates.EmailTemplate{Text: tt.Must(tt.New("Unknown.txt").Parse(""))},
+			nil,
+			nil,
+			"notifier: queue: failed to queue notification: template 'Unknown' is not a known email template",
+		},
+		{
+			"ShouldFailToEncodeData",
+			provider.GetEventEmailTemplate(),
+			make(chan int),
+			nil,
+			"notifier: queue: failed to queue notification: error encoding template data: json: unsupported type: chan int",
+		},
+		{
+			"ShouldFailToSave",
+			provider.GetEventEmailTemplate(),
+			nil,
+			func(t *testing.T, store *mocks.MockStorage) {
+				store.
+					EXPECT().
+					SaveQueuedNotification(gomock.Any(), gomock.Any()).
+					Return(errors.New("bad conn"))
+			},
+			"notifier: queue: failed to queue notification: bad conn",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			store := mocks.NewMockStorage(ctrl)
+
+			if tc.setup != nil {
+				tc.setup(t, store)
+			}
+
+			n := notification.NewQueueNotifier(newTestQueueConfig(), mocks.NewMockNotifier(ctrl), store, provider)
+
+			err := n.Send(context.Background(), mail.Address{Name: "John Smith", Address: "john@example.com"}, "Test Subject", tc.template, tc.data)
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestQueueNotifierDeliver(t *testing.T) {
+	provider := newTestQueueTemplates(t)
+
+	testCases := []struct {
+		name     string
+		have     model.QueuedNotification
+		claimed  bool
+		send     error
+		expected func(t *testing.T, updated model.QueuedNotification)
+	}{
+		{
+			"ShouldSkipNotificationClaimedByAnotherWorker",
+			newTestQueuedNotification(0),
+			false,
+			nil,
+			nil,
+		},
+		{
+			"ShouldMarkNotificationSent",
+			newTestQueuedNotification(0),
+			true,
+			nil,
+			func(t *testing.T, updated model.QueuedNotification) {
+				assert.Equal(t, model.QueuedNotificationStatusSent, updated.Status)
+				assert.Equal(t, 1, updated.Attempts)
+				assert.True(t, updated.SentAt.Valid)
+				assert.False(t, updated.LastError.Valid)
+			},
+		},
+		{
+			"ShouldRetryNotification",
+			newTestQueuedNotification(1),
+			true,
+			errors.New("bad conn"),
+			func(t *testing.T, updated model.QueuedNotification) {
+				assert.Equal(t, model.QueuedNotificationStatusPending, updated.Status)
+				assert.Equal(t, 2, updated.Attempts)
+				assert.False(t, updated.SentAt.Valid)
+				assert.Equal(t, sql.NullString{String: "bad conn", Valid: true}, updated.LastError)
+			},
+		},
+		{
+			"ShouldMarkNotificationDeadAtMaxAttempts",
+			newTestQueuedNotification(2),
+			true,
+			errors.New("bad conn"),
+			func(t *testing.T, updated model.QueuedNotification) {
+				assert.Equal(t, model.QueuedNotificationStatusDead, updated.Status)
+				assert.Equal(t, 3, updated.Attempts)
+				assert.False(t, updated.SentAt.Valid)
+				assert.Equal(t, sql.NullString{String: "bad conn", Valid: true}, updated.LastError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			store := mocks.NewMockStorage(ctrl)
+			notifier := mocks.NewMockNotifier(ctrl)
+
+			ctx, cancel := context.WithCancel(context.Background())
+
+			defer cancel()
+
+			var until time.Time
+
+			calls := []any{
+				store.
+					EXPECT().
+					LoadQueuedNotificationsDue(gomock.Any(), gomock.Any(), 10).
+					Return([]model.QueuedNotification{tc.have}, nil),
+				store.
+					EXPECT().
+					ClaimQueuedNotification(gomock.Any(), tc.have.ID, tc.have.Attempts, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _, _ int, next time.Time) (bool, error) {
+						until = next
+
+						if !tc.claimed {
+							cancel()
+						}
+
+						return tc.claimed, nil
+					}),
+			}
+
+			if tc.claimed {
+				calls = append(calls,
+					notifier.
+						EXPECT().
+						Send(gomock.Any(), mail.Address{Name: "John Smith", Address: "john@example.com"}, "Test Subject", provider.GetEventEmailTemplate(), map[string]any{"Title": "Test"}).
+						Return(tc.send),
+					store.
+						EXPECT().
+						UpdateQueuedNotificationStatus(gomock.Any(), gomock.Any()).
+						DoAndReturn(func(_ context.Context, updated model.QueuedNotification) error {
+							assert.Equal(t, tc.have.ID, updated.ID)
+							assert.Equal(t, until, updated.NextAttemptAt)
+
+							tc.expected(t, updated)
+
+							cancel()
+
+							return nil
+						}),
+				)
+			}
+
+			gomock.InOrder(calls...)
+
+			n := notification.NewQueueNotifier(newTestQueueConfig(), notifier, store, provider)
+
+			runTestQueueNotifier(t, ctx, n)
+		})
+	}
+}
+
+func TestQueueNotifierBackoff(t *testing.T) {
+	testCases := []struct {
+		name     string
+		attempts int
+		expected time.Duration
+	}{
+		{"ShouldUseRetryIntervalForFirstAttempt", 0, time.Second},
+		{"ShouldDoubleForSecondAttempt", 1, time.Second * 2},
+		{"ShouldDoubleForThirdAttempt", 2, time.Second * 4},
+		{"ShouldCapAtMaxRetryInterval", 4, time.Second * 10},
+		{"ShouldCapAtMaxRetryIntervalForManyAttempts", 100, time.Second * 10},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			store := mocks.NewMockStorage(ctrl)
+
+			ctx, cancel := context.WithCancel(context.Background())
+
+			defer cancel()
+
+			var before, until time.Time
+
+			gomock.InOrder(
+				store.
+					EXPECT().
+					LoadQueuedNotificationsDue(gomock.Any(), gomock.Any(), 10).
+					DoAndReturn(func(_ context.Context, _ time.Time, _ int) ([]model.QueuedNotification, error) {
+						before = time.Now()
+
+						return []model.QueuedNotification{newTestQueuedNotification(tc.attempts)}, nil
+					}),
+				store.
+					EXPECT().
+					ClaimQueuedNotification(gomock.Any(), 1, tc.attempts, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _, _ int, next time.Time) (bool, error) {
+						until = next
+
+						cancel()
+
+						return false, nil
+					}),
+			)
+
+			config := newTestQueueConfig()
+			config.MaxAttempts = 1000
+
+			n := notification.NewQueueNotifier(config, mocks.NewMockNotifier(ctrl), store, newTestQueueTemplates(t))
+
+			runTestQueueNotifier(t, ctx, n)
+
+			assert.GreaterOrEqual(t, until.Sub(before), tc.expected)
+			assert.Less(t, until.Sub(before), tc.expected+time.Second)
+		})
+	}
+}
+
+func TestQueueNotifierProcessShouldContinueFullBatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	store := mocks.NewMockStorage(ctrl)
+	notifier := mocks.NewMockNotifier(ctrl)
+	provider := newTestQueueTemplates(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	defer cancel()
+
+	batch := make([]model.QueuedNotification, 10)
+
+	for i := range batch {
+		batch[i] = newTestQueuedNotification(0)
+		batch[i].ID = i + 1
+	}
+
+	store.
+		EXPECT().
+		ClaimQueuedNotification(gomock.Any(), gomock.Any(), 0, gomock.Any()).
+		Return(true, nil).
+		Times(len(batch))
+
+	notifier.
+		EXPECT().
+		Send(gomock.Any(), gomock.Any(), "Test Subject", gomock.Any(), gomock.Any()).
+		Return(nil).
+		Times(len(batch))
+
+	store.
+		EXPECT().
+		UpdateQueuedNotificationStatus(gomock.Any(), gomock.Any()).
+		Return(nil).
+		Times(len(batch))
+
+	gomock.InOrder(
+		store.
+			EXPECT().
+			LoadQueuedNotificationsDue(gomock.Any(), gomock.Any(), 10).
+			Return(batch, nil),
+		store.
+			EXPECT().
+			LoadQueuedNotificationsDue(gomock.Any(), gomock.Any(), 10).
+			DoAndReturn(func(_ context.Context, _ time.Time, _ int) ([]model.QueuedNotification, error) {
+				cancel()
+
+				return nil, nil
+			}),
+	)
+
+	n := notification.NewQueueNotifier(newTestQueueConfig(), notifier, store, provider)
+
+	runTestQueueNotifier(t, ctx, n)
+}
+
+// runTestQueueNotifier runs the notifier until the context is cancelled. The poll interval is long enough that the
+// test fails if the notifier waits for the next poll instead of the expected behaviour.
+func runTestQueueNotifier(t *testing.T, ctx context.Context, n *notification.QueueNotifier) {
+	done := make(chan error, 1)
+
+	go func() {
+		done <- n.Run(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second * 10):
+		t.Fatal("timed out waiting for the queue notifier to stop")
+	}
+}
+
+func newTestQueueConfig() *schema.NotifierQueue {
+	return &schema.NotifierQueue{
+		Enable:           true,
+		Workers:          1,
+		PollInterval:     time.Hour,
+		MaxAttempts:      3,
+		RetryInterval:    time.Second,
+		MaxRetryInterval: time.Second * 10,
+	}
+}
+
+func newTestQueuedNotification(attempts int) model.QueuedNotification {
+	return model.QueuedNotification{
+		ID:        1,
+		Attempts:  attempts,
+		Status:    model.QueuedNotificationStatusPending,
+		Recipient: `"John Smith" <john@example.com>`,
+		Subject:   "Test Subject",
+		Template:  templates.TemplateNameEmailEvent,
+		Data:      []byte(`{"Title":"Test"}`),
+	}
+}
+
+func newTestQueueTemplates(t *testing.T) *templates.Provider {
+	provider, err := templates.New(templates.Config{})
+
+	require.NoError(t, err)
+
+	return provider
+}
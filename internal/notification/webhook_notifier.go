@@ -0,0 +1,205 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/mail"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/logging"
+	"github.com/authelia/authelia/v4/internal/templates"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// NewWebhookNotifier creates a WebhookNotifier using the notifier configuration.
+func NewWebhookNotifier(config *schema.NotifierWebhook, certPool *x509.CertPool) *WebhookNotifier {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if config.TLS != nil {
+		transport.TLSClientConfig = utils.NewTLSConfig(config.TLS, certPool)
+	}
+
+	return &WebhookNotifier{
+		config: config,
+		client: &http.Client{Transport: transport, Timeout: config.Timeout},
+		log:    logging.Logger().WithFields(map[string]any{"provider": "notifier", "notifier": "webhook"}),
+	}
+}
+
+// WebhookNotifier a notifier to send notifications to a HTTP endpoint.
+type WebhookNotifier struct {
+	config *schema.NotifierWebhook
+	client *http.Client
+	log    *logrus.Entry
+}
+
+// StartupCheck implements model.StartupCheck to perform startup check operations. It only ensures the endpoint is
+// reachable, any HTTP response is considered a success as the endpoint is not required to implement HEAD requests.
+func (n *WebhookNotifier) StartupCheck() (err error) {
+	var (
+		req  *http.Request
+		resp *http.Response
+	)
+
+	if req, err = http.NewRequest(http.MethodHead, n.config.Address.String(), nil); err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set(headerUserAgent, fmt.Sprintf(webhookUserAgentFmt, utils.Version()))
+
+	if resp, err = n.client.Do(req); err != nil {
+		return fmt.Errorf("failed to perform request: %w", err)
+	}
+
+	_ = resp.Body.Close()
+
+	return nil
+}
+
+// Send a notification via the WebhookNotifier.
+func (n *WebhookNotifier) Send(ctx context.Context, recipient mail.Address, subject string, et *templates.EmailTemplate, data any) (err error) {
+	payload := WebhookPayload{
+		Recipient: WebhookRecipient{Name: recipient.Name, Address: recipient.Address},
+		Subject:   subject,
+		Template:  et.Name(),
+		Data:      data,
+	}
+
+	buf := &bytes.Buffer{}
+
+	if err = et.Text.Execute(buf, data); err != nil {
+		return fmt.Errorf("notifier: webhook: failed to render body: text template errored: %w", err)
+	}
+
+	payload.Text = buf.String()
+
+	buf.Reset()
+
+	if err = et.HTML.Execute(buf, data); err != nil {
+		return fmt.Errorf("notifier: webhook: failed to render body: html template errored: %w", err)
+	}
+
+	payload.HTML = buf.String()
+
+	var body []byte
+
+	if body, err = json.Marshal(&payload); err != nil {
+		return fmt.Errorf("notifier: webhook: failed to marshal payload: %w", err)
+	}
+
+	// The timeout bounds the total time spent delivering the notification including any retries, as the request which
+	// caused the notification waits for the delivery unless the queue is enabled.
+	ctx, cancel := context.WithTimeout(ctx, n.config.Timeout)
+
+	defer cancel()
+
+	deadline, _ := ctx.Deadline()
+	delivery := uuid.New().String()
+	interval := n.config.RetryInterval
+
+	for attempt := 0; ; attempt++ {
+		var retry bool
+
+		if retry, err = n.send(ctx, delivery, body); err == nil {
+			return nil
+		}
+
+		if !retry || attempt >= n.config.MaxRetries || time.Now().Add(interval).After(deadline) {
+			break
+		}
+
+		n.log.WithError(err).WithFields(map[string]any{"delivery": delivery, "attempt": attempt + 1}).Debugf("Failed to send notification, retrying in %s", interval)
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("notifier: webhook: failed to send notification: %w", ctx.Err())
+		case <-time.After(interval):
+			interval = min(interval*2, schema.WebhookNotifierMaximumRetryInterval)
+		}
+	}
+
+	return fmt.Errorf("notifier: webhook: failed to send notification: %w", err)
+}
+
+// send performs a single delivery attempt and reports if the attempt may be retried on failure.
+func (n *WebhookNotifier) send(ctx context.Context, delivery string, body []byte) (retry bool, err error) {
+	var (
+		req  *http.Request
+		resp *http.Response
+	)
+
+	if req, err = http.NewRequestWithContext(ctx, http.MethodPost, n.config.Address.String(), bytes.NewReader(body)); err != nil {
+		return false, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	for key, value := range n.config.Headers {
+		req.Header.Set(key, value)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req.Header.Set(headerContentType, webhookContentType)
+	req.Header.Set(headerUserAgent, fmt.Sprintf(webhookUserAgentFmt, utils.Version()))
+	req.Header.Set(webhookHeaderDelivery, delivery)
+	req.Header.Set(webhookHeaderTimestamp, timestamp)
+	req.Header.Set(webhookHeaderSignature, webhookSignaturePrefix+WebhookSignature([]byte(n.config.Secret), timestamp, body))
+
+	if resp, err = n.client.Do(req); err != nil {
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded), fmt.Errorf("failed to perform request: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode >= 500:
+		return true, fmt.Errorf("endpoint responded with status code %d", resp.StatusCode)
+	default:
+		return false, fmt.Errorf("endpoint responded with status code %d", resp.StatusCode)
+	}
+}
+
+// WebhookSignature returns the hex encoded HMAC-SHA256 signature of a payload. The signed message is the timestamp and
+// the payload joined by a period which allows receivers to reject replayed requests.
+func WebhookSignature(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// WebhookPayload is the JSON body sent to the webhook endpoint.
+type WebhookPayload struct {
+	Recipient WebhookRecipient `json:"recipient"`
+	Subject   string           `json:"subject"`
+	Template  string           `json:"template"`
+	Text      string           `json:"text"`
+	HTML      string           `json:"html"`
+	Data      any              `json:"data"`
+}
+
+// WebhookRecipient is the recipient of a WebhookPayload.
+type WebhookRecipient struct {
+	Name    string `json:"name,omitempty"`
+	Address string `json:"address"`
+}
@@ -0,0 +1,262 @@
+package notification
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	th "html/template"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"net/url"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	tt "text/template"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/templates"
+)
+
+func TestWebhookNotifierSendShouldSignPayload(t *testing.T) {
+	var (
+		headers http.Header
+		body    []byte
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header.Clone()
+		body, _ = io.ReadAll(r.Body)
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(newTestWebhookConfig(t, server.URL, 0, time.Millisecond), nil)
+
+	require.NoError(t, notifier.Send(context.Background(), mail.Address{Name: "John Smith", Address: "john@example.com"}, "Test Subject", newTestWebhookTemplate(), testWebhookData))
+
+	assert.Equal(t, webhookContentType, headers.Get(headerContentType))
+	assert.Equal(t, "value", headers.Get("X-Custom"))
+	assert.NotEmpty(t, headers.Get(webhookHeaderDelivery))
+
+	timestamp := headers.Get(webhookHeaderTimestamp)
+
+	_, err := strconv.ParseInt(timestamp, 10, 64)
+	require.NoError(t, err)
+
+	mac := hmac.New(sha256.New, []byte("abc123"))
+
+	mac.Write([]byte(timestamp + "." + string(body)))
+
+	assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), headers.Get(webhookHeaderSignature))
+
+	payload := WebhookPayload{}
+
+	require.NoError(t, json.Unmarshal(body, &payload))
+
+	assert.Equal(t, WebhookRecipient{Name: "John Smith", Address: "john@example.com"}, payload.Recipient)
+	assert.Equal(t, "Test Subject", payload.Subject)
+	assert.Equal(t, "Test", payload.Template)
+	assert.Equal(t, "Hello John", payload.Text)
+	assert.Equal(t, "<p>Hello John</p>", payload.HTML)
+	assert.Equal(t, map[string]any{"Name": "John"}, payload.Data)
+}
+
+func TestWebhookNotifierSendShouldRetry(t *testing.T) {
+	testCases := []struct {
+		name       string
+		maxRetries int
+		statuses   []int
+		expected   int
+		err        string
+	}{
+		{
+			"ShouldRetryServerError",
+			3,
+			[]int{http.StatusInternalServerError, http.StatusBadGateway, http.StatusOK},
+			3,
+			"",
+		},
+		{
+			"ShouldNotRetryClientError",
+			3,
+			[]int{http.StatusBadRequest},
+			1,
+			"notifier: webhook: failed to send notification: endpoint responded with status code 400",
+		},
+		{
+			"ShouldNotRetryTooManyRequests",
+			3,
+			[]int{http.StatusTooManyRequests},
+			1,
+			"notifier: webhook: failed to send notification: endpoint responded with status code 429",
+		},
+		{
+			"ShouldStopAfterMaxRetries",
+			2,
+			[]int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK},
+			3,
+			"notifier: webhook: failed to send notification: endpoint responded with status code 503",
+		},
+		{
+			"ShouldNotRetryWhenDisabled",
+			-1,
+			[]int{http.StatusInternalServerError, http.StatusOK},
+			1,
+			"notifier: webhook: failed to send notification: endpoint responded with status code 500",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var attempts atomic.Int32
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := int(attempts.Add(1))
+
+				w.WriteHeader(tc.statuses[min(n, len(tc.statuses))-1])
+			}))
+
+			defer server.Close()
+
+			notifier := NewWebhookNotifier(newTestWebhookConfig(t, server.URL, tc.maxRetries, time.Millisecond), nil)
+
+			err := notifier.Send(context.Background(), mail.Address{Address: "john@example.com"}, "Test Subject", newTestWebhookTemplate(), testWebhookData)
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+
+			assert.Equal(t, tc.expected, int(attempts.Load()))
+		})
+	}
+}
+
+func TestWebhookNotifierSendShouldRetryTransportError(t *testing.T) {
+	var attempts atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) == 1 {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			require.NoError(t, err)
+
+			_ = conn.Close()
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(newTestWebhookConfig(t, server.URL, 1, time.Millisecond), nil)
+
+	assert.NoError(t, notifier.Send(context.Background(), mail.Address{Address: "john@example.com"}, "Test Subject", newTestWebhookTemplate(), testWebhookData))
+	assert.Equal(t, int32(2), attempts.Load())
+}
+
+func TestWebhookNotifierSendShouldStopOnContextCancel(t *testing.T) {
+	var attempts atomic.Int32
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	defer cancel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+
+		w.WriteHeader(http.StatusInternalServerError)
+
+		cancel()
+	}))
+
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(newTestWebhookConfig(t, server.URL, 3, time.Second), nil)
+
+	err := notifier.Send(ctx, mail.Address{Address: "john@example.com"}, "Test Subject", newTestWebhookTemplate(), testWebhookData)
+
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Equal(t, int32(1), attempts.Load())
+}
+
+func TestWebhookNotifierSendShouldStopRetryingAtTimeout(t *testing.T) {
+	var attempts atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+
+	defer server.Close()
+
+	config := newTestWebhookConfig(t, server.URL, 10, time.Millisecond*100)
+	config.Timeout = time.Millisecond * 250
+
+	notifier := NewWebhookNotifier(config, nil)
+
+	start := time.Now()
+
+	err := notifier.Send(context.Background(), mail.Address{Address: "john@example.com"}, "Test Subject", newTestWebhookTemplate(), testWebhookData)
+
+	assert.EqualError(t, err, "notifier: webhook: failed to send notification: endpoint responded with status code 500")
+	assert.Less(t, time.Since(start), config.Timeout)
+	assert.Equal(t, int32(2), attempts.Load())
+}
+
+func TestWebhookNotifierStartupCheck(t *testing.T) {
+	var method string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method = r.Method
+
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}))
+
+	notifier := NewWebhookNotifier(newTestWebhookConfig(t, server.URL, 0, time.Millisecond), nil)
+
+	assert.NoError(t, notifier.StartupCheck())
+	assert.Equal(t, http.MethodHead, method)
+
+	server.Close()
+
+	assert.ErrorContains(t, notifier.StartupCheck(), "failed to perform request: ")
+}
+
+var testWebhookData = map[string]string{"Name": "John"}
+
+func newTestWebhookConfig(t *testing.T, address string, maxRetries int, interval time.Duration) *schema.NotifierWebhook {
+	u, err := url.Parse(address)
+
+	require.NoError(t, err)
+
+	return &schema.NotifierWebhook{
+		Address:       u,
+		Secret:        "abc123",
+		Timeout:       time.Second * 5,
+		MaxRetries:    maxRetries,
+		RetryInterval: interval,
+		Headers:       map[string]string{"X-Custom": "value"},
+	}
+}
+
+func newTestWebhookTemplate() *templates.EmailTemplate {
+	return &templates.EmailTemplate{
+		Text: tt.Must(tt.New("Test.txt").Parse("Hello {{ .Name }}")),
+		HTML: th.Must(th.New("Test.html").Parse("<p>Hello {{ .Name }}</p>")),
+	}
+}
@@ -9,6 +9,8 @@ func NewAuthenticationMethodsReferencesFromClaim(claim []string) (amr Authentica
 			amr.TOTP = true
 		case AMRShortMessageService:
 			amr.Duo = true
+		case AMREmailOneTimeCode:
+			amr.Email = true
 		case AMRHardwareSecuredKey:
 			amr.WebAuthn = true
 		case AMRUserPresence:
@@ -23,20 +25,25 @@ func NewAuthenticationMethodsReferencesFromClaim(claim []string) (amr Authentica
 type AuthenticationMethodsReferences struct {
 	UsernameAndPassword  bool
 	TOTP                 bool
+	RecoveryCode         bool
 	Duo                  bool
+	Email                bool
 	WebAuthn             bool
 	WebAuthnUserPresence bool
 	WebAuthnUserVerified bool
 }
 
-// FactorKnowledge returns true if a "something you know" factor of authentication was used.
+// FactorKnowledge returns true if a "something you know" factor of authentication was used. A WebAuthn credential which
+// performed user verification is considered to have used this factor as the authenticator verified a PIN or biometric.
 func (r AuthenticationMethodsReferences) FactorKnowledge() bool {
-	return r.UsernameAndPassword
+	return r.UsernameAndPassword || (r.WebAuthn && r.WebAuthnUserVerified)
 }
 
-// FactorPossession returns true if a "something you have" factor of authentication was used.
+// FactorPossession returns true if a "something you have" factor of authentication was used. A recovery code is
+// considered to be a possession factor as it's a one-time secret the user was issued and is expected to keep safe, and
+// an email one-time code is considered to be a possession factor as it proves control of the users mailbox.
 func (r AuthenticationMethodsReferences) FactorPossession() bool {
-	return r.TOTP || r.WebAuthn || r.Duo
+	return r.TOTP || r.RecoveryCode || r.WebAuthn || r.Duo || r.Email
 }
 
 // MultiFactorAuthentication returns true if multiple factors were used.
@@ -46,12 +53,12 @@ func (r AuthenticationMethodsReferences) MultiFactorAuthentication() bool {
 
 // ChannelBrowser returns true if a browser was used to authenticate.
 func (r AuthenticationMethodsReferences) ChannelBrowser() bool {
-	return r.UsernameAndPassword || r.TOTP || r.WebAuthn
+	return r.UsernameAndPassword || r.TOTP || r.RecoveryCode || r.WebAuthn
 }
 
 // ChannelService returns true if a non-browser service was used to authenticate.
 func (r AuthenticationMethodsReferences) ChannelService() bool {
-	return r.Duo
+	return r.Duo || r.Email
 }
 
 // MultiChannelAuthentication returns true if the user used more than one channel to authenticate.
@@ -59,7 +66,8 @@ func (r AuthenticationMethodsReferences) MultiChannelAuthentication() bool {
 	return r.ChannelBrowser() && r.ChannelService()
 }
 
-// MarshalRFC8176 returns the AMR claim slice of strings in the RFC8176 format.
+// MarshalRFC8176 returns the AMR claim slice of strings in the RFC8176 format. A recovery code is a one-time password
+// so it's represented by the same value as TOTP.
 // https://datatracker.ietf.org/doc/html/rfc8176
 func (r AuthenticationMethodsReferences) MarshalRFC8176() []string {
 	var amr []string
@@ -68,7 +76,7 @@ func (r AuthenticationMethodsReferences) MarshalRFC8176() []string {
 		amr = append(amr, AMRPasswordBasedAuthentication)
 	}
 
-	if r.TOTP {
+	if r.TOTP || r.RecoveryCode {
 		amr = append(amr, AMROneTimePassword)
 	}
 
@@ -76,6 +84,10 @@ func (r AuthenticationMethodsReferences) MarshalRFC8176() []string {
 		amr = append(amr, AMRShortMessageService)
 	}
 
+	if r.Email {
+		amr = append(amr, AMREmailOneTimeCode)
+	}
+
 	if r.WebAuthn {
 		amr = append(amr, AMRHardwareSecuredKey)
 	}
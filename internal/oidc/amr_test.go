@@ -51,6 +51,62 @@ func TestAuthenticationMethodsReferences(t *testing.T) {
 				RFC8176:                    []string{"otp"},
 			},
 		},
+		{
+			desc: "RecoveryCode",
+
+			is: oidc.AuthenticationMethodsReferences{RecoveryCode: true},
+			want: testAMRWant{
+				FactorKnowledge:            false,
+				FactorPossession:           true,
+				MultiFactorAuthentication:  false,
+				ChannelBrowser:             true,
+				ChannelService:             false,
+				MultiChannelAuthentication: false,
+				RFC8176:                    []string{"otp"},
+			},
+		},
+		{
+			desc: "Username and Password with RecoveryCode",
+
+			is: oidc.AuthenticationMethodsReferences{UsernameAndPassword: true, RecoveryCode: true},
+			want: testAMRWant{
+				FactorKnowledge:            true,
+				FactorPossession:           true,
+				MultiFactorAuthentication:  true,
+				ChannelBrowser:             true,
+				ChannelService:             false,
+				MultiChannelAuthentication: false,
+				RFC8176:                    []string{"pwd", "otp", "mfa"},
+			},
+		},
+		{
+			desc: "Email",
+
+			is: oidc.AuthenticationMethodsReferences{Email: true},
+			want: testAMRWant{
+				FactorKnowledge:            false,
+				FactorPossession:           true,
+				MultiFactorAuthentication:  false,
+				ChannelBrowser:             false,
+				ChannelService:             true,
+				MultiChannelAuthentication: false,
+				RFC8176:                    []string{"email"},
+			},
+		},
+		{
+			desc: "Username and Password with Email",
+
+			is: oidc.AuthenticationMethodsReferences{UsernameAndPassword: true, Email: true},
+			want: testAMRWant{
+				FactorKnowledge:            true,
+				FactorPossession:           true,
+				MultiFactorAuthentication:  true,
+				ChannelBrowser:             true,
+				ChannelService:             true,
+				MultiChannelAuthentication: true,
+				RFC8176:                    []string{"pwd", "email", "mfa", "mca"},
+			},
+		},
 		{
 			desc: "WebAuthn",
 
@@ -98,13 +154,13 @@ func TestAuthenticationMethodsReferences(t *testing.T) {
 
 			is: oidc.AuthenticationMethodsReferences{WebAuthn: true, WebAuthnUserVerified: true, WebAuthnUserPresence: true},
 			want: testAMRWant{
-				FactorKnowledge:            false,
+				FactorKnowledge:            true,
 				FactorPossession:           true,
-				MultiFactorAuthentication:  false,
+				MultiFactorAuthentication:  true,
 				ChannelBrowser:             true,
 				ChannelService:             false,
 				MultiChannelAuthentication: false,
-				RFC8176:                    []string{"hwk", "user", "pin"},
+				RFC8176:                    []string{"hwk", "user", "pin", "mfa"},
 			},
 		},
 		{
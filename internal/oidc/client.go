@@ -321,6 +321,12 @@ func (c *RegisteredClient) IsAuthenticationLevelSufficient(level authentication.
 	return authorization.IsAuthLevelSufficient(level, c.GetAuthorizationPolicyRequiredLevel(subject))
 }
 
+// IsAuthenticationMethodsSufficient returns if the provided Authentication Methods References are sufficient for the
+// client of the AutheliaClient.
+func (c *RegisteredClient) IsAuthenticationMethodsSufficient(amr []string, subject authorization.Subject) (sufficient bool) {
+	return authorization.IsAuthMethodsSufficient(amr, c.AuthorizationPolicy.GetRequiredAMR(subject))
+}
+
 // GetAuthorizationPolicyRequiredLevel returns the required authorization.Level given an authorization.Subject.
 func (c *RegisteredClient) GetAuthorizationPolicyRequiredLevel(subject authorization.Subject) (level authorization.Level) {
 	return c.AuthorizationPolicy.GetRequiredLevel(subject)
@@ -23,6 +23,7 @@ func NewClientAuthorizationPolicy(name string, config *schema.IdentityProvidersO
 				policy.Rules = append(policy.Rules, ClientAuthorizationPolicyRule{
 					Policy:   authorization.NewLevel(r.Policy),
 					Subjects: authorization.NewSubjects(r.Subjects),
+					AMR:      r.AMR,
 				})
 			}
 
@@ -77,10 +78,23 @@ func (p *ClientAuthorizationPolicy) GetRequiredLevel(subject authorization.Subje
 	return p.DefaultPolicy
 }
 
+// GetRequiredAMR returns the Authentication Methods References of which at least one must have been used given an
+// authorization.Subject.
+func (p *ClientAuthorizationPolicy) GetRequiredAMR(subject authorization.Subject) (amr []string) {
+	for _, rule := range p.Rules {
+		if rule.IsMatch(subject) {
+			return rule.AMR
+		}
+	}
+
+	return nil
+}
+
 // ClientAuthorizationPolicyRule describes the authorization.Level for particular criteria relevant to OpenID Connect 1.0 Clients.
 type ClientAuthorizationPolicyRule struct {
 	Subjects []authorization.AccessControlSubjects
 	Policy   authorization.Level
+	AMR      []string
 }
 
 // MatchesSubjects returns true if the rule matches the subjects.
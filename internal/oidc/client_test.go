@@ -251,6 +251,32 @@ func TestIsAuthenticationLevelSufficient(t *testing.T) {
 	assert.False(t, c.IsAuthenticationLevelSufficient(authentication.TwoFactor, authorization.Subject{}))
 }
 
+func TestIsAuthenticationMethodsSufficient(t *testing.T) {
+	c := &oidc.RegisteredClient{}
+
+	c.AuthorizationPolicy = oidc.ClientAuthorizationPolicy{DefaultPolicy: authorization.TwoFactor}
+	assert.True(t, c.IsAuthenticationMethodsSufficient(nil, authorization.Subject{}))
+	assert.True(t, c.IsAuthenticationMethodsSufficient([]string{"pwd", "otp", "mfa"}, authorization.Subject{Username: "john"}))
+
+	c.AuthorizationPolicy = oidc.ClientAuthorizationPolicy{DefaultPolicy: authorization.TwoFactor, Rules: []oidc.ClientAuthorizationPolicyRule{
+		{
+			Policy: authorization.TwoFactor,
+			Subjects: []authorization.AccessControlSubjects{
+				{
+					Subjects: []authorization.SubjectMatcher{
+						authorization.AccessControlUser{Name: "john"},
+					},
+				},
+			},
+			AMR: []string{"hwk"},
+		},
+	}}
+
+	assert.False(t, c.IsAuthenticationMethodsSufficient([]string{"pwd", "otp", "mfa"}, authorization.Subject{Username: "john"}))
+	assert.True(t, c.IsAuthenticationMethodsSufficient([]string{"pwd", "hwk", "mfa"}, authorization.Subject{Username: "john"}))
+	assert.True(t, c.IsAuthenticationMethodsSufficient([]string{"pwd", "otp", "mfa"}, authorization.Subject{Username: "fred"}))
+}
+
 func TestClient_GetConsentResponseBody(t *testing.T) {
 	c := &oidc.RegisteredClient{}
 
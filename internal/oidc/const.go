@@ -317,6 +317,16 @@ const (
 	//
 	// RFC8176: https://datatracker.ietf.org/doc/html/rfc8176
 	AMRShortMessageService = "sms"
+
+	// AMREmailOneTimeCode is an Authentication Method Reference Value that represents authentication via a one-time
+	// code delivered to the user by email. This value is not registered in the IANA Authentication Method Reference
+	// Values registry, it's intentionally distinct from AMROneTimePassword so relying parties can distinguish it from
+	// TOTP.
+	//
+	// Authelia utilizes this when a user has used an Email One-Time Code to authenticate. Factor: Have, Channel: Service.
+	//
+	// RFC8176: https://datatracker.ietf.org/doc/html/rfc8176
+	AMREmailOneTimeCode = "email"
 )
 
 const (
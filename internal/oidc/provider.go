@@ -33,6 +33,19 @@ func NewOpenIDConnectProvider(config *schema.IdentityProvidersOpenIDConnect, sto
 	return provider
 }
 
+// ReloadClients replaces the registered clients with the clients from the provided configuration. It returns false if
+// the client store doesn't support reloading the clients.
+func (p *OpenIDConnectProvider) ReloadClients(config *schema.IdentityProvidersOpenIDConnect) (reloaded bool) {
+	store, ok := p.Store.ClientStore.(*MemoryClientStore)
+	if !ok {
+		return false
+	}
+
+	store.Reload(config)
+
+	return true
+}
+
 // GetOAuth2WellKnownConfiguration returns the discovery document for the OAuth Configuration.
 func (p *OpenIDConnectProvider) GetOAuth2WellKnownConfiguration(issuer string) OAuth2WellKnownConfiguration {
 	options := p.discovery.OAuth2WellKnownConfiguration.Copy()
@@ -29,24 +29,28 @@ func NewStore(config *schema.IdentityProvidersOpenIDConnect, provider storage.Pr
 }
 
 func NewMemoryClientStore(config *schema.IdentityProvidersOpenIDConnect) (store *MemoryClientStore) {
-	logger := logging.Logger()
-
-	store = &MemoryClientStore{
-		clients: map[string]Client{},
+	return &MemoryClientStore{
+		clients: newMemoryClients(config),
 	}
+}
 
-	for _, client := range config.Clients {
-		policy := authorization.NewLevel(client.AuthorizationPolicy)
-		logger.Debugf("Registering client %s with policy %s (%v)", client.ID, client.AuthorizationPolicy, policy)
+// Reload replaces the registered clients with the clients from the provided configuration.
+func (s *MemoryClientStore) Reload(config *schema.IdentityProvidersOpenIDConnect) {
+	clients := newMemoryClients(config)
 
-		store.clients[client.ID] = NewClient(client, config)
-	}
+	s.mu.Lock()
 
-	return store
+	defer s.mu.Unlock()
+
+	s.clients = clients
 }
 
 // GetRegisteredClient returns a Client matching the provided id.
 func (s *MemoryClientStore) GetRegisteredClient(_ context.Context, id string) (client Client, err error) {
+	s.mu.RLock()
+
+	defer s.mu.RUnlock()
+
 	client, ok := s.clients[id]
 	if !ok {
 		return nil, oauthelia2.ErrInvalidClient.WithDebugf("Client with id '%s' does not appear to be a registered client.", id)
@@ -55,6 +59,21 @@ func (s *MemoryClientStore) GetRegisteredClient(_ context.Context, id string) (c
 	return client, nil
 }
 
+func newMemoryClients(config *schema.IdentityProvidersOpenIDConnect) (clients map[string]Client) {
+	logger := logging.Logger()
+
+	clients = make(map[string]Client, len(config.Clients))
+
+	for _, client := range config.Clients {
+		policy := authorization.NewLevel(client.AuthorizationPolicy)
+		logger.Debugf("Registering client %s with policy %s (%v)", client.ID, client.AuthorizationPolicy, policy)
+
+		clients[client.ID] = NewClient(client, config)
+	}
+
+	return clients
+}
+
 // GenerateOpaqueUserID either retrieves or creates an opaque user id from a sectorID and username.
 func (s *Store) GenerateOpaqueUserID(ctx context.Context, sectorID, username string) (opaqueID *model.UserOpaqueIdentifier, err error) {
 	if opaqueID, err = s.provider.LoadUserOpaqueIdentifierBySignature(ctx, "openid", sectorID, username); err != nil {
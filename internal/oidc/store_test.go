@@ -101,6 +101,55 @@ func TestOpenIDConnectStore_GetInternalClient_InvalidClient(t *testing.T) {
 	assert.EqualError(t, err, "invalid_client")
 }
 
+func TestOpenIDConnectStore_Reload(t *testing.T) {
+	ctx := context.Background()
+
+	config := &schema.IdentityProvidersOpenIDConnect{
+		IssuerCertificateChain: schema.X509CertificateChain{},
+		IssuerPrivateKey:       x509PrivateKeyRSA2048,
+		Clients: []schema.IdentityProvidersOpenIDConnectClient{
+			{
+				ID:                  myclient,
+				Name:                myclientdesc,
+				AuthorizationPolicy: onefactor,
+				Scopes:              []string{oidc.ScopeOpenID, oidc.ScopeProfile},
+				Secret:              tOpenIDConnectPlainTextClientSecret,
+			},
+		},
+	}
+
+	store := oidc.NewMemoryClientStore(config)
+
+	client, err := store.GetRegisteredClient(ctx, myclient)
+	require.NoError(t, err)
+	assert.Equal(t, myclientdesc, client.GetName())
+
+	client, err = store.GetRegisteredClient(ctx, "another-client")
+	assert.EqualError(t, err, "invalid_client")
+	assert.Nil(t, client)
+
+	config.Clients = []schema.IdentityProvidersOpenIDConnectClient{
+		{
+			ID:                  "another-client",
+			Name:                "Another Client",
+			AuthorizationPolicy: twofactor,
+			Scopes:              []string{oidc.ScopeOpenID},
+			Secret:              tOpenIDConnectPlainTextClientSecret,
+		},
+	}
+
+	store.Reload(config)
+
+	client, err = store.GetRegisteredClient(ctx, myclient)
+	assert.EqualError(t, err, "invalid_client")
+	assert.Nil(t, client)
+
+	client, err = store.GetRegisteredClient(ctx, "another-client")
+	require.NoError(t, err)
+	assert.Equal(t, "Another Client", client.GetName())
+	assert.Equal(t, authorization.TwoFactor, client.GetAuthorizationPolicyRequiredLevel(authorization.Subject{}))
+}
+
 func TestOpenIDConnectStore_IsValidClientID(t *testing.T) {
 	ctx := context.Background()
 
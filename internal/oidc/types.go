@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"net/url"
+	"sync"
 	"time"
 
 	oauthelia2 "authelia.com/provider/oauth2"
@@ -52,6 +53,8 @@ type ClientStore interface {
 // MemoryClientStore is an implementation of the ClientStore which just stores the clients in memory.
 type MemoryClientStore struct {
 	clients map[string]Client
+
+	mu sync.RWMutex
 }
 
 // RegisteredClient represents a registered client.
@@ -150,6 +153,7 @@ type Client interface {
 	GetConsentResponseBody(consent *model.OAuth2ConsentSession) (body ConsentGetResponseBody)
 	GetConsentPolicy() ClientConsentPolicy
 	IsAuthenticationLevelSufficient(level authentication.Level, subject authorization.Subject) (sufficient bool)
+	IsAuthenticationMethodsSufficient(amr []string, subject authorization.Subject) (sufficient bool)
 	GetAuthorizationPolicyRequiredLevel(subject authorization.Subject) (level authorization.Level)
 	GetAuthorizationPolicy() (policy ClientAuthorizationPolicy)
 
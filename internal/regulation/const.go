@@ -5,6 +5,12 @@ import "fmt"
 // ErrUserIsBanned user is banned error message.
 var ErrUserIsBanned = fmt.Errorf("user is banned")
 
+// ErrUserIsLocked user is locked error message.
+var ErrUserIsLocked = fmt.Errorf("user is locked")
+
+// ErrIPIsBanned ip is banned error message.
+var ErrIPIsBanned = fmt.Errorf("ip is banned")
+
 const (
 	// AuthType1FA is the string representing an auth log for first-factor authentication.
 	AuthType1FA = "1FA"
@@ -17,4 +23,51 @@ const (
 
 	// AuthTypeDuo is the string representing an auth log for second-factor authentication via DUO.
 	AuthTypeDuo = "Duo"
+
+	// AuthTypePasskey is the string representing an auth log for passwordless authentication via a WebAuthn passkey.
+	AuthTypePasskey = "Passkey"
+
+	// AuthTypeRecoveryCode is the string representing an auth log for second-factor authentication via a recovery code.
+	AuthTypeRecoveryCode = "Recovery"
+
+	// AuthTypeEmail is the string representing an auth log for second-factor authentication via an email one-time code.
+	AuthTypeEmail = "Email"
+)
+
+const (
+	// UnlockMethodAdministrator is the string representing a lockout which was unlocked by an administrator.
+	UnlockMethodAdministrator = "administrator"
+
+	// UnlockMethodIdentityVerification is the string representing a lockout which was unlocked by the user after
+	// completing an identity verification.
+	UnlockMethodIdentityVerification = "identity_verification"
+)
+
+const (
+	// BanTypeUser is the string representing a ban which applies to a user.
+	BanTypeUser = "user"
+
+	// BanTypeIP is the string representing a ban which applies to an IP.
+	BanTypeIP = "ip"
+)
+
+const (
+	// BanSourceRegulation is the string representing a ban which was applied automatically by the regulation.
+	BanSourceRegulation = "regulation"
+
+	// BanSourceLockout is the string representing a ban which was applied automatically by the regulation lockout.
+	BanSourceLockout = "lockout"
+
+	// BanSourceManual is the string representing a ban which was applied manually by an administrator.
+	BanSourceManual = "manual"
+)
+
+const (
+	banReasonCleared  = "cleared by an administrator"
+	banReasonUnlocked = "unlocked"
+)
+
+const (
+	historyPageSize = 100
+	historyMaxPages = 10
 )
@@ -2,6 +2,9 @@ package regulation
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"net"
 	"strings"
 	"time"
 
@@ -14,10 +17,11 @@ import (
 // NewRegulator create a regulator instance.
 func NewRegulator(config schema.Regulation, store storage.RegulatorProvider, clock clock.Provider) *Regulator {
 	return &Regulator{
-		enabled: config.MaxRetries > 0,
-		store:   store,
-		clock:   clock,
-		config:  config,
+		enabled:     config.MaxRetries > 0,
+		progressive: config.MaxRetries > 0 && (config.Backoff.Multiplier > 1 || config.Lockout.MaxBans > 0),
+		store:       store,
+		clock:       clock,
+		config:      config,
 	}
 }
 
@@ -39,18 +43,429 @@ func (r *Regulator) Mark(ctx Context, successful, banned bool, username, request
 }
 
 // Regulate the authentication attempts for a given user.
-// This method returns ErrUserIsBanned if the user is banned along with the time until when the user is banned.
-func (r *Regulator) Regulate(ctx context.Context, username string) (time.Time, error) {
+// This method returns ErrIPIsBanned or ErrUserIsBanned if the remote IP or the user is banned along with the time until
+// when the ban ends which is the zero value for permanent bans, or ErrUserIsLocked if the user is locked until they're
+// unlocked.
+func (r *Regulator) Regulate(ctx Context, username string) (time.Time, error) {
 	// If there is regulation configuration, no regulation applies.
 	if !r.enabled {
 		return time.Time{}, nil
 	}
 
-	attempts, err := r.store.LoadAuthenticationLogs(ctx, username, r.clock.Now().Add(-r.config.BanTime), 10, 0)
-	if err != nil {
+	if ip := ctx.RemoteIP(); ip != nil {
+		if ban, err := r.regulateIP(ctx, ip); err == nil && ban != nil {
+			return ban.Until(), ErrIPIsBanned
+		}
+	}
+
+	ban, err := r.regulate(ctx, username)
+	if err != nil || ban == nil {
 		return time.Time{}, nil
 	}
 
+	if ban.Source == BanSourceLockout {
+		return time.Time{}, ErrUserIsLocked
+	}
+
+	return ban.Until(), ErrUserIsBanned
+}
+
+// Lockout evaluates the ban history of a given user and locks the account if the lockout threshold has been reached.
+// This method returns true only if the account was locked by this call, which is used to notify the user.
+func (r *Regulator) Lockout(ctx Context, username string) (locked bool, err error) {
+	if !r.enabled || r.config.Lockout.MaxBans <= 0 {
+		return false, nil
+	}
+
+	var (
+		from    time.Time
+		periods []period
+		lockout *model.RegulationLockout
+	)
+
+	if from, _, err = r.since(ctx, username); err != nil {
+		return false, err
+	}
+
+	if periods, lockout, err = r.history(ctx, username, from); err != nil {
+		return false, err
+	}
+
+	if lockout != nil && lockout.Active() {
+		return false, nil
+	}
+
+	if len(periods) < r.config.Lockout.MaxBans {
+		return false, nil
+	}
+
+	if err = r.store.SaveRegulationLockout(ctx, model.RegulationLockout{
+		LockedAt: r.clock.Now(),
+		LockedIP: model.NewNullIP(ctx.RemoteIP()),
+		Username: username,
+		Bans:     len(periods),
+	}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// Unlock removes an active lockout for a given user recording the method used to unlock the account. Any bans which
+// occurred before the unlock are no longer considered when regulating the user.
+func (r *Regulator) Unlock(ctx context.Context, username, method string) (err error) {
+	now := r.clock.Now()
+
+	if err = r.store.UnlockRegulationLockout(ctx, username, method, now); err != nil {
+		return err
+	}
+
+	if !r.progressive || r.config.Lockout.MaxBans <= 0 {
+		return nil
+	}
+
+	var (
+		from    time.Time
+		periods []period
+	)
+
+	if from, _, err = r.since(ctx, username); err != nil {
+		return err
+	}
+
+	if periods, _, err = r.history(ctx, username, from); err != nil {
+		return err
+	}
+
+	ban := r.regulatePeriods(username, periods, nil)
+	if ban == nil || ban.Source != BanSourceLockout {
+		return nil
+	}
+
+	// The lockout was determined from the bans but was never saved, so a revoked ban is recorded in order for the
+	// authentication attempts which caused it to no longer be considered.
+	return r.store.SaveBannedUser(ctx, model.BannedUser{
+		Time:     ban.Time,
+		Revoked:  sql.NullTime{Time: now, Valid: true},
+		Username: username,
+		Source:   BanSourceLockout,
+		Reason:   sql.NullString{String: banReasonUnlocked, Valid: true},
+	})
+}
+
+// Ban manually bans a user or an IP for the given duration, a duration of 0 bans permanently. The value is considered
+// an IP if it can be parsed as one, otherwise it's considered a username.
+func (r *Regulator) Ban(ctx context.Context, value string, duration time.Duration, reason string) (err error) {
+	now := r.clock.Now()
+
+	expires := sql.NullTime{Time: now.Add(duration), Valid: duration > 0}
+	description := sql.NullString{String: reason, Valid: len(reason) != 0}
+
+	if ip := net.ParseIP(value); ip != nil {
+		return r.store.SaveBannedIP(ctx, model.BannedIP{
+			Time:    now,
+			Expires: expires,
+			IP:      model.NewIP(ip),
+			Source:  BanSourceManual,
+			Reason:  description,
+		})
+	}
+
+	return r.store.SaveBannedUser(ctx, model.BannedUser{
+		Time:     now,
+		Expires:  expires,
+		Username: value,
+		Source:   BanSourceManual,
+		Reason:   description,
+	})
+}
+
+// Clear lifts all bans which currently apply to a user or an IP. The value is considered an IP if it can be parsed as
+// one, otherwise it's considered a username. Any authentication attempts for a user which occurred before the bans
+// were lifted are no longer considered when regulating the user.
+func (r *Regulator) Clear(ctx context.Context, value string) (err error) {
+	now := r.clock.Now()
+
+	if ip := net.ParseIP(value); ip != nil {
+		return r.store.RevokeBannedIP(ctx, model.NewIP(ip), now)
+	}
+
+	if err = r.store.RevokeBannedUser(ctx, value, now); err != nil {
+		return err
+	}
+
+	if err = r.store.UnlockRegulationLockout(ctx, value, UnlockMethodAdministrator, now); err != nil {
+		return err
+	}
+
+	if !r.enabled {
+		return nil
+	}
+
+	var ban *Ban
+
+	if ban, err = r.regulate(ctx, value); err != nil || ban == nil {
+		return err
+	}
+
+	// The revoked ban is recorded so the authentication attempts which caused it are no longer considered.
+	return r.store.SaveBannedUser(ctx, model.BannedUser{
+		Time:     ban.Time,
+		Expires:  toNullTime(ban.Expires),
+		Revoked:  sql.NullTime{Time: now, Valid: true},
+		Username: value,
+		Source:   ban.Source,
+		Reason:   sql.NullString{String: banReasonCleared, Valid: true},
+	})
+}
+
+// Bans returns all of the bans which currently apply to users and IPs.
+func (r *Regulator) Bans(ctx context.Context) (bans []Ban, err error) {
+	now := r.clock.Now()
+
+	var (
+		users []model.BannedUser
+		ips   []model.BannedIP
+	)
+
+	seen := map[string]struct{}{}
+
+	for page := 0; page < historyMaxPages; page++ {
+		if users, err = r.store.LoadBannedUsers(ctx, now, historyPageSize, page); err != nil && !errors.Is(err, storage.ErrNoBannedUsers) {
+			return nil, err
+		}
+
+		for _, user := range users {
+			seen[user.Username] = struct{}{}
+
+			bans = append(bans, newBanFromBannedUser(user))
+		}
+
+		if len(users) < historyPageSize {
+			break
+		}
+	}
+
+	for page := 0; page < historyMaxPages; page++ {
+		if ips, err = r.store.LoadBannedIPs(ctx, now, historyPageSize, page); err != nil && !errors.Is(err, storage.ErrNoBannedIPs) {
+			return nil, err
+		}
+
+		for _, ip := range ips {
+			bans = append(bans, newBanFromBannedIP(ip))
+		}
+
+		if len(ips) < historyPageSize {
+			break
+		}
+	}
+
+	if !r.enabled {
+		return bans, nil
+	}
+
+	var regulated []Ban
+
+	if regulated, err = r.regulatedBans(ctx, now, seen); err != nil {
+		return nil, err
+	}
+
+	return append(bans, regulated...), nil
+}
+
+// Status returns the regulation status of a user or an IP including the recent authentication attempts. The value is
+// considered an IP if it can be parsed as one, otherwise it's considered a username.
+func (r *Regulator) Status(ctx context.Context, value string) (status *Status, err error) {
+	now := r.clock.Now()
+
+	if ip := net.ParseIP(value); ip != nil {
+		status = &Status{Type: BanTypeIP, Value: ip.String()}
+
+		var bans []model.BannedIP
+
+		if bans, err = r.store.LoadBannedIP(ctx, model.NewIP(ip), now); err != nil {
+			return nil, err
+		}
+
+		for _, ban := range bans {
+			status.Bans = append(status.Bans, newBanFromBannedIP(ban))
+		}
+
+		if status.Attempts, err = r.paginate(func(limit, page int) ([]model.AuthenticationAttempt, error) {
+			return r.store.LoadAuthenticationLogsByRemoteIP(ctx, model.NewIP(ip), now.Add(-r.lookback()), limit, page)
+		}); err != nil {
+			return nil, err
+		}
+
+		return status, nil
+	}
+
+	status = &Status{Type: BanTypeUser, Value: value}
+
+	var (
+		from   time.Time
+		active []model.BannedUser
+		ban    *Ban
+	)
+
+	if from, active, err = r.since(ctx, value); err != nil {
+		return nil, err
+	}
+
+	for _, b := range active {
+		status.Bans = append(status.Bans, newBanFromBannedUser(b))
+	}
+
+	if r.enabled {
+		if ban, err = r.regulateUser(ctx, value, from); err != nil {
+			return nil, err
+		}
+
+		if ban != nil {
+			status.Bans = append(status.Bans, *ban)
+		}
+	}
+
+	if status.Attempts, err = r.paginate(func(limit, page int) ([]model.AuthenticationAttempt, error) {
+		return r.store.LoadAuthenticationLogsByUsername(ctx, value, now.Add(-r.lookback()), limit, page)
+	}); err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+// regulatedBans returns the bans which were applied automatically by the regulation excluding the users which have
+// been seen as they have an active manual ban. The authentication attempts of every user which may be regulated are
+// loaded at once rather than regulating each user individually.
+func (r *Regulator) regulatedBans(ctx context.Context, now time.Time, seen map[string]struct{}) (bans []Ban, err error) {
+	var (
+		lockouts []model.RegulationLockout
+		attempts []model.AuthenticationAttempt
+	)
+
+	for page := 0; page < historyMaxPages; page++ {
+		if lockouts, err = r.store.LoadRegulationLockouts(ctx, historyPageSize, page); err != nil && !errors.Is(err, storage.ErrNoRegulationLockout) {
+			return nil, err
+		}
+
+		for _, lockout := range lockouts {
+			seen[lockout.Username] = struct{}{}
+
+			bans = append(bans, Ban{Type: BanTypeUser, Value: lockout.Username, Source: BanSourceLockout, Time: lockout.LockedAt})
+		}
+
+		if len(lockouts) < historyPageSize {
+			break
+		}
+	}
+
+	if attempts, err = r.paginate(func(limit, page int) ([]model.AuthenticationAttempt, error) {
+		return r.store.LoadAuthenticationLogsRegulated(ctx, now.Add(-r.lookback()), r.config.MaxRetries, limit, page)
+	}); err != nil {
+		return nil, err
+	}
+
+	var usernames []string
+
+	grouped := map[string][]model.AuthenticationAttempt{}
+
+	for _, attempt := range attempts {
+		if _, ok := seen[attempt.Username]; ok {
+			continue
+		}
+
+		if _, ok := grouped[attempt.Username]; !ok {
+			usernames = append(usernames, attempt.Username)
+		}
+
+		grouped[attempt.Username] = append(grouped[attempt.Username], attempt)
+	}
+
+	var ban *Ban
+
+	for _, username := range usernames {
+		if r.progressive {
+			ban = r.regulatePeriods(username, r.bans(grouped[username]), nil)
+		} else {
+			ban = r.regulateAttempts(username, grouped[username])
+		}
+
+		if ban != nil {
+			bans = append(bans, *ban)
+		}
+	}
+
+	return bans, nil
+}
+
+// regulateIP returns the manual ban which applies to an IP if any.
+func (r *Regulator) regulateIP(ctx context.Context, ip net.IP) (ban *Ban, err error) {
+	var bans []model.BannedIP
+
+	if bans, err = r.store.LoadBannedIP(ctx, model.NewIP(ip), r.clock.Now()); err != nil {
+		return nil, err
+	}
+
+	if len(bans) == 0 {
+		return nil, nil
+	}
+
+	latest := newBanFromBannedIP(bans[0])
+
+	for _, b := range bans[1:] {
+		if latest.Expires != nil && (!b.Expires.Valid || b.Expires.Time.After(*latest.Expires)) {
+			latest = newBanFromBannedIP(b)
+		}
+	}
+
+	return &latest, nil
+}
+
+// regulate returns the ban which applies to a user if any. Manual bans take precedence over the regulation.
+func (r *Regulator) regulate(ctx context.Context, username string) (ban *Ban, err error) {
+	var (
+		from   time.Time
+		active []model.BannedUser
+	)
+
+	if from, active, err = r.since(ctx, username); err != nil {
+		return nil, err
+	}
+
+	if len(active) != 0 {
+		latest := newBanFromBannedUser(active[0])
+
+		for _, b := range active[1:] {
+			if latest.Expires != nil && (!b.Expires.Valid || b.Expires.Time.After(*latest.Expires)) {
+				latest = newBanFromBannedUser(b)
+			}
+		}
+
+		return &latest, nil
+	}
+
+	return r.regulateUser(ctx, username, from)
+}
+
+// regulateUser returns the ban applied by the regulation to a user if any considering authentication attempts since
+// the given time.
+func (r *Regulator) regulateUser(ctx context.Context, username string, from time.Time) (ban *Ban, err error) {
+	if r.progressive {
+		return r.regulateProgressive(ctx, username, from)
+	}
+
+	attempts, err := r.store.LoadAuthenticationLogs(ctx, username, from, 10, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.regulateAttempts(username, attempts), nil
+}
+
+// regulateAttempts returns the ban applied by the regulation to a user if any given the latest authentication
+// attempts of the user ordered from the latest to the oldest.
+func (r *Regulator) regulateAttempts(username string, attempts []model.AuthenticationAttempt) (ban *Ban) {
 	latestFailedAttempts := make([]model.AuthenticationAttempt, 0, r.config.MaxRetries)
 
 	for _, attempt := range attempts {
@@ -66,7 +481,7 @@ func (r *Regulator) Regulate(ctx context.Context, username string) (time.Time, e
 	// If the number of failed attempts within the ban time is less than the max number of retries
 	// then the user is not banned.
 	if len(latestFailedAttempts) < r.config.MaxRetries {
-		return time.Time{}, nil
+		return nil
 	}
 
 	// Now we compute the time between the latest attempt and the MaxRetry-th one. If it's
@@ -76,8 +491,211 @@ func (r *Regulator) Regulate(ctx context.Context, username string) (time.Time, e
 
 	if durationBetweenLatestAttempts < r.config.FindTime {
 		bannedUntil := latestFailedAttempts[0].Time.Add(r.config.BanTime)
-		return bannedUntil, ErrUserIsBanned
+
+		return &Ban{Type: BanTypeUser, Value: username, Source: BanSourceRegulation, Time: latestFailedAttempts[0].Time, Expires: &bannedUntil}
+	}
+
+	return nil
+}
+
+func (r *Regulator) regulateProgressive(ctx context.Context, username string, from time.Time) (ban *Ban, err error) {
+	periods, lockout, err := r.history(ctx, username, from)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.regulatePeriods(username, periods, lockout), nil
+}
+
+// regulatePeriods returns the ban applied by the progressive regulation to a user if any given the periods of each ban
+// and the latest lockout of the user.
+func (r *Regulator) regulatePeriods(username string, periods []period, lockout *model.RegulationLockout) (ban *Ban) {
+	n := len(periods)
+
+	if r.config.Lockout.MaxBans > 0 {
+		if lockout != nil && lockout.Active() {
+			return &Ban{Type: BanTypeUser, Value: username, Source: BanSourceLockout, Time: lockout.LockedAt}
+		}
+
+		if n >= r.config.Lockout.MaxBans {
+			return &Ban{Type: BanTypeUser, Value: username, Source: BanSourceLockout, Time: periods[n-1].start}
+		}
+	}
+
+	if n != 0 && periods[n-1].end.After(r.clock.Now()) {
+		return &Ban{Type: BanTypeUser, Value: username, Source: BanSourceRegulation, Time: periods[n-1].start, Expires: &periods[n-1].end}
+	}
+
+	return nil
+}
+
+// since returns the time from which the authentication attempts of a user are considered along with the manual bans
+// which currently apply to the user. Authentication attempts which occurred before a ban was revoked are not
+// considered.
+func (r *Regulator) since(ctx context.Context, username string) (from time.Time, active []model.BannedUser, err error) {
+	now := r.clock.Now()
+	from = now.Add(-r.lookback())
+
+	var bans []model.BannedUser
+
+	if bans, err = r.store.LoadBannedUser(ctx, username, now, from); err != nil {
+		return from, nil, err
+	}
+
+	for _, ban := range bans {
+		switch {
+		case ban.Active(now):
+			active = append(active, ban)
+		case ban.Revoked.Valid && ban.Revoked.Time.After(from):
+			from = ban.Revoked.Time
+		}
+	}
+
+	return from, active, nil
+}
+
+// lookback returns the duration of authentication history which is considered when regulating.
+func (r *Regulator) lookback() time.Duration {
+	if r.progressive && r.config.Lockout.Window > r.config.BanTime {
+		return r.config.Lockout.Window
+	}
+
+	return r.config.BanTime
+}
+
+// history returns the periods of each ban since the given time along with the latest lockout for a user. Bans which
+// occurred before the latest unlock are not included.
+func (r *Regulator) history(ctx context.Context, username string, from time.Time) (periods []period, lockout *model.RegulationLockout, err error) {
+	switch lockout, err = r.store.LoadRegulationLockout(ctx, username); {
+	case err == nil:
+		if lockout.UnlockedAt.Valid && lockout.UnlockedAt.Time.After(from) {
+			from = lockout.UnlockedAt.Time
+		}
+	case errors.Is(err, storage.ErrNoRegulationLockout):
+		lockout = nil
+	default:
+		return nil, nil, err
+	}
+
+	var attempts []model.AuthenticationAttempt
+
+	if attempts, err = r.paginate(func(limit, page int) ([]model.AuthenticationAttempt, error) {
+		return r.store.LoadAuthenticationLogs(ctx, username, from, limit, page)
+	}); err != nil {
+		return nil, nil, err
+	}
+
+	return r.bans(attempts), lockout, nil
+}
+
+// paginate loads authentication attempts page by page until a page is not full or the maximum number of pages has been
+// loaded.
+func (r *Regulator) paginate(load func(limit, page int) ([]model.AuthenticationAttempt, error)) (attempts []model.AuthenticationAttempt, err error) {
+	var results []model.AuthenticationAttempt
+
+	for page := 0; page < historyMaxPages; page++ {
+		if results, err = load(historyPageSize, page); err != nil {
+			if errors.Is(err, storage.ErrNoAuthenticationLogs) {
+				break
+			}
+
+			return nil, err
+		}
+
+		attempts = append(attempts, results...)
+
+		if len(results) < historyPageSize {
+			break
+		}
+	}
+
+	return attempts, nil
+}
+
+// bans replays the authentication attempts from the oldest to the latest and returns the period of each ban.
+func (r *Regulator) bans(attempts []model.AuthenticationAttempt) (periods []period) {
+	failures := make([]time.Time, 0, r.config.MaxRetries+1)
+
+	for i := len(attempts) - 1; i >= 0; i-- {
+		attempt := attempts[i]
+
+		if n := len(periods); n != 0 && attempt.Time.Before(periods[n-1].end) {
+			continue
+		}
+
+		if attempt.Successful {
+			failures = failures[:0]
+
+			continue
+		}
+
+		if failures = append(failures, attempt.Time); len(failures) > r.config.MaxRetries {
+			failures = failures[1:]
+		}
+
+		if len(failures) == r.config.MaxRetries && attempt.Time.Sub(failures[0]) < r.config.FindTime {
+			periods = append(periods, period{start: attempt.Time, end: attempt.Time.Add(r.banTime(len(periods)))})
+
+			failures = failures[:0]
+		}
+	}
+
+	return periods
+}
+
+// banTime returns the duration of a ban given the number of bans which occurred prior to it.
+func (r *Regulator) banTime(prior int) (duration time.Duration) {
+	duration = r.config.BanTime
+
+	for i := 0; i < prior && r.config.Backoff.Multiplier > 1; i++ {
+		if r.config.Backoff.MaxBanTime > 0 && duration >= r.config.Backoff.MaxBanTime {
+			break
+		}
+
+		duration *= time.Duration(r.config.Backoff.Multiplier)
+	}
+
+	if r.config.Backoff.MaxBanTime > 0 && duration > r.config.Backoff.MaxBanTime {
+		return r.config.Backoff.MaxBanTime
+	}
+
+	return duration
+}
+
+func newBanFromBannedUser(ban model.BannedUser) Ban {
+	return Ban{
+		Type:    BanTypeUser,
+		Value:   ban.Username,
+		Source:  ban.Source,
+		Time:    ban.Time,
+		Expires: fromNullTime(ban.Expires),
+		Reason:  ban.Reason.String,
+	}
+}
+
+func newBanFromBannedIP(ban model.BannedIP) Ban {
+	return Ban{
+		Type:    BanTypeIP,
+		Value:   ban.IP.IP.String(),
+		Source:  ban.Source,
+		Time:    ban.Time,
+		Expires: fromNullTime(ban.Expires),
+		Reason:  ban.Reason.String,
+	}
+}
+
+func fromNullTime(value sql.NullTime) *time.Time {
+	if !value.Valid {
+		return nil
+	}
+
+	return &value.Time
+}
+
+func toNullTime(value *time.Time) sql.NullTime {
+	if value == nil {
+		return sql.NullTime{}
 	}
 
-	return time.Time{}, nil
+	return sql.NullTime{Time: *value, Valid: true}
 }
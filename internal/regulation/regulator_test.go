@@ -1,6 +1,7 @@
 package regulation_test
 
 import (
+	"database/sql"
 	"fmt"
 	"net"
 	"testing"
@@ -15,6 +16,7 @@ import (
 	"github.com/authelia/authelia/v4/internal/mocks"
 	"github.com/authelia/authelia/v4/internal/model"
 	"github.com/authelia/authelia/v4/internal/regulation"
+	"github.com/authelia/authelia/v4/internal/storage"
 )
 
 type RegulatorSuite struct {
@@ -38,6 +40,20 @@ func (s *RegulatorSuite) TearDownTest() {
 	s.mock.Ctrl.Finish()
 }
 
+func (s *RegulatorSuite) expectNoBans() {
+	s.mock.StorageMock.EXPECT().
+		LoadBannedIP(s.mock.Ctx, model.NewIP(net.ParseIP("127.0.0.1")), s.mock.Clock.Now()).
+		Return(nil, nil)
+
+	s.expectNoBannedUser()
+}
+
+func (s *RegulatorSuite) expectNoBannedUser() {
+	s.mock.StorageMock.EXPECT().
+		LoadBannedUser(s.mock.Ctx, "john", s.mock.Clock.Now(), gomock.Any()).
+		Return(nil, nil)
+}
+
 func (s *RegulatorSuite) TestShouldMark() {
 	regulator := regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
 
@@ -58,6 +74,8 @@ func (s *RegulatorSuite) TestShouldMark() {
 func (s *RegulatorSuite) TestShouldHandleRegulateError() {
 	regulator := regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
 
+	s.expectNoBans()
+
 	s.mock.StorageMock.EXPECT().LoadAuthenticationLogs(s.mock.Ctx, "john", s.mock.Clock.Now().Add(-s.mock.Ctx.Configuration.Regulation.BanTime), 10, 0).Return(nil, fmt.Errorf("failed"))
 
 	until, err := regulator.Regulate(s.mock.Ctx, "john")
@@ -75,6 +93,8 @@ func (s *RegulatorSuite) TestShouldNotThrowWhenUserIsLegitimate() {
 		},
 	}
 
+	s.expectNoBans()
+
 	s.mock.StorageMock.EXPECT().
 		LoadAuthenticationLogs(s.mock.Ctx, gomock.Eq("john"), gomock.Any(), gomock.Eq(10), gomock.Eq(0)).
 		Return(attemptsInDB, nil)
@@ -106,6 +126,8 @@ func (s *RegulatorSuite) TestShouldNotThrowWhenFailedAuthenticationNotInFindTime
 		},
 	}
 
+	s.expectNoBans()
+
 	s.mock.StorageMock.EXPECT().
 		LoadAuthenticationLogs(s.mock.Ctx, gomock.Eq("john"), gomock.Any(), gomock.Eq(10), gomock.Eq(0)).
 		Return(attemptsInDB, nil)
@@ -142,6 +164,8 @@ func (s *RegulatorSuite) TestShouldBanUserIfLatestAttemptsAreWithinFinTime() {
 		},
 	}
 
+	s.expectNoBans()
+
 	s.mock.StorageMock.EXPECT().
 		LoadAuthenticationLogs(s.mock.Ctx, gomock.Eq("john"), gomock.Any(), gomock.Eq(10), gomock.Eq(0)).
 		Return(attemptsInDB, nil)
@@ -175,6 +199,8 @@ func (s *RegulatorSuite) TestShouldCheckUserIsStillBanned() {
 		},
 	}
 
+	s.expectNoBans()
+
 	s.mock.StorageMock.EXPECT().
 		LoadAuthenticationLogs(s.mock.Ctx, gomock.Eq("john"), gomock.Any(), gomock.Eq(10), gomock.Eq(0)).
 		Return(attemptsInDB, nil)
@@ -199,6 +225,8 @@ func (s *RegulatorSuite) TestShouldCheckUserIsNotYetBanned() {
 		},
 	}
 
+	s.expectNoBans()
+
 	s.mock.StorageMock.EXPECT().
 		LoadAuthenticationLogs(s.mock.Ctx, gomock.Eq("john"), gomock.Any(), gomock.Eq(10), gomock.Eq(0)).
 		Return(attemptsInDB, nil)
@@ -231,6 +259,8 @@ func (s *RegulatorSuite) TestShouldCheckUserWasAboutToBeBanned() {
 		},
 	}
 
+	s.expectNoBans()
+
 	s.mock.StorageMock.EXPECT().
 		LoadAuthenticationLogs(s.mock.Ctx, gomock.Eq("john"), gomock.Any(), gomock.Eq(10), gomock.Eq(0)).
 		Return(attemptsInDB, nil)
@@ -267,6 +297,8 @@ func (s *RegulatorSuite) TestShouldCheckRegulationHasBeenResetOnSuccessfulAttemp
 		},
 	}
 
+	s.expectNoBans()
+
 	s.mock.StorageMock.EXPECT().
 		LoadAuthenticationLogs(s.mock.Ctx, gomock.Eq("john"), gomock.Any(), gomock.Eq(10), gomock.Eq(0)).
 		Return(attemptsInDB, nil)
@@ -277,6 +309,555 @@ func (s *RegulatorSuite) TestShouldCheckRegulationHasBeenResetOnSuccessfulAttemp
 	assert.NoError(s.T(), err)
 }
 
+func (s *RegulatorSuite) progressiveAttempts() []model.AuthenticationAttempt {
+	return []model.AuthenticationAttempt{
+		{
+			Username:   "john",
+			Successful: false,
+			Time:       s.mock.Clock.Now().Add(-110 * time.Second),
+		},
+		{
+			Username:   "john",
+			Successful: false,
+			Time:       s.mock.Clock.Now().Add(-115 * time.Second),
+		},
+		{
+			Username:   "john",
+			Successful: false,
+			Time:       s.mock.Clock.Now().Add(-120 * time.Second),
+		},
+		{
+			Username:   "john",
+			Successful: false,
+			Time:       s.mock.Clock.Now().Add(-20 * time.Minute),
+		},
+		{
+			Username:   "john",
+			Successful: false,
+			Time:       s.mock.Clock.Now().Add(-20*time.Minute - 5*time.Second),
+		},
+		{
+			Username:   "john",
+			Successful: false,
+			Time:       s.mock.Clock.Now().Add(-20*time.Minute - 10*time.Second),
+		},
+	}
+}
+
+// This test checks that the second ban within the lockout window lasts longer than the first one when the backoff is
+// configured.
+func (s *RegulatorSuite) TestShouldApplyProgressiveBackoff() {
+	s.mock.Ctx.Configuration.Regulation.Backoff = schema.RegulationBackoff{Multiplier: 2, MaxBanTime: time.Hour}
+	s.mock.Ctx.Configuration.Regulation.Lockout = schema.RegulationLockout{Window: time.Hour * 24}
+
+	s.expectNoBans()
+
+	gomock.InOrder(
+		s.mock.StorageMock.EXPECT().
+			LoadRegulationLockout(s.mock.Ctx, "john").
+			Return(nil, storage.ErrNoRegulationLockout),
+		s.mock.StorageMock.EXPECT().
+			LoadAuthenticationLogs(s.mock.Ctx, "john", s.mock.Clock.Now().Add(-time.Hour*24), 100, 0).
+			Return(s.progressiveAttempts(), nil),
+	)
+
+	regulator := regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
+
+	until, err := regulator.Regulate(s.mock.Ctx, "john")
+
+	s.ErrorIs(err, regulation.ErrUserIsBanned)
+	s.Equal(s.mock.Clock.Now().Add(-110*time.Second+360*time.Second), until)
+}
+
+func (s *RegulatorSuite) TestShouldCapProgressiveBackoff() {
+	s.mock.Ctx.Configuration.Regulation.Backoff = schema.RegulationBackoff{Multiplier: 10, MaxBanTime: time.Second * 200}
+	s.mock.Ctx.Configuration.Regulation.Lockout = schema.RegulationLockout{Window: time.Hour * 24}
+
+	s.expectNoBans()
+
+	gomock.InOrder(
+		s.mock.StorageMock.EXPECT().
+			LoadRegulationLockout(s.mock.Ctx, "john").
+			Return(nil, storage.ErrNoRegulationLockout),
+		s.mock.StorageMock.EXPECT().
+			LoadAuthenticationLogs(s.mock.Ctx, "john", s.mock.Clock.Now().Add(-time.Hour*24), 100, 0).
+			Return(s.progressiveAttempts(), nil),
+	)
+
+	regulator := regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
+
+	until, err := regulator.Regulate(s.mock.Ctx, "john")
+
+	s.ErrorIs(err, regulation.ErrUserIsBanned)
+	s.Equal(s.mock.Clock.Now().Add(-110*time.Second+200*time.Second), until)
+}
+
+func (s *RegulatorSuite) TestShouldLockUserAfterMaxBans() {
+	s.mock.Ctx.Configuration.Regulation.Lockout = schema.RegulationLockout{MaxBans: 2, Window: time.Hour * 24}
+
+	s.expectNoBannedUser()
+
+	gomock.InOrder(
+		s.mock.StorageMock.EXPECT().
+			LoadRegulationLockout(s.mock.Ctx, "john").
+			Return(nil, storage.ErrNoRegulationLockout),
+		s.mock.StorageMock.EXPECT().
+			LoadAuthenticationLogs(s.mock.Ctx, "john", s.mock.Clock.Now().Add(-time.Hour*24), 100, 0).
+			Return(s.progressiveAttempts(), nil),
+		s.mock.StorageMock.EXPECT().
+			SaveRegulationLockout(s.mock.Ctx, model.RegulationLockout{
+				LockedAt: s.mock.Clock.Now(),
+				LockedIP: model.NewNullIP(net.ParseIP("127.0.0.1")),
+				Username: "john",
+				Bans:     2,
+			}).
+			Return(nil),
+	)
+
+	regulator := regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
+
+	locked, err := regulator.Lockout(s.mock.Ctx, "john")
+
+	s.NoError(err)
+	s.True(locked)
+}
+
+func (s *RegulatorSuite) TestShouldNotLockUserAlreadyLocked() {
+	s.mock.Ctx.Configuration.Regulation.Lockout = schema.RegulationLockout{MaxBans: 2, Window: time.Hour * 24}
+
+	s.expectNoBannedUser()
+
+	gomock.InOrder(
+		s.mock.StorageMock.EXPECT().
+			LoadRegulationLockout(s.mock.Ctx, "john").
+			Return(&model.RegulationLockout{Username: "john", LockedAt: s.mock.Clock.Now().Add(-time.Minute), Bans: 2}, nil),
+		s.mock.StorageMock.EXPECT().
+			LoadAuthenticationLogs(s.mock.Ctx, "john", s.mock.Clock.Now().Add(-time.Hour*24), 100, 0).
+			Return(s.progressiveAttempts(), nil),
+	)
+
+	regulator := regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
+
+	locked, err := regulator.Lockout(s.mock.Ctx, "john")
+
+	s.NoError(err)
+	s.False(locked)
+}
+
+func (s *RegulatorSuite) TestShouldRegulateLockedUser() {
+	s.mock.Ctx.Configuration.Regulation.Lockout = schema.RegulationLockout{MaxBans: 5, Window: time.Hour * 24}
+
+	s.expectNoBans()
+
+	gomock.InOrder(
+		s.mock.StorageMock.EXPECT().
+			LoadRegulationLockout(s.mock.Ctx, "john").
+			Return(&model.RegulationLockout{Username: "john", LockedAt: s.mock.Clock.Now().Add(-time.Minute), Bans: 5}, nil),
+		s.mock.StorageMock.EXPECT().
+			LoadAuthenticationLogs(s.mock.Ctx, "john", s.mock.Clock.Now().Add(-time.Hour*24), 100, 0).
+			Return(nil, storage.ErrNoAuthenticationLogs),
+	)
+
+	regulator := regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
+
+	_, err := regulator.Regulate(s.mock.Ctx, "john")
+
+	s.ErrorIs(err, regulation.ErrUserIsLocked)
+}
+
+// This test checks that bans which occurred before the user was unlocked are disregarded.
+func (s *RegulatorSuite) TestShouldNotRegulateUnlockedUser() {
+	s.mock.Ctx.Configuration.Regulation.Lockout = schema.RegulationLockout{MaxBans: 2, Window: time.Hour * 24}
+
+	unlocked := s.mock.Clock.Now().Add(-time.Second * 100)
+
+	s.expectNoBans()
+
+	gomock.InOrder(
+		s.mock.StorageMock.EXPECT().
+			LoadRegulationLockout(s.mock.Ctx, "john").
+			Return(&model.RegulationLockout{
+				Username:       "john",
+				LockedAt:       s.mock.Clock.Now().Add(-time.Minute * 2),
+				Bans:           2,
+				UnlockedAt:     sql.NullTime{Time: unlocked, Valid: true},
+				UnlockedMethod: sql.NullString{String: regulation.UnlockMethodAdministrator, Valid: true},
+			}, nil),
+		s.mock.StorageMock.EXPECT().
+			LoadAuthenticationLogs(s.mock.Ctx, "john", unlocked, 100, 0).
+			Return(nil, storage.ErrNoAuthenticationLogs),
+	)
+
+	regulator := regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
+
+	_, err := regulator.Regulate(s.mock.Ctx, "john")
+
+	s.NoError(err)
+}
+
+func (s *RegulatorSuite) TestShouldUnlockUser() {
+	s.mock.StorageMock.EXPECT().
+		UnlockRegulationLockout(s.mock.Ctx, "john", regulation.UnlockMethodAdministrator, s.mock.Clock.Now()).
+		Return(nil)
+
+	regulator := regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
+
+	s.NoError(regulator.Unlock(s.mock.Ctx, "john", regulation.UnlockMethodAdministrator))
+}
+
+func (s *RegulatorSuite) TestShouldUnlockUserWithUnsavedLockout() {
+	s.mock.Ctx.Configuration.Regulation.Lockout = schema.RegulationLockout{MaxBans: 2, Window: time.Hour * 24}
+
+	gomock.InOrder(
+		s.mock.StorageMock.EXPECT().
+			UnlockRegulationLockout(s.mock.Ctx, "john", regulation.UnlockMethodAdministrator, s.mock.Clock.Now()).
+			Return(nil),
+		s.mock.StorageMock.EXPECT().
+			LoadBannedUser(s.mock.Ctx, "john", s.mock.Clock.Now(), s.mock.Clock.Now().Add(-time.Hour*24)).
+			Return(nil, nil),
+		s.mock.StorageMock.EXPECT().
+			LoadRegulationLockout(s.mock.Ctx, "john").
+			Return(nil, storage.ErrNoRegulationLockout),
+		s.mock.StorageMock.EXPECT().
+			LoadAuthenticationLogs(s.mock.Ctx, "john", s.mock.Clock.Now().Add(-time.Hour*24), 100, 0).
+			Return(s.progressiveAttempts(), nil),
+		s.mock.StorageMock.EXPECT().
+			SaveBannedUser(s.mock.Ctx, model.BannedUser{
+				Time:     s.mock.Clock.Now().Add(-110 * time.Second),
+				Revoked:  sql.NullTime{Time: s.mock.Clock.Now(), Valid: true},
+				Username: "john",
+				Source:   regulation.BanSourceLockout,
+				Reason:   sql.NullString{String: "unlocked", Valid: true},
+			}).
+			Return(nil),
+	)
+
+	regulator := regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
+
+	s.NoError(regulator.Unlock(s.mock.Ctx, "john", regulation.UnlockMethodAdministrator))
+}
+
+func (s *RegulatorSuite) TestShouldUnlockUserWithSavedLockout() {
+	s.mock.Ctx.Configuration.Regulation.Lockout = schema.RegulationLockout{MaxBans: 2, Window: time.Hour * 24}
+
+	gomock.InOrder(
+		s.mock.StorageMock.EXPECT().
+			UnlockRegulationLockout(s.mock.Ctx, "john", regulation.UnlockMethodAdministrator, s.mock.Clock.Now()).
+			Return(nil),
+		s.mock.StorageMock.EXPECT().
+			LoadBannedUser(s.mock.Ctx, "john", s.mock.Clock.Now(), s.mock.Clock.Now().Add(-time.Hour*24)).
+			Return(nil, nil),
+		s.mock.StorageMock.EXPECT().
+			LoadRegulationLockout(s.mock.Ctx, "john").
+			Return(&model.RegulationLockout{
+				Username:       "john",
+				LockedAt:       s.mock.Clock.Now().Add(-time.Minute),
+				Bans:           2,
+				UnlockedAt:     sql.NullTime{Time: s.mock.Clock.Now(), Valid: true},
+				UnlockedMethod: sql.NullString{String: regulation.UnlockMethodAdministrator, Valid: true},
+			}, nil),
+		s.mock.StorageMock.EXPECT().
+			LoadAuthenticationLogs(s.mock.Ctx, "john", s.mock.Clock.Now(), 100, 0).
+			Return(nil, storage.ErrNoAuthenticationLogs),
+	)
+
+	regulator := regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
+
+	s.NoError(regulator.Unlock(s.mock.Ctx, "john", regulation.UnlockMethodAdministrator))
+}
+
+func (s *RegulatorSuite) TestShouldBanUserManually() {
+	expires := s.mock.Clock.Now().Add(time.Hour)
+
+	s.mock.StorageMock.EXPECT().
+		LoadBannedIP(s.mock.Ctx, model.NewIP(net.ParseIP("127.0.0.1")), s.mock.Clock.Now()).
+		Return(nil, nil)
+
+	s.mock.StorageMock.EXPECT().
+		LoadBannedUser(s.mock.Ctx, "john", s.mock.Clock.Now(), s.mock.Clock.Now().Add(-s.mock.Ctx.Configuration.Regulation.BanTime)).
+		Return([]model.BannedUser{
+			{
+				Time:     s.mock.Clock.Now().Add(-time.Minute),
+				Expires:  sql.NullTime{Time: expires, Valid: true},
+				Username: "john",
+				Source:   regulation.BanSourceManual,
+			},
+		}, nil)
+
+	regulator := regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
+
+	until, err := regulator.Regulate(s.mock.Ctx, "john")
+
+	s.ErrorIs(err, regulation.ErrUserIsBanned)
+	s.Equal(expires, until)
+}
+
+func (s *RegulatorSuite) TestShouldBanIPManually() {
+	s.mock.StorageMock.EXPECT().
+		LoadBannedIP(s.mock.Ctx, model.NewIP(net.ParseIP("127.0.0.1")), s.mock.Clock.Now()).
+		Return([]model.BannedIP{
+			{
+				Time:   s.mock.Clock.Now().Add(-time.Minute),
+				IP:     model.NewIP(net.ParseIP("127.0.0.1")),
+				Source: regulation.BanSourceManual,
+			},
+		}, nil)
+
+	regulator := regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
+
+	until, err := regulator.Regulate(s.mock.Ctx, "john")
+
+	s.ErrorIs(err, regulation.ErrIPIsBanned)
+	s.True(until.IsZero())
+}
+
+// This test checks that authentication attempts which occurred before a ban was revoked are disregarded.
+func (s *RegulatorSuite) TestShouldNotRegulateAttemptsBeforeRevokedBan() {
+	revoked := s.mock.Clock.Now().Add(-time.Second * 10)
+
+	s.mock.StorageMock.EXPECT().
+		LoadBannedIP(s.mock.Ctx, model.NewIP(net.ParseIP("127.0.0.1")), s.mock.Clock.Now()).
+		Return(nil, nil)
+
+	s.mock.StorageMock.EXPECT().
+		LoadBannedUser(s.mock.Ctx, "john", s.mock.Clock.Now(), s.mock.Clock.Now().Add(-s.mock.Ctx.Configuration.Regulation.BanTime)).
+		Return([]model.BannedUser{
+			{
+				Time:     s.mock.Clock.Now().Add(-time.Second * 30),
+				Revoked:  sql.NullTime{Time: revoked, Valid: true},
+				Username: "john",
+				Source:   regulation.BanSourceRegulation,
+			},
+		}, nil)
+
+	s.mock.StorageMock.EXPECT().
+		LoadAuthenticationLogs(s.mock.Ctx, "john", revoked, 10, 0).
+		Return(nil, nil)
+
+	regulator := regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
+
+	_, err := regulator.Regulate(s.mock.Ctx, "john")
+
+	s.NoError(err)
+}
+
+func (s *RegulatorSuite) TestShouldSaveManualBans() {
+	gomock.InOrder(
+		s.mock.StorageMock.EXPECT().
+			SaveBannedUser(s.mock.Ctx, model.BannedUser{
+				Time:     s.mock.Clock.Now(),
+				Expires:  sql.NullTime{Time: s.mock.Clock.Now().Add(time.Hour), Valid: true},
+				Username: "john",
+				Source:   regulation.BanSourceManual,
+				Reason:   sql.NullString{String: "suspicious", Valid: true},
+			}).
+			Return(nil),
+		s.mock.StorageMock.EXPECT().
+			SaveBannedIP(s.mock.Ctx, model.BannedIP{
+				Time:   s.mock.Clock.Now(),
+				IP:     model.NewIP(net.ParseIP("192.168.1.1")),
+				Source: regulation.BanSourceManual,
+			}).
+			Return(nil),
+	)
+
+	regulator := regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
+
+	s.NoError(regulator.Ban(s.mock.Ctx, "john", time.Hour, "suspicious"))
+	s.NoError(regulator.Ban(s.mock.Ctx, "192.168.1.1", 0, ""))
+}
+
+func (s *RegulatorSuite) TestShouldClearIP() {
+	s.mock.StorageMock.EXPECT().
+		RevokeBannedIP(s.mock.Ctx, model.NewIP(net.ParseIP("192.168.1.1")), s.mock.Clock.Now()).
+		Return(nil)
+
+	regulator := regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
+
+	s.NoError(regulator.Clear(s.mock.Ctx, "192.168.1.1"))
+}
+
+// This test checks that clearing a user which is banned by the regulation records the revoked ban.
+func (s *RegulatorSuite) TestShouldClearUserBannedByRegulation() {
+	attempts := []model.AuthenticationAttempt{
+		{
+			Username:   "john",
+			Successful: false,
+			Time:       s.mock.Clock.Now().Add(-1 * time.Second),
+		},
+		{
+			Username:   "john",
+			Successful: false,
+			Time:       s.mock.Clock.Now().Add(-4 * time.Second),
+		},
+		{
+			Username:   "john",
+			Successful: false,
+			Time:       s.mock.Clock.Now().Add(-6 * time.Second),
+		},
+	}
+
+	expires := s.mock.Clock.Now().Add(-1 * time.Second).Add(s.mock.Ctx.Configuration.Regulation.BanTime)
+
+	gomock.InOrder(
+		s.mock.StorageMock.EXPECT().
+			RevokeBannedUser(s.mock.Ctx, "john", s.mock.Clock.Now()).
+			Return(nil),
+		s.mock.StorageMock.EXPECT().
+			UnlockRegulationLockout(s.mock.Ctx, "john", regulation.UnlockMethodAdministrator, s.mock.Clock.Now()).
+			Return(nil),
+		s.mock.StorageMock.EXPECT().
+			LoadBannedUser(s.mock.Ctx, "john", s.mock.Clock.Now(), gomock.Any()).
+			Return(nil, nil),
+		s.mock.StorageMock.EXPECT().
+			LoadAuthenticationLogs(s.mock.Ctx, "john", gomock.Any(), 10, 0).
+			Return(attempts, nil),
+		s.mock.StorageMock.EXPECT().
+			SaveBannedUser(s.mock.Ctx, model.BannedUser{
+				Time:     s.mock.Clock.Now().Add(-1 * time.Second),
+				Expires:  sql.NullTime{Time: expires, Valid: true},
+				Revoked:  sql.NullTime{Time: s.mock.Clock.Now(), Valid: true},
+				Username: "john",
+				Source:   regulation.BanSourceRegulation,
+				Reason:   sql.NullString{String: "cleared by an administrator", Valid: true},
+			}).
+			Return(nil),
+	)
+
+	regulator := regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
+
+	s.NoError(regulator.Clear(s.mock.Ctx, "john"))
+}
+
+// This test checks that the bans applied by the regulation are determined from a single query of the authentication
+// attempts rather than regulating each user individually, and that users with an active manual ban or an active
+// lockout are only listed once.
+func (s *RegulatorSuite) TestShouldListBans() {
+	now := s.mock.Clock.Now()
+	expires := now.Add(time.Hour)
+
+	failed := func(username string, ago time.Duration) model.AuthenticationAttempt {
+		return model.AuthenticationAttempt{Username: username, Successful: false, Time: now.Add(-ago)}
+	}
+
+	gomock.InOrder(
+		s.mock.StorageMock.EXPECT().
+			LoadBannedUsers(s.mock.Ctx, now, 100, 0).
+			Return([]model.BannedUser{
+				{Time: now.Add(-time.Minute), Expires: sql.NullTime{Time: expires, Valid: true}, Username: "harry", Source: regulation.BanSourceManual, Reason: sql.NullString{String: "suspicious", Valid: true}},
+			}, nil),
+		s.mock.StorageMock.EXPECT().
+			LoadBannedIPs(s.mock.Ctx, now, 100, 0).
+			Return([]model.BannedIP{
+				{Time: now.Add(-time.Minute), IP: model.NewIP(net.ParseIP("192.168.1.1")), Source: regulation.BanSourceManual},
+			}, nil),
+		s.mock.StorageMock.EXPECT().
+			LoadRegulationLockouts(s.mock.Ctx, 100, 0).
+			Return(nil, storage.ErrNoRegulationLockout),
+		s.mock.StorageMock.EXPECT().
+			LoadAuthenticationLogsRegulated(s.mock.Ctx, now.Add(-s.mock.Ctx.Configuration.Regulation.BanTime), 3, 100, 0).
+			Return([]model.AuthenticationAttempt{
+				failed("harry", time.Second),
+				failed("harry", time.Second*2),
+				failed("harry", time.Second*3),
+				failed("john", time.Second*10),
+				failed("john", time.Second*15),
+				failed("john", time.Second*20),
+				failed("sam", time.Second*10),
+				{Username: "sam", Successful: true, Time: now.Add(-time.Second * 12)},
+				failed("sam", time.Second*15),
+				failed("sam", time.Second*20),
+				failed("sam", time.Second*25),
+			}, nil),
+	)
+
+	regulator := regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
+
+	bans, err := regulator.Bans(s.mock.Ctx)
+
+	s.NoError(err)
+
+	johnExpires := now.Add(-time.Second * 10).Add(s.mock.Ctx.Configuration.Regulation.BanTime)
+
+	s.Equal([]regulation.Ban{
+		{Type: regulation.BanTypeUser, Value: "harry", Source: regulation.BanSourceManual, Time: now.Add(-time.Minute), Expires: &expires, Reason: "suspicious"},
+		{Type: regulation.BanTypeIP, Value: "192.168.1.1", Source: regulation.BanSourceManual, Time: now.Add(-time.Minute)},
+		{Type: regulation.BanTypeUser, Value: "john", Source: regulation.BanSourceRegulation, Time: now.Add(-time.Second * 10), Expires: &johnExpires},
+	}, bans)
+}
+
+// This test checks that users with an active lockout are listed once when the progressive regulation is enabled.
+func (s *RegulatorSuite) TestShouldListProgressiveBans() {
+	s.mock.Ctx.Configuration.Regulation.Backoff = schema.RegulationBackoff{Multiplier: 2, MaxBanTime: time.Hour}
+	s.mock.Ctx.Configuration.Regulation.Lockout = schema.RegulationLockout{MaxBans: 3, Window: time.Hour * 24}
+
+	now := s.mock.Clock.Now()
+
+	var attempts []model.AuthenticationAttempt
+
+	for _, attempt := range s.progressiveAttempts() {
+		attempt.Username = "bob"
+
+		attempts = append(attempts, attempt)
+	}
+
+	attempts = append(attempts, s.progressiveAttempts()...)
+
+	gomock.InOrder(
+		s.mock.StorageMock.EXPECT().
+			LoadBannedUsers(s.mock.Ctx, now, 100, 0).
+			Return(nil, storage.ErrNoBannedUsers),
+		s.mock.StorageMock.EXPECT().
+			LoadBannedIPs(s.mock.Ctx, now, 100, 0).
+			Return(nil, storage.ErrNoBannedIPs),
+		s.mock.StorageMock.EXPECT().
+			LoadRegulationLockouts(s.mock.Ctx, 100, 0).
+			Return([]model.RegulationLockout{{LockedAt: now.Add(-time.Minute), Username: "bob", Bans: 3}}, nil),
+		s.mock.StorageMock.EXPECT().
+			LoadAuthenticationLogsRegulated(s.mock.Ctx, now.Add(-time.Hour*24), 3, 100, 0).
+			Return(attempts, nil),
+	)
+
+	regulator := regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
+
+	bans, err := regulator.Bans(s.mock.Ctx)
+
+	s.NoError(err)
+
+	expires := now.Add(-110 * time.Second).Add(360 * time.Second)
+
+	s.Equal([]regulation.Ban{
+		{Type: regulation.BanTypeUser, Value: "bob", Source: regulation.BanSourceLockout, Time: now.Add(-time.Minute)},
+		{Type: regulation.BanTypeUser, Value: "john", Source: regulation.BanSourceRegulation, Time: now.Add(-110 * time.Second), Expires: &expires},
+	}, bans)
+}
+
+func (s *RegulatorSuite) TestShouldHandleListBansError() {
+	now := s.mock.Clock.Now()
+
+	gomock.InOrder(
+		s.mock.StorageMock.EXPECT().
+			LoadBannedUsers(s.mock.Ctx, now, 100, 0).
+			Return(nil, storage.ErrNoBannedUsers),
+		s.mock.StorageMock.EXPECT().
+			LoadBannedIPs(s.mock.Ctx, now, 100, 0).
+			Return(nil, storage.ErrNoBannedIPs),
+		s.mock.StorageMock.EXPECT().
+			LoadRegulationLockouts(s.mock.Ctx, 100, 0).
+			Return(nil, storage.ErrNoRegulationLockout),
+		s.mock.StorageMock.EXPECT().
+			LoadAuthenticationLogsRegulated(s.mock.Ctx, gomock.Any(), 3, 100, 0).
+			Return(nil, fmt.Errorf("failed")),
+	)
+
+	regulator := regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
+
+	bans, err := regulator.Bans(s.mock.Ctx)
+
+	s.EqualError(err, "failed")
+	s.Nil(bans)
+}
+
 func TestRunRegulatorSuite(t *testing.T) {
 	s := new(RegulatorSuite)
 	suite.Run(t, s)
@@ -302,6 +883,8 @@ func (s *RegulatorSuite) TestShouldHaveRegulatorDisabled() {
 		},
 	}
 
+	s.expectNoBans()
+
 	s.mock.StorageMock.EXPECT().
 		LoadAuthenticationLogs(s.mock.Ctx, gomock.Eq("john"), gomock.Any(), gomock.Eq(10), gomock.Eq(0)).
 		Return(attemptsInDB, nil)
@@ -3,9 +3,11 @@ package regulation
 import (
 	"context"
 	"net"
+	"time"
 
 	"github.com/authelia/authelia/v4/internal/clock"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/model"
 	"github.com/authelia/authelia/v4/internal/storage"
 )
 
@@ -14,6 +16,9 @@ type Regulator struct {
 	// Is the regulation enabled.
 	enabled bool
 
+	// Is the progressive backoff or lockout enabled.
+	progressive bool
+
 	config schema.Regulation
 
 	store storage.RegulatorProvider
@@ -33,3 +38,52 @@ type Context interface {
 type MetricsRecorder interface {
 	RecordAuthn(success, banned bool, authType string)
 }
+
+// Ban represents a ban which currently applies to a user or an IP.
+type Ban struct {
+	// Type is either BanTypeUser or BanTypeIP.
+	Type string
+
+	// Value is the username or IP the ban applies to.
+	Value string
+
+	// Source is either BanSourceRegulation, BanSourceLockout, or BanSourceManual.
+	Source string
+
+	// Time is the time the ban started.
+	Time time.Time
+
+	// Expires is the time the ban ends, or nil if the ban is permanent.
+	Expires *time.Time
+
+	// Reason is the reason provided for a manual ban.
+	Reason string
+}
+
+// Until returns the time the ban ends or the zero value if it's permanent.
+func (b Ban) Until() time.Time {
+	if b.Expires == nil {
+		return time.Time{}
+	}
+
+	return *b.Expires
+}
+
+// Status represents the regulation status of a user or an IP.
+type Status struct {
+	// Type is either BanTypeUser or BanTypeIP.
+	Type string
+
+	// Value is the username or IP the status applies to.
+	Value string
+
+	// Bans is the list of bans which currently apply.
+	Bans []Ban
+
+	// Attempts is the list of recent authentication attempts from the latest to the oldest.
+	Attempts []model.AuthenticationAttempt
+}
+
+type period struct {
+	start, end time.Time
+}
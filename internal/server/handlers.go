@@ -4,6 +4,7 @@ import (
 	"crypto/tls"
 	"fmt"
 	"net"
+	"net/http"
 	"os"
 	"path"
 	"strings"
@@ -252,6 +253,13 @@ func handleRouter(config *schema.Configuration, providers middlewares.Providers)
 	delayFunc := middlewares.TimingAttackDelay(10, 250, 85, time.Second, true)
 
 	r.POST("/api/firstfactor", middlewareAPI(handlers.FirstFactorPOST(delayFunc)))
+
+	// Only register the passkey endpoints if passwordless WebAuthn login is enabled.
+	if !config.WebAuthn.Disable && config.WebAuthn.EnablePasskeyLogin {
+		r.GET("/api/firstfactor/passkey", middlewareAPI(handlers.FirstFactorPasskeyGET))
+		r.POST("/api/firstfactor/passkey", middlewareAPI(handlers.FirstFactorPasskeyPOST(delayFunc)))
+	}
+
 	r.POST("/api/logout", middlewareAPI(handlers.LogoutPOST))
 
 	// Only register endpoints if forgot password is not disabled.
@@ -265,6 +273,25 @@ func handleRouter(config *schema.Configuration, providers middlewares.Providers)
 		r.DELETE("/api/reset-password", middlewareAPI(handlers.ResetPasswordDELETE))
 	}
 
+	// Only register endpoints if unlocking a locked account with an identity verification is enabled.
+	if config.Regulation.Lockout.MaxBans > 0 && config.Regulation.Lockout.IdentityVerificationUnlock {
+		r.POST("/api/regulation/unlock/identity/start", middlewareAPI(handlers.RegulationUnlockIdentityStart))
+		r.POST("/api/regulation/unlock/identity/finish", middlewareAPI(handlers.RegulationUnlockIdentityFinish))
+
+		r.DELETE("/api/regulation/unlock", middlewareAPI(handlers.RegulationUnlockDELETE))
+	}
+
+	// Only register the regulation administration endpoints if they're enabled.
+	if config.Regulation.Administration.Enable {
+		r.GET("/api/regulation/bans", middleware1FA(handlers.RegulationBansGET))
+		r.GET("/api/regulation/bans/{value}", middleware1FA(handlers.RegulationBanGET))
+	}
+
+	// Only register the configuration reload endpoint if it's enabled.
+	if config.Reload.Administration.Enable {
+		r.POST("/api/configuration/reload", middleware1FA(handlers.ConfigurationReloadPOST))
+	}
+
 	// Information about the user.
 	r.GET("/api/user/info", middleware1FA(handlers.UserInfoGET))
 	r.POST("/api/user/info", middleware1FA(handlers.UserInfoPOST))
@@ -306,8 +333,37 @@ func handleRouter(config *schema.Configuration, providers middlewares.Providers)
 		r.DELETE("/api/secondfactor/webauthn/credential/{credentialID}", middlewareElevated1FA(handlers.WebAuthnCredentialDELETE))
 	}
 
+	if !config.RecoveryCodes.Disable {
+		r.POST("/api/secondfactor/recovery-code", middleware1FA(handlers.RecoveryCodePOST))
+		r.PUT("/api/secondfactor/recovery-codes", middlewareElevated1FA(handlers.RecoveryCodesPUT))
+	}
+
+	if config.TrustedDevice.Enable {
+		r.POST("/api/secondfactor/trusted-device", middleware1FA(handlers.TrustedDevicePOST))
+		r.GET("/api/secondfactor/trusted-devices", middleware1FA(handlers.TrustedDevicesGET))
+		r.DELETE("/api/secondfactor/trusted-device/{deviceID}", middlewareElevated1FA(handlers.TrustedDeviceDELETE))
+	}
+
+	if config.EmailOneTimeCode.Enable {
+		r.PUT("/api/secondfactor/email", middleware1FA(handlers.EmailOneTimeCodePUT))
+		r.POST("/api/secondfactor/email", middleware1FA(handlers.EmailOneTimeCodePOST))
+	}
+
 	// Configure DUO api endpoint only if configuration exists.
-	if !config.DuoAPI.Disable {
+	switch {
+	case config.DuoAPI.Disable:
+		break
+	case config.DuoAPI.UniversalPrompt:
+		var client *http.Client
+
+		if os.Getenv("ENVIRONMENT") == dev {
+			client = &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}} //nolint:gosec // Only used in the development environment.
+		}
+
+		prompt := duo.NewUniversalPromptClient(config.DuoAPI.IntegrationKey, config.DuoAPI.SecretKey, config.DuoAPI.Hostname, client)
+
+		r.POST("/api/secondfactor/duo", middleware1FA(handlers.DuoUniversalPromptPOST(prompt)))
+	default:
 		var duoAPI duo.API
 		if os.Getenv("ENVIRONMENT") == dev {
 			duoAPI = duo.NewDuoAPI(duoapi.NewDuoApi(
@@ -5,6 +5,7 @@ import (
 	"crypto/sha1" //nolint:gosec
 	"encoding/hex"
 	"fmt"
+	"math"
 	"os"
 	"path"
 	"path/filepath"
@@ -266,19 +267,28 @@ func NewTemplatedFileOptions(config *schema.Configuration) (opts *TemplatedFileO
 	opts = &TemplatedFileOptions{
 		AssetPath:              config.Server.AssetPath,
 		DuoSelfEnrollment:      strFalse,
+		PasskeyLogin:           strconv.FormatBool(!config.WebAuthn.Disable && config.WebAuthn.EnablePasskeyLogin),
+		RecoveryCodes:          strconv.FormatBool(!config.RecoveryCodes.Disable),
 		RememberMe:             strconv.FormatBool(!config.Session.DisableRememberMe),
 		ResetPassword:          strconv.FormatBool(!config.AuthenticationBackend.PasswordReset.Disable),
 		ResetPasswordCustomURL: config.AuthenticationBackend.PasswordReset.CustomURL.String(),
 		PrivacyPolicyURL:       "",
 		PrivacyPolicyAccept:    strFalse,
 		Theme:                  config.Theme,
+		TrustedDeviceDays:      "0",
 
 		EndpointsPasswordReset: !(config.AuthenticationBackend.PasswordReset.Disable || config.AuthenticationBackend.PasswordReset.CustomURL.String() != ""),
 		EndpointsWebAuthn:      !config.WebAuthn.Disable,
+		EndpointsPasskey:       !config.WebAuthn.Disable && config.WebAuthn.EnablePasskeyLogin,
 		EndpointsTOTP:          !config.TOTP.Disable,
+		EndpointsRecoveryCodes: !config.RecoveryCodes.Disable,
+		EndpointsEmail:         config.EmailOneTimeCode.Enable,
 		EndpointsDuo:           !config.DuoAPI.Disable,
 		EndpointsOpenIDConnect: !(config.IdentityProviders.OIDC == nil),
 		EndpointsAuthz:         config.Server.Endpoints.Authz,
+
+		EndpointsRegulationAdministration: config.Regulation.Administration.Enable,
+		EndpointsConfigurationReload:      config.Reload.Administration.Enable,
 	}
 
 	if config.PrivacyPolicy.Enabled {
@@ -290,6 +300,10 @@ func NewTemplatedFileOptions(config *schema.Configuration) (opts *TemplatedFileO
 		opts.DuoSelfEnrollment = strconv.FormatBool(config.DuoAPI.EnableSelfEnrollment)
 	}
 
+	if config.TrustedDevice.Enable {
+		opts.TrustedDeviceDays = strconv.Itoa(int(math.Ceil(config.TrustedDevice.Lifespan.Hours() / 24)))
+	}
+
 	return opts
 }
 
@@ -297,6 +311,8 @@ func NewTemplatedFileOptions(config *schema.Configuration) (opts *TemplatedFileO
 type TemplatedFileOptions struct {
 	AssetPath              string
 	DuoSelfEnrollment      string
+	PasskeyLogin           string
+	RecoveryCodes          string
 	RememberMe             string
 	ResetPassword          string
 	ResetPasswordCustomURL string
@@ -304,13 +320,20 @@ type TemplatedFileOptions struct {
 	PrivacyPolicyAccept    string
 	Session                string
 	Theme                  string
+	TrustedDeviceDays      string
 
 	EndpointsPasswordReset bool
 	EndpointsWebAuthn      bool
+	EndpointsPasskey       bool
 	EndpointsTOTP          bool
+	EndpointsRecoveryCodes bool
+	EndpointsEmail         bool
 	EndpointsDuo           bool
 	EndpointsOpenIDConnect bool
 
+	EndpointsRegulationAdministration bool
+	EndpointsConfigurationReload      bool
+
 	EndpointsAuthz map[string]schema.ServerEndpointsAuthz
 }
 
@@ -327,6 +350,8 @@ func (options *TemplatedFileOptions) CommonData(base, baseURL, domain, nonce, lo
 		CSPNonce:               nonce,
 		LogoOverride:           logoOverride,
 		DuoSelfEnrollment:      options.DuoSelfEnrollment,
+		PasskeyLogin:           options.PasskeyLogin,
+		RecoveryCodes:          options.RecoveryCodes,
 		RememberMe:             options.RememberMe,
 		ResetPassword:          options.ResetPassword,
 		ResetPasswordCustomURL: options.ResetPasswordCustomURL,
@@ -334,6 +359,7 @@ func (options *TemplatedFileOptions) CommonData(base, baseURL, domain, nonce, lo
 		PrivacyPolicyAccept:    options.PrivacyPolicyAccept,
 		Session:                options.Session,
 		Theme:                  options.Theme,
+		TrustedDeviceDays:      options.TrustedDeviceDays,
 	}
 }
 
@@ -346,11 +372,14 @@ func (options *TemplatedFileOptions) commonDataWithRememberMe(base, baseURL, dom
 		CSPNonce:               nonce,
 		LogoOverride:           logoOverride,
 		DuoSelfEnrollment:      options.DuoSelfEnrollment,
+		PasskeyLogin:           options.PasskeyLogin,
+		RecoveryCodes:          options.RecoveryCodes,
 		RememberMe:             rememberMe,
 		ResetPassword:          options.ResetPassword,
 		ResetPasswordCustomURL: options.ResetPasswordCustomURL,
 		Session:                options.Session,
 		Theme:                  options.Theme,
+		TrustedDeviceDays:      options.TrustedDeviceDays,
 	}
 }
 
@@ -365,10 +394,16 @@ func (options *TemplatedFileOptions) OpenAPIData(base, baseURL, domain, nonce st
 		Session:        options.Session,
 		PasswordReset:  options.EndpointsPasswordReset,
 		WebAuthn:       options.EndpointsWebAuthn,
+		Passkey:        options.EndpointsPasskey,
 		TOTP:           options.EndpointsTOTP,
+		RecoveryCodes:  options.EndpointsRecoveryCodes,
+		Email:          options.EndpointsEmail,
 		Duo:            options.EndpointsDuo,
 		OpenIDConnect:  options.EndpointsOpenIDConnect,
 		EndpointsAuthz: options.EndpointsAuthz,
+
+		RegulationAdministration: options.EndpointsRegulationAdministration,
+		ConfigurationReload:      options.EndpointsConfigurationReload,
 	}
 }
 
@@ -380,6 +415,8 @@ type TemplatedFileCommonData struct {
 	CSPNonce               string
 	LogoOverride           string
 	DuoSelfEnrollment      string
+	PasskeyLogin           string
+	RecoveryCodes          string
 	RememberMe             string
 	ResetPassword          string
 	ResetPasswordCustomURL string
@@ -387,6 +424,7 @@ type TemplatedFileCommonData struct {
 	PrivacyPolicyAccept    string
 	Session                string
 	Theme                  string
+	TrustedDeviceDays      string
 }
 
 // TemplatedFileOpenAPIData is a struct which is used for the OpenAPI spec file.
@@ -398,9 +436,15 @@ type TemplatedFileOpenAPIData struct {
 	Session       string
 	PasswordReset bool
 	WebAuthn      bool
+	Passkey       bool
 	TOTP          bool
+	RecoveryCodes bool
+	Email         bool
 	Duo           bool
 	OpenIDConnect bool
 
+	RegulationAdministration bool
+	ConfigurationReload      bool
+
 	EndpointsAuthz map[string]schema.ServerEndpointsAuthz
 }
@@ -138,6 +138,45 @@ func TestShouldSetSessionAuthenticationLevels(t *testing.T) {
 	assert.Equal(t, timeZeroFactor, authAt)
 }
 
+func TestShouldSetSessionAuthenticationLevelsTrustedDevice(t *testing.T) {
+	ctx := &fasthttp.RequestCtx{}
+
+	timeOneFactor := time.Unix(1625048140, 0).UTC()
+	timeTwoFactor := time.Unix(1625048150, 0).UTC()
+	timeTOTP := time.Unix(1625048160, 0).UTC()
+
+	provider, err := newTestSession()
+	assert.NoError(t, err)
+
+	session, _ := provider.GetSession(ctx)
+
+	session.SetOneFactor(timeOneFactor, &authentication.UserDetails{Username: testUsername}, false)
+	session.SetTwoFactorTrustedDevice(timeTwoFactor)
+
+	err = provider.SaveSession(ctx, session)
+	assert.NoError(t, err)
+
+	session, err = provider.GetSession(ctx)
+	assert.NoError(t, err)
+
+	assert.Equal(t, UserSession{
+		CookieDomain:               testDomain,
+		Username:                   testUsername,
+		AuthenticationLevel:        authentication.TwoFactor,
+		LastActivity:               timeTwoFactor.Unix(),
+		FirstFactorAuthnTimestamp:  timeOneFactor.Unix(),
+		SecondFactorAuthnTimestamp: timeTwoFactor.Unix(),
+		AuthenticationMethodRefs:   oidc.AuthenticationMethodsReferences{UsernameAndPassword: true},
+		TrustedDevice:              true,
+	}, session)
+
+	session.SetTwoFactorTOTP(timeTOTP)
+
+	assert.False(t, session.TrustedDevice)
+	assert.Equal(t, oidc.AuthenticationMethodsReferences{UsernameAndPassword: true, TOTP: true}, session.AuthenticationMethodRefs)
+	assert.Equal(t, timeTOTP.Unix(), session.SecondFactorAuthnTimestamp)
+}
+
 func TestShouldSetSessionAuthenticationLevelsAMR(t *testing.T) {
 	ctx := &fasthttp.RequestCtx{}
 
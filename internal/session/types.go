@@ -36,10 +36,16 @@ type UserSession struct {
 
 	AuthenticationMethodRefs oidc.AuthenticationMethodsReferences
 
+	// TrustedDevice is true when the second factor was satisfied by a trusted device instead of a second factor method.
+	TrustedDevice bool
+
 	// WebAuthn holds the session registration data for this session.
 	WebAuthn *WebAuthn
 	TOTP     *TOTP
 
+	// Duo holds the Duo Universal Prompt authentication data for this session.
+	Duo *Duo
+
 	// This boolean is set to true after identity verification and checked
 	// while doing the query actually updating the password.
 	PasswordResetUsername *string
@@ -59,6 +65,16 @@ type TOTP struct {
 	Expires   time.Time
 }
 
+// Duo holds the Duo Universal Prompt authentication session data.
+type Duo struct {
+	State      string
+	Nonce      string
+	TargetURL  string
+	Workflow   string
+	WorkflowID string
+	Expires    time.Time
+}
+
 // WebAuthn holds the standard WebAuthn session data plus some extra.
 type WebAuthn struct {
 	*webauthn.SessionData
@@ -24,6 +24,22 @@ func (s *UserSession) IsAnonymous() bool {
 
 // SetOneFactor sets the 1FA AMR's and expected property values for one factor authentication.
 func (s *UserSession) SetOneFactor(now time.Time, details *authentication.UserDetails, keepMeLoggedIn bool) {
+	s.setOneFactor(now, details, keepMeLoggedIn)
+
+	s.AuthenticationMethodRefs.UsernameAndPassword = true
+}
+
+// SetOneFactorPasskey sets the 1FA AMR's and expected property values for one factor authentication via a passkey.
+func (s *UserSession) SetOneFactorPasskey(now time.Time, details *authentication.UserDetails, keepMeLoggedIn, userPresence, userVerified bool) {
+	s.setOneFactor(now, details, keepMeLoggedIn)
+
+	s.AuthenticationMethodRefs.WebAuthn = true
+	s.AuthenticationMethodRefs.WebAuthnUserPresence, s.AuthenticationMethodRefs.WebAuthnUserVerified = userPresence, userVerified
+
+	s.WebAuthn = nil
+}
+
+func (s *UserSession) setOneFactor(now time.Time, details *authentication.UserDetails, keepMeLoggedIn bool) {
 	s.FirstFactorAuthnTimestamp = now.Unix()
 	s.LastActivity = now.Unix()
 	s.AuthenticationLevel = authentication.OneFactor
@@ -34,14 +50,13 @@ func (s *UserSession) SetOneFactor(now time.Time, details *authentication.UserDe
 	s.DisplayName = details.DisplayName
 	s.Groups = details.Groups
 	s.Emails = details.Emails
-
-	s.AuthenticationMethodRefs.UsernameAndPassword = true
 }
 
 func (s *UserSession) setTwoFactor(now time.Time) {
 	s.SecondFactorAuthnTimestamp = now.Unix()
 	s.LastActivity = now.Unix()
 	s.AuthenticationLevel = authentication.TwoFactor
+	s.TrustedDevice = false
 }
 
 // SetTwoFactorTOTP sets the relevant TOTP AMR's and sets the factor to 2FA.
@@ -50,12 +65,30 @@ func (s *UserSession) SetTwoFactorTOTP(now time.Time) {
 	s.AuthenticationMethodRefs.TOTP = true
 }
 
+// SetTwoFactorRecoveryCode sets the relevant recovery code AMR's and sets the factor to 2FA.
+func (s *UserSession) SetTwoFactorRecoveryCode(now time.Time) {
+	s.setTwoFactor(now)
+	s.AuthenticationMethodRefs.RecoveryCode = true
+}
+
 // SetTwoFactorDuo sets the relevant Duo AMR's and sets the factor to 2FA.
 func (s *UserSession) SetTwoFactorDuo(now time.Time) {
 	s.setTwoFactor(now)
 	s.AuthenticationMethodRefs.Duo = true
 }
 
+// SetTwoFactorEmail sets the relevant Email One-Time Code AMR's and sets the factor to 2FA.
+func (s *UserSession) SetTwoFactorEmail(now time.Time) {
+	s.setTwoFactor(now)
+	s.AuthenticationMethodRefs.Email = true
+}
+
+// SetTwoFactorPasskey sets the factor to 2FA for a passkey login which performed user verification. The relevant
+// WebAuthn AMR's must already be set via SetOneFactorPasskey.
+func (s *UserSession) SetTwoFactorPasskey(now time.Time) {
+	s.setTwoFactor(now)
+}
+
 // SetTwoFactorWebAuthn sets the relevant WebAuthn AMR's and sets the factor to 2FA.
 func (s *UserSession) SetTwoFactorWebAuthn(now time.Time, userPresence, userVerified bool) {
 	s.setTwoFactor(now)
@@ -65,6 +98,13 @@ func (s *UserSession) SetTwoFactorWebAuthn(now time.Time, userPresence, userVeri
 	s.WebAuthn = nil
 }
 
+// SetTwoFactorTrustedDevice sets the factor to 2FA for a first factor login from a trusted device. No AMR's are set as
+// the user has not performed a second factor method.
+func (s *UserSession) SetTwoFactorTrustedDevice(now time.Time) {
+	s.setTwoFactor(now)
+	s.TrustedDevice = true
+}
+
 // AuthenticatedTime returns the unix timestamp this session authenticated successfully at the given level.
 func (s *UserSession) AuthenticatedTime(level authorization.Level) (authenticatedTime time.Time, err error) {
 	switch level {
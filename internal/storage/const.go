@@ -6,11 +6,18 @@ import (
 
 const (
 	tableAuthenticationLogs   = "authentication_logs"
+	tableBannedIP             = "banned_ip"
+	tableBannedUser           = "banned_user"
 	tableDuoDevices           = "duo_devices"
 	tableIdentityVerification = "identity_verification"
+	tableKnownLogin           = "known_login"
+	tableNotificationQueue    = "notification_queue"
 	tableOneTimeCode          = "one_time_code"
+	tableRecoveryCodes        = "recovery_codes"
+	tableRegulationLockout    = "regulation_lockout"
 	tableTOTPConfigurations   = "totp_configurations"
 	tableTOTPHistory          = "totp_history"
+	tableTrustedDevice        = "trusted_device"
 	tableUserOpaqueIdentifier = "user_opaque_identifier"
 	tableUserPreferences      = "user_preferences"
 	tableWebAuthnCredentials  = "webauthn_credentials" //nolint:gosec // This is a table name, not a credential.
@@ -27,14 +34,95 @@ const (
 	tableOAuth2PKCERequestSession   = "oauth2_pkce_request_session"
 	tableOAuth2RefreshTokenSession  = "oauth2_refresh_token_session" //nolint:gosec // This is not a hardcoded credential.
 
-	tableMigrations = "migrations"
-	tableEncryption = "encryption"
+	tableMigrations    = "migrations"
+	tableEncryption    = "encryption"
+	tableEncryptionKey = "encryption_key"
 )
 
 const (
 	encryptionNameCheck = "check"
 )
 
+const (
+	// schemaVersionEncryptionKeys is the schema version which introduced the data keys used for envelope encryption.
+	schemaVersionEncryptionKeys = 24
+
+	dataKeyIDSize = 8
+)
+
+const (
+	retentionAuthenticationLogs   = "authentication_logs"
+	retentionTOTPHistory          = "totp_history"
+	retentionIdentityVerification = "identity_verification"
+	retentionOneTimeCode          = "one_time_code"
+	retentionOAuth2Sessions       = "oauth2_sessions"
+	retentionOAuth2BlacklistedJTI = "oauth2_blacklisted_jti"
+)
+
+// tablesOAuth2Sessions are the OAuth 2.0 session tables which are pruned by the oauth2_sessions retention policy. The
+// consent sessions are not included as the other sessions reference them.
+var tablesOAuth2Sessions = []string{
+	tableOAuth2AccessTokenSession,
+	tableOAuth2AuthorizeCodeSession,
+	tableOAuth2OpenIDConnectSession,
+	tableOAuth2PARContext,
+	tableOAuth2PKCERequestSession,
+	tableOAuth2RefreshTokenSession,
+}
+
+// tablesBackup is the order tables are written to and restored from a backup archive. This order satisfies the foreign
+// key constraints of the schema. Tables which exist in the schema but are not in this list are included in the backup
+// after these tables.
+var tablesBackup = []string{
+	tableEncryptionKey,
+	tableEncryption,
+	tableUserOpaqueIdentifier,
+	tableUserPreferences,
+	tableAuthenticationLogs,
+	tableRegulationLockout,
+	tableBannedUser,
+	tableBannedIP,
+	tableIdentityVerification,
+	tableOneTimeCode,
+	tableTOTPConfigurations,
+	tableTOTPHistory,
+	tableWebAuthnUsers,
+	tableWebAuthnCredentials,
+	tableDuoDevices,
+	tableRecoveryCodes,
+	tableKnownLogin,
+	tableTrustedDevice,
+	tableNotificationQueue,
+	tableOAuth2ConsentPreConfiguration,
+	tableOAuth2ConsentSession,
+	tableOAuth2AccessTokenSession,
+	tableOAuth2AuthorizeCodeSession,
+	tableOAuth2OpenIDConnectSession,
+	tableOAuth2PARContext,
+	tableOAuth2PKCERequestSession,
+	tableOAuth2RefreshTokenSession,
+	tableOAuth2BlacklistedJTI,
+}
+
+const (
+	// BackupVersion is the current version of the backup archive format.
+	BackupVersion = 1
+
+	backupColumnID       = "id"
+	backupColumnName     = "name"
+	backupColumnValue    = "value"
+	backupTablePrefixBKP = "_bkp_"
+	backupTablePrefixSQL = "sqlite_"
+)
+
+const (
+	backupKindString backupKind = iota
+	backupKindInteger
+	backupKindBoolean
+	backupKindBytes
+	backupKindTime
+)
+
 // WARNING: Do not change/remove these consts. They are used for Pre1 migrations.
 const (
 	tablePre1TOTPSecrets                = "totp_secrets"
@@ -71,6 +159,7 @@ const (
 
 var (
 	reMigration = regexp.MustCompile(`^V(?P<Version>\d{4})\.(?P<Name>[^.]+)\.(?P<Direction>(up|down))\.sql$`)
+	reTableName = regexp.MustCompile(`^[a-zA-Z0-9_]+$`)
 )
 
 const (
@@ -8,6 +8,21 @@ var (
 	// ErrNoAuthenticationLogs error thrown when no matching authentication logs have been found in DB.
 	ErrNoAuthenticationLogs = errors.New("no matching authentication logs found")
 
+	// ErrNoRegulationLockout error thrown when no regulation lockout has been found in DB.
+	ErrNoRegulationLockout = errors.New("no regulation lockout found")
+
+	// ErrNoBannedUsers error thrown when no banned users have been found in DB.
+	ErrNoBannedUsers = errors.New("no banned users found")
+
+	// ErrNoBannedIPs error thrown when no banned IPs have been found in DB.
+	ErrNoBannedIPs = errors.New("no banned IPs found")
+
+	// ErrNoWebAuthnUser error thrown when no WebAuthn user has been found in DB.
+	ErrNoWebAuthnUser = errors.New("no WebAuthn user found")
+
+	// ErrNoQueuedNotifications error thrown when no queued notifications have been found in DB.
+	ErrNoQueuedNotifications = errors.New("no queued notifications found")
+
 	// ErrNoTOTPConfiguration error thrown when no TOTP configuration has been found in DB.
 	ErrNoTOTPConfiguration = errors.New("no TOTP configuration for user")
 
@@ -47,10 +62,20 @@ const (
 	ErrFmtMigrateAlreadyOnTargetVersion       = "schema migration target version %d is the same current version %d"
 )
 
+const (
+	errFmtBackupSchemaVersion      = "error performing backup: the schema version %s doesn't support backups"
+	errFmtBackupVersionUnsupported = "error reading the backup header: the backup version %d is not supported, the supported version is %d"
+	errFmtRestoreSchemaVersion     = "error restoring the backup: the current schema version %d doesn't match the backup schema version %d, " +
+		"the schema must either be empty or the same version as the backup"
+	errFmtRestoreTableNotEmpty = "error restoring the backup: the table '%s' contains %d rows and the force option is required to delete the existing data"
+)
+
 const (
 	errFmtFailedMigration                     = "schema migration %d (%s) failed: %w"
 	errFmtSchemaCurrentGreaterThanLatestKnown = "current schema version is greater than the latest known schema " +
 		"version, you must downgrade to schema version %d before you can use this version of Authelia"
+	errFmtMigrateDownDataKeys = "schema down migration target version %d doesn't support data keys and the storage " +
+		"is encrypted with a data key which would make the encrypted values unrecoverable"
 )
 
 const (
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS regulation_lockout (
+    id INTEGER NOT NULL PRIMARY KEY AUTO_INCREMENT,
+    locked TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    locked_ip VARCHAR(39) NULL DEFAULT NULL,
+    username VARCHAR(100) NOT NULL,
+    bans INTEGER NOT NULL,
+    unlocked TIMESTAMP NULL DEFAULT NULL,
+    unlocked_method VARCHAR(50) NULL DEFAULT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_520_ci;
+
+CREATE INDEX regulation_lockout_username_idx ON regulation_lockout (username, locked);
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS banned_user (
+    id INTEGER NOT NULL PRIMARY KEY AUTO_INCREMENT,
+    time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires TIMESTAMP NULL DEFAULT NULL,
+    revoked TIMESTAMP NULL DEFAULT NULL,
+    username VARCHAR(100) NOT NULL,
+    source VARCHAR(20) NOT NULL,
+    reason TEXT NULL DEFAULT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_520_ci;
+
+CREATE INDEX banned_user_username_idx ON banned_user (username, revoked, expires);
+
+CREATE TABLE IF NOT EXISTS banned_ip (
+    id INTEGER NOT NULL PRIMARY KEY AUTO_INCREMENT,
+    time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires TIMESTAMP NULL DEFAULT NULL,
+    revoked TIMESTAMP NULL DEFAULT NULL,
+    ip VARCHAR(39) NOT NULL,
+    source VARCHAR(20) NOT NULL,
+    reason TEXT NULL DEFAULT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_520_ci;
+
+CREATE INDEX banned_ip_ip_idx ON banned_ip (ip, revoked, expires);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS recovery_codes (
+    id INTEGER NOT NULL PRIMARY KEY AUTO_INCREMENT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    used_at TIMESTAMP NULL DEFAULT NULL,
+    username VARCHAR(100) NOT NULL,
+    hash VARCHAR(512) NOT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_520_ci;
+
+CREATE INDEX recovery_codes_lookup_key ON recovery_codes (username, used_at);
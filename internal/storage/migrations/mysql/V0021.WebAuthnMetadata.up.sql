@@ -0,0 +1 @@
+ALTER TABLE webauthn_credentials ADD COLUMN model VARCHAR(255) NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+ALTER TABLE totp_configurations DROP COLUMN counter;
+ALTER TABLE totp_configurations DROP COLUMN type;
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS regulation_lockout (
+    id SERIAL CONSTRAINT regulation_lockout_pkey PRIMARY KEY,
+    locked TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    locked_ip VARCHAR(39) NULL DEFAULT NULL,
+    username VARCHAR(100) NOT NULL,
+    bans INTEGER NOT NULL,
+    unlocked TIMESTAMP WITH TIME ZONE NULL DEFAULT NULL,
+    unlocked_method VARCHAR(50) NULL DEFAULT NULL
+);
+
+CREATE INDEX regulation_lockout_username_idx ON regulation_lockout (username, locked);
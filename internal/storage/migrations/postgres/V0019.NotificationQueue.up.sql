@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS notification_queue (
+    id SERIAL CONSTRAINT notification_queue_pkey PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    sent_at TIMESTAMP WITH TIME ZONE NULL DEFAULT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    status VARCHAR(10) NOT NULL,
+    recipient VARCHAR(512) NOT NULL,
+    subject VARCHAR(512) NOT NULL,
+    template VARCHAR(100) NOT NULL,
+    data BYTEA NOT NULL,
+    last_error TEXT NULL DEFAULT NULL
+);
+
+CREATE INDEX notification_queue_lookup_key ON notification_queue (status, next_attempt_at);
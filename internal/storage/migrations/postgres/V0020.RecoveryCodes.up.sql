@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS recovery_codes (
+    id SERIAL CONSTRAINT recovery_codes_pkey PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    used_at TIMESTAMP WITH TIME ZONE NULL DEFAULT NULL,
+    username VARCHAR(100) NOT NULL,
+    hash VARCHAR(512) NOT NULL
+);
+
+CREATE INDEX recovery_codes_lookup_key ON recovery_codes (username, used_at);
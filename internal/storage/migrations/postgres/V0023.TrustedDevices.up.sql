@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS trusted_device (
+    id SERIAL CONSTRAINT trusted_device_pkey PRIMARY KEY,
+    public_id CHAR(36) NOT NULL,
+    signature VARCHAR(128) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_ip VARCHAR(39) NOT NULL,
+    last_used_at TIMESTAMP WITH TIME ZONE NULL DEFAULT NULL,
+    last_used_ip VARCHAR(39) NULL DEFAULT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE NULL DEFAULT NULL,
+    username VARCHAR(100) NOT NULL,
+    description VARCHAR(255) NOT NULL
+);
+
+CREATE UNIQUE INDEX trusted_device_public_id_key ON trusted_device (public_id);
+CREATE INDEX trusted_device_username_idx ON trusted_device (username);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS encryption_key (
+    id SERIAL CONSTRAINT encryption_key_pkey PRIMARY KEY,
+    key_id VARCHAR(64) NOT NULL,
+    kek_provider VARCHAR(50) NOT NULL,
+    kek_id VARCHAR(255) NOT NULL,
+    wrapped_key BYTEA NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    rotated_at TIMESTAMP WITH TIME ZONE NULL DEFAULT NULL
+);
+
+CREATE UNIQUE INDEX encryption_key_key_id_key ON encryption_key (key_id);
@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS banned_user (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    time DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires DATETIME NULL DEFAULT NULL,
+    revoked DATETIME NULL DEFAULT NULL,
+    username VARCHAR(100) NOT NULL,
+    source VARCHAR(20) NOT NULL,
+    reason TEXT NULL DEFAULT NULL
+);
+
+CREATE INDEX banned_user_username_idx ON banned_user (username, revoked, expires);
+
+CREATE TABLE IF NOT EXISTS banned_ip (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    time DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires DATETIME NULL DEFAULT NULL,
+    revoked DATETIME NULL DEFAULT NULL,
+    ip VARCHAR(39) NOT NULL,
+    source VARCHAR(20) NOT NULL,
+    reason TEXT NULL DEFAULT NULL
+);
+
+CREATE INDEX banned_ip_ip_idx ON banned_ip (ip, revoked, expires);
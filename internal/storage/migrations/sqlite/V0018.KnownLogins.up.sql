@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS known_login (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    first_seen DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_seen DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    username VARCHAR(100) NOT NULL,
+    remote_ip VARCHAR(39) NOT NULL,
+    user_agent_hash CHAR(64) NOT NULL
+);
+
+CREATE UNIQUE INDEX known_login_lookup_key ON known_login (username, remote_ip, user_agent_hash);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS recovery_codes (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    used_at DATETIME NULL DEFAULT NULL,
+    username VARCHAR(100) NOT NULL,
+    hash VARCHAR(512) NOT NULL
+);
+
+CREATE INDEX recovery_codes_lookup_key ON recovery_codes (username, used_at);
@@ -0,0 +1 @@
+ALTER TABLE webauthn_credentials DROP COLUMN model;
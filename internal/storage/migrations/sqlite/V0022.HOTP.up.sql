@@ -0,0 +1,2 @@
+ALTER TABLE totp_configurations ADD COLUMN type VARCHAR(10) NOT NULL DEFAULT 'totp';
+ALTER TABLE totp_configurations ADD COLUMN counter INTEGER NOT NULL DEFAULT 0;
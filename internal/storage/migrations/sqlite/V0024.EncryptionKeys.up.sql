@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS encryption_key (
+    id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+    key_id VARCHAR(64) NOT NULL,
+    kek_provider VARCHAR(50) NOT NULL,
+    kek_id VARCHAR(255) NOT NULL,
+    wrapped_key BLOB NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    rotated_at DATETIME NULL DEFAULT NULL
+);
+
+CREATE UNIQUE INDEX encryption_key_key_id_key ON encryption_key (key_id);
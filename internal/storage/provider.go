@@ -3,6 +3,7 @@ package storage
 import (
 	"context"
 	"database/sql"
+	"io"
 	"time"
 
 	"authelia.com/provider/oauth2/storage"
@@ -59,7 +60,7 @@ type Provider interface {
 
 	// UpdateTOTPConfigurationSignIn updates a registered TOTP configuration in the storage provider with the relevant
 	// sign in information.
-	UpdateTOTPConfigurationSignIn(ctx context.Context, id int, lastUsedAt sql.NullTime) (err error)
+	UpdateTOTPConfigurationSignIn(ctx context.Context, id int, lastUsedAt sql.NullTime, counter uint64) (err error)
 
 	// DeleteTOTPConfiguration delete a TOTP configuration from the storage provider given a username.
 	DeleteTOTPConfiguration(ctx context.Context, username string) (err error)
@@ -90,6 +91,9 @@ type Provider interface {
 	// LoadWebAuthnUser loads a registered WebAuthn user from the storage provider.
 	LoadWebAuthnUser(ctx context.Context, rpid, username string) (user *model.WebAuthnUser, err error)
 
+	// LoadWebAuthnUserByUserID loads a registered WebAuthn user from the storage provider using the opaque user id.
+	LoadWebAuthnUserByUserID(ctx context.Context, rpid, userID string) (user *model.WebAuthnUser, err error)
+
 	/*
 		Implementation for User WebAuthn Device Registrations.
 	*/
@@ -131,6 +135,60 @@ type Provider interface {
 	// LoadPreferredDuoDevice loads a Duo device from the storage provider for a given username.
 	LoadPreferredDuoDevice(ctx context.Context, username string) (device *model.DuoDevice, err error)
 
+	/*
+		Implementation for Recovery Codes.
+	*/
+
+	// SaveRecoveryCodes replaces all recovery codes for a given username in the storage provider.
+	SaveRecoveryCodes(ctx context.Context, username string, codes []model.RecoveryCode) (err error)
+
+	// LoadRecoveryCodes loads the unused recovery codes from the storage provider for a given username.
+	LoadRecoveryCodes(ctx context.Context, username string) (codes []model.RecoveryCode, err error)
+
+	// ConsumeRecoveryCode marks a recovery code as used in the storage provider, returning false if it was already
+	// used.
+	ConsumeRecoveryCode(ctx context.Context, id int, usedAt time.Time) (consumed bool, err error)
+
+	// DeleteRecoveryCodes deletes all recovery codes from the storage provider for a given username.
+	DeleteRecoveryCodes(ctx context.Context, username string) (err error)
+
+	/*
+		Implementation for Trusted Devices.
+	*/
+
+	// SaveTrustedDevice saves a trusted device to the storage provider after generating the signature of the secret.
+	SaveTrustedDevice(ctx context.Context, device model.TrustedDevice) (err error)
+
+	// LoadTrustedDevice loads a trusted device from the storage provider given a username, public ID, and secret. If the
+	// combination doesn't match a trusted device nil is returned without an error.
+	LoadTrustedDevice(ctx context.Context, username string, publicID uuid.UUID, secret []byte) (device *model.TrustedDevice, err error)
+
+	// LoadTrustedDevices loads the trusted devices for a user from the storage provider which have neither been revoked
+	// nor expired at the given time.
+	LoadTrustedDevices(ctx context.Context, username string, now time.Time) (devices []model.TrustedDevice, err error)
+
+	// UpdateTrustedDeviceSignIn updates the last used values of a trusted device in the storage provider.
+	UpdateTrustedDeviceSignIn(ctx context.Context, device model.TrustedDevice) (err error)
+
+	// RevokeTrustedDevice revokes a trusted device for a user in the storage provider given the public ID, returning
+	// false if there was no trusted device to revoke.
+	RevokeTrustedDevice(ctx context.Context, username string, publicID uuid.UUID, revokedAt time.Time) (revoked bool, err error)
+
+	// RevokeTrustedDevices revokes all trusted devices for a user in the storage provider.
+	RevokeTrustedDevices(ctx context.Context, username string, revokedAt time.Time) (err error)
+
+	/*
+		Implementation for Known Logins.
+	*/
+
+	// SaveKnownLogin saves a known login to the storage provider, updating the last seen time if the remote IP and user
+	// agent combination is already known for the user.
+	SaveKnownLogin(ctx context.Context, login model.KnownLogin) (err error)
+
+	// LoadKnownLoginSummary loads a summary of the known logins for a user from the storage provider which describes
+	// how many of them match the given remote IP and user agent hash.
+	LoadKnownLoginSummary(ctx context.Context, username string, ip model.IP, userAgentHash string) (summary *model.KnownLoginSummary, err error)
+
 	/*
 		Implementation for Identity Verification (JWT).
 	*/
@@ -168,6 +226,9 @@ type Provider interface {
 	// LoadOneTimeCode loads a one-time code from the storage provider given a username, intent, and code.
 	LoadOneTimeCode(ctx context.Context, username, intent, raw string) (code *model.OneTimeCode, err error)
 
+	// CountOneTimeCodes returns the number of one-time codes issued to a user with a specific intent since the given time.
+	CountOneTimeCodes(ctx context.Context, username, intent string, since time.Time) (count int, err error)
+
 	// LoadOneTimeCodeBySignature loads a one-time code from the storage provider given the signature.
 	// This method should NOT be used to validate a One-Time Code, LoadOneTimeCode should be used instead.
 	LoadOneTimeCodeBySignature(ctx context.Context, signature string) (code *model.OneTimeCode, err error)
@@ -209,6 +270,10 @@ type Provider interface {
 	// has been granted by the authorization endpoint.
 	SaveOAuth2ConsentSessionGranted(ctx context.Context, id int) (err error)
 
+	// LoadOAuth2ConsentSessionsAuthorizedCount returns the number of OAuth2.0 consent sessions in the storage provider
+	// which a subject has authorized for a given client.
+	LoadOAuth2ConsentSessionsAuthorizedCount(ctx context.Context, clientID string, subject uuid.UUID) (count int, err error)
+
 	// LoadOAuth2ConsentSessionByChallengeID returns an OAuth2.0 consent session in the storage provider given the
 	// challenge ID.
 	LoadOAuth2ConsentSessionByChallengeID(ctx context.Context, challengeID uuid.UUID) (consent *model.OAuth2ConsentSession, err error)
@@ -295,7 +360,22 @@ type Provider interface {
 	// SchemaEncryptionCheckKey checks the encryption key configured is valid for the storage provider.
 	SchemaEncryptionCheckKey(ctx context.Context, verbose bool) (result EncryptionValidationResult, err error)
 
+	// SchemaEncryptionRotateKEK wraps every data key with the current key-encryption key of the key management
+	// provider.
+	SchemaEncryptionRotateKEK(ctx context.Context) (result DataKeyRotationResult, err error)
+
+	// SchemaBackup writes a backup archive containing the data from every table in the storage provider to the writer.
+	SchemaBackup(ctx context.Context, w io.Writer) (result BackupResult, err error)
+
+	// SchemaRestore restores a backup archive from the reader to the storage provider.
+	SchemaRestore(ctx context.Context, r io.Reader, force bool) (result BackupResult, err error)
+
+	// SchemaTableRowCount returns the number of rows in a table of the storage provider.
+	SchemaTableRowCount(ctx context.Context, table string) (count int, err error)
+
 	RegulatorProvider
+	NotificationQueueProvider
+	RetentionProvider
 }
 
 // RegulatorProvider is an interface providing storage capabilities for persisting any kind of data related to the regulator.
@@ -303,6 +383,104 @@ type RegulatorProvider interface {
 	// AppendAuthenticationLog saves an authentication attempt to the storage provider.
 	AppendAuthenticationLog(ctx context.Context, attempt model.AuthenticationAttempt) (err error)
 
-	// LoadAuthenticationLogs loads authentication attempts from the storage provider (paginated).
+	// LoadAuthenticationLogs loads the authentication attempts of every authentication type which were not made while
+	// banned from the storage provider (paginated).
 	LoadAuthenticationLogs(ctx context.Context, username string, fromDate time.Time, limit, page int) (attempts []model.AuthenticationAttempt, err error)
+
+	// SaveRegulationLockout saves a regulation lockout to the storage provider.
+	SaveRegulationLockout(ctx context.Context, lockout model.RegulationLockout) (err error)
+
+	// LoadRegulationLockout loads the latest regulation lockout for a user from the storage provider regardless of if
+	// it has been unlocked or not.
+	LoadRegulationLockout(ctx context.Context, username string) (lockout *model.RegulationLockout, err error)
+
+	// UnlockRegulationLockout marks all active regulation lockouts for a user in the storage provider as unlocked.
+	UnlockRegulationLockout(ctx context.Context, username, method string, unlockedAt time.Time) (err error)
+
+	// LoadRegulationLockouts loads the active regulation lockouts from the storage provider (paginated).
+	LoadRegulationLockouts(ctx context.Context, limit, page int) (lockouts []model.RegulationLockout, err error)
+
+	// LoadAuthenticationLogsByUsername loads all authentication attempts for a user from the storage provider
+	// regardless of type or outcome (paginated).
+	LoadAuthenticationLogsByUsername(ctx context.Context, username string, fromDate time.Time, limit, page int) (attempts []model.AuthenticationAttempt, err error)
+
+	// LoadAuthenticationLogsByRemoteIP loads all authentication attempts for a remote IP from the storage provider
+	// regardless of type or outcome (paginated).
+	LoadAuthenticationLogsByRemoteIP(ctx context.Context, ip model.IP, fromDate time.Time, limit, page int) (attempts []model.AuthenticationAttempt, err error)
+
+	// LoadAuthenticationLogsRegulated loads the authentication attempts of every user with at least the given number
+	// of unsuccessful authentication attempts from the storage provider ordered by username (paginated). Attempts of
+	// every authentication type are included, however attempts made while banned and attempts which occurred before a
+	// ban of the user was revoked or a lockout of the user was unlocked are not included.
+	LoadAuthenticationLogsRegulated(ctx context.Context, fromDate time.Time, retries, limit, page int) (attempts []model.AuthenticationAttempt, err error)
+
+	// SaveBannedUser saves a banned user to the storage provider.
+	SaveBannedUser(ctx context.Context, ban model.BannedUser) (err error)
+
+	// LoadBannedUser loads the bans for a user from the storage provider which are either active at the given time or
+	// which have been revoked after the given since time.
+	LoadBannedUser(ctx context.Context, username string, now, since time.Time) (bans []model.BannedUser, err error)
+
+	// LoadBannedUsers loads the bans for all users from the storage provider which are active at the given time
+	// (paginated).
+	LoadBannedUsers(ctx context.Context, now time.Time, limit, page int) (bans []model.BannedUser, err error)
+
+	// RevokeBannedUser revokes all bans for a user in the storage provider which are active at the given time.
+	RevokeBannedUser(ctx context.Context, username string, revokedAt time.Time) (err error)
+
+	// SaveBannedIP saves a banned IP to the storage provider.
+	SaveBannedIP(ctx context.Context, ban model.BannedIP) (err error)
+
+	// LoadBannedIP loads the bans for an IP from the storage provider which are active at the given time.
+	LoadBannedIP(ctx context.Context, ip model.IP, now time.Time) (bans []model.BannedIP, err error)
+
+	// LoadBannedIPs loads the bans for all IPs from the storage provider which are active at the given time
+	// (paginated).
+	LoadBannedIPs(ctx context.Context, now time.Time, limit, page int) (bans []model.BannedIP, err error)
+
+	// RevokeBannedIP revokes all bans for an IP in the storage provider which are active at the given time.
+	RevokeBannedIP(ctx context.Context, ip model.IP, revokedAt time.Time) (err error)
+}
+
+// NotificationQueueProvider is an interface providing storage capabilities for persisting notifications which are
+// delivered asynchronously.
+type NotificationQueueProvider interface {
+	// SaveQueuedNotification saves a notification to the queue in the storage provider.
+	SaveQueuedNotification(ctx context.Context, notification model.QueuedNotification) (err error)
+
+	// LoadQueuedNotificationsDue loads the pending notifications from the storage provider which are due for delivery
+	// at the given time.
+	LoadQueuedNotificationsDue(ctx context.Context, now time.Time, limit int) (notifications []model.QueuedNotification, err error)
+
+	// LoadQueuedNotifications loads the notifications with the given status from the storage provider without the
+	// template data (paginated).
+	LoadQueuedNotifications(ctx context.Context, status string, limit, page int) (notifications []model.QueuedNotification, err error)
+
+	// ClaimQueuedNotification claims a pending notification in the storage provider for a delivery attempt.
+	ClaimQueuedNotification(ctx context.Context, id, attempts int, until time.Time) (claimed bool, err error)
+
+	// UpdateQueuedNotificationStatus updates the delivery status of a notification in the storage provider.
+	UpdateQueuedNotificationStatus(ctx context.Context, notification model.QueuedNotification) (err error)
+
+	// RetryQueuedNotification moves a dead notification in the storage provider back to the pending state.
+	RetryQueuedNotification(ctx context.Context, id int, now time.Time) (err error)
+
+	// RetryQueuedNotifications moves all dead notifications in the storage provider back to the pending state.
+	RetryQueuedNotifications(ctx context.Context, now time.Time) (affected int64, err error)
+
+	// PurgeQueuedNotifications deletes the notifications with the given status from the storage provider which were
+	// created before the given time.
+	PurgeQueuedNotifications(ctx context.Context, status string, before time.Time) (affected int64, err error)
+}
+
+// RetentionProvider is an interface providing storage capabilities for pruning data which has exceeded its retention
+// period.
+type RetentionProvider interface {
+	// CountPrunable returns the number of rows in the storage provider which have exceeded the retention period of the
+	// target at the given time.
+	CountPrunable(ctx context.Context, target PruneTarget, before time.Time) (count int64, err error)
+
+	// Prune deletes up to limit rows from the storage provider which have exceeded the retention period of the target
+	// at the given time.
+	Prune(ctx context.Context, target PruneTarget, before time.Time, limit int) (affected int64, err error)
 }
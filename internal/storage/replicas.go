@@ -0,0 +1,170 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+
+	"github.com/authelia/authelia/v4/internal/logging"
+)
+
+// ReplicatedProvider is implemented by storage providers which support read replicas.
+type ReplicatedProvider interface {
+	// Replicas returns the ReplicaSet of the provider or nil if no replicas are configured.
+	Replicas() *ReplicaSet
+}
+
+// NewReplicaSet opens a connection pool for each of the data source names. The replicas are considered unhealthy until
+// they have been checked.
+func NewReplicaSet(driverName string, addresses, dataSourceNames []string, interval, timeout time.Duration) (replicas *ReplicaSet, err error) {
+	replicas = &ReplicaSet{
+		interval: interval,
+		timeout:  timeout,
+		log:      logging.Logger().WithField("provider", "storage"),
+	}
+
+	var db *sqlx.DB
+
+	for i, dataSourceName := range dataSourceNames {
+		if db, err = sqlx.Open(driverName, dataSourceName); err != nil {
+			_ = replicas.Close()
+
+			return nil, err
+		}
+
+		replicas.replicas = append(replicas.replicas, &replica{address: addresses[i], db: db})
+	}
+
+	return replicas, nil
+}
+
+// ReplicaSet is a set of read replicas which are selected using round-robin load balancing. Only the replicas which
+// passed the most recent health check are selected.
+type ReplicaSet struct {
+	replicas []*replica
+	next     atomic.Uint64
+
+	interval time.Duration
+	timeout  time.Duration
+
+	log *logrus.Entry
+}
+
+type replica struct {
+	address string
+	db      *sqlx.DB
+	healthy atomic.Bool
+}
+
+// Run the health checks of the replicas at the configured interval until the context is done.
+func (r *ReplicaSet) Run(ctx context.Context) (err error) {
+	ticker := time.NewTicker(r.interval)
+
+	defer ticker.Stop()
+
+	for {
+		r.Check(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check the health of each replica and returns the number of healthy replicas.
+func (r *ReplicaSet) Check(ctx context.Context) (healthy int) {
+	for _, rep := range r.replicas {
+		if r.check(ctx, rep) {
+			healthy++
+		}
+	}
+
+	return healthy
+}
+
+// Close the connection pools of the replicas.
+func (r *ReplicaSet) Close() (err error) {
+	errs := make([]error, 0, len(r.replicas))
+
+	for _, rep := range r.replicas {
+		errs = append(errs, rep.db.Close())
+	}
+
+	return errors.Join(errs...)
+}
+
+func (r *ReplicaSet) check(ctx context.Context, rep *replica) bool {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+
+	defer cancel()
+
+	err := rep.db.PingContext(ctx)
+
+	switch healthy := err == nil; {
+	case healthy && !rep.healthy.Swap(true):
+		r.log.WithField("replica", rep.address).Info("Storage replica is healthy")
+	case !healthy && rep.healthy.Swap(false):
+		r.log.WithField("replica", rep.address).WithError(err).Warn("Storage replica is unhealthy and will not be used until it passes a health check")
+	}
+
+	return err == nil
+}
+
+// reader returns the next healthy replica, or nil if the primary must be used as the context is part of a
+// transaction or there are no healthy replicas.
+func (r *ReplicaSet) reader(ctx context.Context) *replica {
+	if r == nil || len(r.replicas) == 0 || ctx.Value(ctxKeyTransaction) != nil {
+		return nil
+	}
+
+	n := uint64(len(r.replicas))
+	start := r.next.Add(1)
+
+	for i := uint64(0); i < n; i++ {
+		if rep := r.replicas[(start+i)%n]; rep.healthy.Load() {
+			return rep
+		}
+	}
+
+	return nil
+}
+
+// failed handles an error returned by a replica. A replica which returns no rows may not have received the most recent
+// changes from the primary so it's not considered unhealthy, however any other error marks the replica as unhealthy
+// until the next successful health check.
+func (r *ReplicaSet) failed(ctx context.Context, rep *replica, err error) {
+	if errors.Is(err, sql.ErrNoRows) || ctx.Err() != nil {
+		return
+	}
+
+	if rep.healthy.Swap(false) {
+		r.log.WithField("replica", rep.address).WithError(err).Warn("Storage replica failed to perform a query and will not be used until it passes a health check")
+	}
+}
+
+// Replicas returns the ReplicaSet of the provider or nil if no replicas are configured.
+func (p *SQLProvider) Replicas() *ReplicaSet {
+	return p.replicas
+}
+
+// readGetContext performs a GetContext using a replica if one is available, falling back to the primary if the
+// replica returns an error. It must only be used for queries where stale results are safe, queries which are used to
+// make security decisions such as the revocation state of a session or the regulation of a user must use the primary.
+func (p *SQLProvider) readGetContext(ctx context.Context, dest any, query string, args ...any) (err error) {
+	if rep := p.replicas.reader(ctx); rep != nil {
+		if err = rep.db.GetContext(ctx, dest, query, args...); err == nil {
+			return nil
+		}
+
+		p.replicas.failed(ctx, rep, err)
+	}
+
+	return p.db.GetContext(ctx, dest, query, args...)
+}
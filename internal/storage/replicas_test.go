@@ -0,0 +1,188 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplicaSet(t *testing.T) {
+	dir := t.TempDir()
+
+	replicas, err := NewReplicaSet("sqlite3",
+		[]string{"replica1", "replica2", "replica3"},
+		[]string{filepath.Join(dir, "replica1.db"), filepath.Join(dir, "replica2.db"), filepath.Join(dir, "missing", "replica3.db")},
+		time.Second, time.Second,
+	)
+	require.NoError(t, err)
+
+	defer replicas.Close()
+
+	ctx := context.Background()
+
+	assert.Nil(t, replicas.reader(ctx))
+
+	assert.Equal(t, 2, replicas.Check(ctx))
+
+	seen := map[string]int{}
+
+	for i := 0; i < 6; i++ {
+		rep := replicas.reader(ctx)
+		require.NotNil(t, rep)
+
+		seen[rep.address]++
+	}
+
+	assert.Len(t, seen, 2)
+	assert.Contains(t, seen, "replica1")
+	assert.Contains(t, seen, "replica2")
+
+	assert.Nil(t, replicas.reader(context.WithValue(ctx, ctxKeyTransaction, &sql.Tx{})))
+
+	rep := replicas.reader(ctx)
+
+	replicas.failed(ctx, rep, sql.ErrNoRows)
+	assert.True(t, rep.healthy.Load())
+
+	replicas.failed(ctx, rep, errors.New("bad connection"))
+	assert.False(t, rep.healthy.Load())
+
+	for i := 0; i < 4; i++ {
+		assert.NotEqual(t, rep.address, replicas.reader(ctx).address)
+	}
+
+	assert.Equal(t, 2, replicas.Check(ctx))
+	assert.True(t, rep.healthy.Load())
+
+	var nilReplicas *ReplicaSet
+
+	assert.Nil(t, nilReplicas.reader(ctx))
+}
+
+func TestSQLProviderReadFallback(t *testing.T) {
+	dir := t.TempDir()
+
+	primary, err := sqlx.Open("sqlite3", filepath.Join(dir, "primary.db"))
+	require.NoError(t, err)
+
+	defer primary.Close()
+
+	replicas, err := NewReplicaSet("sqlite3", []string{"replica"}, []string{filepath.Join(dir, "replica.db")}, time.Second, time.Second)
+	require.NoError(t, err)
+
+	defer replicas.Close()
+
+	ctx := context.Background()
+
+	for db, value := range map[*sqlx.DB]string{primary: "primary", replicas.replicas[0].db: "replica"} {
+		_, err = db.ExecContext(ctx, "CREATE TABLE example (id INTEGER, value VARCHAR(10));")
+		require.NoError(t, err)
+
+		_, err = db.ExecContext(ctx, "INSERT INTO example (id, value) VALUES (1, ?);", value)
+		require.NoError(t, err)
+	}
+
+	_, err = primary.ExecContext(ctx, "INSERT INTO example (id, value) VALUES (2, 'primary');")
+	require.NoError(t, err)
+
+	_, err = primary.ExecContext(ctx, "CREATE TABLE primary_only AS SELECT value FROM example;")
+	require.NoError(t, err)
+
+	provider := &SQLProvider{db: primary, replicas: replicas}
+
+	var value string
+
+	require.NoError(t, provider.readGetContext(ctx, &value, "SELECT value FROM example WHERE id = 1;"))
+	assert.Equal(t, "primary", value)
+
+	require.Equal(t, 1, replicas.Check(ctx))
+
+	require.NoError(t, provider.readGetContext(ctx, &value, "SELECT value FROM example WHERE id = 1;"))
+	assert.Equal(t, "replica", value)
+
+	require.NoError(t, provider.readGetContext(ctx, &value, "SELECT value FROM example WHERE id = 2;"))
+	assert.Equal(t, "primary", value)
+	assert.True(t, replicas.replicas[0].healthy.Load())
+}
+
+func TestSQLProviderShouldUsePrimaryForConsistentReads(t *testing.T) {
+	dir := t.TempDir()
+
+	primary, err := sqlx.Open("sqlite3", filepath.Join(dir, "primary.db"))
+	require.NoError(t, err)
+
+	defer primary.Close()
+
+	replicas, err := NewReplicaSet("sqlite3", []string{"replica"}, []string{filepath.Join(dir, "replica.db")}, time.Second, time.Second)
+	require.NoError(t, err)
+
+	defer replicas.Close()
+
+	ctx := context.Background()
+
+	provider := &SQLProvider{
+		db:       primary,
+		replicas: replicas,
+		keys:     SQLProviderKeys{encryption: sha256.Sum256([]byte("a_not_so_secure_encryption_key"))},
+
+		sqlSelectOAuth2AuthorizeCodeSession:       "SELECT signature, active, revoked, session_data FROM oauth2_session WHERE signature = ?;",
+		sqlSelectAuthenticationAttemptsByUsername: "SELECT successful, username FROM authentication_log WHERE ? IS NOT NULL AND username = ? LIMIT ? OFFSET ?;",
+		sqlSelectWebAuthnCredentialsByUsername:    "SELECT username, description FROM webauthn_credential WHERE username = ?;",
+	}
+
+	data, err := provider.encrypt([]byte("{}"))
+	require.NoError(t, err)
+
+	// The replica represents a lagging copy of the primary where the session was not yet revoked, the authentication
+	// attempt differs, and the credential was not yet removed.
+	for db, revoked := range map[*sqlx.DB]bool{primary: true, replicas.replicas[0].db: false} {
+		_, err = db.ExecContext(ctx, "CREATE TABLE oauth2_session (signature VARCHAR(10), active BOOLEAN, revoked BOOLEAN, session_data BLOB);")
+		require.NoError(t, err)
+
+		_, err = db.ExecContext(ctx, "INSERT INTO oauth2_session (signature, active, revoked, session_data) VALUES ('abc', ?, ?, ?);", !revoked, revoked, data)
+		require.NoError(t, err)
+
+		_, err = db.ExecContext(ctx, "CREATE TABLE authentication_log (successful BOOLEAN, username VARCHAR(10));")
+		require.NoError(t, err)
+
+		_, err = db.ExecContext(ctx, "INSERT INTO authentication_log (successful, username) VALUES (?, 'john');", !revoked)
+		require.NoError(t, err)
+
+		_, err = db.ExecContext(ctx, "CREATE TABLE webauthn_credential (username VARCHAR(10), description VARCHAR(10));")
+		require.NoError(t, err)
+
+		if !revoked {
+			_, err = db.ExecContext(ctx, "INSERT INTO webauthn_credential (username, description) VALUES ('john', 'removed');")
+			require.NoError(t, err)
+		}
+	}
+
+	require.Equal(t, 1, replicas.Check(ctx))
+
+	session, err := provider.LoadOAuth2Session(ctx, OAuth2SessionTypeAuthorizeCode, "abc")
+	require.NoError(t, err)
+
+	assert.False(t, session.Active)
+	assert.True(t, session.Revoked)
+
+	attempts, err := provider.LoadAuthenticationLogs(ctx, "john", time.Unix(0, 0), 10, 0)
+	require.NoError(t, err)
+	require.Len(t, attempts, 1)
+
+	assert.False(t, attempts[0].Successful)
+
+	credentials, err := provider.LoadWebAuthnCredentialsByUsername(ctx, "", "john")
+	require.NoError(t, err)
+
+	assert.Len(t, credentials, 0)
+
+	assert.True(t, replicas.replicas[0].healthy.Load())
+}
@@ -0,0 +1,179 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/authelia/authelia/v4/internal/clock"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/logging"
+)
+
+// NewPruneTargets returns the tables which are pruned given the retention configuration. Data which is configured to
+// be retained indefinitely is not included.
+func NewPruneTargets(config *schema.StorageRetention) (targets []PruneTarget) {
+	for _, class := range []struct {
+		name      string
+		retention time.Duration
+		tables    []string
+		column    string
+	}{
+		{retentionAuthenticationLogs, config.AuthenticationLogs, []string{tableAuthenticationLogs}, "time"},
+		{retentionTOTPHistory, config.TOTPHistory, []string{tableTOTPHistory}, "created_at"},
+		{retentionIdentityVerification, config.IdentityVerification, []string{tableIdentityVerification}, "exp"},
+		{retentionOneTimeCode, config.OneTimeCode, []string{tableOneTimeCode}, "expires"},
+		{retentionOAuth2Sessions, config.OAuth2Sessions, tablesOAuth2Sessions, "requested_at"},
+		{retentionOAuth2BlacklistedJTI, config.OAuth2BlacklistedJTI, []string{tableOAuth2BlacklistedJTI}, "expires_at"},
+	} {
+		if class.retention <= 0 {
+			continue
+		}
+
+		for _, table := range class.tables {
+			targets = append(targets, PruneTarget{Name: class.name, Table: table, Column: class.column, Retention: class.retention})
+		}
+	}
+
+	return targets
+}
+
+// PruneTarget describes a table which is pruned and the column which determines when each row exceeds the retention
+// period.
+type PruneTarget struct {
+	Name      string
+	Table     string
+	Column    string
+	Retention time.Duration
+}
+
+func (t PruneTarget) validate() (err error) {
+	if !reTableName.MatchString(t.Table) || !reTableName.MatchString(t.Column) {
+		return fmt.Errorf("the prune target table '%s' or column '%s' is not valid", t.Table, t.Column)
+	}
+
+	return nil
+}
+
+// PruneResult is the result of pruning a PruneTarget.
+type PruneResult struct {
+	PruneTarget
+
+	Before time.Time
+	Rows   int64
+}
+
+// MetricsRecorder represents the methods used to record the storage metrics.
+type MetricsRecorder interface {
+	RecordStoragePrunedRows(table string, rows int64)
+}
+
+// NewRetentionJanitor creates a RetentionJanitor which prunes the data which has exceeded the configured retention
+// period from the storage provider.
+func NewRetentionJanitor(config *schema.StorageRetention, provider RetentionProvider, recorder MetricsRecorder) *RetentionJanitor {
+	return &RetentionJanitor{
+		config:   config,
+		provider: provider,
+		recorder: recorder,
+		clock:    clock.New(),
+		log:      logging.Logger().WithField("worker", "retention"),
+	}
+}
+
+// RetentionJanitor prunes the data which has exceeded the configured retention period from the storage provider in
+// batches, either periodically via Run or on demand via Prune.
+type RetentionJanitor struct {
+	config   *schema.StorageRetention
+	provider RetentionProvider
+	recorder MetricsRecorder
+	clock    clock.Provider
+	log      *logrus.Entry
+}
+
+// Run the janitor which prunes the data at the configured interval until the context is done.
+func (j *RetentionJanitor) Run(ctx context.Context) (err error) {
+	ticker := time.NewTicker(j.config.Interval)
+
+	defer ticker.Stop()
+
+	for {
+		results, err := j.Prune(ctx, false)
+
+		if err != nil && ctx.Err() == nil {
+			j.log.WithError(err).Error("Error occurred pruning the expired data from the storage")
+		}
+
+		for _, result := range results {
+			if result.Rows != 0 {
+				j.log.WithFields(map[string]any{"table": result.Table, "rows": result.Rows}).Debug("Pruned the expired data from the storage")
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Prune the data which has exceeded the configured retention period from the storage provider. If dryRun is true the
+// data is counted instead of deleted. An error pruning one table does not prevent the other tables being pruned.
+func (j *RetentionJanitor) Prune(ctx context.Context, dryRun bool) (results []PruneResult, err error) {
+	now := j.clock.Now()
+
+	var errs []error
+
+	for _, target := range NewPruneTargets(j.config) {
+		if ctx.Err() != nil {
+			errs = append(errs, ctx.Err())
+
+			break
+		}
+
+		result := PruneResult{PruneTarget: target, Before: now.Add(-target.Retention)}
+
+		var e error
+
+		if dryRun {
+			result.Rows, e = j.provider.CountPrunable(ctx, target, result.Before)
+		} else {
+			result.Rows, e = j.prune(ctx, target, result.Before)
+		}
+
+		if e != nil {
+			errs = append(errs, e)
+		}
+
+		results = append(results, result)
+	}
+
+	return results, errors.Join(errs...)
+}
+
+func (j *RetentionJanitor) prune(ctx context.Context, target PruneTarget, before time.Time) (rows int64, err error) {
+	var affected int64
+
+	for {
+		if err = ctx.Err(); err != nil {
+			return rows, err
+		}
+
+		if affected, err = j.provider.Prune(ctx, target, before, j.config.BatchSize); err != nil {
+			return rows, err
+		}
+
+		rows += affected
+
+		if j.recorder != nil && affected > 0 {
+			j.recorder.RecordStoragePrunedRows(target.Table, affected)
+		}
+
+		if affected < int64(j.config.BatchSize) {
+			return rows, nil
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/clock"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/logging"
+)
+
+func TestNewPruneTargets(t *testing.T) {
+	config := schema.DefaultStorageRetentionConfiguration
+
+	targets := NewPruneTargets(&config)
+
+	require.Len(t, targets, 11)
+
+	assert.Equal(t, PruneTarget{Name: retentionAuthenticationLogs, Table: tableAuthenticationLogs, Column: "time", Retention: time.Hour * 24 * 90}, targets[0])
+	assert.Equal(t, PruneTarget{Name: retentionOAuth2BlacklistedJTI, Table: tableOAuth2BlacklistedJTI, Column: "expires_at", Retention: time.Hour * 24}, targets[10])
+
+	for _, target := range targets {
+		assert.NoError(t, target.validate())
+	}
+
+	config.AuthenticationLogs = -1
+	config.OAuth2Sessions = -1
+
+	targets = NewPruneTargets(&config)
+
+	require.Len(t, targets, 4)
+
+	for _, target := range targets {
+		assert.NotEqual(t, retentionAuthenticationLogs, target.Name)
+		assert.NotEqual(t, retentionOAuth2Sessions, target.Name)
+	}
+}
+
+func TestPruneTargetValidate(t *testing.T) {
+	assert.EqualError(t, PruneTarget{Table: "users; DROP TABLE users", Column: "time"}.validate(), "the prune target table 'users; DROP TABLE users' or column 'time' is not valid")
+	assert.EqualError(t, PruneTarget{Table: tableAuthenticationLogs, Column: ""}.validate(), "the prune target table 'authentication_logs' or column '' is not valid")
+}
+
+func TestRetentionJanitorPrune(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	config := &schema.StorageRetention{
+		BatchSize:          10,
+		AuthenticationLogs: time.Hour,
+		TOTPHistory:        time.Minute,
+	}
+
+	provider := &testRetentionProvider{
+		rows: map[string]int64{
+			tableAuthenticationLogs: 25,
+			tableTOTPHistory:        3,
+		},
+	}
+
+	recorder := &testRetentionRecorder{rows: map[string]int64{}}
+
+	janitor := NewRetentionJanitor(config, provider, recorder)
+	janitor.clock = clock.NewFixed(now)
+
+	results, err := janitor.Prune(context.Background(), true)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	assert.Equal(t, int64(25), results[0].Rows)
+	assert.Equal(t, now.Add(-time.Hour), results[0].Before)
+	assert.Equal(t, int64(3), results[1].Rows)
+	assert.Equal(t, now.Add(-time.Minute), results[1].Before)
+	assert.Equal(t, 0, provider.calls)
+	assert.Len(t, recorder.rows, 0)
+
+	results, err = janitor.Prune(context.Background(), false)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	assert.Equal(t, int64(25), results[0].Rows)
+	assert.Equal(t, int64(3), results[1].Rows)
+	assert.Equal(t, 4, provider.calls)
+	assert.Equal(t, map[string]int64{tableAuthenticationLogs: 25, tableTOTPHistory: 3}, recorder.rows)
+	assert.Equal(t, int64(0), provider.rows[tableAuthenticationLogs])
+	assert.Equal(t, int64(0), provider.rows[tableTOTPHistory])
+}
+
+func TestRetentionJanitorPruneShouldContinueOnError(t *testing.T) {
+	config := &schema.StorageRetention{
+		BatchSize:          10,
+		AuthenticationLogs: time.Hour,
+		TOTPHistory:        time.Minute,
+	}
+
+	provider := &testRetentionProvider{
+		rows: map[string]int64{
+			tableTOTPHistory: 3,
+		},
+		err: map[string]error{
+			tableAuthenticationLogs: errors.New("bad connection"),
+		},
+	}
+
+	janitor := NewRetentionJanitor(config, provider, nil)
+
+	results, err := janitor.Prune(context.Background(), false)
+	assert.EqualError(t, err, "bad connection")
+	require.Len(t, results, 2)
+	assert.Equal(t, int64(0), results[0].Rows)
+	assert.Equal(t, int64(3), results[1].Rows)
+}
+
+func TestRetentionJanitorRun(t *testing.T) {
+	config := &schema.StorageRetention{
+		Interval:           time.Hour,
+		BatchSize:          10,
+		AuthenticationLogs: time.Hour,
+	}
+
+	provider := &testRetentionProvider{
+		rows: map[string]int64{
+			tableAuthenticationLogs: 5,
+		},
+	}
+
+	janitor := NewRetentionJanitor(config, provider, nil)
+	janitor.log = logging.Logger().WithField("test", t.Name())
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	cancel()
+
+	assert.NoError(t, janitor.Run(ctx))
+	assert.Equal(t, int64(5), provider.rows[tableAuthenticationLogs])
+	assert.Equal(t, 0, provider.calls)
+}
+
+type testRetentionProvider struct {
+	rows  map[string]int64
+	err   map[string]error
+	calls int
+}
+
+func (p *testRetentionProvider) CountPrunable(_ context.Context, target PruneTarget, _ time.Time) (count int64, err error) {
+	if err = p.err[target.Table]; err != nil {
+		return 0, err
+	}
+
+	return p.rows[target.Table], nil
+}
+
+func (p *testRetentionProvider) Prune(_ context.Context, target PruneTarget, _ time.Time, limit int) (affected int64, err error) {
+	p.calls++
+
+	if err = p.err[target.Table]; err != nil {
+		return 0, err
+	}
+
+	affected = min(p.rows[target.Table], int64(limit))
+
+	p.rows[target.Table] -= affected
+
+	return affected, nil
+}
+
+type testRetentionRecorder struct {
+	rows map[string]int64
+}
+
+func (r *testRetentionRecorder) RecordStoragePrunedRows(table string, rows int64) {
+	r.rows[table] += rows
+}
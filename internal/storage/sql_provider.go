@@ -15,6 +15,7 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/kms"
 	"github.com/authelia/authelia/v4/internal/logging"
 	"github.com/authelia/authelia/v4/internal/model"
 )
@@ -37,7 +38,25 @@ func NewSQLProvider(config *schema.Configuration, name, driverName, dataSourceNa
 		log: logging.Logger(),
 
 		sqlInsertAuthenticationAttempt:            fmt.Sprintf(queryFmtInsertAuthenticationLogEntry, tableAuthenticationLogs),
-		sqlSelectAuthenticationAttemptsByUsername: fmt.Sprintf(queryFmtSelect1FAAuthenticationLogEntryByUsername, tableAuthenticationLogs),
+		sqlSelectAuthenticationAttemptsByUsername: fmt.Sprintf(queryFmtSelectRegulationAuthenticationLogEntriesByUsername, tableAuthenticationLogs),
+
+		sqlSelectAuthenticationLogsByUsername: fmt.Sprintf(queryFmtSelectAuthenticationLogEntriesByUsername, tableAuthenticationLogs),
+		sqlSelectAuthenticationLogsByRemoteIP: fmt.Sprintf(queryFmtSelectAuthenticationLogEntriesByRemoteIP, tableAuthenticationLogs),
+		sqlSelectAuthenticationLogsRegulated:  fmt.Sprintf(queryFmtSelectRegulationAuthenticationLogEntries, tableAuthenticationLogs, tableAuthenticationLogs, tableBannedUser, tableRegulationLockout),
+
+		sqlInsertRegulationLockout:                 fmt.Sprintf(queryFmtInsertRegulationLockout, tableRegulationLockout),
+		sqlSelectLatestRegulationLockoutByUsername: fmt.Sprintf(queryFmtSelectLatestRegulationLockoutByUsername, tableRegulationLockout),
+		sqlUpdateRegulationLockoutUnlockByUsername: fmt.Sprintf(queryFmtUpdateRegulationLockoutUnlockByUsername, tableRegulationLockout),
+		sqlSelectActiveRegulationLockouts:          fmt.Sprintf(queryFmtSelectActiveRegulationLockouts, tableRegulationLockout),
+
+		sqlInsertBannedUser:           fmt.Sprintf(queryFmtInsertBannedUser, tableBannedUser),
+		sqlSelectBannedUserByUsername: fmt.Sprintf(queryFmtSelectBannedUserByUsername, tableBannedUser),
+		sqlSelectActiveBannedUsers:    fmt.Sprintf(queryFmtSelectActiveBannedUsers, tableBannedUser),
+		sqlRevokeBannedUserByUsername: fmt.Sprintf(queryFmtRevokeBannedUserByUsername, tableBannedUser),
+		sqlInsertBannedIP:             fmt.Sprintf(queryFmtInsertBannedIP, tableBannedIP),
+		sqlSelectActiveBannedIPByIP:   fmt.Sprintf(queryFmtSelectActiveBannedIPByIP, tableBannedIP),
+		sqlSelectActiveBannedIPs:      fmt.Sprintf(queryFmtSelectActiveBannedIPs, tableBannedIP),
+		sqlRevokeBannedIPByIP:         fmt.Sprintf(queryFmtRevokeBannedIPByIP, tableBannedIP),
 
 		sqlInsertIdentityVerification:  fmt.Sprintf(queryFmtInsertIdentityVerification, tableIdentityVerification),
 		sqlConsumeIdentityVerification: fmt.Sprintf(queryFmtConsumeIdentityVerification, tableIdentityVerification),
@@ -51,6 +70,7 @@ func NewSQLProvider(config *schema.Configuration, name, driverName, dataSourceNa
 		sqlSelectOneTimeCodeBySignature: fmt.Sprintf(queryFmtSelectOTCBySignature, tableOneTimeCode),
 		sqlSelectOneTimeCodeByID:        fmt.Sprintf(queryFmtSelectOTCByID, tableOneTimeCode),
 		sqlSelectOneTimeCodeByPublicID:  fmt.Sprintf(queryFmtSelectOTCByPublicID, tableOneTimeCode),
+		sqlSelectOneTimeCodeCount:       fmt.Sprintf(queryFmtSelectOTCCountByUsernameAndIntent, tableOneTimeCode),
 
 		sqlUpsertTOTPConfig:  fmt.Sprintf(queryFmtUpsertTOTPConfiguration, tableTOTPConfigurations),
 		sqlDeleteTOTPConfig:  fmt.Sprintf(queryFmtDeleteTOTPConfiguration, tableTOTPConfigurations),
@@ -66,6 +86,8 @@ func NewSQLProvider(config *schema.Configuration, name, driverName, dataSourceNa
 		sqlInsertWebAuthnUser: fmt.Sprintf(queryFmtInsertWebAuthnUser, tableWebAuthnUsers),
 		sqlSelectWebAuthnUser: fmt.Sprintf(queryFmtSelectWebAuthnUser, tableWebAuthnUsers),
 
+		sqlSelectWebAuthnUserByUserID: fmt.Sprintf(queryFmtSelectWebAuthnUserByUserID, tableWebAuthnUsers),
+
 		sqlInsertWebAuthnCredential:                           fmt.Sprintf(queryFmtInsertWebAuthnCredential, tableWebAuthnCredentials),
 		sqlSelectWebAuthnCredentials:                          fmt.Sprintf(queryFmtSelectWebAuthnCredentials, tableWebAuthnCredentials),
 		sqlSelectWebAuthnCredentialsByUsername:                fmt.Sprintf(queryFmtSelectWebAuthnCredentialsByUsername, tableWebAuthnCredentials),
@@ -81,9 +103,33 @@ func NewSQLProvider(config *schema.Configuration, name, driverName, dataSourceNa
 		sqlDeleteDuoDevice: fmt.Sprintf(queryFmtDeleteDuoDevice, tableDuoDevices),
 		sqlSelectDuoDevice: fmt.Sprintf(queryFmtSelectDuoDevice, tableDuoDevices),
 
+		sqlUpsertKnownLogin:        fmt.Sprintf(queryFmtUpsertKnownLogin, tableKnownLogin),
+		sqlSelectKnownLoginSummary: fmt.Sprintf(queryFmtSelectKnownLoginSummary, tableKnownLogin),
+
+		sqlInsertRecoveryCode:  fmt.Sprintf(queryFmtInsertRecoveryCode, tableRecoveryCodes),
+		sqlSelectRecoveryCodes: fmt.Sprintf(queryFmtSelectRecoveryCodes, tableRecoveryCodes),
+		sqlConsumeRecoveryCode: fmt.Sprintf(queryFmtConsumeRecoveryCode, tableRecoveryCodes),
+		sqlDeleteRecoveryCodes: fmt.Sprintf(queryFmtDeleteRecoveryCodes, tableRecoveryCodes),
+
+		sqlInsertTrustedDevice:       fmt.Sprintf(queryFmtInsertTrustedDevice, tableTrustedDevice),
+		sqlSelectTrustedDevice:       fmt.Sprintf(queryFmtSelectTrustedDevice, tableTrustedDevice),
+		sqlSelectTrustedDevices:      fmt.Sprintf(queryFmtSelectTrustedDevices, tableTrustedDevice),
+		sqlUpdateTrustedDeviceSignIn: fmt.Sprintf(queryFmtUpdateTrustedDeviceSignIn, tableTrustedDevice),
+		sqlRevokeTrustedDevice:       fmt.Sprintf(queryFmtRevokeTrustedDevice, tableTrustedDevice),
+		sqlRevokeTrustedDevices:      fmt.Sprintf(queryFmtRevokeTrustedDevices, tableTrustedDevice),
+
+		sqlInsertQueuedNotification:          fmt.Sprintf(queryFmtInsertQueuedNotification, tableNotificationQueue),
+		sqlSelectQueuedNotificationsDue:      fmt.Sprintf(queryFmtSelectQueuedNotificationsDue, tableNotificationQueue),
+		sqlSelectQueuedNotificationsByStatus: fmt.Sprintf(queryFmtSelectQueuedNotificationsByStatus, tableNotificationQueue),
+		sqlClaimQueuedNotification:           fmt.Sprintf(queryFmtClaimQueuedNotification, tableNotificationQueue),
+		sqlUpdateQueuedNotificationStatus:    fmt.Sprintf(queryFmtUpdateQueuedNotificationStatus, tableNotificationQueue),
+		sqlRetryQueuedNotification:           fmt.Sprintf(queryFmtRetryQueuedNotification, tableNotificationQueue),
+		sqlRetryQueuedNotifications:          fmt.Sprintf(queryFmtRetryQueuedNotifications, tableNotificationQueue),
+		sqlDeleteQueuedNotifications:         fmt.Sprintf(queryFmtDeleteQueuedNotifications, tableNotificationQueue),
+
 		sqlUpsertPreferred2FAMethod: fmt.Sprintf(queryFmtUpsertPreferred2FAMethod, tableUserPreferences),
 		sqlSelectPreferred2FAMethod: fmt.Sprintf(queryFmtSelectPreferred2FAMethod, tableUserPreferences),
-		sqlSelectUserInfo:           fmt.Sprintf(queryFmtSelectUserInfo, tableTOTPConfigurations, tableWebAuthnCredentials, tableDuoDevices, tableUserPreferences),
+		sqlSelectUserInfo:           fmt.Sprintf(queryFmtSelectUserInfo, tableTOTPConfigurations, tableWebAuthnCredentials, tableDuoDevices, tableRecoveryCodes, tableUserPreferences),
 
 		sqlInsertUserOpaqueIdentifier:            fmt.Sprintf(queryFmtInsertUserOpaqueIdentifier, tableUserOpaqueIdentifier),
 		sqlSelectUserOpaqueIdentifier:            fmt.Sprintf(queryFmtSelectUserOpaqueIdentifier, tableUserOpaqueIdentifier),
@@ -105,6 +151,7 @@ func NewSQLProvider(config *schema.Configuration, name, driverName, dataSourceNa
 		sqlUpdateOAuth2ConsentSessionSubject:       fmt.Sprintf(queryFmtUpdateOAuth2ConsentSessionSubject, tableOAuth2ConsentSession),
 		sqlUpdateOAuth2ConsentSessionResponse:      fmt.Sprintf(queryFmtUpdateOAuth2ConsentSessionResponse, tableOAuth2ConsentSession),
 		sqlUpdateOAuth2ConsentSessionGranted:       fmt.Sprintf(queryFmtUpdateOAuth2ConsentSessionGranted, tableOAuth2ConsentSession),
+		sqlSelectOAuth2ConsentSessionsAuthorized:   fmt.Sprintf(queryFmtSelectOAuth2ConsentSessionsAuthorizedCount, tableOAuth2ConsentSession),
 		sqlSelectOAuth2ConsentSessionByChallengeID: fmt.Sprintf(queryFmtSelectOAuth2ConsentSessionByChallengeID, tableOAuth2ConsentSession),
 
 		sqlInsertOAuth2AccessTokenSession:                fmt.Sprintf(queryFmtInsertOAuth2Session, tableOAuth2AccessTokenSession),
@@ -152,6 +199,8 @@ func NewSQLProvider(config *schema.Configuration, name, driverName, dataSourceNa
 		sqlFmtRenameTable: queryFmtRenameTable,
 	}
 
+	provider.kms, provider.errKMS = kms.NewProvider(&config.Storage.KeyManagement)
+
 	return provider
 }
 
@@ -164,8 +213,11 @@ type SQLProvider struct {
 	schema     string
 	config     *schema.Configuration
 	errOpen    error
+	errKMS     error
 
-	keys SQLProviderKeys
+	keys     SQLProviderKeys
+	kms      kms.Provider
+	replicas *ReplicaSet
 
 	log *logrus.Logger
 
@@ -173,6 +225,28 @@ type SQLProvider struct {
 	sqlInsertAuthenticationAttempt            string
 	sqlSelectAuthenticationAttemptsByUsername string
 
+	sqlSelectAuthenticationLogsByUsername string
+	sqlSelectAuthenticationLogsByRemoteIP string
+	sqlSelectAuthenticationLogsRegulated  string
+
+	// Table: regulation_lockout.
+	sqlInsertRegulationLockout                 string
+	sqlSelectLatestRegulationLockoutByUsername string
+	sqlUpdateRegulationLockoutUnlockByUsername string
+	sqlSelectActiveRegulationLockouts          string
+
+	// Table: banned_user.
+	sqlInsertBannedUser           string
+	sqlSelectBannedUserByUsername string
+	sqlSelectActiveBannedUsers    string
+	sqlRevokeBannedUserByUsername string
+
+	// Table: banned_ip.
+	sqlInsertBannedIP           string
+	sqlSelectActiveBannedIPByIP string
+	sqlSelectActiveBannedIPs    string
+	sqlRevokeBannedIPByIP       string
+
 	// Table: identity_verification.
 	sqlInsertIdentityVerification  string
 	sqlConsumeIdentityVerification string
@@ -187,6 +261,7 @@ type SQLProvider struct {
 	sqlSelectOneTimeCodeBySignature string
 	sqlSelectOneTimeCodeByID        string
 	sqlSelectOneTimeCodeByPublicID  string
+	sqlSelectOneTimeCodeCount       string
 
 	// Table: totp_configurations.
 	sqlUpsertTOTPConfig  string
@@ -205,6 +280,8 @@ type SQLProvider struct {
 	sqlInsertWebAuthnUser string
 	sqlSelectWebAuthnUser string
 
+	sqlSelectWebAuthnUserByUserID string
+
 	// Table: webauthn_credentials.
 	sqlInsertWebAuthnCredential                  string
 	sqlSelectWebAuthnCredentials                 string
@@ -224,6 +301,34 @@ type SQLProvider struct {
 	sqlDeleteDuoDevice string
 	sqlSelectDuoDevice string
 
+	// Table: known_login.
+	sqlUpsertKnownLogin        string
+	sqlSelectKnownLoginSummary string
+
+	// Table: recovery_codes.
+	sqlInsertRecoveryCode  string
+	sqlSelectRecoveryCodes string
+	sqlConsumeRecoveryCode string
+	sqlDeleteRecoveryCodes string
+
+	// Table: trusted_device.
+	sqlInsertTrustedDevice       string
+	sqlSelectTrustedDevice       string
+	sqlSelectTrustedDevices      string
+	sqlUpdateTrustedDeviceSignIn string
+	sqlRevokeTrustedDevice       string
+	sqlRevokeTrustedDevices      string
+
+	// Table: notification_queue.
+	sqlInsertQueuedNotification          string
+	sqlSelectQueuedNotificationsDue      string
+	sqlSelectQueuedNotificationsByStatus string
+	sqlClaimQueuedNotification           string
+	sqlUpdateQueuedNotificationStatus    string
+	sqlRetryQueuedNotification           string
+	sqlRetryQueuedNotifications          string
+	sqlDeleteQueuedNotifications         string
+
 	// Table: user_preferences.
 	sqlUpsertPreferred2FAMethod string
 	sqlSelectPreferred2FAMethod string
@@ -253,6 +358,7 @@ type SQLProvider struct {
 	sqlUpdateOAuth2ConsentSessionSubject       string
 	sqlUpdateOAuth2ConsentSessionResponse      string
 	sqlUpdateOAuth2ConsentSessionGranted       string
+	sqlSelectOAuth2ConsentSessionsAuthorized   string
 	sqlSelectOAuth2ConsentSessionByChallengeID string
 
 	// Table: oauth2_authorization_code_session.
@@ -307,11 +413,15 @@ type SQLProvider struct {
 	// Utility.
 	sqlSelectExistingTables string
 	sqlFmtRenameTable       string
+	sqlFmtResetSequence     string
 }
 
-// SQLProviderKeys are the cryptography keys used by a SQLProvider.
+// SQLProviderKeys are the cryptography keys used by a SQLProvider. The data keys are only present when key management
+// is configured, in which case the current data key is used for encryption instead of the encryption key.
 type SQLProviderKeys struct {
 	encryption [32]byte
+	data       map[string]*[32]byte
+	current    string
 	otcHMAC    []byte
 	otpHMAC    []byte
 }
@@ -322,6 +432,10 @@ func (p *SQLProvider) StartupCheck() (err error) {
 		return fmt.Errorf("error opening database: %w", p.errOpen)
 	}
 
+	if p.errKMS != nil {
+		return fmt.Errorf("error initializing the key management provider: %w", p.errKMS)
+	}
+
 	// TODO: Decide if this is needed, or if it should be configurable.
 	for i := 0; i < 19; i++ {
 		if err = p.db.Ping(); err == nil {
@@ -358,6 +472,10 @@ func (p *SQLProvider) StartupCheck() (err error) {
 		return fmt.Errorf("error during schema migrate: %w", err)
 	}
 
+	if err = p.initDataKeys(ctx); err != nil {
+		return fmt.Errorf("failed to initialize the data key during startup: %w", err)
+	}
+
 	if p.keys.otcHMAC, err = p.getHMACOneTimeCode(ctx); err != nil {
 		return fmt.Errorf("failed to initialize the hmac one-time code signature key during startup: %w", err)
 	}
@@ -366,6 +484,10 @@ func (p *SQLProvider) StartupCheck() (err error) {
 		return fmt.Errorf("failed to initialize the hmac one-time password signature key during startup: %w", err)
 	}
 
+	if p.replicas != nil {
+		p.log.Infof("Storage has %d of %d read replicas available", p.replicas.Check(ctx), len(p.replicas.replicas))
+	}
+
 	return nil
 }
 
@@ -404,6 +526,10 @@ func (p *SQLProvider) Rollback(ctx context.Context) (err error) {
 
 // Close the underlying storage provider.
 func (p *SQLProvider) Close() (err error) {
+	if p.replicas != nil {
+		return errors.Join(p.db.Close(), p.replicas.Close())
+	}
+
 	return p.db.Close()
 }
 
@@ -432,7 +558,7 @@ func (p *SQLProvider) LoadPreferred2FAMethod(ctx context.Context, username strin
 
 // LoadUserInfo loads the model.UserInfo from the storage provider.
 func (p *SQLProvider) LoadUserInfo(ctx context.Context, username string) (info model.UserInfo, err error) {
-	err = p.db.GetContext(ctx, &info, p.sqlSelectUserInfo, username, username, username, username)
+	err = p.readGetContext(ctx, &info, p.sqlSelectUserInfo, username, username, username, username, username)
 
 	switch {
 	case err == nil, errors.Is(err, sql.ErrNoRows):
@@ -514,8 +640,8 @@ func (p *SQLProvider) SaveTOTPConfiguration(ctx context.Context, config model.TO
 
 	if _, err = p.db.ExecContext(ctx, p.sqlUpsertTOTPConfig,
 		config.CreatedAt, config.LastUsedAt,
-		config.Username, config.Issuer,
-		config.Algorithm, config.Digits, config.Period, config.Secret); err != nil {
+		config.Username, config.Issuer, config.Type,
+		config.Algorithm, config.Digits, config.Period, config.Counter, config.Secret); err != nil {
 		return fmt.Errorf("error upserting TOTP configuration for user '%s': %w", config.Username, err)
 	}
 
@@ -523,8 +649,8 @@ func (p *SQLProvider) SaveTOTPConfiguration(ctx context.Context, config model.TO
 }
 
 // UpdateTOTPConfigurationSignIn updates a registered TOTP configuration in the storage provider with the relevant sign in information.
-func (p *SQLProvider) UpdateTOTPConfigurationSignIn(ctx context.Context, id int, lastUsedAt sql.NullTime) (err error) {
-	if _, err = p.db.ExecContext(ctx, p.sqlUpdateTOTPConfigRecordSignIn, lastUsedAt, id); err != nil {
+func (p *SQLProvider) UpdateTOTPConfigurationSignIn(ctx context.Context, id int, lastUsedAt sql.NullTime, counter uint64) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlUpdateTOTPConfigRecordSignIn, lastUsedAt, counter, id); err != nil {
 		return fmt.Errorf("error updating TOTP configuration id %d: %w", id, err)
 	}
 
@@ -629,6 +755,23 @@ func (p *SQLProvider) LoadWebAuthnUser(ctx context.Context, rpid, username strin
 	return user, nil
 }
 
+// LoadWebAuthnUserByUserID loads a registered WebAuthn user from the storage provider using the opaque user id which
+// is used as the user handle for discoverable credentials.
+func (p *SQLProvider) LoadWebAuthnUserByUserID(ctx context.Context, rpid, userID string) (user *model.WebAuthnUser, err error) {
+	user = &model.WebAuthnUser{}
+
+	if err = p.db.GetContext(ctx, user, p.sqlSelectWebAuthnUserByUserID, rpid, userID); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrNoWebAuthnUser
+		default:
+			return nil, fmt.Errorf("error selecting WebAuthn user with relying party id '%s' by user id: %w", rpid, err)
+		}
+	}
+
+	return user, nil
+}
+
 // SaveWebAuthnCredential saves a registered WebAuthn credential to the storage provider.
 func (p *SQLProvider) SaveWebAuthnCredential(ctx context.Context, credential model.WebAuthnCredential) (err error) {
 	if credential.PublicKey, err = p.encrypt(credential.PublicKey); err != nil {
@@ -637,7 +780,7 @@ func (p *SQLProvider) SaveWebAuthnCredential(ctx context.Context, credential mod
 
 	if _, err = p.db.ExecContext(ctx, p.sqlInsertWebAuthnCredential,
 		credential.CreatedAt, credential.LastUsedAt, credential.RPID, credential.Username, credential.Description,
-		credential.KID, credential.AAGUID, credential.AttestationType, credential.Attachment, credential.Transport,
+		credential.KID, credential.AAGUID, credential.AttestationType, credential.Model, credential.Attachment, credential.Transport,
 		credential.SignCount, credential.CloneWarning, credential.Discoverable, credential.Present, credential.Verified,
 		credential.BackupEligible, credential.BackupState, credential.PublicKey,
 	); err != nil {
@@ -795,6 +938,178 @@ func (p *SQLProvider) LoadPreferredDuoDevice(ctx context.Context, username strin
 	return device, nil
 }
 
+// SaveRecoveryCodes replaces all recovery codes for a given username in the storage provider using a transaction.
+func (p *SQLProvider) SaveRecoveryCodes(ctx context.Context, username string, codes []model.RecoveryCode) (err error) {
+	tx, err := p.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("error beginning transaction to save recovery codes for user '%s': %w", username, err)
+	}
+
+	if _, err = tx.ExecContext(ctx, p.sqlDeleteRecoveryCodes, username); err != nil {
+		return p.rollbackRecoveryCodes(tx, fmt.Errorf("error deleting recovery codes for user '%s': %w", username, err))
+	}
+
+	for _, code := range codes {
+		if _, err = tx.ExecContext(ctx, p.sqlInsertRecoveryCode, code.CreatedAt, username, code.Hash); err != nil {
+			return p.rollbackRecoveryCodes(tx, fmt.Errorf("error inserting recovery code for user '%s': %w", username, err))
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("error committing recovery codes for user '%s': %w", username, err)
+	}
+
+	return nil
+}
+
+func (p *SQLProvider) rollbackRecoveryCodes(tx *sqlx.Tx, err error) error {
+	if rerr := tx.Rollback(); rerr != nil {
+		return fmt.Errorf("rollback error %v: rollback due to error: %w", rerr, err)
+	}
+
+	return fmt.Errorf("rollback due to error: %w", err)
+}
+
+// LoadRecoveryCodes loads the unused recovery codes from the storage provider for a given username.
+func (p *SQLProvider) LoadRecoveryCodes(ctx context.Context, username string) (codes []model.RecoveryCode, err error) {
+	codes = []model.RecoveryCode{}
+
+	if err = p.db.SelectContext(ctx, &codes, p.sqlSelectRecoveryCodes, username); err != nil {
+		return nil, fmt.Errorf("error selecting recovery codes for user '%s': %w", username, err)
+	}
+
+	return codes, nil
+}
+
+// ConsumeRecoveryCode marks a recovery code as used in the storage provider, returning false if it was already used.
+func (p *SQLProvider) ConsumeRecoveryCode(ctx context.Context, id int, usedAt time.Time) (consumed bool, err error) {
+	var (
+		result   sql.Result
+		affected int64
+	)
+
+	if result, err = p.db.ExecContext(ctx, p.sqlConsumeRecoveryCode, usedAt, id); err != nil {
+		return false, fmt.Errorf("error updating recovery code with id '%d' as used: %w", id, err)
+	}
+
+	if affected, err = result.RowsAffected(); err != nil {
+		return false, fmt.Errorf("error determining the rows affected updating recovery code with id '%d' as used: %w", id, err)
+	}
+
+	return affected == 1, nil
+}
+
+// DeleteRecoveryCodes deletes all recovery codes from the storage provider for a given username.
+func (p *SQLProvider) DeleteRecoveryCodes(ctx context.Context, username string) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlDeleteRecoveryCodes, username); err != nil {
+		return fmt.Errorf("error deleting recovery codes for user '%s': %w", username, err)
+	}
+
+	return nil
+}
+
+// SaveTrustedDevice saves a trusted device to the storage provider after generating the signature of the secret.
+func (p *SQLProvider) SaveTrustedDevice(ctx context.Context, device model.TrustedDevice) (err error) {
+	device.Signature = p.trustedDeviceSignature(device.Username, device.PublicID, device.Secret)
+
+	if _, err = p.db.ExecContext(ctx, p.sqlInsertTrustedDevice,
+		device.PublicID, device.Signature, device.CreatedAt, device.CreatedIP, device.ExpiresAt,
+		device.Username, device.Description); err != nil {
+		return fmt.Errorf("error inserting trusted device for user '%s' with public id '%s': %w", device.Username, device.PublicID, err)
+	}
+
+	return nil
+}
+
+// LoadTrustedDevice loads a trusted device from the storage provider given a username, public ID, and secret. If the
+// combination doesn't match a trusted device nil is returned without an error.
+func (p *SQLProvider) LoadTrustedDevice(ctx context.Context, username string, publicID uuid.UUID, secret []byte) (device *model.TrustedDevice, err error) {
+	device = &model.TrustedDevice{}
+
+	signature := p.trustedDeviceSignature(username, publicID, secret)
+
+	if err = p.db.GetContext(ctx, device, p.sqlSelectTrustedDevice, publicID, signature, username); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("error selecting trusted device for user '%s' with public id '%s': %w", username, publicID, err)
+	}
+
+	return device, nil
+}
+
+// LoadTrustedDevices loads the trusted devices for a user from the storage provider which have neither been revoked
+// nor expired at the given time.
+func (p *SQLProvider) LoadTrustedDevices(ctx context.Context, username string, now time.Time) (devices []model.TrustedDevice, err error) {
+	devices = []model.TrustedDevice{}
+
+	if err = p.db.SelectContext(ctx, &devices, p.sqlSelectTrustedDevices, username, now); err != nil {
+		return nil, fmt.Errorf("error selecting trusted devices for user '%s': %w", username, err)
+	}
+
+	return devices, nil
+}
+
+// UpdateTrustedDeviceSignIn updates the last used values of a trusted device in the storage provider.
+func (p *SQLProvider) UpdateTrustedDeviceSignIn(ctx context.Context, device model.TrustedDevice) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlUpdateTrustedDeviceSignIn, device.LastUsedAt, device.LastUsedIP, device.ID); err != nil {
+		return fmt.Errorf("error updating trusted device for user '%s' with public id '%s': %w", device.Username, device.PublicID, err)
+	}
+
+	return nil
+}
+
+// RevokeTrustedDevice revokes a trusted device for a user in the storage provider given the public ID, returning false
+// if there was no trusted device to revoke.
+func (p *SQLProvider) RevokeTrustedDevice(ctx context.Context, username string, publicID uuid.UUID, revokedAt time.Time) (revoked bool, err error) {
+	var (
+		result   sql.Result
+		affected int64
+	)
+
+	if result, err = p.db.ExecContext(ctx, p.sqlRevokeTrustedDevice, revokedAt, username, publicID); err != nil {
+		return false, fmt.Errorf("error revoking trusted device for user '%s' with public id '%s': %w", username, publicID, err)
+	}
+
+	if affected, err = result.RowsAffected(); err != nil {
+		return false, fmt.Errorf("error determining the rows affected revoking trusted device for user '%s' with public id '%s': %w", username, publicID, err)
+	}
+
+	return affected != 0, nil
+}
+
+// RevokeTrustedDevices revokes all trusted devices for a user in the storage provider.
+func (p *SQLProvider) RevokeTrustedDevices(ctx context.Context, username string, revokedAt time.Time) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlRevokeTrustedDevices, revokedAt, username); err != nil {
+		return fmt.Errorf("error revoking trusted devices for user '%s': %w", username, err)
+	}
+
+	return nil
+}
+
+// SaveKnownLogin saves a known login to the storage provider, updating the last seen time if the remote IP and user
+// agent combination is already known for the user.
+func (p *SQLProvider) SaveKnownLogin(ctx context.Context, login model.KnownLogin) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlUpsertKnownLogin, login.FirstSeen, login.LastSeen, login.Username, login.RemoteIP, login.UserAgentHash); err != nil {
+		return fmt.Errorf("error upserting known login for user '%s' with remote ip '%s': %w", login.Username, login.RemoteIP.IP, err)
+	}
+
+	return nil
+}
+
+// LoadKnownLoginSummary loads a summary of the known logins for a user from the storage provider which describes
+// how many of them match the given remote IP and user agent hash.
+func (p *SQLProvider) LoadKnownLoginSummary(ctx context.Context, username string, ip model.IP, userAgentHash string) (summary *model.KnownLoginSummary, err error) {
+	summary = &model.KnownLoginSummary{}
+
+	if err = p.db.GetContext(ctx, summary, p.sqlSelectKnownLoginSummary, ip, userAgentHash, username); err != nil {
+		return nil, fmt.Errorf("error selecting known login summary for user '%s': %w", username, err)
+	}
+
+	return summary, nil
+}
+
 // SaveIdentityVerification save an identity verification record to the storage provider.
 func (p *SQLProvider) SaveIdentityVerification(ctx context.Context, verification model.IdentityVerification) (err error) {
 	if _, err = p.db.ExecContext(ctx, p.sqlInsertIdentityVerification,
@@ -916,6 +1231,15 @@ func (p *SQLProvider) LoadOneTimeCode(ctx context.Context, username, intent, raw
 	return code, nil
 }
 
+// CountOneTimeCodes returns the number of one-time codes issued to a user with a specific intent since the given time.
+func (p *SQLProvider) CountOneTimeCodes(ctx context.Context, username, intent string, since time.Time) (count int, err error) {
+	if err = p.db.GetContext(ctx, &count, p.sqlSelectOneTimeCodeCount, username, intent, since); err != nil {
+		return 0, fmt.Errorf("error selecting one-time code count for user '%s': %w", username, err)
+	}
+
+	return count, nil
+}
+
 // LoadOneTimeCodeBySignature loads a one-time code from the storage provider given the signature.
 // This method should NOT be used to validate a One-Time Code, LoadOneTimeCode should be used instead.
 func (p *SQLProvider) LoadOneTimeCodeBySignature(ctx context.Context, signature string) (code *model.OneTimeCode, err error) {
@@ -1049,6 +1373,16 @@ func (p *SQLProvider) SaveOAuth2ConsentSessionGranted(ctx context.Context, id in
 	return nil
 }
 
+// LoadOAuth2ConsentSessionsAuthorizedCount returns the number of OAuth2.0 consent sessions in the storage provider
+// which a subject has authorized for a given client.
+func (p *SQLProvider) LoadOAuth2ConsentSessionsAuthorizedCount(ctx context.Context, clientID string, subject uuid.UUID) (count int, err error) {
+	if err = p.db.GetContext(ctx, &count, p.sqlSelectOAuth2ConsentSessionsAuthorized, clientID, subject); err != nil {
+		return 0, fmt.Errorf("error selecting authorized oauth2 consent session count for client id '%s' and subject '%s': %w", clientID, subject, err)
+	}
+
+	return count, nil
+}
+
 // LoadOAuth2ConsentSessionByChallengeID returns an OAuth2.0 consent session in the storage provider given the challenge ID.
 func (p *SQLProvider) LoadOAuth2ConsentSessionByChallengeID(ctx context.Context, challengeID uuid.UUID) (consent *model.OAuth2ConsentSession, err error) {
 	consent = &model.OAuth2ConsentSession{}
@@ -1321,7 +1655,8 @@ func (p *SQLProvider) AppendAuthenticationLog(ctx context.Context, attempt model
 	return nil
 }
 
-// LoadAuthenticationLogs loads authentication attempts from the storage provider (paginated).
+// LoadAuthenticationLogs loads the authentication attempts of every authentication type which were not made while
+// banned from the storage provider (paginated).
 func (p *SQLProvider) LoadAuthenticationLogs(ctx context.Context, username string, fromDate time.Time, limit, page int) (attempts []model.AuthenticationAttempt, err error) {
 	attempts = make([]model.AuthenticationAttempt, 0, limit)
 
@@ -1335,3 +1670,332 @@ func (p *SQLProvider) LoadAuthenticationLogs(ctx context.Context, username strin
 
 	return attempts, nil
 }
+
+// SaveRegulationLockout saves a regulation lockout to the storage provider.
+func (p *SQLProvider) SaveRegulationLockout(ctx context.Context, lockout model.RegulationLockout) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlInsertRegulationLockout,
+		lockout.LockedAt, lockout.LockedIP, lockout.Username, lockout.Bans); err != nil {
+		return fmt.Errorf("error inserting regulation lockout for user '%s': %w", lockout.Username, err)
+	}
+
+	return nil
+}
+
+// LoadRegulationLockout loads the latest regulation lockout for a user from the storage provider regardless of if it
+// has been unlocked or not.
+func (p *SQLProvider) LoadRegulationLockout(ctx context.Context, username string) (lockout *model.RegulationLockout, err error) {
+	lockout = &model.RegulationLockout{}
+
+	if err = p.db.GetContext(ctx, lockout, p.sqlSelectLatestRegulationLockoutByUsername, username); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNoRegulationLockout
+		}
+
+		return nil, fmt.Errorf("error selecting regulation lockout for user '%s': %w", username, err)
+	}
+
+	return lockout, nil
+}
+
+// UnlockRegulationLockout marks all active regulation lockouts for a user in the storage provider as unlocked.
+func (p *SQLProvider) UnlockRegulationLockout(ctx context.Context, username, method string, unlockedAt time.Time) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlUpdateRegulationLockoutUnlockByUsername, unlockedAt, method, username); err != nil {
+		return fmt.Errorf("error updating regulation lockout for user '%s': %w", username, err)
+	}
+
+	return nil
+}
+
+// LoadRegulationLockouts loads the active regulation lockouts from the storage provider (paginated).
+func (p *SQLProvider) LoadRegulationLockouts(ctx context.Context, limit, page int) (lockouts []model.RegulationLockout, err error) {
+	lockouts = make([]model.RegulationLockout, 0, limit)
+
+	if err = p.db.SelectContext(ctx, &lockouts, p.sqlSelectActiveRegulationLockouts, limit, limit*page); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNoRegulationLockout
+		}
+
+		return nil, fmt.Errorf("error selecting regulation lockouts: %w", err)
+	}
+
+	return lockouts, nil
+}
+
+// LoadAuthenticationLogsByUsername loads all authentication attempts for a user from the storage provider regardless
+// of type or outcome (paginated).
+func (p *SQLProvider) LoadAuthenticationLogsByUsername(ctx context.Context, username string, fromDate time.Time, limit, page int) (attempts []model.AuthenticationAttempt, err error) {
+	attempts = make([]model.AuthenticationAttempt, 0, limit)
+
+	if err = p.db.SelectContext(ctx, &attempts, p.sqlSelectAuthenticationLogsByUsername, fromDate, username, limit, limit*page); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNoAuthenticationLogs
+		}
+
+		return nil, fmt.Errorf("error selecting authentication logs for user '%s': %w", username, err)
+	}
+
+	return attempts, nil
+}
+
+// LoadAuthenticationLogsByRemoteIP loads all authentication attempts for a remote IP from the storage provider
+// regardless of type or outcome (paginated).
+func (p *SQLProvider) LoadAuthenticationLogsByRemoteIP(ctx context.Context, ip model.IP, fromDate time.Time, limit, page int) (attempts []model.AuthenticationAttempt, err error) {
+	attempts = make([]model.AuthenticationAttempt, 0, limit)
+
+	if err = p.db.SelectContext(ctx, &attempts, p.sqlSelectAuthenticationLogsByRemoteIP, fromDate, ip, limit, limit*page); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNoAuthenticationLogs
+		}
+
+		return nil, fmt.Errorf("error selecting authentication logs for remote ip '%s': %w", ip.IP.String(), err)
+	}
+
+	return attempts, nil
+}
+
+// LoadAuthenticationLogsRegulated loads the authentication attempts of every user with at least the given number of
+// unsuccessful authentication attempts from the storage provider ordered by username (paginated). Attempts of every
+// authentication type are included, however attempts made while banned and attempts which occurred before a ban of
+// the user was revoked or a lockout of the user was unlocked are not included.
+func (p *SQLProvider) LoadAuthenticationLogsRegulated(ctx context.Context, fromDate time.Time, retries, limit, page int) (attempts []model.AuthenticationAttempt, err error) {
+	attempts = make([]model.AuthenticationAttempt, 0, limit)
+
+	if err = p.db.SelectContext(ctx, &attempts, p.sqlSelectAuthenticationLogsRegulated, fromDate, fromDate, retries, limit, limit*page); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNoAuthenticationLogs
+		}
+
+		return nil, fmt.Errorf("error selecting regulated authentication logs: %w", err)
+	}
+
+	return attempts, nil
+}
+
+// SaveBannedUser saves a banned user to the storage provider.
+func (p *SQLProvider) SaveBannedUser(ctx context.Context, ban model.BannedUser) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlInsertBannedUser,
+		ban.Time, ban.Expires, ban.Revoked, ban.Username, ban.Source, ban.Reason); err != nil {
+		return fmt.Errorf("error inserting banned user '%s': %w", ban.Username, err)
+	}
+
+	return nil
+}
+
+// LoadBannedUser loads the bans for a user from the storage provider which are either active at the given time or
+// which have been revoked after the given since time.
+func (p *SQLProvider) LoadBannedUser(ctx context.Context, username string, now, since time.Time) (bans []model.BannedUser, err error) {
+	if err = p.db.SelectContext(ctx, &bans, p.sqlSelectBannedUserByUsername, username, now, since); err != nil {
+		return nil, fmt.Errorf("error selecting banned user '%s': %w", username, err)
+	}
+
+	return bans, nil
+}
+
+// LoadBannedUsers loads the bans for all users from the storage provider which are active at the given time
+// (paginated).
+func (p *SQLProvider) LoadBannedUsers(ctx context.Context, now time.Time, limit, page int) (bans []model.BannedUser, err error) {
+	bans = make([]model.BannedUser, 0, limit)
+
+	if err = p.db.SelectContext(ctx, &bans, p.sqlSelectActiveBannedUsers, now, limit, limit*page); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNoBannedUsers
+		}
+
+		return nil, fmt.Errorf("error selecting banned users: %w", err)
+	}
+
+	return bans, nil
+}
+
+// RevokeBannedUser revokes all bans for a user in the storage provider which are active at the given time.
+func (p *SQLProvider) RevokeBannedUser(ctx context.Context, username string, revokedAt time.Time) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlRevokeBannedUserByUsername, revokedAt, username, revokedAt); err != nil {
+		return fmt.Errorf("error revoking banned user '%s': %w", username, err)
+	}
+
+	return nil
+}
+
+// SaveBannedIP saves a banned IP to the storage provider.
+func (p *SQLProvider) SaveBannedIP(ctx context.Context, ban model.BannedIP) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlInsertBannedIP,
+		ban.Time, ban.Expires, ban.Revoked, ban.IP, ban.Source, ban.Reason); err != nil {
+		return fmt.Errorf("error inserting banned ip '%s': %w", ban.IP.IP.String(), err)
+	}
+
+	return nil
+}
+
+// LoadBannedIP loads the bans for an IP from the storage provider which are active at the given time.
+func (p *SQLProvider) LoadBannedIP(ctx context.Context, ip model.IP, now time.Time) (bans []model.BannedIP, err error) {
+	if err = p.db.SelectContext(ctx, &bans, p.sqlSelectActiveBannedIPByIP, ip, now); err != nil {
+		return nil, fmt.Errorf("error selecting banned ip '%s': %w", ip.IP.String(), err)
+	}
+
+	return bans, nil
+}
+
+// LoadBannedIPs loads the bans for all IPs from the storage provider which are active at the given time (paginated).
+func (p *SQLProvider) LoadBannedIPs(ctx context.Context, now time.Time, limit, page int) (bans []model.BannedIP, err error) {
+	bans = make([]model.BannedIP, 0, limit)
+
+	if err = p.db.SelectContext(ctx, &bans, p.sqlSelectActiveBannedIPs, now, limit, limit*page); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNoBannedIPs
+		}
+
+		return nil, fmt.Errorf("error selecting banned ips: %w", err)
+	}
+
+	return bans, nil
+}
+
+// RevokeBannedIP revokes all bans for an IP in the storage provider which are active at the given time.
+func (p *SQLProvider) RevokeBannedIP(ctx context.Context, ip model.IP, revokedAt time.Time) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlRevokeBannedIPByIP, revokedAt, ip, revokedAt); err != nil {
+		return fmt.Errorf("error revoking banned ip '%s': %w", ip.IP.String(), err)
+	}
+
+	return nil
+}
+
+// SaveQueuedNotification saves a notification to the queue in the storage provider encrypting the template data.
+func (p *SQLProvider) SaveQueuedNotification(ctx context.Context, notification model.QueuedNotification) (err error) {
+	if notification.Data, err = p.encrypt(notification.Data); err != nil {
+		return fmt.Errorf("error encrypting queued notification data for recipient '%s': %w", notification.Recipient, err)
+	}
+
+	if _, err = p.db.ExecContext(ctx, p.sqlInsertQueuedNotification,
+		notification.CreatedAt, notification.NextAttemptAt, notification.Attempts, notification.Status,
+		notification.Recipient, notification.Subject, notification.Template, notification.Data); err != nil {
+		return fmt.Errorf("error inserting queued notification for recipient '%s': %w", notification.Recipient, err)
+	}
+
+	return nil
+}
+
+// LoadQueuedNotificationsDue loads the pending notifications from the storage provider which are due for delivery at
+// the given time decrypting the template data.
+func (p *SQLProvider) LoadQueuedNotificationsDue(ctx context.Context, now time.Time, limit int) (notifications []model.QueuedNotification, err error) {
+	notifications = make([]model.QueuedNotification, 0, limit)
+
+	if err = p.db.SelectContext(ctx, &notifications, p.sqlSelectQueuedNotificationsDue, model.QueuedNotificationStatusPending, now, limit); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("error selecting due queued notifications: %w", err)
+	}
+
+	for i := range notifications {
+		if notifications[i].Data, err = p.decrypt(notifications[i].Data); err != nil {
+			return nil, fmt.Errorf("error decrypting queued notification data with id '%d': %w", notifications[i].ID, err)
+		}
+	}
+
+	return notifications, nil
+}
+
+// LoadQueuedNotifications loads the notifications with the given status from the storage provider without the
+// template data (paginated).
+func (p *SQLProvider) LoadQueuedNotifications(ctx context.Context, status string, limit, page int) (notifications []model.QueuedNotification, err error) {
+	notifications = make([]model.QueuedNotification, 0, limit)
+
+	if err = p.db.SelectContext(ctx, &notifications, p.sqlSelectQueuedNotificationsByStatus, status, limit, limit*page); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNoQueuedNotifications
+		}
+
+		return nil, fmt.Errorf("error selecting queued notifications with status '%s': %w", status, err)
+	}
+
+	return notifications, nil
+}
+
+// ClaimQueuedNotification claims a pending notification in the storage provider for a delivery attempt by
+// incrementing the attempts and deferring the next attempt until the given time. The claim only succeeds if the number
+// of attempts matches the given value, which prevents more than one worker delivering the same notification.
+func (p *SQLProvider) ClaimQueuedNotification(ctx context.Context, id, attempts int, until time.Time) (claimed bool, err error) {
+	var (
+		result   sql.Result
+		affected int64
+	)
+
+	if result, err = p.db.ExecContext(ctx, p.sqlClaimQueuedNotification, until, id, model.QueuedNotificationStatusPending, attempts); err != nil {
+		return false, fmt.Errorf("error claiming queued notification with id '%d': %w", id, err)
+	}
+
+	if affected, err = result.RowsAffected(); err != nil {
+		return false, fmt.Errorf("error claiming queued notification with id '%d': %w", id, err)
+	}
+
+	return affected == 1, nil
+}
+
+// UpdateQueuedNotificationStatus updates the status, next attempt time, sent time, and last error of a notification
+// in the storage provider.
+func (p *SQLProvider) UpdateQueuedNotificationStatus(ctx context.Context, notification model.QueuedNotification) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlUpdateQueuedNotificationStatus,
+		notification.Status, notification.NextAttemptAt, notification.SentAt, notification.LastError, notification.ID); err != nil {
+		return fmt.Errorf("error updating queued notification with id '%d': %w", notification.ID, err)
+	}
+
+	return nil
+}
+
+// RetryQueuedNotification moves a dead notification in the storage provider back to the pending state with the
+// attempts reset so it's delivered at the given time.
+func (p *SQLProvider) RetryQueuedNotification(ctx context.Context, id int, now time.Time) (err error) {
+	var (
+		result   sql.Result
+		affected int64
+	)
+
+	if result, err = p.db.ExecContext(ctx, p.sqlRetryQueuedNotification,
+		model.QueuedNotificationStatusPending, now, id, model.QueuedNotificationStatusDead); err != nil {
+		return fmt.Errorf("error retrying queued notification with id '%d': %w", id, err)
+	}
+
+	if affected, err = result.RowsAffected(); err != nil {
+		return fmt.Errorf("error retrying queued notification with id '%d': %w", id, err)
+	}
+
+	if affected == 0 {
+		return ErrNoQueuedNotifications
+	}
+
+	return nil
+}
+
+// RetryQueuedNotifications moves all dead notifications in the storage provider back to the pending state with the
+// attempts reset so they're delivered at the given time.
+func (p *SQLProvider) RetryQueuedNotifications(ctx context.Context, now time.Time) (affected int64, err error) {
+	var result sql.Result
+
+	if result, err = p.db.ExecContext(ctx, p.sqlRetryQueuedNotifications,
+		model.QueuedNotificationStatusPending, now, model.QueuedNotificationStatusDead); err != nil {
+		return 0, fmt.Errorf("error retrying dead queued notifications: %w", err)
+	}
+
+	if affected, err = result.RowsAffected(); err != nil {
+		return 0, fmt.Errorf("error retrying dead queued notifications: %w", err)
+	}
+
+	return affected, nil
+}
+
+// PurgeQueuedNotifications deletes the notifications with the given status from the storage provider which were
+// created before the given time.
+func (p *SQLProvider) PurgeQueuedNotifications(ctx context.Context, status string, before time.Time) (affected int64, err error) {
+	var result sql.Result
+
+	if result, err = p.db.ExecContext(ctx, p.sqlDeleteQueuedNotifications, status, before); err != nil {
+		return 0, fmt.Errorf("error purging queued notifications with status '%s': %w", status, err)
+	}
+
+	if affected, err = result.RowsAffected(); err != nil {
+		return 0, fmt.Errorf("error purging queued notifications with status '%s': %w", status, err)
+	}
+
+	return affected, nil
+}
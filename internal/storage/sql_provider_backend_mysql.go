@@ -2,6 +2,7 @@ package storage
 
 import (
 	"crypto/x509"
+	"fmt"
 	"time"
 
 	"github.com/go-sql-driver/mysql"
@@ -17,8 +18,10 @@ type MySQLProvider struct {
 
 // NewMySQLProvider a MySQL provider.
 func NewMySQLProvider(config *schema.Configuration, caCertPool *x509.CertPool) (provider *MySQLProvider) {
+	var err error
+
 	provider = &MySQLProvider{
-		SQLProvider: NewSQLProvider(config, providerMySQL, providerMySQL, dsnMySQL(config.Storage.MySQL, caCertPool)),
+		SQLProvider: NewSQLProvider(config, providerMySQL, providerMySQL, dsnMySQL(config.Storage.MySQL, caCertPool, "storage")),
 	}
 
 	// All providers have differing SELECT existing table statements.
@@ -27,19 +30,30 @@ func NewMySQLProvider(config *schema.Configuration, caCertPool *x509.CertPool) (
 	// Specific alterations to this provider.
 	provider.sqlFmtRenameTable = queryFmtMySQLRenameTable
 
+	// MySQL doesn't support the ON CONFLICT operation but has an ON DUPLICATE KEY operation instead.
+	provider.sqlUpsertKnownLogin = fmt.Sprintf(queryFmtUpsertKnownLoginMySQL, tableKnownLogin)
+
+	if replicas := config.Storage.MySQL.Replicas; len(replicas.Addresses) != 0 {
+		addresses, dataSourceNames := dsnMySQLReplicas(config.Storage.MySQL, caCertPool)
+
+		if provider.replicas, err = NewReplicaSet(providerMySQL, addresses, dataSourceNames, replicas.HealthCheckInterval, config.Storage.MySQL.Timeout); err != nil && provider.errOpen == nil {
+			provider.errOpen = err
+		}
+	}
+
 	return provider
 }
 
-func dsnMySQL(config *schema.StorageMySQL, caCertPool *x509.CertPool) (dataSourceName string) {
+func dsnMySQL(config *schema.StorageMySQL, caCertPool *x509.CertPool, tlsName string) (dataSourceName string) {
 	dsnConfig := mysql.NewConfig()
 
 	dsnConfig.Net = config.Address.Network()
 	dsnConfig.Addr = config.Address.NetworkAddress()
 
 	if config.TLS != nil {
-		_ = mysql.RegisterTLSConfig("storage", utils.NewTLSConfig(config.TLS, caCertPool))
+		_ = mysql.RegisterTLSConfig(tlsName, utils.NewTLSConfig(config.TLS, caCertPool))
 
-		dsnConfig.TLSConfig = "storage"
+		dsnConfig.TLSConfig = tlsName
 	}
 
 	dsnConfig.DBName = config.Database
@@ -52,3 +66,26 @@ func dsnMySQL(config *schema.StorageMySQL, caCertPool *x509.CertPool) (dataSourc
 
 	return dsnConfig.FormatDSN()
 }
+
+// dsnMySQLReplicas returns the addresses and data source names of the replicas. The replicas use the primary
+// configuration with the exception of the address, and the TLS server name when it's the hostname of the primary.
+func dsnMySQLReplicas(config *schema.StorageMySQL, caCertPool *x509.CertPool) (addresses, dataSourceNames []string) {
+	for i, address := range config.Replicas.Addresses {
+		replica := *config
+
+		replica.Address = address
+
+		if config.TLS != nil && config.Address != nil && config.TLS.ServerName == config.Address.Hostname() {
+			tlsc := *config.TLS
+
+			tlsc.ServerName = address.Hostname()
+
+			replica.TLS = &tlsc
+		}
+
+		addresses = append(addresses, address.String())
+		dataSourceNames = append(dataSourceNames, dsnMySQL(&replica, caCertPool, fmt.Sprintf("storage-replica-%d", i)))
+	}
+
+	return addresses, dataSourceNames
+}
@@ -21,6 +21,8 @@ type PostgreSQLProvider struct {
 
 // NewPostgreSQLProvider a PostgreSQL provider.
 func NewPostgreSQLProvider(config *schema.Configuration, caCertPool *x509.CertPool) (provider *PostgreSQLProvider) {
+	var err error
+
 	provider = &PostgreSQLProvider{
 		SQLProvider: NewSQLProvider(config, providerPostgres, "pgx", dsnPostgreSQL(config.Storage.PostgreSQL, caCertPool)),
 	}
@@ -59,6 +61,7 @@ func NewPostgreSQLProvider(config *schema.Configuration, caCertPool *x509.CertPo
 	provider.sqlSelectOneTimeCodeBySignature = provider.db.Rebind(provider.sqlSelectOneTimeCodeBySignature)
 	provider.sqlSelectOneTimeCodeByID = provider.db.Rebind(provider.sqlSelectOneTimeCodeByID)
 	provider.sqlSelectOneTimeCodeByPublicID = provider.db.Rebind(provider.sqlSelectOneTimeCodeByPublicID)
+	provider.sqlSelectOneTimeCodeCount = provider.db.Rebind(provider.sqlSelectOneTimeCodeCount)
 
 	provider.sqlSelectTOTPConfig = provider.db.Rebind(provider.sqlSelectTOTPConfig)
 	provider.sqlUpdateTOTPConfigRecordSignIn = provider.db.Rebind(provider.sqlUpdateTOTPConfigRecordSignIn)
@@ -71,6 +74,7 @@ func NewPostgreSQLProvider(config *schema.Configuration, caCertPool *x509.CertPo
 
 	provider.sqlInsertWebAuthnUser = provider.db.Rebind(provider.sqlInsertWebAuthnUser)
 	provider.sqlSelectWebAuthnUser = provider.db.Rebind(provider.sqlSelectWebAuthnUser)
+	provider.sqlSelectWebAuthnUserByUserID = provider.db.Rebind(provider.sqlSelectWebAuthnUserByUserID)
 
 	provider.sqlInsertWebAuthnCredential = provider.db.Rebind(provider.sqlInsertWebAuthnCredential)
 	provider.sqlSelectWebAuthnCredentials = provider.db.Rebind(provider.sqlSelectWebAuthnCredentials)
@@ -86,9 +90,51 @@ func NewPostgreSQLProvider(config *schema.Configuration, caCertPool *x509.CertPo
 	provider.sqlSelectDuoDevice = provider.db.Rebind(provider.sqlSelectDuoDevice)
 	provider.sqlDeleteDuoDevice = provider.db.Rebind(provider.sqlDeleteDuoDevice)
 
+	provider.sqlUpsertKnownLogin = provider.db.Rebind(provider.sqlUpsertKnownLogin)
+	provider.sqlSelectKnownLoginSummary = provider.db.Rebind(provider.sqlSelectKnownLoginSummary)
+
+	provider.sqlInsertRecoveryCode = provider.db.Rebind(provider.sqlInsertRecoveryCode)
+	provider.sqlSelectRecoveryCodes = provider.db.Rebind(provider.sqlSelectRecoveryCodes)
+	provider.sqlConsumeRecoveryCode = provider.db.Rebind(provider.sqlConsumeRecoveryCode)
+	provider.sqlDeleteRecoveryCodes = provider.db.Rebind(provider.sqlDeleteRecoveryCodes)
+
+	provider.sqlInsertTrustedDevice = provider.db.Rebind(provider.sqlInsertTrustedDevice)
+	provider.sqlSelectTrustedDevice = provider.db.Rebind(provider.sqlSelectTrustedDevice)
+	provider.sqlSelectTrustedDevices = provider.db.Rebind(provider.sqlSelectTrustedDevices)
+	provider.sqlUpdateTrustedDeviceSignIn = provider.db.Rebind(provider.sqlUpdateTrustedDeviceSignIn)
+	provider.sqlRevokeTrustedDevice = provider.db.Rebind(provider.sqlRevokeTrustedDevice)
+	provider.sqlRevokeTrustedDevices = provider.db.Rebind(provider.sqlRevokeTrustedDevices)
+
+	provider.sqlInsertQueuedNotification = provider.db.Rebind(provider.sqlInsertQueuedNotification)
+	provider.sqlSelectQueuedNotificationsDue = provider.db.Rebind(provider.sqlSelectQueuedNotificationsDue)
+	provider.sqlSelectQueuedNotificationsByStatus = provider.db.Rebind(provider.sqlSelectQueuedNotificationsByStatus)
+	provider.sqlClaimQueuedNotification = provider.db.Rebind(provider.sqlClaimQueuedNotification)
+	provider.sqlUpdateQueuedNotificationStatus = provider.db.Rebind(provider.sqlUpdateQueuedNotificationStatus)
+	provider.sqlRetryQueuedNotification = provider.db.Rebind(provider.sqlRetryQueuedNotification)
+	provider.sqlRetryQueuedNotifications = provider.db.Rebind(provider.sqlRetryQueuedNotifications)
+	provider.sqlDeleteQueuedNotifications = provider.db.Rebind(provider.sqlDeleteQueuedNotifications)
+
 	provider.sqlInsertAuthenticationAttempt = provider.db.Rebind(provider.sqlInsertAuthenticationAttempt)
 	provider.sqlSelectAuthenticationAttemptsByUsername = provider.db.Rebind(provider.sqlSelectAuthenticationAttemptsByUsername)
 
+	provider.sqlInsertRegulationLockout = provider.db.Rebind(provider.sqlInsertRegulationLockout)
+	provider.sqlSelectLatestRegulationLockoutByUsername = provider.db.Rebind(provider.sqlSelectLatestRegulationLockoutByUsername)
+	provider.sqlUpdateRegulationLockoutUnlockByUsername = provider.db.Rebind(provider.sqlUpdateRegulationLockoutUnlockByUsername)
+	provider.sqlSelectActiveRegulationLockouts = provider.db.Rebind(provider.sqlSelectActiveRegulationLockouts)
+
+	provider.sqlSelectAuthenticationLogsByUsername = provider.db.Rebind(provider.sqlSelectAuthenticationLogsByUsername)
+	provider.sqlSelectAuthenticationLogsByRemoteIP = provider.db.Rebind(provider.sqlSelectAuthenticationLogsByRemoteIP)
+	provider.sqlSelectAuthenticationLogsRegulated = provider.db.Rebind(provider.sqlSelectAuthenticationLogsRegulated)
+
+	provider.sqlInsertBannedUser = provider.db.Rebind(provider.sqlInsertBannedUser)
+	provider.sqlSelectBannedUserByUsername = provider.db.Rebind(provider.sqlSelectBannedUserByUsername)
+	provider.sqlSelectActiveBannedUsers = provider.db.Rebind(provider.sqlSelectActiveBannedUsers)
+	provider.sqlRevokeBannedUserByUsername = provider.db.Rebind(provider.sqlRevokeBannedUserByUsername)
+	provider.sqlInsertBannedIP = provider.db.Rebind(provider.sqlInsertBannedIP)
+	provider.sqlSelectActiveBannedIPByIP = provider.db.Rebind(provider.sqlSelectActiveBannedIPByIP)
+	provider.sqlSelectActiveBannedIPs = provider.db.Rebind(provider.sqlSelectActiveBannedIPs)
+	provider.sqlRevokeBannedIPByIP = provider.db.Rebind(provider.sqlRevokeBannedIPByIP)
+
 	provider.sqlInsertMigration = provider.db.Rebind(provider.sqlInsertMigration)
 	provider.sqlSelectMigrations = provider.db.Rebind(provider.sqlSelectMigrations)
 	provider.sqlSelectLatestMigration = provider.db.Rebind(provider.sqlSelectLatestMigration)
@@ -101,6 +147,7 @@ func NewPostgreSQLProvider(config *schema.Configuration, caCertPool *x509.CertPo
 	provider.sqlUpdateOAuth2ConsentSessionSubject = provider.db.Rebind(provider.sqlUpdateOAuth2ConsentSessionSubject)
 	provider.sqlUpdateOAuth2ConsentSessionResponse = provider.db.Rebind(provider.sqlUpdateOAuth2ConsentSessionResponse)
 	provider.sqlUpdateOAuth2ConsentSessionGranted = provider.db.Rebind(provider.sqlUpdateOAuth2ConsentSessionGranted)
+	provider.sqlSelectOAuth2ConsentSessionsAuthorized = provider.db.Rebind(provider.sqlSelectOAuth2ConsentSessionsAuthorized)
 	provider.sqlSelectOAuth2ConsentSessionByChallengeID = provider.db.Rebind(provider.sqlSelectOAuth2ConsentSessionByChallengeID)
 
 	provider.sqlInsertOAuth2AccessTokenSession = provider.db.Rebind(provider.sqlInsertOAuth2AccessTokenSession)
@@ -145,8 +192,19 @@ func NewPostgreSQLProvider(config *schema.Configuration, caCertPool *x509.CertPo
 
 	provider.sqlSelectOAuth2BlacklistedJTI = provider.db.Rebind(provider.sqlSelectOAuth2BlacklistedJTI)
 
+	// PostgreSQL sequences are not updated when a row is inserted with an explicit id such as during a restore.
+	provider.sqlFmtResetSequence = queryFmtPostgreSQLResetSequence
+
 	provider.schema = config.Storage.PostgreSQL.Schema
 
+	if replicas := config.Storage.PostgreSQL.Replicas; len(replicas.Addresses) != 0 {
+		addresses, dataSourceNames := dsnPostgreSQLReplicas(config.Storage.PostgreSQL, caCertPool)
+
+		if provider.replicas, err = NewReplicaSet("pgx", addresses, dataSourceNames, replicas.HealthCheckInterval, config.Storage.PostgreSQL.Timeout); err != nil && provider.errOpen == nil {
+			provider.errOpen = err
+		}
+	}
+
 	return provider
 }
 
@@ -171,6 +229,29 @@ func dsnPostgreSQL(config *schema.StoragePostgreSQL, globalCACertPool *x509.Cert
 	return stdlib.RegisterConnConfig(dsnConfig)
 }
 
+// dsnPostgreSQLReplicas returns the addresses and data source names of the replicas. The replicas use the primary
+// configuration with the exception of the address, and the TLS server name when it's the hostname of the primary.
+func dsnPostgreSQLReplicas(config *schema.StoragePostgreSQL, globalCACertPool *x509.CertPool) (addresses, dataSourceNames []string) {
+	for _, address := range config.Replicas.Addresses {
+		replica := *config
+
+		replica.Address = address
+
+		if config.TLS != nil && config.Address != nil && config.TLS.ServerName == config.Address.Hostname() {
+			tlsc := *config.TLS
+
+			tlsc.ServerName = address.Hostname()
+
+			replica.TLS = &tlsc
+		}
+
+		addresses = append(addresses, address.String())
+		dataSourceNames = append(dataSourceNames, dsnPostgreSQL(&replica, globalCACertPool))
+	}
+
+	return addresses, dataSourceNames
+}
+
 func loadPostgreSQLTLSConfig(config *schema.StoragePostgreSQL, globalCACertPool *x509.CertPool) (tlsConfig *tls.Config) {
 	if config.TLS != nil {
 		return utils.NewTLSConfig(config.TLS, globalCACertPool)
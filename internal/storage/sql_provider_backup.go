@@ -0,0 +1,617 @@
+package storage
+
+import (
+	"compress/gzip"
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/mattn/go-sqlite3"
+
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// SchemaBackup writes a backup archive containing the data from every table in the storage provider to the writer.
+// The migrations table is not included as the schema version is recorded in the header of the archive instead.
+// Encrypted values are written as they're stored so restoring the archive requires the same encryption key, or the same
+// key management provider when the values are encrypted with a data key.
+func (p *SQLProvider) SchemaBackup(ctx context.Context, w io.Writer) (result BackupResult, err error) {
+	if result.Header.SchemaVersion, err = p.SchemaVersion(ctx); err != nil {
+		return result, fmt.Errorf("error determining the schema version: %w", err)
+	}
+
+	if result.Header.SchemaVersion <= 0 {
+		return result, fmt.Errorf(errFmtBackupSchemaVersion, SchemaVersionToString(result.Header.SchemaVersion))
+	}
+
+	var tables []string
+
+	if tables, err = p.SchemaTables(ctx); err != nil {
+		return result, fmt.Errorf("error determining the schema tables: %w", err)
+	}
+
+	result.Header.Version = BackupVersion
+	result.Header.Provider = p.name
+	result.Header.Created = time.Now().UTC()
+	result.Header.Tables = backupTables(tables)
+
+	gz := gzip.NewWriter(w)
+
+	encoder := json.NewEncoder(gz)
+
+	if err = encoder.Encode(backupRecord{Header: &result.Header}); err != nil {
+		return result, fmt.Errorf("error writing the backup header: %w", err)
+	}
+
+	var rows int
+
+	for _, table := range result.Header.Tables {
+		if rows, err = p.schemaBackupTable(ctx, encoder, table); err != nil {
+			return result, fmt.Errorf("error writing table '%s' to the backup: %w", table, err)
+		}
+
+		result.Tables = append(result.Tables, BackupTableResult{Name: table, Rows: rows})
+	}
+
+	if err = gz.Close(); err != nil {
+		return result, fmt.Errorf("error writing the backup: %w", err)
+	}
+
+	return result, nil
+}
+
+// SchemaRestore restores a backup archive from the reader to the storage provider. If the storage provider has no
+// schema it's migrated to the schema version of the archive, otherwise the schema version must match the archive. The
+// tables must not contain any data unless force is true in which case the existing data is deleted. The restore is
+// performed in a single transaction and the number of rows in each table is checked against the archive.
+func (p *SQLProvider) SchemaRestore(ctx context.Context, r io.Reader, force bool) (result BackupResult, err error) {
+	var gz *gzip.Reader
+
+	if gz, err = gzip.NewReader(r); err != nil {
+		return result, fmt.Errorf("error reading the backup: %w", err)
+	}
+
+	defer gz.Close()
+
+	decoder := json.NewDecoder(gz)
+
+	decoder.UseNumber()
+
+	var record backupRecord
+
+	if err = decoder.Decode(&record); err != nil {
+		return result, fmt.Errorf("error reading the backup header: %w", err)
+	}
+
+	if record.Header == nil {
+		return result, errors.New("error reading the backup header: the backup doesn't start with a header")
+	}
+
+	result.Header = *record.Header
+
+	if result.Header.Version != BackupVersion {
+		return result, fmt.Errorf(errFmtBackupVersionUnsupported, result.Header.Version, BackupVersion)
+	}
+
+	if err = p.schemaRestoreVersion(ctx, result.Header.SchemaVersion); err != nil {
+		return result, err
+	}
+
+	var tx *sqlx.Tx
+
+	if tx, err = p.db.BeginTxx(ctx, nil); err != nil {
+		return result, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if err = p.schemaRestoreClear(ctx, tx, result.Header.Tables, force); err != nil {
+		return result, schemaRestoreRollback(tx, err)
+	}
+
+	var rows int
+
+	for _, table := range result.Header.Tables {
+		if rows, err = p.schemaRestoreTable(ctx, tx, decoder, table); err != nil {
+			return result, schemaRestoreRollback(tx, fmt.Errorf("error restoring table '%s' from the backup: %w", table, err))
+		}
+
+		if table == tableEncryptionKey {
+			// The encryption values are encrypted with the data keys in the backup so these must be loaded first.
+			if err = p.loadDataKeys(ctx, tx); err != nil {
+				return result, schemaRestoreRollback(tx, fmt.Errorf("error restoring table '%s' from the backup: %w", table, err))
+			}
+		}
+
+		result.Tables = append(result.Tables, BackupTableResult{Name: table, Rows: rows})
+	}
+
+	if err = tx.Commit(); err != nil {
+		return result, schemaRestoreRollback(tx, fmt.Errorf("failed to commit the transaction: %w", err))
+	}
+
+	return result, nil
+}
+
+// SchemaTableRowCount returns the number of rows in a table of the storage provider.
+func (p *SQLProvider) SchemaTableRowCount(ctx context.Context, table string) (count int, err error) {
+	if !reTableName.MatchString(table) {
+		return -1, fmt.Errorf("error counting rows: the table name '%s' is not valid", table)
+	}
+
+	if err = p.db.GetContext(ctx, &count, fmt.Sprintf(queryFmtSelectTableRowCount, table)); err != nil {
+		return -1, fmt.Errorf("error counting rows in table '%s': %w", table, err)
+	}
+
+	return count, nil
+}
+
+func (p *SQLProvider) schemaBackupTable(ctx context.Context, encoder *json.Encoder, table string) (n int, err error) {
+	var rows *sqlx.Rows
+
+	if rows, err = p.db.QueryxContext(ctx, fmt.Sprintf(queryFmtSelectBackupRows, table)); err != nil {
+		return 0, err
+	}
+
+	defer func() {
+		if err := rows.Close(); err != nil {
+			p.log.Errorf(logFmtErrClosingConn, err)
+		}
+	}()
+
+	var types []*sql.ColumnType
+
+	if types, err = rows.ColumnTypes(); err != nil {
+		return 0, fmt.Errorf("error determining the column types: %w", err)
+	}
+
+	header := backupTable{Name: table, Columns: make([]backupColumn, len(types))}
+
+	for i, t := range types {
+		header.Columns[i] = backupColumn{Name: t.Name(), Kind: newBackupKind(t.DatabaseTypeName())}
+	}
+
+	if err = encoder.Encode(backupRecord{Table: &header}); err != nil {
+		return 0, err
+	}
+
+	var values []any
+
+	for rows.Next() {
+		if values, err = rows.SliceScan(); err != nil {
+			return n, fmt.Errorf("error scanning row: %w", err)
+		}
+
+		for i, value := range values {
+			if values[i], err = backupValueEncode(header.Columns[i].Kind, value); err != nil {
+				return n, fmt.Errorf("error encoding column '%s': %w", header.Columns[i].Name, err)
+			}
+		}
+
+		if err = encoder.Encode(backupRecord{Row: values}); err != nil {
+			return n, err
+		}
+
+		n++
+	}
+
+	if err = rows.Err(); err != nil {
+		return n, err
+	}
+
+	if err = encoder.Encode(backupRecord{End: &backupTableEnd{Name: table, Rows: n}}); err != nil {
+		return n, err
+	}
+
+	return n, nil
+}
+
+func (p *SQLProvider) schemaRestoreVersion(ctx context.Context, version int) (err error) {
+	var current int
+
+	if current, err = p.SchemaVersion(ctx); err != nil {
+		return fmt.Errorf("error determining the schema version: %w", err)
+	}
+
+	switch current {
+	case version:
+		return nil
+	case 0:
+		if err = p.SchemaMigrate(ctx, true, version); err != nil {
+			return fmt.Errorf("error migrating the schema to version %d: %w", version, err)
+		}
+
+		return nil
+	default:
+		return fmt.Errorf(errFmtRestoreSchemaVersion, current, version)
+	}
+}
+
+func (p *SQLProvider) schemaRestoreClear(ctx context.Context, tx *sqlx.Tx, tables []string, force bool) (err error) {
+	var count int
+
+	for _, table := range tables {
+		if !reTableName.MatchString(table) {
+			return fmt.Errorf("error restoring the backup: the table name '%s' is not valid", table)
+		}
+
+		if force || table == tableEncryption || table == tableEncryptionKey {
+			continue
+		}
+
+		if err = tx.GetContext(ctx, &count, fmt.Sprintf(queryFmtSelectTableRowCount, table)); err != nil {
+			return fmt.Errorf("error counting rows in table '%s': %w", table, err)
+		}
+
+		if count != 0 {
+			return fmt.Errorf(errFmtRestoreTableNotEmpty, table, count)
+		}
+	}
+
+	for i := len(tables) - 1; i >= 0; i-- {
+		if _, err = tx.ExecContext(ctx, fmt.Sprintf(queryFmtDeleteBackupRows, tables[i])); err != nil {
+			return fmt.Errorf("error deleting existing rows in table '%s': %w", tables[i], err)
+		}
+	}
+
+	return nil
+}
+
+func (p *SQLProvider) schemaRestoreTable(ctx context.Context, tx *sqlx.Tx, decoder *json.Decoder, table string) (n int, err error) {
+	var record backupRecord
+
+	if err = decoder.Decode(&record); err != nil {
+		return 0, fmt.Errorf("error reading the table header: %w", err)
+	}
+
+	if record.Table == nil || record.Table.Name != table {
+		return 0, errors.New("error reading the table header: the backup doesn't contain the table at the expected position")
+	}
+
+	columns := record.Table.Columns
+
+	names := make([]string, len(columns))
+
+	for i, column := range columns {
+		if !reTableName.MatchString(column.Name) {
+			return 0, fmt.Errorf("error reading the table header: the column name '%s' is not valid", column.Name)
+		}
+
+		names[i] = column.Name
+	}
+
+	var kinds []backupKind
+
+	if kinds, err = schemaRestoreColumnKinds(ctx, tx, table, names); err != nil {
+		return 0, err
+	}
+
+	var stmt *sqlx.Stmt
+
+	query := fmt.Sprintf(queryFmtInsertBackupRow, table, strings.Join(names, ", "), strings.TrimSuffix(strings.Repeat("?, ", len(names)), ", "))
+
+	if stmt, err = tx.PreparexContext(ctx, tx.Rebind(query)); err != nil {
+		return 0, fmt.Errorf("error preparing the insert statement: %w", err)
+	}
+
+	defer func() {
+		if err := stmt.Close(); err != nil {
+			p.log.Errorf(logFmtErrClosingConn, err)
+		}
+	}()
+
+	for {
+		record = backupRecord{}
+
+		if err = decoder.Decode(&record); err != nil {
+			return n, fmt.Errorf("error reading row: %w", err)
+		}
+
+		switch {
+		case record.End != nil:
+			if record.End.Name != table || record.End.Rows != n {
+				return n, fmt.Errorf("the backup indicates the table contains %d rows but %d rows were read", record.End.Rows, n)
+			}
+
+			return n, p.schemaRestoreTableFinalize(ctx, tx, table, names, n)
+		case record.Row != nil:
+			if len(record.Row) != len(columns) {
+				return n, fmt.Errorf("error reading row: the row has %d values but the table has %d columns", len(record.Row), len(columns))
+			}
+
+			values := make([]any, len(columns))
+
+			for i, value := range record.Row {
+				if values[i], err = backupValueDecode(columns[i].Kind, value); err != nil {
+					return n, fmt.Errorf("error decoding column '%s': %w", names[i], err)
+				}
+
+				if values[i], err = backupValueConvert(kinds[i], values[i]); err != nil {
+					return n, fmt.Errorf("error converting column '%s': %w", names[i], err)
+				}
+
+				if table == tableEncryption && names[i] == backupColumnValue {
+					if err = p.schemaRestoreCheckEncryptionValue(values[i]); err != nil {
+						return n, err
+					}
+				}
+			}
+
+			if _, err = stmt.ExecContext(ctx, values...); err != nil {
+				return n, fmt.Errorf("error inserting row: %w", err)
+			}
+
+			n++
+		default:
+			return n, errors.New("error reading row: the backup contains an unexpected record")
+		}
+	}
+}
+
+func (p *SQLProvider) schemaRestoreTableFinalize(ctx context.Context, tx *sqlx.Tx, table string, names []string, n int) (err error) {
+	var count int
+
+	if err = tx.GetContext(ctx, &count, fmt.Sprintf(queryFmtSelectTableRowCount, table)); err != nil {
+		return fmt.Errorf("error counting rows: %w", err)
+	}
+
+	if count != n {
+		return fmt.Errorf("the table contains %d rows after the restore but the backup contains %d rows", count, n)
+	}
+
+	if p.sqlFmtResetSequence == "" || !utils.IsStringInSlice(backupColumnID, names) {
+		return nil
+	}
+
+	if _, err = tx.ExecContext(ctx, fmt.Sprintf(p.sqlFmtResetSequence, table, table)); err != nil {
+		return fmt.Errorf("error resetting the sequence: %w", err)
+	}
+
+	return nil
+}
+
+func (p *SQLProvider) schemaRestoreCheckEncryptionValue(value any) (err error) {
+	data, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("error checking the encryption value: the value has the unexpected type %T", value)
+	}
+
+	if _, err = p.decrypt(data); err != nil {
+		return ErrSchemaEncryptionInvalidKey
+	}
+
+	return nil
+}
+
+func schemaRestoreColumnKinds(ctx context.Context, tx *sqlx.Tx, table string, names []string) (kinds []backupKind, err error) {
+	var rows *sqlx.Rows
+
+	if rows, err = tx.QueryxContext(ctx, fmt.Sprintf(queryFmtSelectBackupColumns, strings.Join(names, ", "), table)); err != nil {
+		return nil, fmt.Errorf("error determining the column types: %w", err)
+	}
+
+	defer rows.Close()
+
+	var types []*sql.ColumnType
+
+	if types, err = rows.ColumnTypes(); err != nil {
+		return nil, fmt.Errorf("error determining the column types: %w", err)
+	}
+
+	kinds = make([]backupKind, len(types))
+
+	for i, t := range types {
+		kinds[i] = newBackupKind(t.DatabaseTypeName())
+	}
+
+	return kinds, nil
+}
+
+func schemaRestoreRollback(tx *sqlx.Tx, err error) error {
+	if rerr := tx.Rollback(); rerr != nil {
+		return fmt.Errorf("%w: rollback error: %+v", err, rerr)
+	}
+
+	return err
+}
+
+// backupTables returns the tables which should be included in a backup in the order they should be restored.
+func backupTables(existing []string) (tables []string) {
+	for _, table := range tablesBackup {
+		if utils.IsStringInSlice(table, existing) {
+			tables = append(tables, table)
+		}
+	}
+
+	var extra []string
+
+	for _, table := range existing {
+		switch {
+		case table == tableMigrations,
+			utils.IsStringInSlice(table, tablesBackup),
+			utils.IsStringInSlice(table, tablesPre1),
+			strings.HasPrefix(table, backupTablePrefixBKP),
+			strings.HasPrefix(table, backupTablePrefixSQL):
+			continue
+		default:
+			extra = append(extra, table)
+		}
+	}
+
+	sort.Strings(extra)
+
+	return append(tables, extra...)
+}
+
+// newBackupKind returns the backupKind for a column given the database type name reported by the driver.
+func newBackupKind(databaseTypeName string) backupKind {
+	name := strings.ToUpper(databaseTypeName)
+
+	switch {
+	case strings.Contains(name, "BOOL"):
+		return backupKindBoolean
+	case strings.Contains(name, "BLOB"), strings.Contains(name, "BYTEA"), strings.Contains(name, "BINARY"):
+		return backupKindBytes
+	case strings.Contains(name, "TIME"), strings.Contains(name, "DATE"):
+		return backupKindTime
+	case strings.Contains(name, "INT"), strings.Contains(name, "SERIAL"):
+		return backupKindInteger
+	default:
+		return backupKindString
+	}
+}
+
+// String returns a string representation of this backupKind.
+func (k backupKind) String() string {
+	switch k {
+	case backupKindString:
+		return "string"
+	case backupKindInteger:
+		return "integer"
+	case backupKindBoolean:
+		return "boolean"
+	case backupKindBytes:
+		return "bytes"
+	case backupKindTime:
+		return "time"
+	default:
+		return invalid
+	}
+}
+
+// backupValueEncode converts a value scanned from a column of the given kind into a value which can be represented
+// in JSON.
+func backupValueEncode(kind backupKind, value any) (encoded any, err error) {
+	if value, err = backupValueConvert(kind, value); err != nil {
+		return nil, err
+	}
+
+	switch v := value.(type) {
+	case []byte:
+		return base64.StdEncoding.EncodeToString(v), nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	default:
+		return v, nil
+	}
+}
+
+// backupValueDecode converts a value decoded from JSON into the native representation of the given kind.
+func backupValueDecode(kind backupKind, value any) (decoded any, err error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	switch v := value.(type) {
+	case string:
+		switch kind {
+		case backupKindBytes:
+			return base64.StdEncoding.DecodeString(v)
+		case backupKindTime:
+			return time.Parse(time.RFC3339Nano, v)
+		}
+	case json.Number:
+		var i int64
+
+		if i, err = v.Int64(); err != nil {
+			return nil, err
+		}
+
+		return backupValueConvert(kind, i)
+	}
+
+	return backupValueConvert(kind, value)
+}
+
+// backupValueConvert converts a value to the native representation of the given kind. This handles the differences
+// in how each driver represents values, for example MySQL represents booleans as integers.
+//
+//nolint:gocyclo // This function is a simple type switch.
+func backupValueConvert(kind backupKind, value any) (converted any, err error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		if kind == backupKindBytes {
+			return v, nil
+		}
+
+		return backupValueConvert(kind, string(v))
+	case string:
+		switch kind {
+		case backupKindString:
+			return v, nil
+		case backupKindBytes:
+			return []byte(v), nil
+		case backupKindInteger:
+			return strconv.ParseInt(v, 10, 64)
+		case backupKindBoolean:
+			return strconv.ParseBool(v)
+		case backupKindTime:
+			return backupParseTime(v)
+		}
+	case bool:
+		switch kind {
+		case backupKindBoolean:
+			return v, nil
+		case backupKindInteger:
+			if v {
+				return int64(1), nil
+			}
+
+			return int64(0), nil
+		case backupKindString:
+			return strconv.FormatBool(v), nil
+		}
+	case int:
+		return backupValueConvert(kind, int64(v))
+	case int16:
+		return backupValueConvert(kind, int64(v))
+	case int32:
+		return backupValueConvert(kind, int64(v))
+	case int64:
+		switch kind {
+		case backupKindInteger:
+			return v, nil
+		case backupKindBoolean:
+			return v != 0, nil
+		case backupKindString:
+			return strconv.FormatInt(v, 10), nil
+		}
+	case float64:
+		switch kind {
+		case backupKindInteger, backupKindBoolean:
+			return backupValueConvert(kind, int64(v))
+		case backupKindString:
+			return strconv.FormatFloat(v, 'f', -1, 64), nil
+		}
+	case time.Time:
+		switch kind {
+		case backupKindTime:
+			return v, nil
+		case backupKindString:
+			return v.Format(time.RFC3339Nano), nil
+		}
+	}
+
+	return nil, fmt.Errorf("can't convert a value of type %T to a %s value", value, kind)
+}
+
+func backupParseTime(value string) (t time.Time, err error) {
+	trimmed := strings.TrimSuffix(value, "Z")
+
+	for _, layout := range sqlite3.SQLiteTimestampFormats {
+		if t, err = time.ParseInLocation(layout, trimmed, time.UTC); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Parse(time.RFC3339Nano, value)
+}
@@ -0,0 +1,179 @@
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBackupKind(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     []string
+		expected backupKind
+	}{
+		{
+			"ShouldHandleBooleans",
+			[]string{"BOOL", "BOOLEAN", "boolean"},
+			backupKindBoolean,
+		},
+		{
+			"ShouldHandleBytes",
+			[]string{"BYTEA", "BLOB", "MEDIUMBLOB", "VARBINARY"},
+			backupKindBytes,
+		},
+		{
+			"ShouldHandleTime",
+			[]string{"TIMESTAMP", "TIMESTAMPTZ", "DATETIME", "DATE"},
+			backupKindTime,
+		},
+		{
+			"ShouldHandleIntegers",
+			[]string{"INT4", "INT8", "INTEGER", "TINYINT", "BIGINT", "SERIAL"},
+			backupKindInteger,
+		},
+		{
+			"ShouldHandleStrings",
+			[]string{"VARCHAR", "VARCHAR(100)", "CHAR(36)", "BPCHAR", "TEXT", ""},
+			backupKindString,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, have := range tc.have {
+				assert.Equal(t, tc.expected, newBackupKind(have), have)
+			}
+		})
+	}
+}
+
+func TestBackupValueConvert(t *testing.T) {
+	now := time.Unix(1700000000, 0).UTC()
+
+	testCases := []struct {
+		name     string
+		kind     backupKind
+		have     any
+		expected any
+		err      string
+	}{
+		{"ShouldConvertNil", backupKindString, nil, nil, ""},
+		{"ShouldConvertBytesToString", backupKindString, []byte("abc"), "abc", ""},
+		{"ShouldConvertBytesToBytes", backupKindBytes, []byte("abc"), []byte("abc"), ""},
+		{"ShouldConvertBytesToInteger", backupKindInteger, []byte("42"), int64(42), ""},
+		{"ShouldConvertStringToBoolean", backupKindBoolean, "1", true, ""},
+		{"ShouldConvertStringToTime", backupKindTime, "2023-11-14 22:13:20", now, ""},
+		{"ShouldConvertBooleanToInteger", backupKindInteger, true, int64(1), ""},
+		{"ShouldConvertBooleanFalseToInteger", backupKindInteger, false, int64(0), ""},
+		{"ShouldConvertInteger32ToBoolean", backupKindBoolean, int32(1), true, ""},
+		{"ShouldConvertIntegerToBooleanFalse", backupKindBoolean, int64(0), false, ""},
+		{"ShouldConvertIntegerToString", backupKindString, int64(7), "7", ""},
+		{"ShouldConvertTime", backupKindTime, now, now, ""},
+		{"ShouldNotConvertTimeToInteger", backupKindInteger, now, nil, "can't convert a value of type time.Time to a integer value"},
+		{"ShouldNotConvertBooleanToTime", backupKindTime, true, nil, "can't convert a value of type bool to a time value"},
+		{"ShouldNotConvertInvalidInteger", backupKindInteger, "abc", nil, "strconv.ParseInt: parsing \"abc\": invalid syntax"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := backupValueConvert(tc.kind, tc.have)
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+
+				if expected, ok := tc.expected.(time.Time); ok {
+					assert.True(t, expected.Equal(actual.(time.Time)))
+				} else {
+					assert.Equal(t, tc.expected, actual)
+				}
+			} else {
+				assert.EqualError(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestBackupValueEncodeDecode(t *testing.T) {
+	now := time.Unix(1700000000, 123000).In(time.FixedZone("AEST", 10*60*60))
+
+	testCases := []struct {
+		name string
+		kind backupKind
+		have any
+	}{
+		{"ShouldRoundTripString", backupKindString, "example"},
+		{"ShouldRoundTripInteger", backupKindInteger, int64(1234567890123)},
+		{"ShouldRoundTripBoolean", backupKindBoolean, true},
+		{"ShouldRoundTripBytes", backupKindBytes, []byte{0x00, 0x01, 0xfe, 0xff}},
+		{"ShouldRoundTripTime", backupKindTime, now},
+		{"ShouldRoundTripNil", backupKindBytes, nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			encoded, err := backupValueEncode(tc.kind, tc.have)
+			require.NoError(t, err)
+
+			data, err := json.Marshal(encoded)
+			require.NoError(t, err)
+
+			var raw any
+
+			decoder := json.NewDecoder(bytes.NewReader(data))
+			decoder.UseNumber()
+
+			require.NoError(t, decoder.Decode(&raw))
+
+			decoded, err := backupValueDecode(tc.kind, raw)
+			require.NoError(t, err)
+
+			if expected, ok := tc.have.(time.Time); ok {
+				assert.True(t, expected.Equal(decoded.(time.Time)))
+			} else {
+				assert.Equal(t, tc.have, decoded)
+			}
+		})
+	}
+}
+
+func TestBackupTables(t *testing.T) {
+	existing := []string{
+		tableOAuth2ConsentSession,
+		"sqlite_sequence",
+		tableMigrations,
+		tableTOTPConfigurations,
+		"_bkp_UP_V0002_totp_configurations",
+		"zz_custom",
+		tableEncryption,
+		tableUserOpaqueIdentifier,
+		tableOAuth2ConsentPreConfiguration,
+		"aa_custom",
+	}
+
+	assert.Equal(t, []string{
+		tableEncryption,
+		tableUserOpaqueIdentifier,
+		tableTOTPConfigurations,
+		tableOAuth2ConsentPreConfiguration,
+		tableOAuth2ConsentSession,
+		"aa_custom",
+		"zz_custom",
+	}, backupTables(existing))
+}
+
+func TestBackupResultRows(t *testing.T) {
+	result := BackupResult{
+		Tables: []BackupTableResult{
+			{Name: tableEncryption, Rows: 3},
+			{Name: tableTOTPConfigurations, Rows: 10},
+		},
+	}
+
+	assert.Equal(t, 13, result.Rows())
+	assert.Equal(t, 0, BackupResult{}.Rows())
+}
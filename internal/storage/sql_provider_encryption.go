@@ -20,6 +20,10 @@ import (
 // SchemaEncryptionChangeKey uses the currently configured key to decrypt values in the storage provider and the key
 // provided by this command to encrypt the values again and update them using a transaction.
 func (p *SQLProvider) SchemaEncryptionChangeKey(ctx context.Context, rawKey string) (err error) {
+	if p.kms != nil {
+		return fmt.Errorf("error changing the storage encryption key: the encryption key can't be changed when key management is configured, rotate the key-encryption key instead")
+	}
+
 	key := sha256.Sum256([]byte(rawKey))
 
 	if bytes.Equal(key[:], p.keys.encryption[:]) {
@@ -35,10 +39,25 @@ func (p *SQLProvider) SchemaEncryptionChangeKey(ctx context.Context, rawKey stri
 		return fmt.Errorf("error beginning transaction to change encryption key: %w", err)
 	}
 
+	encrypt := func(clearText []byte) (cipherText []byte, err error) {
+		return utils.Encrypt(clearText, &key)
+	}
+
+	if err = p.schemaEncryptionChangeKey(ctx, tx, encrypt); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// schemaEncryptionChangeKey decrypts every encrypted value in the storage provider with the current keys and encrypts
+// them again with the encrypt func. The transaction is rolled back if an error occurs.
+func (p *SQLProvider) schemaEncryptionChangeKey(ctx context.Context, tx *sqlx.Tx, encrypt EncryptFunc) (err error) {
 	encChangeFuncs := []EncryptionChangeKeyFunc{
 		schemaEncryptionChangeKeyOneTimeCode,
 		schemaEncryptionChangeKeyTOTP,
 		schemaEncryptionChangeKeyWebAuthn,
+		schemaEncryptionChangeKeyQueuedNotification,
 	}
 
 	for i := 0; true; i++ {
@@ -54,7 +73,7 @@ func (p *SQLProvider) SchemaEncryptionChangeKey(ctx context.Context, rawKey stri
 	encChangeFuncs = append(encChangeFuncs, schemaEncryptionChangeKeyEncryption)
 
 	for _, encChangeFunc := range encChangeFuncs {
-		if err = encChangeFunc(ctx, p, tx, key); err != nil {
+		if err = encChangeFunc(ctx, p, tx, encrypt); err != nil {
 			if rerr := tx.Rollback(); rerr != nil {
 				return fmt.Errorf("rollback error %v: rollback due to error: %w", rerr, err)
 			}
@@ -63,7 +82,7 @@ func (p *SQLProvider) SchemaEncryptionChangeKey(ctx context.Context, rawKey stri
 		}
 	}
 
-	return tx.Commit()
+	return nil
 }
 
 // SchemaEncryptionCheckKey checks the encryption key configured is valid for the database.
@@ -77,6 +96,12 @@ func (p *SQLProvider) SchemaEncryptionCheckKey(ctx context.Context, verbose bool
 		return result, ErrSchemaEncryptionVersionUnsupported
 	}
 
+	if version >= schemaVersionEncryptionKeys && p.keys.data == nil {
+		if err = p.loadDataKeys(ctx, p.db); err != nil {
+			return result, err
+		}
+	}
+
 	result = EncryptionValidationResult{
 		Tables: map[string]EncryptionValidationTableResult{},
 	}
@@ -90,6 +115,7 @@ func (p *SQLProvider) SchemaEncryptionCheckKey(ctx context.Context, verbose bool
 			schemaEncryptionCheckKeyOneTimeCode,
 			schemaEncryptionCheckKeyTOTP,
 			schemaEncryptionCheckKeyWebAuthn,
+			schemaEncryptionCheckKeyQueuedNotification,
 		}
 
 		for i := 0; true; i++ {
@@ -114,7 +140,7 @@ func (p *SQLProvider) SchemaEncryptionCheckKey(ctx context.Context, verbose bool
 	return result, nil
 }
 
-func schemaEncryptionChangeKeyOneTimeCode(ctx context.Context, provider *SQLProvider, tx *sqlx.Tx, key [32]byte) (err error) {
+func schemaEncryptionChangeKeyOneTimeCode(ctx context.Context, provider *SQLProvider, tx *sqlx.Tx, encrypt EncryptFunc) (err error) {
 	var count int
 
 	if err = tx.GetContext(ctx, &count, fmt.Sprintf(queryFmtSelectRowCount, tableOneTimeCode)); err != nil {
@@ -142,7 +168,7 @@ func schemaEncryptionChangeKeyOneTimeCode(ctx context.Context, provider *SQLProv
 			return fmt.Errorf("error decrypting one-time code with id '%d': %w", c.ID, err)
 		}
 
-		if c.Code, err = utils.Encrypt(c.Code, &key); err != nil {
+		if c.Code, err = encrypt(c.Code); err != nil {
 			return fmt.Errorf("error encrypting one-time code with id '%d': %w", c.ID, err)
 		}
 
@@ -154,7 +180,47 @@ func schemaEncryptionChangeKeyOneTimeCode(ctx context.Context, provider *SQLProv
 	return nil
 }
 
-func schemaEncryptionChangeKeyTOTP(ctx context.Context, provider *SQLProvider, tx *sqlx.Tx, key [32]byte) (err error) {
+func schemaEncryptionChangeKeyQueuedNotification(ctx context.Context, provider *SQLProvider, tx *sqlx.Tx, encrypt EncryptFunc) (err error) {
+	var count int
+
+	if err = tx.GetContext(ctx, &count, fmt.Sprintf(queryFmtSelectRowCount, tableNotificationQueue)); err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return nil
+	}
+
+	notifications := make([]encQueuedNotification, 0, count)
+
+	if err = tx.SelectContext(ctx, &notifications, fmt.Sprintf(queryFmtSelectQueuedNotificationsEncryptedData, tableNotificationQueue)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+
+		return fmt.Errorf("error selecting queued notifications: %w", err)
+	}
+
+	query := provider.db.Rebind(fmt.Sprintf(queryFmtUpdateQueuedNotificationEncryptedData, tableNotificationQueue))
+
+	for _, n := range notifications {
+		if n.Data, err = provider.decrypt(n.Data); err != nil {
+			return fmt.Errorf("error decrypting queued notification with id '%d': %w", n.ID, err)
+		}
+
+		if n.Data, err = encrypt(n.Data); err != nil {
+			return fmt.Errorf("error encrypting queued notification with id '%d': %w", n.ID, err)
+		}
+
+		if _, err = tx.ExecContext(ctx, query, n.Data, n.ID); err != nil {
+			return fmt.Errorf("error updating queued notification with id '%d': %w", n.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func schemaEncryptionChangeKeyTOTP(ctx context.Context, provider *SQLProvider, tx *sqlx.Tx, encrypt EncryptFunc) (err error) {
 	var count int
 
 	if err = tx.GetContext(ctx, &count, fmt.Sprintf(queryFmtSelectRowCount, tableTOTPConfigurations)); err != nil {
@@ -182,7 +248,7 @@ func schemaEncryptionChangeKeyTOTP(ctx context.Context, provider *SQLProvider, t
 			return fmt.Errorf("error decrypting TOTP configuration secret with id '%d': %w", c.ID, err)
 		}
 
-		if c.Secret, err = utils.Encrypt(c.Secret, &key); err != nil {
+		if c.Secret, err = encrypt(c.Secret); err != nil {
 			return fmt.Errorf("error encrypting TOTP configuration secret with id '%d': %w", c.ID, err)
 		}
 
@@ -194,7 +260,7 @@ func schemaEncryptionChangeKeyTOTP(ctx context.Context, provider *SQLProvider, t
 	return nil
 }
 
-func schemaEncryptionChangeKeyWebAuthn(ctx context.Context, provider *SQLProvider, tx *sqlx.Tx, key [32]byte) (err error) {
+func schemaEncryptionChangeKeyWebAuthn(ctx context.Context, provider *SQLProvider, tx *sqlx.Tx, encrypt EncryptFunc) (err error) {
 	var count int
 
 	if err = tx.GetContext(ctx, &count, fmt.Sprintf(queryFmtSelectRowCount, tableWebAuthnCredentials)); err != nil {
@@ -222,7 +288,7 @@ func schemaEncryptionChangeKeyWebAuthn(ctx context.Context, provider *SQLProvide
 			return fmt.Errorf("error decrypting WebAuthn credential public key with id '%d': %w", d.ID, err)
 		}
 
-		if d.PublicKey, err = utils.Encrypt(d.PublicKey, &key); err != nil {
+		if d.PublicKey, err = encrypt(d.PublicKey); err != nil {
 			return fmt.Errorf("error encrypting WebAuthn credential public key with id '%d': %w", d.ID, err)
 		}
 
@@ -235,7 +301,7 @@ func schemaEncryptionChangeKeyWebAuthn(ctx context.Context, provider *SQLProvide
 }
 
 func schemaEncryptionChangeKeyOpenIDConnect(typeOAuth2Session OAuth2SessionType) EncryptionChangeKeyFunc {
-	return func(ctx context.Context, provider *SQLProvider, tx *sqlx.Tx, key [32]byte) (err error) {
+	return func(ctx context.Context, provider *SQLProvider, tx *sqlx.Tx, encrypt EncryptFunc) (err error) {
 		var count int
 
 		if err = tx.GetContext(ctx, &count, fmt.Sprintf(queryFmtSelectRowCount, typeOAuth2Session.Table())); err != nil {
@@ -259,7 +325,7 @@ func schemaEncryptionChangeKeyOpenIDConnect(typeOAuth2Session OAuth2SessionType)
 				return fmt.Errorf("error decrypting oauth2 %s session data with id '%d': %w", typeOAuth2Session.String(), s.ID, err)
 			}
 
-			if s.Session, err = utils.Encrypt(s.Session, &key); err != nil {
+			if s.Session, err = encrypt(s.Session); err != nil {
 				return fmt.Errorf("error encrypting oauth2 %s session data with id '%d': %w", typeOAuth2Session.String(), s.ID, err)
 			}
 
@@ -272,7 +338,7 @@ func schemaEncryptionChangeKeyOpenIDConnect(typeOAuth2Session OAuth2SessionType)
 	}
 }
 
-func schemaEncryptionChangeKeyEncryption(ctx context.Context, provider *SQLProvider, tx *sqlx.Tx, key [32]byte) (err error) {
+func schemaEncryptionChangeKeyEncryption(ctx context.Context, provider *SQLProvider, tx *sqlx.Tx, encrypt EncryptFunc) (err error) {
 	var count int
 
 	if err = tx.GetContext(ctx, &count, fmt.Sprintf(queryFmtSelectRowCount, tableEncryption)); err != nil {
@@ -300,7 +366,7 @@ func schemaEncryptionChangeKeyEncryption(ctx context.Context, provider *SQLProvi
 			return fmt.Errorf("error decrypting encyption value with id '%d': %w", c.ID, err)
 		}
 
-		if c.Value, err = utils.Encrypt(c.Value, &key); err != nil {
+		if c.Value, err = encrypt(c.Value); err != nil {
 			return fmt.Errorf("error encrypting encyption value with id '%d': %w", c.ID, err)
 		}
 
@@ -343,6 +409,37 @@ func schemaEncryptionCheckKeyOneTimeCode(ctx context.Context, provider *SQLProvi
 	return tableOneTimeCode, result
 }
 
+func schemaEncryptionCheckKeyQueuedNotification(ctx context.Context, provider *SQLProvider) (table string, result EncryptionValidationTableResult) {
+	var (
+		rows *sqlx.Rows
+		err  error
+	)
+
+	if rows, err = provider.db.QueryxContext(ctx, fmt.Sprintf(queryFmtSelectQueuedNotificationsEncryptedData, tableNotificationQueue)); err != nil {
+		return tableNotificationQueue, EncryptionValidationTableResult{Error: fmt.Errorf("error selecting queued notifications: %w", err)}
+	}
+
+	var notification encQueuedNotification
+
+	for rows.Next() {
+		result.Total++
+
+		if err = rows.StructScan(&notification); err != nil {
+			_ = rows.Close()
+
+			return tableNotificationQueue, EncryptionValidationTableResult{Error: fmt.Errorf("error scanning queued notification to struct: %w", err)}
+		}
+
+		if _, err = provider.decrypt(notification.Data); err != nil {
+			result.Invalid++
+		}
+	}
+
+	_ = rows.Close()
+
+	return tableNotificationQueue, result
+}
+
 func schemaEncryptionCheckKeyTOTP(ctx context.Context, provider *SQLProvider) (table string, result EncryptionValidationTableResult) {
 	var (
 		rows *sqlx.Rows
@@ -470,10 +567,26 @@ func schemaEncryptionCheckKeyEncryption(ctx context.Context, provider *SQLProvid
 }
 
 func (p *SQLProvider) encrypt(clearText []byte) (cipherText []byte, err error) {
+	if p.keys.current != "" {
+		return encryptEnvelope(p.keys.current, p.keys.data[p.keys.current], clearText)
+	}
+
 	return utils.Encrypt(clearText, &p.keys.encryption)
 }
 
 func (p *SQLProvider) decrypt(cipherText []byte) (clearText []byte, err error) {
+	if id, header, payload, ok := decodeEnvelope(cipherText); ok {
+		if key, ok := p.keys.data[id]; ok {
+			return decryptEnvelope(key, header, payload)
+		}
+
+		if clearText, err = utils.Decrypt(cipherText, &p.keys.encryption); err != nil {
+			return nil, fmt.Errorf("the value is encrypted with the data key with id '%s' which is not available", id)
+		}
+
+		return clearText, nil
+	}
+
 	return utils.Decrypt(cipherText, &p.keys.encryption)
 }
 
@@ -487,6 +600,12 @@ func (p *SQLProvider) otcHMACSignature(values ...[]byte) string {
 	return fmt.Sprintf("%x", h.Sum(nil))
 }
 
+// trustedDeviceSignature returns the signature of a trusted device secret which is bound to the username and public
+// ID of the trusted device.
+func (p *SQLProvider) trustedDeviceSignature(username string, publicID uuid.UUID, secret []byte) string {
+	return p.otcHMACSignature([]byte(tableTrustedDevice), []byte(username), publicID[:], secret)
+}
+
 func (p *SQLProvider) otpHMACSignature(values ...[]byte) string {
 	h := hmac.New(sha256.New, p.keys.otpHMAC)
 
@@ -0,0 +1,258 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/authelia/authelia/v4/internal/kms"
+)
+
+// envelopeMagic is the prefix of values encrypted with a data key. The prefix is followed by the length of the data
+// key identifier, the data key identifier, the nonce, the ciphertext, and the tag. The prefix and identifier are
+// authenticated as additional data so the identifier recorded with each value can't be altered.
+var envelopeMagic = []byte{0x00, 'A', 'E', 0x01}
+
+// SchemaEncryptionRotateKEK unwraps every data key with the key-encryption key it was wrapped with and wraps it again
+// with the current key-encryption key of the key management provider. The encrypted values are not modified.
+func (p *SQLProvider) SchemaEncryptionRotateKEK(ctx context.Context) (result DataKeyRotationResult, err error) {
+	if p.kms == nil {
+		return result, errors.New("error rotating the key-encryption key: key management is not configured")
+	}
+
+	var tx *sqlx.Tx
+
+	if tx, err = p.db.BeginTxx(ctx, nil); err != nil {
+		return result, fmt.Errorf("error beginning transaction to rotate the key-encryption key: %w", err)
+	}
+
+	if result, err = p.schemaEncryptionRotateKEK(ctx, tx); err != nil {
+		if rerr := tx.Rollback(); rerr != nil {
+			return result, fmt.Errorf("rollback error %v: rollback due to error: %w", rerr, err)
+		}
+
+		return result, fmt.Errorf("rollback due to error: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return result, fmt.Errorf("error committing the transaction to rotate the key-encryption key: %w", err)
+	}
+
+	return result, nil
+}
+
+func (p *SQLProvider) schemaEncryptionRotateKEK(ctx context.Context, tx *sqlx.Tx) (result DataKeyRotationResult, err error) {
+	var keys []encDataKey
+
+	if err = tx.SelectContext(ctx, &keys, fmt.Sprintf(queryFmtSelectEncryptionKeys, tableEncryptionKey)); err != nil {
+		return result, fmt.Errorf("error selecting data keys: %w", err)
+	}
+
+	result.Provider = p.kms.Name()
+
+	query := tx.Rebind(fmt.Sprintf(queryFmtUpdateEncryptionKeyWrappedKey, tableEncryptionKey))
+
+	var key, wrapped []byte
+
+	for _, k := range keys {
+		if key, err = p.unwrapDataKey(ctx, k); err != nil {
+			return result, err
+		}
+
+		if result.KEKID, wrapped, err = p.kms.Wrap(ctx, key); err != nil {
+			return result, fmt.Errorf("error wrapping data key with id '%s': %w", k.KeyID, err)
+		}
+
+		if _, err = tx.ExecContext(ctx, query, result.KEKID, wrapped, time.Now(), k.ID); err != nil {
+			return result, fmt.Errorf("error updating data key with id '%s': %w", k.KeyID, err)
+		}
+
+		result.Keys = append(result.Keys, k.KeyID)
+	}
+
+	return result, nil
+}
+
+// initDataKeys loads the data keys and if key management is configured but no data key exists a new data key is
+// generated. Every encrypted value is encrypted again with the new data key in the same transaction the data key is
+// saved in, so the encryption key is only required until this has completed.
+func (p *SQLProvider) initDataKeys(ctx context.Context) (err error) {
+	if err = p.loadDataKeys(ctx, p.db); err != nil {
+		return err
+	}
+
+	if p.kms == nil || p.keys.current != "" {
+		return nil
+	}
+
+	key, raw := &[32]byte{}, make([]byte, dataKeyIDSize)
+
+	if _, err = io.ReadFull(rand.Reader, key[:]); err != nil {
+		return fmt.Errorf("error generating data key: %w", err)
+	}
+
+	if _, err = io.ReadFull(rand.Reader, raw); err != nil {
+		return fmt.Errorf("error generating data key: %w", err)
+	}
+
+	id := hex.EncodeToString(raw)
+
+	var (
+		kekID   string
+		wrapped []byte
+	)
+
+	if kekID, wrapped, err = p.kms.Wrap(ctx, key[:]); err != nil {
+		return fmt.Errorf("error wrapping data key: %w", err)
+	}
+
+	var tx *sqlx.Tx
+
+	if tx, err = p.db.BeginTxx(ctx, nil); err != nil {
+		return fmt.Errorf("error beginning transaction to save the data key: %w", err)
+	}
+
+	if _, err = tx.ExecContext(ctx, tx.Rebind(fmt.Sprintf(queryFmtInsertEncryptionKey, tableEncryptionKey)), id, p.kms.Name(), kekID, wrapped, time.Now()); err != nil {
+		_ = tx.Rollback()
+
+		return fmt.Errorf("error inserting data key: %w", err)
+	}
+
+	encrypt := func(clearText []byte) (cipherText []byte, err error) {
+		return encryptEnvelope(id, key, clearText)
+	}
+
+	if err = p.schemaEncryptionChangeKey(ctx, tx, encrypt); err != nil {
+		return fmt.Errorf("error encrypting the storage with the data key: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("error committing the transaction to save the data key: %w", err)
+	}
+
+	p.keys.data[id], p.keys.current = key, id
+
+	p.log.Infof("Storage data key with id '%s' has been generated and wrapped with the '%s' key-encryption key with id '%s'", id, p.kms.Name(), kekID)
+
+	return nil
+}
+
+// loadDataKeys loads and unwraps every data key in the storage. The most recent data key becomes the current data
+// key.
+func (p *SQLProvider) loadDataKeys(ctx context.Context, conn SQLXConnection) (err error) {
+	var keys []encDataKey
+
+	if err = sqlx.SelectContext(ctx, conn, &keys, fmt.Sprintf(queryFmtSelectEncryptionKeys, tableEncryptionKey)); err != nil {
+		return fmt.Errorf("error selecting data keys: %w", err)
+	}
+
+	data, current := map[string]*[32]byte{}, ""
+
+	var key []byte
+
+	for _, k := range keys {
+		if key, err = p.unwrapDataKey(ctx, k); err != nil {
+			return err
+		}
+
+		data[k.KeyID], current = (*[32]byte)(key), k.KeyID
+	}
+
+	p.keys.data, p.keys.current = data, current
+
+	return nil
+}
+
+func (p *SQLProvider) unwrapDataKey(ctx context.Context, k encDataKey) (key []byte, err error) {
+	switch {
+	case p.kms == nil:
+		return nil, fmt.Errorf("error unwrapping data key with id '%s': the data key is wrapped with the '%s' key management provider but key management is not configured", k.KeyID, k.KEKProvider)
+	case p.kms.Name() != k.KEKProvider:
+		return nil, fmt.Errorf("error unwrapping data key with id '%s': the data key is wrapped with the '%s' key management provider but the '%s' key management provider is configured", k.KeyID, k.KEKProvider, p.kms.Name())
+	}
+
+	if key, err = p.kms.Unwrap(ctx, k.KEKID, k.WrappedKey); err != nil {
+		return nil, fmt.Errorf("error unwrapping data key with id '%s': %w", k.KeyID, err)
+	}
+
+	if len(key) != kms.KeySize {
+		return nil, fmt.Errorf("error unwrapping data key with id '%s': the data key has an invalid length of %d bytes", k.KeyID, len(key))
+	}
+
+	return key, nil
+}
+
+func encryptEnvelope(id string, key *[32]byte, clearText []byte) (cipherText []byte, err error) {
+	var gcm cipher.AEAD
+
+	if gcm, err = newEnvelopeGCM(key); err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, 0, len(envelopeMagic)+1+len(id)+gcm.NonceSize())
+
+	header = append(header, envelopeMagic...)
+	header = append(header, byte(len(id)))
+	header = append(header, id...)
+
+	nonce := make([]byte, gcm.NonceSize())
+
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	return gcm.Seal(append(header, nonce...), nonce, clearText, header), nil
+}
+
+func decryptEnvelope(key *[32]byte, header, payload []byte) (clearText []byte, err error) {
+	var gcm cipher.AEAD
+
+	if gcm, err = newEnvelopeGCM(key); err != nil {
+		return nil, err
+	}
+
+	if len(payload) < gcm.NonceSize() {
+		return nil, errors.New("malformed ciphertext")
+	}
+
+	return gcm.Open(nil, payload[:gcm.NonceSize()], payload[gcm.NonceSize():], header)
+}
+
+// decodeEnvelope splits a value encrypted with a data key into the data key identifier, the header, and the payload.
+// The ok value is false if the value was not encrypted with a data key.
+func decodeEnvelope(cipherText []byte) (id string, header, payload []byte, ok bool) {
+	n := len(envelopeMagic)
+
+	if len(cipherText) <= n || !bytes.Equal(cipherText[:n], envelopeMagic) {
+		return "", nil, nil, false
+	}
+
+	size := int(cipherText[n])
+
+	if size == 0 || len(cipherText) < n+1+size {
+		return "", nil, nil, false
+	}
+
+	header = cipherText[:n+1+size]
+
+	return string(header[n+1:]), header, cipherText[n+1+size:], true
+}
+
+func newEnvelopeGCM(key *[32]byte) (gcm cipher.AEAD, err error) {
+	var block cipher.Block
+
+	if block, err = aes.NewCipher(key[:]); err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,114 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+func TestEnvelope(t *testing.T) {
+	key := &[32]byte{1, 2, 3}
+
+	cipherText, err := encryptEnvelope("abc123", key, []byte("example"))
+	require.NoError(t, err)
+
+	id, header, payload, ok := decodeEnvelope(cipherText)
+	require.True(t, ok)
+	assert.Equal(t, "abc123", id)
+	assert.Equal(t, append(append([]byte{}, envelopeMagic...), append([]byte{6}, "abc123"...)...), header)
+
+	clearText, err := decryptEnvelope(key, header, payload)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("example"), clearText)
+
+	_, err = decryptEnvelope(&[32]byte{}, header, payload)
+	assert.EqualError(t, err, "cipher: message authentication failed")
+
+	tampered := append([]byte{}, cipherText...)
+	tampered[len(envelopeMagic)+1] = 'x'
+
+	_, header, payload, ok = decodeEnvelope(tampered)
+	require.True(t, ok)
+
+	_, err = decryptEnvelope(key, header, payload)
+	assert.EqualError(t, err, "cipher: message authentication failed")
+
+	_, err = decryptEnvelope(key, header, payload[:4])
+	assert.EqualError(t, err, "malformed ciphertext")
+}
+
+func TestDecodeEnvelope(t *testing.T) {
+	testCases := []struct {
+		name string
+		have []byte
+		ok   bool
+	}{
+		{"ShouldDecode", append(append([]byte{}, envelopeMagic...), 1, 'a', 0xff), true},
+		{"ShouldNotDecodeEmpty", nil, false},
+		{"ShouldNotDecodeMagicOnly", envelopeMagic, false},
+		{"ShouldNotDecodeWrongMagic", []byte{0x00, 'A', 'E', 0x02, 1, 'a'}, false},
+		{"ShouldNotDecodeZeroLengthID", append(append([]byte{}, envelopeMagic...), 0, 'a'), false},
+		{"ShouldNotDecodeTruncatedID", append(append([]byte{}, envelopeMagic...), 5, 'a'), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, _, ok := decodeEnvelope(tc.have)
+
+			assert.Equal(t, tc.ok, ok)
+		})
+	}
+}
+
+func TestSQLProviderEncryptDecrypt(t *testing.T) {
+	provider := &SQLProvider{
+		keys: SQLProviderKeys{
+			encryption: sha256.Sum256([]byte("a_very_important_secret")),
+		},
+	}
+
+	legacy, err := provider.encrypt([]byte("legacy"))
+	require.NoError(t, err)
+
+	_, _, _, ok := decodeEnvelope(legacy)
+	assert.False(t, ok)
+
+	provider.keys.data = map[string]*[32]byte{"first": {1}}
+	provider.keys.current = "first"
+
+	first, err := provider.encrypt([]byte("first"))
+	require.NoError(t, err)
+
+	id, _, _, ok := decodeEnvelope(first)
+	assert.True(t, ok)
+	assert.Equal(t, "first", id)
+
+	provider.keys.data["second"] = &[32]byte{2}
+	provider.keys.current = "second"
+
+	second, err := provider.encrypt([]byte("second"))
+	require.NoError(t, err)
+
+	for value, expected := range map[string][]byte{"legacy": legacy, "first": first, "second": second} {
+		clearText, err := provider.decrypt(expected)
+		require.NoError(t, err)
+		assert.Equal(t, []byte(value), clearText)
+	}
+
+	delete(provider.keys.data, "first")
+
+	_, err = provider.decrypt(first)
+	assert.EqualError(t, err, "the value is encrypted with the data key with id 'first' which is not available")
+
+	key := sha256.Sum256([]byte("another_important_secret"))
+
+	other, err := utils.Encrypt([]byte("other"), &key)
+	require.NoError(t, err)
+
+	_, err = provider.decrypt(other)
+	assert.EqualError(t, err, "cipher: message authentication failed")
+}
@@ -35,7 +35,7 @@ const (
 
 const (
 	queryFmtSelectUserInfo = `
-		SELECT second_factor_method, (SELECT EXISTS (SELECT id FROM %s WHERE username = ?)) AS has_totp, (SELECT EXISTS (SELECT id FROM %s WHERE username = ?)) AS has_webauthn, (SELECT EXISTS (SELECT id FROM %s WHERE username = ?)) AS has_duo
+		SELECT second_factor_method, (SELECT EXISTS (SELECT id FROM %s WHERE username = ?)) AS has_totp, (SELECT EXISTS (SELECT id FROM %s WHERE username = ?)) AS has_webauthn, (SELECT EXISTS (SELECT id FROM %s WHERE username = ?)) AS has_duo, (SELECT COUNT(id) FROM %s WHERE username = ? AND used_at IS NULL) AS recovery_codes
 		FROM %s
 		WHERE username = ?;`
 
@@ -97,6 +97,11 @@ const (
 		FROM %s
 		WHERE public_id = ?;`
 
+	queryFmtSelectOTCCountByUsernameAndIntent = `
+		SELECT COUNT(id)
+		FROM %s
+		WHERE username = ? AND intent = ? AND issued > ?;`
+
 	queryFmtInsertOTC = `
 		INSERT INTO %s (public_id, signature, issued, issued_ip, expires, username, intent, code)
 		VALUES (?, ?, ?, ?, ?, ?, ?, ?);`
@@ -123,29 +128,29 @@ const (
 
 const (
 	queryFmtSelectTOTPConfiguration = `
-		SELECT id, created_at, last_used_at, username, issuer, algorithm, digits, period, secret
+		SELECT id, created_at, last_used_at, username, issuer, type, algorithm, digits, period, counter, secret
 		FROM %s
 		WHERE username = ?;`
 
 	queryFmtSelectTOTPConfigurations = `
-		SELECT id, created_at, last_used_at, username, issuer, algorithm, digits, period, secret
+		SELECT id, created_at, last_used_at, username, issuer, type, algorithm, digits, period, counter, secret
 		FROM %s
 		LIMIT ?
 		OFFSET ?;`
 
 	queryFmtUpsertTOTPConfiguration = `
-		REPLACE INTO %s (created_at, last_used_at, username, issuer, algorithm, digits, period, secret)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?);`
+		REPLACE INTO %s (created_at, last_used_at, username, issuer, type, algorithm, digits, period, counter, secret)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`
 
 	queryFmtUpsertTOTPConfigurationPostgreSQL = `
-		INSERT INTO %s (created_at, last_used_at, username, issuer, algorithm, digits, period, secret)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		INSERT INTO %s (created_at, last_used_at, username, issuer, type, algorithm, digits, period, counter, secret)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 			ON CONFLICT (username)
-			DO UPDATE SET created_at = $1, last_used_at = $2, issuer = $4, algorithm = $5, digits = $6, period = $7, secret = $8;`
+			DO UPDATE SET created_at = $1, last_used_at = $2, issuer = $4, type = $5, algorithm = $6, digits = $7, period = $8, counter = $9, secret = $10;`
 
 	queryFmtUpdateTOTPConfigRecordSignIn = `
 		UPDATE %s
-		SET last_used_at = ?
+		SET last_used_at = ?, counter = ?
 		WHERE id = ?;`
 
 	queryFmtUpdateTOTPConfigRecordSignInByUsername = `
@@ -181,23 +186,23 @@ const (
 //nolint:gosec // The following queries are not hard coded credentials.
 const (
 	queryFmtSelectWebAuthnCredentials = `
-		SELECT id, created_at, last_used_at, rpid, username, description, kid, aaguid, attestation_type, attachment, transport, sign_count, clone_warning, legacy, discoverable, present, verified, backup_eligible, backup_state, public_key
+		SELECT id, created_at, last_used_at, rpid, username, description, kid, aaguid, attestation_type, model, attachment, transport, sign_count, clone_warning, legacy, discoverable, present, verified, backup_eligible, backup_state, public_key
 		FROM %s
 		LIMIT ?
 		OFFSET ?;`
 
 	queryFmtSelectWebAuthnCredentialsByUsername = `
-		SELECT id, created_at, last_used_at, rpid, username, description, kid, aaguid, attestation_type, attachment, transport, sign_count, clone_warning, legacy, discoverable, present, verified, backup_eligible, backup_state, public_key
+		SELECT id, created_at, last_used_at, rpid, username, description, kid, aaguid, attestation_type, model, attachment, transport, sign_count, clone_warning, legacy, discoverable, present, verified, backup_eligible, backup_state, public_key
 		FROM %s
 		WHERE username = ?;`
 
 	queryFmtSelectWebAuthnCredentialsByRPIDByUsername = `
-		SELECT id, created_at, last_used_at, rpid, username, description, kid, aaguid, attestation_type, attachment, transport, sign_count, clone_warning, legacy, discoverable, present, verified, backup_eligible, backup_state, public_key
+		SELECT id, created_at, last_used_at, rpid, username, description, kid, aaguid, attestation_type, model, attachment, transport, sign_count, clone_warning, legacy, discoverable, present, verified, backup_eligible, backup_state, public_key
 		FROM %s
 		WHERE rpid = ? AND username = ?;`
 
 	queryFmtSelectWebAuthnCredentialByID = `
-		SELECT id, created_at, last_used_at, rpid, username, description, kid, aaguid, attestation_type, attachment, transport, sign_count, clone_warning, legacy, discoverable, present, verified, backup_eligible, backup_state, public_key
+		SELECT id, created_at, last_used_at, rpid, username, description, kid, aaguid, attestation_type, model, attachment, transport, sign_count, clone_warning, legacy, discoverable, present, verified, backup_eligible, backup_state, public_key
 		FROM %s
 		WHERE id = ?;`
 
@@ -214,8 +219,8 @@ const (
 		WHERE id = ?;`
 
 	queryFmtInsertWebAuthnCredential = `
-		INSERT INTO %s (created_at, last_used_at, rpid, username, description, kid, aaguid, attestation_type, attachment, transport, sign_count, clone_warning, discoverable, present, verified, backup_eligible, backup_state, public_key)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`
+		INSERT INTO %s (created_at, last_used_at, rpid, username, description, kid, aaguid, attestation_type, model, attachment, transport, sign_count, clone_warning, discoverable, present, verified, backup_eligible, backup_state, public_key)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`
 
 	queryFmtDeleteWebAuthnCredential = `
 		DELETE FROM %s
@@ -248,6 +253,11 @@ const (
 		SELECT id, rpid, username, userid
 		FROM %s
 		WHERE rpid = ? AND username = ?;`
+
+	queryFmtSelectWebAuthnUserByUserID = `
+		SELECT id, rpid, username, userid
+		FROM %s
+		WHERE rpid = ? AND userid = ?;`
 )
 
 const (
@@ -273,18 +283,258 @@ const (
 		ORDER BY id;`
 )
 
+const (
+	queryFmtUpsertKnownLogin = `
+		INSERT INTO %s (first_seen, last_seen, username, remote_ip, user_agent_hash)
+		VALUES (?, ?, ?, ?, ?)
+			ON CONFLICT (username, remote_ip, user_agent_hash)
+			DO UPDATE SET last_seen = excluded.last_seen;`
+
+	queryFmtUpsertKnownLoginMySQL = `
+		INSERT INTO %s (first_seen, last_seen, username, remote_ip, user_agent_hash)
+		VALUES (?, ?, ?, ?, ?)
+			ON DUPLICATE KEY UPDATE last_seen = VALUES(last_seen);`
+
+	queryFmtSelectKnownLoginSummary = `
+		SELECT
+			COUNT(id) AS total,
+			COALESCE(SUM(CASE WHEN remote_ip = ? THEN 1 ELSE 0 END), 0) AS remote_ip,
+			COALESCE(SUM(CASE WHEN user_agent_hash = ? THEN 1 ELSE 0 END), 0) AS user_agent
+		FROM %s
+		WHERE username = ?;`
+)
+
+const (
+	queryFmtInsertRecoveryCode = `
+		INSERT INTO %s (created_at, username, hash)
+		VALUES (?, ?, ?);`
+
+	queryFmtSelectRecoveryCodes = `
+		SELECT id, created_at, used_at, username, hash
+		FROM %s
+		WHERE username = ? AND used_at IS NULL
+		ORDER BY id ASC;`
+
+	queryFmtConsumeRecoveryCode = `
+		UPDATE %s
+		SET used_at = ?
+		WHERE id = ? AND used_at IS NULL;`
+
+	queryFmtDeleteRecoveryCodes = `
+		DELETE FROM %s
+		WHERE username = ?;`
+)
+
+const (
+	queryFmtInsertTrustedDevice = `
+		INSERT INTO %s (public_id, signature, created_at, created_ip, expires_at, username, description)
+		VALUES (?, ?, ?, ?, ?, ?, ?);`
+
+	queryFmtSelectTrustedDevice = `
+		SELECT id, public_id, signature, created_at, created_ip, last_used_at, last_used_ip, expires_at, revoked_at, username, description
+		FROM %s
+		WHERE public_id = ? AND signature = ? AND username = ?;`
+
+	queryFmtSelectTrustedDevices = `
+		SELECT id, public_id, signature, created_at, created_ip, last_used_at, last_used_ip, expires_at, revoked_at, username, description
+		FROM %s
+		WHERE username = ? AND revoked_at IS NULL AND expires_at > ?
+		ORDER BY created_at DESC;`
+
+	queryFmtUpdateTrustedDeviceSignIn = `
+		UPDATE %s
+		SET last_used_at = ?, last_used_ip = ?
+		WHERE id = ?;`
+
+	queryFmtRevokeTrustedDevice = `
+		UPDATE %s
+		SET revoked_at = ?
+		WHERE username = ? AND public_id = ? AND revoked_at IS NULL;`
+
+	queryFmtRevokeTrustedDevices = `
+		UPDATE %s
+		SET revoked_at = ?
+		WHERE username = ? AND revoked_at IS NULL;`
+)
+
+const (
+	queryFmtInsertQueuedNotification = `
+		INSERT INTO %s (created_at, next_attempt_at, attempts, status, recipient, subject, template, data)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?);`
+
+	queryFmtSelectQueuedNotificationsDue = `
+		SELECT id, created_at, next_attempt_at, sent_at, attempts, status, recipient, subject, template, data, last_error
+		FROM %s
+		WHERE status = ? AND next_attempt_at <= ?
+		ORDER BY next_attempt_at ASC
+		LIMIT ?;`
+
+	queryFmtSelectQueuedNotificationsByStatus = `
+		SELECT id, created_at, next_attempt_at, sent_at, attempts, status, recipient, subject, template, last_error
+		FROM %s
+		WHERE status = ?
+		ORDER BY id DESC
+		LIMIT ?
+		OFFSET ?;`
+
+	queryFmtClaimQueuedNotification = `
+		UPDATE %s
+		SET attempts = attempts + 1, next_attempt_at = ?
+		WHERE id = ? AND status = ? AND attempts = ?;`
+
+	queryFmtUpdateQueuedNotificationStatus = `
+		UPDATE %s
+		SET status = ?, next_attempt_at = ?, sent_at = ?, last_error = ?
+		WHERE id = ?;`
+
+	queryFmtRetryQueuedNotification = `
+		UPDATE %s
+		SET status = ?, attempts = 0, next_attempt_at = ?, last_error = NULL
+		WHERE id = ? AND status = ?;`
+
+	queryFmtRetryQueuedNotifications = `
+		UPDATE %s
+		SET status = ?, attempts = 0, next_attempt_at = ?, last_error = NULL
+		WHERE status = ?;`
+
+	queryFmtDeleteQueuedNotifications = `
+		DELETE FROM %s
+		WHERE status = ? AND created_at < ?;`
+
+	queryFmtSelectQueuedNotificationsEncryptedData = `
+		SELECT id, data
+		FROM %s;`
+
+	queryFmtUpdateQueuedNotificationEncryptedData = `
+		UPDATE %s
+		SET data = ?
+		WHERE id = ?;`
+)
+
 const (
 	queryFmtInsertAuthenticationLogEntry = `
 		INSERT INTO %s (time, successful, banned, username, auth_type, remote_ip, request_uri, request_method)
 		VALUES (?, ?, ?, ?, ?, ?, ?, ?);`
 
-	queryFmtSelect1FAAuthenticationLogEntryByUsername = `
+	queryFmtSelectRegulationAuthenticationLogEntriesByUsername = `
 		SELECT time, successful, username
 		FROM %s
-		WHERE time > ? AND username = ? AND auth_type = '1FA' AND banned = FALSE
+		WHERE time > ? AND username = ? AND banned = FALSE
 		ORDER BY time DESC
 		LIMIT ?
 		OFFSET ?;`
+
+	queryFmtSelectAuthenticationLogEntriesByUsername = `
+		SELECT id, time, successful, banned, username, auth_type, remote_ip, request_uri, request_method
+		FROM %s
+		WHERE time > ? AND username = ?
+		ORDER BY time DESC
+		LIMIT ?
+		OFFSET ?;`
+
+	queryFmtSelectAuthenticationLogEntriesByRemoteIP = `
+		SELECT id, time, successful, banned, username, auth_type, remote_ip, request_uri, request_method
+		FROM %s
+		WHERE time > ? AND remote_ip = ?
+		ORDER BY time DESC
+		LIMIT ?
+		OFFSET ?;`
+
+	queryFmtSelectRegulationAuthenticationLogEntries = `
+		SELECT a.time, a.successful, a.username
+		FROM %s AS a
+		WHERE a.time > ? AND a.banned = FALSE AND a.username IN (
+			SELECT username
+			FROM %s
+			WHERE time > ? AND successful = FALSE AND banned = FALSE
+			GROUP BY username
+			HAVING COUNT(id) >= ?
+		) AND NOT EXISTS (
+			SELECT 1
+			FROM %s AS b
+			WHERE b.username = a.username AND b.revoked IS NOT NULL AND b.revoked >= a.time
+		) AND NOT EXISTS (
+			SELECT 1
+			FROM %s AS l
+			WHERE l.username = a.username AND l.unlocked IS NOT NULL AND l.unlocked >= a.time
+		)
+		ORDER BY a.username ASC, a.time DESC
+		LIMIT ?
+		OFFSET ?;`
+)
+
+const (
+	queryFmtInsertRegulationLockout = `
+		INSERT INTO %s (locked, locked_ip, username, bans)
+		VALUES (?, ?, ?, ?);`
+
+	queryFmtSelectLatestRegulationLockoutByUsername = `
+		SELECT id, locked, locked_ip, username, bans, unlocked, unlocked_method
+		FROM %s
+		WHERE username = ?
+		ORDER BY locked DESC
+		LIMIT 1;`
+
+	queryFmtUpdateRegulationLockoutUnlockByUsername = `
+		UPDATE %s
+		SET unlocked = ?, unlocked_method = ?
+		WHERE username = ? AND unlocked IS NULL;`
+
+	queryFmtSelectActiveRegulationLockouts = `
+		SELECT id, locked, locked_ip, username, bans, unlocked, unlocked_method
+		FROM %s
+		WHERE unlocked IS NULL
+		ORDER BY locked DESC
+		LIMIT ?
+		OFFSET ?;`
+)
+
+const (
+	queryFmtInsertBannedUser = `
+		INSERT INTO %s (time, expires, revoked, username, source, reason)
+		VALUES (?, ?, ?, ?, ?, ?);`
+
+	queryFmtSelectBannedUserByUsername = `
+		SELECT id, time, expires, revoked, username, source, reason
+		FROM %s
+		WHERE username = ? AND ((revoked IS NULL AND (expires IS NULL OR expires > ?)) OR revoked > ?)
+		ORDER BY time DESC;`
+
+	queryFmtSelectActiveBannedUsers = `
+		SELECT id, time, expires, revoked, username, source, reason
+		FROM %s
+		WHERE revoked IS NULL AND (expires IS NULL OR expires > ?)
+		ORDER BY time DESC
+		LIMIT ?
+		OFFSET ?;`
+
+	queryFmtRevokeBannedUserByUsername = `
+		UPDATE %s
+		SET revoked = ?
+		WHERE username = ? AND revoked IS NULL AND (expires IS NULL OR expires > ?);`
+
+	queryFmtInsertBannedIP = `
+		INSERT INTO %s (time, expires, revoked, ip, source, reason)
+		VALUES (?, ?, ?, ?, ?, ?);`
+
+	queryFmtSelectActiveBannedIPByIP = `
+		SELECT id, time, expires, revoked, ip, source, reason
+		FROM %s
+		WHERE ip = ? AND revoked IS NULL AND (expires IS NULL OR expires > ?)
+		ORDER BY time DESC;`
+
+	queryFmtSelectActiveBannedIPs = `
+		SELECT id, time, expires, revoked, ip, source, reason
+		FROM %s
+		WHERE revoked IS NULL AND (expires IS NULL OR expires > ?)
+		ORDER BY time DESC
+		LIMIT ?
+		OFFSET ?;`
+
+	queryFmtRevokeBannedIPByIP = `
+		UPDATE %s
+		SET revoked = ?
+		WHERE ip = ? AND revoked IS NULL AND (expires IS NULL OR expires > ?);`
 )
 
 const (
@@ -309,7 +559,24 @@ const (
 
 	queryFmtUpdateEncryptionEncryptedData = `
 		UPDATE %s
-		SET value = ?`
+		SET value = ?
+		WHERE id = ?;`
+)
+
+const (
+	queryFmtSelectEncryptionKeys = `
+		SELECT id, key_id, kek_provider, kek_id, wrapped_key
+		FROM %s
+		ORDER BY id ASC;`
+
+	queryFmtInsertEncryptionKey = `
+		INSERT INTO %s (key_id, kek_provider, kek_id, wrapped_key, created_at)
+		VALUES (?, ?, ?, ?, ?);`
+
+	queryFmtUpdateEncryptionKeyWrappedKey = `
+		UPDATE %s
+		SET kek_id = ?, wrapped_key = ?, rotated_at = ?
+		WHERE id = ?;`
 )
 
 const (
@@ -354,6 +621,11 @@ const (
 		SET granted = TRUE
 		WHERE id = ? AND responded_at IS NOT NULL;`
 
+	queryFmtSelectOAuth2ConsentSessionsAuthorizedCount = `
+		SELECT COUNT(id)
+		FROM %s
+		WHERE client_id = ? AND subject = ? AND authorized = TRUE;`
+
 	queryFmtSelectOAuth2Session = `
 		SELECT id, challenge_id, request_id, client_id, signature, subject, requested_at,
 		requested_scopes, granted_scopes, requested_audience, granted_audience,
@@ -451,3 +723,48 @@ const (
 		SELECT id, service, sector_id, username, identifier
 		FROM %s;`
 )
+
+const (
+	queryFmtSelectBackupRows = `
+		SELECT *
+		FROM %s;`
+
+	queryFmtSelectBackupColumns = `
+		SELECT %s
+		FROM %s
+		WHERE 1 = 0;`
+
+	queryFmtSelectTableRowCount = `
+		SELECT COUNT(*)
+		FROM %s;`
+
+	queryFmtDeleteBackupRows = `
+		DELETE FROM %s;`
+
+	queryFmtInsertBackupRow = `
+		INSERT INTO %s (%s)
+		VALUES (%s);`
+
+	queryFmtPostgreSQLResetSequence = `
+		SELECT setval(pg_get_serial_sequence('%s', 'id'), MAX(id))
+		FROM %s;`
+)
+
+const (
+	queryFmtSelectPrunableCount = `
+		SELECT COUNT(id)
+		FROM %s
+		WHERE %s < ?;`
+
+	queryFmtDeletePrunable = `
+		DELETE FROM %s
+		WHERE id IN (
+			SELECT id FROM (
+				SELECT id
+				FROM %s
+				WHERE %s < ?
+				ORDER BY id
+				LIMIT ?
+			) AS prunable
+		);`
+)
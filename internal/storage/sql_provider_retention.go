@@ -0,0 +1,38 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// CountPrunable returns the number of rows in the storage provider which have exceeded the retention period of the
+// target at the given time.
+func (p *SQLProvider) CountPrunable(ctx context.Context, target PruneTarget, before time.Time) (count int64, err error) {
+	if err = target.validate(); err != nil {
+		return 0, err
+	}
+
+	if err = p.db.GetContext(ctx, &count, p.db.Rebind(fmt.Sprintf(queryFmtSelectPrunableCount, target.Table, target.Column)), before); err != nil {
+		return 0, fmt.Errorf("error counting prunable rows in table '%s': %w", target.Table, err)
+	}
+
+	return count, nil
+}
+
+// Prune deletes up to limit rows from the storage provider which have exceeded the retention period of the target at
+// the given time.
+func (p *SQLProvider) Prune(ctx context.Context, target PruneTarget, before time.Time, limit int) (affected int64, err error) {
+	if err = target.validate(); err != nil {
+		return 0, err
+	}
+
+	var result sql.Result
+
+	if result, err = p.db.ExecContext(ctx, p.db.Rebind(fmt.Sprintf(queryFmtDeletePrunable, target.Table, target.Table, target.Column)), before, limit); err != nil {
+		return 0, fmt.Errorf("error pruning rows from table '%s': %w", target.Table, err)
+	}
+
+	return result.RowsAffected()
+}
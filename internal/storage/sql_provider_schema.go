@@ -183,6 +183,14 @@ func (p *SQLProvider) SchemaMigrate(ctx context.Context, up bool, version int) (
 		return err
 	}
 
+	if err = p.schemaMigrateCheckDataKeys(ctx, conn, up, version, currentVersion); err != nil {
+		if tx != nil {
+			_ = tx.Rollback()
+		}
+
+		return err
+	}
+
 	if err = p.schemaMigrate(ctx, conn, currentVersion, version); err != nil {
 		if tx != nil && err == ErrNoMigrationsFound {
 			_ = tx.Rollback()
@@ -233,6 +241,26 @@ func (p *SQLProvider) schemaMigrate(ctx context.Context, conn SQLXConnection, pr
 	return nil
 }
 
+// schemaMigrateCheckDataKeys prevents migrating down to a schema version which doesn't support data keys when values
+// are encrypted with a data key, as those values would no longer be able to be decrypted.
+func (p *SQLProvider) schemaMigrateCheckDataKeys(ctx context.Context, conn SQLXConnection, up bool, targetVersion, currentVersion int) (err error) {
+	if up || targetVersion >= schemaVersionEncryptionKeys || currentVersion < schemaVersionEncryptionKeys {
+		return nil
+	}
+
+	var count int
+
+	if err = sqlx.GetContext(ctx, conn, &count, fmt.Sprintf(queryFmtSelectRowCount, tableEncryptionKey)); err != nil {
+		return fmt.Errorf("error counting data keys: %w", err)
+	}
+
+	if count != 0 {
+		return fmt.Errorf(errFmtMigrateDownDataKeys, targetVersion)
+	}
+
+	return nil
+}
+
 func (p *SQLProvider) schemaMigrateLock(ctx context.Context, conn SQLXConnection) (err error) {
 	if p.name != providerPostgres {
 		return nil
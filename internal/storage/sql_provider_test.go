@@ -0,0 +1,99 @@
+package storage
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/model"
+)
+
+func TestSQLProviderShouldLoadRegulationAuthenticationLogsOfEveryType(t *testing.T) {
+	provider := newTestSQLiteProvider(t)
+
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+
+	attempts := []model.AuthenticationAttempt{
+		{Time: now.Add(-time.Second * 5), Username: "john", Type: "1FA"},
+		{Time: now.Add(-time.Second * 4), Username: "john", Type: "TOTP"},
+		{Time: now.Add(-time.Second * 3), Username: "john", Type: "Recovery"},
+		{Time: now.Add(-time.Second * 2), Username: "john", Type: "Email", Banned: true},
+		{Time: now.Add(-time.Second * 1), Username: "harry", Type: "WebAuthn"},
+	}
+
+	for _, attempt := range attempts {
+		require.NoError(t, provider.AppendAuthenticationLog(ctx, attempt))
+	}
+
+	results, err := provider.LoadAuthenticationLogs(ctx, "john", now.Add(-time.Minute), 10, 0)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+
+	assert.Equal(t, "Recovery", results[0].Type)
+	assert.Equal(t, "TOTP", results[1].Type)
+	assert.Equal(t, "1FA", results[2].Type)
+
+	results, err = provider.LoadAuthenticationLogsRegulated(ctx, now.Add(-time.Minute), 3, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+
+	for _, result := range results {
+		assert.Equal(t, "john", result.Username)
+	}
+}
+
+func TestSQLProviderShouldUpsertKnownLogin(t *testing.T) {
+	provider := newTestSQLiteProvider(t)
+
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Second)
+	ip := model.NewIP(net.ParseIP("192.168.0.1"))
+
+	login := model.KnownLogin{FirstSeen: now.Add(-time.Hour), LastSeen: now.Add(-time.Hour), Username: "john", RemoteIP: ip, UserAgentHash: "abc"}
+
+	require.NoError(t, provider.SaveKnownLogin(ctx, login))
+
+	// Saving the same values again must not fail as would happen if the update affected no rows and an insert followed.
+	require.NoError(t, provider.SaveKnownLogin(ctx, login))
+
+	login.FirstSeen, login.LastSeen = now, now
+
+	require.NoError(t, provider.SaveKnownLogin(ctx, login))
+
+	summary, err := provider.LoadKnownLoginSummary(ctx, "john", ip, "abc")
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, summary.Total)
+
+	var logins []model.KnownLogin
+
+	require.NoError(t, provider.db.SelectContext(ctx, &logins, "SELECT id, first_seen, last_seen, username, remote_ip, user_agent_hash FROM known_login;"))
+	require.Len(t, logins, 1)
+
+	assert.Equal(t, now.Add(-time.Hour), logins[0].FirstSeen.UTC())
+	assert.Equal(t, now, logins[0].LastSeen.UTC())
+}
+
+func newTestSQLiteProvider(t *testing.T) *SQLiteProvider {
+	provider := NewSQLiteProvider(&schema.Configuration{
+		Storage: schema.Storage{
+			EncryptionKey: "a_not_so_secure_encryption_key",
+			Local:         &schema.StorageLocal{Path: filepath.Join(t.TempDir(), "db.sqlite3")},
+		},
+	})
+
+	require.NoError(t, provider.StartupCheck())
+
+	t.Cleanup(func() {
+		_ = provider.Close()
+	})
+
+	return provider
+}
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	"time"
 
 	"github.com/jmoiron/sqlx"
 )
@@ -21,8 +22,12 @@ type SQLXConnection interface {
 	sqlx.ExtContext
 }
 
-// EncryptionChangeKeyFunc handles encryption key changes for a specific table or tables.
-type EncryptionChangeKeyFunc func(ctx context.Context, provider *SQLProvider, tx *sqlx.Tx, key [32]byte) (err error)
+// EncryptionChangeKeyFunc handles encryption key changes for a specific table or tables. The values are decrypted with
+// the current keys of the provider and encrypted again with the encrypt func.
+type EncryptionChangeKeyFunc func(ctx context.Context, provider *SQLProvider, tx *sqlx.Tx, encrypt EncryptFunc) (err error)
+
+// EncryptFunc encrypts a value.
+type EncryptFunc func(clearText []byte) (cipherText []byte, err error)
 
 // EncryptionCheckKeyFunc handles encryption key checking for a specific table or tables.
 type EncryptionCheckKeyFunc func(ctx context.Context, provider *SQLProvider) (table string, result EncryptionValidationTableResult)
@@ -47,11 +52,31 @@ type encOneTimeCode struct {
 	Code []byte `db:"code"`
 }
 
+type encQueuedNotification struct {
+	ID   int    `db:"id"`
+	Data []byte `db:"data"`
+}
+
 type encEncryption struct {
 	ID    int    `db:"id"`
 	Value []byte `db:"value"`
 }
 
+type encDataKey struct {
+	ID          int    `db:"id"`
+	KeyID       string `db:"key_id"`
+	KEKProvider string `db:"kek_provider"`
+	KEKID       string `db:"kek_id"`
+	WrappedKey  []byte `db:"wrapped_key"`
+}
+
+// DataKeyRotationResult contains information about the data keys rewrapped by a key-encryption key rotation.
+type DataKeyRotationResult struct {
+	Provider string
+	KEKID    string
+	Keys     []string
+}
+
 // EncryptionValidationResult contains information about the success of a schema encryption validation.
 type EncryptionValidationResult struct {
 	InvalidCheckValue bool
@@ -156,3 +181,60 @@ func (s OAuth2SessionType) Table() string {
 		return ""
 	}
 }
+
+// BackupHeader is the header of a backup archive.
+type BackupHeader struct {
+	Version       int       `json:"version"`
+	SchemaVersion int       `json:"schema_version"`
+	Provider      string    `json:"provider"`
+	Created       time.Time `json:"created"`
+	Tables        []string  `json:"tables"`
+}
+
+// BackupResult contains information about a backup archive which was written or restored.
+type BackupResult struct {
+	Header BackupHeader
+	Tables []BackupTableResult
+}
+
+// Rows returns the total number of rows in all tables of the result.
+func (r BackupResult) Rows() (rows int) {
+	for _, table := range r.Tables {
+		rows += table.Rows
+	}
+
+	return rows
+}
+
+// BackupTableResult contains information about a single table which was written to or restored from a backup archive.
+type BackupTableResult struct {
+	Name string
+	Rows int
+}
+
+// backupRecord is a single line of a backup archive. Exactly one of the fields is set.
+type backupRecord struct {
+	Header *BackupHeader   `json:"header,omitempty"`
+	Table  *backupTable    `json:"table,omitempty"`
+	Row    []any           `json:"row,omitempty"`
+	End    *backupTableEnd `json:"end,omitempty"`
+}
+
+type backupTable struct {
+	Name    string         `json:"name"`
+	Columns []backupColumn `json:"columns"`
+}
+
+type backupColumn struct {
+	Name string     `json:"name"`
+	Kind backupKind `json:"kind"`
+}
+
+type backupTableEnd struct {
+	Name string `json:"name"`
+	Rows int    `json:"rows"`
+}
+
+// backupKind is the normalized kind of value stored in a column which allows values to be converted between the
+// column types of the different storage providers.
+type backupKind int
@@ -14,6 +14,34 @@ const (
 	TemplateNameOIDCAuthorizeFormPost = "AuthorizeResponseFormPost.html"
 )
 
+// Event Template File Names. These templates are optional and if they don't exist in the template path the generic
+// event template is used instead.
+const (
+	TemplateNameEmailEventOneTimePasswordAdded        = "EventOneTimePasswordAdded"
+	TemplateNameEmailEventOneTimePasswordRemoved      = "EventOneTimePasswordRemoved"
+	TemplateNameEmailEventWebAuthnCredentialAdded     = "EventWebAuthnCredentialAdded"
+	TemplateNameEmailEventWebAuthnCredentialRemoved   = "EventWebAuthnCredentialRemoved"
+	TemplateNameEmailEventDuoDeviceChanged            = "EventDuoDeviceChanged"
+	TemplateNameEmailEventNewLogin                    = "EventNewLogin"
+	TemplateNameEmailEventAccountLocked               = "EventAccountLocked"
+	TemplateNameEmailEventOpenIDConnectConsentGranted = "EventOpenIDConnectConsentGranted"
+	TemplateNameEmailEventRecoveryCodeUsed            = "EventRecoveryCodeUsed"
+	TemplateNameEmailEventRecoveryCodesGenerated      = "EventRecoveryCodesGenerated"
+)
+
+var templateNamesEmailEvents = []string{
+	TemplateNameEmailEventOneTimePasswordAdded,
+	TemplateNameEmailEventOneTimePasswordRemoved,
+	TemplateNameEmailEventWebAuthnCredentialAdded,
+	TemplateNameEmailEventWebAuthnCredentialRemoved,
+	TemplateNameEmailEventDuoDeviceChanged,
+	TemplateNameEmailEventNewLogin,
+	TemplateNameEmailEventAccountLocked,
+	TemplateNameEmailEventOpenIDConnectConsentGranted,
+	TemplateNameEmailEventRecoveryCodeUsed,
+	TemplateNameEmailEventRecoveryCodesGenerated,
+}
+
 // Template Category Names.
 const (
 	TemplateCategoryNotifications = "notification"
@@ -5,6 +5,7 @@ import (
 	th "html/template"
 	"io/fs"
 	"path"
+	"sync"
 	tt "text/template"
 )
 
@@ -25,6 +26,27 @@ func New(config Config) (provider *Provider, err error) {
 type Provider struct {
 	config    Config
 	templates Templates
+
+	mu sync.RWMutex
+}
+
+// Reload loads the templates using the provided Config and replaces the existing notification templates if they were
+// all loaded successfully, otherwise the existing templates are retained.
+func (p *Provider) Reload(config Config) (err error) {
+	var provider *Provider
+
+	if provider, err = New(config); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+
+	defer p.mu.Unlock()
+
+	p.config = config
+	p.templates.notification = provider.templates.notification
+
+	return nil
 }
 
 // LoadTemplatedAssets takes an embed.FS and loads each templated asset document into a Template.
@@ -86,19 +108,66 @@ func (p *Provider) GetAssetOpenAPISpecTemplate() (t Template) {
 
 // GetIdentityVerificationJWTEmailTemplate returns the EmailTemplate for Identity Verification notifications.
 func (p *Provider) GetIdentityVerificationJWTEmailTemplate() (t *EmailTemplate) {
+	p.mu.RLock()
+
+	defer p.mu.RUnlock()
+
 	return p.templates.notification.jwtIdentityVerification
 }
 
 // GetIdentityVerificationOTCEmailTemplate returns the EmailTemplate for Identity Verification notifications.
 func (p *Provider) GetIdentityVerificationOTCEmailTemplate() (t *EmailTemplate) {
+	p.mu.RLock()
+
+	defer p.mu.RUnlock()
+
 	return p.templates.notification.otcIdentityVerification
 }
 
 // GetEventEmailTemplate returns an EmailTemplate used for generic event notifications.
 func (p *Provider) GetEventEmailTemplate() (t *EmailTemplate) {
+	p.mu.RLock()
+
+	defer p.mu.RUnlock()
+
+	return p.templates.notification.event
+}
+
+// GetEventEmailTemplateByName returns the EmailTemplate used for a specific event notification. If the name is not a
+// known event template name the generic event EmailTemplate is returned.
+func (p *Provider) GetEventEmailTemplateByName(name string) (t *EmailTemplate) {
+	p.mu.RLock()
+
+	defer p.mu.RUnlock()
+
+	var ok bool
+
+	if t, ok = p.templates.notification.events[name]; ok {
+		return t
+	}
+
 	return p.templates.notification.event
 }
 
+// GetEmailTemplateByName returns the EmailTemplate with the given name as returned by EmailTemplate.Name, or nil if
+// no EmailTemplate exists with the name.
+func (p *Provider) GetEmailTemplateByName(name string) (t *EmailTemplate) {
+	p.mu.RLock()
+
+	defer p.mu.RUnlock()
+
+	switch name {
+	case TemplateNameEmailIdentityVerificationJWT:
+		return p.templates.notification.jwtIdentityVerification
+	case TemplateNameEmailIdentityVerificationOTC:
+		return p.templates.notification.otcIdentityVerification
+	case TemplateNameEmailEvent:
+		return p.templates.notification.event
+	default:
+		return p.templates.notification.events[name]
+	}
+}
+
 // GetOpenIDConnectAuthorizeResponseFormPostTemplate returns a Template used to generate the OpenID Connect 1.0 Form Post Authorize Response.
 func (p *Provider) GetOpenIDConnectAuthorizeResponseFormPostTemplate() (t *th.Template) {
 	return p.templates.oidc.formpost
@@ -119,6 +188,14 @@ func (p *Provider) load() (err error) {
 		errs = append(errs, err)
 	}
 
+	p.templates.notification.events = make(map[string]*EmailTemplate, len(templateNamesEmailEvents))
+
+	for _, name := range templateNamesEmailEvents {
+		if p.templates.notification.events[name], err = loadEmailEventTemplate(name, p.config.EmailTemplatesPath); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
 	var data []byte
 
 	if data, err = embedFS.ReadFile(path.Join("src", TemplateCategoryOpenIDConnect, TemplateNameOIDCAuthorizeFormPost)); err != nil {
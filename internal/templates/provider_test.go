@@ -0,0 +1,117 @@
+package templates
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviderGetEventEmailTemplateByName(t *testing.T) {
+	dir := t.TempDir()
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, TemplateNameEmailEventNewLogin+extText), []byte("New login from {{ .RemoteIP }}"), 0600))
+
+	provider, err := New(Config{EmailTemplatesPath: dir})
+	require.NoError(t, err)
+
+	data := EmailEventValues{Title: "New Login", DisplayName: "John Smith", RemoteIP: "192.168.1.1"}
+
+	testCases := []struct {
+		name         string
+		have         string
+		expectedName string
+		expected     string
+	}{
+		{"ShouldUseOverride", TemplateNameEmailEventNewLogin, TemplateNameEmailEventNewLogin, "New login from 192.168.1.1"},
+		{"ShouldFallbackToGenericEvent", TemplateNameEmailEventAccountLocked, TemplateNameEmailEventAccountLocked, ""},
+		{"ShouldReturnGenericEventForUnknown", "EventUnknown", TemplateNameEmailEvent, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			et := provider.GetEventEmailTemplateByName(tc.have)
+			require.NotNil(t, et)
+
+			assert.Equal(t, tc.expectedName+extText, et.Text.Name())
+			assert.Equal(t, tc.expectedName+extHTML, et.HTML.Name())
+
+			buf := &bytes.Buffer{}
+
+			require.NoError(t, et.Text.Execute(buf, data))
+
+			if tc.expected == "" {
+				assert.Contains(t, buf.String(), "John Smith")
+			} else {
+				assert.Equal(t, tc.expected, buf.String())
+			}
+
+			buf.Reset()
+
+			require.NoError(t, et.HTML.Execute(buf, data))
+			assert.Contains(t, buf.String(), "John Smith")
+		})
+	}
+}
+
+func TestProviderGetEmailTemplateByName(t *testing.T) {
+	provider, err := New(Config{})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name string
+		have string
+	}{
+		{"ShouldReturnIdentityVerificationJWT", TemplateNameEmailIdentityVerificationJWT},
+		{"ShouldReturnIdentityVerificationOTC", TemplateNameEmailIdentityVerificationOTC},
+		{"ShouldReturnEvent", TemplateNameEmailEvent},
+		{"ShouldReturnEventNewLogin", TemplateNameEmailEventNewLogin},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			et := provider.GetEmailTemplateByName(tc.have)
+			require.NotNil(t, et)
+
+			assert.Equal(t, tc.have, et.Name())
+		})
+	}
+
+	assert.Nil(t, provider.GetEmailTemplateByName("Unknown"))
+	assert.Equal(t, "", provider.GetEmailTemplateByName("Unknown").Name())
+}
+
+func TestProviderReload(t *testing.T) {
+	dir := t.TempDir()
+
+	provider, err := New(Config{})
+	require.NoError(t, err)
+
+	data := EmailEventValues{Title: "New Login", DisplayName: "John Smith", RemoteIP: "192.168.1.1"}
+
+	buf := &bytes.Buffer{}
+
+	require.NoError(t, provider.GetEventEmailTemplateByName(TemplateNameEmailEventNewLogin).Text.Execute(buf, data))
+	assert.Contains(t, buf.String(), "John Smith")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, TemplateNameEmailEventNewLogin+extText), []byte("New login from {{ .RemoteIP }}"), 0600))
+
+	require.NoError(t, provider.Reload(Config{EmailTemplatesPath: dir}))
+
+	buf.Reset()
+
+	require.NoError(t, provider.GetEventEmailTemplateByName(TemplateNameEmailEventNewLogin).Text.Execute(buf, data))
+	assert.Equal(t, "New login from 192.168.1.1", buf.String())
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, TemplateNameEmailEventNewLogin+extText), []byte("New login from {{ .RemoteIP "), 0600))
+
+	assert.Error(t, provider.Reload(Config{EmailTemplatesPath: dir}))
+
+	buf.Reset()
+
+	require.NoError(t, provider.GetEventEmailTemplateByName(TemplateNameEmailEventNewLogin).Text.Execute(buf, data))
+	assert.Equal(t, "New login from 192.168.1.1", buf.String())
+}
@@ -3,6 +3,8 @@ package templates
 import (
 	th "html/template"
 	"io"
+	"path"
+	"strings"
 	tt "text/template"
 )
 
@@ -34,6 +36,7 @@ type NotificationTemplates struct {
 	jwtIdentityVerification *EmailTemplate
 	otcIdentityVerification *EmailTemplate
 	event                   *EmailTemplate
+	events                  map[string]*EmailTemplate
 }
 
 // Template covers shared implementations between the text and html template.Template.
@@ -55,6 +58,15 @@ type EmailTemplate struct {
 	Text *tt.Template
 }
 
+// Name returns the name of the EmailTemplate without the file extension.
+func (et *EmailTemplate) Name() string {
+	if et == nil || et.Text == nil {
+		return ""
+	}
+
+	return strings.TrimSuffix(et.Text.Name(), path.Ext(et.Text.Name()))
+}
+
 // EmailEventValues are the values used for event templates.
 type EmailEventValues struct {
 	Title       string
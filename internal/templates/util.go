@@ -120,6 +120,44 @@ func loadEmailTemplate(name, overridePath string) (t *EmailTemplate, err error)
 	return t, nil
 }
 
+// loadEmailEventTemplate loads an event EmailTemplate using the override for the specific event if it exists, otherwise
+// the generic event template is used. The resulting template always uses the specific event name.
+func loadEmailEventTemplate(name, overridePath string) (t *EmailTemplate, err error) {
+	var (
+		embed bool
+		tpath string
+		data  []byte
+	)
+
+	t = &EmailTemplate{}
+
+	if tpath, embed, data, err = readEmailEventTemplate(name, extText, overridePath); err != nil {
+		return nil, err
+	}
+
+	if t.Text, err = parseTextTemplate(name, tpath, embed, data); err != nil {
+		return nil, err
+	}
+
+	if tpath, embed, data, err = readEmailEventTemplate(name, extHTML, overridePath); err != nil {
+		return nil, err
+	}
+
+	if t.HTML, err = parseHTMLTemplate(name, tpath, embed, data); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func readEmailEventTemplate(name, ext, overridePath string) (tPath string, embed bool, data []byte, err error) {
+	if overridePath != "" && fileExists(filepath.Join(overridePath, name+ext)) {
+		return readTemplate(name, ext, TemplateCategoryNotifications, overridePath)
+	}
+
+	return readTemplate(TemplateNameEmailEvent, ext, TemplateCategoryNotifications, overridePath)
+}
+
 func strval(v any) string {
 	switch v := v.(type) {
 	case string:
@@ -0,0 +1,6 @@
+package totp
+
+const (
+	steamAlphabet   = "23456789BCDFGHJKMNPQRTVWXY"
+	steamCodeLength = 5
+)
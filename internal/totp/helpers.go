@@ -1,6 +1,12 @@
 package totp
 
 import (
+	"crypto/hmac"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"strings"
+
 	"github.com/authelia/otp"
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
@@ -18,3 +24,35 @@ func otpStringToAlgo(in string) (algorithm otp.Algorithm) {
 		return otp.AlgorithmSHA1
 	}
 }
+
+// generateSteamCode generates a Steam Guard code which uses the HOTP dynamic truncation with a custom alphanumeric
+// alphabet instead of decimal digits.
+func generateSteamCode(secret string, counter uint64, algorithm otp.Algorithm) (code string, err error) {
+	var key []byte
+
+	if key, err = base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(strings.ToUpper(strings.TrimRight(strings.TrimSpace(secret), "="))); err != nil {
+		return "", fmt.Errorf("error decoding base32 string: %w", err)
+	}
+
+	buf := make([]byte, 8)
+
+	binary.BigEndian.PutUint64(buf, counter)
+
+	mac := hmac.New(algorithm.Hash, key)
+
+	mac.Write(buf)
+
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0xf
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	out := make([]byte, steamCodeLength)
+
+	for i := range out {
+		out[i] = steamAlphabet[value%uint32(len(steamAlphabet))]
+		value /= uint32(len(steamAlphabet))
+	}
+
+	return string(out), nil
+}
@@ -1,10 +1,13 @@
 package totp
 
 import (
+	"crypto/subtle"
 	"encoding/base32"
 	"fmt"
+	"strings"
 
 	"github.com/authelia/otp"
+	"github.com/authelia/otp/hotp"
 	"github.com/authelia/otp/totp"
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
@@ -28,6 +31,12 @@ func NewTimeBasedProvider(config schema.TOTP) (provider *TimeBased) {
 		provider.skew = 1
 	}
 
+	if config.HOTPLookAhead != nil {
+		provider.lookAhead = uint64(*config.HOTPLookAhead)
+	} else {
+		provider.lookAhead = 10
+	}
+
 	return provider
 }
 
@@ -52,6 +61,7 @@ type TimeBased struct {
 	period    uint
 	skew      uint
 	size      uint
+	lookAhead uint64
 }
 
 // GenerateCustom generates a TOTP with custom options.
@@ -89,6 +99,7 @@ func (p TimeBased) GenerateCustom(ctx Context, username, algorithm, secret strin
 		CreatedAt: ctx.GetClock().Now(),
 		Username:  username,
 		Issuer:    p.issuer,
+		Type:      model.OneTimePasswordTypeTOTP,
 		Algorithm: algorithm,
 		Digits:    digits,
 		Secret:    []byte(key.Secret()),
@@ -103,16 +114,78 @@ func (p TimeBased) Generate(ctx Context, username string) (config *model.TOTPCon
 	return p.GenerateCustom(ctx, username, p.algorithm, "", p.digits, p.period, p.size)
 }
 
-// Validate the token against the given configuration.
+// Validate the token against the given configuration. The returned step is the time step for time-based
+// configurations and the counter for counter-based configurations.
 func (p TimeBased) Validate(ctx Context, token string, config *model.TOTPConfiguration) (valid bool, step uint64, err error) {
-	opts := totp.ValidateOpts{
-		Period:    config.Period,
-		Skew:      p.skew,
+	switch config.Type {
+	case model.OneTimePasswordTypeHOTP:
+		return p.validateCounter(token, config)
+	case model.OneTimePasswordTypeSteam:
+		return p.validateSteam(ctx, token, config)
+	default:
+		opts := totp.ValidateOpts{
+			Period:    config.Period,
+			Skew:      p.skew,
+			Digits:    otp.Digits(config.Digits),
+			Algorithm: otpStringToAlgo(config.Algorithm),
+		}
+
+		return totp.ValidateCustomStep(token, string(config.Secret), ctx.GetClock().Now().UTC(), opts)
+	}
+}
+
+// validateCounter validates a HOTP token against the current counter and the configured look-ahead window which
+// accounts for tokens which were generated but never used.
+func (p TimeBased) validateCounter(token string, config *model.TOTPConfiguration) (valid bool, step uint64, err error) {
+	opts := hotp.ValidateOpts{
 		Digits:    otp.Digits(config.Digits),
 		Algorithm: otpStringToAlgo(config.Algorithm),
 	}
 
-	return totp.ValidateCustomStep(token, string(config.Secret), ctx.GetClock().Now().UTC(), opts)
+	for counter := config.Counter; counter <= config.Counter+p.lookAhead; counter++ {
+		if valid, err = hotp.ValidateCustom(token, counter, string(config.Secret), opts); err != nil {
+			return false, 0, err
+		}
+
+		if valid {
+			return true, counter, nil
+		}
+	}
+
+	return false, 0, nil
+}
+
+// validateSteam validates a Steam Guard token using the same time steps and skew as a standard TOTP token.
+func (p TimeBased) validateSteam(ctx Context, token string, config *model.TOTPConfiguration) (valid bool, step uint64, err error) {
+	period := uint64(config.Period)
+
+	if period == 0 {
+		period = 30
+	}
+
+	current := uint64(ctx.GetClock().Now().UTC().Unix()) / period
+
+	steps := []uint64{current}
+
+	for i := uint64(1); i <= uint64(p.skew); i++ {
+		steps = append(steps, current+i, current-i)
+	}
+
+	token = strings.ToUpper(strings.TrimSpace(token))
+
+	for _, step = range steps {
+		var code string
+
+		if code, err = generateSteamCode(string(config.Secret), step, otpStringToAlgo(config.Algorithm)); err != nil {
+			return false, 0, err
+		}
+
+		if subtle.ConstantTimeCompare([]byte(code), []byte(token)) == 1 {
+			return true, step, nil
+		}
+	}
+
+	return false, 0, nil
 }
 
 // Options returns the configured options for this provider.
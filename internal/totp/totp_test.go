@@ -11,6 +11,7 @@ import (
 
 	"github.com/authelia/authelia/v4/internal/clock"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/model"
 	"github.com/authelia/authelia/v4/internal/random"
 )
 
@@ -156,3 +157,85 @@ func TestTOTPGenerate(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, secret, 32)
 }
+
+func TestHOTPValidate(t *testing.T) {
+	lookAhead := 2
+
+	provider := NewTimeBasedProvider(schema.TOTP{
+		Issuer:           "Authelia",
+		DefaultAlgorithm: "SHA1",
+		DefaultDigits:    6,
+		DefaultPeriod:    30,
+		HOTPLookAhead:    &lookAhead,
+		SecretSize:       32,
+	})
+
+	ctx := NewContext(context.TODO(), &clock.Real{}, &random.Cryptographical{})
+
+	// Test vectors from RFC4226 Appendix D.
+	config := &model.TOTPConfiguration{
+		Type:      model.OneTimePasswordTypeHOTP,
+		Algorithm: "SHA1",
+		Digits:    6,
+		Counter:   2,
+		Secret:    []byte("GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"),
+	}
+
+	testCases := []struct {
+		name  string
+		token string
+		valid bool
+		step  uint64
+	}{
+		{"ShouldValidateCurrentCounter", "359152", true, 2},
+		{"ShouldValidateLookAhead", "338314", true, 4},
+		{"ShouldNotValidateBeyondLookAhead", "254676", false, 0},
+		{"ShouldNotValidatePreviousCounter", "287082", false, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			valid, step, err := provider.Validate(ctx, tc.token, config)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.valid, valid)
+			assert.Equal(t, tc.step, step)
+		})
+	}
+}
+
+func TestSteamValidate(t *testing.T) {
+	provider := NewTimeBasedProvider(schema.TOTP{
+		Issuer:           "Authelia",
+		DefaultAlgorithm: "SHA1",
+		DefaultDigits:    6,
+		DefaultPeriod:    30,
+		SecretSize:       32,
+	})
+
+	ctx := NewContext(context.TODO(), clock.NewFixed(time.Unix(59, 0)), &random.Cryptographical{})
+
+	config := &model.TOTPConfiguration{
+		Type:      model.OneTimePasswordTypeSteam,
+		Algorithm: "SHA1",
+		Digits:    5,
+		Period:    30,
+		Secret:    []byte("GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"),
+	}
+
+	valid, step, err := provider.Validate(ctx, "pv9m4", config)
+
+	assert.NoError(t, err)
+	assert.True(t, valid)
+	assert.Equal(t, uint64(1), step)
+
+	valid, _, err = provider.Validate(ctx, "R87JJ", config)
+
+	assert.NoError(t, err)
+	assert.False(t, valid)
+
+	code, err := generateSteamCode(string(config.Secret), 56666666, otpStringToAlgo("SHA1"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "R87JJ", code)
+}
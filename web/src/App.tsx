@@ -11,9 +11,11 @@ import {
     ConsentRoute,
     IndexRoute,
     LogoutRoute,
+    RegulationUnlockRoute,
     ResetPasswordStep1Route,
     ResetPasswordStep2Route,
     RevokeOneTimeCodeRoute,
+    RevokeRegulationUnlockRoute,
     RevokeResetPasswordRoute,
     SettingsRoute,
 } from "@constants/Routes";
@@ -22,7 +24,13 @@ import ThemeContextProvider from "@contexts/ThemeContext";
 import NotificationsContext from "@hooks/NotificationsContext";
 import { Notification } from "@models/Notifications";
 import { getBasePath } from "@utils/BasePath";
-import { getDuoSelfEnrollment, getRememberMe, getResetPassword, getResetPasswordCustomURL } from "@utils/Configuration";
+import {
+    getDuoSelfEnrollment,
+    getPasskeyLogin,
+    getRememberMe,
+    getResetPassword,
+    getResetPasswordCustomURL,
+} from "@utils/Configuration";
 import LoadingPage from "@views/LoadingPage/LoadingPage";
 import LoginPortal from "@views/LoginPortal/LoginPortal";
 
@@ -32,8 +40,10 @@ const ConsentView = lazy(() => import("@views/LoginPortal/ConsentView/ConsentVie
 const SignOut = lazy(() => import("@views/LoginPortal/SignOut/SignOut"));
 const ResetPasswordStep1 = lazy(() => import("@views/ResetPassword/ResetPasswordStep1"));
 const ResetPasswordStep2 = lazy(() => import("@views/ResetPassword/ResetPasswordStep2"));
+const RegulationUnlockView = lazy(() => import("@views/RegulationUnlock/RegulationUnlockView"));
 const SettingsRouter = lazy(() => import("@views/Settings/SettingsRouter"));
 const RevokeOneTimeCodeView = lazy(() => import("@views/Revoke/RevokeOneTimeCodeView"));
+const RevokeRegulationUnlockTokenView = lazy(() => import("@views/Revoke/RevokeRegulationUnlockTokenView"));
 const RevokeResetPasswordTokenView = lazy(() => import("@views/Revoke/RevokeResetPasswordTokenView"));
 
 faConfig.autoAddCss = false;
@@ -63,16 +73,22 @@ const App: React.FC<Props> = (props: Props) => {
                                 <Routes>
                                     <Route path={ResetPasswordStep1Route} element={<ResetPasswordStep1 />} />
                                     <Route path={ResetPasswordStep2Route} element={<ResetPasswordStep2 />} />
+                                    <Route path={RegulationUnlockRoute} element={<RegulationUnlockView />} />
                                     <Route path={LogoutRoute} element={<SignOut />} />
                                     <Route path={ConsentRoute} element={<ConsentView />} />
                                     <Route path={RevokeOneTimeCodeRoute} element={<RevokeOneTimeCodeView />} />
                                     <Route path={RevokeResetPasswordRoute} element={<RevokeResetPasswordTokenView />} />
+                                    <Route
+                                        path={RevokeRegulationUnlockRoute}
+                                        element={<RevokeRegulationUnlockTokenView />}
+                                    />
                                     <Route path={`${SettingsRoute}/*`} element={<SettingsRouter />} />
                                     <Route
                                         path={`${IndexRoute}*`}
                                         element={
                                             <LoginPortal
                                                 duoSelfEnrollment={getDuoSelfEnrollment()}
+                                                passkeyLogin={getPasskeyLogin()}
                                                 rememberMe={getRememberMe()}
                                                 resetPassword={getResetPassword()}
                                                 resetPasswordCustomURL={getResetPasswordCustomURL()}
@@ -6,12 +6,16 @@ export const SecondFactorRoute: string = "/2fa";
 export const SecondFactorWebAuthnSubRoute: string = "/webauthn";
 export const SecondFactorTOTPSubRoute: string = "/one-time-password";
 export const SecondFactorPushSubRoute: string = "/push-notification";
+export const SecondFactorRecoveryCodeSubRoute: string = "/recovery-code";
+export const SecondFactorEmailSubRoute: string = "/email";
 
 export const ResetPasswordStep1Route: string = "/reset-password/step1";
 export const ResetPasswordStep2Route: string = "/reset-password/step2";
+export const RegulationUnlockRoute: string = "/regulation/unlock";
 export const LogoutRoute: string = "/logout";
 
 export const SettingsRoute: string = "/settings";
 export const SettingsTwoFactorAuthenticationSubRoute: string = "/two-factor-authentication";
 export const RevokeOneTimeCodeRoute: string = "/revoke/one-time-code";
 export const RevokeResetPasswordRoute: string = "/revoke/reset-password";
+export const RevokeRegulationUnlockRoute: string = "/revoke/regulation/unlock";
@@ -5,3 +5,11 @@ export const IdentityToken: string = "token";
 export const RedirectionURL: string = "rd";
 
 export const RequestMethod: string = "rm";
+
+export const AuthenticationMethodsReferences: string = "amr";
+
+export const Reauthenticate: string = "reauth";
+
+export const DuoState: string = "state";
+
+export const DuoCode: string = "duo_code";
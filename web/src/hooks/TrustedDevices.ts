@@ -0,0 +1,6 @@
+import { useRemoteCall } from "@hooks/RemoteCall";
+import { getTrustedDevices } from "@services/TrustedDevices";
+
+export function useTrustedDevices() {
+    return useRemoteCall(getTrustedDevices, []);
+}
@@ -2,6 +2,7 @@ export interface UserInfoTOTPConfiguration {
     created_at: Date;
     last_used_at?: Date;
     issuer: string;
+    type?: TOTPType;
     algorithm: TOTPAlgorithm;
     digits: TOTPDigits;
     period: number;
@@ -23,6 +24,7 @@ export enum TOTPAlgorithm {
 }
 
 export type TOTPDigits = 6 | 8;
+export type TOTPType = "totp" | "hotp" | "steam";
 export type TOTPAlgorithmPayload = "SHA1" | "SHA256" | "SHA512";
 
 export function toAlgorithmString(alg: TOTPAlgorithm): TOTPAlgorithmPayload {
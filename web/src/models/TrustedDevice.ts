@@ -0,0 +1,11 @@
+export interface TrustedDevice {
+    id: string;
+    created_at: string;
+    created_ip: string;
+    last_used_at?: string;
+    last_used_ip?: string;
+    expires_at: string;
+    username: string;
+    description: string;
+    current: boolean;
+}
@@ -6,4 +6,5 @@ export interface UserInfo {
     has_webauthn: boolean;
     has_totp: boolean;
     has_duo: boolean;
+    recovery_codes: number;
 }
@@ -8,6 +8,7 @@ const basePath = getBasePath();
 export const ConsentPath = basePath + "/api/oidc/consent";
 
 export const FirstFactorPath = basePath + "/api/firstfactor";
+export const FirstFactorPasskeyPath = basePath + "/api/firstfactor/passkey";
 
 export const TOTPRegistrationPath = basePath + "/api/secondfactor/totp/register";
 export const TOTPConfigurationPath = basePath + "/api/secondfactor/totp";
@@ -22,12 +23,23 @@ export const CompleteDuoDeviceSelectionPath = basePath + "/api/secondfactor/duo_
 
 export const CompletePushNotificationSignInPath = basePath + "/api/secondfactor/duo";
 export const CompleteTOTPSignInPath = basePath + "/api/secondfactor/totp";
+export const CompleteRecoveryCodeSignInPath = basePath + "/api/secondfactor/recovery-code";
+export const EmailOneTimeCodePath = basePath + "/api/secondfactor/email";
+
+export const RecoveryCodesPath = basePath + "/api/secondfactor/recovery-codes";
+
+export const TrustedDevicePath = basePath + "/api/secondfactor/trusted-device";
+export const TrustedDevicesPath = basePath + "/api/secondfactor/trusted-devices";
 
 export const InitiateResetPasswordPath = basePath + "/api/reset-password/identity/start";
 export const CompleteResetPasswordPath = basePath + "/api/reset-password/identity/finish";
 
 // Do the password reset during completion.
 export const ResetPasswordPath = basePath + "/api/reset-password";
+
+export const CompleteRegulationUnlockPath = basePath + "/api/regulation/unlock/identity/finish";
+export const RegulationUnlockPath = basePath + "/api/regulation/unlock";
+
 export const ChecksSafeRedirectionPath = basePath + "/api/checks/safe-redirection";
 
 export const LogoutPath = basePath + "/api/logout";
@@ -0,0 +1,52 @@
+import axios from "axios";
+
+import { EmailOneTimeCodePath, ErrorResponse, OKResponse } from "@services/Api";
+import { PostWithOptionalResponse } from "@services/Client";
+import { SignInResponse } from "@services/SignIn";
+
+interface CompleteEmailOneTimeCodeSignInBody {
+    code: string;
+    targetURL?: string;
+    workflow?: string;
+    workflowID?: string;
+}
+
+export enum EmailOneTimeCodeSendResult {
+    Success = 1,
+    RateLimited,
+    Failure,
+}
+
+export async function sendEmailOneTimeCode() {
+    const res = await axios<OKResponse | ErrorResponse>({
+        method: "PUT",
+        url: EmailOneTimeCodePath,
+        validateStatus: (status) => {
+            return status === 429 || (status >= 200 && status < 400);
+        },
+    });
+
+    if (res.status === 429) {
+        return EmailOneTimeCodeSendResult.RateLimited;
+    }
+
+    return res.status === 200 && res.data.status === "OK"
+        ? EmailOneTimeCodeSendResult.Success
+        : EmailOneTimeCodeSendResult.Failure;
+}
+
+export function completeEmailOneTimeCodeSignIn(
+    code: string,
+    targetURL?: string,
+    workflow?: string,
+    workflowID?: string,
+) {
+    const body: CompleteEmailOneTimeCodeSignInBody = {
+        code: code,
+        targetURL: targetURL,
+        workflow: workflow,
+        workflowID: workflowID,
+    };
+
+    return PostWithOptionalResponse<SignInResponse>(EmailOneTimeCodePath, body);
+}
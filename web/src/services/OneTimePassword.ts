@@ -1,5 +1,6 @@
 import { CompleteTOTPSignInPath, TOTPRegistrationPath } from "@services/Api";
 import { DeleteWithOptionalResponse, PostWithOptionalResponse } from "@services/Client";
+import { RecoveryCodesResponse } from "@services/RecoveryCodes";
 import { SignInResponse } from "@services/SignIn";
 
 interface CompleteTOTPSignInBody {
@@ -25,7 +26,7 @@ export function completeTOTPRegister(passcode: string) {
         token: `${passcode}`,
     };
 
-    return PostWithOptionalResponse(TOTPRegistrationPath, body);
+    return PostWithOptionalResponse<RecoveryCodesResponse>(TOTPRegistrationPath, body);
 }
 
 export function stopTOTPRegister() {
@@ -9,13 +9,23 @@ interface CompletePushSignInBody {
     targetURL?: string;
     workflow?: string;
     workflowID?: string;
+    state?: string;
+    duo_code?: string;
 }
 
-export function completePushNotificationSignIn(targetURL?: string, workflow?: string, workflowID?: string) {
+export function completePushNotificationSignIn(
+    targetURL?: string,
+    workflow?: string,
+    workflowID?: string,
+    state?: string,
+    code?: string,
+) {
     const body: CompletePushSignInBody = {
         targetURL: targetURL,
         workflow: workflow,
         workflowID: workflowID,
+        state: state,
+        duo_code: code,
     };
 
     return PostWithOptionalResponse<DuoSignInResponse>(CompletePushNotificationSignInPath, body);
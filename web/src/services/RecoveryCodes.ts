@@ -0,0 +1,31 @@
+import { CompleteRecoveryCodeSignInPath, RecoveryCodesPath } from "@services/Api";
+import { PostWithOptionalResponse, Put } from "@services/Client";
+import { SignInResponse } from "@services/SignIn";
+
+interface CompleteRecoveryCodeSignInBody {
+    code: string;
+    targetURL?: string;
+    workflow?: string;
+    workflowID?: string;
+}
+
+export interface RecoveryCodesResponse {
+    recovery_codes: string[];
+}
+
+export function completeRecoveryCodeSignIn(code: string, targetURL?: string, workflow?: string, workflowID?: string) {
+    const body: CompleteRecoveryCodeSignInBody = {
+        code: code,
+        targetURL: targetURL,
+        workflow: workflow,
+        workflowID: workflowID,
+    };
+
+    return PostWithOptionalResponse<SignInResponse>(CompleteRecoveryCodeSignInPath, body);
+}
+
+export async function generateRecoveryCodes() {
+    const res = await Put<RecoveryCodesResponse>(RecoveryCodesPath);
+
+    return res.recovery_codes;
+}
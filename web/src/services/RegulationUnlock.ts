@@ -0,0 +1,23 @@
+import axios from "axios";
+
+import {
+    CompleteRegulationUnlockPath,
+    ErrorResponse,
+    OKResponse,
+    RegulationUnlockPath,
+} from "@services/Api";
+import { PostWithOptionalResponse } from "@services/Client";
+
+export async function completeRegulationUnlockProcess(token: string) {
+    return PostWithOptionalResponse(CompleteRegulationUnlockPath, { token });
+}
+
+export async function deleteRegulationUnlockToken(token: string) {
+    const res = await axios<OKResponse | ErrorResponse>({
+        method: "DELETE",
+        url: RegulationUnlockPath,
+        data: { token: token },
+    });
+
+    return res.status === 200 && res.data.status === "OK";
+}
@@ -10,8 +10,19 @@ export enum AuthenticationLevel {
 export interface AutheliaState {
     username: string;
     authentication_level: AuthenticationLevel;
+    amr?: string[];
 }
 
 export async function getState(): Promise<AutheliaState> {
     return Get<AutheliaState>(StatePath);
 }
+
+export function isAuthenticationMethodsSufficient(state: AutheliaState, required: string | undefined): boolean {
+    if (!required) {
+        return true;
+    }
+
+    const amr = state.amr || [];
+
+    return required.split(" ").some((value) => amr.includes(value));
+}
@@ -0,0 +1,32 @@
+import axios from "axios";
+
+import { TrustedDevice } from "@models/TrustedDevice";
+import {
+    AuthenticationOKResponse,
+    TrustedDevicePath,
+    TrustedDevicesPath,
+    validateStatusAuthentication,
+} from "@services/Api";
+import { GetWithOptionalData, PostWithOptionalResponse } from "@services/Client";
+
+export async function trustDevice() {
+    return PostWithOptionalResponse(TrustedDevicePath);
+}
+
+export async function getTrustedDevices(): Promise<TrustedDevice[]> {
+    const res = await GetWithOptionalData<TrustedDevice[] | null>(TrustedDevicesPath);
+
+    if (res === null) {
+        return [];
+    }
+
+    return res;
+}
+
+export async function revokeTrustedDevice(deviceID: string) {
+    return axios<AuthenticationOKResponse>({
+        method: "DELETE",
+        url: `${TrustedDevicePath}/${deviceID}`,
+        validateStatus: validateStatusAuthentication,
+    });
+}
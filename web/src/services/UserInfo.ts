@@ -3,7 +3,7 @@ import { UserInfo } from "@models/UserInfo";
 import { UserInfo2FAMethodPath, UserInfoPath } from "@services/Api";
 import { Get, Post, PostWithOptionalResponse } from "@services/Client";
 
-export type Method2FA = "webauthn" | "totp" | "mobile_push";
+export type Method2FA = "webauthn" | "totp" | "mobile_push" | "email";
 
 export interface UserInfoPayload {
     display_name: string;
@@ -11,6 +11,7 @@ export interface UserInfoPayload {
     has_webauthn: boolean;
     has_totp: boolean;
     has_duo: boolean;
+    recovery_codes: number;
 }
 
 export interface MethodPreferencePayload {
@@ -18,7 +19,7 @@ export interface MethodPreferencePayload {
 }
 
 export function isMethod2FA(method: string) {
-    return ["webauthn", "totp", "mobile_push"].includes(method);
+    return ["webauthn", "totp", "mobile_push", "email"].includes(method);
 }
 
 export function toSecondFactorMethod(method: Method2FA): SecondFactorMethod {
@@ -29,6 +30,8 @@ export function toSecondFactorMethod(method: Method2FA): SecondFactorMethod {
             return SecondFactorMethod.WebAuthn;
         case "mobile_push":
             return SecondFactorMethod.MobilePush;
+        case "email":
+            return SecondFactorMethod.Email;
     }
 }
 
@@ -40,6 +43,8 @@ export function toMethod2FA(method: SecondFactorMethod): Method2FA {
             return "webauthn";
         case SecondFactorMethod.MobilePush:
             return "mobile_push";
+        case SecondFactorMethod.Email:
+            return "email";
     }
 }
 
@@ -4,6 +4,7 @@ import {
     TOTPAlgorithmPayload,
     TOTPDigits,
     TOTPOptions,
+    TOTPType,
     UserInfoTOTPConfiguration,
     toEnum,
 } from "@models/TOTPConfiguration";
@@ -21,6 +22,7 @@ export interface UserInfoTOTPConfigurationPayload {
     created_at: string;
     last_used_at?: string;
     issuer: string;
+    type?: TOTPType;
     algorithm: TOTPAlgorithmPayload;
     digits: TOTPDigits;
     period: number;
@@ -31,6 +33,7 @@ function toUserInfoTOTPConfiguration(payload: UserInfoTOTPConfigurationPayload):
         created_at: new Date(payload.created_at),
         last_used_at: payload.last_used_at ? new Date(payload.last_used_at) : undefined,
         issuer: payload.issuer,
+        type: payload.type,
         algorithm: toEnum(payload.algorithm),
         digits: payload.digits,
         period: payload.period,
@@ -19,6 +19,7 @@ import {
 } from "@models/WebAuthn";
 import {
     AuthenticationOKResponse,
+    FirstFactorPasskeyPath,
     OKResponse,
     OptionalDataServiceResponse,
     ServiceResponse,
@@ -87,11 +88,13 @@ function getAssertionResultFromDOMException(
 
 export async function getAttestationCreationOptions(
     description: string,
+    passkey: boolean,
 ): Promise<PublicKeyCredentialCreationOptionsStatus> {
     const response = await axios.put<ServiceResponse<CredentialCreation>>(
         WebAuthnRegistrationPath,
         {
             description: description,
+            passkey: passkey,
         },
         {
             validateStatus: function (status) {
@@ -129,6 +132,21 @@ export async function getAuthenticationOptions(): Promise<PublicKeyCredentialReq
     };
 }
 
+export async function getPasskeyAuthenticationOptions(): Promise<PublicKeyCredentialRequestOptionsStatus> {
+    const response = await axios.get<ServiceResponse<CredentialRequest>>(FirstFactorPasskeyPath);
+
+    if (response.data.status !== "OK" || response.data.data == null) {
+        return {
+            status: response.status,
+        };
+    }
+
+    return {
+        options: response.data.data.publicKey,
+        status: response.status,
+    };
+}
+
 export async function startWebAuthnRegistration(options: PublicKeyCredentialCreationOptionsJSON) {
     const result: RegistrationResult = {
         result: AttestationResult.Failure,
@@ -189,12 +207,7 @@ async function postRegistrationResponse(
     return axios.post<OptionalDataServiceResponse<any>>(WebAuthnRegistrationPath, response);
 }
 
-export async function postAuthenticationResponse(
-    response: AuthenticationResponseJSON,
-    targetURL?: string | undefined,
-    workflow?: string,
-    workflowID?: string,
-) {
+function encodeUserHandle(response: AuthenticationResponseJSON) {
     if (response.response.userHandle) {
         // Encode the userHandle to match the typing on the backend.
         response.response.userHandle = btoa(response.response.userHandle)
@@ -202,6 +215,15 @@ export async function postAuthenticationResponse(
             .replace(/\//g, "_")
             .replace(/=/g, "");
     }
+}
+
+export async function postAuthenticationResponse(
+    response: AuthenticationResponseJSON,
+    targetURL?: string | undefined,
+    workflow?: string,
+    workflowID?: string,
+) {
+    encodeUserHandle(response);
 
     return axios.post<ServiceResponse<SignInResponse>>(WebAuthnAssertionPath, {
         response: response,
@@ -211,8 +233,28 @@ export async function postAuthenticationResponse(
     });
 }
 
+export async function postPasskeyAuthenticationResponse(
+    response: AuthenticationResponseJSON,
+    rememberMe: boolean,
+    targetURL?: string | undefined,
+    requestMethod?: string,
+    workflow?: string,
+    workflowID?: string,
+) {
+    encodeUserHandle(response);
+
+    return axios.post<ServiceResponse<SignInResponse>>(FirstFactorPasskeyPath, {
+        response: response,
+        keepMeLoggedIn: rememberMe,
+        targetURL: targetURL,
+        requestMethod: requestMethod,
+        workflow: workflow,
+        workflowID: workflowID,
+    });
+}
+
 export async function finishRegistration(response: RegistrationResponseJSON) {
-    let result = {
+    let result: { status: AttestationResult; message: string; recovery_codes?: string[] } = {
         status: AttestationResult.Failure,
         message: "Device registration failed.",
     };
@@ -223,6 +265,7 @@ export async function finishRegistration(response: RegistrationResponseJSON) {
             return {
                 status: AttestationResult.Success,
                 message: "",
+                recovery_codes: resp.data.data?.recovery_codes,
             };
         }
     } catch (error) {
@@ -34,9 +34,12 @@ Object.defineProperty(window, "localStorage", { value: localStorageMock });
 
 document.body.setAttribute("data-basepath", "");
 document.body.setAttribute("data-duoselfenrollment", "true");
+document.body.setAttribute("data-passkeylogin", "false");
+document.body.setAttribute("data-recoverycodes", "true");
 document.body.setAttribute("data-rememberme", "true");
 document.body.setAttribute("data-resetpassword", "true");
 document.body.setAttribute("data-resetpasswordcustomurl", "");
 document.body.setAttribute("data-privacypolicyurl", "");
 document.body.setAttribute("data-privacypolicyaccept", "false");
 document.body.setAttribute("data-theme", "light");
+document.body.setAttribute("data-trusteddevicedays", "0");
@@ -15,6 +15,14 @@ export function getLogoOverride() {
     return getEmbeddedVariable("logooverride") === "true";
 }
 
+export function getPasskeyLogin() {
+    return getEmbeddedVariable("passkeylogin") === "true";
+}
+
+export function getRecoveryCodes() {
+    return getEmbeddedVariable("recoverycodes") === "true";
+}
+
 export function getRememberMe() {
     return getEmbeddedVariable("rememberme") === "true";
 }
@@ -42,3 +50,7 @@ export function getPrivacyPolicyRequireAccept() {
 export function getTheme() {
     return getEmbeddedVariable("theme");
 }
+
+export function getTrustedDeviceDays() {
+    return parseInt(getEmbeddedVariable("trusteddevicedays"), 10) || 0;
+}
@@ -15,10 +15,17 @@ import { useQueryParam } from "@hooks/QueryParam";
 import { useWorkflow } from "@hooks/Workflow";
 import LoginLayout from "@layouts/LoginLayout";
 import { IsCapsLockModified } from "@services/CapsLock";
+import { AssertionResult, AssertionResultFailureString } from "@models/WebAuthn";
 import { postFirstFactor } from "@services/FirstFactor";
+import {
+    getAuthenticationResult,
+    getPasskeyAuthenticationOptions,
+    postPasskeyAuthenticationResponse,
+} from "@services/WebAuthn";
 
 export interface Props {
     disabled: boolean;
+    passkeyLogin: boolean;
     rememberMe: boolean;
 
     resetPassword: boolean;
@@ -36,7 +43,7 @@ const FirstFactorForm = function (props: Props) {
     const navigate = useNavigate();
     const redirectionURL = useQueryParam(RedirectionURL);
     const requestMethod = useQueryParam(RequestMethod);
-    const [workflow] = useWorkflow();
+    const [workflow, workflowID] = useWorkflow();
     const { createErrorNotification } = useNotifications();
 
     const loginChannel = useMemo(() => new BroadcastChannel<boolean>("login"), []);
@@ -110,6 +117,63 @@ const FirstFactorForm = function (props: Props) {
         workflow,
     ]);
 
+    const handlePasskeySignIn = useCallback(async () => {
+        props.onAuthenticationStart();
+
+        try {
+            const optionsStatus = await getPasskeyAuthenticationOptions();
+
+            if (optionsStatus.status !== 200 || optionsStatus.options == null) {
+                createErrorNotification(translate("Failed to initiate passkey sign in process"));
+                props.onAuthenticationFailure();
+
+                return;
+            }
+
+            const result = await getAuthenticationResult(optionsStatus.options);
+
+            if (result.result !== AssertionResult.Success || result.response == null) {
+                createErrorNotification(translate(AssertionResultFailureString(result.result)));
+                props.onAuthenticationFailure();
+
+                return;
+            }
+
+            const response = await postPasskeyAuthenticationResponse(
+                result.response,
+                rememberMe,
+                redirectionURL,
+                requestMethod,
+                workflow,
+                workflowID,
+            );
+
+            if (response.data.status === "OK" && response.status === 200) {
+                await loginChannel.postMessage(true);
+                props.onAuthenticationSuccess(response.data.data ? response.data.data.redirect : undefined);
+
+                return;
+            }
+
+            createErrorNotification(translate("The server rejected the passkey"));
+            props.onAuthenticationFailure();
+        } catch (err) {
+            console.error(err);
+            createErrorNotification(translate("The server rejected the passkey"));
+            props.onAuthenticationFailure();
+        }
+    }, [
+        createErrorNotification,
+        loginChannel,
+        props,
+        redirectionURL,
+        rememberMe,
+        requestMethod,
+        translate,
+        workflow,
+        workflowID,
+    ]);
+
     const handleResetPasswordClick = () => {
         if (props.resetPassword) {
             if (props.resetPasswordCustomURL !== "") {
@@ -271,6 +335,20 @@ const FirstFactorForm = function (props: Props) {
                             {translate("Sign in")}
                         </Button>
                     </Grid>
+                    {props.passkeyLogin ? (
+                        <Grid item xs={12}>
+                            <Button
+                                id="passkey-sign-in-button"
+                                variant="outlined"
+                                color="primary"
+                                fullWidth
+                                disabled={disabled}
+                                onClick={handlePasskeySignIn}
+                            >
+                                {translate("Sign in with a passkey")}
+                            </Button>
+                        </Grid>
+                    ) : null}
                     {props.resetPassword ? (
                         <Grid item xs={12} className={classnames(styles.actionRow, styles.flexEnd)}>
                             <Link
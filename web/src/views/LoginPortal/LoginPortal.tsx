@@ -6,12 +6,13 @@ import { Route, Routes, useLocation } from "react-router-dom";
 import {
     AuthenticatedRoute,
     IndexRoute,
+    SecondFactorEmailSubRoute,
     SecondFactorPushSubRoute,
     SecondFactorRoute,
     SecondFactorTOTPSubRoute,
     SecondFactorWebAuthnSubRoute,
 } from "@constants/Routes";
-import { RedirectionURL } from "@constants/SearchParams";
+import { AuthenticationMethodsReferences, Reauthenticate, RedirectionURL } from "@constants/SearchParams";
 import { useLocalStorageMethodContext } from "@contexts/LocalStorageMethodContext";
 import { useConfiguration } from "@hooks/Configuration";
 import { useNotifications } from "@hooks/NotificationsContext";
@@ -20,9 +21,10 @@ import { useRedirector } from "@hooks/Redirector";
 import { useRouterNavigate } from "@hooks/RouterNavigate";
 import { useAutheliaState } from "@hooks/State";
 import { useUserInfoPOST } from "@hooks/UserInfo";
+import { Configuration } from "@models/Configuration";
 import { SecondFactorMethod } from "@models/Methods";
 import { checkSafeRedirection } from "@services/SafeRedirection";
-import { AuthenticationLevel } from "@services/State";
+import { AuthenticationLevel, isAuthenticationMethodsSufficient } from "@services/State";
 import LoadingPage from "@views/LoadingPage/LoadingPage";
 
 const AuthenticatedView = lazy(() => import("@views/LoginPortal/AuthenticatedView/AuthenticatedView"));
@@ -31,6 +33,7 @@ const SecondFactorForm = lazy(() => import("@views/LoginPortal/SecondFactor/Seco
 
 export interface Props {
     duoSelfEnrollment: boolean;
+    passkeyLogin: boolean;
     rememberMe: boolean;
 
     resetPassword: boolean;
@@ -43,9 +46,12 @@ const RedirectionErrorMessage =
 const LoginPortal = function (props: Props) {
     const location = useLocation();
     const redirectionURL = useQueryParam(RedirectionURL);
+    const requiredAMR = useQueryParam(AuthenticationMethodsReferences);
+    const reauth = useQueryParam(Reauthenticate);
     const { createErrorNotification } = useNotifications();
     const [firstFactorDisabled, setFirstFactorDisabled] = useState(true);
     const [broadcastRedirect, setBroadcastRedirect] = useState(false);
+    const [reauthenticated, setReauthenticated] = useState(false);
     const redirector = useRedirector();
     const { localStorageMethod } = useLocalStorageMethodContext();
     const { t: translate } = useTranslation();
@@ -56,6 +62,20 @@ const LoginPortal = function (props: Props) {
 
     const navigate = useRouterNavigate();
 
+    // The user is required to step up to a specific second factor method when they've already completed second factor
+    // authentication with a method which doesn't satisfy the requirements.
+    const stepUp =
+        state !== undefined &&
+        state.authentication_level === AuthenticationLevel.TwoFactor &&
+        !isAuthenticationMethodsSufficient(state, requiredAMR);
+
+    // The effective authentication level is lowered when the user is required to step up or to re-authenticate because
+    // the maximum authentication age of a factor has been exceeded.
+    const authenticationLevel =
+        state === undefined
+            ? AuthenticationLevel.Unauthenticated
+            : effectiveAuthenticationLevel(state.authentication_level, reauth, reauthenticated, stepUp);
+
     // Fetch the state when portal is mounted.
     useEffect(() => {
         fetchState();
@@ -71,10 +91,10 @@ const LoginPortal = function (props: Props) {
 
     // Enable first factor when user is unauthenticated.
     useEffect(() => {
-        if (state && state.authentication_level > AuthenticationLevel.Unauthenticated) {
+        if (state && authenticationLevel > AuthenticationLevel.Unauthenticated) {
             setFirstFactorDisabled(true);
         }
-    }, [state, setFirstFactorDisabled]);
+    }, [state, authenticationLevel, setFirstFactorDisabled]);
 
     // Display an error when state fetching fails
     useEffect(() => {
@@ -108,8 +128,8 @@ const LoginPortal = function (props: Props) {
                 redirectionURL &&
                 ((configuration &&
                     configuration.available_methods.size === 0 &&
-                    state.authentication_level >= AuthenticationLevel.OneFactor) ||
-                    state.authentication_level === AuthenticationLevel.TwoFactor ||
+                    authenticationLevel >= AuthenticationLevel.OneFactor) ||
+                    authenticationLevel === AuthenticationLevel.TwoFactor ||
                     broadcastRedirect)
             ) {
                 try {
@@ -126,19 +146,21 @@ const LoginPortal = function (props: Props) {
                 return;
             }
 
-            if (state.authentication_level === AuthenticationLevel.Unauthenticated) {
+            if (authenticationLevel === AuthenticationLevel.Unauthenticated) {
                 setFirstFactorDisabled(false);
                 navigate(IndexRoute);
-            } else if (state.authentication_level >= AuthenticationLevel.OneFactor && userInfo && configuration) {
+            } else if (authenticationLevel >= AuthenticationLevel.OneFactor && userInfo && configuration) {
                 if (configuration.available_methods.size === 0) {
                     navigate(AuthenticatedRoute, false);
                 } else {
-                    const method = localStorageMethod || userInfo.method;
+                    const method = methodFromAMR(requiredAMR, configuration) || localStorageMethod || userInfo.method;
 
                     if (method === SecondFactorMethod.WebAuthn) {
                         navigate(`${SecondFactorRoute}${SecondFactorWebAuthnSubRoute}`);
                     } else if (method === SecondFactorMethod.MobilePush) {
                         navigate(`${SecondFactorRoute}${SecondFactorPushSubRoute}`);
+                    } else if (method === SecondFactorMethod.Email) {
+                        navigate(`${SecondFactorRoute}${SecondFactorEmailSubRoute}`);
                     } else {
                         navigate(`${SecondFactorRoute}${SecondFactorTOTPSubRoute}`);
                     }
@@ -157,6 +179,8 @@ const LoginPortal = function (props: Props) {
         broadcastRedirect,
         localStorageMethod,
         translate,
+        requiredAMR,
+        authenticationLevel,
     ]);
 
     const handleChannelStateChange = async () => {
@@ -165,6 +189,8 @@ const LoginPortal = function (props: Props) {
     };
 
     const handleAuthSuccess = async (redirectionURL: string | undefined) => {
+        setReauthenticated(true);
+
         if (redirectionURL) {
             // Do an external redirection pushed by the server.
             redirector(redirectionURL);
@@ -176,7 +202,7 @@ const LoginPortal = function (props: Props) {
 
     const firstFactorReady =
         state !== undefined &&
-        state.authentication_level === AuthenticationLevel.Unauthenticated &&
+        authenticationLevel === AuthenticationLevel.Unauthenticated &&
         location.pathname === IndexRoute;
 
     return (
@@ -187,6 +213,7 @@ const LoginPortal = function (props: Props) {
                     <ComponentOrLoading ready={firstFactorReady}>
                         <FirstFactorForm
                             disabled={firstFactorDisabled}
+                            passkeyLogin={props.passkeyLogin}
                             rememberMe={props.rememberMe}
                             resetPassword={props.resetPassword}
                             resetPasswordCustomURL={props.resetPasswordCustomURL}
@@ -203,7 +230,7 @@ const LoginPortal = function (props: Props) {
                 element={
                     state && userInfo && configuration ? (
                         <SecondFactorForm
-                            authenticationLevel={state.authentication_level}
+                            authenticationLevel={authenticationLevel}
                             userInfo={userInfo}
                             configuration={configuration}
                             duoSelfEnrollment={props.duoSelfEnrollment}
@@ -218,6 +245,56 @@ const LoginPortal = function (props: Props) {
     );
 };
 
+function effectiveAuthenticationLevel(
+    level: AuthenticationLevel,
+    reauth: string | undefined,
+    reauthenticated: boolean,
+    stepUp: boolean,
+) {
+    if (!reauthenticated) {
+        if (reauth === "one_factor") {
+            return AuthenticationLevel.Unauthenticated;
+        }
+
+        if (reauth === "two_factor" && level === AuthenticationLevel.TwoFactor) {
+            return AuthenticationLevel.OneFactor;
+        }
+    }
+
+    return stepUp ? AuthenticationLevel.OneFactor : level;
+}
+
+function methodFromAMR(required: string | undefined, configuration: Configuration) {
+    if (!required) {
+        return undefined;
+    }
+
+    for (const value of required.split(" ")) {
+        let method: SecondFactorMethod | undefined;
+
+        switch (value) {
+            case "hwk":
+                method = SecondFactorMethod.WebAuthn;
+                break;
+            case "otp":
+                method = SecondFactorMethod.TOTP;
+                break;
+            case "sms":
+                method = SecondFactorMethod.MobilePush;
+                break;
+            case "email":
+                method = SecondFactorMethod.Email;
+                break;
+        }
+
+        if (method !== undefined && configuration.available_methods.has(method)) {
+            return method;
+        }
+    }
+
+    return undefined;
+}
+
 interface ComponentOrLoadingProps {
     ready: boolean;
 
@@ -0,0 +1,166 @@
+import React, { useCallback, useEffect, useState } from "react";
+
+import { Button, TextField } from "@mui/material";
+import Grid from "@mui/material/Unstable_Grid2/Grid2";
+import { useTranslation } from "react-i18next";
+
+import { RedirectionURL } from "@constants/SearchParams";
+import { useNotifications } from "@hooks/NotificationsContext";
+import { useQueryParam } from "@hooks/QueryParam";
+import { useWorkflow } from "@hooks/Workflow";
+import {
+    EmailOneTimeCodeSendResult,
+    completeEmailOneTimeCodeSignIn,
+    sendEmailOneTimeCode,
+} from "@services/EmailOneTimeCode";
+import { AuthenticationLevel } from "@services/State";
+import MethodContainer, { State as MethodContainerState } from "@views/LoginPortal/SecondFactor/MethodContainer";
+import { State } from "@views/LoginPortal/SecondFactor/OneTimePasswordMethod";
+
+export interface Props {
+    id: string;
+    authenticationLevel: AuthenticationLevel;
+
+    onSignInError: (err: Error) => void;
+    onSignInSuccess: (redirectURL: string | undefined) => void;
+}
+
+const EmailMethod = function (props: Props) {
+    const [code, setCode] = useState("");
+    const [sent, setSent] = useState(false);
+    const [sending, setSending] = useState(false);
+    const [state, setState] = useState(
+        props.authenticationLevel === AuthenticationLevel.TwoFactor ? State.Success : State.Idle,
+    );
+    const redirectionURL = useQueryParam(RedirectionURL);
+    const [workflow, workflowID] = useWorkflow();
+    const { createInfoNotification } = useNotifications();
+    const { t: translate } = useTranslation();
+
+    const { onSignInSuccess, onSignInError } = props;
+
+    const handleSend = useCallback(async () => {
+        if (props.authenticationLevel === AuthenticationLevel.TwoFactor) {
+            return;
+        }
+
+        setSending(true);
+
+        try {
+            switch (await sendEmailOneTimeCode()) {
+                case EmailOneTimeCodeSendResult.Success:
+                    setSent(true);
+                    createInfoNotification(translate("A one-time code has been sent to your email address"));
+                    break;
+                case EmailOneTimeCodeSendResult.RateLimited:
+                    onSignInError(new Error(translate("Too many one-time codes have been requested, try again later")));
+                    break;
+                default:
+                    onSignInError(new Error(translate("There was an issue sending the one-time code")));
+            }
+        } catch (err) {
+            console.error(err);
+            onSignInError(new Error(translate("There was an issue sending the one-time code")));
+        }
+
+        setSending(false);
+    }, [createInfoNotification, onSignInError, props.authenticationLevel, translate]);
+
+    const handleSignIn = useCallback(async () => {
+        if (props.authenticationLevel === AuthenticationLevel.TwoFactor || code === "") {
+            return;
+        }
+
+        try {
+            setState(State.InProgress);
+            const res = await completeEmailOneTimeCodeSignIn(code, redirectionURL, workflow, workflowID);
+            setState(State.Success);
+            onSignInSuccess(res ? res.redirect : undefined);
+        } catch (err) {
+            console.error(err);
+            onSignInError(new Error(translate("The One-Time Code might be wrong or has expired")));
+            setState(State.Failure);
+        }
+
+        setCode("");
+    }, [
+        code,
+        onSignInError,
+        onSignInSuccess,
+        props.authenticationLevel,
+        redirectionURL,
+        translate,
+        workflow,
+        workflowID,
+    ]);
+
+    // Set successful state if user is already authenticated.
+    useEffect(() => {
+        if (props.authenticationLevel >= AuthenticationLevel.TwoFactor) {
+            setState(State.Success);
+        }
+    }, [props.authenticationLevel, setState]);
+
+    const methodState =
+        props.authenticationLevel === AuthenticationLevel.TwoFactor
+            ? MethodContainerState.ALREADY_AUTHENTICATED
+            : MethodContainerState.METHOD;
+
+    return (
+        <MethodContainer
+            id={props.id}
+            title={translate("Email")}
+            explanation={translate("Enter the one-time code sent to your email address")}
+            duoSelfEnrollment={false}
+            registered={true}
+            state={methodState}
+        >
+            <Grid container spacing={2}>
+                <Grid xs={12}>
+                    <Button
+                        id="email-send-button"
+                        variant="outlined"
+                        color="primary"
+                        fullWidth
+                        disabled={sending || state === State.InProgress}
+                        onClick={() => handleSend().catch(console.error)}
+                    >
+                        {sent ? translate("Resend Code") : translate("Send Code")}
+                    </Button>
+                </Grid>
+                <Grid xs={12}>
+                    <TextField
+                        id="email-one-time-code-textfield"
+                        label={translate("One-Time Code")}
+                        variant="outlined"
+                        fullWidth
+                        value={code}
+                        disabled={!sent || state === State.InProgress}
+                        error={state === State.Failure}
+                        autoComplete="one-time-code"
+                        onChange={(e) => setCode(e.target.value)}
+                        onKeyDown={(e) => {
+                            if (e.key === "Enter") {
+                                handleSignIn().catch(console.error);
+                            }
+                        }}
+                    />
+                </Grid>
+                <Grid xs={12}>
+                    <Button
+                        id="email-sign-in-button"
+                        variant="contained"
+                        color="primary"
+                        fullWidth
+                        disabled={!sent || state === State.InProgress || code === ""}
+                        onClick={() => handleSignIn().catch(console.error)}
+                    >
+                        {translate("Sign in")}
+                    </Button>
+                </Grid>
+            </Grid>
+        </MethodContainer>
+    );
+};
+
+export default EmailMethod;
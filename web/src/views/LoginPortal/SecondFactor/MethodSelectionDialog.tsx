@@ -1,5 +1,6 @@
 import React, { ReactNode } from "react";
 
+import { Email } from "@mui/icons-material";
 import { Button, Dialog, DialogActions, DialogContent, Grid, Theme, Typography, useTheme } from "@mui/material";
 import makeStyles from "@mui/styles/makeStyles";
 import { useTranslation } from "react-i18next";
@@ -55,6 +56,14 @@ const MethodSelectionDialog = function (props: Props) {
                             onClick={() => props.onClick(SecondFactorMethod.MobilePush)}
                         />
                     ) : null}
+                    {props.methods.has(SecondFactorMethod.Email) ? (
+                        <MethodItem
+                            id="email-option"
+                            method={translate("Email")}
+                            icon={<Email sx={{ fontSize: 32 }} />}
+                            onClick={() => props.onClick(SecondFactorMethod.Email)}
+                        />
+                    ) : null}
                 </Grid>
             </DialogContent>
             <DialogActions>
@@ -16,6 +16,8 @@ export interface Props {
 
     digits: number;
     period: number;
+    counter?: boolean;
+    alphanumeric?: boolean;
 
     onChange: (passcode: string) => void;
 }
@@ -24,7 +26,7 @@ const OTPDial = function (props: Props) {
     const styles = useStyles();
 
     return (
-        <IconWithContext icon={<Icon state={props.state} period={props.period} />}>
+        <IconWithContext icon={<Icon state={props.state} period={props.period} counter={props.counter} />}>
             <span className={styles.otpInput} id="otp-input">
                 <OtpInput
                     shouldAutoFocus
@@ -32,7 +34,7 @@ const OTPDial = function (props: Props) {
                     value={props.passcode}
                     numInputs={props.digits}
                     isDisabled={props.state === State.InProgress || props.state === State.Success}
-                    isInputNum
+                    isInputNum={!props.alphanumeric}
                     hasErrored={props.state === State.Failure}
                     autoComplete="one-time-code"
                     inputStyle={classnames(
@@ -71,12 +73,13 @@ const useStyles = makeStyles((theme: Theme) => ({
 interface IconProps {
     state: State;
     period: number;
+    counter?: boolean;
 }
 
 function Icon(props: IconProps) {
     return (
         <Fragment>
-            {props.state !== State.Success ? (
+            {props.state !== State.Success && !props.counter ? (
                 <TimerIcon backgroundColor="#000" color="#FFFFFF" width={64} height={64} period={props.period} />
             ) : null}
             {props.state === State.Success ? <SuccessIcon /> : null}
@@ -126,6 +126,8 @@ const OneTimePasswordMethod = function (props: Props) {
                         passcode={passcode}
                         period={resp?.period || 30}
                         digits={resp?.digits || 6}
+                        counter={resp?.type === "hotp"}
+                        alphanumeric={resp?.type === "steam"}
                         onChange={setPasscode}
                         state={state}
                     />
@@ -7,7 +7,7 @@ import { useTranslation } from "react-i18next";
 import FailureIcon from "@components/FailureIcon";
 import PushNotificationIcon from "@components/PushNotificationIcon";
 import SuccessIcon from "@components/SuccessIcon";
-import { RedirectionURL } from "@constants/SearchParams";
+import { DuoCode, DuoState, RedirectionURL } from "@constants/SearchParams";
 import { useIsMountedRef } from "@hooks/Mounted";
 import { useQueryParam } from "@hooks/QueryParam";
 import { useWorkflow } from "@hooks/Workflow";
@@ -48,6 +48,9 @@ const PushNotificationMethod = function (props: Props) {
     const [state, setState] = useState(State.SignInInProgress);
     const redirectionURL = useQueryParam(RedirectionURL);
     const [workflow, workflowID] = useWorkflow();
+    const duoState = useQueryParam(DuoState);
+    const duoCode = useQueryParam(DuoCode);
+    const duoCodeUsed = useRef(false);
     const mounted = useIsMountedRef();
     const [enroll_url, setEnrollUrl] = useState("");
     const [devices, setDevices] = useState([] as SelectableDevice[]);
@@ -98,10 +101,23 @@ const PushNotificationMethod = function (props: Props) {
 
         try {
             setState(State.SignInInProgress);
-            const res = await completePushNotificationSignIn(redirectionURL, workflow, workflowID);
+            // The Duo Universal Prompt redirects back with a single use code which must only be submitted once.
+            const code = duoCode && !duoCodeUsed.current ? duoCode : undefined;
+            duoCodeUsed.current = true;
+            const res = await completePushNotificationSignIn(
+                redirectionURL,
+                workflow,
+                workflowID,
+                code ? duoState : undefined,
+                code,
+            );
             // If the request was initiated and the user changed 2FA method in the meantime,
             // the process is interrupted to avoid updating state of unmounted component.
             if (!mounted.current) return;
+            if (res && res.result === "universal_prompt") {
+                window.location.href = res.redirect;
+                return;
+            }
             if (res && res.result === "auth") {
                 let selectableDevices = [] as SelectableDevice[];
                 res.devices.forEach((d) =>
@@ -141,6 +157,8 @@ const PushNotificationMethod = function (props: Props) {
         props.authenticationLevel,
         props.duoSelfEnrollment,
         redirectionURL,
+        duoState,
+        duoCode,
         workflow,
         workflowID,
         mounted,
@@ -0,0 +1,123 @@
+import React, { useCallback, useEffect, useState } from "react";
+
+import { Button, TextField } from "@mui/material";
+import Grid from "@mui/material/Unstable_Grid2/Grid2";
+import { useTranslation } from "react-i18next";
+
+import { RedirectionURL } from "@constants/SearchParams";
+import { useQueryParam } from "@hooks/QueryParam";
+import { useWorkflow } from "@hooks/Workflow";
+import { completeRecoveryCodeSignIn } from "@services/RecoveryCodes";
+import { AuthenticationLevel } from "@services/State";
+import MethodContainer, { State as MethodContainerState } from "@views/LoginPortal/SecondFactor/MethodContainer";
+import { State } from "@views/LoginPortal/SecondFactor/OneTimePasswordMethod";
+
+export interface Props {
+    id: string;
+    authenticationLevel: AuthenticationLevel;
+    registered: boolean;
+
+    onSignInError: (err: Error) => void;
+    onSignInSuccess: (redirectURL: string | undefined) => void;
+}
+
+const RecoveryCodeMethod = function (props: Props) {
+    const [code, setCode] = useState("");
+    const [state, setState] = useState(
+        props.authenticationLevel === AuthenticationLevel.TwoFactor ? State.Success : State.Idle,
+    );
+    const redirectionURL = useQueryParam(RedirectionURL);
+    const [workflow, workflowID] = useWorkflow();
+    const { t: translate } = useTranslation();
+
+    const { onSignInSuccess, onSignInError } = props;
+
+    const handleSignIn = useCallback(async () => {
+        if (!props.registered || props.authenticationLevel === AuthenticationLevel.TwoFactor || code === "") {
+            return;
+        }
+
+        try {
+            setState(State.InProgress);
+            const res = await completeRecoveryCodeSignIn(code, redirectionURL, workflow, workflowID);
+            setState(State.Success);
+            onSignInSuccess(res ? res.redirect : undefined);
+        } catch (err) {
+            console.error(err);
+            onSignInError(new Error(translate("The Recovery Code might be wrong or has already been used")));
+            setState(State.Failure);
+        }
+
+        setCode("");
+    }, [
+        code,
+        onSignInError,
+        onSignInSuccess,
+        props.authenticationLevel,
+        props.registered,
+        redirectionURL,
+        translate,
+        workflow,
+        workflowID,
+    ]);
+
+    // Set successful state if user is already authenticated.
+    useEffect(() => {
+        if (props.authenticationLevel >= AuthenticationLevel.TwoFactor) {
+            setState(State.Success);
+        }
+    }, [props.authenticationLevel, setState]);
+
+    let methodState = MethodContainerState.METHOD;
+    if (props.authenticationLevel === AuthenticationLevel.TwoFactor) {
+        methodState = MethodContainerState.ALREADY_AUTHENTICATED;
+    } else if (!props.registered) {
+        methodState = MethodContainerState.NOT_REGISTERED;
+    }
+
+    return (
+        <MethodContainer
+            id={props.id}
+            title={translate("Recovery Code")}
+            explanation={translate("Enter one of your unused Recovery Codes")}
+            duoSelfEnrollment={false}
+            registered={props.registered}
+            state={methodState}
+        >
+            <Grid container spacing={2}>
+                <Grid xs={12}>
+                    <TextField
+                        id="recovery-code-textfield"
+                        label={translate("Recovery Code")}
+                        variant="outlined"
+                        fullWidth
+                        value={code}
+                        disabled={state === State.InProgress}
+                        error={state === State.Failure}
+                        autoComplete="one-time-code"
+                        onChange={(e) => setCode(e.target.value)}
+                        onKeyDown={(e) => {
+                            if (e.key === "Enter") {
+                                handleSignIn().catch(console.error);
+                            }
+                        }}
+                    />
+                </Grid>
+                <Grid xs={12}>
+                    <Button
+                        id="recovery-code-button"
+                        variant="contained"
+                        color="primary"
+                        fullWidth
+                        disabled={state === State.InProgress || code === ""}
+                        onClick={() => handleSignIn().catch(console.error)}
+                    >
+                        {translate("Sign in")}
+                    </Button>
+                </Grid>
+            </Grid>
+        </MethodContainer>
+    );
+};
+
+export default RecoveryCodeMethod;
@@ -1,13 +1,16 @@
-import React, { lazy, useEffect, useState } from "react";
+import React, { lazy, useCallback, useEffect, useState } from "react";
 
-import { Button, Grid, Theme } from "@mui/material";
+import { Button, Checkbox, FormControlLabel, Grid, Theme } from "@mui/material";
 import makeStyles from "@mui/styles/makeStyles";
 import { browserSupportsWebAuthn } from "@simplewebauthn/browser";
 import { useTranslation } from "react-i18next";
 import { Route, Routes, useNavigate } from "react-router-dom";
 
 import {
+    SecondFactorEmailSubRoute,
     SecondFactorPushSubRoute,
+    SecondFactorRecoveryCodeSubRoute,
+    SecondFactorRoute,
     SecondFactorTOTPSubRoute,
     SecondFactorWebAuthnSubRoute,
     SettingsRoute,
@@ -21,11 +24,15 @@ import { Configuration } from "@models/Configuration";
 import { SecondFactorMethod } from "@models/Methods";
 import { UserInfo } from "@models/UserInfo";
 import { AuthenticationLevel } from "@services/State";
+import { trustDevice } from "@services/TrustedDevices";
 import { setPreferred2FAMethod } from "@services/UserInfo";
+import { getRecoveryCodes, getTrustedDeviceDays } from "@utils/Configuration";
 import MethodSelectionDialog from "@views/LoginPortal/SecondFactor/MethodSelectionDialog";
 
+const EmailMethod = lazy(() => import("@views/LoginPortal/SecondFactor/EmailMethod"));
 const OneTimePasswordMethod = lazy(() => import("@views/LoginPortal/SecondFactor/OneTimePasswordMethod"));
 const PushNotificationMethod = lazy(() => import("@views/LoginPortal/SecondFactor/PushNotificationMethod"));
+const RecoveryCodeMethod = lazy(() => import("@views/LoginPortal/SecondFactor/RecoveryCodeMethod"));
 const WebAuthnMethod = lazy(() => import("@views/LoginPortal/SecondFactor/WebAuthnMethod"));
 
 export interface Props {
@@ -43,6 +50,7 @@ const SecondFactorForm = function (props: Props) {
     const navigate = useNavigate();
     const [methodSelectionOpen, setMethodSelectionOpen] = useState(false);
     const [stateWebAuthnSupported, setStateWebAuthnSupported] = useState(false);
+    const [trustThisDevice, setTrustThisDevice] = useState(false);
     const { createErrorNotification } = useNotifications();
     const { setLocalStorageMethod, localStorageMethodAvailable } = useLocalStorageMethodContext();
     const { t: translate } = useTranslation();
@@ -79,6 +87,35 @@ const SecondFactorForm = function (props: Props) {
         navigate(SignOutRoute);
     };
 
+    const handleRecoveryCodeClick = () => {
+        navigate(`${SecondFactorRoute}${SecondFactorRecoveryCodeSubRoute}`);
+    };
+
+    const { onAuthenticationSuccess } = props;
+
+    const handleAuthenticationSuccess = useCallback(
+        (redirectURL: string | undefined) => {
+            if (!trustThisDevice) {
+                onAuthenticationSuccess(redirectURL);
+
+                return;
+            }
+
+            trustDevice()
+                .catch((err) => {
+                    console.error(err);
+                    createErrorNotification(translate("There was an issue trusting this device"));
+                })
+                .finally(() => {
+                    onAuthenticationSuccess(redirectURL);
+                });
+        },
+        [createErrorNotification, onAuthenticationSuccess, translate, trustThisDevice],
+    );
+
+    const recoveryCodes = getRecoveryCodes() && props.userInfo.recovery_codes > 0;
+    const trustedDeviceDays = getTrustedDeviceDays();
+
     return (
         <LoginLayout
             id="second-factor-stage"
@@ -105,6 +142,12 @@ const SecondFactorForm = function (props: Props) {
                             {translate("Methods")}
                         </Button>
                     ) : null}
+                    {recoveryCodes ? " | " : null}
+                    {recoveryCodes ? (
+                        <Button color="secondary" onClick={handleRecoveryCodeClick} id="recovery-code-button">
+                            {translate("Recovery Code")}
+                        </Button>
+                    ) : null}
                 </Grid>
                 <Grid item xs={12} className={styles.methodContainer}>
                     <Routes>
@@ -120,7 +163,7 @@ const SecondFactorForm = function (props: Props) {
                                         navigate(`${SettingsRoute}${SettingsTwoFactorAuthenticationSubRoute}`);
                                     }}
                                     onSignInError={(err) => createErrorNotification(err.message)}
-                                    onSignInSuccess={props.onAuthenticationSuccess}
+                                    onSignInSuccess={handleAuthenticationSuccess}
                                 />
                             }
                         />
@@ -136,7 +179,7 @@ const SecondFactorForm = function (props: Props) {
                                         navigate(`${SettingsRoute}${SettingsTwoFactorAuthenticationSubRoute}`);
                                     }}
                                     onSignInError={(err) => createErrorNotification(err.message)}
-                                    onSignInSuccess={props.onAuthenticationSuccess}
+                                    onSignInSuccess={handleAuthenticationSuccess}
                                 />
                             }
                         />
@@ -150,12 +193,51 @@ const SecondFactorForm = function (props: Props) {
                                     registered={props.userInfo.has_duo}
                                     onSelectionClick={props.onMethodChanged}
                                     onSignInError={(err) => createErrorNotification(err.message)}
-                                    onSignInSuccess={props.onAuthenticationSuccess}
+                                    onSignInSuccess={handleAuthenticationSuccess}
+                                />
+                            }
+                        />
+                        <Route
+                            path={SecondFactorEmailSubRoute}
+                            element={
+                                <EmailMethod
+                                    id="email-method"
+                                    authenticationLevel={props.authenticationLevel}
+                                    onSignInError={(err) => createErrorNotification(err.message)}
+                                    onSignInSuccess={handleAuthenticationSuccess}
+                                />
+                            }
+                        />
+                        <Route
+                            path={SecondFactorRecoveryCodeSubRoute}
+                            element={
+                                <RecoveryCodeMethod
+                                    id="recovery-code-method"
+                                    authenticationLevel={props.authenticationLevel}
+                                    registered={props.userInfo.recovery_codes > 0}
+                                    onSignInError={(err) => createErrorNotification(err.message)}
+                                    onSignInSuccess={handleAuthenticationSuccess}
                                 />
                             }
                         />
                     </Routes>
                 </Grid>
+                {trustedDeviceDays > 0 ? (
+                    <Grid item xs={12}>
+                        <FormControlLabel
+                            control={
+                                <Checkbox
+                                    id="trust-device-checkbox"
+                                    checked={trustThisDevice}
+                                    onChange={() => setTrustThisDevice(!trustThisDevice)}
+                                    value="trustThisDevice"
+                                    color="primary"
+                                />
+                            }
+                            label={translate("Trust this device for {{count}} days", { count: trustedDeviceDays })}
+                        />
+                    </Grid>
+                ) : null}
             </Grid>
         </LoginLayout>
     );
@@ -0,0 +1,55 @@
+import React, { useCallback, useEffect } from "react";
+
+import { useTranslation } from "react-i18next";
+
+import { IndexRoute } from "@constants/Routes";
+import { IdentityToken } from "@constants/SearchParams";
+import { useNotifications } from "@hooks/NotificationsContext";
+import { useQueryParam } from "@hooks/QueryParam";
+import { useRouterNavigate } from "@hooks/RouterNavigate";
+import { completeRegulationUnlockProcess } from "@services/RegulationUnlock";
+import LoadingPage from "@views/LoadingPage/LoadingPage";
+
+const RegulationUnlockView = function () {
+    const { t: translate } = useTranslation();
+    const { createSuccessNotification, createErrorNotification } = useNotifications();
+
+    const processToken = useQueryParam(IdentityToken);
+    const navigate = useRouterNavigate();
+
+    const handleRedirect = useCallback(() => {
+        setTimeout(() => {
+            navigate(IndexRoute, false);
+        }, 1500);
+    }, [navigate]);
+
+    const completeProcess = useCallback(async () => {
+        if (!processToken) {
+            createErrorNotification(translate("No verification token provided"));
+
+            handleRedirect();
+
+            return;
+        }
+
+        try {
+            await completeRegulationUnlockProcess(processToken);
+            createSuccessNotification(translate("Your account has been unlocked"));
+        } catch (err) {
+            console.error(err);
+            createErrorNotification(
+                translate("There was an issue completing the process the verification token might have expired"),
+            );
+        }
+
+        handleRedirect();
+    }, [createErrorNotification, createSuccessNotification, handleRedirect, processToken, translate]);
+
+    useEffect(() => {
+        completeProcess().catch(console.error);
+    }, [completeProcess]);
+
+    return <LoadingPage />;
+};
+
+export default RegulationUnlockView;
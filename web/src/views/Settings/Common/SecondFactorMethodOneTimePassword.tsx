@@ -73,6 +73,8 @@ const SecondFactorMethodOneTimePassword = function (props: Props) {
                     passcode={passcode}
                     period={config.period}
                     digits={config.digits}
+                    counter={config.type === "hotp"}
+                    alphanumeric={config.type === "steam"}
                     onChange={setPasscode}
                     state={state}
                 />
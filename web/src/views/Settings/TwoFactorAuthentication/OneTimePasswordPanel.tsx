@@ -17,6 +17,7 @@ interface Props {
     info?: UserInfo;
     config: UserInfoTOTPConfiguration | undefined | null;
     handleRefreshState: () => void;
+    handleRecoveryCodes?: (codes: string[]) => void;
 }
 
 const OneTimePasswordPanel = function (props: Props) {
@@ -165,6 +166,7 @@ const OneTimePasswordPanel = function (props: Props) {
                     handleResetState();
                     props.handleRefreshState();
                 }}
+                handleRecoveryCodes={props.handleRecoveryCodes}
             />
             <OneTimePasswordDeleteDialog
                 open={dialogDeleteOpen}
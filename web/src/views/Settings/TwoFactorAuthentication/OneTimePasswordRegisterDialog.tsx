@@ -49,6 +49,7 @@ const steps = ["Start", "Register", "Confirm"];
 interface Props {
     open: boolean;
     setClosed: () => void;
+    handleRecoveryCodes?: (codes: string[]) => void;
 }
 
 interface Options {
@@ -122,21 +123,28 @@ const OneTimePasswordRegisterDialog = function (props: Props) {
         })();
     }, [props, secretURL, resetStates]);
 
-    const handleFinished = useCallback(() => {
-        setSuccess(true);
+    const handleFinished = useCallback(
+        (recoveryCodes?: string[]) => {
+            setSuccess(true);
 
-        setTimeout(() => {
-            createSuccessNotification(
-                translate("Successfully {{action}} the {{item}}", {
-                    action: translate("added"),
-                    item: translate("One-Time Password"),
-                }),
-            );
+            setTimeout(() => {
+                createSuccessNotification(
+                    translate("Successfully {{action}} the {{item}}", {
+                        action: translate("added"),
+                        item: translate("One-Time Password"),
+                    }),
+                );
 
-            props.setClosed();
-            resetStates();
-        }, 750);
-    }, [createSuccessNotification, props, resetStates, translate]);
+                props.setClosed();
+                resetStates();
+
+                if (recoveryCodes && props.handleRecoveryCodes) {
+                    props.handleRecoveryCodes(recoveryCodes);
+                }
+            }, 750);
+        },
+        [createSuccessNotification, props, resetStates, translate],
+    );
 
     const handleOnClose = () => {
         if (!props.open) {
@@ -235,9 +243,9 @@ const OneTimePasswordRegisterDialog = function (props: Props) {
                 const registerValue = dialValue;
                 setDialValue("");
 
-                await completeTOTPRegister(registerValue);
+                const response = await completeTOTPRegister(registerValue);
 
-                handleFinished();
+                handleFinished(response?.recovery_codes);
             } catch (err) {
                 console.error(err);
                 setDialState(State.Failure);
@@ -0,0 +1,57 @@
+import React from "react";
+
+import { Alert, Button, Dialog, DialogActions, DialogContent, DialogContentText, DialogTitle } from "@mui/material";
+import Grid from "@mui/material/Unstable_Grid2/Grid2";
+import { useTranslation } from "react-i18next";
+
+import CopyButton from "@components/CopyButton";
+
+interface Props {
+    codes?: string[];
+    handleClose: () => void;
+}
+
+const RecoveryCodesDialog = function (props: Props) {
+    const { t: translate } = useTranslation("settings");
+
+    const open = props.codes !== undefined && props.codes.length !== 0;
+
+    return (
+        <Dialog open={open} onClose={props.handleClose} aria-labelledby="recovery-codes-dialog-title">
+            <DialogTitle id="recovery-codes-dialog-title">{translate("Recovery Codes")}</DialogTitle>
+            <DialogContent>
+                <DialogContentText sx={{ mb: 3 }}>
+                    {translate(
+                        "Each recovery code can be used once to sign in if you lose access to your other second factor methods",
+                    )}
+                </DialogContentText>
+                <Alert severity={"warning"} sx={{ mb: 3 }}>
+                    {translate("Store these recovery codes somewhere safe as they will not be shown again")}
+                </Alert>
+                <Grid container spacing={1} id={"recovery-codes-list"}>
+                    {props.codes?.map((code) => (
+                        <Grid xs={6} key={code}>
+                            <code>{code}</code>
+                        </Grid>
+                    ))}
+                </Grid>
+            </DialogContent>
+            <DialogActions>
+                <CopyButton
+                    variant={"contained"}
+                    tooltip={translate("Click to copy the {{value}}", { value: translate("Recovery Codes") })}
+                    value={props.codes ? props.codes.join("\n") : null}
+                    fullWidth={false}
+                    childrenCopied={translate("Copied")}
+                >
+                    {translate("Recovery Codes")}
+                </CopyButton>
+                <Button id={"dialog-close"} onClick={props.handleClose}>
+                    {translate("Close")}
+                </Button>
+            </DialogActions>
+        </Dialog>
+    );
+};
+
+export default RecoveryCodesDialog;
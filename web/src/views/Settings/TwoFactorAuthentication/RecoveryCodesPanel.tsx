@@ -0,0 +1,168 @@
+import React, { Fragment, useCallback, useState } from "react";
+
+import { Button, Paper, Tooltip, Typography } from "@mui/material";
+import Grid from "@mui/material/Unstable_Grid2/Grid2";
+import { useTranslation } from "react-i18next";
+
+import { useNotifications } from "@hooks/NotificationsContext";
+import { UserInfo } from "@models/UserInfo";
+import { generateRecoveryCodes } from "@services/RecoveryCodes";
+import { UserSessionElevation, getUserSessionElevation } from "@services/UserSessionElevation";
+import IdentityVerificationDialog from "@views/Settings/Common/IdentityVerificationDialog";
+import SecondFactorDialog from "@views/Settings/Common/SecondFactorDialog";
+
+interface Props {
+    info?: UserInfo;
+    handleRecoveryCodes: (codes: string[]) => void;
+    handleRefreshState: () => void;
+}
+
+const RecoveryCodesPanel = function (props: Props) {
+    const { t: translate } = useTranslation("settings");
+
+    const { createErrorNotification } = useNotifications();
+
+    const [elevation, setElevation] = useState<UserSessionElevation>();
+
+    const [dialogSFOpening, setDialogSFOpening] = useState(false);
+    const [dialogIVOpening, setDialogIVOpening] = useState(false);
+
+    const handleResetState = useCallback(() => {
+        setDialogSFOpening(false);
+        setDialogIVOpening(false);
+
+        setElevation(undefined);
+    }, []);
+
+    const handleGenerate = useCallback(() => {
+        (async () => {
+            try {
+                const codes = await generateRecoveryCodes();
+
+                props.handleRecoveryCodes(codes);
+            } catch (err) {
+                console.error(err);
+
+                createErrorNotification(
+                    translate("There was an issue generating the {{item}}", { item: translate("Recovery Codes") }),
+                );
+            } finally {
+                props.handleRefreshState();
+            }
+        })();
+    }, [createErrorNotification, props, translate]);
+
+    const handleSFDialogClosed = (ok: boolean, changed: boolean) => {
+        if (!ok) {
+            console.warn("Second Factor dialog close callback failed, it was likely cancelled by the user.");
+
+            handleResetState();
+
+            return;
+        }
+
+        if (changed) {
+            handleElevationRefresh()
+                .catch(console.error)
+                .then(() => {
+                    setDialogIVOpening(true);
+                });
+        } else {
+            setDialogIVOpening(true);
+        }
+    };
+
+    const handleSFDialogOpened = () => {
+        setDialogSFOpening(false);
+    };
+
+    const handleIVDialogClosed = useCallback(
+        (ok: boolean) => {
+            if (!ok) {
+                console.warn(
+                    "Identity Verification dialog close callback failed, it was likely cancelled by the user.",
+                );
+
+                handleResetState();
+
+                return;
+            }
+
+            handleResetState();
+            handleGenerate();
+        },
+        [handleGenerate, handleResetState],
+    );
+
+    const handleIVDialogOpened = () => {
+        setDialogIVOpening(false);
+    };
+
+    const handleElevationRefresh = async () => {
+        const result = await getUserSessionElevation();
+
+        setElevation(result);
+    };
+
+    const handleRegenerate = () => {
+        handleElevationRefresh().catch(console.error);
+
+        setDialogSFOpening(true);
+    };
+
+    const remaining = props.info ? props.info.recovery_codes : 0;
+
+    return (
+        <Fragment>
+            <SecondFactorDialog
+                info={props.info}
+                elevation={elevation}
+                opening={dialogSFOpening}
+                handleClosed={handleSFDialogClosed}
+                handleOpened={handleSFDialogOpened}
+            />
+            <IdentityVerificationDialog
+                opening={dialogIVOpening}
+                elevation={elevation}
+                handleClosed={handleIVDialogClosed}
+                handleOpened={handleIVDialogOpened}
+            />
+            <Paper variant={"outlined"}>
+                <Grid container spacing={2} padding={2}>
+                    <Grid xs={12}>
+                        <Typography variant={"h5"}>{translate("Recovery Codes")}</Typography>
+                    </Grid>
+                    <Grid xs={12}>
+                        <Tooltip
+                            title={translate(
+                                "Click to generate a new set of recovery codes which replaces any existing recovery codes",
+                            )}
+                        >
+                            <span>
+                                <Button
+                                    variant="outlined"
+                                    color="primary"
+                                    onClick={handleRegenerate}
+                                    id={"recovery-codes-generate"}
+                                >
+                                    {remaining === 0 ? translate("Generate") : translate("Regenerate")}
+                                </Button>
+                            </span>
+                        </Tooltip>
+                    </Grid>
+                    <Grid xs={12}>
+                        <Typography variant={"subtitle2"} id={"recovery-codes-remaining"}>
+                            {remaining === 0
+                                ? translate("You don't have any unused recovery codes")
+                                : translate("You have {{count}} unused recovery codes remaining", {
+                                      count: remaining,
+                                  })}
+                        </Typography>
+                    </Grid>
+                </Grid>
+            </Paper>
+        </Fragment>
+    );
+};
+
+export default RecoveryCodesPanel;
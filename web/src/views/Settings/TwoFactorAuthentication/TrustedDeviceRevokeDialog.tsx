@@ -0,0 +1,88 @@
+import React from "react";
+
+import { useTranslation } from "react-i18next";
+
+import { useNotifications } from "@hooks/NotificationsContext";
+import { TrustedDevice } from "@models/TrustedDevice";
+import { revokeTrustedDevice } from "@services/TrustedDevices";
+import DeleteDialog from "@views/Settings/TwoFactorAuthentication/DeleteDialog";
+
+interface Props {
+    open: boolean;
+    device?: TrustedDevice;
+    handleClose: () => void;
+}
+
+const TrustedDeviceRevokeDialog = function (props: Props) {
+    const { t: translate } = useTranslation("settings");
+    const { createSuccessNotification, createErrorNotification } = useNotifications();
+
+    const handleCancel = () => {
+        props.handleClose();
+    };
+
+    const handleRevoke = async () => {
+        if (!props.device) {
+            return;
+        }
+
+        const response = await revokeTrustedDevice(props.device.id);
+
+        if (response.data.status === "KO") {
+            if (response.data.elevation) {
+                createErrorNotification(
+                    translate("You must be elevated to {{action}} a {{item}}", {
+                        action: translate("revoke"),
+                        item: translate("Trusted Device"),
+                    }),
+                );
+            } else if (response.data.authentication) {
+                createErrorNotification(
+                    translate("You must have a higher authentication level to {{action}} a {{item}}", {
+                        action: translate("revoke"),
+                        item: translate("Trusted Device"),
+                    }),
+                );
+            } else {
+                createErrorNotification(
+                    translate("There was a problem {{action}} the {{item}}", {
+                        action: translate("revoking"),
+                        item: translate("Trusted Device"),
+                    }),
+                );
+            }
+
+            return;
+        }
+
+        createSuccessNotification(
+            translate("Successfully {{action}} the {{item}}", {
+                action: translate("revoked"),
+                item: translate("Trusted Device"),
+            }),
+        );
+
+        props.handleClose();
+    };
+
+    const handleClose = (ok: boolean) => {
+        if (ok) {
+            handleRevoke().catch(console.error);
+        } else {
+            handleCancel();
+        }
+    };
+
+    return (
+        <DeleteDialog
+            open={props.open}
+            handleClose={handleClose}
+            title={translate("Remove {{item}}", { item: translate("Trusted Device") })}
+            text={translate(
+                "Are you sure you want to revoke the Trusted Device it will be required to complete the second factor again",
+            )}
+        />
+    );
+};
+
+export default TrustedDeviceRevokeDialog;
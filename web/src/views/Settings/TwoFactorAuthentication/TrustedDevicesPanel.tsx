@@ -0,0 +1,180 @@
+import React, { Fragment, useCallback, useState } from "react";
+
+import { Devices } from "@mui/icons-material";
+import { Paper, Typography } from "@mui/material";
+import Grid from "@mui/material/Unstable_Grid2/Grid2";
+import { useTranslation } from "react-i18next";
+
+import { TrustedDevice } from "@models/TrustedDevice";
+import { UserInfo } from "@models/UserInfo";
+import { UserSessionElevation, getUserSessionElevation } from "@services/UserSessionElevation";
+import IdentityVerificationDialog from "@views/Settings/Common/IdentityVerificationDialog";
+import SecondFactorDialog from "@views/Settings/Common/SecondFactorDialog";
+import CredentialItem from "@views/Settings/TwoFactorAuthentication/CredentialItem";
+import TrustedDeviceRevokeDialog from "@views/Settings/TwoFactorAuthentication/TrustedDeviceRevokeDialog";
+
+interface Props {
+    info?: UserInfo;
+    devices: TrustedDevice[] | undefined;
+    handleRefreshState: () => void;
+}
+
+const TrustedDevicesPanel = function (props: Props) {
+    const { t: translate } = useTranslation("settings");
+
+    const [elevation, setElevation] = useState<UserSessionElevation>();
+
+    const [dialogSFOpening, setDialogSFOpening] = useState(false);
+    const [dialogIVOpening, setDialogIVOpening] = useState(false);
+
+    const [dialogRevokeOpen, setDialogRevokeOpen] = useState(false);
+    const [indexRevoke, setIndexRevoke] = useState(-1);
+
+    const handleResetState = useCallback(() => {
+        setDialogSFOpening(false);
+        setDialogIVOpening(false);
+
+        setElevation(undefined);
+
+        setDialogRevokeOpen(false);
+        setIndexRevoke(-1);
+    }, []);
+
+    const handleSFDialogClosed = (ok: boolean, changed: boolean) => {
+        if (!ok) {
+            console.warn("Second Factor dialog close callback failed, it was likely cancelled by the user.");
+
+            handleResetState();
+
+            return;
+        }
+
+        if (changed) {
+            handleElevationRefresh()
+                .catch(console.error)
+                .then(() => {
+                    setDialogIVOpening(true);
+                });
+        } else {
+            setDialogIVOpening(true);
+        }
+    };
+
+    const handleSFDialogOpened = () => {
+        setDialogSFOpening(false);
+    };
+
+    const handleIVDialogClosed = useCallback(
+        (ok: boolean) => {
+            if (!ok) {
+                console.warn(
+                    "Identity Verification dialog close callback failed, it was likely cancelled by the user.",
+                );
+
+                handleResetState();
+
+                return;
+            }
+
+            setElevation(undefined);
+            setDialogRevokeOpen(true);
+        },
+        [handleResetState],
+    );
+
+    const handleIVDialogOpened = () => {
+        setDialogIVOpening(false);
+    };
+
+    const handleElevationRefresh = async () => {
+        const result = await getUserSessionElevation();
+
+        setElevation(result);
+    };
+
+    const handleRevoke = (index: number) => {
+        if (!props.devices) return;
+
+        if (props.devices.length + 1 < index) return;
+
+        setIndexRevoke(index);
+
+        handleElevationRefresh().catch(console.error);
+
+        setDialogSFOpening(true);
+    };
+
+    return (
+        <Fragment>
+            <SecondFactorDialog
+                info={props.info}
+                elevation={elevation}
+                opening={dialogSFOpening}
+                handleClosed={handleSFDialogClosed}
+                handleOpened={handleSFDialogOpened}
+            />
+            <IdentityVerificationDialog
+                elevation={elevation}
+                opening={dialogIVOpening}
+                handleClosed={handleIVDialogClosed}
+                handleOpened={handleIVDialogOpened}
+            />
+            <TrustedDeviceRevokeDialog
+                open={dialogRevokeOpen}
+                device={indexRevoke === -1 || !props.devices ? undefined : props.devices[indexRevoke]}
+                handleClose={() => {
+                    handleResetState();
+                    props.handleRefreshState();
+                }}
+            />
+            <Paper variant={"outlined"}>
+                <Grid container spacing={2} padding={2}>
+                    <Grid xs={12}>
+                        <Typography variant={"h5"}>{translate("Trusted Devices")}</Typography>
+                    </Grid>
+                    <Grid xs={12}>
+                        {props.devices === undefined || props.devices.length === 0 ? (
+                            <Typography variant={"subtitle2"}>
+                                {translate(
+                                    "No Trusted Devices have been added you can trust a device after completing the second factor",
+                                )}
+                            </Typography>
+                        ) : (
+                            <Grid container spacing={3}>
+                                {props.devices.map((device, index) => (
+                                    <Grid xs={12} md={6} xl={3} key={index}>
+                                        <CredentialItem
+                                            id={`trusted-device-${index}`}
+                                            icon={
+                                                <Devices
+                                                    fontSize="large"
+                                                    color={device.current ? "success" : "action"}
+                                                />
+                                            }
+                                            description={device.description}
+                                            qualifier={
+                                                device.current
+                                                    ? ` (${translate("This Device")})`
+                                                    : ` (${device.last_used_ip ?? device.created_ip})`
+                                            }
+                                            created_at={new Date(device.created_at)}
+                                            last_used_at={
+                                                device.last_used_at ? new Date(device.last_used_at) : undefined
+                                            }
+                                            tooltipDelete={translate("Revoke this {{item}}", {
+                                                item: translate("Trusted Device"),
+                                            })}
+                                            handleDelete={() => handleRevoke(index)}
+                                        />
+                                    </Grid>
+                                ))}
+                            </Grid>
+                        )}
+                    </Grid>
+                </Grid>
+            </Paper>
+        </Fragment>
+    );
+};
+
+export default TrustedDevicesPanel;
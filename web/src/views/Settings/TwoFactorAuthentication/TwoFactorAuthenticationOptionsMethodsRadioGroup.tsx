@@ -55,6 +55,16 @@ const TwoFactorAuthenticationOptionsMethodsRadioGroup = function (props: Props)
                                     value={v}
                                 />
                             );
+                        case SecondFactorMethod.Email:
+                            return (
+                                <FormControlLabel
+                                    id={`method-${props.id}-default-email`}
+                                    control={<Radio />}
+                                    label={translate("Email")}
+                                    key={index}
+                                    value={v}
+                                />
+                            );
                         default:
                             return <Fragment />;
                     }
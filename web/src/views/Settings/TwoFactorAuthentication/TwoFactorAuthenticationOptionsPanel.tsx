@@ -58,6 +58,9 @@ const TwoFactorAuthenticationOptionsPanel = function (props: Props) {
                             valuesFinal.push(value);
                         }
                         break;
+                    case SecondFactorMethod.Email:
+                        valuesFinal.push(value);
+                        break;
                 }
             }
         });
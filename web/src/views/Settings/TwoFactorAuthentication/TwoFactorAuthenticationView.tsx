@@ -5,11 +5,16 @@ import { useTranslation } from "react-i18next";
 
 import { useConfiguration } from "@hooks/Configuration";
 import { useNotifications } from "@hooks/NotificationsContext";
+import { useTrustedDevices } from "@hooks/TrustedDevices";
 import { useUserInfoPOST } from "@hooks/UserInfo";
 import { useUserInfoTOTPConfigurationOptional } from "@hooks/UserInfoTOTPConfiguration";
 import { useUserWebAuthnCredentials } from "@hooks/WebAuthnCredentials";
 import { SecondFactorMethod } from "@models/Methods";
+import { getRecoveryCodes, getTrustedDeviceDays } from "@utils/Configuration";
 import OneTimePasswordPanel from "@views/Settings/TwoFactorAuthentication/OneTimePasswordPanel";
+import RecoveryCodesDialog from "@views/Settings/TwoFactorAuthentication/RecoveryCodesDialog";
+import RecoveryCodesPanel from "@views/Settings/TwoFactorAuthentication/RecoveryCodesPanel";
+import TrustedDevicesPanel from "@views/Settings/TwoFactorAuthentication/TrustedDevicesPanel";
 import TwoFactorAuthenticationOptionsPanel from "@views/Settings/TwoFactorAuthentication/TwoFactorAuthenticationOptionsPanel";
 import WebAuthnCredentialsPanel from "@views/Settings/TwoFactorAuthentication/WebAuthnCredentialsPanel";
 
@@ -21,6 +26,7 @@ const TwoFactorAuthenticationView = function (props: Props) {
     const [refreshState, setRefreshState] = useState(0);
     const [refreshWebAuthnState, setRefreshWebAuthnState] = useState(0);
     const [refreshTOTPState, setRefreshTOTPState] = useState(0);
+    const [refreshTrustedDevicesState, setRefreshTrustedDevicesState] = useState(0);
     const { createErrorNotification } = useNotifications();
 
     const [configuration, fetchConfiguration, , fetchConfigurationError] = useConfiguration();
@@ -28,14 +34,20 @@ const TwoFactorAuthenticationView = function (props: Props) {
     const [userTOTPConfig, fetchUserTOTPConfig, , fetchUserTOTPConfigError] = useUserInfoTOTPConfigurationOptional();
     const [userWebAuthnCredentials, fetchUserWebAuthnCredentials, , fetchUserWebAuthnCredentialsError] =
         useUserWebAuthnCredentials();
+    const [trustedDevices, fetchTrustedDevices, , fetchTrustedDevicesError] = useTrustedDevices();
     const [hasTOTP, setHasTOTP] = useState(false);
     const [hasWebAuthn, setHasWebAuthn] = useState(false);
+    const [recoveryCodes, setRecoveryCodes] = useState<string[]>();
 
     const handleRefreshWebAuthnState = () => {
         setRefreshState((refreshState) => refreshState + 1);
         setRefreshWebAuthnState((refreshWebAuthnState) => refreshWebAuthnState + 1);
     };
 
+    const handleRefreshTrustedDevicesState = () => {
+        setRefreshTrustedDevicesState((refreshTrustedDevicesState) => refreshTrustedDevicesState + 1);
+    };
+
     const handleRefreshTOTPState = () => {
         setRefreshState((refreshState) => refreshState + 1);
         setRefreshTOTPState((refreshTOTPState) => refreshTOTPState + 1);
@@ -68,6 +80,14 @@ const TwoFactorAuthenticationView = function (props: Props) {
         fetchUserWebAuthnCredentials();
     }, [fetchUserWebAuthnCredentials, hasWebAuthn, refreshWebAuthnState]);
 
+    useEffect(() => {
+        if (getTrustedDeviceDays() === 0) {
+            return;
+        }
+
+        fetchTrustedDevices();
+    }, [fetchTrustedDevices, refreshTrustedDevicesState]);
+
     useEffect(() => {
         if (fetchConfigurationError) {
             createErrorNotification(
@@ -106,12 +126,28 @@ const TwoFactorAuthenticationView = function (props: Props) {
         }
     }, [fetchUserWebAuthnCredentialsError, createErrorNotification, translate]);
 
+    useEffect(() => {
+        if (fetchTrustedDevicesError) {
+            createErrorNotification(
+                translate("There was an issue retrieving the {{item}}", {
+                    item: translate("Trusted Devices"),
+                }),
+            );
+        }
+    }, [fetchTrustedDevicesError, createErrorNotification, translate]);
+
     const handleRefreshUserInfo = () => {
         fetchUserInfo();
     };
 
+    const handleRecoveryCodes = (codes: string[]) => {
+        setRecoveryCodes(codes);
+        fetchUserInfo();
+    };
+
     return (
         <Fragment>
+            <RecoveryCodesDialog codes={recoveryCodes} handleClose={() => setRecoveryCodes(undefined)} />
             <Grid container spacing={2}>
                 {configuration?.available_methods.has(SecondFactorMethod.TOTP) ? (
                     <Grid xs={12}>
@@ -119,6 +155,7 @@ const TwoFactorAuthenticationView = function (props: Props) {
                             info={userInfo}
                             config={userTOTPConfig}
                             handleRefreshState={handleRefreshTOTPState}
+                            handleRecoveryCodes={handleRecoveryCodes}
                         />
                     </Grid>
                 ) : null}
@@ -128,6 +165,25 @@ const TwoFactorAuthenticationView = function (props: Props) {
                             info={userInfo}
                             credentials={userWebAuthnCredentials}
                             handleRefreshState={handleRefreshWebAuthnState}
+                            handleRecoveryCodes={handleRecoveryCodes}
+                        />
+                    </Grid>
+                ) : null}
+                {getRecoveryCodes() && userInfo && (userInfo.has_totp || userInfo.has_webauthn) ? (
+                    <Grid xs={12}>
+                        <RecoveryCodesPanel
+                            info={userInfo}
+                            handleRecoveryCodes={handleRecoveryCodes}
+                            handleRefreshState={handleRefreshUserInfo}
+                        />
+                    </Grid>
+                ) : null}
+                {getTrustedDeviceDays() > 0 ? (
+                    <Grid xs={12}>
+                        <TrustedDevicesPanel
+                            info={userInfo}
+                            devices={trustedDevices}
+                            handleRefreshState={handleRefreshTrustedDevicesState}
                         />
                     </Grid>
                 ) : null}
@@ -70,6 +70,14 @@ const WebAuthnCredentialInformationDialog = function (props: Props) {
                                         : props.credential.aaguid
                                 }
                             />
+                            <PropertyText
+                                name={translate("Authenticator Model")}
+                                value={
+                                    props.credential.model === undefined
+                                        ? translate("Unknown")
+                                        : props.credential.model
+                                }
+                            />
                             <PropertyText
                                 name={translate("Attestation Type")}
                                 value={props.credential.attestation_type}
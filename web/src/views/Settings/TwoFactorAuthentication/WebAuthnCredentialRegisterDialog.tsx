@@ -3,11 +3,13 @@ import React, { Fragment, MutableRefObject, useCallback, useEffect, useRef, useS
 import {
     Box,
     Button,
+    Checkbox,
     Dialog,
     DialogActions,
     DialogContent,
     DialogContentText,
     DialogTitle,
+    FormControlLabel,
     Step,
     StepLabel,
     Stepper,
@@ -25,12 +27,14 @@ import WebAuthnRegisterIcon from "@components/WebAuthnRegisterIcon";
 import { useNotifications } from "@hooks/NotificationsContext";
 import { AttestationResult, AttestationResultFailureString, WebAuthnTouchState } from "@models/WebAuthn";
 import { finishRegistration, getAttestationCreationOptions, startWebAuthnRegistration } from "@services/WebAuthn";
+import { getPasskeyLogin } from "@utils/Configuration";
 
 const steps = ["Description", "Verification"];
 
 interface Props {
     open: boolean;
     setClosed: () => void;
+    handleRecoveryCodes?: (codes: string[]) => void;
 }
 
 const WebAuthnCredentialRegisterDialog = function (props: Props) {
@@ -45,6 +49,7 @@ const WebAuthnCredentialRegisterDialog = function (props: Props) {
     const [timeout, setTimeout] = useState<number | null>(null);
     const [description, setDescription] = useState("");
     const [errorDescription, setErrorDescription] = useState(false);
+    const [passkey, setPasskey] = useState(false);
 
     const nameRef = useRef() as MutableRefObject<HTMLInputElement>;
 
@@ -55,6 +60,7 @@ const WebAuthnCredentialRegisterDialog = function (props: Props) {
         setTimeout(null);
         setDescription("");
         setErrorDescription(false);
+        setPasskey(false);
     };
 
     const handleClose = useCallback(() => {
@@ -93,6 +99,10 @@ const WebAuthnCredentialRegisterDialog = function (props: Props) {
                                 item: translate("WebAuthn Credential"),
                             }),
                         );
+
+                        if (response.recovery_codes && props.handleRecoveryCodes) {
+                            props.handleRecoveryCodes(response.recovery_codes);
+                        }
                         break;
                     case AttestationResult.Failure:
                         createErrorNotification(response.message);
@@ -112,7 +122,7 @@ const WebAuthnCredentialRegisterDialog = function (props: Props) {
         } finally {
             handleClose();
         }
-    }, [props.open, options, createSuccessNotification, translate, createErrorNotification, handleClose]);
+    }, [props, options, createSuccessNotification, translate, createErrorNotification, handleClose]);
 
     useEffect(() => {
         if (!props.open || state !== WebAuthnTouchState.Failure || activeStep !== 0) {
@@ -147,7 +157,7 @@ const WebAuthnCredentialRegisterDialog = function (props: Props) {
                 return;
             }
 
-            const res = await getAttestationCreationOptions(description);
+            const res = await getAttestationCreationOptions(description, passkey);
 
             switch (res.status) {
                 case 200:
@@ -175,7 +185,7 @@ const WebAuthnCredentialRegisterDialog = function (props: Props) {
 
             await performCredentialCreation();
         })();
-    }, [createErrorNotification, description, performCredentialCreation, props.open, translate]);
+    }, [createErrorNotification, description, passkey, performCredentialCreation, props.open, translate]);
 
     const handleCredentialDescription = useCallback(
         (description: string) => {
@@ -223,6 +233,20 @@ const WebAuthnCredentialRegisterDialog = function (props: Props) {
                                     }}
                                 />
                             </Grid>
+                            {getPasskeyLogin() ? (
+                                <Grid xs={12}>
+                                    <FormControlLabel
+                                        control={
+                                            <Checkbox
+                                                id="webauthn-credential-passkey"
+                                                checked={passkey}
+                                                onChange={(v) => setPasskey(v.target.checked)}
+                                            />
+                                        }
+                                        label={translate("Register as a passkey for passwordless login")}
+                                    />
+                                </Grid>
+                            ) : null}
                         </Grid>
                     </Box>
                 );
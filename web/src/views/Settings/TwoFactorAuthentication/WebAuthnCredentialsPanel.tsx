@@ -19,6 +19,7 @@ interface Props {
     info?: UserInfo;
     credentials: WebAuthnCredential[] | undefined;
     handleRefreshState: () => void;
+    handleRecoveryCodes?: (codes: string[]) => void;
 }
 
 const WebAuthnCredentialsPanel = function (props: Props) {
@@ -209,6 +210,7 @@ const WebAuthnCredentialsPanel = function (props: Props) {
                     handleResetState();
                     props.handleRefreshState();
                 }}
+                handleRecoveryCodes={props.handleRecoveryCodes}
             />
             <WebAuthnCredentialInformationDialog
                 credential={